@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"repertoire/auth/api/requests"
+	"repertoire/auth/api/server"
+	"repertoire/auth/api/validation"
+	"repertoire/auth/domain/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountHandler struct {
+	service service.AccountService
+	server.BaseHandler
+}
+
+func NewAccountHandler(
+	service service.AccountService,
+	validator *validation.Validator,
+) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+		BaseHandler: server.BaseHandler{
+			Validator: validator,
+		},
+	}
+}
+
+func (a AccountHandler) RequestPasswordReset(c *gin.Context) {
+	var request requests.RequestPasswordResetRequest
+	errCode := a.BindAndValidate(c, &request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	errCode = a.service.RequestPasswordReset(request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	a.SendMessage(c, "if the email belongs to an account, a password reset link has been sent to it")
+}
+
+func (a AccountHandler) ResetPassword(c *gin.Context) {
+	var request requests.ResetPasswordRequest
+	errCode := a.BindAndValidate(c, &request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	errCode = a.service.ResetPassword(request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	a.SendMessage(c, "your password has been reset")
+}
+
+func (a AccountHandler) RequestEmailVerification(c *gin.Context) {
+	token := a.GetTokenFromContext(c)
+
+	errCode := a.service.RequestEmailVerification(token)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	a.SendMessage(c, "a verification link has been sent to your email")
+}
+
+func (a AccountHandler) VerifyEmail(c *gin.Context) {
+	var request requests.VerifyEmailRequest
+	errCode := a.BindAndValidate(c, &request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	errCode = a.service.VerifyEmail(request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	a.SendMessage(c, "your email has been verified")
+}
@@ -27,6 +27,16 @@ func NewMainHandler(
 	}
 }
 
+func (a MainHandler) GetRevokedTokens(c *gin.Context) {
+	revokedTokens, errCode := a.service.GetRevokedTokens()
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, revokedTokens)
+}
+
 func (a MainHandler) Refresh(c *gin.Context) {
 	var request requests.RefreshRequest
 	errCode := a.BindAndValidate(c, &request)
@@ -35,13 +45,13 @@ func (a MainHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	token, errCode := a.service.Refresh(request)
+	tokens, errCode := a.service.Refresh(request)
 	if errCode != nil {
 		_ = c.AbortWithError(errCode.Code, errCode.Error)
 		return
 	}
 
-	c.JSON(http.StatusOK, token)
+	c.JSON(http.StatusOK, tokens)
 }
 
 func (a MainHandler) SignIn(c *gin.Context) {
@@ -52,11 +62,40 @@ func (a MainHandler) SignIn(c *gin.Context) {
 		return
 	}
 
-	token, errCode := a.service.SignIn(request)
+	tokens, errCode := a.service.SignIn(request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (a MainHandler) SignOut(c *gin.Context) {
+	var request requests.SignOutRequest
+	errCode := a.BindAndValidate(c, &request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	errCode = a.service.SignOut(request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	a.SendMessage(c, "you have been signed out")
+}
+
+func (a MainHandler) SignOutAll(c *gin.Context) {
+	token := a.GetTokenFromContext(c)
+
+	errCode := a.service.SignOutAll(token)
 	if errCode != nil {
 		_ = c.AbortWithError(errCode.Code, errCode.Error)
 		return
 	}
 
-	c.JSON(http.StatusOK, token)
+	a.SendMessage(c, "you have been signed out of all devices")
 }
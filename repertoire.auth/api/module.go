@@ -19,12 +19,14 @@ var middlewares = fx.Options(
 )
 
 var handlers = fx.Options(
+	fx.Provide(handler.NewAccountHandler),
 	fx.Provide(handler.NewCentrifugoHandler),
 	fx.Provide(handler.NewMainHandler),
 	fx.Provide(handler.NewStorageHandler),
 )
 
 var routers = fx.Options(
+	fx.Provide(router.NewAccountRouter),
 	fx.Provide(router.NewCentrifugoRouter),
 	fx.Provide(router.NewMainRouter),
 	fx.Provide(router.NewStorageRouter),
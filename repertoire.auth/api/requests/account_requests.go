@@ -0,0 +1,14 @@
+package requests
+
+type RequestPasswordResetRequest struct {
+	Email string `validate:"required,max=256,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `validate:"required"`
+	Password string `validate:"required,min=8,has_upper,has_lower,has_digit"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `validate:"required"`
+}
@@ -0,0 +1,174 @@
+package requests
+
+import (
+	"net/http"
+	"repertoire/auth/api/validation"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRequestPasswordResetRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := RequestPasswordResetRequest{Email: validEmail}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateRequestPasswordResetRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              RequestPasswordResetRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Email Test Cases
+		{
+			"Email is invalid because it's required",
+			RequestPasswordResetRequest{Email: ""},
+			"Email",
+			"required",
+		},
+		{
+			"Email is invalid because it has too many characters",
+			RequestPasswordResetRequest{Email: strings.Repeat("a", 257)},
+			"Email",
+			"max",
+		},
+		{
+			"Email is invalid because it is not an email",
+			RequestPasswordResetRequest{Email: "someone"},
+			"Email",
+			"email",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "RequestPasswordResetRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateResetPasswordRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := ResetPasswordRequest{Token: "some-token", Password: validPassword}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateResetPasswordRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              ResetPasswordRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Token Test Cases
+		{
+			"Token is invalid because it's required",
+			ResetPasswordRequest{Token: "", Password: validPassword},
+			"Token",
+			"required",
+		},
+		// Password Test Cases
+		{
+			"Password is invalid because it's required",
+			ResetPasswordRequest{Token: "some-token", Password: ""},
+			"Password",
+			"required",
+		},
+		{
+			"Password is invalid because it has less than 8 characters",
+			ResetPasswordRequest{Token: "some-token", Password: "Pass12"},
+			"Password",
+			"min",
+		},
+		{
+			"Password is invalid because it has no uppercase character",
+			ResetPasswordRequest{Token: "some-token", Password: "password123"},
+			"Password",
+			"has_upper",
+		},
+		{
+			"Password is invalid because it has no lowercase character",
+			ResetPasswordRequest{Token: "some-token", Password: "PASSWORD123"},
+			"Password",
+			"has_lower",
+		},
+		{
+			"Password is invalid because it has no digit",
+			ResetPasswordRequest{Token: "some-token", Password: "Passwords"},
+			"Password",
+			"has_digit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "ResetPasswordRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateVerifyEmailRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := VerifyEmailRequest{Token: "some-token"}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateVerifyEmailRequest_WhenTokenIsEmpty_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := VerifyEmailRequest{Token: ""}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Contains(t, errCode.Error.Error(), "VerifyEmailRequest.Token")
+	assert.Contains(t, errCode.Error.Error(), "'required' tag")
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+}
@@ -1,10 +1,14 @@
 package requests
 
 type RefreshRequest struct {
-	Token string
+	RefreshToken string `validate:"required"`
 }
 
 type SignInRequest struct {
 	Email    string `validate:"required,max=256,email"`
 	Password string `validate:"required"`
 }
+
+type SignOutRequest struct {
+	RefreshToken string `validate:"required"`
+}
@@ -91,3 +91,61 @@ func TestValidateSignInRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t
 		})
 	}
 }
+
+func TestValidateRefreshRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := RefreshRequest{RefreshToken: "some-refresh-token"}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateRefreshRequest_WhenRefreshTokenIsEmpty_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := RefreshRequest{RefreshToken: ""}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Contains(t, errCode.Error.Error(), "RefreshRequest.RefreshToken")
+	assert.Contains(t, errCode.Error.Error(), "'required' tag")
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+}
+
+func TestValidateSignOutRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := SignOutRequest{RefreshToken: "some-refresh-token"}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateSignOutRequest_WhenRefreshTokenIsEmpty_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := SignOutRequest{RefreshToken: ""}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Contains(t, errCode.Error.Error(), "SignOutRequest.RefreshToken")
+	assert.Contains(t, errCode.Error.Error(), "'required' tag")
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"repertoire/auth/api/handler"
+	"repertoire/auth/api/server"
+)
+
+type AccountRouter struct {
+	requestHandler *server.RequestHandler
+	handler        *handler.AccountHandler
+}
+
+func (a AccountRouter) RegisterRoutes() {
+	publicApi := a.requestHandler.PublicRouter.Group("")
+	{
+		publicApi.PUT("/password-reset/request", a.handler.RequestPasswordReset)
+		publicApi.PUT("/password-reset", a.handler.ResetPassword)
+		publicApi.PUT("/email-verification", a.handler.VerifyEmail)
+	}
+
+	privateApi := a.requestHandler.PrivateRouter.Group("")
+	{
+		privateApi.PUT("/email-verification/request", a.handler.RequestEmailVerification)
+	}
+}
+
+func NewAccountRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.AccountHandler,
+) AccountRouter {
+	return AccountRouter{
+		handler:        handler,
+		requestHandler: requestHandler,
+	}
+}
@@ -11,10 +11,17 @@ type MainRouter struct {
 }
 
 func (m MainRouter) RegisterRoutes() {
-	api := m.requestHandler.PublicRouter.Group("")
+	publicApi := m.requestHandler.PublicRouter.Group("")
 	{
-		api.PUT("/refresh", m.handler.Refresh)
-		api.PUT("/sign-in", m.handler.SignIn)
+		publicApi.GET("/revoked-tokens", m.handler.GetRevokedTokens)
+		publicApi.PUT("/refresh", m.handler.Refresh)
+		publicApi.PUT("/sign-in", m.handler.SignIn)
+		publicApi.PUT("/sign-out", m.handler.SignOut)
+	}
+
+	privateApi := m.requestHandler.PrivateRouter.Group("")
+	{
+		privateApi.PUT("/sign-out-all", m.handler.SignOutAll)
 	}
 }
 
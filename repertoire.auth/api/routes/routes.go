@@ -15,11 +15,13 @@ type Route interface {
 
 func NewRoutes(
 	lc fx.Lifecycle,
+	accountRouter router.AccountRouter,
 	centrifugoRouter router.CentrifugoRouter,
 	mainRouter router.MainRouter,
 	storageRouter router.StorageRouter,
 ) *Routes {
 	routes := &Routes{
+		accountRouter,
 		centrifugoRouter,
 		mainRouter,
 		storageRouter,
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"repertoire/auth/data/logger"
+	"repertoire/auth/internal"
+	"repertoire/auth/model"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fileMailer does not send the mails, but writes them in the mail directory (when there is one) and logs them,
+// so that they can be read during development and tests
+type fileMailer struct {
+	env    internal.Env
+	logger *logger.Logger
+}
+
+func newFileMailer(env internal.Env, logger *logger.Logger) Mailer {
+	return fileMailer{
+		env:    env,
+		logger: logger,
+	}
+}
+
+func (f fileMailer) Send(mail model.Mail) error {
+	f.logger.Info("Mail sent",
+		zap.String("to", mail.To),
+		zap.String("subject", mail.Subject),
+		zap.String("body", mail.Body),
+	)
+	if f.env.MailDirectory == "" {
+		return nil
+	}
+
+	err := os.MkdirAll(f.env.MailDirectory, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	name := time.Now().UTC().Format("20060102150405") + "-" + uuid.NewString() + ".eml"
+	return os.WriteFile(filepath.Join(f.env.MailDirectory, name), message(f.env.MailFrom, mail), 0644)
+}
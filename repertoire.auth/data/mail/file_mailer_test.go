@@ -0,0 +1,46 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"repertoire/auth/data/logger"
+	"repertoire/auth/internal"
+	"repertoire/auth/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer_Send_WhenThereIsNoDirectory_ShouldOnlyLogTheMail(t *testing.T) {
+	// given
+	_uut := newFileMailer(internal.Env{}, logger.NewLoggerMock())
+
+	// when
+	err := _uut.Send(model.Mail{To: "someone@example.com", Subject: "Subject", Body: "Body"})
+
+	// then
+	assert.NoError(t, err)
+}
+
+func TestFileMailer_Send_WhenThereIsDirectory_ShouldWriteTheMail(t *testing.T) {
+	// given
+	directory := filepath.Join(t.TempDir(), "mails")
+	env := internal.Env{MailFrom: "no-reply@example.com", MailDirectory: directory}
+	_uut := newFileMailer(env, logger.NewLoggerMock())
+
+	// when
+	err := _uut.Send(model.Mail{To: "someone@example.com", Subject: "Subject", Body: "Body"})
+
+	// then
+	assert.NoError(t, err)
+
+	entries, _ := os.ReadDir(directory)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, ".eml", filepath.Ext(entries[0].Name()))
+
+	content, _ := os.ReadFile(filepath.Join(directory, entries[0].Name()))
+	assert.Contains(t, string(content), "From: no-reply@example.com\r\n")
+	assert.Contains(t, string(content), "To: someone@example.com\r\n")
+	assert.Contains(t, string(content), "Subject: Subject\r\n")
+	assert.Contains(t, string(content), "\r\n\r\nBody")
+}
@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"repertoire/auth/data/logger"
+	"repertoire/auth/internal"
+	"repertoire/auth/model"
+	"strings"
+	"time"
+)
+
+type Mailer interface {
+	Send(mail model.Mail) error
+}
+
+func NewMailer(env internal.Env, logger *logger.Logger) Mailer {
+	if env.Mailer == internal.SmtpMailer {
+		return newSmtpMailer(env)
+	}
+	return newFileMailer(env, logger)
+}
+
+// message builds the plain text RFC 5322 message of the mail
+func message(from string, mail model.Mail) []byte {
+	var builder strings.Builder
+	builder.WriteString("From: " + sanitizeHeader(from) + "\r\n")
+	builder.WriteString("To: " + sanitizeHeader(mail.To) + "\r\n")
+	builder.WriteString("Subject: " + sanitizeHeader(mail.Subject) + "\r\n")
+	builder.WriteString("Date: " + time.Now().UTC().Format(time.RFC1123Z) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(strings.ReplaceAll(mail.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(builder.String())
+}
+
+// sanitizeHeader removes the line breaks, so that no other header can be injected
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,16 @@
+package mail
+
+import (
+	"repertoire/auth/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MailerMock struct {
+	mock.Mock
+}
+
+func (m *MailerMock) Send(mail model.Mail) error {
+	args := m.Called(mail)
+	return args.Error(0)
+}
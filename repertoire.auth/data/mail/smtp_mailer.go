@@ -0,0 +1,48 @@
+package mail
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"repertoire/auth/internal"
+	"repertoire/auth/model"
+)
+
+type smtpMailer struct {
+	env  internal.Env
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func newSmtpMailer(env internal.Env) Mailer {
+	return smtpMailer{
+		env:  env,
+		send: smtp.SendMail,
+	}
+}
+
+func (s smtpMailer) Send(mail model.Mail) error {
+	from, err := parseAddress(s.env.MailFrom)
+	if err != nil {
+		return err
+	}
+	to, err := parseAddress(mail.To)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.env.SmtpUsername != "" {
+		auth = smtp.PlainAuth("", s.env.SmtpUsername, s.env.SmtpPassword, s.env.SmtpHost)
+	}
+
+	addr := net.JoinHostPort(s.env.SmtpHost, s.env.SmtpPort)
+	return s.send(addr, auth, from, []string{to}, message(s.env.MailFrom, mail))
+}
+
+func parseAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Address, nil
+}
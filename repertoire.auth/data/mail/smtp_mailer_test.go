@@ -0,0 +1,116 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"repertoire/auth/internal"
+	"repertoire/auth/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmtpMailer_Send_WhenAddressIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+	}{
+		{"when from is invalid", "not-an-address", "someone@example.com"},
+		{"when to is invalid", "Repertoire <no-reply@example.com>", "not-an-address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			called := false
+			_uut := smtpMailer{
+				env: internal.Env{MailFrom: tt.from, SmtpHost: "localhost", SmtpPort: "25"},
+				send: func(string, smtp.Auth, string, []string, []byte) error {
+					called = true
+					return nil
+				},
+			}
+
+			// when
+			err := _uut.Send(model.Mail{To: tt.to, Subject: "Subject", Body: "Body"})
+
+			// then
+			assert.Error(t, err)
+			assert.False(t, called)
+		})
+	}
+}
+
+func TestSmtpMailer_Send_WhenSendFails_ShouldReturnError(t *testing.T) {
+	// given
+	internalError := errors.New("internal error")
+	_uut := smtpMailer{
+		env: internal.Env{MailFrom: "no-reply@example.com", SmtpHost: "localhost", SmtpPort: "25"},
+		send: func(string, smtp.Auth, string, []string, []byte) error {
+			return internalError
+		},
+	}
+
+	// when
+	err := _uut.Send(model.Mail{To: "someone@example.com", Subject: "Subject", Body: "Body"})
+
+	// then
+	assert.Equal(t, internalError, err)
+}
+
+func TestSmtpMailer_Send_WhenSuccessful_ShouldSendTheMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+	}{
+		{"without authentication", ""},
+		{"with authentication", "username"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			env := internal.Env{
+				MailFrom:     "Repertoire <no-reply@example.com>",
+				SmtpHost:     "smtp.example.com",
+				SmtpPort:     "587",
+				SmtpUsername: tt.username,
+				SmtpPassword: "password",
+			}
+			mail := model.Mail{
+				To:      "Someone <someone@example.com>",
+				Subject: "Some Subject\r\nBcc: someone-else@example.com",
+				Body:    "First line\nSecond line",
+			}
+
+			var sentAddr, sentFrom string
+			var sentAuth smtp.Auth
+			var sentTo []string
+			var sentMessage []byte
+			_uut := smtpMailer{
+				env: env,
+				send: func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+					sentAddr, sentAuth, sentFrom, sentTo, sentMessage = addr, a, from, to, msg
+					return nil
+				},
+			}
+
+			// when
+			err := _uut.Send(mail)
+
+			// then
+			assert.NoError(t, err)
+
+			assert.Equal(t, "smtp.example.com:587", sentAddr)
+			assert.Equal(t, tt.username != "", sentAuth != nil)
+			assert.Equal(t, "no-reply@example.com", sentFrom)
+			assert.Equal(t, []string{"someone@example.com"}, sentTo)
+
+			message := string(sentMessage)
+			assert.Contains(t, message, "From: Repertoire <no-reply@example.com>\r\n")
+			assert.Contains(t, message, "To: Someone <someone@example.com>\r\n")
+			assert.Contains(t, message, "Subject: Some SubjectBcc: someone-else@example.com\r\n")
+			assert.NotContains(t, message, "\r\nBcc:")
+			assert.Contains(t, message, "\r\n\r\nFirst line\r\nSecond line")
+		})
+	}
+}
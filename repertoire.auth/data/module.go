@@ -3,6 +3,7 @@ package data
 import (
 	"repertoire/auth/data/database"
 	"repertoire/auth/data/logger"
+	"repertoire/auth/data/mail"
 	"repertoire/auth/data/repository"
 	"repertoire/auth/data/service"
 
@@ -27,7 +28,11 @@ var services = fx.Options(
 
 var Module = fx.Options(
 	loggers,
+	fx.Provide(mail.NewMailer),
+	fx.Provide(repository.NewRefreshTokenRepository),
+	fx.Provide(repository.NewRevokedTokenRepository),
 	fx.Provide(repository.NewUserRepository),
+	fx.Provide(repository.NewUserTokenRepository),
 	services,
 	fx.Provide(database.NewClient),
 )
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"repertoire/auth/data/database"
+	"repertoire/auth/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	GetByHash(refreshToken *model.RefreshToken, tokenHash string) error
+	GetAllByFamilyID(refreshTokens *[]model.RefreshToken, familyID uuid.UUID) error
+	GetAllByUserID(refreshTokens *[]model.RefreshToken, userID uuid.UUID) error
+	Create(refreshToken *model.RefreshToken) error
+	Rotate(id uuid.UUID, replacement *model.RefreshToken) (bool, error)
+	Revoke(ids []uuid.UUID) error
+	DeleteExpired() error
+}
+
+type refreshTokenRepository struct {
+	client database.Client
+}
+
+func NewRefreshTokenRepository(client database.Client) RefreshTokenRepository {
+	return refreshTokenRepository{
+		client: client,
+	}
+}
+
+func (r refreshTokenRepository) GetByHash(refreshToken *model.RefreshToken, tokenHash string) error {
+	return r.client.Find(&refreshToken, model.RefreshToken{TokenHash: tokenHash}).Error
+}
+
+func (r refreshTokenRepository) GetAllByFamilyID(refreshTokens *[]model.RefreshToken, familyID uuid.UUID) error {
+	return r.client.Find(&refreshTokens, model.RefreshToken{FamilyID: familyID}).Error
+}
+
+func (r refreshTokenRepository) GetAllByUserID(refreshTokens *[]model.RefreshToken, userID uuid.UUID) error {
+	return r.client.Find(&refreshTokens, model.RefreshToken{UserID: userID}).Error
+}
+
+func (r refreshTokenRepository) Create(refreshToken *model.RefreshToken) error {
+	return r.client.Create(&refreshToken).Error
+}
+
+// Rotate revokes the refresh token in favor of its replacement, which is created in the same transaction,
+// and reports false when the token had already been revoked (or rotated) by someone else
+func (r refreshTokenRepository) Rotate(id uuid.UUID, replacement *model.RefreshToken) (bool, error) {
+	rotated := false
+	err := r.client.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", id).
+			Updates(map[string]any{
+				"revoked_at":     time.Now().UTC(),
+				"replaced_by_id": replacement.ID,
+			})
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		err := tx.Create(&replacement).Error
+		if err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+func (r refreshTokenRepository) Revoke(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.client.Model(&model.RefreshToken{}).
+		Where("id IN (?) AND revoked_at IS NULL", ids).
+		Update("revoked_at", time.Now().UTC()).
+		Error
+}
+
+func (r refreshTokenRepository) DeleteExpired() error {
+	return r.client.Where("expires_at < ?", time.Now().UTC()).Delete(&model.RefreshToken{}).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"repertoire/auth/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RefreshTokenRepositoryMock) GetByHash(refreshToken *model.RefreshToken, tokenHash string) error {
+	args := r.Called(refreshToken, tokenHash)
+
+	if len(args) > 1 {
+		*refreshToken = *args.Get(1).(*model.RefreshToken)
+	}
+
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) GetAllByFamilyID(refreshTokens *[]model.RefreshToken, familyID uuid.UUID) error {
+	args := r.Called(refreshTokens, familyID)
+
+	if len(args) > 1 {
+		*refreshTokens = *args.Get(1).(*[]model.RefreshToken)
+	}
+
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) GetAllByUserID(refreshTokens *[]model.RefreshToken, userID uuid.UUID) error {
+	args := r.Called(refreshTokens, userID)
+
+	if len(args) > 1 {
+		*refreshTokens = *args.Get(1).(*[]model.RefreshToken)
+	}
+
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) Create(refreshToken *model.RefreshToken) error {
+	args := r.Called(refreshToken)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) Rotate(id uuid.UUID, replacement *model.RefreshToken) (bool, error) {
+	args := r.Called(id, replacement)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *RefreshTokenRepositoryMock) Revoke(ids []uuid.UUID) error {
+	args := r.Called(ids)
+	return args.Error(0)
+}
+
+func (r *RefreshTokenRepositoryMock) DeleteExpired() error {
+	args := r.Called()
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"repertoire/auth/data/database"
+	"repertoire/auth/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository interface {
+	Get(revokedToken *model.RevokedToken, id uuid.UUID) error
+	GetAll(revokedTokens *[]model.RevokedToken) error
+	CreateMany(revokedTokens *[]model.RevokedToken) error
+	DeleteExpired() error
+}
+
+type revokedTokenRepository struct {
+	client database.Client
+}
+
+func NewRevokedTokenRepository(client database.Client) RevokedTokenRepository {
+	return revokedTokenRepository{
+		client: client,
+	}
+}
+
+func (r revokedTokenRepository) Get(revokedToken *model.RevokedToken, id uuid.UUID) error {
+	return r.client.Find(&revokedToken, model.RevokedToken{ID: id}).Error
+}
+
+func (r revokedTokenRepository) GetAll(revokedTokens *[]model.RevokedToken) error {
+	return r.client.
+		Where("expires_at > ?", time.Now().UTC()).
+		Order("expires_at").
+		Find(&revokedTokens).
+		Error
+}
+
+func (r revokedTokenRepository) CreateMany(revokedTokens *[]model.RevokedToken) error {
+	if len(*revokedTokens) == 0 {
+		return nil
+	}
+	return r.client.Clauses(clause.OnConflict{DoNothing: true}).Create(&revokedTokens).Error
+}
+
+func (r revokedTokenRepository) DeleteExpired() error {
+	return r.client.Where("expires_at < ?", time.Now().UTC()).Delete(&model.RevokedToken{}).Error
+}
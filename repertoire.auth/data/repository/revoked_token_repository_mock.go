@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"repertoire/auth/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type RevokedTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *RevokedTokenRepositoryMock) Get(revokedToken *model.RevokedToken, id uuid.UUID) error {
+	args := r.Called(revokedToken, id)
+
+	if len(args) > 1 {
+		*revokedToken = *args.Get(1).(*model.RevokedToken)
+	}
+
+	return args.Error(0)
+}
+
+func (r *RevokedTokenRepositoryMock) GetAll(revokedTokens *[]model.RevokedToken) error {
+	args := r.Called(revokedTokens)
+
+	if len(args) > 1 {
+		*revokedTokens = *args.Get(1).(*[]model.RevokedToken)
+	}
+
+	return args.Error(0)
+}
+
+func (r *RevokedTokenRepositoryMock) CreateMany(revokedTokens *[]model.RevokedToken) error {
+	args := r.Called(revokedTokens)
+	return args.Error(0)
+}
+
+func (r *RevokedTokenRepositoryMock) DeleteExpired() error {
+	args := r.Called()
+	return args.Error(0)
+}
@@ -10,6 +10,8 @@ import (
 type UserRepository interface {
 	Get(user *model.User, id uuid.UUID) error
 	GetByEmail(user *model.User, email string) error
+	UpdatePassword(id uuid.UUID, password string) error
+	VerifyEmail(id uuid.UUID) error
 }
 
 type userRepository struct {
@@ -29,3 +31,11 @@ func (u userRepository) Get(user *model.User, id uuid.UUID) error {
 func (u userRepository) GetByEmail(user *model.User, email string) error {
 	return u.client.Find(&user, model.User{Email: email}).Error
 }
+
+func (u userRepository) UpdatePassword(id uuid.UUID, password string) error {
+	return u.client.Model(&model.User{ID: id}).Update("password", password).Error
+}
+
+func (u userRepository) VerifyEmail(id uuid.UUID) error {
+	return u.client.Model(&model.User{ID: id}).Update("email_verified", true).Error
+}
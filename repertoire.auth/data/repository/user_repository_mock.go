@@ -30,3 +30,13 @@ func (u *UserRepositoryMock) GetByEmail(user *model.User, email string) error {
 
 	return args.Error(0)
 }
+
+func (u *UserRepositoryMock) UpdatePassword(id uuid.UUID, password string) error {
+	args := u.Called(id, password)
+	return args.Error(0)
+}
+
+func (u *UserRepositoryMock) VerifyEmail(id uuid.UUID) error {
+	args := u.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"repertoire/auth/data/database"
+	"repertoire/auth/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserTokenRepository interface {
+	GetByHash(userToken *model.UserToken, tokenHash string, tokenType model.UserTokenType) error
+	Create(userToken *model.UserToken) error
+	Use(id uuid.UUID) (bool, error)
+	DeleteAllByUser(userID uuid.UUID, tokenType model.UserTokenType) error
+}
+
+type userTokenRepository struct {
+	client database.Client
+}
+
+func NewUserTokenRepository(client database.Client) UserTokenRepository {
+	return userTokenRepository{
+		client: client,
+	}
+}
+
+func (u userTokenRepository) GetByHash(
+	userToken *model.UserToken,
+	tokenHash string,
+	tokenType model.UserTokenType,
+) error {
+	return u.client.Find(&userToken, model.UserToken{TokenHash: tokenHash, Type: tokenType}).Error
+}
+
+func (u userTokenRepository) Create(userToken *model.UserToken) error {
+	return u.client.Create(&userToken).Error
+}
+
+// Use marks the token as used, and reports false when the token had already been used by someone else
+func (u userTokenRepository) Use(id uuid.UUID) (bool, error) {
+	result := u.client.Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now().UTC())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (u userTokenRepository) DeleteAllByUser(userID uuid.UUID, tokenType model.UserTokenType) error {
+	return u.client.
+		Where("user_id = ? AND type = ?", userID, tokenType).
+		Delete(&model.UserToken{}).
+		Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"repertoire/auth/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (u *UserTokenRepositoryMock) GetByHash(
+	userToken *model.UserToken,
+	tokenHash string,
+	tokenType model.UserTokenType,
+) error {
+	args := u.Called(userToken, tokenHash, tokenType)
+
+	if len(args) > 1 {
+		*userToken = *args.Get(1).(*model.UserToken)
+	}
+
+	return args.Error(0)
+}
+
+func (u *UserTokenRepositoryMock) Create(userToken *model.UserToken) error {
+	args := u.Called(userToken)
+	return args.Error(0)
+}
+
+func (u *UserTokenRepositoryMock) Use(id uuid.UUID) (bool, error) {
+	args := u.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (u *UserTokenRepositoryMock) DeleteAllByUser(userID uuid.UUID, tokenType model.UserTokenType) error {
+	args := u.Called(userID, tokenType)
+	return args.Error(0)
+}
@@ -16,10 +16,11 @@ type JwtService interface {
 	Authorize(authToken string) *wrapper.ErrorCode
 	GetUserIDFromJwt(tokenString string) (uuid.UUID, *wrapper.ErrorCode)
 
-	Validate(tokenString string) (uuid.UUID, *wrapper.ErrorCode)
+	// Validate checks an unexpired access token and returns its user ID and token ID (jti)
+	Validate(tokenString string) (uuid.UUID, uuid.UUID, *wrapper.ErrorCode)
 	ValidateCredentials(clientCredentials model.ClientCredentials) *wrapper.ErrorCode
 
-	CreateToken(user model.User) (string, *wrapper.ErrorCode)
+	CreateToken(user model.User, tokenID uuid.UUID) (string, *wrapper.ErrorCode)
 	CreateCentrifugoToken(userID uuid.UUID) (string, string, *wrapper.ErrorCode)
 	CreateStorageToken(userID uuid.UUID) (string, string, *wrapper.ErrorCode)
 }
@@ -78,79 +79,79 @@ func (j jwtService) GetUserIDFromJwt(tokenString string) (uuid.UUID, *wrapper.Er
 
 // Validation
 
-func (j jwtService) Validate(tokenString string) (uuid.UUID, *wrapper.ErrorCode) {
+func (j jwtService) Validate(tokenString string) (uuid.UUID, uuid.UUID, *wrapper.ErrorCode) {
 	publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(j.env.JwtPublicKey))
 	if err != nil {
-		return uuid.Nil, wrapper.InternalServerError(err)
+		return uuid.Nil, uuid.Nil, wrapper.InternalServerError(err)
 	}
 	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 		return publicKey, nil
 	})
-	if err != nil && !errors.Is(err, jwt.ErrTokenExpired) {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 
 	// signing method
 	if token.Method != jwt.SigningMethodRS256 {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid signing method"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid signing method"))
 	}
 
 	// audience
 	aud, err := token.Claims.GetAudience()
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 	if len(aud) != 1 || aud[0] != j.env.JwtAudience {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("wrong audience"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("wrong audience"))
 	}
 
 	// issuer
 	iss, err := token.Claims.GetIssuer()
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 	if iss != j.env.JwtIssuer {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("wrong issuer"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("wrong issuer"))
 	}
 
 	// expiration time
 	exp, err := token.Claims.GetExpirationTime()
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 	if exp == nil {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("missing expiration time"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("missing expiration time"))
 	}
 
 	// jti
 	jtiClaim, jtiFound := token.Claims.(jwt.MapClaims)["jti"]
 	if !jtiFound {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("missing jti"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("missing jti"))
 	}
 
 	jti, err := uuid.Parse(jtiClaim.(string))
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 	if jti == uuid.Nil {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid jti"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid jti"))
 	}
 
 	// sub
 	sub, err := token.Claims.GetSubject()
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 
 	userID, err := uuid.Parse(sub)
 	if err != nil {
-		return uuid.Nil, wrapper.UnauthorizedError(err)
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 	if userID == uuid.Nil {
-		return uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid sub"))
+		return uuid.Nil, uuid.Nil, wrapper.UnauthorizedError(errors.New("invalid sub"))
 	}
 
-	return userID, nil
+	return userID, jti, nil
 }
 
 func (j jwtService) ValidateCredentials(clientCredentials model.ClientCredentials) *wrapper.ErrorCode {
@@ -164,7 +165,7 @@ func (j jwtService) ValidateCredentials(clientCredentials model.ClientCredential
 
 // Create Tokens
 
-func (j jwtService) CreateToken(user model.User) (string, *wrapper.ErrorCode) {
+func (j jwtService) CreateToken(user model.User, tokenID uuid.UUID) (string, *wrapper.ErrorCode) {
 	expiresIn, err := time.ParseDuration(j.env.JwtExpirationTime)
 	if err != nil {
 		return "", wrapper.InternalServerError(err)
@@ -175,7 +176,7 @@ func (j jwtService) CreateToken(user model.User) (string, *wrapper.ErrorCode) {
 	}
 
 	claims := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"jti": uuid.New().String(),
+		"jti": tokenID.String(),
 		"sub": user.ID.String(),
 		"iss": j.env.JwtIssuer,
 		"aud": j.env.JwtAudience,
@@ -223,6 +224,8 @@ func (j jwtService) CreateStorageToken(userID uuid.UUID) (string, string, *wrapp
 		"aud": j.env.StorageJwtAudience,
 		"iat": time.Now().UTC().Unix(),
 		"exp": time.Now().UTC().Add(expiresIn).Unix(),
+		// the storage only allows writes and deletes under this directory
+		"prefix": userID.String(),
 	})
 	token, err := claims.SignedString([]byte(j.env.StorageJwtSecretKey))
 	if err != nil {
@@ -23,8 +23,8 @@ func (j *JwtServiceMock) Authorize(authToken string) *wrapper.ErrorCode {
 	return errCode
 }
 
-func (j *JwtServiceMock) CreateToken(user model.User) (string, *wrapper.ErrorCode) {
-	args := j.Called(user)
+func (j *JwtServiceMock) CreateToken(user model.User, tokenID uuid.UUID) (string, *wrapper.ErrorCode) {
+	args := j.Called(user, tokenID)
 
 	var errCode *wrapper.ErrorCode
 	if a := args.Get(1); a != nil {
@@ -56,15 +56,15 @@ func (j *JwtServiceMock) CreateStorageToken(userID uuid.UUID) (string, string, *
 	return args.String(0), args.String(1), errCode
 }
 
-func (j *JwtServiceMock) Validate(tokenString string) (uuid.UUID, *wrapper.ErrorCode) {
+func (j *JwtServiceMock) Validate(tokenString string) (uuid.UUID, uuid.UUID, *wrapper.ErrorCode) {
 	args := j.Called(tokenString)
 
 	var errCode *wrapper.ErrorCode
-	if a := args.Get(1); a != nil {
+	if a := args.Get(2); a != nil {
 		errCode = a.(*wrapper.ErrorCode)
 	}
 
-	return args.Get(0).(uuid.UUID), errCode
+	return args.Get(0).(uuid.UUID), args.Get(1).(uuid.UUID), errCode
 }
 
 func (j *JwtServiceMock) ValidateCredentials(clientCredentials model.ClientCredentials) *wrapper.ErrorCode {
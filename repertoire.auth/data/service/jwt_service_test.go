@@ -403,10 +403,11 @@ func TestJwtService_Validate_WhenPublicKeyIsNotAKey_ShouldReturnInternalServerEr
 	token, _ := claims.SignedString(key)
 
 	// when
-	userID, errCode := _uut.Validate(token)
+	userID, tokenID, errCode := _uut.Validate(token)
 
 	// then
 	assert.Empty(t, userID)
+	assert.Empty(t, tokenID)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Error(t, errCode.Error)
@@ -506,13 +507,25 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
 		},
+		{
+			"when token is expired",
+			jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+				"jti": uuid.New().String(),
+				"sub": uuid.New().String(),
+				"iss": env.JwtIssuer,
+				"aud": env.JwtAudience,
+				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+			}),
+			env.JwtPublicKey,
+			env.JwtPrivateKey,
+		},
 		// jti
 		{
 			"when jti is missing",
 			jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -523,7 +536,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 				"jti": "something else",
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -534,7 +547,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 				"jti": uuid.Nil,
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -546,7 +559,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 				"jti": uuid.New().String(),
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -558,7 +571,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 				"sub": "This is a sub",
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -570,7 +583,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 				"sub": uuid.Nil.String(),
 				"iss": env.JwtIssuer,
 				"aud": env.JwtAudience,
-				"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+				"exp": time.Now().UTC().Add(time.Hour).Unix(),
 			}),
 			env.JwtPublicKey,
 			env.JwtPrivateKey,
@@ -588,10 +601,11 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 			token, _ := tt.claims.SignedString(key)
 
 			// when
-			userID, errCode := _uut.Validate(token)
+			userID, tokenID, errCode := _uut.Validate(token)
 
 			// then
 			assert.Empty(t, userID)
+			assert.Empty(t, tokenID)
 			assert.NotNil(t, errCode)
 			assert.Equal(t, http.StatusUnauthorized, errCode.Code)
 			assert.Error(t, errCode.Error)
@@ -599,7 +613,7 @@ func TestJwtService_Validate_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 	}
 }
 
-func TestJwtService_Validate_WhenSuccessful_ShouldReturnUserID(t *testing.T) {
+func TestJwtService_Validate_WhenSuccessful_ShouldReturnUserIDAndTokenID(t *testing.T) {
 	// given
 	env := internal.Env{
 		JwtPublicKey: publicKey,
@@ -610,23 +624,24 @@ func TestJwtService_Validate_WhenSuccessful_ShouldReturnUserID(t *testing.T) {
 		ID: uuid.New(),
 	}
 
-	// expired token
+	jti := uuid.New()
 	claims := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"jti": uuid.New().String(),
+		"jti": jti.String(),
 		"sub": user.ID.String(),
 		"iss": env.JwtIssuer,
 		"aud": env.JwtAudience,
 		"iat": time.Now().UTC().Unix(),
-		"exp": time.Now().UTC().Add(-time.Hour).Unix(),
+		"exp": time.Now().UTC().Add(time.Hour).Unix(),
 	})
 	key, _ := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey))
 	token, _ := claims.SignedString(key)
 
 	// when
-	userID, errCode := _uut.Validate(token)
+	userID, tokenID, errCode := _uut.Validate(token)
 
 	// then
-	assert.Equal(t, userID, user.ID)
+	assert.Equal(t, user.ID, userID)
+	assert.Equal(t, jti, tokenID)
 	assert.Nil(t, errCode)
 }
 
@@ -712,7 +727,7 @@ func TestJwtService_CreateToken_WhenExpirationTimeIsInvalid_ShouldReturnInternal
 	user := model.User{}
 
 	// when
-	tokenString, errCode := _uut.CreateToken(user)
+	tokenString, errCode := _uut.CreateToken(user, uuid.New())
 
 	// then
 	assert.Empty(t, tokenString)
@@ -732,7 +747,7 @@ func TestJwtService_CreateToken_WhenPrivateKeyIsInvalid_ShouldReturnInternalErro
 	user := model.User{}
 
 	// when
-	tokenString, errCode := _uut.CreateToken(user)
+	tokenString, errCode := _uut.CreateToken(user, uuid.New())
 
 	// then
 	assert.Empty(t, tokenString)
@@ -756,10 +771,12 @@ func TestJwtService_CreateToken_WhenSuccessful_ShouldReturnNewToken(t *testing.T
 		ID: uuid.New(),
 	}
 
+	tokenID := uuid.New()
+
 	expiresIn, _ := time.ParseDuration(env.JwtExpirationTime)
 
 	// when
-	tokenString, errCode := _uut.CreateToken(user)
+	tokenString, errCode := _uut.CreateToken(user, tokenID)
 
 	// then
 	assert.Nil(t, errCode)
@@ -785,7 +802,7 @@ func TestJwtService_CreateToken_WhenSuccessful_ShouldReturnNewToken(t *testing.T
 	assert.NoError(t, err)
 
 	assert.Equal(t, jwt.SigningMethodRS256, token.Method)
-	assert.NotEmpty(t, jti)
+	assert.Equal(t, tokenID, jti)
 	assert.Equal(t, user.ID.String(), sub)
 	assert.Len(t, aud, 1)
 	assert.Equal(t, env.JwtAudience, aud[0])
@@ -932,4 +949,5 @@ func TestJwtService_CreateStorageToken_WhenSuccessful_ShouldReturnNewToken(t *te
 	assert.Equal(t, env.JwtIssuer, iss)
 	assert.WithinDuration(t, time.Now().UTC(), iat.Time, 10*time.Second)
 	assert.WithinDuration(t, time.Now().Add(expiresInDuration).UTC(), exp.Time.UTC(), 10*time.Second)
+	assert.Equal(t, userID.String(), token.Claims.(jwt.MapClaims)["prefix"])
 }
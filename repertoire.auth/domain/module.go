@@ -7,6 +7,7 @@ import (
 )
 
 var Module = fx.Options(
+	fx.Provide(service.NewAccountService),
 	fx.Provide(service.NewCentrifugoService),
 	fx.Provide(service.NewMainService),
 	fx.Provide(service.NewStorageService),
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"repertoire/auth/api/requests"
+	"repertoire/auth/data/mail"
+	"repertoire/auth/data/repository"
+	"repertoire/auth/data/service"
+	"repertoire/auth/internal"
+	"repertoire/auth/internal/wrapper"
+	"repertoire/auth/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AccountService interface {
+	RequestPasswordReset(request requests.RequestPasswordResetRequest) *wrapper.ErrorCode
+	ResetPassword(request requests.ResetPasswordRequest) *wrapper.ErrorCode
+	RequestEmailVerification(token string) *wrapper.ErrorCode
+	VerifyEmail(request requests.VerifyEmailRequest) *wrapper.ErrorCode
+}
+
+type accountService struct {
+	mainService         MainService
+	jwtService          service.JwtService
+	bCryptService       service.BCryptService
+	mailer              mail.Mailer
+	userRepository      repository.UserRepository
+	userTokenRepository repository.UserTokenRepository
+	env                 internal.Env
+}
+
+func NewAccountService(
+	mainService MainService,
+	jwtService service.JwtService,
+	bCryptService service.BCryptService,
+	mailer mail.Mailer,
+	userRepository repository.UserRepository,
+	userTokenRepository repository.UserTokenRepository,
+	env internal.Env,
+) AccountService {
+	return &accountService{
+		mainService:         mainService,
+		jwtService:          jwtService,
+		bCryptService:       bCryptService,
+		mailer:              mailer,
+		userRepository:      userRepository,
+		userTokenRepository: userTokenRepository,
+		env:                 env,
+	}
+}
+
+func (a *accountService) RequestPasswordReset(request requests.RequestPasswordResetRequest) *wrapper.ErrorCode {
+	// get user (without telling whether the email belongs to an account or not)
+	var user model.User
+	err := a.userRepository.GetByEmail(&user, strings.ToLower(request.Email))
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(user).IsZero() {
+		return nil
+	}
+
+	token, errCode := a.createUserToken(
+		user.ID,
+		model.PasswordResetUserToken,
+		a.env.PasswordResetTokenExpirationTime,
+	)
+	if errCode != nil {
+		return errCode
+	}
+
+	return a.sendLink(
+		user,
+		"Reset your password",
+		"To reset your password, follow the link below (it can be used only once):",
+		a.env.PasswordResetUrl,
+		token,
+	)
+}
+
+func (a *accountService) ResetPassword(request requests.ResetPasswordRequest) *wrapper.ErrorCode {
+	userToken, errCode := a.useUserToken(request.Token, model.PasswordResetUserToken)
+	if errCode != nil {
+		return errCode
+	}
+
+	// update password
+	hashedPassword, err := a.bCryptService.Hash(request.Password)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	err = a.userRepository.UpdatePassword(userToken.UserID, hashedPassword)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	// sign out everywhere, as the old password might have been compromised
+	return a.mainService.RevokeAllSessions(userToken.UserID)
+}
+
+func (a *accountService) RequestEmailVerification(token string) *wrapper.ErrorCode {
+	// get user
+	userID, errCode := a.jwtService.GetUserIDFromJwt(token)
+	if errCode != nil {
+		return errCode
+	}
+
+	var user model.User
+	err := a.userRepository.Get(&user, userID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(user).IsZero() {
+		return wrapper.UnauthorizedError(errors.New("not authorized"))
+	}
+	if user.EmailVerified {
+		return wrapper.BadRequestError(errors.New("email is already verified"))
+	}
+
+	verificationToken, errCode := a.createUserToken(
+		user.ID,
+		model.EmailVerificationUserToken,
+		a.env.EmailVerificationTokenExpirationTime,
+	)
+	if errCode != nil {
+		return errCode
+	}
+
+	return a.sendLink(
+		user,
+		"Verify your email",
+		"To verify your email, follow the link below:",
+		a.env.EmailVerificationUrl,
+		verificationToken,
+	)
+}
+
+func (a *accountService) VerifyEmail(request requests.VerifyEmailRequest) *wrapper.ErrorCode {
+	userToken, errCode := a.useUserToken(request.Token, model.EmailVerificationUserToken)
+	if errCode != nil {
+		return errCode
+	}
+
+	err := a.userRepository.VerifyEmail(userToken.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
+
+// createUserToken replaces the previous tokens of the same type, so that only the last one sent can be used
+func (a *accountService) createUserToken(
+	userID uuid.UUID,
+	tokenType model.UserTokenType,
+	expirationTime string,
+) (string, *wrapper.ErrorCode) {
+	expiresIn, err := time.ParseDuration(expirationTime)
+	if err != nil {
+		return "", wrapper.InternalServerError(err)
+	}
+
+	err = a.userTokenRepository.DeleteAllByUser(userID, tokenType)
+	if err != nil {
+		return "", wrapper.InternalServerError(err)
+	}
+
+	token, err := generateOpaqueToken()
+	if err != nil {
+		return "", wrapper.InternalServerError(err)
+	}
+	err = a.userTokenRepository.Create(&model.UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      tokenType,
+		TokenHash: hashOpaqueToken(token),
+		ExpiresAt: time.Now().UTC().Add(expiresIn),
+	})
+	if err != nil {
+		return "", wrapper.InternalServerError(err)
+	}
+
+	return token, nil
+}
+
+// useUserToken marks the token as used, when it exists, has not expired and has not been used before
+func (a *accountService) useUserToken(
+	token string,
+	tokenType model.UserTokenType,
+) (model.UserToken, *wrapper.ErrorCode) {
+	var userToken model.UserToken
+	err := a.userTokenRepository.GetByHash(&userToken, hashOpaqueToken(token), tokenType)
+	if err != nil {
+		return model.UserToken{}, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(userToken).IsZero() ||
+		userToken.UsedAt != nil ||
+		time.Now().UTC().After(userToken.ExpiresAt) {
+		return model.UserToken{}, wrapper.BadRequestError(errors.New("invalid token"))
+	}
+
+	// when another request used it first, the token cannot be used again
+	used, err := a.userTokenRepository.Use(userToken.ID)
+	if err != nil {
+		return model.UserToken{}, wrapper.InternalServerError(err)
+	}
+	if !used {
+		return model.UserToken{}, wrapper.BadRequestError(errors.New("invalid token"))
+	}
+
+	return userToken, nil
+}
+
+func (a *accountService) sendLink(
+	user model.User,
+	subject string,
+	text string,
+	baseUrl string,
+	token string,
+) *wrapper.ErrorCode {
+	link, err := url.Parse(baseUrl)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	err = a.mailer.Send(model.Mail{
+		To:      user.Email,
+		Subject: subject,
+		Body:    "Hi " + user.Name + ",\n\n" + text + "\n\n" + link.String() + "\n",
+	})
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,515 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"repertoire/auth/api/requests"
+	"repertoire/auth/data/mail"
+	"repertoire/auth/data/repository"
+	"repertoire/auth/data/service"
+	"repertoire/auth/internal"
+	"repertoire/auth/internal/wrapper"
+	"repertoire/auth/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var accountServiceEnv = internal.Env{
+	PasswordResetTokenExpirationTime:     "1h",
+	PasswordResetUrl:                     "http://localhost/reset-password",
+	EmailVerificationTokenExpirationTime: "24h",
+	EmailVerificationUrl:                 "http://localhost/verify-email",
+}
+
+// Request Password Reset
+
+func TestAccountService_RequestPasswordReset_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, nil, userRepository, nil, accountServiceEnv)
+
+	request := requests.RequestPasswordResetRequest{Email: "Someone@yahoo.com"}
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetByEmail", new(model.User), strings.ToLower(request.Email)).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.RequestPasswordReset(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestAccountService_RequestPasswordReset_WhenUserIsEmpty_ShouldReturnNilWithoutSendingMail(t *testing.T) {
+	// given
+	mailer := new(mail.MailerMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, mailer, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.RequestPasswordResetRequest{Email: "someone@yahoo.com"}
+
+	userRepository.On("GetByEmail", new(model.User), request.Email).Return(nil).Once()
+
+	// when
+	errCode := _uut.RequestPasswordReset(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertNotCalled(t, "Create")
+	mailer.AssertNotCalled(t, "Send")
+}
+
+func TestAccountService_RequestPasswordReset_WhenSendFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	mailer := new(mail.MailerMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, mailer, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.RequestPasswordResetRequest{Email: "someone@yahoo.com"}
+
+	user := &model.User{ID: uuid.New(), Email: request.Email, Name: "Someone"}
+	userRepository.On("GetByEmail", new(model.User), request.Email).Return(nil, user).Once()
+
+	userTokenRepository.On("DeleteAllByUser", user.ID, model.PasswordResetUserToken).Return(nil).Once()
+	userTokenRepository.On("Create", mock.IsType(new(model.UserToken))).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	mailer.On("Send", mock.IsType(model.Mail{})).Return(internalError).Once()
+
+	// when
+	errCode := _uut.RequestPasswordReset(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+	mailer.AssertExpectations(t)
+}
+
+func TestAccountService_RequestPasswordReset_WhenSuccessful_ShouldSendTheResetLink(t *testing.T) {
+	// given
+	mailer := new(mail.MailerMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, mailer, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.RequestPasswordResetRequest{Email: "someone@yahoo.com"}
+
+	user := &model.User{ID: uuid.New(), Email: request.Email, Name: "Someone"}
+	userRepository.On("GetByEmail", new(model.User), request.Email).Return(nil, user).Once()
+
+	userTokenRepository.On("DeleteAllByUser", user.ID, model.PasswordResetUserToken).Return(nil).Once()
+
+	var userToken *model.UserToken
+	userTokenRepository.On("Create", mock.IsType(new(model.UserToken))).
+		Run(func(args mock.Arguments) {
+			userToken = args.Get(0).(*model.UserToken)
+		}).
+		Return(nil).
+		Once()
+
+	var sentMail model.Mail
+	mailer.On("Send", mock.IsType(model.Mail{})).
+		Run(func(args mock.Arguments) {
+			sentMail = args.Get(0).(model.Mail)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.RequestPasswordReset(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	assert.NotEmpty(t, userToken.ID)
+	assert.Equal(t, user.ID, userToken.UserID)
+	assert.Equal(t, model.PasswordResetUserToken, userToken.Type)
+	assert.Nil(t, userToken.UsedAt)
+	assert.WithinDuration(t, time.Now().UTC().Add(time.Hour), userToken.ExpiresAt, time.Minute)
+
+	assert.Equal(t, user.Email, sentMail.To)
+	assert.NotEmpty(t, sentMail.Subject)
+	token := assertLink(t, sentMail.Body, accountServiceEnv.PasswordResetUrl)
+	assert.Equal(t, hashOpaqueToken(token), userToken.TokenHash)
+
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+	mailer.AssertExpectations(t)
+}
+
+// Reset Password
+
+func TestAccountService_ResetPassword_WhenTokenIsInvalid_ShouldReturnBadRequestError(t *testing.T) {
+	tests := []struct {
+		name      string
+		userToken *model.UserToken
+	}{
+		{
+			"when token is missing",
+			&model.UserToken{},
+		},
+		{
+			"when token has been used",
+			&model.UserToken{
+				ID:        uuid.New(),
+				ExpiresAt: time.Now().UTC().Add(time.Hour),
+				UsedAt:    &[]time.Time{time.Now().UTC()}[0],
+			},
+		},
+		{
+			"when token has expired",
+			&model.UserToken{ID: uuid.New(), ExpiresAt: time.Now().UTC().Add(-time.Minute)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			userTokenRepository := new(repository.UserTokenRepositoryMock)
+			_uut := NewAccountService(nil, nil, nil, nil, nil, userTokenRepository, accountServiceEnv)
+
+			request := requests.ResetPasswordRequest{Token: "some-token", Password: "NewPassword123"}
+
+			userTokenRepository.On(
+				"GetByHash",
+				new(model.UserToken),
+				hashOpaqueToken(request.Token),
+				model.PasswordResetUserToken,
+			).Return(nil, tt.userToken).Once()
+
+			// when
+			errCode := _uut.ResetPassword(request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+			assert.Equal(t, "invalid token", errCode.Error.Error())
+
+			userTokenRepository.AssertExpectations(t)
+			userTokenRepository.AssertNotCalled(t, "Use")
+		})
+	}
+}
+
+func TestAccountService_ResetPassword_WhenTokenIsUsedConcurrently_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, nil, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.ResetPasswordRequest{Token: "some-token", Password: "NewPassword123"}
+
+	userToken := &model.UserToken{ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	userTokenRepository.On(
+		"GetByHash",
+		new(model.UserToken),
+		hashOpaqueToken(request.Token),
+		model.PasswordResetUserToken,
+	).Return(nil, userToken).Once()
+	userTokenRepository.On("Use", userToken.ID).Return(false, nil).Once()
+
+	// when
+	errCode := _uut.ResetPassword(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "invalid token", errCode.Error.Error())
+
+	userTokenRepository.AssertExpectations(t)
+	userRepository.AssertNotCalled(t, "UpdatePassword")
+}
+
+func TestAccountService_ResetPassword_WhenUpdatePasswordFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	bCryptService := new(service.BCryptServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, bCryptService, nil, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.ResetPasswordRequest{Token: "some-token", Password: "NewPassword123"}
+
+	userToken := &model.UserToken{ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	userTokenRepository.On(
+		"GetByHash",
+		new(model.UserToken),
+		hashOpaqueToken(request.Token),
+		model.PasswordResetUserToken,
+	).Return(nil, userToken).Once()
+	userTokenRepository.On("Use", userToken.ID).Return(true, nil).Once()
+
+	hashedPassword := "hashed password"
+	bCryptService.On("Hash", request.Password).Return(hashedPassword, nil).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("UpdatePassword", userToken.UserID, hashedPassword).Return(internalError).Once()
+
+	// when
+	errCode := _uut.ResetPassword(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	bCryptService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+}
+
+func TestAccountService_ResetPassword_WhenSuccessful_ShouldUpdatePasswordAndRevokeAllSessions(t *testing.T) {
+	// given
+	mainService := new(MainServiceMock)
+	bCryptService := new(service.BCryptServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(
+		mainService,
+		nil,
+		bCryptService,
+		nil,
+		userRepository,
+		userTokenRepository,
+		accountServiceEnv,
+	)
+
+	request := requests.ResetPasswordRequest{Token: "some-token", Password: "NewPassword123"}
+
+	userToken := &model.UserToken{ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	userTokenRepository.On(
+		"GetByHash",
+		new(model.UserToken),
+		hashOpaqueToken(request.Token),
+		model.PasswordResetUserToken,
+	).Return(nil, userToken).Once()
+	userTokenRepository.On("Use", userToken.ID).Return(true, nil).Once()
+
+	hashedPassword := "hashed password"
+	bCryptService.On("Hash", request.Password).Return(hashedPassword, nil).Once()
+	userRepository.On("UpdatePassword", userToken.UserID, hashedPassword).Return(nil).Once()
+
+	mainService.On("RevokeAllSessions", userToken.UserID).Return(nil).Once()
+
+	// when
+	errCode := _uut.ResetPassword(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	mainService.AssertExpectations(t)
+	bCryptService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+}
+
+// Request Email Verification
+
+func TestAccountService_RequestEmailVerification_WhenGetUserIDFromJwtFails_ShouldReturnError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := NewAccountService(nil, jwtService, nil, nil, nil, nil, accountServiceEnv)
+
+	token := "This is a token"
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden"))
+	jwtService.On("GetUserIDFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	errCode := _uut.RequestEmailVerification(token)
+
+	// then
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestAccountService_RequestEmailVerification_WhenUserIsEmpty_ShouldReturnUnauthorizedError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewAccountService(nil, jwtService, nil, nil, userRepository, nil, accountServiceEnv)
+
+	token := "This is a token"
+	userID := uuid.New()
+	jwtService.On("GetUserIDFromJwt", token).Return(userID, nil).Once()
+	userRepository.On("Get", new(model.User), userID).Return(nil).Once()
+
+	// when
+	errCode := _uut.RequestEmailVerification(token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestAccountService_RequestEmailVerification_WhenEmailIsAlreadyVerified_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, jwtService, nil, nil, userRepository, userTokenRepository, accountServiceEnv)
+
+	token := "This is a token"
+	user := &model.User{ID: uuid.New(), Email: "someone@yahoo.com", EmailVerified: true}
+	jwtService.On("GetUserIDFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	// when
+	errCode := _uut.RequestEmailVerification(token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "email is already verified", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertNotCalled(t, "Create")
+}
+
+func TestAccountService_RequestEmailVerification_WhenSuccessful_ShouldSendTheVerificationLink(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	mailer := new(mail.MailerMock)
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, jwtService, nil, mailer, userRepository, userTokenRepository, accountServiceEnv)
+
+	token := "This is a token"
+	user := &model.User{ID: uuid.New(), Email: "someone@yahoo.com", Name: "Someone"}
+	jwtService.On("GetUserIDFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	userTokenRepository.On("DeleteAllByUser", user.ID, model.EmailVerificationUserToken).Return(nil).Once()
+
+	var userToken *model.UserToken
+	userTokenRepository.On("Create", mock.IsType(new(model.UserToken))).
+		Run(func(args mock.Arguments) {
+			userToken = args.Get(0).(*model.UserToken)
+		}).
+		Return(nil).
+		Once()
+
+	var sentMail model.Mail
+	mailer.On("Send", mock.IsType(model.Mail{})).
+		Run(func(args mock.Arguments) {
+			sentMail = args.Get(0).(model.Mail)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.RequestEmailVerification(token)
+
+	// then
+	assert.Nil(t, errCode)
+
+	assert.Equal(t, user.ID, userToken.UserID)
+	assert.Equal(t, model.EmailVerificationUserToken, userToken.Type)
+	assert.WithinDuration(t, time.Now().UTC().Add(24*time.Hour), userToken.ExpiresAt, time.Minute)
+
+	assert.Equal(t, user.Email, sentMail.To)
+	verificationToken := assertLink(t, sentMail.Body, accountServiceEnv.EmailVerificationUrl)
+	assert.Equal(t, hashOpaqueToken(verificationToken), userToken.TokenHash)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+	mailer.AssertExpectations(t)
+}
+
+// Verify Email
+
+func TestAccountService_VerifyEmail_WhenTokenIsInvalid_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, nil, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.VerifyEmailRequest{Token: "some-token"}
+
+	userTokenRepository.On(
+		"GetByHash",
+		new(model.UserToken),
+		hashOpaqueToken(request.Token),
+		model.EmailVerificationUserToken,
+	).Return(nil).Once()
+
+	// when
+	errCode := _uut.VerifyEmail(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "invalid token", errCode.Error.Error())
+
+	userTokenRepository.AssertExpectations(t)
+	userRepository.AssertNotCalled(t, "VerifyEmail")
+}
+
+func TestAccountService_VerifyEmail_WhenSuccessful_ShouldVerifyTheEmail(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	userTokenRepository := new(repository.UserTokenRepositoryMock)
+	_uut := NewAccountService(nil, nil, nil, nil, userRepository, userTokenRepository, accountServiceEnv)
+
+	request := requests.VerifyEmailRequest{Token: "some-token"}
+
+	userToken := &model.UserToken{ID: uuid.New(), UserID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	userTokenRepository.On(
+		"GetByHash",
+		new(model.UserToken),
+		hashOpaqueToken(request.Token),
+		model.EmailVerificationUserToken,
+	).Return(nil, userToken).Once()
+	userTokenRepository.On("Use", userToken.ID).Return(true, nil).Once()
+
+	userRepository.On("VerifyEmail", userToken.UserID).Return(nil).Once()
+
+	// when
+	errCode := _uut.VerifyEmail(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	userRepository.AssertExpectations(t)
+	userTokenRepository.AssertExpectations(t)
+}
+
+// assertLink asserts that the body contains a link to the base url, and returns its token
+func assertLink(t *testing.T, body string, baseUrl string) string {
+	var link *url.URL
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, baseUrl) {
+			link, _ = url.Parse(line)
+		}
+	}
+	if !assert.NotNil(t, link) {
+		return ""
+	}
+
+	token := link.Query().Get("token")
+	assert.NotEmpty(t, token)
+	return token
+}
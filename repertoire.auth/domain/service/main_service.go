@@ -8,79 +8,284 @@ import (
 	"repertoire/auth/data/logger"
 	"repertoire/auth/data/repository"
 	"repertoire/auth/data/service"
+	"repertoire/auth/internal"
 	"repertoire/auth/internal/wrapper"
 	"repertoire/auth/model"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 type MainService interface {
-	Refresh(request requests.RefreshRequest) (string, *wrapper.ErrorCode)
-	SignIn(request requests.SignInRequest) (string, *wrapper.ErrorCode)
+	GetRevokedTokens() ([]model.RevokedToken, *wrapper.ErrorCode)
+	RevokeAllSessions(userID uuid.UUID) *wrapper.ErrorCode
+	Refresh(request requests.RefreshRequest) (model.Tokens, *wrapper.ErrorCode)
+	SignIn(request requests.SignInRequest) (model.Tokens, *wrapper.ErrorCode)
+	SignOut(request requests.SignOutRequest) *wrapper.ErrorCode
+	SignOutAll(token string) *wrapper.ErrorCode
 }
 
 type mainService struct {
-	jwtService     service.JwtService
-	bCryptService  service.BCryptService
-	userRepository repository.UserRepository
-	logger         *logger.Logger
+	jwtService             service.JwtService
+	bCryptService          service.BCryptService
+	refreshTokenRepository repository.RefreshTokenRepository
+	revokedTokenRepository repository.RevokedTokenRepository
+	userRepository         repository.UserRepository
+	env                    internal.Env
+	logger                 *logger.Logger
 }
 
 func NewMainService(
 	jwtService service.JwtService,
 	bCryptService service.BCryptService,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	revokedTokenRepository repository.RevokedTokenRepository,
 	userRepository repository.UserRepository,
+	env internal.Env,
 	logger *logger.Logger,
 ) MainService {
 	return &mainService{
-		jwtService:     jwtService,
-		bCryptService:  bCryptService,
-		userRepository: userRepository,
-		logger:         logger,
+		jwtService:             jwtService,
+		bCryptService:          bCryptService,
+		refreshTokenRepository: refreshTokenRepository,
+		revokedTokenRepository: revokedTokenRepository,
+		userRepository:         userRepository,
+		env:                    env,
+		logger:                 logger,
 	}
 }
 
-func (m *mainService) Refresh(request requests.RefreshRequest) (string, *wrapper.ErrorCode) {
-	// validate token
-	userID, errCode := m.jwtService.Validate(request.Token)
-	if errCode != nil {
-		if errCode.Code == http.StatusUnauthorized {
-			m.logger.Warn("Invalid token", zap.Error(errCode.Error), zap.String("token", request.Token))
-			return "", wrapper.UnauthorizedError(errors.New("invalid token"))
-		}
-		return "", errCode
+func (m *mainService) GetRevokedTokens() ([]model.RevokedToken, *wrapper.ErrorCode) {
+	revokedTokens := make([]model.RevokedToken, 0)
+	err := m.revokedTokenRepository.GetAll(&revokedTokens)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	return revokedTokens, nil
+}
+
+func (m *mainService) RevokeAllSessions(userID uuid.UUID) *wrapper.ErrorCode {
+	var refreshTokens []model.RefreshToken
+	err := m.refreshTokenRepository.GetAllByUserID(&refreshTokens, userID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return m.revoke(refreshTokens)
+}
+
+func (m *mainService) Refresh(request requests.RefreshRequest) (model.Tokens, *wrapper.ErrorCode) {
+	// get refresh token
+	var refreshToken model.RefreshToken
+	err := m.refreshTokenRepository.GetByHash(&refreshToken, hashOpaqueToken(request.RefreshToken))
+	if err != nil {
+		return model.Tokens{}, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(refreshToken).IsZero() {
+		return model.Tokens{}, wrapper.UnauthorizedError(errors.New("invalid refresh token"))
+	}
+
+	// a refresh token that has already been rotated is only presented again when it got stolen,
+	// so the whole family is revoked, signing out both the legitimate user and the attacker
+	if refreshToken.ReplacedByID != nil {
+		return model.Tokens{}, m.revokeReusedFamily(refreshToken)
+	}
+	if refreshToken.RevokedAt != nil || time.Now().UTC().After(refreshToken.ExpiresAt) {
+		return model.Tokens{}, wrapper.UnauthorizedError(errors.New("invalid refresh token"))
 	}
 
 	// get user
 	var user model.User
-	err := m.userRepository.Get(&user, userID)
+	err = m.userRepository.Get(&user, refreshToken.UserID)
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return model.Tokens{}, wrapper.InternalServerError(err)
 	}
 	if reflect.ValueOf(user).IsZero() {
-		return "", wrapper.UnauthorizedError(errors.New("not authorized"))
+		return model.Tokens{}, wrapper.UnauthorizedError(errors.New("not authorized"))
+	}
+
+	tokens, newRefreshToken, errCode := m.createTokens(user, refreshToken.FamilyID)
+	if errCode != nil {
+		return model.Tokens{}, errCode
+	}
+
+	// rotate (when another request rotated it first, the token has been used twice)
+	rotated, err := m.refreshTokenRepository.Rotate(refreshToken.ID, &newRefreshToken)
+	if err != nil {
+		return model.Tokens{}, wrapper.InternalServerError(err)
+	}
+	if !rotated {
+		return model.Tokens{}, m.revokeReusedFamily(refreshToken)
 	}
 
-	return m.jwtService.CreateToken(user)
+	return tokens, nil
 }
 
-func (m *mainService) SignIn(request requests.SignInRequest) (string, *wrapper.ErrorCode) {
+func (m *mainService) SignIn(request requests.SignInRequest) (model.Tokens, *wrapper.ErrorCode) {
 	// get user
 	var user model.User
 	err := m.userRepository.GetByEmail(&user, strings.ToLower(request.Email))
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return model.Tokens{}, wrapper.InternalServerError(err)
 	}
 	if reflect.ValueOf(user).IsZero() {
-		return "", wrapper.UnauthorizedError(errors.New("invalid credentials"))
+		return model.Tokens{}, wrapper.UnauthorizedError(errors.New("invalid credentials"))
 	}
 
 	// check password
 	err = m.bCryptService.CompareHash(user.Password, request.Password)
 	if err != nil {
-		return "", wrapper.UnauthorizedError(errors.New("invalid credentials"))
+		return model.Tokens{}, wrapper.UnauthorizedError(errors.New("invalid credentials"))
+	}
+
+	// clean up the refresh tokens that cannot be used anymore
+	err = m.refreshTokenRepository.DeleteExpired()
+	if err != nil {
+		return model.Tokens{}, wrapper.InternalServerError(err)
+	}
+
+	tokens, refreshToken, errCode := m.createTokens(user, uuid.New())
+	if errCode != nil {
+		return model.Tokens{}, errCode
+	}
+	err = m.refreshTokenRepository.Create(&refreshToken)
+	if err != nil {
+		return model.Tokens{}, wrapper.InternalServerError(err)
+	}
+
+	return tokens, nil
+}
+
+func (m *mainService) SignOut(request requests.SignOutRequest) *wrapper.ErrorCode {
+	// get refresh token
+	var refreshToken model.RefreshToken
+	err := m.refreshTokenRepository.GetByHash(&refreshToken, hashOpaqueToken(request.RefreshToken))
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(refreshToken).IsZero() {
+		return wrapper.UnauthorizedError(errors.New("invalid refresh token"))
+	}
+
+	// revoke the session
+	var refreshTokens []model.RefreshToken
+	err = m.refreshTokenRepository.GetAllByFamilyID(&refreshTokens, refreshToken.FamilyID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return m.revoke(refreshTokens)
+}
+
+func (m *mainService) SignOutAll(token string) *wrapper.ErrorCode {
+	// validate token
+	userID, tokenID, errCode := m.jwtService.Validate(token)
+	if errCode != nil {
+		if errCode.Code == http.StatusUnauthorized {
+			m.logger.Warn("Invalid token", zap.Error(errCode.Error), zap.String("token", token))
+			return wrapper.UnauthorizedError(errors.New("invalid token"))
+		}
+		return errCode
+	}
+
+	var revokedToken model.RevokedToken
+	err := m.revokedTokenRepository.Get(&revokedToken, tokenID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if !reflect.ValueOf(revokedToken).IsZero() {
+		return wrapper.UnauthorizedError(errors.New("invalid token"))
+	}
+
+	return m.RevokeAllSessions(userID)
+}
+
+// createTokens creates the access and refresh tokens, but leaves storing the refresh token to the caller
+func (m *mainService) createTokens(
+	user model.User,
+	familyID uuid.UUID,
+) (model.Tokens, model.RefreshToken, *wrapper.ErrorCode) {
+	expiresIn, err := time.ParseDuration(m.env.RefreshTokenExpirationTime)
+	if err != nil {
+		return model.Tokens{}, model.RefreshToken{}, wrapper.InternalServerError(err)
+	}
+
+	accessTokenID := uuid.New()
+	token, errCode := m.jwtService.CreateToken(user, accessTokenID)
+	if errCode != nil {
+		return model.Tokens{}, model.RefreshToken{}, errCode
+	}
+
+	refreshToken, err := generateOpaqueToken()
+	if err != nil {
+		return model.Tokens{}, model.RefreshToken{}, wrapper.InternalServerError(err)
+	}
+
+	tokens := model.Tokens{Token: token, RefreshToken: refreshToken}
+	return tokens, model.RefreshToken{
+		ID:            uuid.New(),
+		UserID:        user.ID,
+		FamilyID:      familyID,
+		TokenHash:     hashOpaqueToken(refreshToken),
+		AccessTokenID: accessTokenID,
+		ExpiresAt:     time.Now().UTC().Add(expiresIn),
+	}, nil
+}
+
+func (m *mainService) revokeReusedFamily(refreshToken model.RefreshToken) *wrapper.ErrorCode {
+	m.logger.Warn(
+		"Refresh token reuse detected",
+		zap.String("userID", refreshToken.UserID.String()),
+		zap.String("familyID", refreshToken.FamilyID.String()),
+	)
+
+	var refreshTokens []model.RefreshToken
+	err := m.refreshTokenRepository.GetAllByFamilyID(&refreshTokens, refreshToken.FamilyID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	errCode := m.revoke(refreshTokens)
+	if errCode != nil {
+		return errCode
+	}
+
+	return wrapper.UnauthorizedError(errors.New("invalid refresh token"))
+}
+
+// revoke revokes the refresh tokens, and adds the access tokens issued alongside them,
+// that have not expired yet, to the revocation list
+func (m *mainService) revoke(refreshTokens []model.RefreshToken) *wrapper.ErrorCode {
+	expiresIn, err := time.ParseDuration(m.env.JwtExpirationTime)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	var ids []uuid.UUID
+	revokedTokens := make([]model.RevokedToken, 0)
+	for _, refreshToken := range refreshTokens {
+		ids = append(ids, refreshToken.ID)
+
+		accessTokenExpiresAt := refreshToken.CreatedAt.Add(expiresIn)
+		if accessTokenExpiresAt.After(time.Now().UTC()) {
+			revokedTokens = append(revokedTokens, model.RevokedToken{
+				ID:        refreshToken.AccessTokenID,
+				ExpiresAt: accessTokenExpiresAt,
+			})
+		}
+	}
+
+	err = m.refreshTokenRepository.Revoke(ids)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	err = m.revokedTokenRepository.CreateMany(&revokedTokens)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	err = m.revokedTokenRepository.DeleteExpired()
+	if err != nil {
+		return wrapper.InternalServerError(err)
 	}
 
-	return m.jwtService.CreateToken(user)
+	return nil
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"repertoire/auth/api/requests"
+	"repertoire/auth/internal/wrapper"
+	"repertoire/auth/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MainServiceMock struct {
+	mock.Mock
+}
+
+func (m *MainServiceMock) GetRevokedTokens() ([]model.RevokedToken, *wrapper.ErrorCode) {
+	args := m.Called()
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(1); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return args.Get(0).([]model.RevokedToken), errCode
+}
+
+func (m *MainServiceMock) RevokeAllSessions(userID uuid.UUID) *wrapper.ErrorCode {
+	args := m.Called(userID)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
+
+func (m *MainServiceMock) Refresh(request requests.RefreshRequest) (model.Tokens, *wrapper.ErrorCode) {
+	args := m.Called(request)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(1); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return args.Get(0).(model.Tokens), errCode
+}
+
+func (m *MainServiceMock) SignIn(request requests.SignInRequest) (model.Tokens, *wrapper.ErrorCode) {
+	args := m.Called(request)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(1); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return args.Get(0).(model.Tokens), errCode
+}
+
+func (m *MainServiceMock) SignOut(request requests.SignOutRequest) *wrapper.ErrorCode {
+	args := m.Called(request)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
+
+func (m *MainServiceMock) SignOutAll(token string) *wrapper.ErrorCode {
+	args := m.Called(token)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
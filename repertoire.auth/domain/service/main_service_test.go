@@ -7,160 +7,512 @@ import (
 	"repertoire/auth/data/logger"
 	"repertoire/auth/data/repository"
 	"repertoire/auth/data/service"
+	"repertoire/auth/internal"
 	"repertoire/auth/internal/wrapper"
 	"repertoire/auth/model"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
-func TestRefresh_WhenValidateJwtFails_ShouldReturnError(t *testing.T) {
+var mainServiceEnv = internal.Env{
+	JwtExpirationTime:          "1h",
+	RefreshTokenExpirationTime: "720h",
+}
+
+// Get Revoked Tokens
+
+func TestMainService_GetRevokedTokens_WhenGetAllFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
-	jwtService := new(service.JwtServiceMock)
-	_uut := NewMainService(jwtService, nil, nil, logger.NewLoggerMock())
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, nil, revokedTokenRepository, nil, mainServiceEnv, nil)
+
+	internalError := errors.New("something went wrong")
+	revokedTokenRepository.On("GetAll", mock.IsType(new([]model.RevokedToken))).
+		Return(internalError).
+		Once()
+
+	// when
+	revokedTokens, errCode := _uut.GetRevokedTokens()
 
-	request := requests.RefreshRequest{
-		Token: "This is a token",
+	// then
+	assert.Nil(t, revokedTokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	revokedTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_GetRevokedTokens_WhenSuccessful_ShouldReturnRevokedTokens(t *testing.T) {
+	// given
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, nil, revokedTokenRepository, nil, mainServiceEnv, nil)
+
+	expectedRevokedTokens := &[]model.RevokedToken{
+		{ID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Minute)},
+		{ID: uuid.New(), ExpiresAt: time.Now().UTC().Add(time.Hour)},
 	}
+	revokedTokenRepository.On("GetAll", mock.IsType(new([]model.RevokedToken))).
+		Return(nil, expectedRevokedTokens).
+		Once()
 
-	internalError := wrapper.InternalServerError(errors.New("internal error"))
-	jwtService.On("Validate", request.Token).Return(uuid.Nil, internalError).Once()
+	// when
+	revokedTokens, errCode := _uut.GetRevokedTokens()
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, *expectedRevokedTokens, revokedTokens)
+
+	revokedTokenRepository.AssertExpectations(t)
+}
+
+// Revoke All Sessions
+
+func TestMainService_RevokeAllSessions_WhenGetAllByUserIDFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, nil, mainServiceEnv, nil)
+
+	userID := uuid.New()
+	internalError := errors.New("internal error")
+	refreshTokenRepository.On("GetAllByUserID", new([]model.RefreshToken), userID).
+		Return(internalError).
+		Once()
 
 	// when
-	token, errCode := _uut.Refresh(request)
+	errCode := _uut.RevokeAllSessions(userID)
 
 	// then
-	assert.Empty(t, token)
 	assert.NotNil(t, errCode)
-	assert.Equal(t, internalError, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
 
-	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+// Refresh
+
+func TestMainService_Refresh_WhenGetRefreshTokenFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, nil, mainServiceEnv, nil)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+	internalError := errors.New("something went wrong")
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(internalError).
+		Once()
+
+	// when
+	tokens, errCode := _uut.Refresh(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_Refresh_WhenRefreshTokenIsInvalid_ShouldReturnUnauthorizedError(t *testing.T) {
+	tests := []struct {
+		name         string
+		refreshToken *model.RefreshToken
+	}{
+		{
+			"when refresh token is not found",
+			&model.RefreshToken{},
+		},
+		{
+			"when refresh token is revoked",
+			&model.RefreshToken{
+				ID:        uuid.New(),
+				ExpiresAt: time.Now().UTC().Add(time.Hour),
+				RevokedAt: &[]time.Time{time.Now().UTC()}[0],
+			},
+		},
+		{
+			"when refresh token is expired",
+			&model.RefreshToken{
+				ID:        uuid.New(),
+				ExpiresAt: time.Now().UTC().Add(-time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+			_uut := NewMainService(nil, nil, refreshTokenRepository, nil, nil, mainServiceEnv, nil)
+
+			request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+			refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+				Return(nil, tt.refreshToken).
+				Once()
+
+			// when
+			tokens, errCode := _uut.Refresh(request)
+
+			// then
+			assert.Empty(t, tokens)
+			assert.NotNil(t, errCode)
+			assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+			assert.Equal(t, "invalid refresh token", errCode.Error.Error())
+
+			refreshTokenRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMainService_Refresh_WhenRefreshTokenIsReused_ShouldRevokeFamilyAndReturnUnauthorizedError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(
+		nil,
+		nil,
+		refreshTokenRepository,
+		revokedTokenRepository,
+		nil,
+		mainServiceEnv,
+		logger.NewLoggerMock(),
+	)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+	replacedByID := uuid.New()
+	refreshToken := &model.RefreshToken{
+		ID:            uuid.New(),
+		UserID:        uuid.New(),
+		FamilyID:      uuid.New(),
+		AccessTokenID: uuid.New(),
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+		RevokedAt:     &[]time.Time{time.Now().UTC()}[0],
+		ReplacedByID:  &replacedByID,
+		CreatedAt:     time.Now().UTC().Add(-2 * time.Hour),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
+
+	family := &[]model.RefreshToken{
+		*refreshToken,
+		{
+			ID:            replacedByID,
+			FamilyID:      refreshToken.FamilyID,
+			AccessTokenID: uuid.New(),
+			CreatedAt:     time.Now().UTC().Add(-time.Minute),
+		},
+	}
+	refreshTokenRepository.On("GetAllByFamilyID", new([]model.RefreshToken), refreshToken.FamilyID).
+		Return(nil, family).
+		Once()
+
+	refreshTokenRepository.On("Revoke", []uuid.UUID{refreshToken.ID, replacedByID}).Return(nil).Once()
+	revokedTokenRepository.On("CreateMany", mock.IsType(new([]model.RevokedToken))).
+		Run(func(args mock.Arguments) {
+			revokedTokens := args.Get(0).(*[]model.RevokedToken)
+			// the access token of the reused refresh token has already expired
+			assert.Len(t, *revokedTokens, 1)
+			assert.Equal(t, (*family)[1].AccessTokenID, (*revokedTokens)[0].ID)
+			assert.WithinDuration(t, (*family)[1].CreatedAt.Add(time.Hour), (*revokedTokens)[0].ExpiresAt, time.Second)
+		}).
+		Return(nil).
+		Once()
+	revokedTokenRepository.On("DeleteExpired").Return(nil).Once()
+
+	// when
+	tokens, errCode := _uut.Refresh(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+	assert.Equal(t, "invalid refresh token", errCode.Error.Error())
+
+	refreshTokenRepository.AssertExpectations(t)
+	revokedTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_Refresh_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
+
+	internalError := errors.New("something went wrong")
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(internalError).Once()
+
+	// when
+	tokens, errCode := _uut.Refresh(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	refreshTokenRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestMainService_Refresh_WhenUserIsEmpty_ShouldReturnUnauthorizedError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
+
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(nil).Once()
+
+	// when
+	tokens, errCode := _uut.Refresh(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+	assert.Equal(t, "not authorized", errCode.Error.Error())
+
+	refreshTokenRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 }
 
-func TestRefresh_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestMainService_Refresh_WhenRotateFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, nil, userRepository, nil)
+	_uut := NewMainService(jwtService, nil, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
 
-	request := requests.RefreshRequest{
-		Token: "This is a token",
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
 	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
 
-	userID := uuid.New()
-	jwtService.On("Validate", request.Token).Return(userID, nil).Once()
+	user := &model.User{ID: refreshToken.UserID}
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(nil, user).Once()
+
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).Return("This is the new token", nil).Once()
 
 	internalError := errors.New("something went wrong")
-	userRepository.On("Get", new(model.User), userID).Return(internalError).Once()
+	refreshTokenRepository.On("Rotate", refreshToken.ID, mock.IsType(new(model.RefreshToken))).
+		Return(false, internalError).
+		Once()
 
 	// when
-	token, errCode := _uut.Refresh(request)
+	tokens, errCode := _uut.Refresh(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
 	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
 }
 
-func TestRefresh_WhenUserIsEmpty_ShouldReturnUnauthorizedError(t *testing.T) {
+func TestMainService_Refresh_WhenRefreshTokenIsRotatedConcurrently_ShouldRevokeFamilyAndReturnUnauthorizedError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, nil, userRepository, nil)
-
-	request := requests.RefreshRequest{
-		Token: "This is a token",
+	_uut := NewMainService(
+		jwtService,
+		nil,
+		refreshTokenRepository,
+		revokedTokenRepository,
+		userRepository,
+		mainServiceEnv,
+		logger.NewLoggerMock(),
+	)
+
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
+
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
 	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
 
-	userID := uuid.New()
-	jwtService.On("Validate", request.Token).Return(userID, nil).Once()
-	userRepository.On("Get", new(model.User), userID).Return(nil).Once()
+	user := &model.User{ID: refreshToken.UserID}
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(nil, user).Once()
+
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).Return("This is the new token", nil).Once()
+
+	refreshTokenRepository.On("Rotate", refreshToken.ID, mock.IsType(new(model.RefreshToken))).
+		Return(false, nil).
+		Once()
+
+	refreshTokenRepository.On("GetAllByFamilyID", new([]model.RefreshToken), refreshToken.FamilyID).
+		Return(nil, &[]model.RefreshToken{*refreshToken}).
+		Once()
+	refreshTokenRepository.On("Revoke", []uuid.UUID{refreshToken.ID}).Return(nil).Once()
+	revokedTokenRepository.On("CreateMany", mock.IsType(new([]model.RevokedToken))).Return(nil).Once()
+	revokedTokenRepository.On("DeleteExpired").Return(nil).Once()
 
 	// when
-	token, errCode := _uut.Refresh(request)
+	tokens, errCode := _uut.Refresh(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
-	assert.Equal(t, "not authorized", errCode.Error.Error())
+	assert.Equal(t, "invalid refresh token", errCode.Error.Error())
 
 	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	revokedTokenRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
 }
 
-func TestRefresh_WhenCreateTokenFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestMainService_Refresh_WhenCreateTokenFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, nil, userRepository, nil)
+	_uut := NewMainService(jwtService, nil, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
 
-	request := requests.RefreshRequest{
-		Token: "This is a token",
-	}
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
 
-	userID := uuid.New()
-	jwtService.On("Validate", request.Token).Return(userID, nil).Once()
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
 
-	user := &model.User{ID: userID}
-	userRepository.On("Get", new(model.User), userID).Return(nil, user).Once()
+	user := &model.User{ID: refreshToken.UserID}
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(nil, user).Once()
 
 	internalError := wrapper.InternalServerError(errors.New("something went wrong"))
-	jwtService.On("CreateToken", *user).Return("", internalError).Once()
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).Return("", internalError).Once()
 
 	// when
-	token, errCode := _uut.Refresh(request)
+	tokens, errCode := _uut.Refresh(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, internalError, errCode)
 
 	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertNotCalled(t, "Rotate")
 	userRepository.AssertExpectations(t)
 }
 
-func TestRefresh_WhenSuccessful_ShouldReturnNewToken(t *testing.T) {
+func TestMainService_Refresh_WhenSuccessful_ShouldRotateRefreshTokenAndReturnNewTokens(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, nil, userRepository, nil)
+	_uut := NewMainService(jwtService, nil, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
 
 	// given - mocking
-	request := requests.RefreshRequest{
-		Token: "This is a token",
-	}
+	request := requests.RefreshRequest{RefreshToken: "This is a refresh token"}
 
-	userID := uuid.New()
-	jwtService.On("Validate", request.Token).Return(userID, nil).Once()
+	refreshToken := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
 
-	user := &model.User{ID: userID}
-	userRepository.On("Get", new(model.User), userID).Return(nil, user).Once()
+	user := &model.User{ID: refreshToken.UserID}
+	userRepository.On("Get", new(model.User), refreshToken.UserID).Return(nil, user).Once()
 
+	var accessTokenID uuid.UUID
 	expectedToken := "This is the new token"
-	jwtService.On("CreateToken", *user).Return(expectedToken, nil).Once()
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).
+		Run(func(args mock.Arguments) {
+			accessTokenID = args.Get(1).(uuid.UUID)
+		}).
+		Return(expectedToken, nil).
+		Once()
+
+	var newRefreshToken model.RefreshToken
+	refreshTokenRepository.On("Rotate", refreshToken.ID, mock.IsType(new(model.RefreshToken))).
+		Run(func(args mock.Arguments) {
+			newRefreshToken = *args.Get(1).(*model.RefreshToken)
+		}).
+		Return(true, nil).
+		Once()
 
 	// when
-	token, errCode := _uut.Refresh(request)
+	tokens, errCode := _uut.Refresh(request)
 
 	// then
 	assert.Nil(t, errCode)
-	assert.Equal(t, expectedToken, token)
+	assert.Equal(t, expectedToken, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.NotEqual(t, request.RefreshToken, tokens.RefreshToken)
+
+	assert.NotEmpty(t, newRefreshToken.ID)
+	assert.Equal(t, user.ID, newRefreshToken.UserID)
+	assert.Equal(t, refreshToken.FamilyID, newRefreshToken.FamilyID)
+	assert.Equal(t, hashOpaqueToken(tokens.RefreshToken), newRefreshToken.TokenHash)
+	assert.Equal(t, accessTokenID, newRefreshToken.AccessTokenID)
+	assert.WithinDuration(t, time.Now().UTC().Add(720*time.Hour), newRefreshToken.ExpiresAt, time.Minute)
 
 	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
 }
 
+// Sign In
+
 func TestMainService_SignIn_WhenGetUserByEmailFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(nil, nil, userRepository, nil)
+	_uut := NewMainService(nil, nil, nil, nil, userRepository, mainServiceEnv, nil)
 
 	request := requests.SignInRequest{
 		Email:    "Samuel@yahoo.com",
@@ -173,10 +525,10 @@ func TestMainService_SignIn_WhenGetUserByEmailFails_ShouldReturnInternalServerEr
 		Once()
 
 	// when
-	token, errCode := _uut.SignIn(request)
+	tokens, errCode := _uut.SignIn(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
@@ -187,7 +539,7 @@ func TestMainService_SignIn_WhenGetUserByEmailFails_ShouldReturnInternalServerEr
 func TestMainService_SignIn_WhenUserIsEmpty_ShouldReturnUnauthorizedError(t *testing.T) {
 	// given
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(nil, nil, userRepository, nil)
+	_uut := NewMainService(nil, nil, nil, nil, userRepository, mainServiceEnv, nil)
 
 	request := requests.SignInRequest{
 		Email:    "Samuel@yahoo.com",
@@ -199,10 +551,10 @@ func TestMainService_SignIn_WhenUserIsEmpty_ShouldReturnUnauthorizedError(t *tes
 		Once()
 
 	// when
-	token, errCode := _uut.SignIn(request)
+	tokens, errCode := _uut.SignIn(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
 	assert.Equal(t, "invalid credentials", errCode.Error.Error())
@@ -214,7 +566,7 @@ func TestMainService_SignIn_WhenPasswordsAreNotTheSame_ShouldReturnUnauthorizedE
 	// given
 	bCryptService := new(service.BCryptServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(nil, bCryptService, userRepository, nil)
+	_uut := NewMainService(nil, bCryptService, nil, nil, userRepository, mainServiceEnv, nil)
 
 	request := requests.SignInRequest{
 		Email:    "Samuel@yahoo.com",
@@ -233,10 +585,10 @@ func TestMainService_SignIn_WhenPasswordsAreNotTheSame_ShouldReturnUnauthorizedE
 	bCryptService.On("CompareHash", user.Password, request.Password).Return(errors.New("")).Once()
 
 	// when
-	token, errCode := _uut.SignIn(request)
+	tokens, errCode := _uut.SignIn(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
 	assert.Equal(t, "invalid credentials", errCode.Error.Error())
@@ -245,12 +597,53 @@ func TestMainService_SignIn_WhenPasswordsAreNotTheSame_ShouldReturnUnauthorizedE
 	bCryptService.AssertExpectations(t)
 }
 
+func TestMainService_SignIn_WhenDeleteExpiredRefreshTokensFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	bCryptService := new(service.BCryptServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewMainService(nil, bCryptService, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
+
+	request := requests.SignInRequest{
+		Email:    "Samuel@yahoo.com",
+		Password: "Password123",
+	}
+
+	user := &model.User{
+		ID:       uuid.New(),
+		Email:    "samuel@yahoo.com",
+		Password: "hashedPassword",
+	}
+	userRepository.On("GetByEmail", new(model.User), strings.ToLower(request.Email)).
+		Return(nil, user).
+		Once()
+
+	bCryptService.On("CompareHash", user.Password, request.Password).Return(nil).Once()
+
+	internalError := errors.New("something went wrong")
+	refreshTokenRepository.On("DeleteExpired").Return(internalError).Once()
+
+	// when
+	tokens, errCode := _uut.SignIn(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	bCryptService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+}
+
 func TestMainService_SignIn_WhenCreateTokenFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	bCryptService := new(service.BCryptServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, bCryptService, userRepository, nil)
+	_uut := NewMainService(jwtService, bCryptService, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
 
 	request := requests.SignInRequest{
 		Email:    "Samuel@yahoo.com",
@@ -267,29 +660,76 @@ func TestMainService_SignIn_WhenCreateTokenFails_ShouldReturnInternalServerError
 		Once()
 
 	bCryptService.On("CompareHash", user.Password, request.Password).Return(nil).Once()
+	refreshTokenRepository.On("DeleteExpired").Return(nil).Once()
 
 	internalError := wrapper.InternalServerError(errors.New("something went wrong"))
-	jwtService.On("CreateToken", *user).Return("", internalError).Once()
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).Return("", internalError).Once()
 
 	// when
-	token, errCode := _uut.SignIn(request)
+	tokens, errCode := _uut.SignIn(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, internalError, errCode)
 
 	userRepository.AssertExpectations(t)
 	bCryptService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+func TestMainService_SignIn_WhenCreateRefreshTokenFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	bCryptService := new(service.BCryptServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := NewMainService(jwtService, bCryptService, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
+
+	request := requests.SignInRequest{
+		Email:    "Samuel@yahoo.com",
+		Password: "Password123",
+	}
+
+	user := &model.User{
+		ID:       uuid.New(),
+		Email:    "samuel@yahoo.com",
+		Password: "hashedPassword",
+	}
+	userRepository.On("GetByEmail", new(model.User), strings.ToLower(request.Email)).
+		Return(nil, user).
+		Once()
+
+	bCryptService.On("CompareHash", user.Password, request.Password).Return(nil).Once()
+	refreshTokenRepository.On("DeleteExpired").Return(nil).Once()
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).Return("This is a token", nil).Once()
+
+	internalError := errors.New("something went wrong")
+	refreshTokenRepository.On("Create", mock.IsType(new(model.RefreshToken))).Return(internalError).Once()
+
+	// when
+	tokens, errCode := _uut.SignIn(request)
+
+	// then
+	assert.Empty(t, tokens)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	bCryptService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 }
 
-func TestMainService_SignIn_WhenSuccessful_ShouldReturnNewToken(t *testing.T) {
+func TestMainService_SignIn_WhenSuccessful_ShouldReturnNewTokens(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	bCryptService := new(service.BCryptServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := NewMainService(jwtService, bCryptService, userRepository, nil)
+	_uut := NewMainService(jwtService, bCryptService, refreshTokenRepository, nil, userRepository, mainServiceEnv, nil)
 
 	request := requests.SignInRequest{
 		Email:    "Samuel@yahoo.com",
@@ -306,18 +746,253 @@ func TestMainService_SignIn_WhenSuccessful_ShouldReturnNewToken(t *testing.T) {
 		Once()
 
 	bCryptService.On("CompareHash", user.Password, request.Password).Return(nil).Once()
+	refreshTokenRepository.On("DeleteExpired").Return(nil).Once()
 
+	var accessTokenID uuid.UUID
 	expectedToken := "This is the generated token"
-	jwtService.On("CreateToken", *user).Return(expectedToken, nil).Once()
+	jwtService.On("CreateToken", *user, mock.IsType(uuid.UUID{})).
+		Run(func(args mock.Arguments) {
+			accessTokenID = args.Get(1).(uuid.UUID)
+		}).
+		Return(expectedToken, nil).
+		Once()
+
+	var refreshToken model.RefreshToken
+	refreshTokenRepository.On("Create", mock.IsType(new(model.RefreshToken))).
+		Run(func(args mock.Arguments) {
+			refreshToken = *args.Get(0).(*model.RefreshToken)
+		}).
+		Return(nil).
+		Once()
 
 	// when
-	token, errCode := _uut.SignIn(request)
+	tokens, errCode := _uut.SignIn(request)
 
 	// then
 	assert.Nil(t, errCode)
-	assert.Equal(t, expectedToken, token)
+	assert.Equal(t, expectedToken, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	assert.NotEmpty(t, refreshToken.ID)
+	assert.NotEmpty(t, refreshToken.FamilyID)
+	assert.Equal(t, user.ID, refreshToken.UserID)
+	assert.Equal(t, hashOpaqueToken(tokens.RefreshToken), refreshToken.TokenHash)
+	assert.Equal(t, accessTokenID, refreshToken.AccessTokenID)
+	assert.Nil(t, refreshToken.RevokedAt)
+	assert.Nil(t, refreshToken.ReplacedByID)
 
 	userRepository.AssertExpectations(t)
 	bCryptService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+// Sign Out
+
+func TestMainService_SignOut_WhenRefreshTokenIsNotFound_ShouldReturnUnauthorizedError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, nil, mainServiceEnv, nil)
+
+	request := requests.SignOutRequest{RefreshToken: "This is a refresh token"}
+
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.SignOut(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+	assert.Equal(t, "invalid refresh token", errCode.Error.Error())
+
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_SignOut_WhenRevokeFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, nil, nil, mainServiceEnv, nil)
+
+	request := requests.SignOutRequest{RefreshToken: "This is a refresh token"}
+
+	refreshToken := &model.RefreshToken{ID: uuid.New(), FamilyID: uuid.New()}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
+	refreshTokenRepository.On("GetAllByFamilyID", new([]model.RefreshToken), refreshToken.FamilyID).
+		Return(nil, &[]model.RefreshToken{*refreshToken}).
+		Once()
+
+	internalError := errors.New("something went wrong")
+	refreshTokenRepository.On("Revoke", []uuid.UUID{refreshToken.ID}).Return(internalError).Once()
+
+	// when
+	errCode := _uut.SignOut(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_SignOut_WhenSuccessful_ShouldRevokeTheSession(t *testing.T) {
+	// given
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(nil, nil, refreshTokenRepository, revokedTokenRepository, nil, mainServiceEnv, nil)
+
+	request := requests.SignOutRequest{RefreshToken: "This is a refresh token"}
+
+	refreshToken := &model.RefreshToken{
+		ID:            uuid.New(),
+		FamilyID:      uuid.New(),
+		AccessTokenID: uuid.New(),
+		CreatedAt:     time.Now().UTC().Add(-10 * time.Minute),
+	}
+	refreshTokenRepository.On("GetByHash", new(model.RefreshToken), hashOpaqueToken(request.RefreshToken)).
+		Return(nil, refreshToken).
+		Once()
+	refreshTokenRepository.On("GetAllByFamilyID", new([]model.RefreshToken), refreshToken.FamilyID).
+		Return(nil, &[]model.RefreshToken{*refreshToken}).
+		Once()
+	refreshTokenRepository.On("Revoke", []uuid.UUID{refreshToken.ID}).Return(nil).Once()
+
+	revokedTokenRepository.On("CreateMany", mock.IsType(new([]model.RevokedToken))).
+		Run(func(args mock.Arguments) {
+			revokedTokens := args.Get(0).(*[]model.RevokedToken)
+			assert.Len(t, *revokedTokens, 1)
+			assert.Equal(t, refreshToken.AccessTokenID, (*revokedTokens)[0].ID)
+			assert.WithinDuration(t, refreshToken.CreatedAt.Add(time.Hour), (*revokedTokens)[0].ExpiresAt, time.Second)
+		}).
+		Return(nil).
+		Once()
+	revokedTokenRepository.On("DeleteExpired").Return(nil).Once()
+
+	// when
+	errCode := _uut.SignOut(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	refreshTokenRepository.AssertExpectations(t)
+	revokedTokenRepository.AssertExpectations(t)
+}
+
+// Sign Out All
+
+func TestMainService_SignOutAll_WhenValidateFails_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name          string
+		errCode       *wrapper.ErrorCode
+		expectedError *wrapper.ErrorCode
+	}{
+		{
+			"when token is invalid",
+			wrapper.UnauthorizedError(errors.New("token is expired")),
+			wrapper.UnauthorizedError(errors.New("invalid token")),
+		},
+		{
+			"when validation fails internally",
+			wrapper.InternalServerError(errors.New("internal error")),
+			wrapper.InternalServerError(errors.New("internal error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			jwtService := new(service.JwtServiceMock)
+			_uut := NewMainService(jwtService, nil, nil, nil, nil, mainServiceEnv, logger.NewLoggerMock())
+
+			token := "This is a token"
+			jwtService.On("Validate", token).Return(uuid.Nil, uuid.Nil, tt.errCode).Once()
+
+			// when
+			errCode := _uut.SignOutAll(token)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Equal(t, tt.expectedError, errCode)
+
+			jwtService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMainService_SignOutAll_WhenTokenIsRevoked_ShouldReturnUnauthorizedError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(jwtService, nil, nil, revokedTokenRepository, nil, mainServiceEnv, nil)
+
+	token := "This is a token"
+	userID := uuid.New()
+	tokenID := uuid.New()
+	jwtService.On("Validate", token).Return(userID, tokenID, nil).Once()
+
+	revokedToken := &model.RevokedToken{ID: tokenID, ExpiresAt: time.Now().UTC().Add(time.Hour)}
+	revokedTokenRepository.On("Get", new(model.RevokedToken), tokenID).Return(nil, revokedToken).Once()
+
+	// when
+	errCode := _uut.SignOutAll(token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusUnauthorized, errCode.Code)
+	assert.Equal(t, "invalid token", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	revokedTokenRepository.AssertExpectations(t)
+}
+
+func TestMainService_SignOutAll_WhenSuccessful_ShouldRevokeAllSessions(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	refreshTokenRepository := new(repository.RefreshTokenRepositoryMock)
+	revokedTokenRepository := new(repository.RevokedTokenRepositoryMock)
+	_uut := NewMainService(jwtService, nil, refreshTokenRepository, revokedTokenRepository, nil, mainServiceEnv, nil)
+
+	token := "This is a token"
+	userID := uuid.New()
+	tokenID := uuid.New()
+	jwtService.On("Validate", token).Return(userID, tokenID, nil).Once()
+
+	revokedTokenRepository.On("Get", new(model.RevokedToken), tokenID).Return(nil).Once()
+
+	refreshTokens := &[]model.RefreshToken{
+		{ID: uuid.New(), AccessTokenID: tokenID, CreatedAt: time.Now().UTC().Add(-time.Minute)},
+		{ID: uuid.New(), AccessTokenID: uuid.New(), CreatedAt: time.Now().UTC().Add(-30 * time.Minute)},
+		{ID: uuid.New(), AccessTokenID: uuid.New(), CreatedAt: time.Now().UTC().Add(-48 * time.Hour)},
+	}
+	refreshTokenRepository.On("GetAllByUserID", new([]model.RefreshToken), userID).
+		Return(nil, refreshTokens).
+		Once()
+	refreshTokenRepository.On("Revoke", []uuid.UUID{(*refreshTokens)[0].ID, (*refreshTokens)[1].ID, (*refreshTokens)[2].ID}).
+		Return(nil).
+		Once()
+
+	revokedTokenRepository.On("CreateMany", mock.IsType(new([]model.RevokedToken))).
+		Run(func(args mock.Arguments) {
+			revokedTokens := args.Get(0).(*[]model.RevokedToken)
+			assert.Len(t, *revokedTokens, 2)
+			assert.Equal(t, (*refreshTokens)[0].AccessTokenID, (*revokedTokens)[0].ID)
+			assert.Equal(t, (*refreshTokens)[1].AccessTokenID, (*revokedTokens)[1].ID)
+		}).
+		Return(nil).
+		Once()
+	revokedTokenRepository.On("DeleteExpired").Return(nil).Once()
+
+	// when
+	errCode := _uut.SignOutAll(token)
+
+	// then
+	assert.Nil(t, errCode)
+
 	jwtService.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	revokedTokenRepository.AssertExpectations(t)
 }
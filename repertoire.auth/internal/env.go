@@ -30,6 +30,21 @@ type Env struct {
 	JwtAudience       string
 	JwtExpirationTime string
 
+	RefreshTokenExpirationTime string
+
+	PasswordResetTokenExpirationTime     string
+	PasswordResetUrl                     string
+	EmailVerificationTokenExpirationTime string
+	EmailVerificationUrl                 string
+
+	Mailer        string
+	MailFrom      string
+	MailDirectory string
+	SmtpHost      string
+	SmtpPort      string
+	SmtpUsername  string
+	SmtpPassword  string
+
 	StorageJwtSecretKey      string
 	StorageJwtAudience       string
 	StorageJwtExpirationTime string
@@ -69,6 +84,21 @@ func NewEnv() Env {
 		JwtIssuer:         os.Getenv("JWT_ISSUER"),
 		JwtExpirationTime: os.Getenv("JWT_EXPIRATION_TIME"),
 
+		RefreshTokenExpirationTime: os.Getenv("REFRESH_TOKEN_EXPIRATION_TIME"),
+
+		PasswordResetTokenExpirationTime:     os.Getenv("PASSWORD_RESET_TOKEN_EXPIRATION_TIME"),
+		PasswordResetUrl:                     os.Getenv("PASSWORD_RESET_URL"),
+		EmailVerificationTokenExpirationTime: os.Getenv("EMAIL_VERIFICATION_TOKEN_EXPIRATION_TIME"),
+		EmailVerificationUrl:                 os.Getenv("EMAIL_VERIFICATION_URL"),
+
+		Mailer:        os.Getenv("MAILER"),
+		MailFrom:      os.Getenv("MAIL_FROM"),
+		MailDirectory: os.Getenv("MAIL_DIRECTORY"),
+		SmtpHost:      os.Getenv("SMTP_HOST"),
+		SmtpPort:      os.Getenv("SMTP_PORT"),
+		SmtpUsername:  os.Getenv("SMTP_USERNAME"),
+		SmtpPassword:  os.Getenv("SMTP_PASSWORD"),
+
 		StorageJwtSecretKey:      os.Getenv("STORAGE_JWT_SECRET_KEY"),
 		StorageJwtAudience:       os.Getenv("STORAGE_JWT_AUDIENCE"),
 		StorageJwtExpirationTime: os.Getenv("STORAGE_JWT_EXPIRATION_TIME"),
@@ -83,3 +113,6 @@ func NewEnv() Env {
 }
 
 var DevelopmentEnvironment = "development"
+
+var SmtpMailer = "smtp"
+var FileMailer = "file"
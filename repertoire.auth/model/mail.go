@@ -0,0 +1,7 @@
+package model
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshToken struct {
+	ID            uuid.UUID `gorm:"primaryKey; type:uuid; <-:create"`
+	UserID        uuid.UUID `gorm:"not null"`
+	FamilyID      uuid.UUID `gorm:"not null"`
+	TokenHash     string    `gorm:"size:64; unique; not null"`
+	AccessTokenID uuid.UUID `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	RevokedAt     *time.Time
+	ReplacedByID  *uuid.UUID
+	CreatedAt     time.Time `gorm:"default:current_timestamp; not null; <-:create"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevokedToken struct {
+	ID        uuid.UUID `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	ExpiresAt time.Time `gorm:"not null" json:"expiresAt"`
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"-"`
+}
@@ -0,0 +1,6 @@
+package model
+
+type Tokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserTokenType string
+
+const (
+	PasswordResetUserToken     UserTokenType = "password_reset"
+	EmailVerificationUserToken UserTokenType = "email_verification"
+)
+
+// UserToken is a single-use token, sent to the user by email, to prove the ownership of the email address
+type UserToken struct {
+	ID        uuid.UUID     `gorm:"primaryKey; type:uuid; <-:create"`
+	UserID    uuid.UUID     `gorm:"not null"`
+	Type      UserTokenType `gorm:"size:30; not null"`
+	TokenHash string        `gorm:"size:64; unique; not null"`
+	ExpiresAt time.Time     `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create"`
+}
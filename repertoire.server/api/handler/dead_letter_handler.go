@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/server"
+	"repertoire/server/api/validation"
+	"repertoire/server/domain/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DeadLetterHandler struct {
+	service service.DeadLetterService
+	server.BaseHandler
+}
+
+func NewDeadLetterHandler(
+	service service.DeadLetterService,
+	validator *validation.Validator,
+) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		service: service,
+		BaseHandler: server.BaseHandler{
+			Validator: validator,
+		},
+	}
+}
+
+func (d DeadLetterHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	deadLetterMessage, errorCode := d.service.Get(id)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetterMessage)
+}
+
+func (d DeadLetterHandler) GetAll(c *gin.Context) {
+	var request requests.GetDeadLetterMessagesRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := d.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	result, errorCode := d.service.GetAll(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (d DeadLetterHandler) GetMetrics(c *gin.Context) {
+	metrics, errorCode := d.service.GetMetrics()
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+func (d DeadLetterHandler) Replay(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := d.service.Replay(id)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	d.SendMessage(c, "message has been replayed successfully")
+}
+
+func (d DeadLetterHandler) Discard(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := d.service.Discard(id)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	d.SendMessage(c, "message has been discarded successfully")
+}
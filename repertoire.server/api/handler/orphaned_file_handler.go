@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/server"
+	"repertoire/server/api/validation"
+	"repertoire/server/domain/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrphanedFileHandler struct {
+	service service.OrphanedFileService
+	server.BaseHandler
+}
+
+func NewOrphanedFileHandler(
+	service service.OrphanedFileService,
+	validator *validation.Validator,
+) *OrphanedFileHandler {
+	return &OrphanedFileHandler{
+		service: service,
+		BaseHandler: server.BaseHandler{
+			Validator: validator,
+		},
+	}
+}
+
+func (o OrphanedFileHandler) Collect(c *gin.Context) {
+	var request requests.CollectOrphanedFilesRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := o.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	var userID *uuid.UUID
+	if request.UserID != nil {
+		id := uuid.MustParse(*request.UserID)
+		userID = &id
+	}
+
+	result, errorCode := o.service.Collect(userID, request.DryRun)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
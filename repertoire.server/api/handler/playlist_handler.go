@@ -197,6 +197,40 @@ func (p PlaylistHandler) Update(c *gin.Context) {
 	p.SendMessage(c, "playlist has been updated successfully")
 }
 
+func (p PlaylistHandler) UpdateSmartRules(c *gin.Context) {
+	var request requests.UpdatePlaylistSmartRulesRequest
+	errorCode := p.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	errorCode = p.service.UpdateSmartRules(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	p.SendMessage(c, "playlist smart rules have been updated successfully")
+}
+
+func (p PlaylistHandler) Freeze(c *gin.Context) {
+	var request requests.FreezePlaylistRequest
+	errorCode := p.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	errorCode = p.service.Freeze(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	p.SendMessage(c, "playlist has been frozen successfully")
+}
+
 func (p PlaylistHandler) BulkDelete(c *gin.Context) {
 	var request requests.BulkDeletePlaylistsRequest
 	errorCode := p.BindAndValidate(c, &request)
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/server"
+	"repertoire/server/api/validation"
+	"repertoire/server/domain/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PracticeSessionHandler struct {
+	service service.PracticeSessionService
+	server.BaseHandler
+}
+
+func NewPracticeSessionHandler(
+	service service.PracticeSessionService,
+	validator *validation.Validator,
+) *PracticeSessionHandler {
+	return &PracticeSessionHandler{
+		service: service,
+		BaseHandler: server.BaseHandler{
+			Validator: validator,
+		},
+	}
+}
+
+func (p PracticeSessionHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	session, errorCode := p.service.Get(id)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+func (p PracticeSessionHandler) GetAll(c *gin.Context) {
+	var request requests.GetPracticeSessionsRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := p.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	token := p.GetTokenFromContext(c)
+
+	result, errorCode := p.service.GetAll(request, token)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (p PracticeSessionHandler) Start(c *gin.Context) {
+	var request requests.StartPracticeSessionRequest
+	errorCode := p.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	token := p.GetTokenFromContext(c)
+
+	id, errorCode := p.service.Start(request, token)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id": id,
+	})
+}
+
+func (p PracticeSessionHandler) AddSong(c *gin.Context) {
+	var request requests.AddSongToPracticeSessionRequest
+	errorCode := p.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	errorCode = p.service.AddSong(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	p.SendMessage(c, "song has been added to practice session successfully!")
+}
+
+func (p PracticeSessionHandler) Finish(c *gin.Context) {
+	var request requests.FinishPracticeSessionRequest
+	errorCode := p.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	errorCode = p.service.Finish(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	p.SendMessage(c, "practice session has been finished successfully!")
+}
+
+func (p PracticeSessionHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := p.service.Delete(id)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	p.SendMessage(c, "practice session has been deleted successfully!")
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/server"
+	"repertoire/server/api/validation"
+	"repertoire/server/domain/service"
+	"repertoire/server/internal/enums"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ProgressHandler struct {
+	service service.ProgressService
+	server.BaseHandler
+}
+
+func NewProgressHandler(
+	service service.ProgressService,
+	validator *validation.Validator,
+) *ProgressHandler {
+	return &ProgressHandler{
+		service: service,
+		BaseHandler: server.BaseHandler{
+			Validator: validator,
+		},
+	}
+}
+
+func (p ProgressHandler) GetTimeline(c *gin.Context) {
+	p.getTimeline(c, nil)
+}
+
+func (p ProgressHandler) GetSectionTimeline(c *gin.Context) {
+	p.getTimeline(c, &[]enums.TimelineScope{enums.SectionTimeline}[0])
+}
+
+func (p ProgressHandler) GetSongTimeline(c *gin.Context) {
+	p.getTimeline(c, &[]enums.TimelineScope{enums.SongTimeline}[0])
+}
+
+func (p ProgressHandler) GetAlbumTimeline(c *gin.Context) {
+	p.getTimeline(c, &[]enums.TimelineScope{enums.AlbumTimeline}[0])
+}
+
+func (p ProgressHandler) GetArtistTimeline(c *gin.Context) {
+	p.getTimeline(c, &[]enums.TimelineScope{enums.ArtistTimeline}[0])
+}
+
+func (p ProgressHandler) GetPlaylistTimeline(c *gin.Context) {
+	p.getTimeline(c, &[]enums.TimelineScope{enums.PlaylistTimeline}[0])
+}
+
+func (p ProgressHandler) getTimeline(c *gin.Context, scope *enums.TimelineScope) {
+	var request requests.GetProgressTimelineRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	if scope != nil {
+		request.Scope = scope
+		request.ScopeID, err = uuid.Parse(c.Param("id"))
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	errorCode := p.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	token := p.GetTokenFromContext(c)
+
+	timeline, errorCode := p.service.GetTimeline(request, token)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
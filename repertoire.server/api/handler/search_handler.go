@@ -8,6 +8,7 @@ import (
 	"repertoire/server/domain/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type SearchHandler struct {
@@ -61,3 +62,32 @@ func (s SearchHandler) MeiliWebhook(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+func (s SearchHandler) Reconcile(c *gin.Context) {
+	var request requests.SearchReconcileRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := s.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	var userID *uuid.UUID
+	if request.UserID != nil {
+		id := uuid.MustParse(*request.UserID)
+		userID = &id
+	}
+
+	result, errorCode := s.service.Reconcile(userID)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -94,6 +94,31 @@ func (s SongHandler) GetFiltersMetadata(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+func (s SongHandler) GetPracticeQueue(c *gin.Context) {
+	var request requests.GetSongsPracticeQueueRequest
+	err := c.BindQuery(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	errorCode := s.Validator.Validate(&request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	token := s.GetTokenFromContext(c)
+
+	result, errorCode := s.service.GetPracticeQueue(request, token)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 func (s SongHandler) Create(c *gin.Context) {
 	var request requests.CreateSongRequest
 	errCode := s.BindAndValidate(c, &request)
@@ -166,6 +191,23 @@ func (s SongHandler) AddPartialRehearsal(c *gin.Context) {
 	s.SendMessage(c, "partial rehearsal has been added successfully!")
 }
 
+func (s SongHandler) UndoRehearsal(c *gin.Context) {
+	var request requests.UndoSongRehearsalRequest
+	errorCode := s.BindAndValidate(c, &request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	errorCode = s.service.UndoRehearsal(request)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	s.SendMessage(c, "rehearsal has been undone successfully!")
+}
+
 func (s SongHandler) Update(c *gin.Context) {
 	var request requests.UpdateSongRequest
 	errorCode := s.BindAndValidate(c, &request)
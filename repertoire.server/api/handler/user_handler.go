@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"io"
 	"net/http"
+	"os"
 	"repertoire/server/api/requests"
 	"repertoire/server/api/server"
 	"repertoire/server/api/validation"
@@ -60,6 +62,18 @@ func (u UserHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+func (u UserHandler) GetStorageUsage(c *gin.Context) {
+	token := u.GetTokenFromContext(c)
+
+	usage, errorCode := u.service.GetStorageUsage(token)
+	if errorCode != nil {
+		_ = c.AbortWithError(errorCode.Code, errorCode.Error)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
 func (u UserHandler) SignUp(c *gin.Context) {
 	var request requests.SignUpRequest
 	errCode := u.BindAndValidate(c, &request)
@@ -68,13 +82,13 @@ func (u UserHandler) SignUp(c *gin.Context) {
 		return
 	}
 
-	token, errCode := u.service.SignUp(request)
+	tokens, errCode := u.service.SignUp(request)
 	if errCode != nil {
 		_ = c.AbortWithError(errCode.Code, errCode.Error)
 		return
 	}
 
-	c.JSON(http.StatusOK, token)
+	c.JSON(http.StatusOK, tokens)
 }
 
 func (u UserHandler) Update(c *gin.Context) {
@@ -96,6 +110,25 @@ func (u UserHandler) Update(c *gin.Context) {
 	u.SendMessage(c, "user has been updated successfully!")
 }
 
+func (u UserHandler) UpdateScoringStrategy(c *gin.Context) {
+	var request requests.UpdateUserScoringStrategyRequest
+	errCode := u.BindAndValidate(c, &request)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	token := u.GetTokenFromContext(c)
+
+	errCode = u.service.UpdateScoringStrategy(request, token)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	u.SendMessage(c, "scoring strategy has been updated successfully!")
+}
+
 func (u UserHandler) Delete(c *gin.Context) {
 	token := u.GetTokenFromContext(c)
 
@@ -108,6 +141,69 @@ func (u UserHandler) Delete(c *gin.Context) {
 	u.SendMessage(c, "user has been deleted successfully!")
 }
 
+func (u UserHandler) ExportData(c *gin.Context) {
+	token := u.GetTokenFromContext(c)
+
+	// the archive is built aside, so that a failure midway can still be sent as an error response
+	archive, err := os.CreateTemp("", "repertoire-export-*.zip")
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+
+	isQueued, errCode := u.service.ExportData(token, func() io.Writer {
+		return archive
+	})
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	if isQueued {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message": "export has been queued, the download link will be sent when it is ready",
+		})
+		return
+	}
+
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	_, err = archive.Seek(0, io.SeekStart)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, size, "application/zip", archive, map[string]string{
+		"Content-Disposition": "attachment; filename=repertoire-export.zip",
+	})
+}
+
+func (u UserHandler) ImportData(c *gin.Context) {
+	file, err := c.FormFile("archive")
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	token := u.GetTokenFromContext(c)
+
+	errCode := u.service.ImportData(file, token)
+	if errCode != nil {
+		_ = c.AbortWithError(errCode.Code, errCode.Error)
+		return
+	}
+
+	u.SendMessage(c, "data has been imported successfully!")
+}
+
 // Pictures
 
 func (u UserHandler) SaveProfilePicture(c *gin.Context) {
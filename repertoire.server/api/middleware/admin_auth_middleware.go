@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"repertoire/server/internal"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminAuthMiddleware struct {
+	env internal.Env
+}
+
+func NewAdminAuthMiddleware(env internal.Env) AdminAuthMiddleware {
+	return AdminAuthMiddleware{env: env}
+}
+
+// Handler lets through only the requests with the admin key,
+// and no request at all when the key is not configured
+func (a AdminAuthMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authKey := c.Request.Header.Get("Authorization")
+		if a.env.AdminAuthKey == "" || subtle.ConstantTimeCompare([]byte(authKey), []byte(a.env.AdminAuthKey)) != 1 {
+			_ = c.AbortWithError(http.StatusUnauthorized, errors.New("you are unauthorized"))
+			return
+		}
+
+		c.Next()
+		return
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"repertoire/server/data/service"
 	"strings"
@@ -9,11 +10,18 @@ import (
 )
 
 type JWTAuthMiddleware struct {
-	jwtService service.JwtService
+	jwtService          service.JwtService
+	revokedTokenService service.RevokedTokenService
 }
 
-func NewJWTAuthMiddleware(jwtService service.JwtService) JWTAuthMiddleware {
-	return JWTAuthMiddleware{jwtService: jwtService}
+func NewJWTAuthMiddleware(
+	jwtService service.JwtService,
+	revokedTokenService service.RevokedTokenService,
+) JWTAuthMiddleware {
+	return JWTAuthMiddleware{
+		jwtService:          jwtService,
+		revokedTokenService: revokedTokenService,
+	}
 }
 
 func (m JWTAuthMiddleware) Handler() gin.HandlerFunc {
@@ -22,11 +30,15 @@ func (m JWTAuthMiddleware) Handler() gin.HandlerFunc {
 		t := strings.Split(authHeader, " ")
 		if len(t) == 2 {
 			authToken := t[1]
-			errorCode := m.jwtService.Authorize(authToken)
+			tokenID, errorCode := m.jwtService.Authorize(authToken)
 			if errorCode != nil {
 				_ = c.AbortWithError(errorCode.Code, errorCode.Error)
 				return
 			}
+			if m.revokedTokenService.IsRevoked(tokenID) {
+				_ = c.AbortWithError(http.StatusUnauthorized, errors.New("token has been revoked"))
+				return
+			}
 
 			c.Next()
 			return
@@ -13,6 +13,7 @@ import (
 )
 
 var middlewares = fx.Options(
+	fx.Provide(middleware.NewAdminAuthMiddleware),
 	fx.Provide(middleware.NewCorsMiddleware),
 	fx.Provide(middleware.NewErrorHandlerMiddleware),
 	fx.Provide(middleware.NewJWTAuthMiddleware),
@@ -22,8 +23,13 @@ var middlewares = fx.Options(
 var handlers = fx.Options(
 	fx.Provide(handler.NewAlbumHandler),
 	fx.Provide(handler.NewArtistHandler),
+	fx.Provide(handler.NewDeadLetterHandler),
+	fx.Provide(handler.NewOrphanedFileHandler),
 	fx.Provide(handler.NewPlaylistHandler),
+	fx.Provide(handler.NewPracticeSessionHandler),
+	fx.Provide(handler.NewProgressHandler),
 	fx.Provide(handler.NewSearchHandler),
+	fx.Provide(handler.NewSetlistHandler),
 	fx.Provide(handler.NewSongHandler),
 	fx.Provide(handler.NewSongSectionHandler),
 	fx.Provide(handler.NewUserDataHandler),
@@ -33,8 +39,13 @@ var handlers = fx.Options(
 var routers = fx.Options(
 	fx.Provide(router.NewAlbumRouter),
 	fx.Provide(router.NewArtistRouter),
+	fx.Provide(router.NewDeadLetterRouter),
+	fx.Provide(router.NewOrphanedFileRouter),
 	fx.Provide(router.NewPlaylistRouter),
+	fx.Provide(router.NewPracticeSessionRouter),
+	fx.Provide(router.NewProgressRouter),
 	fx.Provide(router.NewSearchRouter),
+	fx.Provide(router.NewSetlistRouter),
 	fx.Provide(router.NewSongRouter),
 	fx.Provide(router.NewSongSectionRouter),
 	fx.Provide(router.NewUserDataRouter),
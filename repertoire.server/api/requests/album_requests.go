@@ -8,18 +8,18 @@ import (
 
 type GetAlbumRequest struct {
 	ID           uuid.UUID `validate:"required"`
-	SongsOrderBy []string  `form:"songsOrderBy" validate:"order_by"`
+	SongsOrderBy []string  `form:"songsOrderBy" validate:"order_by=album_songs"`
 }
 
 type GetAlbumsRequest struct {
 	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
 	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	OrderBy     []string `form:"orderBy" validate:"order_by"`
-	SearchBy    []string `form:"searchBy" validate:"search_by"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=albums"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=albums"`
 }
 
 type GetAlbumFiltersMetadataRequest struct {
-	SearchBy []string `form:"searchBy" validate:"search_by"`
+	SearchBy []string `form:"searchBy" validate:"search_by=albums"`
 }
 
 type CreateAlbumRequest struct {
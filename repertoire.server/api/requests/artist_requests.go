@@ -5,12 +5,12 @@ import "github.com/google/uuid"
 type GetArtistsRequest struct {
 	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
 	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	OrderBy     []string `form:"orderBy" validate:"order_by"`
-	SearchBy    []string `form:"searchBy" validate:"search_by"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=artists"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=artists"`
 }
 
 type GetArtistFiltersMetadataRequest struct {
-	SearchBy []string `form:"searchBy" validate:"search_by"`
+	SearchBy []string `form:"searchBy" validate:"search_by=artists"`
 }
 
 type CreateArtistRequest struct {
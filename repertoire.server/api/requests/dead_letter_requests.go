@@ -0,0 +1,7 @@
+package requests
+
+type GetDeadLetterMessagesRequest struct {
+	CurrentPage *int    `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
+	PageSize    *int    `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
+	Topic       *string `form:"topic"`
+}
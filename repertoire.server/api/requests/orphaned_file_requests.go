@@ -0,0 +1,6 @@
+package requests
+
+type CollectOrphanedFilesRequest struct {
+	UserID *string `form:"userId" validate:"omitempty,uuid"`
+	DryRun bool    `form:"dryRun"`
+}
@@ -9,24 +9,31 @@ type GetPlaylistRequest struct {
 type GetPlaylistsRequest struct {
 	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
 	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	OrderBy     []string `form:"orderBy" validate:"order_by"`
-	SearchBy    []string `form:"searchBy" validate:"search_by"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=playlists"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=playlists"`
 }
 
 type GetPlaylistSongsRequest struct {
 	ID          uuid.UUID `validate:"required"`
 	CurrentPage *int      `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
 	PageSize    *int      `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	OrderBy     []string  `form:"orderBy" validate:"order_by"`
+	OrderBy     []string  `form:"orderBy" validate:"order_by=playlist_songs"`
 }
 
 type GetPlaylistFiltersMetadataRequest struct {
-	SearchBy []string `form:"searchBy" validate:"search_by"`
+	SearchBy []string `form:"searchBy" validate:"search_by=playlists"`
+}
+
+type PlaylistSmartRulesRequest struct {
+	SearchBy []string `validate:"search_by=songs"`
+	OrderBy  []string `validate:"order_by=songs"`
+	Limit    *uint    `validate:"omitempty,gt=0"`
 }
 
 type CreatePlaylistRequest struct {
 	Title       string `validate:"required,max=100"`
 	Description string
+	SmartRules  *PlaylistSmartRulesRequest
 }
 
 type AddAlbumsToPlaylistRequest struct {
@@ -51,6 +58,15 @@ type UpdatePlaylistRequest struct {
 	Description string
 }
 
+type UpdatePlaylistSmartRulesRequest struct {
+	ID         uuid.UUID `validate:"required"`
+	SmartRules *PlaylistSmartRulesRequest
+}
+
+type FreezePlaylistRequest struct {
+	ID uuid.UUID `validate:"required"`
+}
+
 type BulkDeletePlaylistsRequest struct {
 	IDs []uuid.UUID `validate:"min=1"`
 }
@@ -0,0 +1,30 @@
+package requests
+
+import "github.com/google/uuid"
+
+type GetPracticeSessionsRequest struct {
+	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
+	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=practice_sessions"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=practice_sessions"`
+}
+
+type StartPracticeSessionRequest struct {
+	Notes string
+}
+
+type FinishPracticeSessionRequest struct {
+	ID    uuid.UUID `validate:"required"`
+	Notes *string
+}
+
+type AddSongToPracticeSessionRequest struct {
+	ID       uuid.UUID                                `validate:"required"`
+	SongID   uuid.UUID                                `validate:"required"`
+	Sections []AddSongToPracticeSessionSectionRequest `validate:"min=1,dive"`
+}
+
+type AddSongToPracticeSessionSectionRequest struct {
+	ID          uuid.UUID `validate:"required"`
+	Occurrences uint      `validate:"gt=0"`
+}
@@ -0,0 +1,18 @@
+package requests
+
+import (
+	"repertoire/server/internal/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetProgressTimelineRequest struct {
+	Interval enums.TimelineInterval `form:"interval" validate:"required,timeline_interval_enum"`
+	From     *time.Time             `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To       *time.Time             `form:"to" time_format:"2006-01-02" time_utc:"1"`
+
+	// taken from the route, the timeline covers the whole user when there is no scope
+	Scope   *enums.TimelineScope `form:"-"`
+	ScopeID uuid.UUID            `form:"-"`
+}
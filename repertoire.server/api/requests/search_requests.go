@@ -5,12 +5,20 @@ import (
 )
 
 type SearchGetRequest struct {
-	Query       string            `form:"query"`
-	CurrentPage *int              `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
-	PageSize    *int              `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	Type        *enums.SearchType `form:"type" validate:"required_with=IDs NotIDs,omitempty,search_type_enum"`
-	IDs         []string          `form:"ids"`
-	NotIDs      []string          `form:"notIds"`
-	Filter      []string          `form:"filter" validate:"search_filter"`
-	Order       []string          `form:"order" validate:"search_order"`
+	Query            string                        `form:"query"`
+	CurrentPage      *int                          `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
+	PageSize         *int                          `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
+	Type             *enums.SearchType             `form:"type" validate:"required_with=IDs NotIDs,omitempty,search_type_enum"`
+	IDs              []string                      `form:"ids"`
+	NotIDs           []string                      `form:"notIds"`
+	Filter           []string                      `form:"filter" validate:"search_filter"`
+	Order            []string                      `form:"order" validate:"search_order"`
+	Facets           bool                          `form:"facets"`
+	Highlight        bool                          `form:"highlight"`
+	TypoTolerance    *bool                         `form:"typoTolerance"`
+	MatchingStrategy *enums.SearchMatchingStrategy `form:"matchingStrategy" validate:"omitempty,search_matching_strategy_enum"`
+}
+
+type SearchReconcileRequest struct {
+	UserID *string `form:"userId" validate:"omitempty,uuid"`
 }
@@ -0,0 +1,65 @@
+package requests
+
+import (
+	"repertoire/server/internal/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetSetlistsRequest struct {
+	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
+	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=setlists"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=setlists"`
+}
+
+type CreateSetlistRequest struct {
+	Title string `validate:"required,max=100"`
+	Venue string `validate:"max=100"`
+	Date  *time.Time
+	Notes string
+}
+
+type CreateSetlistFromPlaylistRequest struct {
+	PlaylistID uuid.UUID `validate:"required"`
+	Title      string    `validate:"required,max=100"`
+	Venue      string    `validate:"max=100"`
+	Date       *time.Time
+	Notes      string
+}
+
+type UpdateSetlistRequest struct {
+	ID    uuid.UUID `validate:"required"`
+	Title string    `validate:"required,max=100"`
+	Venue string    `validate:"max=100"`
+	Date  *time.Time
+	Notes string
+}
+
+// Entries
+
+type AddEntryToSetlistRequest struct {
+	ID              uuid.UUID              `validate:"required"`
+	Type            enums.SetlistEntryType `validate:"required,setlist_entry_type_enum"`
+	SongID          *uuid.UUID             `validate:"required_if=Type song,excluded_unless=Type song"`
+	Title           string                 `validate:"required_unless=Type song,max=100"`
+	PlannedDuration *uint                  `validate:"omitempty,gt=0"`
+}
+
+type UpdateSetlistEntryRequest struct {
+	ID              uuid.UUID `validate:"required"`
+	Title           string    `validate:"max=100"`
+	PlannedDuration *uint     `validate:"omitempty,gt=0"`
+}
+
+type MoveEntryFromSetlistRequest struct {
+	ID          uuid.UUID `validate:"required"`
+	EntryID     uuid.UUID `validate:"required"`
+	OverEntryID uuid.UUID `validate:"required"`
+}
+
+type RemoveEntriesFromSetlistRequest struct {
+	ID       uuid.UUID   `validate:"required"`
+	EntryIDs []uuid.UUID `validate:"min=1"`
+}
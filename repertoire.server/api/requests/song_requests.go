@@ -10,18 +10,23 @@ import (
 type GetSongsRequest struct {
 	CurrentPage *int     `form:"currentPage" validate:"required_with=PageSize,omitempty,gt=0"`
 	PageSize    *int     `form:"pageSize" validate:"required_with=CurrentPage,omitempty,gt=0"`
-	OrderBy     []string `form:"orderBy" validate:"order_by"`
-	SearchBy    []string `form:"searchBy" validate:"search_by"`
+	OrderBy     []string `form:"orderBy" validate:"order_by=songs"`
+	SearchBy    []string `form:"searchBy" validate:"search_by=songs"`
 }
 
 type GetSongFiltersMetadataRequest struct {
-	SearchBy []string `form:"searchBy" validate:"search_by"`
+	SearchBy []string `form:"searchBy" validate:"search_by=songs"`
+}
+
+type GetSongsPracticeQueueRequest struct {
+	Budget *uint `form:"budget" validate:"omitempty,gt=0"`
 }
 
 type CreateSongRequest struct {
 	Title          string `validate:"required,max=100"`
 	Description    string
 	Bpm            *uint
+	Duration       *uint   `validate:"omitempty,gt=0"`
 	SongsterrLink  *string `validate:"omitempty,url,contains=songsterr.com"`
 	YoutubeLink    *string `validate:"omitempty,youtube_link"`
 	ReleaseDate    *internal.Date
@@ -46,12 +51,17 @@ type AddPartialSongRehearsalRequest struct {
 	ID uuid.UUID `validate:"required"`
 }
 
+type UndoSongRehearsalRequest struct {
+	ID uuid.UUID `validate:"required"`
+}
+
 type UpdateSongRequest struct {
 	ID             uuid.UUID `validate:"required"`
 	Title          string    `validate:"required,max=100"`
 	Description    string
 	IsRecorded     bool
 	Bpm            *uint
+	Duration       *uint   `validate:"omitempty,gt=0"`
 	SongsterrLink  *string `validate:"omitempty,url,contains=songsterr.com"`
 	YoutubeLink    *string `validate:"omitempty,youtube_link"`
 	ReleaseDate    *internal.Date
@@ -72,6 +82,9 @@ type BulkDeleteSongsRequest struct {
 }
 
 type CreateSectionRequest struct {
-	Name   string    `validate:"required,max=30"`
-	TypeID uuid.UUID `validate:"required"`
+	Name          string    `validate:"required,max=30"`
+	TypeID        uuid.UUID `validate:"required"`
+	StartOffset   *uint
+	BarCount      *uint   `validate:"omitempty,gt=0"`
+	TimeSignature *string `validate:"omitempty,time_signature"`
 }
@@ -1,5 +1,7 @@
 package requests
 
+import "repertoire/server/internal/enums"
+
 type SignUpRequest struct {
 	Name     string `validate:"required,max=100"`
 	Email    string `validate:"required,max=256,email"`
@@ -9,3 +11,7 @@ type SignUpRequest struct {
 type UpdateUserRequest struct {
 	Name string `validate:"required,max=100"`
 }
+
+type UpdateUserScoringStrategyRequest struct {
+	ScoringStrategy enums.ScoringStrategy `validate:"required,scoring_strategy_enum"`
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"repertoire/server/api/handler"
+	"repertoire/server/api/middleware"
+	"repertoire/server/api/server"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeadLetterRouter struct {
+	requestHandler      *server.RequestHandler
+	handler             *handler.DeadLetterHandler
+	adminAuthMiddleware middleware.AdminAuthMiddleware
+}
+
+func (d DeadLetterRouter) RegisterRoutes() {
+	var adminGroup = &gin.RouterGroup{}
+	*adminGroup = *d.requestHandler.PublicRouter
+	adminGroup.Use(d.adminAuthMiddleware.Handler())
+
+	api := adminGroup.Group("/admin/dead-letters")
+	{
+		api.GET("/metrics", d.handler.GetMetrics)
+		api.GET("/:id", d.handler.Get)
+		api.GET("", d.handler.GetAll)
+		api.POST("/:id/replay", d.handler.Replay)
+		api.DELETE("/:id", d.handler.Discard)
+	}
+}
+
+func NewDeadLetterRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.DeadLetterHandler,
+	adminAuthMiddleware middleware.AdminAuthMiddleware,
+) DeadLetterRouter {
+	return DeadLetterRouter{
+		handler:             handler,
+		requestHandler:      requestHandler,
+		adminAuthMiddleware: adminAuthMiddleware,
+	}
+}
@@ -0,0 +1,38 @@
+package router
+
+import (
+	"repertoire/server/api/handler"
+	"repertoire/server/api/middleware"
+	"repertoire/server/api/server"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrphanedFileRouter struct {
+	requestHandler      *server.RequestHandler
+	handler             *handler.OrphanedFileHandler
+	adminAuthMiddleware middleware.AdminAuthMiddleware
+}
+
+func (o OrphanedFileRouter) RegisterRoutes() {
+	var adminGroup = &gin.RouterGroup{}
+	*adminGroup = *o.requestHandler.PublicRouter
+	adminGroup.Use(o.adminAuthMiddleware.Handler())
+
+	api := adminGroup.Group("/admin/storage/orphaned-files")
+	{
+		api.POST("/collect", o.handler.Collect)
+	}
+}
+
+func NewOrphanedFileRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.OrphanedFileHandler,
+	adminAuthMiddleware middleware.AdminAuthMiddleware,
+) OrphanedFileRouter {
+	return OrphanedFileRouter{
+		handler:             handler,
+		requestHandler:      requestHandler,
+		adminAuthMiddleware: adminAuthMiddleware,
+	}
+}
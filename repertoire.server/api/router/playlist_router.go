@@ -20,7 +20,9 @@ func (p PlaylistRouter) RegisterRoutes() {
 		api.POST("/add-albums", p.handler.AddAlbums)
 		api.POST("/add-artists", p.handler.AddArtists)
 		api.POST("/perfect-rehearsals", p.handler.AddPerfectRehearsals)
+		api.POST("/freeze", p.handler.Freeze)
 		api.PUT("", p.handler.Update)
+		api.PUT("/smart-rules", p.handler.UpdateSmartRules)
 		api.PUT("/bulk-delete", p.handler.BulkDelete)
 		api.DELETE("/:id", p.handler.Delete)
 	}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"repertoire/server/api/handler"
+	"repertoire/server/api/server"
+)
+
+type PracticeSessionRouter struct {
+	requestHandler *server.RequestHandler
+	handler        *handler.PracticeSessionHandler
+}
+
+func (p PracticeSessionRouter) RegisterRoutes() {
+	api := p.requestHandler.PrivateRouter.Group("/practice-sessions")
+	{
+		api.GET("/:id", p.handler.Get)
+		api.GET("", p.handler.GetAll)
+		api.POST("/start", p.handler.Start)
+		api.PUT("/finish", p.handler.Finish)
+		api.DELETE("/:id", p.handler.Delete)
+	}
+
+	api.Group("/songs").POST("/add", p.handler.AddSong)
+}
+
+func NewPracticeSessionRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.PracticeSessionHandler,
+) PracticeSessionRouter {
+	return PracticeSessionRouter{
+		handler:        handler,
+		requestHandler: requestHandler,
+	}
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"repertoire/server/api/handler"
+	"repertoire/server/api/server"
+)
+
+type ProgressRouter struct {
+	requestHandler *server.RequestHandler
+	handler        *handler.ProgressHandler
+}
+
+func (p ProgressRouter) RegisterRoutes() {
+	api := p.requestHandler.PrivateRouter.Group("/progress")
+
+	timelineApi := api.Group("/timeline")
+	{
+		timelineApi.GET("", p.handler.GetTimeline)
+		timelineApi.GET("/sections/:id", p.handler.GetSectionTimeline)
+		timelineApi.GET("/songs/:id", p.handler.GetSongTimeline)
+		timelineApi.GET("/albums/:id", p.handler.GetAlbumTimeline)
+		timelineApi.GET("/artists/:id", p.handler.GetArtistTimeline)
+		timelineApi.GET("/playlists/:id", p.handler.GetPlaylistTimeline)
+	}
+}
+
+func NewProgressRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.ProgressHandler,
+) ProgressRouter {
+	return ProgressRouter{
+		handler:        handler,
+		requestHandler: requestHandler,
+	}
+}
@@ -12,6 +12,7 @@ type SearchRouter struct {
 	requestHandler      *server.RequestHandler
 	handler             *handler.SearchHandler
 	meiliAuthMiddleware middleware.MeiliAuthMiddleware
+	adminAuthMiddleware middleware.AdminAuthMiddleware
 }
 
 func (s SearchRouter) RegisterRoutes() {
@@ -28,16 +29,27 @@ func (s SearchRouter) RegisterRoutes() {
 	{
 		publicApi.POST("", s.handler.MeiliWebhook)
 	}
+
+	var adminGroup = &gin.RouterGroup{}
+	*adminGroup = *s.requestHandler.PublicRouter
+	adminGroup.Use(s.adminAuthMiddleware.Handler())
+
+	adminApi := adminGroup.Group("/admin/search")
+	{
+		adminApi.POST("/reconcile", s.handler.Reconcile)
+	}
 }
 
 func NewSearchRouter(
 	requestHandler *server.RequestHandler,
 	handler *handler.SearchHandler,
 	meiliAuthMiddleware middleware.MeiliAuthMiddleware,
+	adminAuthMiddleware middleware.AdminAuthMiddleware,
 ) SearchRouter {
 	return SearchRouter{
 		handler:             handler,
 		requestHandler:      requestHandler,
 		meiliAuthMiddleware: meiliAuthMiddleware,
+		adminAuthMiddleware: adminAuthMiddleware,
 	}
 }
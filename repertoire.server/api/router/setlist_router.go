@@ -0,0 +1,41 @@
+package router
+
+import (
+	"repertoire/server/api/handler"
+	"repertoire/server/api/server"
+)
+
+type SetlistRouter struct {
+	requestHandler *server.RequestHandler
+	handler        *handler.SetlistHandler
+}
+
+func (s SetlistRouter) RegisterRoutes() {
+	api := s.requestHandler.PrivateRouter.Group("/setlists")
+	{
+		api.GET("/:id", s.handler.Get)
+		api.GET("", s.handler.GetAll)
+		api.POST("", s.handler.Create)
+		api.POST("/from-playlist", s.handler.CreateFromPlaylist)
+		api.PUT("", s.handler.Update)
+		api.DELETE("/:id", s.handler.Delete)
+	}
+
+	entriesApi := api.Group("/entries")
+	{
+		entriesApi.POST("/add", s.handler.AddEntry)
+		entriesApi.PUT("", s.handler.UpdateEntry)
+		entriesApi.PUT("/move", s.handler.MoveEntry)
+		entriesApi.PUT("/remove", s.handler.RemoveEntries)
+	}
+}
+
+func NewSetlistRouter(
+	requestHandler *server.RequestHandler,
+	handler *handler.SetlistHandler,
+) SetlistRouter {
+	return SetlistRouter{
+		handler:        handler,
+		requestHandler: requestHandler,
+	}
+}
@@ -16,10 +16,12 @@ func (s SongRouter) RegisterRoutes() {
 		api.GET("/:id", s.handler.Get)
 		api.GET("", s.handler.GetAll)
 		api.GET("/filters-metadata", s.handler.GetFiltersMetadata)
+		api.GET("/practice-queue", s.handler.GetPracticeQueue)
 		api.POST("", s.handler.Create)
 		api.POST("/perfect-rehearsal", s.handler.AddPerfectRehearsal)
 		api.POST("/perfect-rehearsals", s.handler.AddPerfectRehearsals)
 		api.POST("/partial-rehearsal", s.handler.AddPartialRehearsal)
+		api.POST("/undo-rehearsal", s.handler.UndoRehearsal)
 		api.PUT("", s.handler.Update)
 		api.PUT("/settings", s.handler.UpdateSettings)
 		api.PUT("/bulk-delete", s.handler.BulkDelete)
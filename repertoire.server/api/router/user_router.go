@@ -19,8 +19,12 @@ func (u UserRouter) RegisterRoutes() {
 	api := u.requestHandler.PrivateRouter.Group("/users")
 	{
 		api.GET("/current", u.handler.GetCurrentUser)
+		api.GET("/export", u.handler.ExportData)
+		api.GET("/storage-usage", u.handler.GetStorageUsage)
 		api.GET("/:id", u.handler.Get)
+		api.POST("/import", u.handler.ImportData)
 		api.PUT("", u.handler.Update)
+		api.PUT("/scoring-strategy", u.handler.UpdateScoringStrategy)
 		api.DELETE("", u.handler.Delete)
 	}
 
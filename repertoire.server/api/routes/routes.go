@@ -17,8 +17,13 @@ func NewRoutes(
 	lc fx.Lifecycle,
 	albumRouter router.AlbumRouter,
 	artistRouter router.ArtistRouter,
+	deadLetterRouter router.DeadLetterRouter,
+	orphanedFileRouter router.OrphanedFileRouter,
 	playlistRouter router.PlaylistRouter,
+	practiceSessionRouter router.PracticeSessionRouter,
+	progressRouter router.ProgressRouter,
 	searchRouter router.SearchRouter,
+	setlistRouter router.SetlistRouter,
 	songRouter router.SongRouter,
 	songSectionRouter router.SongSectionRouter,
 	userDataRouter router.UserDataRouter,
@@ -27,8 +32,13 @@ func NewRoutes(
 	routes := &Routes{
 		albumRouter,
 		artistRouter,
+		deadLetterRouter,
+		orphanedFileRouter,
 		playlistRouter,
+		practiceSessionRouter,
+		progressRouter,
 		searchRouter,
+		setlistRouter,
 		songRouter,
 		songSectionRouter,
 		userDataRouter,
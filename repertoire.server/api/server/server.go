@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -26,6 +27,14 @@ func NewServer(lc fx.Lifecycle, handler *RequestHandler, logger *logger.Logger,
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
+			// without it, the URLs of the files cannot be signed, and the storage would reject them all
+			if os.Getenv("STORAGE_SIGNED_URL_SECRET_KEY") == "" {
+				return errors.New("STORAGE_SIGNED_URL_SECRET_KEY is required")
+			}
+			// without it, the URLs of the files would be sent as bare paths, unsigned
+			if os.Getenv("STORAGE_FETCH_URL") == "" {
+				return errors.New("STORAGE_FETCH_URL is required")
+			}
 			return startServer(server, logger)
 		},
 	})
@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"repertoire/server/internal/enums"
+	"repertoire/server/internal/query"
 	"slices"
-	"strings"
 	"unicode"
 
 	"github.com/go-playground/validator/v10"
@@ -47,8 +49,39 @@ func DifficultyEnum(fl validator.FieldLevel) bool {
 	return slices.Contains(difficulties, difficulty)
 }
 
+func ScoringStrategyEnum(fl validator.FieldLevel) bool {
+	strategies := []enums.ScoringStrategy{enums.ClassicScoring, enums.ExponentialScoring, enums.LinearScoring}
+
+	strategy, ok := fl.Field().Interface().(enums.ScoringStrategy)
+	if !ok {
+		return false
+	}
+	return slices.Contains(strategies, strategy)
+}
+
+func SearchMatchingStrategyEnum(fl validator.FieldLevel) bool {
+	strategies := []enums.SearchMatchingStrategy{
+		enums.LastMatchingStrategy,
+		enums.AllMatchingStrategy,
+		enums.FrequencyMatchingStrategy,
+	}
+
+	strategy, ok := fl.Field().Interface().(enums.SearchMatchingStrategy)
+	if !ok {
+		return false
+	}
+	return slices.Contains(strategies, strategy)
+}
+
 func SearchTypeEnum(fl validator.FieldLevel) bool {
-	searchTypes := []enums.SearchType{enums.Artist, enums.Album, enums.Song, enums.Playlist}
+	searchTypes := []enums.SearchType{
+		enums.Artist,
+		enums.BandMember,
+		enums.Album,
+		enums.Song,
+		enums.SongSection,
+		enums.Playlist,
+	}
 
 	searchType, ok := fl.Field().Interface().(enums.SearchType)
 	if !ok {
@@ -57,6 +90,31 @@ func SearchTypeEnum(fl validator.FieldLevel) bool {
 	return slices.Contains(searchTypes, searchType)
 }
 
+func SetlistEntryTypeEnum(fl validator.FieldLevel) bool {
+	entryTypes := []enums.SetlistEntryType{
+		enums.SongEntry,
+		enums.TalkEntry,
+		enums.TuningBreakEntry,
+		enums.EncoreMarkerEntry,
+	}
+
+	entryType, ok := fl.Field().Interface().(enums.SetlistEntryType)
+	if !ok {
+		return false
+	}
+	return slices.Contains(entryTypes, entryType)
+}
+
+func TimelineIntervalEnum(fl validator.FieldLevel) bool {
+	intervals := []enums.TimelineInterval{enums.DayInterval, enums.WeekInterval, enums.MonthInterval}
+
+	interval, ok := fl.Field().Interface().(enums.TimelineInterval)
+	if !ok {
+		return false
+	}
+	return slices.Contains(intervals, interval)
+}
+
 func YoutubeLink(fl validator.FieldLevel) bool {
 	regex := regexp.MustCompile(`^(https?://)?(www\.)?(youtube\.com|youtu\.be)/(watch\?v=|embed/|v/|.+\?v=)?([^&=%?]{11})([%&=?].*)?$`)
 	return regex.MatchString(fl.Field().String())
@@ -67,17 +125,14 @@ func Color(fl validator.FieldLevel) bool {
 	return regex.MatchString(fl.Field().String())
 }
 
+func TimeSignature(fl validator.FieldLevel) bool {
+	regex := regexp.MustCompile(`^([1-9]|[1-9][0-9])/(1|2|4|8|16|32)$`)
+	return regex.MatchString(fl.Field().String())
+}
+
+// OrderBy validates the orders against the fields of the entity given as parameter (e.g. order_by=songs)
 func OrderBy(fl validator.FieldLevel) bool {
-	orderBy, ok := fl.Field().Interface().([]string)
-	if !ok {
-		return false
-	}
-	for _, o := range orderBy {
-		if !validateOrderByElem(o) {
-			return false
-		}
-	}
-	return true
+	return describeOrderByError(fl.Field().Interface(), fl.Param()) == nil
 }
 
 func SearchOrder(fl validator.FieldLevel) bool {
@@ -93,17 +148,9 @@ func SearchOrder(fl validator.FieldLevel) bool {
 	return true
 }
 
+// SearchBy validates the filters against the fields of the entity given as parameter (e.g. search_by=songs)
 func SearchBy(fl validator.FieldLevel) bool {
-	searchBy, ok := fl.Field().Interface().([]string)
-	if !ok {
-		return false
-	}
-	for _, s := range searchBy {
-		if !validateSearchByElem(s) {
-			return false
-		}
-	}
-	return true
+	return describeSearchByError(fl.Field().Interface(), fl.Param()) == nil
 }
 
 func SearchFilter(fl validator.FieldLevel) bool {
@@ -121,46 +168,40 @@ func SearchFilter(fl validator.FieldLevel) bool {
 
 // private functions
 
-func validateOrderByElem(orderBy string) bool {
-	split := strings.Split(orderBy, " ")
-	if len(split) == 1 {
-		return true
-	}
-	if len(split) == 2 {
-		return validateOrderType(strings.ToLower(split[1]))
-	}
-	if len(split) == 3 {
-		return validateOrderNullability(strings.ToLower(split[1]), strings.ToLower(split[2]))
-	}
-	if len(split) == 4 {
-		return validateOrderType(strings.ToLower(split[1])) &&
-			validateOrderNullability(strings.ToLower(split[2]), strings.ToLower(split[3]))
-	}
-	return false
-}
-
 func validateOrderType(str string) bool {
 	return str == "asc" || str == "desc"
 }
 
-func validateOrderNullability(str1 string, str2 string) bool {
-	return str1 == "nulls" && (str2 == "last" || str2 == "first")
+func describeOrderByError(value any, entity string) error {
+	orderBy, ok := value.([]string)
+	if !ok {
+		return errors.New("order by is not a list")
+	}
+	fields, ok := query.Entities[entity]
+	if !ok {
+		return fmt.Errorf("unknown entity '%s'", entity)
+	}
+	for _, o := range orderBy {
+		if _, err := query.ParseOrder(fields, o); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-var filterOperators = []string{"~*", "=", "!=", "<>", "<", ">", "<=", ">=", "is", "in"}
-
-func validateSearchByElem(searchBy string) bool {
-	split := strings.SplitN(searchBy, " ", 3)
-	if len(split) != 3 {
-		return false
+func describeSearchByError(value any, entity string) error {
+	searchBy, ok := value.([]string)
+	if !ok {
+		return errors.New("search by is not a list")
 	}
-
-	operator := strings.ToLower(split[1])
-	searchValue := split[2]
-
-	if operator == "is" {
-		searchValue = strings.ToLower(searchValue)
-		return searchValue == "null" || searchValue == "not null"
+	fields, ok := query.Entities[entity]
+	if !ok {
+		return fmt.Errorf("unknown entity '%s'", entity)
+	}
+	for _, s := range searchBy {
+		if _, err := query.ParseFilter(fields, s); err != nil {
+			return err
+		}
 	}
-	return slices.Contains(filterOperators, operator)
+	return nil
 }
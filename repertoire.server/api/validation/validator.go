@@ -2,6 +2,7 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"repertoire/server/internal/wrapper"
 
 	"go.uber.org/fx"
@@ -37,12 +38,42 @@ func NewValidator(lc fx.Lifecycle) *Validator {
 
 func (v *Validator) Validate(request any) *wrapper.ErrorCode {
 	err := v.validate.Struct(request)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return wrapper.BadRequestError(withDetails(validationErrors))
+	}
 	if err != nil {
 		return wrapper.BadRequestError(err)
 	}
 	return nil
 }
 
+// detailedFieldError explains why the query of a field is invalid (e.g. the unknown field in a search)
+type detailedFieldError struct {
+	validator.FieldError
+	detail error
+}
+
+func (e detailedFieldError) Error() string {
+	return e.FieldError.Error() + ": " + e.detail.Error()
+}
+
+func withDetails(validationErrors validator.ValidationErrors) validator.ValidationErrors {
+	for i, fieldError := range validationErrors {
+		var detail error
+		switch fieldError.Tag() {
+		case "order_by":
+			detail = describeOrderByError(fieldError.Value(), fieldError.Param())
+		case "search_by":
+			detail = describeSearchByError(fieldError.Value(), fieldError.Param())
+		}
+		if detail != nil {
+			validationErrors[i] = detailedFieldError{FieldError: fieldError, detail: detail}
+		}
+	}
+	return validationErrors
+}
+
 func registerCustomValidators(validate *validator.Validate) error {
 	err := validate.RegisterValidation("has_upper", HasUpper)
 	if err != nil {
@@ -64,11 +95,31 @@ func registerCustomValidators(validate *validator.Validate) error {
 		return err
 	}
 
+	err = validate.RegisterValidation("scoring_strategy_enum", ScoringStrategyEnum)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("search_matching_strategy_enum", SearchMatchingStrategyEnum)
+	if err != nil {
+		return err
+	}
+
 	err = validate.RegisterValidation("search_type_enum", SearchTypeEnum)
 	if err != nil {
 		return err
 	}
 
+	err = validate.RegisterValidation("setlist_entry_type_enum", SetlistEntryTypeEnum)
+	if err != nil {
+		return err
+	}
+
+	err = validate.RegisterValidation("timeline_interval_enum", TimelineIntervalEnum)
+	if err != nil {
+		return err
+	}
+
 	err = validate.RegisterValidation("youtube_link", YoutubeLink)
 	if err != nil {
 		return err
@@ -79,6 +130,11 @@ func registerCustomValidators(validate *validator.Validate) error {
 		return err
 	}
 
+	err = validate.RegisterValidation("time_signature", TimeSignature)
+	if err != nil {
+		return err
+	}
+
 	err = validate.RegisterValidation("order_by", OrderBy)
 	if err != nil {
 		return err
@@ -1,26 +1,12 @@
 package database
 
 import (
-	"fmt"
-	"reflect"
-	"slices"
+	"repertoire/server/internal/query"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
-func AddCoalesceToCompoundFields(str []string, compoundFields []string) []string {
-	var newStr = slices.Clone(str)
-	for i, s := range newStr {
-		for _, field := range compoundFields {
-			if strings.Contains(s, field) {
-				newStr[i] = strings.Replace(s, field, "COALESCE("+field+",0)", 1)
-			}
-		}
-	}
-	return newStr
-}
-
 func Paginate(tx *gorm.DB, currentPage *int, pageSize *int) *gorm.DB {
 	if currentPage == nil || pageSize == nil {
 		return tx
@@ -28,44 +14,66 @@ func Paginate(tx *gorm.DB, currentPage *int, pageSize *int) *gorm.DB {
 	return tx.Offset((*currentPage - 1) * *pageSize).Limit(*pageSize)
 }
 
-func OrderBy(tx *gorm.DB, orderBy []string) *gorm.DB {
+// OrderBy adds the orders, parsed against the fields allowed for the entity
+func OrderBy(tx *gorm.DB, orderBy []string, fields query.Fields) *gorm.DB {
 	for _, o := range orderBy {
-		tx = tx.Order(o)
+		order, err := query.ParseOrder(fields, o)
+		if err != nil {
+			_ = tx.AddError(err)
+			return tx
+		}
+
+		str := order.Field.Column
+		if order.Descending {
+			str += " DESC"
+		}
+		if order.Nulls != "" {
+			str += " NULLS " + string(order.Nulls)
+		}
+		tx = tx.Order(str)
 	}
 	return tx
 }
 
-func SearchBy(tx *gorm.DB, searchBy []string) *gorm.DB {
+// SearchBy adds the filters, parsed against the fields allowed for the entity,
+// with the values always bound as parameters
+func SearchBy(tx *gorm.DB, searchBy []string, fields query.Fields) *gorm.DB {
 	for _, s := range searchBy {
-		condition, search := splitSearch(s)
-		if reflect.TypeOf(search).String() == "string" && search == "" {
-			tx.Where(condition)
-		} else {
-			tx.Where(condition, search)
+		filter, err := query.ParseFilter(fields, s)
+		if err != nil {
+			_ = tx.AddError(err)
+			return tx
 		}
+
+		condition, values := buildCondition(filter)
+		tx.Where(condition, values...)
 	}
 	return tx
 }
 
-func splitSearch(str string) (string, any) {
-	split := strings.SplitN(str, " ", 3)
-
-	property := split[0]
-	operator := split[1]
-	searchValue := split[2]
-
-	condition := fmt.Sprintf("(%s %s (?))", property, operator)
-
-	if strings.ToLower(operator) == "is" {
-		return str, ""
-	}
-	if strings.ToLower(operator) == "in" {
-		var values []string
-		for _, val := range strings.Split(searchValue, ",") {
-			values = append(values, strings.TrimSpace(val))
+func buildCondition(filter query.Filter) (string, []any) {
+	switch f := filter.(type) {
+	case query.Group:
+		var conditions []string
+		var values []any
+		for _, child := range f.Filters {
+			condition, childValues := buildCondition(child)
+			conditions = append(conditions, condition)
+			values = append(values, childValues...)
+		}
+		return "(" + strings.Join(conditions, " "+string(f.Operator)+" ") + ")", values
+	case query.Condition:
+		if f.Field.Condition != "" {
+			return "(" + f.Field.Condition + ")", []any{f.Value}
+		}
+		switch f.Operator {
+		case query.IsNull, query.IsNotNull:
+			return "(" + f.Field.Column + " " + string(f.Operator) + ")", nil
+		case query.In:
+			return "(" + f.Field.Column + " IN (?))", []any{f.Value}
+		default:
+			return "(" + f.Field.Column + " " + string(f.Operator) + " ?)", []any{f.Value}
 		}
-		return condition, values
 	}
-
-	return condition, searchValue
+	return "", nil
 }
@@ -8,7 +8,10 @@ import (
 type RepositoryFactory interface {
 	NewArtistRepository() repository.ArtistRepository
 	NewAlbumRepository() repository.AlbumRepository
+	NewOutboxRepository() repository.OutboxRepository
 	NewPlaylistRepository() repository.PlaylistRepository
+	NewPracticeSessionRepository() repository.PracticeSessionRepository
+	NewSetlistRepository() repository.SetlistRepository
 	NewSongRepository() repository.SongRepository
 	NewSongSectionRepository() repository.SongSectionRepository
 	NewUserDataRepository() repository.UserDataRepository
@@ -27,10 +30,22 @@ func (f repositoryFactory) NewAlbumRepository() repository.AlbumRepository {
 	return repository.NewAlbumRepository(f.client)
 }
 
+func (f repositoryFactory) NewOutboxRepository() repository.OutboxRepository {
+	return repository.NewOutboxRepository(f.client)
+}
+
 func (f repositoryFactory) NewPlaylistRepository() repository.PlaylistRepository {
 	return repository.NewPlaylistRepository(f.client)
 }
 
+func (f repositoryFactory) NewPracticeSessionRepository() repository.PracticeSessionRepository {
+	return repository.NewPracticeSessionRepository(f.client)
+}
+
+func (f repositoryFactory) NewSetlistRepository() repository.SetlistRepository {
+	return repository.NewSetlistRepository(f.client)
+}
+
 func (f repositoryFactory) NewSongRepository() repository.SongRepository {
 	return repository.NewSongRepository(f.client)
 }
@@ -0,0 +1,12 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevokedTokenResponse struct {
+	ID        uuid.UUID
+	ExpiresAt time.Time
+}
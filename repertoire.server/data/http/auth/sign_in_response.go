@@ -0,0 +1,6 @@
+package auth
+
+type SignInResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
@@ -44,8 +44,21 @@ func (client AuthClient) CentrifugoToken(userID string, result *auth.TokenRespon
 		Post("/centrifugo/public-token")
 }
 
-func (client AuthClient) SignIn(email string, password string) (*resty.Response, error) {
+func (client AuthClient) SignIn(email string, password string, result *auth.SignInResponse) (*resty.Response, error) {
 	return client.R().
 		SetBody(struct{ Email, Password string }{email, password}).
+		SetResult(&result).
 		Put("/sign-in")
 }
+
+func (client AuthClient) RequestEmailVerification(token string) (*resty.Response, error) {
+	return client.R().
+		SetAuthToken(token).
+		Put("/email-verification/request")
+}
+
+func (client AuthClient) RevokedTokens(result *[]auth.RevokedTokenResponse) (*resty.Response, error) {
+	return client.R().
+		SetResult(&result).
+		Get("/revoked-tokens")
+}
@@ -2,7 +2,9 @@ package client
 
 import (
 	"io"
+	"mime/multipart"
 	"repertoire/server/data/http"
+	"repertoire/server/data/http/storage"
 	"repertoire/server/internal"
 
 	"github.com/go-resty/resty/v2"
@@ -28,6 +30,55 @@ func (client StorageClient) Upload(token string, fileName string, reader io.Read
 		Put("upload")
 }
 
+// UploadStream writes the multipart form while the request is being sent,
+// as the file reader of Upload gets buffered whole before sending
+func (client StorageClient) UploadStream(token string, fileName string, reader io.Reader, filePath string) (*resty.Response, error) {
+	body, bodyWriter := io.Pipe()
+	defer func() { _ = body.Close() }()
+
+	form := multipart.NewWriter(bodyWriter)
+	go func() {
+		_ = bodyWriter.CloseWithError(writeUploadForm(form, fileName, reader, filePath))
+	}()
+
+	return client.R().
+		SetAuthToken(token).
+		SetHeader("Content-Type", form.FormDataContentType()).
+		SetBody(body).
+		Put("upload")
+}
+
+// the storage reads the form as a stream, so the filePath has to be written before the file
+func writeUploadForm(form *multipart.Writer, fileName string, reader io.Reader, filePath string) error {
+	err := form.WriteField("filePath", filePath)
+	if err != nil {
+		return err
+	}
+
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, reader)
+	if err != nil {
+		return err
+	}
+
+	return form.Close()
+}
+
+func (client StorageClient) Get(filePath string) (*resty.Response, error) {
+	return client.R().
+		Get("files/" + filePath)
+}
+
+func (client StorageClient) GetUsage(token string, result *storage.UsageResponse) (*resty.Response, error) {
+	return client.R().
+		SetAuthToken(token).
+		SetResult(&result).
+		Get("usage")
+}
+
 func (client StorageClient) DeleteFile(token string, filePath string) (*resty.Response, error) {
 	return client.R().
 		SetAuthToken(token).
@@ -41,3 +92,11 @@ func (client StorageClient) DeleteDirectories(token string, directoryPaths []str
 		SetBody(body).
 		Put("directories")
 }
+
+func (client StorageClient) QuarantineFiles(token string, filePaths []string) (*resty.Response, error) {
+	var body = struct{ FilePaths []string }{filePaths}
+	return client.R().
+		SetAuthToken(token).
+		SetBody(body).
+		Put("quarantine")
+}
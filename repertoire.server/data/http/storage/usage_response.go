@@ -0,0 +1,15 @@
+package storage
+
+import "time"
+
+type UsageResponse struct {
+	Size  int64
+	Quota int64
+	Files []FileUsageResponse
+}
+
+type FileUsageResponse struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
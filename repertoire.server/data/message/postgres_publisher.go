@@ -0,0 +1,74 @@
+package message
+
+import (
+	"context"
+	stdSQL "database/sql"
+	"errors"
+	"log"
+	"repertoire/server/data/database"
+	"repertoire/server/data/logger"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill-sql/v3/pkg/sql"
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+const postgresSubscriberPollInterval = 100 * time.Millisecond
+
+// postgresPublisher stores the messages in the database, so that they survive restarts,
+// and keeps the offsets of every consumer group
+type postgresPublisher struct {
+	*sql.Publisher
+	subscriber *sql.Subscriber
+	db         *stdSQL.DB
+	logger     *logger.WatermillLogger
+}
+
+func newPostgresPublisher(client database.Client, logger *logger.WatermillLogger) Publisher {
+	db, err := client.DB.DB()
+	if err != nil {
+		log.Fatalf("Failed to get the database connection for the message broker: %v", err)
+	}
+
+	publisher, err := sql.NewPublisher(db, sql.PublisherConfig{
+		SchemaAdapter:        sql.DefaultPostgreSQLSchema{},
+		AutoInitializeSchema: true,
+	}, logger)
+	if err != nil {
+		log.Fatalf("Failed to create the message broker publisher: %v", err)
+	}
+
+	p := postgresPublisher{
+		Publisher: publisher,
+		db:        db,
+		logger:    logger,
+	}
+	subscriber, err := p.newSubscriber("")
+	if err != nil {
+		log.Fatalf("Failed to create the message broker subscriber: %v", err)
+	}
+	p.subscriber = subscriber
+	return p
+}
+
+func (p postgresPublisher) Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error) {
+	return p.subscriber.Subscribe(ctx, topic)
+}
+
+func (p postgresPublisher) NewSubscriber(consumerGroup string) (message.Subscriber, error) {
+	return p.newSubscriber(consumerGroup)
+}
+
+func (p postgresPublisher) Close() error {
+	return errors.Join(p.Publisher.Close(), p.subscriber.Close())
+}
+
+func (p postgresPublisher) newSubscriber(consumerGroup string) (*sql.Subscriber, error) {
+	return sql.NewSubscriber(p.db, sql.SubscriberConfig{
+		ConsumerGroup:    consumerGroup,
+		PollInterval:     postgresSubscriberPollInterval,
+		SchemaAdapter:    sql.DefaultPostgreSQLSchema{},
+		OffsetsAdapter:   sql.DefaultPostgreSQLOffsetsAdapter{},
+		InitializeSchema: true,
+	}, p.logger)
+}
@@ -2,7 +2,9 @@ package message
 
 import (
 	"context"
+	"repertoire/server/data/database"
 	"repertoire/server/data/logger"
+	"repertoire/server/internal"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
@@ -11,9 +13,31 @@ import (
 type Publisher interface {
 	Publish(topic string, messages ...*message.Message) error
 	Subscribe(ctx context.Context, topic string) (<-chan *message.Message, error)
+	// NewSubscriber returns a subscriber for the consumer group,
+	// as every consumer group has to receive all the messages of a topic
+	NewSubscriber(consumerGroup string) (message.Subscriber, error)
 	Close() error
 }
 
-func NewPublisher(logger *logger.WatermillLogger) Publisher {
-	return gochannel.NewGoChannel(gochannel.Config{}, logger)
+// NewPublisher uses the in-memory Go Channel by default,
+// but the messages are lost on restart, so a durable broker can be configured instead.
+// The Go Channel blocks the publishing until the subscribers acknowledge the message,
+// so that the outbox keeps it until it is handled
+func NewPublisher(client database.Client, env internal.Env, logger *logger.WatermillLogger) Publisher {
+	if env.MessageBroker == internal.PostgresMessageBroker {
+		return newPostgresPublisher(client, logger)
+	}
+	return inMemoryPublisher{gochannel.NewGoChannel(
+		gochannel.Config{BlockPublishUntilSubscriberAck: true},
+		logger,
+	)}
+}
+
+type inMemoryPublisher struct {
+	*gochannel.GoChannel
+}
+
+// NewSubscriber returns the Go Channel itself, as it already fans out the messages to all subscribers
+func (i inMemoryPublisher) NewSubscriber(string) (message.Subscriber, error) {
+	return i.GoChannel, nil
 }
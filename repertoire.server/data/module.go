@@ -49,7 +49,12 @@ var httpClients = fx.Options(
 var repositories = fx.Options(
 	fx.Provide(repository.NewAlbumRepository),
 	fx.Provide(repository.NewArtistRepository),
+	fx.Provide(repository.NewDeadLetterRepository),
+	fx.Provide(repository.NewOutboxRepository),
 	fx.Provide(repository.NewPlaylistRepository),
+	fx.Provide(repository.NewPracticeSessionRepository),
+	fx.Provide(repository.NewProcessedMessageRepository),
+	fx.Provide(repository.NewSetlistRepository),
 	fx.Provide(repository.NewSongRepository),
 	fx.Provide(repository.NewSongSectionRepository),
 	fx.Provide(repository.NewUserDataRepository),
@@ -63,6 +68,7 @@ var services = fx.Options(
 	fx.Provide(service.NewSearchTaskTrackerService),
 	fx.Provide(service.NewMessagePublisherService),
 	fx.Provide(service.NewRealTimeService),
+	fx.Provide(service.NewRevokedTokenService),
 	fx.Provide(service.NewSearchEngineService),
 	fx.Provide(service.NewStorageService),
 )
@@ -3,6 +3,7 @@ package repository
 import (
 	"encoding/json"
 	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
@@ -72,7 +73,7 @@ func (a albumRepository) GetWithSongsAndArtist(album *model.Album, id uuid.UUID)
 func (a albumRepository) GetWithAssociations(album *model.Album, id uuid.UUID, songsOrderBy []string) error {
 	return a.client.
 		Preload("Songs", func(db *gorm.DB) *gorm.DB {
-			return database.OrderBy(db, songsOrderBy)
+			return database.OrderBy(db, songsOrderBy, query.AlbumSongFields)
 		}).
 		Joins("Artist").
 		Find(&album, model.Album{ID: id}).
@@ -100,8 +101,7 @@ func (a albumRepository) GetFiltersMetadata(metadata *model.AlbumFiltersMetadata
 		Joins("LEFT JOIN (?) AS ss ON ss.album_id = albums.id", a.getSongsSubQuery(userID)).
 		Where("user_id = ?", userID)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundAlbumsFields)
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.AlbumFields)
 	err := tx.Scan(&metadata).Error
 	if err != nil {
 		return err
@@ -140,12 +140,13 @@ func (a albumRepository) GetAllByIDsWithSongsAndArtist(albums *[]model.Album, id
 		Joins("Artist").
 		Preload("Songs").
 		Preload("Songs.Artist").
+		Preload("Songs.GuitarTuning").
+		Preload("Songs.Sections").
+		Preload("Songs.Sections.SongSectionType").
 		Find(&albums, ids).
 		Error
 }
 
-var compoundAlbumsFields = []string{"songs_count", "rehearsals", "confidence", "progress"}
-
 func (a albumRepository) GetAllByUser(
 	albums *[]model.EnhancedAlbum,
 	userID uuid.UUID,
@@ -161,11 +162,8 @@ func (a albumRepository) GetAllByUser(
 
 	a.addSongsSubQuery(tx, userID)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundAlbumsFields)
-	orderBy = database.AddCoalesceToCompoundFields(orderBy, compoundAlbumsFields)
-
-	database.SearchBy(tx, searchBy)
-	database.OrderBy(tx, orderBy)
+	database.SearchBy(tx, searchBy, query.AlbumFields)
+	database.OrderBy(tx, orderBy, query.AlbumFields)
 	database.Paginate(tx, currentPage, pageSize)
 	return tx.Find(&albums).Error
 }
@@ -177,9 +175,7 @@ func (a albumRepository) GetAllByUserCount(count *int64, userID uuid.UUID, searc
 
 	a.addSongsSubQuery(tx, userID)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundAlbumsFields)
-
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.AlbumFields)
 	return tx.Count(count).Error
 }
 
@@ -245,6 +241,7 @@ func (a albumRepository) addSongsSubQuery(tx *gorm.DB, userID uuid.UUID) {
 			"COALESCE(ss.confidence, 0) as confidence",
 			"COALESCE(ss.progress, 0) as progress",
 			"ss.last_time_played as last_time_played",
+			"COALESCE(ss.total_duration, 0) as total_duration",
 		)
 }
 
@@ -256,6 +253,7 @@ func (a albumRepository) getSongsSubQuery(userID uuid.UUID) *gorm.DB {
 			"AVG(confidence) as confidence",
 			"AVG(progress) as progress",
 			"MAX(last_time_played) as last_time_played",
+			"SUM(duration) as total_duration",
 		).
 		Group("album_id").
 		Where(model.Song{UserID: userID})
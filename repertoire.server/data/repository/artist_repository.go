@@ -2,6 +2,7 @@ package repository
 
 import (
 	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
@@ -13,10 +14,12 @@ type ArtistRepository interface {
 	GetWithAssociations(artist *model.Artist, id uuid.UUID) error
 	GetWithBandMembers(artist *model.Artist, id uuid.UUID) error
 	GetWithSongsOrAlbums(artist *model.Artist, id uuid.UUID, withSongs bool, withAlbums bool) error
+	GetWithSearchAssociations(artist *model.Artist, id uuid.UUID) error
 	GetFiltersMetadata(metadata *model.ArtistFiltersMetadata, userID uuid.UUID, searchBy []string) error
 	GetAllByIDs(artists *[]model.Artist, ids []uuid.UUID, withSongs bool, withAlbums bool) error
 	GetAllByIDsWithSongs(artists *[]model.Artist, ids []uuid.UUID) error
 	GetAllByIDsWithSongSections(artists *[]model.Artist, ids []uuid.UUID) error
+	GetAllIDsByBandMemberRole(ids *[]uuid.UUID, roleID uuid.UUID) error
 	GetAllByUser(
 		artists *[]model.EnhancedArtist,
 		userID uuid.UUID,
@@ -95,6 +98,20 @@ func (a artistRepository) GetWithSongsOrAlbums(
 	return tx.Find(&artist, id).Error
 }
 
+func (a artistRepository) GetWithSearchAssociations(artist *model.Artist, id uuid.UUID) error {
+	return a.client.Model(&model.Artist{}).
+		Preload("Albums").
+		Preload("Songs").
+		Preload("Songs.Album").
+		Preload("Songs.GuitarTuning").
+		Preload("Songs.Sections").
+		Preload("Songs.Sections.SongSectionType").
+		Preload("BandMembers").
+		Preload("BandMembers.Roles").
+		Find(&artist, id).
+		Error
+}
+
 func (a artistRepository) GetFiltersMetadata(metadata *model.ArtistFiltersMetadata, userID uuid.UUID, searchBy []string) error {
 	tx := a.client.
 		Select(
@@ -119,8 +136,7 @@ func (a artistRepository) GetFiltersMetadata(metadata *model.ArtistFiltersMetada
 		Joins("LEFT JOIN (?) AS ss ON ss.artist_id = artists.id", a.getSongsSubQuery(userID)).
 		Where("user_id = ?", userID)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundArtistsFields)
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.ArtistFields)
 	return tx.Scan(&metadata).Error
 }
 
@@ -157,8 +173,14 @@ func (a artistRepository) GetAllByIDsWithSongSections(artists *[]model.Artist, i
 		Error
 }
 
-var compoundArtistsFields = []string{"band_members_count", "albums_count", "songs_count",
-	"rehearsals", "confidence", "progress"}
+func (a artistRepository) GetAllIDsByBandMemberRole(ids *[]uuid.UUID, roleID uuid.UUID) error {
+	return a.client.Model(&model.BandMember{}).
+		Distinct().
+		Joins("JOIN band_member_has_roles ON band_member_has_roles.band_member_id = band_members.id").
+		Where("band_member_has_roles.band_member_role_id = ?", roleID).
+		Pluck("artist_id", ids).
+		Error
+}
 
 func (a artistRepository) GetAllByUser(
 	artists *[]model.EnhancedArtist,
@@ -177,11 +199,8 @@ func (a artistRepository) GetAllByUser(
 	dbSelect = append(dbSelect, a.addSongsSubQuery(tx, userID)...)
 	tx.Select(dbSelect)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundArtistsFields)
-	orderBy = database.AddCoalesceToCompoundFields(orderBy, compoundArtistsFields)
-
-	database.SearchBy(tx, searchBy)
-	database.OrderBy(tx, orderBy)
+	database.SearchBy(tx, searchBy, query.ArtistFields)
+	database.OrderBy(tx, orderBy, query.ArtistFields)
 	database.Paginate(tx, currentPage, pageSize)
 	return tx.Find(&artists).Error
 }
@@ -196,9 +215,7 @@ func (a artistRepository) GetAllByUserCount(count *int64, userID uuid.UUID, sear
 	dbSelect = append(dbSelect, a.addSongsSubQuery(tx, userID)...)
 	tx.Select(dbSelect)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundArtistsFields)
-
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.ArtistFields)
 	return tx.Count(count).Error
 }
 
@@ -283,6 +300,7 @@ func (a artistRepository) addSongsSubQuery(tx *gorm.DB, userID uuid.UUID) []stri
 		"COALESCE(ss.confidence, 0) as confidence",
 		"COALESCE(ss.progress, 0) as progress",
 		"ss.last_time_played as last_time_played",
+		"COALESCE(ss.total_duration, 0) as total_duration",
 	}
 }
 
@@ -309,6 +327,7 @@ func (a artistRepository) getSongsSubQuery(userID uuid.UUID) *gorm.DB {
 			"AVG(confidence) as confidence",
 			"AVG(progress) as progress",
 			"MAX(last_time_played) as last_time_played",
+			"SUM(duration) as total_duration",
 		).
 		Group("artist_id").
 		Where(model.Song{UserID: userID})
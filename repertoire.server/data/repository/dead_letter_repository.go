@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type DeadLetterRepository interface {
+	Get(deadLetterMessage *model.DeadLetterMessage, id uuid.UUID) error
+	GetAll(
+		deadLetterMessages *[]model.DeadLetterMessage,
+		currentPage *int,
+		pageSize *int,
+		topic *string,
+	) error
+	GetAllCount(count *int64, topic *string) error
+	GetMetricsByTopic(metrics *[]model.DeadLetterTopicMetrics) error
+	Create(deadLetterMessage *model.DeadLetterMessage) error
+	Delete(id uuid.UUID) error
+}
+
+type deadLetterRepository struct {
+	client database.Client
+}
+
+func NewDeadLetterRepository(client database.Client) DeadLetterRepository {
+	return deadLetterRepository{
+		client: client,
+	}
+}
+
+func (d deadLetterRepository) Get(deadLetterMessage *model.DeadLetterMessage, id uuid.UUID) error {
+	return d.client.Find(&deadLetterMessage, model.DeadLetterMessage{ID: id}).Error
+}
+
+func (d deadLetterRepository) GetAll(
+	deadLetterMessages *[]model.DeadLetterMessage,
+	currentPage *int,
+	pageSize *int,
+	topic *string,
+) error {
+	tx := d.client.Model(&model.DeadLetterMessage{}).Order("created_at DESC")
+	if topic != nil {
+		tx = tx.Where(model.DeadLetterMessage{Topic: *topic})
+	}
+	database.Paginate(tx, currentPage, pageSize)
+	return tx.Find(&deadLetterMessages).Error
+}
+
+func (d deadLetterRepository) GetAllCount(count *int64, topic *string) error {
+	tx := d.client.Model(&model.DeadLetterMessage{})
+	if topic != nil {
+		tx = tx.Where(model.DeadLetterMessage{Topic: *topic})
+	}
+	return tx.Count(count).Error
+}
+
+func (d deadLetterRepository) GetMetricsByTopic(metrics *[]model.DeadLetterTopicMetrics) error {
+	return d.client.Model(&model.DeadLetterMessage{}).
+		Select("topic, COUNT(*) AS count").
+		Group("topic").
+		Order("count DESC").
+		Scan(&metrics).
+		Error
+}
+
+func (d deadLetterRepository) Create(deadLetterMessage *model.DeadLetterMessage) error {
+	return d.client.Create(&deadLetterMessage).Error
+}
+
+func (d deadLetterRepository) Delete(id uuid.UUID) error {
+	return d.client.Delete(&model.DeadLetterMessage{}, id).Error
+}
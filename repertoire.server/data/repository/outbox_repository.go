@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository interface {
+	GetOldestAndLock(messages *[]model.OutboxMessage, limit int) error
+	Create(message *model.OutboxMessage) error
+	Delete(ids []uuid.UUID) error
+}
+
+type outboxRepository struct {
+	client database.Client
+}
+
+func NewOutboxRepository(client database.Client) OutboxRepository {
+	return outboxRepository{
+		client: client,
+	}
+}
+
+// GetOldestAndLock is meant to be called inside a transaction,
+// so that the messages locked by one relay are skipped by the others
+func (o outboxRepository) GetOldestAndLock(messages *[]model.OutboxMessage, limit int) error {
+	return o.client.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("created_at").
+		Limit(limit).
+		Find(&messages).
+		Error
+}
+
+func (o outboxRepository) Create(message *model.OutboxMessage) error {
+	return o.client.Create(&message).Error
+}
+
+func (o outboxRepository) Delete(ids []uuid.UUID) error {
+	return o.client.Delete(&model.OutboxMessage{}, ids).Error
+}
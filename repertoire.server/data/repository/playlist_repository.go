@@ -2,6 +2,7 @@ package repository
 
 import (
 	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
@@ -72,7 +73,7 @@ func (p playlistRepository) GetPlaylistSongsWithSongs(
 		Joins("Song.Artist").
 		Joins("Song.Album")
 
-	database.OrderBy(tx, orderBy)
+	database.OrderBy(tx, orderBy, query.PlaylistSongFields)
 	database.Paginate(tx, currentPage, pageSize)
 	return tx.Find(&playlistSongs, model.PlaylistSong{PlaylistID: id}).Error
 }
@@ -98,9 +99,7 @@ func (p playlistRepository) GetFiltersMetadata(
 		Joins("LEFT JOIN (?) AS ss ON ss.playlist_id = playlists.id", p.getSongsByPlaylistSubQuery(userID)).
 		Where("user_id = ?", userID)
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundPlaylistsFields)
-
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.PlaylistFields)
 	return tx.Scan(&metadata).Error
 }
 
@@ -119,8 +118,6 @@ func (p playlistRepository) GetAllByIDsWithSongSections(playlists *[]model.Playl
 		Error
 }
 
-var compoundPlaylistsFields = []string{"songs_count"}
-
 func (p playlistRepository) GetAllByUser(
 	playlists *[]model.EnhancedPlaylist,
 	userID uuid.UUID,
@@ -133,15 +130,13 @@ func (p playlistRepository) GetAllByUser(
 		Select(
 			"playlists.*",
 			"COALESCE(ss.songs_count, 0) AS songs_count",
+			"COALESCE(ss.total_duration, 0) AS total_duration",
 		).
 		Joins("LEFT JOIN (?) AS ss ON ss.playlist_id = playlists.id", p.getSongsByPlaylistSubQuery(userID)).
 		Where(model.Playlist{UserID: userID})
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundPlaylistsFields)
-	orderBy = database.AddCoalesceToCompoundFields(orderBy, compoundPlaylistsFields)
-
-	database.SearchBy(tx, searchBy)
-	database.OrderBy(tx, orderBy)
+	database.SearchBy(tx, searchBy, query.PlaylistFields)
+	database.OrderBy(tx, orderBy, query.PlaylistFields)
 	database.Paginate(tx, currentPage, pageSize)
 	return tx.Find(&playlists).Error
 }
@@ -151,9 +146,7 @@ func (p playlistRepository) GetAllByUserCount(count *int64, userID uuid.UUID, se
 		Joins("LEFT JOIN (?) AS ss ON ss.playlist_id = playlists.id", p.getSongsByPlaylistSubQuery(userID)).
 		Where(model.Playlist{UserID: userID})
 
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundPlaylistsFields)
-
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.PlaylistFields)
 	return tx.Count(count).Error
 }
 
@@ -190,8 +183,9 @@ func (p playlistRepository) RemoveSongs(playlistSongs *[]model.PlaylistSong) err
 
 func (p playlistRepository) getSongsByPlaylistSubQuery(userID uuid.UUID) *gorm.DB {
 	return p.client.Model(&model.PlaylistSong{}).
-		Select("playlist_id, COUNT(*) as songs_count").
+		Select("playlist_id, COUNT(*) as songs_count, SUM(songs.duration) as total_duration").
 		Joins("JOIN playlists ON playlists.id = playlist_songs.playlist_id").
+		Joins("JOIN songs ON songs.id = playlist_songs.song_id").
 		Where("playlists.user_id = ?", userID).
 		Group("playlist_id")
 }
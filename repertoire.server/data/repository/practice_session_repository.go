@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PracticeSessionRepository interface {
+	Get(session *model.PracticeSession, id uuid.UUID) error
+	GetWithSongs(session *model.PracticeSession, id uuid.UUID) error
+	GetInProgressByUser(session *model.PracticeSession, userID uuid.UUID) error
+	GetAllByUser(
+		sessions *[]model.PracticeSession,
+		userID uuid.UUID,
+		currentPage *int,
+		pageSize *int,
+		orderBy []string,
+		searchBy []string,
+	) error
+	GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error
+	Create(session *model.PracticeSession) error
+	CreateSong(sessionSong *model.PracticeSessionSong) error
+	Update(session *model.PracticeSession) error
+	Delete(id uuid.UUID) error
+}
+
+type practiceSessionRepository struct {
+	client database.Client
+}
+
+func NewPracticeSessionRepository(client database.Client) PracticeSessionRepository {
+	return practiceSessionRepository{
+		client: client,
+	}
+}
+
+func (p practiceSessionRepository) Get(session *model.PracticeSession, id uuid.UUID) error {
+	return p.client.Find(&session, model.PracticeSession{ID: id}).Error
+}
+
+func (p practiceSessionRepository) GetWithSongs(session *model.PracticeSession, id uuid.UUID) error {
+	return p.client.
+		Preload("Songs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("practice_session_songs.created_at")
+		}).
+		Preload("Songs.Song").
+		Preload("Songs.Sections").
+		Preload("Songs.Sections.SongSection").
+		Preload("Songs.Sections.SongSection.SongSectionType").
+		Find(&session, model.PracticeSession{ID: id}).
+		Error
+}
+
+func (p practiceSessionRepository) GetInProgressByUser(session *model.PracticeSession, userID uuid.UUID) error {
+	return p.client.
+		Where("ended_at IS NULL").
+		Find(&session, model.PracticeSession{UserID: userID}).
+		Error
+}
+
+func (p practiceSessionRepository) GetAllByUser(
+	sessions *[]model.PracticeSession,
+	userID uuid.UUID,
+	currentPage *int,
+	pageSize *int,
+	orderBy []string,
+	searchBy []string,
+) error {
+	tx := p.client.Model(&model.PracticeSession{}).
+		Preload("Songs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("practice_session_songs.created_at")
+		}).
+		Preload("Songs.Song").
+		Preload("Songs.Sections").
+		Where(model.PracticeSession{UserID: userID})
+
+	database.SearchBy(tx, searchBy, query.PracticeSessionFields)
+	database.OrderBy(tx, orderBy, query.PracticeSessionFields)
+	database.Paginate(tx, currentPage, pageSize)
+	return tx.Find(&sessions).Error
+}
+
+func (p practiceSessionRepository) GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error {
+	tx := p.client.Model(&model.PracticeSession{}).
+		Where(model.PracticeSession{UserID: userID})
+
+	database.SearchBy(tx, searchBy, query.PracticeSessionFields)
+	return tx.Count(count).Error
+}
+
+func (p practiceSessionRepository) Create(session *model.PracticeSession) error {
+	return p.client.Create(&session).Error
+}
+
+func (p practiceSessionRepository) CreateSong(sessionSong *model.PracticeSessionSong) error {
+	return p.client.Create(&sessionSong).Error
+}
+
+func (p practiceSessionRepository) Update(session *model.PracticeSession) error {
+	return p.client.Omit("Songs").Save(&session).Error
+}
+
+func (p practiceSessionRepository) Delete(id uuid.UUID) error {
+	return p.client.Delete(&model.PracticeSession{}, id).Error
+}
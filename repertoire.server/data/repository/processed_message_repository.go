@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedMessageRepository interface {
+	IsProcessed(messageID string, handler string) (bool, error)
+	Create(processedMessage *model.ProcessedMessage) error
+	DeleteOlderThan(date time.Time) error
+}
+
+type processedMessageRepository struct {
+	client database.Client
+}
+
+func NewProcessedMessageRepository(client database.Client) ProcessedMessageRepository {
+	return processedMessageRepository{
+		client: client,
+	}
+}
+
+func (p processedMessageRepository) IsProcessed(messageID string, handler string) (bool, error) {
+	var count int64
+	err := p.client.
+		Model(&model.ProcessedMessage{}).
+		Where(model.ProcessedMessage{MessageID: messageID, Handler: handler}).
+		Count(&count).
+		Error
+	return count != 0, err
+}
+
+func (p processedMessageRepository) Create(processedMessage *model.ProcessedMessage) error {
+	return p.client.Clauses(clause.OnConflict{DoNothing: true}).Create(&processedMessage).Error
+}
+
+func (p processedMessageRepository) DeleteOlderThan(date time.Time) error {
+	return p.client.Where("created_at < ?", date).Delete(&model.ProcessedMessage{}).Error
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SetlistRepository interface {
+	Get(setlist *model.Setlist, id uuid.UUID) error
+	GetWithEntries(setlist *model.Setlist, id uuid.UUID) error
+	GetAllByUser(
+		setlists *[]model.Setlist,
+		userID uuid.UUID,
+		currentPage *int,
+		pageSize *int,
+		orderBy []string,
+		searchBy []string,
+	) error
+	GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error
+	Create(setlist *model.Setlist) error
+	Update(setlist *model.Setlist) error
+	Delete(id uuid.UUID) error
+
+	GetEntry(entry *model.SetlistEntry, id uuid.UUID) error
+	GetEntries(entries *[]model.SetlistEntry, id uuid.UUID) error
+	CreateEntry(entry *model.SetlistEntry) error
+	UpdateEntry(entry *model.SetlistEntry) error
+	UpdateAllEntries(entries *[]model.SetlistEntry) error
+	RemoveEntries(entries *[]model.SetlistEntry) error
+}
+
+type setlistRepository struct {
+	client database.Client
+}
+
+func NewSetlistRepository(client database.Client) SetlistRepository {
+	return setlistRepository{
+		client: client,
+	}
+}
+
+func (s setlistRepository) Get(setlist *model.Setlist, id uuid.UUID) error {
+	return s.client.Find(&setlist, model.Setlist{ID: id}).Error
+}
+
+func (s setlistRepository) GetWithEntries(setlist *model.Setlist, id uuid.UUID) error {
+	return s.client.
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("entry_no")
+		}).
+		Preload("Entries.Song").
+		Preload("Entries.Song.Artist").
+		Find(&setlist, model.Setlist{ID: id}).
+		Error
+}
+
+func (s setlistRepository) GetAllByUser(
+	setlists *[]model.Setlist,
+	userID uuid.UUID,
+	currentPage *int,
+	pageSize *int,
+	orderBy []string,
+	searchBy []string,
+) error {
+	tx := s.client.Model(&model.Setlist{}).
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("entry_no")
+		}).
+		Preload("Entries.Song").
+		Where(model.Setlist{UserID: userID})
+
+	database.SearchBy(tx, searchBy, query.SetlistFields)
+	database.OrderBy(tx, orderBy, query.SetlistFields)
+	database.Paginate(tx, currentPage, pageSize)
+	return tx.Find(&setlists).Error
+}
+
+func (s setlistRepository) GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error {
+	tx := s.client.Model(&model.Setlist{}).
+		Where(model.Setlist{UserID: userID})
+
+	database.SearchBy(tx, searchBy, query.SetlistFields)
+	return tx.Count(count).Error
+}
+
+func (s setlistRepository) Create(setlist *model.Setlist) error {
+	return s.client.Create(&setlist).Error
+}
+
+func (s setlistRepository) Update(setlist *model.Setlist) error {
+	return s.client.Omit("Entries").Save(&setlist).Error
+}
+
+func (s setlistRepository) Delete(id uuid.UUID) error {
+	return s.client.Delete(&model.Setlist{}, id).Error
+}
+
+// Entries
+
+func (s setlistRepository) GetEntry(entry *model.SetlistEntry, id uuid.UUID) error {
+	return s.client.Find(&entry, model.SetlistEntry{ID: id}).Error
+}
+
+func (s setlistRepository) GetEntries(entries *[]model.SetlistEntry, id uuid.UUID) error {
+	return s.client.
+		Order("entry_no").
+		Find(&entries, model.SetlistEntry{SetlistID: id}).
+		Error
+}
+
+func (s setlistRepository) CreateEntry(entry *model.SetlistEntry) error {
+	return s.client.Create(&entry).Error
+}
+
+func (s setlistRepository) UpdateEntry(entry *model.SetlistEntry) error {
+	return s.client.Omit("Song").Save(&entry).Error
+}
+
+func (s setlistRepository) UpdateAllEntries(entries *[]model.SetlistEntry) error {
+	return s.client.Transaction(func(tx *gorm.DB) error {
+		for _, entry := range *entries {
+			if err := tx.Omit("Song").Save(&entry).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s setlistRepository) RemoveEntries(entries *[]model.SetlistEntry) error {
+	return s.client.Delete(&entries).Error
+}
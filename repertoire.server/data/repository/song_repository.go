@@ -3,9 +3,8 @@ package repository
 import (
 	"encoding/json"
 	"repertoire/server/data/database"
+	"repertoire/server/internal/query"
 	"repertoire/server/model"
-	"slices"
-	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -26,13 +25,17 @@ type SongRepository interface {
 		searchBy []string,
 	) error
 	GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error
+	GetSmartPlaylistTotals(playlist *model.EnhancedPlaylist) error
+	GetAllByUserWithSections(songs *[]model.Song, userID uuid.UUID) error
 	GetAllByAlbum(songs *[]model.Song, albumID uuid.UUID) error
 	GetAllByAlbumAndTrackNo(songs *[]model.Song, albumID uuid.UUID, trackNo uint) error
 	GetAllByIDs(songs *[]model.Song, ids []uuid.UUID) error
 	GetAllByIDsWithSections(songs *[]model.Song, ids []uuid.UUID) error
 	GetAllByIDsWithSongs(songs *[]model.Song, ids []uuid.UUID) error
-	GetAllByIDsWithArtistAndAlbum(songs *[]model.Song, ids []uuid.UUID) error
+	GetAllByIDsWithSearchAssociations(songs *[]model.Song, ids []uuid.UUID) error
 	GetAllByIDsWithAlbumsAndPlaylists(songs *[]model.Song, ids []uuid.UUID) error
+	GetAllIDsByGuitarTuning(ids *[]uuid.UUID, guitarTuningID uuid.UUID) error
+	GetAllIDsBySectionType(ids *[]uuid.UUID, sectionTypeID uuid.UUID) error
 	CountByAlbum(count *int64, albumID uuid.UUID) error
 	IsBandMemberAssociatedWithSong(songID uuid.UUID, bandMemberID uuid.UUID) (bool, error)
 	Create(song *model.Song) error
@@ -116,6 +119,8 @@ func (s songRepository) GetFiltersMetadata(
 			"MAX(songs.release_date) AS max_release_date",
 			"MIN(bpm) AS min_bpm",
 			"MAX(bpm) AS max_bpm",
+			"MIN(duration) AS min_duration",
+			"MAX(duration) AS max_duration",
 			"JSON_AGG(DISTINCT difficulty) filter (WHERE difficulty IS NOT NULL) as difficulties",
 			"JSON_AGG(DISTINCT guitar_tuning_id) filter (WHERE guitar_tuning_id IS NOT NULL) as guitar_tuning_ids",
 			"JSON_AGG(DISTINCT song_sections.instrument_id) filter (WHERE instrument_id IS NOT NULL) as instrument_ids",
@@ -139,9 +144,7 @@ func (s songRepository) GetFiltersMetadata(
 		Joins("LEFT JOIN song_sections ON song_sections.song_id = songs.id").
 		Where("user_id = ?", userID)
 
-	searchBy = s.addInstrumentsFilter(tx, searchBy)
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundSongsFields)
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.SongFields)
 	err := tx.Scan(&metadata).Error
 	if err != nil {
 		return err
@@ -179,8 +182,6 @@ func (s songRepository) GetFiltersMetadata(
 	return nil
 }
 
-var compoundSongsFields = []string{"sections_count", "solos_count", "riffs_count"}
-
 func (s songRepository) GetAllByUser(
 	songs *[]model.EnhancedSong,
 	userID uuid.UUID,
@@ -199,14 +200,9 @@ func (s songRepository) GetAllByUser(
 		Where(model.Song{UserID: userID})
 
 	s.addSongSectionsSubQuery(tx, userID)
-	searchBy = s.addPlaylistsFilter(tx, searchBy)
-	searchBy = s.addInstrumentsFilter(tx, searchBy)
-
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundSongsFields)
-	orderBy = database.AddCoalesceToCompoundFields(orderBy, compoundSongsFields)
 
-	database.SearchBy(tx, searchBy)
-	database.OrderBy(tx, orderBy)
+	database.SearchBy(tx, searchBy, query.SongFields)
+	database.OrderBy(tx, orderBy, query.SongFields)
 	database.Paginate(tx, currentPage, pageSize)
 	return tx.Find(&songs).Error
 }
@@ -216,15 +212,65 @@ func (s songRepository) GetAllByUserCount(count *int64, userID uuid.UUID, search
 		Where(model.Song{UserID: userID})
 
 	s.addSongSectionsSubQuery(tx, userID)
-	searchBy = s.addPlaylistsFilter(tx, searchBy)
-	searchBy = s.addInstrumentsFilter(tx, searchBy)
-
-	searchBy = database.AddCoalesceToCompoundFields(searchBy, compoundSongsFields)
 
-	database.SearchBy(tx, searchBy)
+	database.SearchBy(tx, searchBy, query.SongFields)
 	return tx.Count(count).Error
 }
 
+// GetSmartPlaylistTotals counts the songs matching the rules of the smart playlist (up to their limit),
+// and sums their durations
+func (s songRepository) GetSmartPlaylistTotals(playlist *model.EnhancedPlaylist) error {
+	rules := playlist.SmartRules
+	tx := s.client.Model(&model.Song{}).
+		Joins(`LEFT JOIN guitar_tunings AS "GuitarTuning" ON "GuitarTuning".id = songs.guitar_tuning_id`).
+		Joins(`LEFT JOIN artists AS "Artist" ON "Artist".id = songs.artist_id`).
+		Joins(`LEFT JOIN albums AS "Album" ON "Album".id = songs.album_id`).
+		Where(model.Song{UserID: playlist.UserID})
+
+	s.addSongSectionsSubQuery(tx, playlist.UserID)
+	tx.Select("songs.duration")
+
+	database.SearchBy(tx, rules.SearchBy, query.SongFields)
+	if rules.Limit != nil {
+		database.OrderBy(tx, rules.OrderBy, query.SongFields)
+		tx.Limit(int(*rules.Limit))
+	}
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	var totals struct {
+		SongsCount    float64
+		TotalDuration uint
+	}
+	err := s.client.
+		Table("(?) AS smart_playlist_songs", tx).
+		Select(
+			"COUNT(*) AS songs_count",
+			"COALESCE(SUM(smart_playlist_songs.duration), 0) AS total_duration",
+		).
+		Scan(&totals).
+		Error
+	if err != nil {
+		return err
+	}
+
+	playlist.SongsCount = totals.SongsCount
+	playlist.TotalDuration = totals.TotalDuration
+	return nil
+}
+
+func (s songRepository) GetAllByUserWithSections(songs *[]model.Song, userID uuid.UUID) error {
+	return s.client.
+		Joins("Artist").
+		Preload("Sections", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_sections.order")
+		}).
+		Preload("Sections.SongSectionType").
+		Find(&songs, model.Song{UserID: userID}).
+		Error
+}
+
 func (s songRepository) GetAllByIDs(songs *[]model.Song, ids []uuid.UUID) error {
 	return s.client.Model(&model.Song{}).Find(&songs, ids).Error
 }
@@ -244,6 +290,21 @@ func (s songRepository) GetAllByAlbum(songs *[]model.Song, albumID uuid.UUID) er
 		Error
 }
 
+func (s songRepository) GetAllIDsByGuitarTuning(ids *[]uuid.UUID, guitarTuningID uuid.UUID) error {
+	return s.client.Model(&model.Song{}).
+		Where("guitar_tuning_id = ?", guitarTuningID).
+		Pluck("id", ids).
+		Error
+}
+
+func (s songRepository) GetAllIDsBySectionType(ids *[]uuid.UUID, sectionTypeID uuid.UUID) error {
+	return s.client.Model(&model.SongSection{}).
+		Distinct().
+		Where("song_section_type_id = ?", sectionTypeID).
+		Pluck("song_id", ids).
+		Error
+}
+
 func (s songRepository) GetAllByAlbumAndTrackNo(songs *[]model.Song, albumID uuid.UUID, trackNo uint) error {
 	return s.client.Model(&model.Song{}).
 		Where("album_id = ? AND album_track_no > ?", albumID, trackNo).
@@ -260,10 +321,13 @@ func (s songRepository) GetAllByIDsWithSongs(songs *[]model.Song, ids []uuid.UUI
 		Error
 }
 
-func (s songRepository) GetAllByIDsWithArtistAndAlbum(songs *[]model.Song, ids []uuid.UUID) error {
+func (s songRepository) GetAllByIDsWithSearchAssociations(songs *[]model.Song, ids []uuid.UUID) error {
 	return s.client.
 		Joins("Artist").
 		Joins("Album").
+		Joins("GuitarTuning").
+		Preload("Sections").
+		Preload("Sections.SongSectionType").
 		Find(&songs, ids).
 		Error
 }
@@ -398,44 +462,3 @@ func (s songRepository) getSongSectionsSubQuery(userID uuid.UUID) *gorm.DB {
 		Where("songs.user_id = ?", userID).
 		Group("song_id")
 }
-
-func (s songRepository) addPlaylistsFilter(tx *gorm.DB, searchBy []string) []string {
-	propertyPrefix := "playlist_id != "
-	newSearchBy := slices.Clone(searchBy)
-	for i := range newSearchBy {
-		if strings.HasPrefix(newSearchBy[i], propertyPrefix) {
-			playlistId := strings.TrimPrefix(newSearchBy[i], propertyPrefix)
-			playlistsSubQuery := s.client.
-				Select("1").
-				Table("playlist_songs").
-				Where("song_id = songs.id").
-				Where("playlist_id = ?", playlistId)
-			tx.Where(`NOT EXISTS (?)`, playlistsSubQuery)
-			newSearchBy = append(newSearchBy[:i], newSearchBy[i+1:]...)
-			break
-		}
-	}
-	return newSearchBy
-}
-
-func (s songRepository) addInstrumentsFilter(tx *gorm.DB, searchBy []string) []string {
-	propertyPrefix := "instrument_id IN "
-	newSearchBy := slices.Clone(searchBy)
-	for i := range newSearchBy {
-		if strings.HasPrefix(newSearchBy[i], propertyPrefix) {
-			var instrumentIds []string
-			for _, id := range strings.Split(strings.TrimPrefix(newSearchBy[i], propertyPrefix), ",") {
-				instrumentIds = append(instrumentIds, strings.TrimSpace(id))
-			}
-			instrumentsSubQuery := s.client.
-				Select("1").
-				Table("song_sections").
-				Where("song_id = songs.id").
-				Where("instrument_id IN (?)", instrumentIds)
-			tx.Where(`EXISTS (?)`, instrumentsSubQuery)
-			newSearchBy = append(newSearchBy[:i], newSearchBy[i+1:]...)
-			break
-		}
-	}
-	return newSearchBy
-}
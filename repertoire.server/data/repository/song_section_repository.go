@@ -2,13 +2,22 @@ package repository
 
 import (
 	"repertoire/server/data/database"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type SongSectionRepository interface {
 	Get(section *model.SongSection, id uuid.UUID) error
+	GetAllWithHistoryByUser(
+		sections *[]model.SongSection,
+		userID uuid.UUID,
+		scope *enums.TimelineScope,
+		scopeID uuid.UUID,
+	) error
+	GetAllWithHistoryBySongs(sections *[]model.SongSection, userID uuid.UUID, songIDs []uuid.UUID) error
 	CountAllBySong(count *int64, songID uuid.UUID) error
 	Create(section *model.SongSection) error
 	Update(section *model.SongSection) error
@@ -21,7 +30,11 @@ type SongSectionRepository interface {
 		sectionID uuid.UUID,
 		property model.SongSectionProperty,
 	) error
-	CreateHistory(history *model.SongSectionHistory) error
+	CreateHistories(history *[]model.SongSectionHistory) error
+
+	GetLastRehearsalBatch(batch *model.RehearsalBatch, songID uuid.UUID) error
+	CreateRehearsalBatch(batch *model.RehearsalBatch) error
+	DeleteRehearsalBatch(id uuid.UUID) error
 }
 
 type songSectionRepository struct {
@@ -38,6 +51,55 @@ func (s songSectionRepository) Get(section *model.SongSection, id uuid.UUID) err
 	return s.client.Find(&section, model.SongSection{ID: id}).Error
 }
 
+func (s songSectionRepository) GetAllWithHistoryByUser(
+	sections *[]model.SongSection,
+	userID uuid.UUID,
+	scope *enums.TimelineScope,
+	scopeID uuid.UUID,
+) error {
+	tx := s.getAllWithHistoryByUserQuery(userID)
+
+	if scope != nil {
+		switch *scope {
+		case enums.SectionTimeline:
+			tx = tx.Where("song_sections.id = ?", scopeID)
+		case enums.SongTimeline:
+			tx = tx.Where("songs.id = ?", scopeID)
+		case enums.AlbumTimeline:
+			tx = tx.Where("songs.album_id = ?", scopeID)
+		case enums.ArtistTimeline:
+			tx = tx.Where("songs.artist_id = ?", scopeID)
+		case enums.PlaylistTimeline:
+			tx = tx.Where(
+				"songs.id IN (?)",
+				s.client.Model(&model.PlaylistSong{}).Select("song_id").Where("playlist_id = ?", scopeID),
+			)
+		}
+	}
+
+	return tx.Find(&sections).Error
+}
+
+func (s songSectionRepository) GetAllWithHistoryBySongs(
+	sections *[]model.SongSection,
+	userID uuid.UUID,
+	songIDs []uuid.UUID,
+) error {
+	return s.getAllWithHistoryByUserQuery(userID).
+		Where("songs.id IN (?)", songIDs).
+		Find(&sections).
+		Error
+}
+
+func (s songSectionRepository) getAllWithHistoryByUserQuery(userID uuid.UUID) *gorm.DB {
+	return s.client.Model(&model.SongSection{}).
+		Preload("History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_section_histories.created_at")
+		}).
+		Joins("JOIN songs ON songs.id = song_sections.song_id").
+		Where("songs.user_id = ?", userID)
+}
+
 func (s songSectionRepository) CountAllBySong(count *int64, songID uuid.UUID) error {
 	return s.client.Model(&model.SongSection{}).
 		Where(model.SongSection{SongID: songID}).
@@ -80,6 +142,25 @@ func (s songSectionRepository) GetHistory(
 		Error
 }
 
-func (s songSectionRepository) CreateHistory(history *model.SongSectionHistory) error {
+func (s songSectionRepository) CreateHistories(history *[]model.SongSectionHistory) error {
 	return s.client.Create(&history).Error
 }
+
+// Rehearsal Batches
+
+func (s songSectionRepository) GetLastRehearsalBatch(batch *model.RehearsalBatch, songID uuid.UUID) error {
+	return s.client.
+		Preload("History").
+		Order("created_at DESC").
+		Limit(1).
+		Find(&batch, model.RehearsalBatch{SongID: songID}).
+		Error
+}
+
+func (s songSectionRepository) CreateRehearsalBatch(batch *model.RehearsalBatch) error {
+	return s.client.Create(&batch).Error
+}
+
+func (s songSectionRepository) DeleteRehearsalBatch(id uuid.UUID) error {
+	return s.client.Delete(&model.RehearsalBatch{}, id).Error
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"repertoire/server/data/database"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/google/uuid"
@@ -12,8 +14,14 @@ import (
 type UserRepository interface {
 	Get(user *model.User, id uuid.UUID) error
 	GetByEmail(user *model.User, email string) error
+	GetWithAllData(user *model.User, id uuid.UUID) error
+	GetWithSearchableData(user *model.User, id uuid.UUID) error
+	GetAllIDs(ids *[]uuid.UUID) error
+	GetAllIDsByOutdatedScoringStrategy(ids *[]uuid.UUID, strategy enums.ScoringStrategy, version uint) error
+	GetImagePaths(paths *[]string, id uuid.UUID) error
 	Create(user *model.User) error
 	Update(user *model.User) error
+	UpdateScoringStrategyVersion(id uuid.UUID, strategy enums.ScoringStrategy, version uint) error
 	Delete(id uuid.UUID) error
 }
 
@@ -35,6 +43,127 @@ func (u userRepository) GetByEmail(user *model.User, email string) error {
 	return u.client.Find(&user, model.User{Email: email}).Error
 }
 
+func (u userRepository) GetWithAllData(user *model.User, id uuid.UUID) error {
+	return u.client.
+		Preload("Artists", func(db *gorm.DB) *gorm.DB {
+			return db.Order("artists.created_at")
+		}).
+		Preload("Artists.BandMembers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("band_members.order")
+		}).
+		Preload("Artists.BandMembers.Roles").
+		Preload("Albums", func(db *gorm.DB) *gorm.DB {
+			return db.Order("albums.created_at")
+		}).
+		Preload("Songs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("songs.created_at")
+		}).
+		Preload("Songs.Settings").
+		Preload("Songs.Sections", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_sections.order")
+		}).
+		Preload("Songs.Sections.History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_section_histories.created_at")
+		}).
+		Preload("Playlists", func(db *gorm.DB) *gorm.DB {
+			return db.Order("playlists.created_at")
+		}).
+		Preload("Playlists.PlaylistSongs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("playlist_songs.song_track_no")
+		}).
+		Preload("PracticeSessions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("practice_sessions.started_at")
+		}).
+		Preload("PracticeSessions.Songs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("practice_session_songs.created_at")
+		}).
+		Preload("PracticeSessions.Songs.Sections").
+		Preload("Setlists", func(db *gorm.DB) *gorm.DB {
+			return db.Order("setlists.created_at")
+		}).
+		Preload("Setlists.Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("setlist_entries.entry_no")
+		}).
+		Preload("BandMemberRoles", func(db *gorm.DB) *gorm.DB {
+			return db.Order("band_member_roles.order")
+		}).
+		Preload("GuitarTunings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("guitar_tunings.order")
+		}).
+		Preload("Instruments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("instruments.order")
+		}).
+		Preload("SongSectionTypes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("song_section_types.order")
+		}).
+		Find(&user, model.User{ID: id}).
+		Error
+}
+
+func (u userRepository) GetWithSearchableData(user *model.User, id uuid.UUID) error {
+	return u.client.
+		Preload("Artists").
+		Preload("Artists.BandMembers").
+		Preload("Artists.BandMembers.Roles").
+		Preload("Albums").
+		Preload("Albums.Artist").
+		Preload("Songs").
+		Preload("Songs.Artist").
+		Preload("Songs.Album").
+		Preload("Songs.GuitarTuning").
+		Preload("Songs.Sections").
+		Preload("Songs.Sections.SongSectionType").
+		Preload("Playlists").
+		Find(&user, model.User{ID: id}).
+		Error
+}
+
+func (u userRepository) GetAllIDs(ids *[]uuid.UUID) error {
+	return u.client.Model(&model.User{}).Order("created_at").Pluck("id", &ids).Error
+}
+
+func (u userRepository) GetAllIDsByOutdatedScoringStrategy(
+	ids *[]uuid.UUID,
+	strategy enums.ScoringStrategy,
+	version uint,
+) error {
+	return u.client.Model(&model.User{}).
+		Where("scoring_strategy = ? AND scoring_strategy_version <> ?", strategy, version).
+		Order("created_at").
+		Pluck("id", &ids).
+		Error
+}
+
+// GetImagePaths returns the paths of all the images that are referenced by the user and the entities of the user
+func (u userRepository) GetImagePaths(paths *[]string, id uuid.UUID) error {
+	queries := []struct {
+		query  *gorm.DB
+		column string
+	}{
+		{u.client.Model(&model.User{}).Where("id = ?", id), "profile_picture_url"},
+		{u.client.Model(&model.Album{}).Where("user_id = ?", id), "image_url"},
+		{u.client.Model(&model.Artist{}).Where("user_id = ?", id), "image_url"},
+		{
+			u.client.Model(&model.BandMember{}).
+				Joins("JOIN artists ON artists.id = band_members.artist_id").
+				Where("artists.user_id = ?", id),
+			"band_members.image_url",
+		},
+		{u.client.Model(&model.Playlist{}).Where("user_id = ?", id), "image_url"},
+		{u.client.Model(&model.Song{}).Where("user_id = ?", id), "image_url"},
+	}
+
+	for _, q := range queries {
+		var queryPaths []string
+		err := q.query.Where(q.column+" IS NOT NULL").Pluck(q.column, &queryPaths).Error
+		if err != nil {
+			return err
+		}
+		*paths = append(*paths, queryPaths...)
+	}
+	return nil
+}
+
 func (u userRepository) Create(user *model.User) error {
 	return u.client.Create(&user).Error
 }
@@ -43,6 +172,15 @@ func (u userRepository) Update(user *model.User) error {
 	return u.client.Save(&user).Error
 }
 
+// UpdateScoringStrategyVersion marks the scores of the user as computed with the version of the strategy,
+// unless the user has switched to another strategy in the meantime
+func (u userRepository) UpdateScoringStrategyVersion(id uuid.UUID, strategy enums.ScoringStrategy, version uint) error {
+	return u.client.Model(&model.User{}).
+		Where("id = ? AND scoring_strategy = ?", id, strategy).
+		Update("scoring_strategy_version", version).
+		Error
+}
+
 func (u userRepository) Delete(id uuid.UUID) error {
 	return u.client.Select(clause.Associations).Delete(&model.User{ID: id}).Error
 }
@@ -0,0 +1,141 @@
+package search
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"repertoire/server/model"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// the attributes that are not searchable, as they are identifiers, links or dates
+var nonSearchableAttributes = []string{"id", "userId", "type", "imageUrl", "createdAt", "updatedAt", "releaseDate"}
+
+// NewPostgresDocument creates the row of a search document, alongside all its searchable texts
+func NewPostgresDocument(document map[string]any) (model.SearchDocument, error) {
+	id, _ := document["id"].(string)
+	documentType, _ := document["type"].(string)
+	userID, _ := document["userId"].(string)
+	if id == "" || documentType == "" {
+		return model.SearchDocument{}, errors.New("search document is missing the id or the type")
+	}
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return model.SearchDocument{}, err
+	}
+
+	return model.SearchDocument{
+		ID:       id,
+		UserID:   parsedUserID,
+		Type:     documentType,
+		Document: document,
+		Content:  strings.Join(searchableTexts(document), " "),
+	}, nil
+}
+
+func searchableTexts(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case []any:
+		var texts []string
+		for _, item := range v {
+			texts = append(texts, searchableTexts(item)...)
+		}
+		return texts
+	case map[string]any:
+		var texts []string
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			if slices.Contains(nonSearchableAttributes, key) || strings.HasSuffix(key, "Id") {
+				continue
+			}
+			texts = append(texts, searchableTexts(v[key])...)
+		}
+		return texts
+	}
+	return nil
+}
+
+// QueryWords splits the query into its words, without any punctuation
+func QueryWords(query string) []string {
+	words := wordRegex.FindAllString(strings.ToLower(query), -1)
+	return slices.Compact(words)
+}
+
+// ToPostgresTextQuery combines the words into a full-text query,
+// where the words can be prefixes (as the user might still be typing) and can be required all or any of them
+func ToPostgresTextQuery(words []string, prefix bool, matchAll bool) string {
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word
+		if prefix {
+			terms[i] += ":*"
+		}
+	}
+	operator := " | "
+	if matchAll {
+		operator = " & "
+	}
+	return strings.Join(terms, operator)
+}
+
+// Highlight wraps the words of the text that match the query in <em> tags (like Meilisearch does),
+// and crops the text around the first match, when it has more words than the crop length
+func Highlight(text string, queryWords []string, prefix bool, cropLength int) string {
+	indexes := wordRegex.FindAllStringIndex(text, -1)
+	if len(indexes) == 0 {
+		return text
+	}
+	isMatch := func(word string) bool {
+		word = strings.ToLower(word)
+		return slices.ContainsFunc(queryWords, func(queryWord string) bool {
+			return word == queryWord || prefix && strings.HasPrefix(word, queryWord)
+		})
+	}
+
+	start, end := 0, len(indexes)
+	if cropLength > 0 && len(indexes) > cropLength {
+		firstMatch := slices.IndexFunc(indexes, func(index []int) bool {
+			return isMatch(text[index[0]:index[1]])
+		})
+		start = max(0, firstMatch-cropLength/2)
+		end = min(len(indexes), start+cropLength)
+		start = max(0, end-cropLength)
+	}
+
+	var result strings.Builder
+	if start > 0 {
+		result.WriteString("…")
+	}
+	textStart, textEnd := 0, len(text)
+	if start > 0 {
+		textStart = indexes[start][0]
+	}
+	if end < len(indexes) {
+		textEnd = indexes[end-1][1]
+	}
+	previous := textStart
+	for _, index := range indexes[start:end] {
+		result.WriteString(text[previous:index[0]])
+		word := text[index[0]:index[1]]
+		if isMatch(word) {
+			result.WriteString("<em>" + word + "</em>")
+		} else {
+			result.WriteString(word)
+		}
+		previous = index[1]
+	}
+	result.WriteString(text[previous:textEnd])
+	if end < len(indexes) {
+		result.WriteString("…")
+	}
+	return result.String()
+}
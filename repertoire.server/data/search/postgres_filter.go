@@ -0,0 +1,365 @@
+package search
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ToPostgresCondition translates a Meilisearch filter expression into a condition on the jsonb documents,
+// so that the same filters keep working when the documents are searched in Postgres.
+// Only the given attributes can be filtered by, just like the filterable attributes of Meilisearch.
+func ToPostgresCondition(filter string, filterableAttributes []string) (string, []any, error) {
+	tokens, err := tokenizeFilter(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	parser := filterParser{tokens: tokens, filterableAttributes: filterableAttributes}
+	condition, err := parser.parseOr()
+	if err != nil {
+		return "", nil, err
+	}
+	if !parser.isAtEnd() {
+		return "", nil, errors.New("invalid filter, unexpected " + parser.peek().text)
+	}
+	return condition, parser.args, nil
+}
+
+// ToPostgresPath returns the expression of the attribute inside the jsonb document (e.g. artist.id -> {artist,id})
+func ToPostgresPath(attribute string) string {
+	return "document #> '{" + strings.ReplaceAll(attribute, ".", ",") + "}'"
+}
+
+// ToPostgresTextPath returns the expression of the attribute inside the jsonb document, as text
+func ToPostgresTextPath(attribute string) string {
+	return "document #>> '{" + strings.ReplaceAll(attribute, ".", ",") + "}'"
+}
+
+// Tokenizer
+
+type filterTokenKind int
+
+const (
+	wordToken filterTokenKind = iota
+	stringToken
+	operatorToken
+	punctuationToken
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == wordToken && strings.EqualFold(t.text, keyword)
+}
+
+func (t filterToken) isPunctuation(punctuation string) bool {
+	return t.kind == punctuationToken && t.text == punctuation
+}
+
+func tokenizeFilter(filter string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			tokens = append(tokens, filterToken{kind: punctuationToken, text: string(r)})
+			i++
+
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			operator := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				operator += "="
+			}
+			if operator == "!" {
+				return nil, errors.New("invalid filter, unexpected !")
+			}
+			tokens = append(tokens, filterToken{kind: operatorToken, text: operator})
+			i += len(operator)
+
+		case r == '"' || r == '\'':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				value.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, errors.New("invalid filter, unterminated string")
+			}
+			tokens = append(tokens, filterToken{kind: stringToken, text: value.String()})
+			i = j + 1
+
+		default:
+			j := i
+			for j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune("()[],=!<>\"'", runes[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: wordToken, text: string(runes[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// Parser
+
+type filterParser struct {
+	tokens               []filterToken
+	position             int
+	args                 []any
+	filterableAttributes []string
+}
+
+func (p *filterParser) isAtEnd() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.isAtEnd() {
+		return filterToken{}
+	}
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() (filterToken, error) {
+	if p.isAtEnd() {
+		return filterToken{}, errors.New("invalid filter, unexpected end")
+	}
+	p.position++
+	return p.tokens[p.position-1], nil
+}
+
+func (p *filterParser) expectPunctuation(punctuation string) error {
+	token, err := p.next()
+	if err != nil {
+		return err
+	}
+	if !token.isPunctuation(punctuation) {
+		return errors.New("invalid filter, expected " + punctuation + " instead of " + token.text)
+	}
+	return nil
+}
+
+func (p *filterParser) parseOr() (string, error) {
+	condition, err := p.parseAnd()
+	if err != nil {
+		return "", err
+	}
+	for p.peek().isKeyword("OR") {
+		p.position++
+		right, err := p.parseAnd()
+		if err != nil {
+			return "", err
+		}
+		condition = "(" + condition + " OR " + right + ")"
+	}
+	return condition, nil
+}
+
+func (p *filterParser) parseAnd() (string, error) {
+	condition, err := p.parseNot()
+	if err != nil {
+		return "", err
+	}
+	for p.peek().isKeyword("AND") {
+		p.position++
+		right, err := p.parseNot()
+		if err != nil {
+			return "", err
+		}
+		condition = "(" + condition + " AND " + right + ")"
+	}
+	return condition, nil
+}
+
+func (p *filterParser) parseNot() (string, error) {
+	if p.peek().isKeyword("NOT") {
+		p.position++
+		condition, err := p.parseNot()
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + condition, nil
+	}
+	if p.peek().isPunctuation("(") {
+		p.position++
+		condition, err := p.parseOr()
+		if err != nil {
+			return "", err
+		}
+		return condition, p.expectPunctuation(")")
+	}
+	return p.parseCondition()
+}
+
+func (p *filterParser) parseCondition() (string, error) {
+	attributeToken, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	attribute := attributeToken.text
+	if attributeToken.kind != wordToken || !slices.Contains(p.filterableAttributes, attribute) {
+		return "", errors.New("invalid filter, attribute " + attribute + " is not filterable")
+	}
+
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case token.kind == operatorToken:
+		value, err := p.parseValue()
+		if err != nil {
+			return "", err
+		}
+		if token.text == "=" {
+			return p.equal(attribute, value), nil
+		}
+		if token.text == "!=" {
+			return "NOT " + p.equal(attribute, value), nil
+		}
+		return p.compare(attribute, token.text, value), nil
+
+	case token.isKeyword("IN"):
+		return p.parseIn(attribute)
+
+	case token.isKeyword("EXISTS"):
+		return "(" + ToPostgresPath(attribute) + " IS NOT NULL)", nil
+
+	case token.isKeyword("IS"):
+		return p.parseIs(attribute)
+
+	case token.isKeyword("NOT"):
+		notToken, err := p.next()
+		if err != nil {
+			return "", err
+		}
+		if notToken.isKeyword("IN") {
+			condition, err := p.parseIn(attribute)
+			return "NOT " + condition, err
+		}
+		if notToken.isKeyword("EXISTS") {
+			return "(" + ToPostgresPath(attribute) + " IS NULL)", nil
+		}
+		return "", errors.New("invalid filter, unexpected " + notToken.text + " after NOT")
+
+	case token.kind == wordToken || token.kind == stringToken:
+		// range, e.g. releaseYear 2000 TO 2010
+		toToken, err := p.next()
+		if err != nil {
+			return "", err
+		}
+		if !toToken.isKeyword("TO") {
+			return "", errors.New("invalid filter, unexpected " + toToken.text)
+		}
+		to, err := p.parseValue()
+		if err != nil {
+			return "", err
+		}
+		return "(" + p.compare(attribute, ">=", token.text) + " AND " + p.compare(attribute, "<=", to) + ")", nil
+	}
+
+	return "", errors.New("invalid filter, unexpected " + token.text)
+}
+
+func (p *filterParser) parseValue() (string, error) {
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if token.kind != wordToken && token.kind != stringToken {
+		return "", errors.New("invalid filter, expected a value instead of " + token.text)
+	}
+	return token.text, nil
+}
+
+func (p *filterParser) parseIn(attribute string) (string, error) {
+	err := p.expectPunctuation("[")
+	if err != nil {
+		return "", err
+	}
+
+	var conditions []string
+	for !p.peek().isPunctuation("]") {
+		value, err := p.parseValue()
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, p.equal(attribute, value))
+		if p.peek().isPunctuation(",") {
+			p.position++
+		}
+	}
+	p.position++
+
+	if len(conditions) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+func (p *filterParser) parseIs(attribute string) (string, error) {
+	token, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	negation := ""
+	if token.isKeyword("NOT") {
+		negation = "NOT "
+		token, err = p.next()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	path := ToPostgresPath(attribute)
+	switch {
+	case token.isKeyword("NULL"):
+		return negation + "COALESCE(" + path + " = 'null'::jsonb, FALSE)", nil
+	case token.isKeyword("EMPTY"):
+		return negation + "COALESCE(" + path + " IN ('\"\"'::jsonb, '[]'::jsonb, '{}'::jsonb), FALSE)", nil
+	}
+	return "", errors.New("invalid filter, unexpected " + token.text + " after IS")
+}
+
+// equal compares case-insensitively, and numerically when both sides are numbers, like Meilisearch does
+func (p *filterParser) equal(attribute string, value string) string {
+	path := ToPostgresPath(attribute)
+	textPath := ToPostgresTextPath(attribute)
+
+	p.args = append(p.args, value)
+	condition := "LOWER(" + textPath + ") = LOWER(?)"
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		p.args = append(p.args, number)
+		condition += " OR CASE WHEN jsonb_typeof(" + path + ") = 'number' THEN (" + textPath + ")::numeric = ? END"
+	}
+	return "COALESCE(" + condition + ", FALSE)"
+}
+
+// compare compares numerically when the value is a number, otherwise lexicographically
+func (p *filterParser) compare(attribute string, operator string, value string) string {
+	path := ToPostgresPath(attribute)
+	textPath := ToPostgresTextPath(attribute)
+
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		p.args = append(p.args, number)
+		return "COALESCE(CASE WHEN jsonb_typeof(" + path + ") = 'number' " +
+			"THEN (" + textPath + ")::numeric " + operator + " ? END, FALSE)"
+	}
+	p.args = append(p.args, value)
+	return "COALESCE(CASE WHEN jsonb_typeof(" + path + ") = 'string' " +
+		"THEN " + textPath + " " + operator + " ? END, FALSE)"
+}
@@ -1,15 +1,16 @@
 package service
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
+	"repertoire/server/data/http/auth"
 	"repertoire/server/data/http/client"
 	"repertoire/server/internal/wrapper"
 )
 
 type AuthService interface {
-	SignIn(email string, password string) (string, *wrapper.ErrorCode)
+	SignIn(email string, password string) (auth.SignInResponse, *wrapper.ErrorCode)
+	RequestEmailVerification(token string) *wrapper.ErrorCode
 }
 
 type authService struct {
@@ -20,17 +21,25 @@ func NewAuthService(authClient client.AuthClient) AuthService {
 	return &authService{authClient: authClient}
 }
 
-func (a authService) SignIn(email string, password string) (string, *wrapper.ErrorCode) {
-	response, err := a.authClient.SignIn(email, password)
+func (a authService) SignIn(email string, password string) (auth.SignInResponse, *wrapper.ErrorCode) {
+	var result auth.SignInResponse
+	response, err := a.authClient.SignIn(email, password, &result)
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return auth.SignInResponse{}, wrapper.InternalServerError(err)
 	}
 	if response.StatusCode() != http.StatusOK {
-		return "", wrapper.InternalServerError(errors.New("failed to sign in" + response.String()))
+		return auth.SignInResponse{}, wrapper.InternalServerError(errors.New("failed to sign in" + response.String()))
 	}
-	var token string
-	if err = json.Unmarshal(response.Body(), &token); err != nil {
-		return "", wrapper.InternalServerError(err)
+	return result, nil
+}
+
+func (a authService) RequestEmailVerification(token string) *wrapper.ErrorCode {
+	response, err := a.authClient.RequestEmailVerification(token)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return wrapper.InternalServerError(errors.New("failed to request email verification" + response.String()))
 	}
-	return token, nil
+	return nil
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"repertoire/server/internal"
 	"repertoire/server/internal/wrapper"
 
@@ -9,7 +10,8 @@ import (
 )
 
 type JwtService interface {
-	Authorize(tokenString string) *wrapper.ErrorCode
+	// Authorize validates the token and returns its ID (jti)
+	Authorize(tokenString string) (uuid.UUID, *wrapper.ErrorCode)
 	GetUserIdFromJwt(tokenString string) (uuid.UUID, *wrapper.ErrorCode)
 }
 
@@ -23,19 +25,28 @@ func NewJwtService(env internal.Env) JwtService {
 	}
 }
 
-func (j *jwtService) Authorize(tokenString string) *wrapper.ErrorCode {
+func (j *jwtService) Authorize(tokenString string) (uuid.UUID, *wrapper.ErrorCode) {
 	publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(j.env.JwtPublicKey))
 	if err != nil {
-		return wrapper.InternalServerError(err)
+		return uuid.Nil, wrapper.InternalServerError(err)
 	}
-	_, err = jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 		return publicKey, nil
 	})
 	if err != nil {
-		return wrapper.UnauthorizedError(err)
+		return uuid.Nil, wrapper.UnauthorizedError(err)
+	}
+
+	jti, found := token.Claims.(jwt.MapClaims)["jti"].(string)
+	if !found {
+		return uuid.Nil, wrapper.UnauthorizedError(errors.New("missing jti"))
+	}
+	tokenID, err := uuid.Parse(jti)
+	if err != nil {
+		return uuid.Nil, wrapper.UnauthorizedError(err)
 	}
 
-	return nil
+	return tokenID, nil
 }
 
 func (j *jwtService) GetUserIdFromJwt(tokenString string) (uuid.UUID, *wrapper.ErrorCode) {
@@ -3,36 +3,83 @@ package service
 import (
 	"encoding/json"
 	"repertoire/server/data/message"
+	"repertoire/server/data/repository"
 	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
 
 	"github.com/ThreeDotsLabs/watermill"
 	watermillMessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
 )
 
+// HandlerMetadataKey targets a message to a single handler, instead of all the handlers of its topic
+const HandlerMetadataKey = "handler"
+
 type MessagePublisherService interface {
 	GetClient() message.Publisher
 	Publish(topic topics.Topic, messagePayload any) error
+	PublishWithinTransaction(outboxRepository repository.OutboxRepository, topic topics.Topic, messagePayload any) error
+	Relay(outboxMessage model.OutboxMessage) error
+	Replay(deadLetterMessage model.DeadLetterMessage) error
 }
 
 type messagePublisherService struct {
-	client message.Publisher
+	client           message.Publisher
+	outboxRepository repository.OutboxRepository
 }
 
-func NewMessagePublisherService(client message.Publisher) MessagePublisherService {
-	return messagePublisherService{client: client}
+func NewMessagePublisherService(
+	client message.Publisher,
+	outboxRepository repository.OutboxRepository,
+) MessagePublisherService {
+	return messagePublisherService{
+		client:           client,
+		outboxRepository: outboxRepository,
+	}
 }
 
 func (m messagePublisherService) GetClient() message.Publisher {
 	return m.client
 }
 
+// Publish saves the message in the outbox on its own, for the messages that do not come with a change
+// (otherwise, PublishWithinTransaction has to be used), so that it still reaches the broker after a crash
 func (m messagePublisherService) Publish(topic topics.Topic, data any) error {
+	return m.PublishWithinTransaction(m.outboxRepository, topic, data)
+}
+
+// PublishWithinTransaction saves the message in the outbox through a repository of the ongoing transaction,
+// so that it is committed (or rolled back) together with the change that produced it.
+// The message reaches the broker once it is relayed
+func (m messagePublisherService) PublishWithinTransaction(
+	outboxRepository repository.OutboxRepository,
+	topic topics.Topic,
+	data any,
+) error {
 	bytes, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
-	msg := watermillMessage.NewMessage(watermill.NewUUID(), bytes)
-	msg.Metadata.Set("topic", string(topic))
-	queue := string(topics.TopicToQueueMap[topic])
-	return m.client.Publish(queue, msg)
+	return outboxRepository.Create(&model.OutboxMessage{
+		ID:      uuid.New(),
+		Queue:   string(topics.TopicToQueueMap[topic]),
+		Topic:   string(topic),
+		Payload: bytes,
+	})
+}
+
+// Relay publishes a message from the outbox, by keeping its ID,
+// so that a message relayed twice is skipped by the handlers that have already handled it
+func (m messagePublisherService) Relay(outboxMessage model.OutboxMessage) error {
+	msg := watermillMessage.NewMessage(outboxMessage.ID.String(), outboxMessage.Payload)
+	msg.Metadata.Set("topic", outboxMessage.Topic)
+	return m.client.Publish(outboxMessage.Queue, msg)
+}
+
+// Replay publishes a dead letter message again, only for the handler that could not handle it
+func (m messagePublisherService) Replay(deadLetterMessage model.DeadLetterMessage) error {
+	msg := watermillMessage.NewMessage(watermill.NewUUID(), watermillMessage.Payload(deadLetterMessage.Payload))
+	msg.Metadata.Set("topic", deadLetterMessage.Topic)
+	msg.Metadata.Set(HandlerMetadataKey, deadLetterMessage.Handler)
+	return m.client.Publish(deadLetterMessage.Queue, msg)
 }
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"errors"
+	"maps"
+	"repertoire/server/data/database"
+	"repertoire/server/data/logger"
+	"repertoire/server/data/search"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// the same attributes as the ones configured on the Meilisearch index, through the search migrations
+var searchFilterableAttributes = []string{
+	"id", "type", "userId", "album", "album.id", "artist", "artist.id", "song", "song.id",
+	"releaseYear", "difficulty", "guitarTuningId",
+}
+var searchSortableAttributes = []string{
+	"title", "name", "updatedAt", "createdAt", "album", "album.title", "artist", "artist.name", "releaseYear",
+}
+
+// Meilisearch returns only 20 hits when no limit is given
+const searchDefaultLimit = 20
+
+// like Meilisearch, the typos are tolerated only on words that are long enough
+const searchMinQueryLengthForTypos = 4
+
+type postgresSearchEngineService struct {
+	client          database.Client
+	realTimeService RealTimeService
+	logger          *logger.Logger
+}
+
+// newPostgresSearchEngineService searches the documents with Postgres full-text search (and trigrams for the typos),
+// so that the application can also run without Meilisearch
+func newPostgresSearchEngineService(
+	client database.Client,
+	realTimeService RealTimeService,
+	logger *logger.Logger,
+) SearchEngineService {
+	return postgresSearchEngineService{
+		client:          client,
+		realTimeService: realTimeService,
+		logger:          logger,
+	}
+}
+
+func (p postgresSearchEngineService) Search(
+	query string,
+	currentPage *int,
+	pageSize *int,
+	searchType *enums.SearchType,
+	userID uuid.UUID,
+	filter []string,
+	sort []string,
+	options model.SearchOptions,
+) (model.SearchResults[map[string]any], *wrapper.ErrorCode) {
+	tx := p.client.Model(&model.SearchDocument{}).Where("user_id = ?", userID)
+
+	// filtering
+	if searchType != nil {
+		tx = tx.Where("type = ?", string(*searchType))
+	}
+	for _, f := range filter {
+		condition, args, err := search.ToPostgresCondition(f, searchFilterableAttributes)
+		if err != nil {
+			return model.SearchResults[map[string]any]{}, wrapper.BadRequestError(err)
+		}
+		tx = tx.Where(condition, args...)
+	}
+
+	// matching (by default, the documents match any of the words, but the ones matching more rank higher)
+	words := search.QueryWords(query)
+	isTypoTolerant := options.TypoTolerance == nil || *options.TypoTolerance
+	matchAll := options.MatchingStrategy != nil && *options.MatchingStrategy == enums.AllMatchingStrategy
+	textQuery := search.ToPostgresTextQuery(words, isTypoTolerant, matchAll)
+	if len(words) > 0 {
+		if isTypoTolerant && len(query) >= searchMinQueryLengthForTypos {
+			tx = tx.Where("(content_vector @@ to_tsquery('simple', ?) OR ? <% content)", textQuery, query)
+		} else {
+			tx = tx.Where("content_vector @@ to_tsquery('simple', ?)", textQuery)
+		}
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	var totalCount int64
+	err := tx.Count(&totalCount).Error
+	if err != nil {
+		return model.SearchResults[map[string]any]{}, wrapper.InternalServerError(err)
+	}
+
+	// ordering
+	ordered := tx
+	for _, s := range sort {
+		attribute, direction, _ := strings.Cut(s, ":")
+		if !slices.Contains(searchSortableAttributes, attribute) {
+			return model.SearchResults[map[string]any]{},
+				wrapper.BadRequestError(errors.New("attribute " + attribute + " is not sortable"))
+		}
+		// the documents without the attribute are always last
+		order := "NULLIF(" + search.ToPostgresPath(attribute) + ", 'null'::jsonb)"
+		if strings.EqualFold(direction, "desc") {
+			ordered = ordered.Order(order + " DESC NULLS LAST")
+		} else {
+			ordered = ordered.Order(order + " ASC NULLS LAST")
+		}
+	}
+	if len(words) > 0 {
+		ordered = ordered.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(content_vector, to_tsquery('simple', ?)) + word_similarity(?, content) DESC",
+			Vars:               []any{textQuery, query},
+			WithoutParentheses: true,
+		}})
+	}
+	ordered = ordered.Order("id")
+
+	// pagination
+	if currentPage != nil && pageSize != nil {
+		ordered = database.Paginate(ordered, currentPage, pageSize)
+	} else {
+		ordered = ordered.Limit(searchDefaultLimit)
+	}
+
+	var documents []model.SearchDocument
+	err = ordered.Find(&documents).Error
+	if err != nil {
+		return model.SearchResults[map[string]any]{}, wrapper.InternalServerError(err)
+	}
+
+	results := make([]map[string]any, len(documents))
+	for i, document := range documents {
+		results[i] = document.Document
+		if options.Highlight {
+			results[i]["formatted"] = p.highlight(document.Document, words, isTypoTolerant)
+		}
+	}
+	result := model.SearchResults[map[string]any]{
+		WithTotalCount: wrapper.WithTotalCount[map[string]any]{
+			Models:     results,
+			TotalCount: totalCount,
+		},
+	}
+
+	if options.Facets {
+		result.Facets, result.FacetStats, err = p.getFacets(tx)
+		if err != nil {
+			return model.SearchResults[map[string]any]{}, wrapper.InternalServerError(err)
+		}
+	}
+
+	return result, nil
+}
+
+func (p postgresSearchEngineService) highlight(document map[string]any, words []string, prefix bool) map[string]any {
+	formatted := map[string]any{}
+	for _, attribute := range searchHighlightedAttributes {
+		if text, ok := document[attribute].(string); ok {
+			formatted[attribute] = search.Highlight(text, words, prefix, int(searchCropLength))
+		}
+	}
+	return formatted
+}
+
+// getFacets counts the documents by each value of the faceted attributes,
+// and, like Meilisearch, computes the stats of the attributes that have only numeric values
+func (p postgresSearchEngineService) getFacets(
+	tx *gorm.DB,
+) (map[string]map[string]int64, map[string]model.SearchFacetStats, error) {
+	facets := map[string]map[string]int64{}
+	facetStats := map[string]model.SearchFacetStats{}
+	for _, facet := range searchFacets {
+		var distribution []struct {
+			Value string
+			Count int64
+		}
+		err := tx.
+			Select(search.ToPostgresTextPath(facet) + " AS value, COUNT(*) AS count").
+			Where("jsonb_typeof(" + search.ToPostgresPath(facet) + ") IN ('string', 'number', 'boolean')").
+			Group("value").
+			Scan(&distribution).
+			Error
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(distribution) == 0 {
+			continue
+		}
+
+		facets[facet] = map[string]int64{}
+		var numbers []float64
+		for _, d := range distribution {
+			facets[facet][d.Value] = d.Count
+			if number, err := strconv.ParseFloat(d.Value, 64); err == nil {
+				numbers = append(numbers, number)
+			}
+		}
+		if len(numbers) == len(distribution) {
+			facetStats[facet] = model.SearchFacetStats{Min: slices.Min(numbers), Max: slices.Max(numbers)}
+		}
+	}
+	return facets, facetStats, nil
+}
+
+func (p postgresSearchEngineService) GetDocument(id string) (map[string]any, error) {
+	var document model.SearchDocument
+	err := p.client.Take(&document, "id = ?", id).Error
+	if err != nil {
+		return map[string]any{}, err
+	}
+	return document.Document, nil
+}
+
+func (p postgresSearchEngineService) GetDocuments(filter string) ([]map[string]any, error) {
+	condition, args, err := search.ToPostgresCondition(filter, searchFilterableAttributes)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	var documents []model.SearchDocument
+	err = p.client.Where(condition, args...).Order("id").Find(&documents).Error
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	results := make([]map[string]any, len(documents))
+	for i, document := range documents {
+		results[i] = document.Document
+	}
+	return results, nil
+}
+
+// Add replaces the documents with the same ID, like Meilisearch does.
+// There are no tasks to track, as the documents are saved right away, so the returned task ID is always 0
+func (p postgresSearchEngineService) Add(items []map[string]any) (int64, error) {
+	documents := make([]model.SearchDocument, len(items))
+	for i, item := range items {
+		document, err := search.NewPostgresDocument(item)
+		if err != nil {
+			return 0, err
+		}
+		documents[i] = document
+	}
+
+	err := p.client.Clauses(clause.OnConflict{UpdateAll: true}).Create(&documents).Error
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidateCache(documents)
+	return 0, nil
+}
+
+// Update merges the fields into the existing documents, and skips the ones that do not exist, like Meilisearch does
+func (p postgresSearchEngineService) Update(items []map[string]any) (int64, error) {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i], _ = item["id"].(string)
+	}
+
+	var documents []model.SearchDocument
+	err := p.client.Transaction(func(tx *gorm.DB) error {
+		var existingDocuments []model.SearchDocument
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Find(&existingDocuments, "id IN ?", ids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			index := slices.IndexFunc(existingDocuments, func(d model.SearchDocument) bool {
+				return d.ID == item["id"]
+			})
+			if index == -1 {
+				continue
+			}
+
+			merged := maps.Clone(existingDocuments[index].Document)
+			maps.Copy(merged, item)
+			document, err := search.NewPostgresDocument(merged)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, document)
+		}
+
+		if len(documents) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&documents).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidateCache(documents)
+	return 0, nil
+}
+
+func (p postgresSearchEngineService) Delete(ids []string) (int64, error) {
+	var documents []model.SearchDocument
+	err := p.client.
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "user_id"}}}).
+		Where("id IN ?", ids).
+		Delete(&documents).
+		Error
+	if err != nil {
+		return 0, err
+	}
+
+	p.invalidateCache(documents)
+	return 0, nil
+}
+
+// HasTaskSucceeded is never needed, as there are no tasks (and no webhook calls) when searching with Postgres
+func (p postgresSearchEngineService) HasTaskSucceeded(string) bool {
+	return false
+}
+
+// invalidateCache notifies the users right away, as there is no webhook to wait for, like with Meilisearch.
+// The documents are already saved, so failing to notify is only logged
+func (p postgresSearchEngineService) invalidateCache(documents []model.SearchDocument) {
+	var userIDs []uuid.UUID
+	for _, document := range documents {
+		if !slices.Contains(userIDs, document.UserID) {
+			userIDs = append(userIDs, document.UserID)
+		}
+	}
+
+	for _, userID := range userIDs {
+		err := p.realTimeService.Publish("search", userID.String(), map[string]any{"action": "SEARCH_CACHE_INVALIDATION"})
+		if err != nil {
+			p.logger.Warn("Failed to invalidate the search cache of user "+userID.String(), zap.Error(err))
+		}
+	}
+}
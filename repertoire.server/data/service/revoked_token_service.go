@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/data/http/auth"
+	"repertoire/server/data/http/client"
+	"repertoire/server/data/logger"
+	"repertoire/server/internal"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RevokedTokenService interface {
+	IsRevoked(tokenID uuid.UUID) bool
+}
+
+// revokedTokenService keeps a copy of the revocation list of the Auth Service,
+// which is fetched again once it gets older than the refresh interval.
+// When the fetch fails, the last known list is kept.
+type revokedTokenService struct {
+	authClient      client.AuthClient
+	logger          *logger.Logger
+	refreshInterval time.Duration
+
+	mutex         sync.RWMutex
+	revokedTokens map[uuid.UUID]time.Time
+	fetchedAt     time.Time
+	fetching      bool
+}
+
+func NewRevokedTokenService(authClient client.AuthClient, env internal.Env, logger *logger.Logger) RevokedTokenService {
+	refreshInterval, err := time.ParseDuration(env.RevokedTokensRefreshInterval)
+	if err != nil {
+		refreshInterval = time.Minute
+	}
+	return &revokedTokenService{
+		authClient:      authClient,
+		logger:          logger,
+		refreshInterval: refreshInterval,
+		revokedTokens:   map[uuid.UUID]time.Time{},
+	}
+}
+
+func (r *revokedTokenService) IsRevoked(tokenID uuid.UUID) bool {
+	r.refreshIfStale()
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	expiresAt, found := r.revokedTokens[tokenID]
+	return found && time.Now().Before(expiresAt)
+}
+
+func (r *revokedTokenService) refreshIfStale() {
+	// only one request fetches the list, while the others keep using the current one
+	r.mutex.Lock()
+	if r.fetching || time.Since(r.fetchedAt) < r.refreshInterval {
+		r.mutex.Unlock()
+		return
+	}
+	r.fetching = true
+	r.mutex.Unlock()
+
+	revokedTokens, err := r.fetch()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.fetching = false
+	r.fetchedAt = time.Now()
+	if err != nil {
+		r.logger.Warn("Failed to fetch the revoked tokens", zap.Error(err))
+		return
+	}
+	r.revokedTokens = revokedTokens
+}
+
+func (r *revokedTokenService) fetch() (map[uuid.UUID]time.Time, error) {
+	var result []auth.RevokedTokenResponse
+	response, err := r.authClient.RevokedTokens(&result)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, errors.New("failed to fetch revoked tokens: " + response.String())
+	}
+
+	revokedTokens := make(map[uuid.UUID]time.Time, len(result))
+	for _, revokedToken := range result {
+		revokedTokens[revokedToken.ID] = revokedToken.ExpiresAt
+	}
+	return revokedTokens, nil
+}
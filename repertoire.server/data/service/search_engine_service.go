@@ -1,15 +1,29 @@
 package service
 
 import (
+	"encoding/json"
+	"repertoire/server/data/database"
+	"repertoire/server/data/logger"
 	"repertoire/server/data/search"
+	"repertoire/server/internal"
 	"repertoire/server/internal/enums"
 	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/meilisearch/meilisearch-go"
 )
 
+// Meilisearch returns only 20 documents when no limit is given
+const getDocumentsPageSize int64 = 1000
+
+// the number of words kept around a highlighted match
+const searchCropLength int64 = 10
+
+var searchFacets = []string{"type", "artist.id", "album.id", "releaseYear", "difficulty", "guitarTuningId"}
+var searchHighlightedAttributes = []string{"title", "name"}
+
 type SearchEngineService interface {
 	Search(
 		query string,
@@ -19,7 +33,8 @@ type SearchEngineService interface {
 		userID uuid.UUID,
 		filter []string,
 		sort []string,
-	) (wrapper.WithTotalCount[map[string]any], *wrapper.ErrorCode)
+		options model.SearchOptions,
+	) (model.SearchResults[map[string]any], *wrapper.ErrorCode)
 	GetDocument(id string) (map[string]any, error)
 	GetDocuments(filter string) ([]map[string]any, error)
 	Add(items []map[string]any) (int64, error)
@@ -32,7 +47,18 @@ type searchEngineService struct {
 	client search.MeiliClient
 }
 
-func NewSearchEngineService(client search.MeiliClient) SearchEngineService {
+// NewSearchEngineService uses Meilisearch by default,
+// but Postgres full-text search can be configured instead, to run without another service
+func NewSearchEngineService(
+	env internal.Env,
+	client search.MeiliClient,
+	dbClient database.Client,
+	realTimeService RealTimeService,
+	logger *logger.Logger,
+) SearchEngineService {
+	if env.SearchEngine == internal.PostgresSearchEngine {
+		return newPostgresSearchEngineService(dbClient, realTimeService, logger)
+	}
 	return searchEngineService{client: client}
 }
 
@@ -44,7 +70,8 @@ func (s searchEngineService) Search(
 	userID uuid.UUID,
 	filter []string,
 	sort []string,
-) (wrapper.WithTotalCount[map[string]any], *wrapper.ErrorCode) {
+	options model.SearchOptions,
+) (model.SearchResults[map[string]any], *wrapper.ErrorCode) {
 	request := &meilisearch.SearchRequest{Sort: sort}
 
 	// pagination
@@ -64,22 +91,62 @@ func (s searchEngineService) Search(
 	}
 	request.Filter = strings.Join(filters, " AND ")
 
+	// options
+	if options.Facets {
+		request.Facets = searchFacets
+	}
+	if options.Highlight {
+		request.AttributesToHighlight = searchHighlightedAttributes
+		request.AttributesToCrop = searchHighlightedAttributes
+		request.CropLength = searchCropLength
+	}
+	if options.MatchingStrategy != nil {
+		request.MatchingStrategy = meilisearch.MatchingStrategy(*options.MatchingStrategy)
+	}
+	if options.TypoTolerance != nil && !*options.TypoTolerance {
+		query = toExactQuery(query)
+	}
+
 	// send search request
 	searchResult, err := s.client.Index("search").Search(query, request)
 	if err != nil {
-		return wrapper.WithTotalCount[map[string]any]{}, wrapper.InternalServerError(err)
+		return model.SearchResults[map[string]any]{}, wrapper.InternalServerError(err)
 	}
 
 	var results []map[string]any
 	_ = searchResult.Hits.DecodeInto(&results)
-	result := wrapper.WithTotalCount[map[string]any]{
-		Models:     results,
-		TotalCount: searchResult.EstimatedTotalHits,
+	for _, res := range results {
+		if formatted, ok := res["_formatted"]; ok {
+			res["formatted"] = formatted
+			delete(res, "_formatted")
+		}
+	}
+	result := model.SearchResults[map[string]any]{
+		WithTotalCount: wrapper.WithTotalCount[map[string]any]{
+			Models:     results,
+			TotalCount: searchResult.EstimatedTotalHits,
+		},
+	}
+	if len(searchResult.FacetDistribution) > 0 {
+		_ = json.Unmarshal(searchResult.FacetDistribution, &result.Facets)
+	}
+	if len(searchResult.FacetStats) > 0 {
+		_ = json.Unmarshal(searchResult.FacetStats, &result.FacetStats)
 	}
 
 	return result, nil
 }
 
+// toExactQuery turns each word into a phrase, as Meilisearch does not tolerate typos on phrases,
+// and the typo tolerance cannot be turned off per search
+func toExactQuery(query string) string {
+	words := strings.Fields(strings.ReplaceAll(query, "\"", ""))
+	for i, word := range words {
+		words[i] = "\"" + word + "\""
+	}
+	return strings.Join(words, " ")
+}
+
 func (s searchEngineService) GetDocument(id string) (map[string]any, error) {
 	var result map[string]any
 	err := s.client.Index("search").GetDocument(id, nil, &result)
@@ -91,17 +158,26 @@ func (s searchEngineService) GetDocument(id string) (map[string]any, error) {
 }
 
 func (s searchEngineService) GetDocuments(filter string) ([]map[string]any, error) {
-	var result meilisearch.DocumentsResult
-	err := s.client.Index("search").GetDocuments(&meilisearch.DocumentsQuery{
-		Filter: filter,
-	}, &result)
-	if err != nil {
-		return []map[string]any{}, err
-	}
-
 	var results []map[string]any
-	_ = result.Results.DecodeInto(&results)
-	return results, nil
+	for offset := int64(0); ; offset += getDocumentsPageSize {
+		var result meilisearch.DocumentsResult
+		err := s.client.Index("search").GetDocuments(&meilisearch.DocumentsQuery{
+			Filter: filter,
+			Offset: offset,
+			Limit:  getDocumentsPageSize,
+		}, &result)
+		if err != nil {
+			return []map[string]any{}, err
+		}
+
+		var page []map[string]any
+		_ = result.Results.DecodeInto(&page)
+		results = append(results, page...)
+
+		if offset+getDocumentsPageSize >= result.Total {
+			return results, nil
+		}
+	}
 }
 
 func (s searchEngineService) Add(items []map[string]any) (int64, error) {
@@ -9,16 +9,25 @@ import (
 	"repertoire/server/data/cache"
 	"repertoire/server/data/http/auth"
 	"repertoire/server/data/http/client"
+	"repertoire/server/data/http/storage"
 	"repertoire/server/internal"
 	"repertoire/server/internal/wrapper"
 	"strings"
 	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
 )
 
 type StorageService interface {
-	Upload(fileHeader *multipart.FileHeader, filePath string) error
+	Get(filePath internal.FilePath) ([]byte, *wrapper.ErrorCode)
+	GetUsage(userID uuid.UUID) (storage.UsageResponse, *wrapper.ErrorCode)
+	Upload(fileHeader *multipart.FileHeader, filePath string) *wrapper.ErrorCode
+	UploadFile(fileName string, content []byte, filePath string) *wrapper.ErrorCode
+	UploadStream(fileName string, reader io.Reader, filePath string) *wrapper.ErrorCode
 	DeleteFile(filePath internal.FilePath) *wrapper.ErrorCode
 	DeleteDirectories(directoryPaths []string) *wrapper.ErrorCode
+	QuarantineFiles(filePaths []string) *wrapper.ErrorCode
 }
 
 type storageService struct {
@@ -40,10 +49,43 @@ func NewStorageService(
 	}
 }
 
-func (s storageService) Upload(fileHeader *multipart.FileHeader, filePath string) error {
+func (s storageService) Get(filePath internal.FilePath) ([]byte, *wrapper.ErrorCode) {
+	res, err := s.storageClient.Get(filePath.StripURL().ToSignedPath())
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, wrapper.NotFoundError(errors.New("Storage Service - Get Not Found: " + res.String()))
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, wrapper.InternalServerError(errors.New("Storage Service - Get failed: " + res.String()))
+	}
+
+	return res.Body(), nil
+}
+
+func (s storageService) GetUsage(userID uuid.UUID) (storage.UsageResponse, *wrapper.ErrorCode) {
+	storageToken, err := s.getAccessToken(userID.String())
+	if err != nil {
+		return storage.UsageResponse{}, wrapper.UnauthorizedError(err)
+	}
+
+	var result storage.UsageResponse
+	res, err := s.storageClient.GetUsage(storageToken, &result)
+	if err != nil {
+		return storage.UsageResponse{}, wrapper.InternalServerError(err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return storage.UsageResponse{}, wrapper.InternalServerError(errors.New("Storage Service - GetUsage failed: " + res.String()))
+	}
+
+	return result, nil
+}
+
+func (s storageService) Upload(fileHeader *multipart.FileHeader, filePath string) *wrapper.ErrorCode {
 	file, err := fileHeader.Open()
 	if err != nil {
-		return err
+		return wrapper.InternalServerError(err)
 	}
 	_ = file.Close()
 
@@ -51,21 +93,43 @@ func (s storageService) Upload(fileHeader *multipart.FileHeader, filePath string
 	buf := new(bytes.Buffer)
 	_, err = io.Copy(buf, file)
 	if err != nil {
-		return err
+		return wrapper.InternalServerError(err)
+	}
+
+	return s.UploadFile(fileHeader.Filename, buf.Bytes(), filePath)
+}
+
+func (s storageService) UploadFile(fileName string, content []byte, filePath string) *wrapper.ErrorCode {
+	userID := s.getUserIDFromPath(filePath)
+	storageToken, err := s.getAccessToken(userID)
+	if err != nil {
+		return wrapper.UnauthorizedError(err)
 	}
 
+	res, err := s.storageClient.Upload(storageToken, fileName, bytes.NewReader(content), filePath)
+	return s.checkUploadResponse(res, err)
+}
+
+func (s storageService) UploadStream(fileName string, reader io.Reader, filePath string) *wrapper.ErrorCode {
 	userID := s.getUserIDFromPath(filePath)
 	storageToken, err := s.getAccessToken(userID)
 	if err != nil {
-		return err
+		return wrapper.UnauthorizedError(err)
 	}
 
-	res, err := s.storageClient.Upload(storageToken, fileHeader.Filename, bytes.NewReader(buf.Bytes()), filePath)
+	res, err := s.storageClient.UploadStream(storageToken, fileName, reader, filePath)
+	return s.checkUploadResponse(res, err)
+}
+
+func (s storageService) checkUploadResponse(res *resty.Response, err error) *wrapper.ErrorCode {
 	if err != nil {
-		return err
+		return wrapper.InternalServerError(err)
+	}
+	if res.StatusCode() == http.StatusRequestEntityTooLarge {
+		return wrapper.PayloadTooLargeError(errors.New("Storage Service - Upload exceeds the storage quota: " + res.String()))
 	}
 	if res.StatusCode() != http.StatusOK {
-		return errors.New("Storage Service - Upload failed: " + res.String())
+		return wrapper.InternalServerError(errors.New("Storage Service - Upload failed: " + res.String()))
 	}
 
 	return nil
@@ -111,6 +175,24 @@ func (s storageService) DeleteDirectories(directoryPaths []string) *wrapper.Erro
 	return nil
 }
 
+func (s storageService) QuarantineFiles(filePaths []string) *wrapper.ErrorCode {
+	userID := s.getUserIDFromPath(filePaths[0])
+	storageToken, err := s.getAccessToken(userID)
+	if err != nil {
+		return wrapper.UnauthorizedError(err)
+	}
+
+	res, err := s.storageClient.QuarantineFiles(storageToken, filePaths)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return wrapper.InternalServerError(errors.New("Storage Service - QuarantineFiles failed: " + res.String()))
+	}
+
+	return nil
+}
+
 func (s storageService) getAccessToken(userID string) (string, error) {
 	// get from cache
 	accessTokenKey := "access_token#" + userID
@@ -0,0 +1,46 @@
+package message
+
+import (
+	"repertoire/server/data/repository"
+	"repertoire/server/model"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+// DeduplicationMiddleware skips the messages that the handler has already handled,
+// as a message is relayed again when the relay fails to remove it from the outbox after publishing it.
+// A message is marked as processed only once it is handled, so a failed message can still be retried
+type DeduplicationMiddleware struct {
+	ProcessedMessageRepository repository.ProcessedMessageRepository
+	Logger                     watermill.LoggerAdapter
+}
+
+func (d DeduplicationMiddleware) Middleware(h message.HandlerFunc) message.HandlerFunc {
+	return func(msg *message.Message) ([]*message.Message, error) {
+		handler := message.HandlerNameFromCtx(msg.Context())
+
+		processed, err := d.ProcessedMessageRepository.IsProcessed(msg.UUID, handler)
+		if err != nil {
+			return nil, err
+		}
+		if processed {
+			return nil, nil
+		}
+
+		producedMessages, err := h(msg)
+		if err != nil {
+			return producedMessages, err
+		}
+
+		// the message is already handled, so failing here would only handle it once more
+		err = d.ProcessedMessageRepository.Create(&model.ProcessedMessage{MessageID: msg.UUID, Handler: handler})
+		if err != nil && d.Logger != nil {
+			d.Logger.Error("Failed to mark the message as processed", err, watermill.LogFields{
+				"message_uuid": msg.UUID,
+				"handler":      handler,
+			})
+		}
+		return producedMessages, nil
+	}
+}
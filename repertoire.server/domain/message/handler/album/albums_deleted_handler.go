@@ -55,16 +55,31 @@ func (a AlbumsDeletedHandler) syncWithSearchEngine(albums []model.Album) error {
 	// previously in delete album, the album was populated with songs, only if they have to be deleted too
 	var albumIDs []string
 	var albumSearchIDs []string
+	var songIDs []string
 	var songSearchIDs []string
 	for _, album := range albums {
 		albumIDs = append(albumIDs, album.ID.String())
 		albumSearchIDs = append(albumSearchIDs, album.ToSearch().ID)
 		for _, song := range album.Songs {
+			songIDs = append(songIDs, song.ID.String())
 			songSearchIDs = append(songSearchIDs, song.ToSearch().ID)
 		}
 	}
 
-	ids := slices.Concat(albumSearchIDs, songSearchIDs)
+	// the sections are deleted together with their songs
+	var sectionSearchIDs []string
+	if len(songIDs) > 0 {
+		filter := fmt.Sprintf("type = %s AND song.id IN [%s]", enums.SongSection, strings.Join(songIDs, ","))
+		sectionSearches, err := a.searchEngineService.GetDocuments(filter)
+		if err != nil {
+			return err
+		}
+		for _, sectionSearch := range sectionSearches {
+			sectionSearchIDs = append(sectionSearchIDs, sectionSearch["id"].(string))
+		}
+	}
+
+	ids := slices.Concat(albumSearchIDs, songSearchIDs, sectionSearchIDs)
 	err := a.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, ids)
 	if err != nil {
 		return err
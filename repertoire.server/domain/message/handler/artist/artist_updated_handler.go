@@ -2,8 +2,10 @@ package artist
 
 import (
 	"encoding/json"
+	"fmt"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/enums"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
 
@@ -16,17 +18,20 @@ type ArtistUpdatedHandler struct {
 	topic                   topics.Topic
 	artistRepository        repository.ArtistRepository
 	messagePublisherService service.MessagePublisherService
+	searchEngineService     service.SearchEngineService
 }
 
 func NewArtistUpdatedHandler(
 	artistRepository repository.ArtistRepository,
 	messagePublisherService service.MessagePublisherService,
+	searchEngineService service.SearchEngineService,
 ) ArtistUpdatedHandler {
 	return ArtistUpdatedHandler{
 		name:                    "artist_updated_handler",
 		topic:                   topics.ArtistUpdatedTopic,
 		artistRepository:        artistRepository,
 		messagePublisherService: messagePublisherService,
+		searchEngineService:     searchEngineService,
 	}
 }
 
@@ -38,7 +43,7 @@ func (a ArtistUpdatedHandler) Handle(msg *watermillMessage.Message) error {
 	}
 
 	var artist model.Artist
-	err = a.artistRepository.GetWithSongsOrAlbums(&artist, artistID, true, true)
+	err = a.artistRepository.GetWithSearchAssociations(&artist, artistID)
 	if err != nil {
 		return err
 	}
@@ -54,13 +59,40 @@ func (a ArtistUpdatedHandler) Handle(msg *watermillMessage.Message) error {
 		albumSearch.Artist = artist.ToAlbumSearch()
 		documentsToUpdate = append(documentsToUpdate, albumSearch)
 	}
+	bandMemberSearchIDs := make(map[string]bool)
+	for _, member := range artist.BandMembers {
+		member.Artist = artist
+		bandMemberSearch := member.ToSearch()
+		documentsToUpdate = append(documentsToUpdate, bandMemberSearch)
+		bandMemberSearchIDs[bandMemberSearch.ID] = true
+	}
 
 	err = a.messagePublisherService.Publish(topics.UpdateFromSearchEngineTopic, documentsToUpdate)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return a.deleteRemovedBandMembers(artist, bandMemberSearchIDs)
+}
+
+func (a ArtistUpdatedHandler) deleteRemovedBandMembers(artist model.Artist, bandMemberSearchIDs map[string]bool) error {
+	filter := fmt.Sprintf("type = %s AND artist.id = %s", enums.BandMember, artist.ID)
+	searches, err := a.searchEngineService.GetDocuments(filter)
+	if err != nil {
+		return err
+	}
+
+	var idsToDelete []string
+	for _, search := range searches {
+		if id := search["id"].(string); !bandMemberSearchIDs[id] {
+			idsToDelete = append(idsToDelete, id)
+		}
+	}
+	if len(idsToDelete) == 0 {
+		return nil
+	}
+
+	return a.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, idsToDelete)
 }
 
 func (a ArtistUpdatedHandler) GetName() string {
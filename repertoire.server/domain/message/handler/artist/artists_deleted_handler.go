@@ -58,19 +58,26 @@ func (a ArtistsDeletedHandler) syncWithSearchEngine(artists []model.Artist) erro
 	var artistSearchIDs []string
 	var albumIDs []string
 	var songIDs []string
+	var deletedSongIDs []string
 	for _, art := range artists {
 		artistIDs = append(artistIDs, art.ID.String())
 		artistSearchIDs = append(artistSearchIDs, art.ToSearch().ID)
 		for _, song := range art.Songs {
 			songIDs = append(songIDs, song.ToSearch().ID)
+			deletedSongIDs = append(deletedSongIDs, song.ID.String())
 		}
 		for _, album := range art.Albums {
 			albumIDs = append(albumIDs, album.ToSearch().ID)
 		}
 	}
 
-	ids := slices.Concat(artistSearchIDs, albumIDs, songIDs)
-	err := a.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, ids)
+	dependentIDs, err := a.getDependentSearchIDs(artistIDs, deletedSongIDs)
+	if err != nil {
+		return err
+	}
+
+	ids := slices.Concat(artistSearchIDs, albumIDs, songIDs, dependentIDs)
+	err = a.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, ids)
 	if err != nil {
 		return err
 	}
@@ -104,6 +111,25 @@ func (a ArtistsDeletedHandler) syncWithSearchEngine(artists []model.Artist) erro
 	return a.messagePublisherService.Publish(topics.UpdateFromSearchEngineTopic, documentsToUpdate)
 }
 
+// getDependentSearchIDs returns the band members of the artists and the sections of the songs,
+// as they are deleted together
+func (a ArtistsDeletedHandler) getDependentSearchIDs(artistIDs []string, songIDs []string) ([]string, error) {
+	filter := fmt.Sprintf("(type = %s AND artist.id IN [%s])", enums.BandMember, strings.Join(artistIDs, ","))
+	if len(songIDs) > 0 {
+		filter += fmt.Sprintf(" OR (type = %s AND song.id IN [%s])", enums.SongSection, strings.Join(songIDs, ","))
+	}
+	searches, err := a.searchEngineService.GetDocuments(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, search := range searches {
+		ids = append(ids, search["id"].(string))
+	}
+	return ids, nil
+}
+
 func (a ArtistsDeletedHandler) cleanupStorage(artists []model.Artist) error {
 	var directoryPaths []string
 
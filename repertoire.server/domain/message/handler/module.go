@@ -38,6 +38,8 @@ var songHandlers = fx.Options(
 
 var userHandlers = fx.Options(
 	fx.Provide(user.NewUserDeletedHandler),
+	fx.Provide(user.NewUserScoringStrategyUpdatedHandler),
+	fx.Provide(user.NewUserDataExportRequestedHandler),
 )
 
 var searchHandlers = fx.Options(
@@ -8,26 +8,24 @@ import (
 	"repertoire/server/model"
 
 	watermillMessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
 )
 
 type SongCreatedHandler struct {
 	name                    string
 	topic                   topics.Topic
-	artistRepository        repository.ArtistRepository
-	albumRepository         repository.AlbumRepository
+	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
 }
 
 func NewSongCreatedHandler(
-	artistRepository repository.ArtistRepository,
-	albumRepository repository.AlbumRepository,
+	songRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
 ) SongCreatedHandler {
 	return SongCreatedHandler{
 		name:                    "song_created_handler",
 		topic:                   topics.SongCreatedTopic,
-		artistRepository:        artistRepository,
-		albumRepository:         albumRepository,
+		songRepository:          songRepository,
 		messagePublisherService: messagePublisherService,
 	}
 }
@@ -39,28 +37,23 @@ func (s SongCreatedHandler) Handle(msg *watermillMessage.Message) error {
 		return err
 	}
 
-	var searches []any
-	songSearch := song.ToSearch()
-
-	if song.ArtistID != nil {
-		var artist model.Artist
-		err = s.artistRepository.Get(&artist, *song.ArtistID)
-		if err != nil {
-			return err
-		}
-		songSearch.Artist = artist.ToSongSearch()
+	// the song is loaded again, as the payload lacks the artist, the album, the guitar tuning and the section types,
+	// when they already existed
+	var songs []model.Song
+	err = s.songRepository.GetAllByIDsWithSearchAssociations(&songs, []uuid.UUID{song.ID})
+	if err != nil {
+		return err
 	}
-	if song.AlbumID != nil {
-		var album model.Album
-		err = s.albumRepository.Get(&album, *song.AlbumID)
-		if err != nil {
-			return err
-		}
-		songSearch.Album = album.ToSongSearch()
+	if len(songs) == 0 {
+		return nil
 	}
 
-	searches = append(searches, songSearch)
+	searches := []any{songs[0].ToSearch()}
+	for _, sectionSearch := range songs[0].ToSectionsSearch() {
+		searches = append(searches, sectionSearch)
+	}
 
+	// the artist and the album were created together with the song
 	if song.Artist != nil {
 		searches = append(searches, song.Artist.ToSearch())
 	}
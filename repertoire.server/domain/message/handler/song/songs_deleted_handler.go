@@ -2,10 +2,13 @@ package song
 
 import (
 	"encoding/json"
+	"fmt"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
+	"repertoire/server/internal/enums"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"strings"
 
 	watermillMessage "github.com/ThreeDotsLabs/watermill/message"
 )
@@ -15,17 +18,20 @@ type SongsDeletedHandler struct {
 	topic                   topics.Topic
 	storageFilePathProvider provider.StorageFilePathProvider
 	messagePublisherService service.MessagePublisherService
+	searchEngineService     service.SearchEngineService
 }
 
 func NewSongsDeletedHandler(
 	storageFilePathProvider provider.StorageFilePathProvider,
 	messagePublisherService service.MessagePublisherService,
+	searchEngineService service.SearchEngineService,
 ) SongsDeletedHandler {
 	return SongsDeletedHandler{
 		name:                    "songs_deleted_handler",
 		topic:                   topics.SongsDeletedTopic,
 		storageFilePathProvider: storageFilePathProvider,
 		messagePublisherService: messagePublisherService,
+		searchEngineService:     searchEngineService,
 	}
 }
 
@@ -37,9 +43,22 @@ func (s SongsDeletedHandler) Handle(msg *watermillMessage.Message) error {
 	}
 
 	var ids []string
+	var songIDs []string
 	for _, song := range songs {
 		ids = append(ids, song.ToSearch().ID)
+		songIDs = append(songIDs, song.ID.String())
 	}
+
+	// the sections are deleted together with their songs
+	filter := fmt.Sprintf("type = %s AND song.id IN [%s]", enums.SongSection, strings.Join(songIDs, ","))
+	sectionSearches, err := s.searchEngineService.GetDocuments(filter)
+	if err != nil {
+		return err
+	}
+	for _, sectionSearch := range sectionSearches {
+		ids = append(ids, sectionSearch["id"].(string))
+	}
+
 	err = s.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, ids)
 	if err != nil {
 		return err
@@ -2,10 +2,13 @@ package song
 
 import (
 	"encoding/json"
+	"fmt"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/enums"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"strings"
 
 	watermillMessage "github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
@@ -16,17 +19,20 @@ type SongsUpdatedHandler struct {
 	topic                   topics.Topic
 	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
+	searchEngineService     service.SearchEngineService
 }
 
 func NewSongsUpdatedHandler(
 	songsRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
+	searchEngineService service.SearchEngineService,
 ) SongsUpdatedHandler {
 	return SongsUpdatedHandler{
 		name:                    "songs_updated_handler",
 		topic:                   topics.SongsUpdatedTopic,
 		songRepository:          songsRepository,
 		messagePublisherService: messagePublisherService,
+		searchEngineService:     searchEngineService,
 	}
 }
 
@@ -38,7 +44,7 @@ func (s SongsUpdatedHandler) Handle(msg *watermillMessage.Message) error {
 	}
 
 	var songs []model.Song
-	err = s.songRepository.GetAllByIDsWithArtistAndAlbum(&songs, ids)
+	err = s.songRepository.GetAllByIDsWithSearchAssociations(&songs, ids)
 	if err != nil {
 		return err
 	}
@@ -47,15 +53,45 @@ func (s SongsUpdatedHandler) Handle(msg *watermillMessage.Message) error {
 	}
 
 	var songSearches []any
+	sectionSearchIDs := make(map[string]bool)
 	for _, song := range songs {
 		songSearches = append(songSearches, song.ToSearch())
+		for _, sectionSearch := range song.ToSectionsSearch() {
+			songSearches = append(songSearches, sectionSearch)
+			sectionSearchIDs[sectionSearch.ID] = true
+		}
 	}
 
 	err = s.messagePublisherService.Publish(topics.UpdateFromSearchEngineTopic, songSearches)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return s.deleteRemovedSections(songs, sectionSearchIDs)
+}
+
+func (s SongsUpdatedHandler) deleteRemovedSections(songs []model.Song, sectionSearchIDs map[string]bool) error {
+	var songIDs []string
+	for _, song := range songs {
+		songIDs = append(songIDs, song.ID.String())
+	}
+	filter := fmt.Sprintf("type = %s AND song.id IN [%s]", enums.SongSection, strings.Join(songIDs, ","))
+	searches, err := s.searchEngineService.GetDocuments(filter)
+	if err != nil {
+		return err
+	}
+
+	var idsToDelete []string
+	for _, search := range searches {
+		if id := search["id"].(string); !sectionSearchIDs[id] {
+			idsToDelete = append(idsToDelete, id)
+		}
+	}
+	if len(idsToDelete) == 0 {
+		return nil
+	}
+
+	return s.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, idsToDelete)
 }
 
 func (s SongsUpdatedHandler) GetName() string {
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/domain/provider"
+	"repertoire/server/internal"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+)
+
+type UserDataExportRequestedHandler struct {
+	name                    string
+	topic                   topics.Topic
+	userRepository          repository.UserRepository
+	userDataExportProcessor processor.UserDataExportProcessor
+	storageFilePathProvider provider.StorageFilePathProvider
+	storageService          service.StorageService
+	realTimeService         service.RealTimeService
+}
+
+func NewUserDataExportRequestedHandler(
+	userRepository repository.UserRepository,
+	userDataExportProcessor processor.UserDataExportProcessor,
+	storageFilePathProvider provider.StorageFilePathProvider,
+	storageService service.StorageService,
+	realTimeService service.RealTimeService,
+) UserDataExportRequestedHandler {
+	return UserDataExportRequestedHandler{
+		name:                    "user_data_export_requested_handler",
+		topic:                   topics.UserDataExportRequestedTopic,
+		userRepository:          userRepository,
+		userDataExportProcessor: userDataExportProcessor,
+		storageFilePathProvider: storageFilePathProvider,
+		storageService:          storageService,
+		realTimeService:         realTimeService,
+	}
+}
+
+func (u UserDataExportRequestedHandler) Handle(msg *message.Message) error {
+	var userID uuid.UUID
+	err := json.Unmarshal(msg.Payload, &userID)
+	if err != nil {
+		return err
+	}
+
+	var user model.User
+	err = u.userRepository.GetWithAllData(&user, userID)
+	if err != nil {
+		return err
+	}
+	// the user might have been deleted in the meantime
+	if reflect.ValueOf(user).IsZero() {
+		return nil
+	}
+
+	// the archive is built on disk and streamed from there, as it can get as big as the user's data
+	archive, err := os.CreateTemp("", "repertoire-export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+
+	errCode := u.userDataExportProcessor.Export(archive, user)
+	if errCode != nil {
+		return errCode.Error
+	}
+	_, err = archive.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	exportPath := u.storageFilePathProvider.GetUserDataExportPath(userID)
+	errCode = u.storageService.UploadStream(path.Base(exportPath), archive, exportPath)
+	if errCode != nil {
+		return errCode.Error
+	}
+
+	downloadURL := internal.FilePath(exportPath)
+	return u.realTimeService.Publish("export", userID.String(), map[string]any{
+		"action": "USER_DATA_EXPORT_READY",
+		"url":    downloadURL.ToFullURL(),
+	})
+}
+
+func (u UserDataExportRequestedHandler) GetName() string {
+	return u.name
+}
+
+func (u UserDataExportRequestedHandler) GetTopic() topics.Topic {
+	return u.topic
+}
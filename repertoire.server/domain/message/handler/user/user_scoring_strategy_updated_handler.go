@@ -0,0 +1,120 @@
+package user
+
+import (
+	"encoding/json"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+)
+
+type UserScoringStrategyUpdatedHandler struct {
+	name                  string
+	topic                 topics.Topic
+	userRepository        repository.UserRepository
+	songRepository        repository.SongRepository
+	songSectionRepository repository.SongSectionRepository
+	progressProcessor     processor.ProgressProcessor
+}
+
+func NewUserScoringStrategyUpdatedHandler(
+	userRepository repository.UserRepository,
+	songRepository repository.SongRepository,
+	songSectionRepository repository.SongSectionRepository,
+	progressProcessor processor.ProgressProcessor,
+) UserScoringStrategyUpdatedHandler {
+	return UserScoringStrategyUpdatedHandler{
+		name:                  "user_scoring_strategy_updated_handler",
+		topic:                 topics.UserScoringStrategyUpdatedTopic,
+		userRepository:        userRepository,
+		songRepository:        songRepository,
+		songSectionRepository: songSectionRepository,
+		progressProcessor:     progressProcessor,
+	}
+}
+
+func (u UserScoringStrategyUpdatedHandler) Handle(msg *message.Message) error {
+	var userID uuid.UUID
+	err := json.Unmarshal(msg.Payload, &userID)
+	if err != nil {
+		return err
+	}
+
+	var user model.User
+	err = u.userRepository.Get(&user, userID)
+	if err != nil {
+		return err
+	}
+	// the user might have been deleted in the meantime
+	if reflect.ValueOf(user).IsZero() {
+		return nil
+	}
+
+	var songs []model.Song
+	err = u.songRepository.GetAllByUserWithSections(&songs, userID)
+	if err != nil {
+		return err
+	}
+
+	// rebuild every section's scores from its history, and the songs' progress medians out of them
+	var songsToUpdate []model.Song
+	for _, song := range songs {
+		if len(song.Sections) == 0 {
+			continue
+		}
+
+		var totalProgress float64 = 0
+		for i, section := range song.Sections {
+			var rehearsalsHistory []model.SongSectionHistory
+			err = u.songSectionRepository.GetHistory(&rehearsalsHistory, section.ID, model.RehearsalsProperty)
+			if err != nil {
+				return err
+			}
+
+			var confidenceHistory []model.SongSectionHistory
+			err = u.songSectionRepository.GetHistory(&confidenceHistory, section.ID, model.ConfidenceProperty)
+			if err != nil {
+				return err
+			}
+
+			song.Sections[i].RehearsalsScore = u.progressProcessor.ComputeRehearsalsScore(rehearsalsHistory, user.ScoringStrategy)
+			song.Sections[i].ConfidenceScore = u.progressProcessor.ComputeConfidenceScore(confidenceHistory, user.ScoringStrategy)
+			song.Sections[i].Progress = u.progressProcessor.ComputeProgress(song.Sections[i], user.ScoringStrategy)
+			totalProgress += float64(song.Sections[i].Progress)
+		}
+		song.Progress = totalProgress / float64(len(song.Sections))
+
+		// only the scores are saved, without the loaded artist and section types
+		song.Artist = nil
+		for i := range song.Sections {
+			song.Sections[i].SongSectionType = model.SongSectionType{}
+		}
+		songsToUpdate = append(songsToUpdate, song)
+	}
+
+	if len(songsToUpdate) > 0 {
+		err = u.songRepository.UpdateAllWithAssociations(&songsToUpdate)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the scores are now up-to-date with the current version of the strategy
+	return u.userRepository.UpdateScoringStrategyVersion(
+		user.ID,
+		user.ScoringStrategy,
+		u.progressProcessor.GetScoringStrategyVersion(user.ScoringStrategy),
+	)
+}
+
+func (u UserScoringStrategyUpdatedHandler) GetName() string {
+	return u.name
+}
+
+func (u UserScoringStrategyUpdatedHandler) GetTopic() topics.Topic {
+	return u.topic
+}
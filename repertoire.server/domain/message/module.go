@@ -11,4 +11,10 @@ var Module = fx.Options(
 	handler.Module,
 	fx.Provide(NewRouter),
 	fx.Invoke(func(router *message.Router) {}),
+	fx.Provide(NewOutboxRelay),
+	fx.Invoke(StartOutboxRelay),
+	fx.Invoke(StartSearchReconciliationScheduler),
+	fx.Invoke(StartOrphanedFilesCollectionScheduler),
+	fx.Invoke(StartProcessedMessagesCleanupScheduler),
+	fx.Invoke(StartScoringStrategiesUpgrade),
 )
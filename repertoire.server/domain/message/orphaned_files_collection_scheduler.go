@@ -0,0 +1,70 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"repertoire/server/data/logger"
+	"repertoire/server/domain/usecase/storage"
+	"repertoire/server/internal"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"go.uber.org/fx"
+)
+
+func StartOrphanedFilesCollectionScheduler(
+	lc fx.Lifecycle,
+	collectOrphanedFiles storage.CollectOrphanedFiles,
+	env internal.Env,
+	logger *logger.WatermillLogger,
+) error {
+	if env.OrphanedFilesCollectionInterval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(env.OrphanedFilesCollectionInterval)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						collectFiles(collectOrphanedFiles, logger)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return errors.New("orphaned files collection scheduler did not stop in time")
+			}
+		},
+	})
+	return nil
+}
+
+func collectFiles(collectOrphanedFiles storage.CollectOrphanedFiles, logger *logger.WatermillLogger) {
+	result, errCode := collectOrphanedFiles.Handle(nil, false)
+	fields := watermill.LogFields{"files": len(result.Files), "size": result.Size}
+	if errCode != nil {
+		logger.Error("Failed to collect the orphaned files", errCode.Error, fields)
+		return
+	}
+	logger.Info("Orphaned files collected", fields)
+}
@@ -0,0 +1,136 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/logger"
+	"repertoire/server/data/service"
+	"repertoire/server/model"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+)
+
+const (
+	outboxRelayInterval  = 200 * time.Millisecond
+	outboxRelayBatchSize = 100
+)
+
+type OutboxRelay struct {
+	transactionManager      transaction.Manager
+	messagePublisherService service.MessagePublisherService
+}
+
+func NewOutboxRelay(
+	transactionManager transaction.Manager,
+	messagePublisherService service.MessagePublisherService,
+) OutboxRelay {
+	return OutboxRelay{
+		transactionManager:      transactionManager,
+		messagePublisherService: messagePublisherService,
+	}
+}
+
+// Relay publishes the oldest batch of messages from the outbox and removes them afterward.
+// A message is removed only once the broker has it (or, in memory, once the handlers acknowledge it),
+// so on a failure (or a crash) the remaining ones stay in the outbox and are picked up by the next run.
+// The messages relayed twice this way are skipped by the DeduplicationMiddleware
+func (o OutboxRelay) Relay() (int, error) {
+	var relayed int
+	var publishErr error
+	err := o.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		outboxRepository := factory.NewOutboxRepository()
+
+		var messages []model.OutboxMessage
+		err := outboxRepository.GetOldestAndLock(&messages, outboxRelayBatchSize)
+		if err != nil {
+			return err
+		}
+
+		var publishedIDs []uuid.UUID
+		for _, outboxMessage := range messages {
+			publishErr = o.messagePublisherService.Relay(outboxMessage)
+			if publishErr != nil {
+				break
+			}
+			publishedIDs = append(publishedIDs, outboxMessage.ID)
+		}
+		if len(publishedIDs) == 0 {
+			return nil
+		}
+
+		err = outboxRepository.Delete(publishedIDs)
+		if err != nil {
+			return err
+		}
+		relayed = len(publishedIDs)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return relayed, publishErr
+}
+
+func StartOutboxRelay(
+	lc fx.Lifecycle,
+	relay OutboxRelay,
+	router *message.Router,
+	logger *logger.WatermillLogger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				// the in-memory broker drops the messages that have no subscribers yet
+				select {
+				case <-ctx.Done():
+					return
+				case <-router.Running():
+				}
+
+				ticker := time.NewTicker(outboxRelayInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						relayOutbox(relay, logger)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return errors.New("outbox relay did not stop in time")
+			}
+		},
+	})
+}
+
+// relayOutbox keeps relaying while the batches are full, to catch up with a backlog
+func relayOutbox(relay OutboxRelay, logger *logger.WatermillLogger) {
+	for {
+		relayed, err := relay.Relay()
+		if err != nil {
+			logger.Error("Failed to relay the outbox messages", err, watermill.LogFields{"relayed": relayed})
+			return
+		}
+		if relayed < outboxRelayBatchSize {
+			return
+		}
+	}
+}
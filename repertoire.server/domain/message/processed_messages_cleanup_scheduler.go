@@ -0,0 +1,58 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"repertoire/server/data/logger"
+	"repertoire/server/data/repository"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+const (
+	processedMessagesCleanupInterval = time.Hour
+	// a message is relayed twice only right after it is relayed the first time,
+	// so it does not have to be remembered for long
+	processedMessagesRetention = 7 * 24 * time.Hour
+)
+
+func StartProcessedMessagesCleanupScheduler(
+	lc fx.Lifecycle,
+	processedMessageRepository repository.ProcessedMessageRepository,
+	logger *logger.WatermillLogger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				ticker := time.NewTicker(processedMessagesCleanupInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						err := processedMessageRepository.DeleteOlderThan(time.Now().Add(-processedMessagesRetention))
+						if err != nil {
+							logger.Error("Failed to clean up the processed messages", err, nil)
+						}
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return errors.New("processed messages cleanup scheduler did not stop in time")
+			}
+		},
+	})
+}
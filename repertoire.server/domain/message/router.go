@@ -2,7 +2,9 @@ package message
 
 import (
 	"context"
+	"encoding/json"
 	"repertoire/server/data/logger"
+	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/message/handler/album"
 	"repertoire/server/domain/message/handler/playlist"
@@ -11,11 +13,13 @@ import (
 	"repertoire/server/domain/message/handler/storage"
 	"repertoire/server/domain/message/handler/user"
 	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
 
 	"github.com/ThreeDotsLabs/watermill"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
 
 	"log"
 	"repertoire/server/domain/message/handler/artist"
@@ -33,6 +37,8 @@ type messageHandler interface {
 func NewRouter(
 	lc fx.Lifecycle,
 	messagePublisherService service.MessagePublisherService,
+	deadLetterRepository repository.DeadLetterRepository,
+	processedMessageRepository repository.ProcessedMessageRepository,
 	logger *logger.WatermillLogger,
 
 	albumCreatedHandler album.AlbumCreatedHandler,
@@ -52,6 +58,8 @@ func NewRouter(
 	songsUpdatedHandler song.SongsUpdatedHandler,
 
 	userDeletedHandler user.UserDeletedHandler,
+	userScoringStrategyUpdatedHandler user.UserScoringStrategyUpdatedHandler,
+	userDataExportRequestedHandler user.UserDataExportRequestedHandler,
 
 	addToSearchEngineHandler search.AddToSearchEngineHandler,
 	deleteFromSearchEngineHandler search.DeleteFromSearchEngineHandler,
@@ -77,6 +85,8 @@ func NewRouter(
 		songsUpdatedHandler,
 
 		userDeletedHandler,
+		userScoringStrategyUpdatedHandler,
+		userDataExportRequestedHandler,
 
 		addToSearchEngineHandler,
 		deleteFromSearchEngineHandler,
@@ -92,10 +102,15 @@ func NewRouter(
 
 	router.AddMiddleware(
 		middleware.CorrelationID,
+		DeduplicationMiddleware{
+			ProcessedMessageRepository: processedMessageRepository,
+			Logger:                     logger,
+		}.Middleware,
 		CustomRetryMiddleware{
-			MaxRetries:      2,
-			InitialInterval: time.Millisecond * 100,
-			Logger:          logger,
+			MaxRetries:           2,
+			InitialInterval:      time.Millisecond * 100,
+			Logger:               logger,
+			DeadLetterRepository: deadLetterRepository,
 		}.Middleware,
 		middleware.Recoverer,
 	)
@@ -103,15 +118,25 @@ func NewRouter(
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			for _, handler := range handlers {
+				// every handler has its own consumer group, as they all listen to the same queues
+				subscriber, err := messagePublisherService.GetClient().NewSubscriber(handler.GetName())
+				if err != nil {
+					return err
+				}
 				router.AddConsumerHandler(
 					handler.GetName(),
 					string(topics.TopicToQueueMap[handler.GetTopic()]),
-					messagePublisherService.GetClient(),
+					subscriber,
 					func(msg *message.Message) error {
 						topic := msg.Metadata.Get("topic")
 						if topic != string(handler.GetTopic()) {
 							return nil
 						}
+						// replayed messages are meant only for the handler that failed them
+						target := msg.Metadata.Get(service.HandlerMetadataKey)
+						if target != "" && target != handler.GetName() {
+							return nil
+						}
 						return handler.Handle(msg)
 					},
 				)
@@ -135,12 +160,14 @@ func NewRouter(
 }
 
 // Copied the Retry Middleware implementation and ACKNOWLEDGED the message on the last retry
-// to fix infinite loop of retrying the message
+// to fix infinite loop of retrying the message.
+// Before acknowledging it, the message is sent to the dead letters, so that it can be replayed later
 
 type CustomRetryMiddleware struct {
-	MaxRetries      int
-	InitialInterval time.Duration
-	Logger          watermill.LoggerAdapter
+	MaxRetries           int
+	InitialInterval      time.Duration
+	Logger               watermill.LoggerAdapter
+	DeadLetterRepository repository.DeadLetterRepository
 }
 
 func (c CustomRetryMiddleware) Middleware(h message.HandlerFunc) message.HandlerFunc {
@@ -162,6 +189,7 @@ func (c CustomRetryMiddleware) Middleware(h message.HandlerFunc) message.Handler
 			waitTime := expBackoff.NextBackOff()
 			select {
 			case <-ctx.Done():
+				c.sendToDeadLetters(msg, err, retryNum)
 				msg.Ack() // Acknowledge the message to stop retrying
 				return producedMessages, err
 			case <-time.After(waitTime):
@@ -184,6 +212,7 @@ func (c CustomRetryMiddleware) Middleware(h message.HandlerFunc) message.Handler
 
 			retryNum++
 			if retryNum > c.MaxRetries {
+				c.sendToDeadLetters(msg, err, retryNum)
 				msg.Ack() // Acknowledge the message to stop retrying
 				break retryLoop
 			}
@@ -192,3 +221,28 @@ func (c CustomRetryMiddleware) Middleware(h message.HandlerFunc) message.Handler
 		return nil, err
 	}
 }
+
+func (c CustomRetryMiddleware) sendToDeadLetters(msg *message.Message, err error, attempts int) {
+	if c.DeadLetterRepository == nil {
+		return
+	}
+
+	deadLetterMessage := model.DeadLetterMessage{
+		ID:        uuid.New(),
+		MessageID: msg.UUID,
+		Queue:     message.SubscribeTopicFromCtx(msg.Context()),
+		Topic:     msg.Metadata.Get("topic"),
+		Handler:   message.HandlerNameFromCtx(msg.Context()),
+		Payload:   json.RawMessage(msg.Payload),
+		Error:     err.Error(),
+		Attempts:  uint(attempts),
+	}
+	dlErr := c.DeadLetterRepository.Create(&deadLetterMessage)
+	if dlErr != nil && c.Logger != nil {
+		c.Logger.Error("Failed to send the message to the dead letters", dlErr, watermill.LogFields{
+			"message_uuid": msg.UUID,
+			"topic":        deadLetterMessage.Topic,
+			"handler":      deadLetterMessage.Handler,
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package message
+
+import (
+	"context"
+	"repertoire/server/data/logger"
+	"repertoire/server/domain/usecase/user"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"go.uber.org/fx"
+)
+
+// StartScoringStrategiesUpgrade recomputes the scores on start, for the strategies that have changed since
+func StartScoringStrategiesUpgrade(
+	lc fx.Lifecycle,
+	upgradeScoringStrategies user.UpgradeScoringStrategies,
+	logger *logger.WatermillLogger,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			// the recomputations go through the outbox, so they are not lost when the router is not running yet
+			upgraded, errCode := upgradeScoringStrategies.Handle()
+			fields := watermill.LogFields{"users": upgraded}
+			if errCode != nil {
+				logger.Error("Failed to upgrade the scoring strategies", errCode.Error, fields)
+				return nil
+			}
+			if upgraded > 0 {
+				logger.Info("Scoring strategies upgraded", fields)
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,79 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"repertoire/server/data/logger"
+	"repertoire/server/domain/usecase/search"
+	"repertoire/server/internal"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"go.uber.org/fx"
+)
+
+func StartSearchReconciliationScheduler(
+	lc fx.Lifecycle,
+	reconcile search.Reconcile,
+	router *message.Router,
+	env internal.Env,
+	logger *logger.WatermillLogger,
+) error {
+	if env.SearchReconciliationInterval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(env.SearchReconciliationInterval)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+				// the reconciliation emits its batches through the search topics
+				select {
+				case <-ctx.Done():
+					return
+				case <-router.Running():
+				}
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						reconcileSearch(reconcile, logger)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return errors.New("search reconciliation scheduler did not stop in time")
+			}
+		},
+	})
+	return nil
+}
+
+func reconcileSearch(reconcile search.Reconcile, logger *logger.WatermillLogger) {
+	result, errCode := reconcile.Handle(nil)
+	fields := watermill.LogFields{"added": result.Added, "updated": result.Updated, "deleted": result.Deleted}
+	if errCode != nil {
+		logger.Error("Failed to reconcile the search engine", errCode.Error, fields)
+		return
+	}
+	logger.Info("Search engine reconciled", fields)
+}
@@ -11,8 +11,13 @@ import (
 )
 
 var processors = fx.Options(
+	fx.Provide(processor.NewPracticeQueueProcessor),
+	fx.Provide(processor.NewPracticeSessionProcessor),
+	fx.Provide(processor.NewPlaylistProcessor),
 	fx.Provide(processor.NewProgressProcessor),
+	fx.Provide(processor.NewProgressTimelineProcessor),
 	fx.Provide(processor.NewSongProcessor),
+	fx.Provide(processor.NewUserDataExportProcessor),
 )
 
 var providers = fx.Options(
@@ -23,8 +28,13 @@ var providers = fx.Options(
 var services = fx.Options(
 	fx.Provide(service.NewAlbumService),
 	fx.Provide(service.NewArtistService),
+	fx.Provide(service.NewDeadLetterService),
+	fx.Provide(service.NewOrphanedFileService),
 	fx.Provide(service.NewPlaylistService),
+	fx.Provide(service.NewPracticeSessionService),
+	fx.Provide(service.NewProgressService),
 	fx.Provide(service.NewSearchService),
+	fx.Provide(service.NewSetlistService),
 	fx.Provide(service.NewSongSectionService),
 	fx.Provide(service.NewSongService),
 	fx.Provide(service.NewUserDataService),
@@ -0,0 +1,53 @@
+package processor
+
+import (
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type PlaylistProcessor interface {
+	GetSmartPlaylistSongIDs(playlist model.Playlist) ([]uuid.UUID, *wrapper.ErrorCode)
+}
+
+type playlistProcessor struct {
+	songRepository repository.SongRepository
+}
+
+func NewPlaylistProcessor(songRepository repository.SongRepository) PlaylistProcessor {
+	return &playlistProcessor{
+		songRepository: songRepository,
+	}
+}
+
+// GetSmartPlaylistSongIDs resolves the songs currently matching the rules of a smart playlist,
+// in the order of the rules and never going past their limit
+func (p *playlistProcessor) GetSmartPlaylistSongIDs(playlist model.Playlist) ([]uuid.UUID, *wrapper.ErrorCode) {
+	rules := playlist.SmartRules
+
+	var currentPage, pageSize *int
+	if rules.Limit != nil {
+		currentPage, pageSize = &[]int{1}[0], &[]int{int(*rules.Limit)}[0]
+	}
+
+	var songs []model.EnhancedSong
+	err := p.songRepository.GetAllByUser(
+		&songs,
+		playlist.UserID,
+		currentPage,
+		pageSize,
+		rules.OrderBy,
+		rules.SearchBy,
+	)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var ids []uuid.UUID
+	for _, song := range songs {
+		ids = append(ids, song.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"cmp"
+	"math"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"slices"
+	"time"
+)
+
+type PracticeQueueProcessor interface {
+	BuildQueue(songs []model.Song, budget *time.Duration) []model.PracticeQueueSong
+}
+
+// Leitner boxes, the more confident a section is, the longer it can go without being rehearsed
+var practiceIntervalsInDays = []float64{1, 2, 4, 8, 16, 32}
+
+// songs that have never been played are considered overdue by this many intervals
+const neverPlayedOverdue float64 = 3
+
+// used when the song has no duration of its own
+const defaultSongPracticeDuration = 5 * time.Minute
+
+var difficultyWeights = map[enums.Difficulty]float64{
+	enums.Easy:       1,
+	enums.Medium:     1.25,
+	enums.Hard:       1.5,
+	enums.Impossible: 1.75,
+}
+
+type practiceQueueProcessor struct{}
+
+func NewPracticeQueueProcessor() PracticeQueueProcessor {
+	return &practiceQueueProcessor{}
+}
+
+func (p practiceQueueProcessor) BuildQueue(songs []model.Song, budget *time.Duration) []model.PracticeQueueSong {
+	now := time.Now().UTC()
+
+	var queue []model.PracticeQueueSong
+	for _, song := range songs {
+		queueSong, isDue := p.prioritizeSong(song, now)
+		if isDue {
+			queue = append(queue, queueSong)
+		}
+	}
+
+	slices.SortStableFunc(queue, func(a, b model.PracticeQueueSong) int {
+		return cmp.Or(
+			cmp.Compare(b.Priority, a.Priority),
+			cmp.Compare(len(b.DueSections), len(a.DueSections)),
+		)
+	})
+
+	if budget == nil {
+		return queue
+	}
+
+	// fill the time budget greedily, the songs that do not fit are skipped in favor of shorter ones
+	var budgetedQueue []model.PracticeQueueSong
+	remaining := uint(budget.Seconds())
+	for _, queueSong := range queue {
+		if queueSong.EstimatedDuration > remaining {
+			continue
+		}
+		budgetedQueue = append(budgetedQueue, queueSong)
+		remaining -= queueSong.EstimatedDuration
+	}
+	return budgetedQueue
+}
+
+func (p practiceQueueProcessor) prioritizeSong(song model.Song, now time.Time) (model.PracticeQueueSong, bool) {
+	queueSong := model.PracticeQueueSong{
+		Song:              song,
+		EstimatedDuration: uint(p.estimatePracticeDuration(song).Seconds()),
+		DueSections:       []model.PracticeQueueSection{},
+	}
+
+	difficultyWeight := 1.0
+	if song.Difficulty != nil {
+		difficultyWeight = difficultyWeights[*song.Difficulty]
+	}
+
+	if len(song.Sections) == 0 {
+		priority, _ := p.computePriority(uint(math.Round(song.Confidence)), song.LastTimePlayed, difficultyWeight, now)
+		queueSong.Priority = priority
+		return queueSong, priority > 0
+	}
+
+	for _, section := range song.Sections {
+		priority, interval := p.computePriority(section.ConfidenceScore, song.LastTimePlayed, difficultyWeight, now)
+		if priority == 0 {
+			continue
+		}
+		queueSong.DueSections = append(queueSong.DueSections, model.PracticeQueueSection{
+			SongSection:  section,
+			Priority:     priority,
+			IntervalDays: uint(interval),
+		})
+		queueSong.Priority = math.Max(queueSong.Priority, priority)
+	}
+
+	slices.SortStableFunc(queueSong.DueSections, func(a, b model.PracticeQueueSection) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
+	return queueSong, len(queueSong.DueSections) > 0
+}
+
+// computePriority returns 0 when the item is not yet due, alongside the interval (in days) it is scheduled on
+func (p practiceQueueProcessor) computePriority(
+	confidenceScore uint,
+	lastTimePlayed *time.Time,
+	difficultyWeight float64,
+	now time.Time,
+) (float64, float64) {
+	confidence := math.Min(float64(confidenceScore), 100)
+	box := int(confidence * float64(len(practiceIntervalsInDays)-1) / 100)
+	interval := practiceIntervalsInDays[box]
+
+	overdue := neverPlayedOverdue
+	if lastTimePlayed != nil {
+		overdue = now.Sub(*lastTimePlayed).Hours() / 24 / interval
+	}
+	if overdue < 1 {
+		return 0, interval
+	}
+
+	// the less confident the section is, the sooner it should be rehearsed
+	confidenceWeight := 1 + (100-confidence)/100
+	return overdue * confidenceWeight * difficultyWeight, interval
+}
+
+func (p practiceQueueProcessor) estimatePracticeDuration(song model.Song) time.Duration {
+	if song.Duration == nil {
+		return defaultSongPracticeDuration
+	}
+	return time.Duration(*song.Duration) * time.Second
+}
@@ -0,0 +1,89 @@
+package processor
+
+import (
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type PracticeSessionProcessor interface {
+	RecordPerfectRehearsal(
+		song model.Song,
+		batchID uuid.UUID,
+		practiceSessionRepository repository.PracticeSessionRepository,
+	) *wrapper.ErrorCode
+	RecordRehearsal(
+		song model.Song,
+		batchID uuid.UUID,
+		sections []model.PracticeSessionSection,
+		practiceSessionRepository repository.PracticeSessionRepository,
+	) *wrapper.ErrorCode
+}
+
+type practiceSessionProcessor struct{}
+
+func NewPracticeSessionProcessor() PracticeSessionProcessor {
+	return &practiceSessionProcessor{}
+}
+
+func (p *practiceSessionProcessor) RecordPerfectRehearsal(
+	song model.Song,
+	batchID uuid.UUID,
+	practiceSessionRepository repository.PracticeSessionRepository,
+) *wrapper.ErrorCode {
+	var sections []model.PracticeSessionSection
+	for _, section := range song.Sections {
+		if section.Occurrences == 0 {
+			continue
+		}
+		sections = append(sections, model.PracticeSessionSection{
+			SongSectionID: section.ID,
+			Occurrences:   section.Occurrences,
+		})
+	}
+	return p.RecordRehearsal(song, batchID, sections, practiceSessionRepository)
+}
+
+// RecordRehearsal adds the song to the practice session in progress (if any),
+// linked to the rehearsal batch, so that undoing the rehearsal removes it as well
+func (p *practiceSessionProcessor) RecordRehearsal(
+	song model.Song,
+	batchID uuid.UUID,
+	sections []model.PracticeSessionSection,
+	practiceSessionRepository repository.PracticeSessionRepository,
+) *wrapper.ErrorCode {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	var session model.PracticeSession
+	err := practiceSessionRepository.GetInProgressByUser(&session, song.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	// rehearsals outside a practice session are not recorded
+	if reflect.ValueOf(session).IsZero() {
+		return nil
+	}
+
+	sessionSong := model.PracticeSessionSong{
+		ID:                uuid.New(),
+		PracticeSessionID: session.ID,
+		SongID:            song.ID,
+		BatchID:           &batchID,
+	}
+	for _, section := range sections {
+		section.ID = uuid.New()
+		section.PracticeSessionSongID = sessionSong.ID
+		sessionSong.Sections = append(sessionSong.Sections, section)
+	}
+
+	err = practiceSessionRepository.CreateSong(&sessionSong)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package processor
+
+import (
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"time"
+)
+
+type ProgressTimelineProcessor interface {
+	BuildTimeline(
+		sections []model.SongSection,
+		interval enums.TimelineInterval,
+		from *time.Time,
+		to *time.Time,
+		strategy enums.ScoringStrategy,
+	) []model.ProgressTimelinePoint
+}
+
+// keeps the series (and the replay of the history) bounded, only the latest buckets are returned
+const maxTimelineBuckets = 366
+
+type progressTimelineProcessor struct {
+	progressProcessor ProgressProcessor
+}
+
+func NewProgressTimelineProcessor(progressProcessor ProgressProcessor) ProgressTimelineProcessor {
+	return &progressTimelineProcessor{
+		progressProcessor: progressProcessor,
+	}
+}
+
+func (p progressTimelineProcessor) BuildTimeline(
+	sections []model.SongSection,
+	interval enums.TimelineInterval,
+	from *time.Time,
+	to *time.Time,
+	strategy enums.ScoringStrategy,
+) []model.ProgressTimelinePoint {
+	end := time.Now().UTC()
+	if to != nil {
+		end = to.UTC()
+	}
+
+	var start time.Time
+	if from != nil {
+		start = from.UTC()
+	} else {
+		// without a starting point, the timeline starts with the first recorded change
+		for _, section := range sections {
+			if len(section.History) > 0 && (start.IsZero() || section.History[0].CreatedAt.Before(start)) {
+				start = section.History[0].CreatedAt.UTC()
+			}
+		}
+		if start.IsZero() {
+			return []model.ProgressTimelinePoint{}
+		}
+	}
+
+	// the range is clamped before building the buckets, so that a far away start cannot make it grow unbounded
+	firstBucketStart := p.truncate(start, interval)
+	if earliest := p.add(p.truncate(end, interval), interval, 1-maxTimelineBuckets); firstBucketStart.Before(earliest) {
+		firstBucketStart = earliest
+	}
+
+	var bucketStarts []time.Time
+	for bucketStart := firstBucketStart; !bucketStart.After(end); bucketStart = p.next(bucketStart, interval) {
+		bucketStarts = append(bucketStarts, bucketStart)
+	}
+
+	timeline := make([]model.ProgressTimelinePoint, 0, len(bucketStarts))
+	for _, bucketStart := range bucketStarts {
+		timeline = append(timeline, p.buildPoint(sections, bucketStart, p.next(bucketStart, interval), strategy))
+	}
+	return timeline
+}
+
+// buildPoint replays the history of each section up to the end of the bucket,
+// as if the scores were computed at that moment
+func (p progressTimelineProcessor) buildPoint(
+	sections []model.SongSection,
+	bucketStart time.Time,
+	bucketEnd time.Time,
+	strategy enums.ScoringStrategy,
+) model.ProgressTimelinePoint {
+	point := model.ProgressTimelinePoint{Date: bucketStart}
+
+	var totalConfidence float64 = 0
+	var totalProgress float64 = 0
+	existingSections := 0
+	for _, section := range sections {
+		if !section.CreatedAt.Before(bucketEnd) {
+			continue
+		}
+		existingSections++
+
+		var rehearsalsHistory []model.SongSectionHistory
+		var confidenceHistory []model.SongSectionHistory
+		for _, h := range section.History {
+			if !h.CreatedAt.Before(bucketEnd) {
+				break
+			}
+
+			if h.Property == model.RehearsalsProperty {
+				rehearsalsHistory = append(rehearsalsHistory, h)
+				if !h.CreatedAt.Before(bucketStart) && h.To > h.From {
+					point.Rehearsals += h.To - h.From
+				}
+			} else if h.Property == model.ConfidenceProperty {
+				confidenceHistory = append(confidenceHistory, h)
+			}
+		}
+
+		confidence := model.DefaultSongSectionConfidence
+		if len(confidenceHistory) > 0 {
+			confidence = confidenceHistory[len(confidenceHistory)-1].To
+		}
+
+		replayedSection := model.SongSection{
+			RehearsalsScore: p.progressProcessor.ComputeRehearsalsScore(rehearsalsHistory, strategy),
+			ConfidenceScore: p.progressProcessor.ComputeConfidenceScore(confidenceHistory, strategy),
+		}
+
+		totalConfidence += float64(confidence)
+		totalProgress += float64(p.progressProcessor.ComputeProgress(replayedSection, strategy))
+	}
+
+	if existingSections > 0 {
+		point.Confidence = totalConfidence / float64(existingSections)
+		point.Progress = totalProgress / float64(existingSections)
+	}
+	return point
+}
+
+func (p progressTimelineProcessor) truncate(t time.Time, interval enums.TimelineInterval) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case enums.WeekInterval:
+		// weeks start on monday
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case enums.MonthInterval:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func (p progressTimelineProcessor) next(t time.Time, interval enums.TimelineInterval) time.Time {
+	return p.add(t, interval, 1)
+}
+
+// add moves the time by a number of intervals (backwards, when negative)
+func (p progressTimelineProcessor) add(t time.Time, interval enums.TimelineInterval, count int) time.Time {
+	switch interval {
+	case enums.WeekInterval:
+		return t.AddDate(0, 0, 7*count)
+	case enums.MonthInterval:
+		return t.AddDate(0, count, 0)
+	default:
+		return t.AddDate(0, 0, count)
+	}
+}
@@ -0,0 +1,195 @@
+package processor
+
+import (
+	"math"
+	"repertoire/server/model"
+	"time"
+)
+
+// ScoringStrategy computes the scores of the sections,
+// and has its version increased whenever the computation changes, so that the stored scores get rebuilt
+type ScoringStrategy interface {
+	Version() uint
+	ComputeRehearsalsScore(history []model.SongSectionHistory) uint64
+	ComputeConfidenceScore(history []model.SongSectionHistory) uint
+	ComputeProgress(section model.SongSection) uint64
+}
+
+// Classic
+
+type classicScoringStrategy struct{}
+
+func (classicScoringStrategy) Version() uint {
+	return 1
+}
+
+func (classicScoringStrategy) ComputeRehearsalsScore(history []model.SongSectionHistory) uint64 {
+	return computeRehearsalsScore(history, func(days float64) float64 {
+		return 30 / days
+	})
+}
+
+func (classicScoringStrategy) ComputeConfidenceScore(history []model.SongSectionHistory) uint {
+	historyLength := len(history)
+	if historyLength == 0 {
+		return model.DefaultSongSectionConfidence
+	}
+
+	overallConfidence := int(history[historyLength-1].To - history[0].From)
+
+	// calculate volatility of fluctuations
+	totalFluctuation := 0 // mean
+	positiveFluctuationsLen := 0
+	positiveFluctuation := 0
+	extremeFluctuations := 0
+	for _, h := range history {
+		currentFluctuation := int(h.To - h.From)
+		totalFluctuation += currentFluctuation
+		if math.Abs(float64(currentFluctuation)) > 30 {
+			extremeFluctuations++
+		}
+
+		if currentFluctuation > 0 {
+			positiveFluctuationsLen++
+			positiveFluctuation += currentFluctuation
+		}
+	}
+	averageFluctuation := float64(totalFluctuation) / float64(historyLength)
+	averagePositiveFluctuation := float64(positiveFluctuation) / float64(positiveFluctuationsLen)
+
+	negativeFluctuation := totalFluctuation - positiveFluctuation
+	negativeFluctuationsLen := historyLength - positiveFluctuationsLen
+	var averageNegativeFluctuation float64 = 0
+	if negativeFluctuationsLen != 0 {
+		averageNegativeFluctuation = float64(negativeFluctuation) / float64(negativeFluctuationsLen)
+	}
+
+	// apply penalty for fluctuations and calculate standard deviation
+	standardDeviation := 0.0
+	for _, h := range history {
+		currentFluctuation := int(h.To - h.From)
+		standardDeviation += math.Pow(float64(currentFluctuation)-averageFluctuation, 2)
+	}
+	standardDeviation = math.Sqrt(standardDeviation / float64(historyLength))
+
+	penaltyDeviation := math.Pow(standardDeviation, 1.25) * 0.25
+	penaltyNegative := math.Abs(averageNegativeFluctuation) * 0.55
+	penaltyExtreme := float64(extremeFluctuations) / float64(historyLength) * 55 / float64(negativeFluctuationsLen+1)
+	penalty := penaltyDeviation + penaltyNegative + penaltyExtreme
+
+	// reward for smooth increase
+	reward := averagePositiveFluctuation * 0.7
+
+	finalScore := float64(overallConfidence) - penalty + reward
+	return clampConfidenceScore(finalScore)
+}
+
+func (classicScoringStrategy) ComputeProgress(section model.SongSection) uint64 {
+	return roundProgress(float64(section.ConfidenceScore) * float64(section.RehearsalsScore) / 100)
+}
+
+// Exponential
+
+// the number of days after which a rehearsal is worth half as much
+const exponentialHalfLifeInDays = 7
+
+// how much the latest confidence change weighs against the previous ones
+const exponentialSmoothingFactor = 0.5
+
+type exponentialScoringStrategy struct{}
+
+func (exponentialScoringStrategy) Version() uint {
+	return 1
+}
+
+func (exponentialScoringStrategy) ComputeRehearsalsScore(history []model.SongSectionHistory) uint64 {
+	return computeRehearsalsScore(history, func(days float64) float64 {
+		return 30 * math.Pow(2, -(days-1)/exponentialHalfLifeInDays)
+	})
+}
+
+func (exponentialScoringStrategy) ComputeConfidenceScore(history []model.SongSectionHistory) uint {
+	if len(history) == 0 {
+		return model.DefaultSongSectionConfidence
+	}
+
+	average := float64(history[0].From)
+	for _, h := range history {
+		average = exponentialSmoothingFactor*float64(h.To) + (1-exponentialSmoothingFactor)*average
+	}
+	return clampConfidenceScore(average)
+}
+
+func (exponentialScoringStrategy) ComputeProgress(section model.SongSection) uint64 {
+	confidenceWeight := (math.Exp(float64(section.ConfidenceScore)/100) - 1) / (math.E - 1)
+	return roundProgress(confidenceWeight * float64(section.RehearsalsScore))
+}
+
+// Linear
+
+type linearScoringStrategy struct{}
+
+func (linearScoringStrategy) Version() uint {
+	return 1
+}
+
+func (linearScoringStrategy) ComputeRehearsalsScore(history []model.SongSectionHistory) uint64 {
+	return computeRehearsalsScore(history, func(days float64) float64 {
+		return math.Max(30-(days-1), 1)
+	})
+}
+
+func (linearScoringStrategy) ComputeConfidenceScore(history []model.SongSectionHistory) uint {
+	if len(history) == 0 {
+		return model.DefaultSongSectionConfidence
+	}
+
+	// the most recent changes weigh the most
+	weightedTotal := 0.0
+	totalWeight := 0.0
+	for i, h := range history {
+		weight := float64(i + 1)
+		weightedTotal += float64(h.To) * weight
+		totalWeight += weight
+	}
+	return clampConfidenceScore(weightedTotal / totalWeight)
+}
+
+func (linearScoringStrategy) ComputeProgress(section model.SongSection) uint64 {
+	return roundProgress((float64(section.ConfidenceScore) + float64(section.RehearsalsScore)) / 2)
+}
+
+// Utils
+
+// computeRehearsalsScore adds up the rehearsals of each change, weighted by the days passed since the previous one
+func computeRehearsalsScore(history []model.SongSectionHistory, daysWeight func(days float64) float64) uint64 {
+	if len(history) == 0 {
+		return 0
+	}
+	if len(history) == 1 {
+		return uint64(history[0].To)
+	}
+
+	var rehearsalsScore uint64 = 0
+	for i := 1; i < len(history); i++ {
+		previousRehearsal := history[i-1]
+		currentRehearsal := history[i]
+
+		daysDifference := currentRehearsal.CreatedAt.Add(24 * time.Hour).Sub(previousRehearsal.CreatedAt)
+		daysValue := daysWeight(daysDifference.Hours() / 24)
+		rehearsalsDifference := currentRehearsal.To - currentRehearsal.From
+		rehearsalsScore += uint64(math.Round(float64(rehearsalsDifference) * daysValue))
+	}
+	return rehearsalsScore
+}
+
+func clampConfidenceScore(score float64) uint {
+	return uint(math.Min(math.Max(math.Round(score), 0), 100))
+}
+
+func roundProgress(progress float64) uint64 {
+	if progress < 0.5 {
+		return 1
+	}
+	return uint64(math.Round(progress))
+}
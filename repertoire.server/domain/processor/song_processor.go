@@ -13,68 +13,99 @@ type SongProcessor interface {
 	AddPerfectRehearsal(
 		song *model.Song,
 		songSectionRepository repository.SongSectionRepository,
-	) (errCode *wrapper.ErrorCode, updatedSong bool)
+	) (errCode *wrapper.ErrorCode, batchID *uuid.UUID)
 }
 
 type songProcessor struct {
 	progressProcessor ProgressProcessor
+	userRepository    repository.UserRepository
 }
 
-func NewSongProcessor(progressProcessor ProgressProcessor) SongProcessor {
-	return &songProcessor{progressProcessor: progressProcessor}
+func NewSongProcessor(
+	progressProcessor ProgressProcessor,
+	userRepository repository.UserRepository,
+) SongProcessor {
+	return &songProcessor{
+		progressProcessor: progressProcessor,
+		userRepository:    userRepository,
+	}
 }
 
 func (s *songProcessor) AddPerfectRehearsal(
 	song *model.Song,
 	songSectionRepository repository.SongSectionRepository,
-) (*wrapper.ErrorCode, bool) {
-	var totalRehearsals float64 = 0
-	var totalProgress float64 = 0
-	for i, section := range song.Sections {
+) (*wrapper.ErrorCode, *uuid.UUID) {
+	var user model.User
+	err := s.userRepository.Get(&user, song.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err), nil
+	}
+
+	// the history added by this rehearsal is grouped, so that it can be undone as a whole
+	batch := model.RehearsalBatch{
+		ID:                     uuid.New(),
+		SongID:                 song.ID,
+		PreviousLastTimePlayed: song.LastTimePlayed,
+	}
+
+	// add history of the rehearsals changes
+	var newHistory []model.SongSectionHistory
+	for _, section := range song.Sections {
 		if section.Occurrences == 0 {
 			continue
 		}
-
-		newRehearsals := section.Rehearsals + section.Occurrences
-		// add history of the rehearsals change
-		newHistory := model.SongSectionHistory{
+		newHistory = append(newHistory, model.SongSectionHistory{
 			ID:            uuid.New(),
 			Property:      model.RehearsalsProperty,
 			From:          section.Rehearsals,
-			To:            newRehearsals,
+			To:            section.Rehearsals + section.Occurrences,
 			SongSectionID: section.ID,
-		}
-		err := songSectionRepository.CreateHistory(&newHistory)
-		if err != nil {
-			return wrapper.InternalServerError(err), false
+			BatchID:       &batch.ID,
+		})
+	}
+
+	// means that no section will get updated
+	if len(newHistory) == 0 {
+		return nil, nil
+	}
+
+	err = songSectionRepository.CreateRehearsalBatch(&batch)
+	if err != nil {
+		return wrapper.InternalServerError(err), nil
+	}
+	err = songSectionRepository.CreateHistories(&newHistory)
+	if err != nil {
+		return wrapper.InternalServerError(err), nil
+	}
+
+	var totalRehearsals float64 = 0
+	var totalProgress float64 = 0
+	for i, section := range song.Sections {
+		if section.Occurrences == 0 {
+			continue
 		}
 
 		// update section's rehearsals score based on the history changes and update the rehearsals and progress too
 		var history []model.SongSectionHistory
 		err = songSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
 		if err != nil {
-			return wrapper.InternalServerError(err), false
+			return wrapper.InternalServerError(err), nil
 		}
 
-		song.Sections[i].Rehearsals = newRehearsals
-		song.Sections[i].RehearsalsScore = s.progressProcessor.ComputeRehearsalsScore(history)
-		song.Sections[i].Progress = s.progressProcessor.ComputeProgress(song.Sections[i])
+		song.Sections[i].Rehearsals = section.Rehearsals + section.Occurrences
+		song.Sections[i].RehearsalsScore = s.progressProcessor.ComputeRehearsalsScore(history, user.ScoringStrategy)
+		song.Sections[i].Progress = s.progressProcessor.ComputeProgress(song.Sections[i], user.ScoringStrategy)
 
 		// add to the total for the median
 		totalProgress += float64(song.Sections[i].Progress)
 		totalRehearsals += float64(song.Sections[i].Rehearsals)
 	}
 
-	// means that no section got updated (because if it did, the total would be at least 1 from an occurrence)
-	if totalRehearsals == 0 {
-		return nil, false
-	}
-
 	// update song media progress and rehearsals + update last time played
 	sectionsCount := len(song.Sections)
 	song.Rehearsals = totalRehearsals / float64(sectionsCount)
 	song.Progress = totalProgress / float64(sectionsCount)
 	song.LastTimePlayed = &[]time.Time{time.Now().UTC()}[0]
 
-	return nil, true
+	return nil, &batch.ID
 }
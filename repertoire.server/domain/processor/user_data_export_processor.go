@@ -0,0 +1,401 @@
+package processor
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"repertoire/server/data/service"
+	"repertoire/server/internal"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type UserDataExportProcessor interface {
+	Export(writer io.Writer, user model.User) *wrapper.ErrorCode
+}
+
+const exportImagesDirectory = "images/"
+
+type userDataExportProcessor struct {
+	storageService service.StorageService
+}
+
+func NewUserDataExportProcessor(storageService service.StorageService) UserDataExportProcessor {
+	return &userDataExportProcessor{
+		storageService: storageService,
+	}
+}
+
+// Export writes a ZIP archive with a JSON file for every kind of entity owned by the user
+// (the user is expected to have all its data loaded), alongside their images
+func (u userDataExportProcessor) Export(writer io.Writer, user model.User) *wrapper.ErrorCode {
+	archive := zip.NewWriter(writer)
+
+	profilePicture, errCode := u.addImage(archive, user.ProfilePictureURL, user.ID)
+	if errCode != nil {
+		return errCode
+	}
+	artists, errCode := u.exportArtists(archive, user)
+	if errCode != nil {
+		return errCode
+	}
+	albums, errCode := u.exportAlbums(archive, user)
+	if errCode != nil {
+		return errCode
+	}
+	songs, errCode := u.exportSongs(archive, user)
+	if errCode != nil {
+		return errCode
+	}
+	playlists, errCode := u.exportPlaylists(archive, user)
+	if errCode != nil {
+		return errCode
+	}
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{model.ExportedUserFile, model.ExportedUser{
+			ID:              user.ID,
+			Name:            user.Name,
+			Email:           user.Email,
+			ProfilePicture:  profilePicture,
+			ScoringStrategy: user.ScoringStrategy,
+			CreatedAt:       user.CreatedAt,
+		}},
+		{model.ExportedArtistsFile, artists},
+		{model.ExportedAlbumsFile, albums},
+		{model.ExportedSongsFile, songs},
+		{model.ExportedPlaylistsFile, playlists},
+		{model.ExportedPracticeSessionsFile, u.exportPracticeSessions(user.PracticeSessions)},
+		{model.ExportedSetlistsFile, u.exportSetlists(user.Setlists)},
+		{model.ExportedBandMemberRolesFile, u.exportBandMemberRoles(user.BandMemberRoles)},
+		{model.ExportedGuitarTuningsFile, u.exportGuitarTunings(user.GuitarTunings)},
+		{model.ExportedInstrumentsFile, u.exportInstruments(user.Instruments)},
+		{model.ExportedSectionTypesFile, u.exportSectionTypes(user.SongSectionTypes)},
+	}
+	for _, file := range files {
+		err := u.addJSON(archive, file.name, file.content)
+		if err != nil {
+			return wrapper.InternalServerError(err)
+		}
+	}
+
+	err := archive.Close()
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
+
+func (u userDataExportProcessor) exportArtists(archive *zip.Writer, user model.User) ([]model.ExportedArtist, *wrapper.ErrorCode) {
+	artists := make([]model.ExportedArtist, 0, len(user.Artists))
+	for _, artist := range user.Artists {
+		image, errCode := u.addImage(archive, artist.ImageURL, user.ID)
+		if errCode != nil {
+			return nil, errCode
+		}
+
+		members := make([]model.ExportedBandMember, 0, len(artist.BandMembers))
+		for _, member := range artist.BandMembers {
+			memberImage, errCode := u.addImage(archive, member.ImageURL, user.ID)
+			if errCode != nil {
+				return nil, errCode
+			}
+
+			roleIDs := make([]uuid.UUID, 0, len(member.Roles))
+			for _, role := range member.Roles {
+				roleIDs = append(roleIDs, role.ID)
+			}
+
+			members = append(members, model.ExportedBandMember{
+				ID:        member.ID,
+				Name:      member.Name,
+				Order:     member.Order,
+				Color:     member.Color,
+				Image:     memberImage,
+				RoleIDs:   roleIDs,
+				CreatedAt: member.CreatedAt,
+				UpdatedAt: member.UpdatedAt,
+			})
+		}
+
+		artists = append(artists, model.ExportedArtist{
+			ID:          artist.ID,
+			Name:        artist.Name,
+			IsBand:      artist.IsBand,
+			Image:       image,
+			BandMembers: members,
+			CreatedAt:   artist.CreatedAt,
+			UpdatedAt:   artist.UpdatedAt,
+		})
+	}
+	return artists, nil
+}
+
+func (u userDataExportProcessor) exportAlbums(archive *zip.Writer, user model.User) ([]model.ExportedAlbum, *wrapper.ErrorCode) {
+	albums := make([]model.ExportedAlbum, 0, len(user.Albums))
+	for _, album := range user.Albums {
+		image, errCode := u.addImage(archive, album.ImageURL, user.ID)
+		if errCode != nil {
+			return nil, errCode
+		}
+
+		albums = append(albums, model.ExportedAlbum{
+			ID:          album.ID,
+			Title:       album.Title,
+			ReleaseDate: album.ReleaseDate,
+			Image:       image,
+			ArtistID:    album.ArtistID,
+			CreatedAt:   album.CreatedAt,
+			UpdatedAt:   album.UpdatedAt,
+		})
+	}
+	return albums, nil
+}
+
+func (u userDataExportProcessor) exportSongs(archive *zip.Writer, user model.User) ([]model.ExportedSong, *wrapper.ErrorCode) {
+	songs := make([]model.ExportedSong, 0, len(user.Songs))
+	for _, song := range user.Songs {
+		image, errCode := u.addImage(archive, song.ImageURL, user.ID)
+		if errCode != nil {
+			return nil, errCode
+		}
+
+		sections := make([]model.ExportedSongSection, 0, len(song.Sections))
+		for _, section := range song.Sections {
+			history := make([]model.ExportedSongSectionHistory, 0, len(section.History))
+			for _, h := range section.History {
+				history = append(history, model.ExportedSongSectionHistory{
+					Property:  h.Property,
+					From:      h.From,
+					To:        h.To,
+					CreatedAt: h.CreatedAt,
+				})
+			}
+
+			sections = append(sections, model.ExportedSongSection{
+				ID:                 section.ID,
+				Name:               section.Name,
+				Order:              section.Order,
+				Occurrences:        section.Occurrences,
+				PartialOccurrences: section.PartialOccurrences,
+				StartOffset:        section.StartOffset,
+				BarCount:           section.BarCount,
+				TimeSignature:      section.TimeSignature,
+				Rehearsals:         section.Rehearsals,
+				Confidence:         section.Confidence,
+				RehearsalsScore:    section.RehearsalsScore,
+				ConfidenceScore:    section.ConfidenceScore,
+				Progress:           section.Progress,
+				SongSectionTypeID:  section.SongSectionTypeID,
+				InstrumentID:       section.InstrumentID,
+				BandMemberID:       section.BandMemberID,
+				History:            history,
+				CreatedAt:          section.CreatedAt,
+				UpdatedAt:          section.UpdatedAt,
+			})
+		}
+
+		songs = append(songs, model.ExportedSong{
+			ID:             song.ID,
+			Title:          song.Title,
+			Description:    song.Description,
+			ReleaseDate:    song.ReleaseDate,
+			Image:          image,
+			IsRecorded:     song.IsRecorded,
+			Bpm:            song.Bpm,
+			Duration:       song.Duration,
+			Difficulty:     song.Difficulty,
+			SongsterrLink:  song.SongsterrLink,
+			YoutubeLink:    song.YoutubeLink,
+			LastTimePlayed: song.LastTimePlayed,
+			Rehearsals:     song.Rehearsals,
+			Confidence:     song.Confidence,
+			Progress:       song.Progress,
+			AlbumID:        song.AlbumID,
+			AlbumTrackNo:   song.AlbumTrackNo,
+			ArtistID:       song.ArtistID,
+			GuitarTuningID: song.GuitarTuningID,
+			Settings: model.ExportedSongSettings{
+				DefaultInstrumentID: song.Settings.DefaultInstrumentID,
+				DefaultBandMemberID: song.Settings.DefaultBandMemberID,
+			},
+			Sections:  sections,
+			CreatedAt: song.CreatedAt,
+			UpdatedAt: song.UpdatedAt,
+		})
+	}
+	return songs, nil
+}
+
+func (u userDataExportProcessor) exportPlaylists(archive *zip.Writer, user model.User) ([]model.ExportedPlaylist, *wrapper.ErrorCode) {
+	playlists := make([]model.ExportedPlaylist, 0, len(user.Playlists))
+	for _, playlist := range user.Playlists {
+		image, errCode := u.addImage(archive, playlist.ImageURL, user.ID)
+		if errCode != nil {
+			return nil, errCode
+		}
+
+		// the playlist songs are loaded in the order of their track number
+		songIDs := make([]uuid.UUID, 0, len(playlist.PlaylistSongs))
+		for _, playlistSong := range playlist.PlaylistSongs {
+			songIDs = append(songIDs, playlistSong.SongID)
+		}
+
+		playlists = append(playlists, model.ExportedPlaylist{
+			ID:          playlist.ID,
+			Title:       playlist.Title,
+			Description: playlist.Description,
+			Image:       image,
+			SongIDs:     songIDs,
+			SmartRules:  playlist.SmartRules,
+			CreatedAt:   playlist.CreatedAt,
+			UpdatedAt:   playlist.UpdatedAt,
+		})
+	}
+	return playlists, nil
+}
+
+func (u userDataExportProcessor) exportPracticeSessions(sessions []model.PracticeSession) []model.ExportedPracticeSession {
+	exported := make([]model.ExportedPracticeSession, 0, len(sessions))
+	for _, session := range sessions {
+		songs := make([]model.ExportedPracticeSessionSong, 0, len(session.Songs))
+		for _, sessionSong := range session.Songs {
+			sections := make([]model.ExportedPracticeSessionSection, 0, len(sessionSong.Sections))
+			for _, section := range sessionSong.Sections {
+				sections = append(sections, model.ExportedPracticeSessionSection{
+					SongSectionID: section.SongSectionID,
+					Occurrences:   section.Occurrences,
+				})
+			}
+
+			songs = append(songs, model.ExportedPracticeSessionSong{
+				SongID:    sessionSong.SongID,
+				Sections:  sections,
+				CreatedAt: sessionSong.CreatedAt,
+			})
+		}
+
+		exported = append(exported, model.ExportedPracticeSession{
+			ID:        session.ID,
+			Notes:     session.Notes,
+			StartedAt: session.StartedAt,
+			EndedAt:   session.EndedAt,
+			Songs:     songs,
+			CreatedAt: session.CreatedAt,
+			UpdatedAt: session.UpdatedAt,
+		})
+	}
+	return exported
+}
+
+func (u userDataExportProcessor) exportSetlists(setlists []model.Setlist) []model.ExportedSetlist {
+	exported := make([]model.ExportedSetlist, 0, len(setlists))
+	for _, setlist := range setlists {
+		entries := make([]model.ExportedSetlistEntry, 0, len(setlist.Entries))
+		for _, entry := range setlist.Entries {
+			entries = append(entries, model.ExportedSetlistEntry{
+				Type:            entry.Type,
+				Title:           entry.Title,
+				PlannedDuration: entry.PlannedDuration,
+				EntryNo:         entry.EntryNo,
+				SongID:          entry.SongID,
+				CreatedAt:       entry.CreatedAt,
+			})
+		}
+
+		exported = append(exported, model.ExportedSetlist{
+			ID:        setlist.ID,
+			Title:     setlist.Title,
+			Venue:     setlist.Venue,
+			Date:      setlist.Date,
+			Notes:     setlist.Notes,
+			Entries:   entries,
+			CreatedAt: setlist.CreatedAt,
+			UpdatedAt: setlist.UpdatedAt,
+		})
+	}
+	return exported
+}
+
+func (u userDataExportProcessor) exportBandMemberRoles(roles []model.BandMemberRole) []model.ExportedUserDataItem {
+	items := make([]model.ExportedUserDataItem, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, model.ExportedUserDataItem{ID: role.ID, Name: role.Name, Order: role.Order})
+	}
+	return items
+}
+
+func (u userDataExportProcessor) exportGuitarTunings(tunings []model.GuitarTuning) []model.ExportedUserDataItem {
+	items := make([]model.ExportedUserDataItem, 0, len(tunings))
+	for _, tuning := range tunings {
+		items = append(items, model.ExportedUserDataItem{ID: tuning.ID, Name: tuning.Name, Order: tuning.Order})
+	}
+	return items
+}
+
+func (u userDataExportProcessor) exportInstruments(instruments []model.Instrument) []model.ExportedUserDataItem {
+	items := make([]model.ExportedUserDataItem, 0, len(instruments))
+	for _, instrument := range instruments {
+		items = append(items, model.ExportedUserDataItem{ID: instrument.ID, Name: instrument.Name, Order: instrument.Order})
+	}
+	return items
+}
+
+func (u userDataExportProcessor) exportSectionTypes(sectionTypes []model.SongSectionType) []model.ExportedUserDataItem {
+	items := make([]model.ExportedUserDataItem, 0, len(sectionTypes))
+	for _, sectionType := range sectionTypes {
+		items = append(items, model.ExportedUserDataItem{ID: sectionType.ID, Name: sectionType.Name, Order: sectionType.Order})
+	}
+	return items
+}
+
+// addImage fetches the image from the storage and writes it inside the archive,
+// by keeping the storage path without the user directory.
+// Images that cannot be found anymore are left out of the export
+func (u userDataExportProcessor) addImage(
+	archive *zip.Writer,
+	imageURL *internal.FilePath,
+	userID uuid.UUID,
+) (*string, *wrapper.ErrorCode) {
+	if imageURL == nil {
+		return nil, nil
+	}
+
+	content, errCode := u.storageService.Get(*imageURL)
+	if errCode != nil && errCode.Code == http.StatusNotFound {
+		return nil, nil
+	}
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	path := exportImagesDirectory + strings.TrimPrefix(string(*imageURL.StripURL()), userID.String()+"/")
+	file, err := archive.Create(path)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	_, err = file.Write(content)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	return &path, nil
+}
+
+func (u userDataExportProcessor) addJSON(archive *zip.Writer, name string, content any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
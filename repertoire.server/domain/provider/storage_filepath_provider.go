@@ -1,8 +1,7 @@
 package provider
 
 import (
-	"mime/multipart"
-	"path/filepath"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 	"strings"
 	"time"
@@ -11,18 +10,21 @@ import (
 )
 
 type StorageFilePathProvider interface {
-	GetUserProfilePicturePath(file *multipart.FileHeader, user model.User) string
-	GetAlbumImagePath(file *multipart.FileHeader, album model.Album) string
-	GetArtistImagePath(file *multipart.FileHeader, artist model.Artist) string
-	GetBandMemberImagePath(file *multipart.FileHeader, artist model.BandMember) string
-	GetPlaylistImagePath(file *multipart.FileHeader, playlist model.Playlist) string
-	GetSongImagePath(file *multipart.FileHeader, song model.Song) string
+	GetUserProfilePicturePath(user model.User) string
+	GetAlbumImagePath(album model.Album) string
+	GetArtistImagePath(artist model.Artist) string
+	GetBandMemberImagePath(artist model.BandMember) string
+	GetPlaylistImagePath(playlist model.Playlist) string
+	GetSongImagePath(song model.Song) string
+	GetUserDataExportPath(userID uuid.UUID) string
 
 	GetUserDirectoryPath(id uuid.UUID) string
 	GetAlbumDirectoryPath(album model.Album) string
 	GetArtistDirectoryPath(artist model.Artist) string
 	GetPlaylistDirectoryPath(playlist model.Playlist) string
 	GetSongDirectoryPath(song model.Song) string
+
+	GetStorageUsageCategory(filePath string) enums.StorageUsageCategory
 }
 
 type storageFilePathProvider struct {
@@ -37,58 +39,64 @@ var artistRootDirectory = "artists"
 var bandMemberRootDirectory = "members"
 var songRootDirectory = "songs"
 var playlistRootDirectory = "playlists"
+var exportRootDirectory = "exports"
+
+// the storage converts all the uploaded images to JPEG
+var imageExtension = ".jpg"
 
-func (s storageFilePathProvider) GetUserProfilePicturePath(file *multipart.FileHeader, user model.User) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetUserProfilePicturePath(user model.User) string {
 	return s.builder().
 		WithDirectory(user.ID.String()).
-		WithFile(s.getTimeFormat(user.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(user.UpdatedAt) + imageExtension).
 		BuildFilePath()
 }
 
-func (s storageFilePathProvider) GetAlbumImagePath(file *multipart.FileHeader, album model.Album) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetAlbumImagePath(album model.Album) string {
 	return s.builder().
 		WithDirectory(album.UserID.String()).
 		WithDirectory(albumRootDirectory).
 		WithDirectory(album.ID.String()).
-		WithFile(s.getTimeFormat(album.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(album.UpdatedAt) + imageExtension).
 		BuildFilePath()
 }
 
-func (s storageFilePathProvider) GetArtistImagePath(file *multipart.FileHeader, artist model.Artist) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetArtistImagePath(artist model.Artist) string {
 	return s.getArtistDirectory(artist).
-		WithFile(s.getTimeFormat(artist.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(artist.UpdatedAt) + imageExtension).
 		BuildFilePath()
 }
 
-func (s storageFilePathProvider) GetBandMemberImagePath(file *multipart.FileHeader, member model.BandMember) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetBandMemberImagePath(member model.BandMember) string {
 	return s.getArtistDirectory(member.Artist).
 		WithDirectory(bandMemberRootDirectory).
 		WithDirectory(member.ID.String()).
-		WithFile(s.getTimeFormat(member.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(member.UpdatedAt) + imageExtension).
 		BuildFilePath()
 }
 
-func (s storageFilePathProvider) GetPlaylistImagePath(file *multipart.FileHeader, playlist model.Playlist) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetPlaylistImagePath(playlist model.Playlist) string {
 	return s.builder().
 		WithDirectory(playlist.UserID.String()).
 		WithDirectory(playlistRootDirectory).
 		WithDirectory(playlist.ID.String()).
-		WithFile(s.getTimeFormat(playlist.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(playlist.UpdatedAt) + imageExtension).
 		BuildFilePath()
 }
 
-func (s storageFilePathProvider) GetSongImagePath(file *multipart.FileHeader, song model.Song) string {
-	fileExtension := filepath.Ext(file.Filename)
+func (s storageFilePathProvider) GetSongImagePath(song model.Song) string {
 	return s.builder().
 		WithDirectory(song.UserID.String()).
 		WithDirectory(songRootDirectory).
 		WithDirectory(song.ID.String()).
-		WithFile(s.getTimeFormat(song.UpdatedAt) + fileExtension).
+		WithFile(s.getTimeFormat(song.UpdatedAt) + imageExtension).
+		BuildFilePath()
+}
+
+func (s storageFilePathProvider) GetUserDataExportPath(userID uuid.UUID) string {
+	return s.builder().
+		WithDirectory(userID.String()).
+		WithDirectory(exportRootDirectory).
+		WithFile(s.getTimeFormat(time.Now().UTC()) + ".zip").
 		BuildFilePath()
 }
 
@@ -130,6 +138,30 @@ func (s storageFilePathProvider) GetSongDirectoryPath(song model.Song) string {
 		BuildDirectoryPath()
 }
 
+// GetStorageUsageCategory tells what the file belongs to, based on the directories that it has been placed in
+// (e.g. {userID}/artists/{artistID}/members/{memberID}/{file} belongs to a band member)
+func (s storageFilePathProvider) GetStorageUsageCategory(filePath string) enums.StorageUsageCategory {
+	directories := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	if len(directories) < 4 {
+		return enums.OtherStorageUsage
+	}
+
+	switch directories[1] {
+	case albumRootDirectory:
+		return enums.AlbumStorageUsage
+	case artistRootDirectory:
+		if len(directories) >= 6 && directories[3] == bandMemberRootDirectory {
+			return enums.BandMemberStorageUsage
+		}
+		return enums.ArtistStorageUsage
+	case playlistRootDirectory:
+		return enums.PlaylistStorageUsage
+	case songRootDirectory:
+		return enums.SongStorageUsage
+	}
+	return enums.OtherStorageUsage
+}
+
 func (s storageFilePathProvider) getTimeFormat(time time.Time) string {
 	return time.Format("2006_01_02T15_04_05")
 }
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type DeadLetterService interface {
+	Discard(id uuid.UUID) *wrapper.ErrorCode
+	Get(id uuid.UUID) (model.DeadLetterMessage, *wrapper.ErrorCode)
+	GetAll(request requests.GetDeadLetterMessagesRequest) (wrapper.WithTotalCount[model.DeadLetterMessage], *wrapper.ErrorCode)
+	GetMetrics() ([]model.DeadLetterTopicMetrics, *wrapper.ErrorCode)
+	Replay(id uuid.UUID) *wrapper.ErrorCode
+}
+
+type deadLetterService struct {
+	discardDeadLetterMessage deadletter.DiscardDeadLetterMessage
+	getDeadLetterMessage     deadletter.GetDeadLetterMessage
+	getAllDeadLetterMessages deadletter.GetAllDeadLetterMessages
+	getDeadLetterMetrics     deadletter.GetDeadLetterMetrics
+	replayDeadLetterMessage  deadletter.ReplayDeadLetterMessage
+}
+
+func NewDeadLetterService(
+	discardDeadLetterMessage deadletter.DiscardDeadLetterMessage,
+	getDeadLetterMessage deadletter.GetDeadLetterMessage,
+	getAllDeadLetterMessages deadletter.GetAllDeadLetterMessages,
+	getDeadLetterMetrics deadletter.GetDeadLetterMetrics,
+	replayDeadLetterMessage deadletter.ReplayDeadLetterMessage,
+) DeadLetterService {
+	return &deadLetterService{
+		discardDeadLetterMessage: discardDeadLetterMessage,
+		getDeadLetterMessage:     getDeadLetterMessage,
+		getAllDeadLetterMessages: getAllDeadLetterMessages,
+		getDeadLetterMetrics:     getDeadLetterMetrics,
+		replayDeadLetterMessage:  replayDeadLetterMessage,
+	}
+}
+
+func (d *deadLetterService) Discard(id uuid.UUID) *wrapper.ErrorCode {
+	return d.discardDeadLetterMessage.Handle(id)
+}
+
+func (d *deadLetterService) Get(id uuid.UUID) (model.DeadLetterMessage, *wrapper.ErrorCode) {
+	return d.getDeadLetterMessage.Handle(id)
+}
+
+func (d *deadLetterService) GetAll(
+	request requests.GetDeadLetterMessagesRequest,
+) (wrapper.WithTotalCount[model.DeadLetterMessage], *wrapper.ErrorCode) {
+	return d.getAllDeadLetterMessages.Handle(request)
+}
+
+func (d *deadLetterService) GetMetrics() ([]model.DeadLetterTopicMetrics, *wrapper.ErrorCode) {
+	return d.getDeadLetterMetrics.Handle()
+}
+
+func (d *deadLetterService) Replay(id uuid.UUID) *wrapper.ErrorCode {
+	return d.replayDeadLetterMessage.Handle(id)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"repertoire/server/domain/usecase/storage"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type OrphanedFileService interface {
+	Collect(userID *uuid.UUID, dryRun bool) (model.OrphanedFilesCollection, *wrapper.ErrorCode)
+}
+
+type orphanedFileService struct {
+	collectOrphanedFiles storage.CollectOrphanedFiles
+}
+
+func NewOrphanedFileService(collectOrphanedFiles storage.CollectOrphanedFiles) OrphanedFileService {
+	return &orphanedFileService{
+		collectOrphanedFiles: collectOrphanedFiles,
+	}
+}
+
+func (o orphanedFileService) Collect(userID *uuid.UUID, dryRun bool) (model.OrphanedFilesCollection, *wrapper.ErrorCode) {
+	return o.collectOrphanedFiles.Handle(userID, dryRun)
+}
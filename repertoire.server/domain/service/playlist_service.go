@@ -20,6 +20,7 @@ type PlaylistService interface {
 	Create(request requests.CreatePlaylistRequest, token string) (uuid.UUID, *wrapper.ErrorCode)
 	Delete(id uuid.UUID) *wrapper.ErrorCode
 	DeleteImage(id uuid.UUID) *wrapper.ErrorCode
+	Freeze(request requests.FreezePlaylistRequest) *wrapper.ErrorCode
 	GetAll(request requests.GetPlaylistsRequest, token string) (wrapper.WithTotalCount[model.EnhancedPlaylist], *wrapper.ErrorCode)
 	Get(request requests.GetPlaylistRequest) (model.Playlist, *wrapper.ErrorCode)
 	GetFiltersMetadata(
@@ -28,6 +29,7 @@ type PlaylistService interface {
 	) (model.PlaylistFiltersMetadata, *wrapper.ErrorCode)
 	SaveImage(file *multipart.FileHeader, id uuid.UUID) *wrapper.ErrorCode
 	Update(request requests.UpdatePlaylistRequest) *wrapper.ErrorCode
+	UpdateSmartRules(request requests.UpdatePlaylistSmartRulesRequest) *wrapper.ErrorCode
 
 	AddSongs(request requests.AddSongsToPlaylistRequest) (*responses.AddSongsToPlaylistResponse, *wrapper.ErrorCode)
 	GetSongs(request requests.GetPlaylistSongsRequest) (wrapper.WithTotalCount[model.Song], *wrapper.ErrorCode)
@@ -44,11 +46,13 @@ type playlistService struct {
 	createPlaylist                  playlist.CreatePlaylist
 	deletePlaylist                  playlist.DeletePlaylist
 	deleteImageFromPlaylist         playlist.DeleteImageFromPlaylist
+	freezePlaylist                  playlist.FreezePlaylist
 	getAllPlaylists                 playlist.GetAllPlaylists
 	getPlaylist                     playlist.GetPlaylist
 	getPlaylistFiltersMetadata      playlist.GetPlaylistFiltersMetadata
 	saveImageToPlaylist             playlist.SaveImageToPlaylist
 	updatePlaylist                  playlist.UpdatePlaylist
+	updatePlaylistSmartRules        playlist.UpdatePlaylistSmartRules
 
 	addSongsToPlaylist      song.AddSongsToPlaylist
 	getPlaylistSongs        song.GetPlaylistSongs
@@ -65,11 +69,13 @@ func NewPlaylistService(
 	createPlaylist playlist.CreatePlaylist,
 	deletePlaylist playlist.DeletePlaylist,
 	deleteImageFromPlaylist playlist.DeleteImageFromPlaylist,
+	freezePlaylist playlist.FreezePlaylist,
 	getAllPlaylists playlist.GetAllPlaylists,
 	getPlaylist playlist.GetPlaylist,
 	getPlaylistFiltersMetadata playlist.GetPlaylistFiltersMetadata,
 	saveImageToPlaylist playlist.SaveImageToPlaylist,
 	updatePlaylist playlist.UpdatePlaylist,
+	updatePlaylistSmartRules playlist.UpdatePlaylistSmartRules,
 
 	addSongsToPlaylist song.AddSongsToPlaylist,
 	getPlaylistSongs song.GetPlaylistSongs,
@@ -85,11 +91,13 @@ func NewPlaylistService(
 		createPlaylist:                  createPlaylist,
 		deletePlaylist:                  deletePlaylist,
 		deleteImageFromPlaylist:         deleteImageFromPlaylist,
+		freezePlaylist:                  freezePlaylist,
 		getAllPlaylists:                 getAllPlaylists,
 		getPlaylist:                     getPlaylist,
 		getPlaylistFiltersMetadata:      getPlaylistFiltersMetadata,
 		saveImageToPlaylist:             saveImageToPlaylist,
 		updatePlaylist:                  updatePlaylist,
+		updatePlaylistSmartRules:        updatePlaylistSmartRules,
 
 		addSongsToPlaylist:      addSongsToPlaylist,
 		getPlaylistSongs:        getPlaylistSongs,
@@ -129,6 +137,10 @@ func (p *playlistService) DeleteImage(id uuid.UUID) *wrapper.ErrorCode {
 	return p.deleteImageFromPlaylist.Handle(id)
 }
 
+func (p *playlistService) Freeze(request requests.FreezePlaylistRequest) *wrapper.ErrorCode {
+	return p.freezePlaylist.Handle(request)
+}
+
 func (p *playlistService) GetAll(request requests.GetPlaylistsRequest, token string) (wrapper.WithTotalCount[model.EnhancedPlaylist], *wrapper.ErrorCode) {
 	return p.getAllPlaylists.Handle(request, token)
 }
@@ -152,6 +164,10 @@ func (p *playlistService) Update(request requests.UpdatePlaylistRequest) *wrappe
 	return p.updatePlaylist.Handle(request)
 }
 
+func (p *playlistService) UpdateSmartRules(request requests.UpdatePlaylistSmartRulesRequest) *wrapper.ErrorCode {
+	return p.updatePlaylistSmartRules.Handle(request)
+}
+
 // songs
 
 func (p *playlistService) AddSongs(
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type PracticeSessionService interface {
+	AddSong(request requests.AddSongToPracticeSessionRequest) *wrapper.ErrorCode
+	Delete(id uuid.UUID) *wrapper.ErrorCode
+	Finish(request requests.FinishPracticeSessionRequest) *wrapper.ErrorCode
+	GetAll(
+		request requests.GetPracticeSessionsRequest,
+		token string,
+	) (wrapper.WithTotalCount[model.PracticeSession], *wrapper.ErrorCode)
+	Get(id uuid.UUID) (model.PracticeSession, *wrapper.ErrorCode)
+	Start(request requests.StartPracticeSessionRequest, token string) (uuid.UUID, *wrapper.ErrorCode)
+}
+
+type practiceSessionService struct {
+	addSongToPracticeSession practice.AddSongToPracticeSession
+	deletePracticeSession    practice.DeletePracticeSession
+	finishPracticeSession    practice.FinishPracticeSession
+	getAllPracticeSessions   practice.GetAllPracticeSessions
+	getPracticeSession       practice.GetPracticeSession
+	startPracticeSession     practice.StartPracticeSession
+}
+
+func NewPracticeSessionService(
+	addSongToPracticeSession practice.AddSongToPracticeSession,
+	deletePracticeSession practice.DeletePracticeSession,
+	finishPracticeSession practice.FinishPracticeSession,
+	getAllPracticeSessions practice.GetAllPracticeSessions,
+	getPracticeSession practice.GetPracticeSession,
+	startPracticeSession practice.StartPracticeSession,
+) PracticeSessionService {
+	return &practiceSessionService{
+		addSongToPracticeSession: addSongToPracticeSession,
+		deletePracticeSession:    deletePracticeSession,
+		finishPracticeSession:    finishPracticeSession,
+		getAllPracticeSessions:   getAllPracticeSessions,
+		getPracticeSession:       getPracticeSession,
+		startPracticeSession:     startPracticeSession,
+	}
+}
+
+func (p *practiceSessionService) AddSong(request requests.AddSongToPracticeSessionRequest) *wrapper.ErrorCode {
+	return p.addSongToPracticeSession.Handle(request)
+}
+
+func (p *practiceSessionService) Delete(id uuid.UUID) *wrapper.ErrorCode {
+	return p.deletePracticeSession.Handle(id)
+}
+
+func (p *practiceSessionService) Finish(request requests.FinishPracticeSessionRequest) *wrapper.ErrorCode {
+	return p.finishPracticeSession.Handle(request)
+}
+
+func (p *practiceSessionService) GetAll(
+	request requests.GetPracticeSessionsRequest,
+	token string,
+) (wrapper.WithTotalCount[model.PracticeSession], *wrapper.ErrorCode) {
+	return p.getAllPracticeSessions.Handle(request, token)
+}
+
+func (p *practiceSessionService) Get(id uuid.UUID) (model.PracticeSession, *wrapper.ErrorCode) {
+	return p.getPracticeSession.Handle(id)
+}
+
+func (p *practiceSessionService) Start(
+	request requests.StartPracticeSessionRequest,
+	token string,
+) (uuid.UUID, *wrapper.ErrorCode) {
+	return p.startPracticeSession.Handle(request, token)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/progress"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type ProgressService interface {
+	GetTimeline(
+		request requests.GetProgressTimelineRequest,
+		token string,
+	) ([]model.ProgressTimelinePoint, *wrapper.ErrorCode)
+}
+
+type progressService struct {
+	getProgressTimeline progress.GetProgressTimeline
+}
+
+func NewProgressService(getProgressTimeline progress.GetProgressTimeline) ProgressService {
+	return &progressService{
+		getProgressTimeline: getProgressTimeline,
+	}
+}
+
+func (p *progressService) GetTimeline(
+	request requests.GetProgressTimelineRequest,
+	token string,
+) ([]model.ProgressTimelinePoint, *wrapper.ErrorCode) {
+	return p.getProgressTimeline.Handle(request, token)
+}
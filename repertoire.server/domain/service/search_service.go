@@ -5,29 +5,43 @@ import (
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/search"
 	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
 )
 
 type SearchService interface {
-	Get(request requests.SearchGetRequest, token string) (wrapper.WithTotalCount[any], *wrapper.ErrorCode)
+	Get(request requests.SearchGetRequest, token string) (model.SearchResults[any], *wrapper.ErrorCode)
 	MeiliWebhook(requestBody io.ReadCloser) *wrapper.ErrorCode
+	Reconcile(userID *uuid.UUID) (model.SearchReconciliation, *wrapper.ErrorCode)
 }
 
 type searchService struct {
 	get          search.Get
 	meiliWebhook search.MeiliWebhook
+	reconcile    search.Reconcile
 }
 
-func NewSearchService(get search.Get, meiliWebhook search.MeiliWebhook) SearchService {
+func NewSearchService(
+	get search.Get,
+	meiliWebhook search.MeiliWebhook,
+	reconcile search.Reconcile,
+) SearchService {
 	return &searchService{
 		get:          get,
 		meiliWebhook: meiliWebhook,
+		reconcile:    reconcile,
 	}
 }
 
-func (s searchService) Get(request requests.SearchGetRequest, token string) (wrapper.WithTotalCount[any], *wrapper.ErrorCode) {
+func (s searchService) Get(request requests.SearchGetRequest, token string) (model.SearchResults[any], *wrapper.ErrorCode) {
 	return s.get.Handle(request, token)
 }
 
 func (s searchService) MeiliWebhook(requestBody io.ReadCloser) *wrapper.ErrorCode {
 	return s.meiliWebhook.Handle(requestBody)
 }
+
+func (s searchService) Reconcile(userID *uuid.UUID) (model.SearchReconciliation, *wrapper.ErrorCode) {
+	return s.reconcile.Handle(userID)
+}
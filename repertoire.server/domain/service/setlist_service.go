@@ -0,0 +1,115 @@
+package service
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/domain/usecase/setlist/entry"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type SetlistService interface {
+	Create(request requests.CreateSetlistRequest, token string) (uuid.UUID, *wrapper.ErrorCode)
+	CreateFromPlaylist(request requests.CreateSetlistFromPlaylistRequest, token string) (uuid.UUID, *wrapper.ErrorCode)
+	Delete(id uuid.UUID) *wrapper.ErrorCode
+	GetAll(request requests.GetSetlistsRequest, token string) (wrapper.WithTotalCount[model.Setlist], *wrapper.ErrorCode)
+	Get(id uuid.UUID) (model.Setlist, *wrapper.ErrorCode)
+	Update(request requests.UpdateSetlistRequest) *wrapper.ErrorCode
+
+	AddEntry(request requests.AddEntryToSetlistRequest) (uuid.UUID, *wrapper.ErrorCode)
+	MoveEntry(request requests.MoveEntryFromSetlistRequest) *wrapper.ErrorCode
+	RemoveEntries(request requests.RemoveEntriesFromSetlistRequest) *wrapper.ErrorCode
+	UpdateEntry(request requests.UpdateSetlistEntryRequest) *wrapper.ErrorCode
+}
+
+type setlistService struct {
+	createSetlist             setlist.CreateSetlist
+	createSetlistFromPlaylist setlist.CreateSetlistFromPlaylist
+	deleteSetlist             setlist.DeleteSetlist
+	getAllSetlists            setlist.GetAllSetlists
+	getSetlist                setlist.GetSetlist
+	updateSetlist             setlist.UpdateSetlist
+
+	addEntryToSetlist        entry.AddEntryToSetlist
+	moveEntryFromSetlist     entry.MoveEntryFromSetlist
+	removeEntriesFromSetlist entry.RemoveEntriesFromSetlist
+	updateSetlistEntry       entry.UpdateSetlistEntry
+}
+
+func NewSetlistService(
+	createSetlist setlist.CreateSetlist,
+	createSetlistFromPlaylist setlist.CreateSetlistFromPlaylist,
+	deleteSetlist setlist.DeleteSetlist,
+	getAllSetlists setlist.GetAllSetlists,
+	getSetlist setlist.GetSetlist,
+	updateSetlist setlist.UpdateSetlist,
+
+	addEntryToSetlist entry.AddEntryToSetlist,
+	moveEntryFromSetlist entry.MoveEntryFromSetlist,
+	removeEntriesFromSetlist entry.RemoveEntriesFromSetlist,
+	updateSetlistEntry entry.UpdateSetlistEntry,
+) SetlistService {
+	return &setlistService{
+		createSetlist:             createSetlist,
+		createSetlistFromPlaylist: createSetlistFromPlaylist,
+		deleteSetlist:             deleteSetlist,
+		getAllSetlists:            getAllSetlists,
+		getSetlist:                getSetlist,
+		updateSetlist:             updateSetlist,
+
+		addEntryToSetlist:        addEntryToSetlist,
+		moveEntryFromSetlist:     moveEntryFromSetlist,
+		removeEntriesFromSetlist: removeEntriesFromSetlist,
+		updateSetlistEntry:       updateSetlistEntry,
+	}
+}
+
+func (s *setlistService) Create(request requests.CreateSetlistRequest, token string) (uuid.UUID, *wrapper.ErrorCode) {
+	return s.createSetlist.Handle(request, token)
+}
+
+func (s *setlistService) CreateFromPlaylist(
+	request requests.CreateSetlistFromPlaylistRequest,
+	token string,
+) (uuid.UUID, *wrapper.ErrorCode) {
+	return s.createSetlistFromPlaylist.Handle(request, token)
+}
+
+func (s *setlistService) Delete(id uuid.UUID) *wrapper.ErrorCode {
+	return s.deleteSetlist.Handle(id)
+}
+
+func (s *setlistService) GetAll(
+	request requests.GetSetlistsRequest,
+	token string,
+) (wrapper.WithTotalCount[model.Setlist], *wrapper.ErrorCode) {
+	return s.getAllSetlists.Handle(request, token)
+}
+
+func (s *setlistService) Get(id uuid.UUID) (model.Setlist, *wrapper.ErrorCode) {
+	return s.getSetlist.Handle(id)
+}
+
+func (s *setlistService) Update(request requests.UpdateSetlistRequest) *wrapper.ErrorCode {
+	return s.updateSetlist.Handle(request)
+}
+
+// Entries
+
+func (s *setlistService) AddEntry(request requests.AddEntryToSetlistRequest) (uuid.UUID, *wrapper.ErrorCode) {
+	return s.addEntryToSetlist.Handle(request)
+}
+
+func (s *setlistService) MoveEntry(request requests.MoveEntryFromSetlistRequest) *wrapper.ErrorCode {
+	return s.moveEntryFromSetlist.Handle(request)
+}
+
+func (s *setlistService) RemoveEntries(request requests.RemoveEntriesFromSetlistRequest) *wrapper.ErrorCode {
+	return s.removeEntriesFromSetlist.Handle(request)
+}
+
+func (s *setlistService) UpdateEntry(request requests.UpdateSetlistEntryRequest) *wrapper.ErrorCode {
+	return s.updateSetlistEntry.Handle(request)
+}
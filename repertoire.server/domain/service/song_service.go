@@ -24,7 +24,12 @@ type SongService interface {
 		request requests.GetSongFiltersMetadataRequest,
 		token string,
 	) (model.SongFiltersMetadata, *wrapper.ErrorCode)
+	GetPracticeQueue(
+		request requests.GetSongsPracticeQueueRequest,
+		token string,
+	) ([]model.PracticeQueueSong, *wrapper.ErrorCode)
 	SaveImage(file *multipart.FileHeader, songID uuid.UUID) *wrapper.ErrorCode
+	UndoRehearsal(request requests.UndoSongRehearsalRequest) *wrapper.ErrorCode
 	Update(request requests.UpdateSongRequest) *wrapper.ErrorCode
 	UpdateSettings(request requests.UpdateSongSettingsRequest) *wrapper.ErrorCode
 
@@ -43,7 +48,9 @@ type songService struct {
 	getAllSongs              song.GetAllSongs
 	getSong                  song.GetSong
 	getSongFiltersMetadata   song.GetSongFiltersMetadata
+	getSongsPracticeQueue    song.GetSongsPracticeQueue
 	saveImageToSong          song.SaveImageToSong
+	undoSongRehearsal        song.UndoSongRehearsal
 	updateSong               song.UpdateSong
 	updateSongSettings       song.UpdateSongSettings
 
@@ -62,7 +69,9 @@ func NewSongService(
 	getAllSongs song.GetAllSongs,
 	getSong song.GetSong,
 	getSongFiltersMetadata song.GetSongFiltersMetadata,
+	getSongsPracticeQueue song.GetSongsPracticeQueue,
 	saveImageToSong song.SaveImageToSong,
+	undoSongRehearsal song.UndoSongRehearsal,
 	updateSong song.UpdateSong,
 	updateSongSettings song.UpdateSongSettings,
 
@@ -80,7 +89,9 @@ func NewSongService(
 		getAllSongs:              getAllSongs,
 		getSong:                  getSong,
 		getSongFiltersMetadata:   getSongFiltersMetadata,
+		getSongsPracticeQueue:    getSongsPracticeQueue,
 		saveImageToSong:          saveImageToSong,
+		undoSongRehearsal:        undoSongRehearsal,
 		updateSong:               updateSong,
 		updateSongSettings:       updateSongSettings,
 
@@ -132,10 +143,21 @@ func (s *songService) GetFiltersMetadata(
 	return s.getSongFiltersMetadata.Handle(request, token)
 }
 
+func (s *songService) GetPracticeQueue(
+	request requests.GetSongsPracticeQueueRequest,
+	token string,
+) ([]model.PracticeQueueSong, *wrapper.ErrorCode) {
+	return s.getSongsPracticeQueue.Handle(request, token)
+}
+
 func (s *songService) SaveImage(file *multipart.FileHeader, songID uuid.UUID) *wrapper.ErrorCode {
 	return s.saveImageToSong.Handle(file, songID)
 }
 
+func (s *songService) UndoRehearsal(request requests.UndoSongRehearsalRequest) *wrapper.ErrorCode {
+	return s.undoSongRehearsal.Handle(request)
+}
+
 func (s *songService) Update(request requests.UpdateSongRequest) *wrapper.ErrorCode {
 	return s.updateSong.Handle(request)
 }
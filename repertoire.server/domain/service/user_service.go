@@ -1,8 +1,10 @@
 package service
 
 import (
+	"io"
 	"mime/multipart"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/http/auth"
 	"repertoire/server/domain/usecase/user"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
@@ -13,36 +15,52 @@ import (
 type UserService interface {
 	Delete(token string) *wrapper.ErrorCode
 	DeleteProfilePicture(token string) *wrapper.ErrorCode
+	ExportData(token string, getWriter func() io.Writer) (bool, *wrapper.ErrorCode)
 	Get(id uuid.UUID) (user model.User, e *wrapper.ErrorCode)
+	GetStorageUsage(token string) (model.StorageUsage, *wrapper.ErrorCode)
+	ImportData(file *multipart.FileHeader, token string) *wrapper.ErrorCode
 	SaveProfilePicture(file *multipart.FileHeader, token string) *wrapper.ErrorCode
-	SignUp(request requests.SignUpRequest) (string, *wrapper.ErrorCode)
+	SignUp(request requests.SignUpRequest) (auth.SignInResponse, *wrapper.ErrorCode)
 	Update(request requests.UpdateUserRequest, token string) *wrapper.ErrorCode
+	UpdateScoringStrategy(request requests.UpdateUserScoringStrategyRequest, token string) *wrapper.ErrorCode
 }
 
 type userService struct {
 	deleteUser                   user.DeleteUser
 	deleteProfilePictureFromUser user.DeleteProfilePictureFromUser
+	exportUserData               user.ExportUserData
 	getUser                      user.GetUser
+	getUserStorageUsage          user.GetUserStorageUsage
+	importUserData               user.ImportUserData
 	saveProfilePictureToUser     user.SaveProfilePictureToUser
 	signUp                       user.SignUp
 	updateUser                   user.UpdateUser
+	updateUserScoringStrategy    user.UpdateUserScoringStrategy
 }
 
 func NewUserService(
 	deleteUser user.DeleteUser,
 	deleteProfilePictureFromUser user.DeleteProfilePictureFromUser,
+	exportUserData user.ExportUserData,
 	getUser user.GetUser,
+	getUserStorageUsage user.GetUserStorageUsage,
+	importUserData user.ImportUserData,
 	saveProfilePictureToUser user.SaveProfilePictureToUser,
 	signUp user.SignUp,
 	updateUser user.UpdateUser,
+	updateUserScoringStrategy user.UpdateUserScoringStrategy,
 ) UserService {
 	return &userService{
 		deleteUser:                   deleteUser,
 		deleteProfilePictureFromUser: deleteProfilePictureFromUser,
+		exportUserData:               exportUserData,
 		getUser:                      getUser,
+		getUserStorageUsage:          getUserStorageUsage,
+		importUserData:               importUserData,
 		saveProfilePictureToUser:     saveProfilePictureToUser,
 		signUp:                       signUp,
 		updateUser:                   updateUser,
+		updateUserScoringStrategy:    updateUserScoringStrategy,
 	}
 }
 
@@ -54,18 +72,37 @@ func (u *userService) DeleteProfilePicture(token string) *wrapper.ErrorCode {
 	return u.deleteProfilePictureFromUser.Handle(token)
 }
 
+func (u *userService) ExportData(token string, getWriter func() io.Writer) (bool, *wrapper.ErrorCode) {
+	return u.exportUserData.Handle(token, getWriter)
+}
+
 func (u *userService) Get(id uuid.UUID) (model.User, *wrapper.ErrorCode) {
 	return u.getUser.Handle(id)
 }
 
+func (u *userService) GetStorageUsage(token string) (model.StorageUsage, *wrapper.ErrorCode) {
+	return u.getUserStorageUsage.Handle(token)
+}
+
+func (u *userService) ImportData(file *multipart.FileHeader, token string) *wrapper.ErrorCode {
+	return u.importUserData.Handle(file, token)
+}
+
 func (u *userService) SaveProfilePicture(file *multipart.FileHeader, token string) *wrapper.ErrorCode {
 	return u.saveProfilePictureToUser.Handle(file, token)
 }
 
-func (u *userService) SignUp(request requests.SignUpRequest) (string, *wrapper.ErrorCode) {
+func (u *userService) SignUp(request requests.SignUpRequest) (auth.SignInResponse, *wrapper.ErrorCode) {
 	return u.signUp.Handle(request)
 }
 
 func (u *userService) Update(request requests.UpdateUserRequest, token string) *wrapper.ErrorCode {
 	return u.updateUser.Handle(request, token)
 }
+
+func (u *userService) UpdateScoringStrategy(
+	request requests.UpdateUserScoringStrategyRequest,
+	token string,
+) *wrapper.ErrorCode {
+	return u.updateUserScoringStrategy.Handle(request, token)
+}
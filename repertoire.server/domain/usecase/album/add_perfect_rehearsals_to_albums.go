@@ -8,23 +8,28 @@ import (
 	"repertoire/server/domain/processor"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+
+	"github.com/google/uuid"
 )
 
 type AddPerfectRehearsalsToAlbums struct {
-	repository         repository.AlbumRepository
-	songProcessor      processor.SongProcessor
-	transactionManager transaction.Manager
+	repository               repository.AlbumRepository
+	songProcessor            processor.SongProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
+	transactionManager       transaction.Manager
 }
 
 func NewAddPerfectRehearsalsToAlbums(
 	repository repository.AlbumRepository,
 	songProcessor processor.SongProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 	transactionManager transaction.Manager,
 ) AddPerfectRehearsalsToAlbums {
 	return AddPerfectRehearsalsToAlbums{
-		repository:         repository,
-		songProcessor:      songProcessor,
-		transactionManager: transactionManager,
+		repository:               repository,
+		songProcessor:            songProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
+		transactionManager:       transactionManager,
 	}
 }
 
@@ -44,15 +49,17 @@ func (a AddPerfectRehearsalsToAlbums) Handle(request requests.AddPerfectRehearsa
 		transactionSongRepository := factory.NewSongRepository()
 
 		var newSongs []model.Song
+		var batchIDs []uuid.UUID
 		for _, album := range albums {
 			for _, song := range album.Songs {
-				errC, isUpdated := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
+				errC, batchID := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
 				if errC != nil {
 					errCode = errC
 					return errCode.Error
 				}
-				if isUpdated {
+				if batchID != nil {
 					newSongs = append(newSongs, song)
+					batchIDs = append(batchIDs, *batchID)
 				}
 			}
 		}
@@ -63,6 +70,14 @@ func (a AddPerfectRehearsalsToAlbums) Handle(request requests.AddPerfectRehearsa
 				errCode = wrapper.InternalServerError(err)
 				return err
 			}
+
+			transactionPracticeSessionRepository := factory.NewPracticeSessionRepository()
+			for i, song := range newSongs {
+				errCode = a.practiceSessionProcessor.RecordPerfectRehearsal(song, batchIDs[i], transactionPracticeSessionRepository)
+				if errCode != nil {
+					return errCode.Error
+				}
+			}
 		}
 		return nil
 	})
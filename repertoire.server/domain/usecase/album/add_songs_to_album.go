@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -15,17 +16,20 @@ type AddSongsToAlbum struct {
 	repository              repository.AlbumRepository
 	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewAddSongsToAlbum(
 	repository repository.AlbumRepository,
 	songRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) AddSongsToAlbum {
 	return AddSongsToAlbum{
 		repository:              repository,
 		songRepository:          songRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -65,12 +69,17 @@ func (a AddSongsToAlbum) Handle(request requests.AddSongsToAlbumRequest) *wrappe
 		}
 	}
 
-	err = a.songRepository.UpdateAll(&songs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = a.messagePublisherService.Publish(topics.SongsUpdatedTopic, request.SongIDs)
+	err = a.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateAll(&songs)
+		if err != nil {
+			return err
+		}
+		return a.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			request.SongIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
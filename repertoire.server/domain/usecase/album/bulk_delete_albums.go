@@ -3,6 +3,7 @@ package album
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type BulkDeleteAlbums struct {
 	repository              repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewBulkDeleteAlbums(
 	repository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) BulkDeleteAlbums {
 	return BulkDeleteAlbums{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -40,16 +44,23 @@ func (b BulkDeleteAlbums) Handle(request requests.BulkDeleteAlbumsRequest) *wrap
 		return wrapper.NotFoundError(errors.New("albums not found"))
 	}
 
-	if request.WithSongs {
-		err = b.repository.DeleteWithSongs(request.IDs)
-	} else {
-		err = b.repository.Delete(request.IDs)
-	}
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = b.messagePublisherService.Publish(topics.AlbumsDeletedTopic, albums)
+	err = b.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		albumRepository := factory.NewAlbumRepository()
+		var err error
+		if request.WithSongs {
+			err = albumRepository.DeleteWithSongs(request.IDs)
+		} else {
+			err = albumRepository.Delete(request.IDs)
+		}
+		if err != nil {
+			return err
+		}
+		return b.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsDeletedTopic,
+			albums,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -2,7 +2,7 @@ package album
 
 import (
 	"repertoire/server/api/requests"
-	"repertoire/server/data/repository"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
@@ -13,19 +13,19 @@ import (
 
 type CreateAlbum struct {
 	jwtService              service.JwtService
-	repository              repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewCreateAlbum(
 	jwtService service.JwtService,
-	repository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) CreateAlbum {
 	return CreateAlbum{
 		jwtService:              jwtService,
-		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -43,12 +43,17 @@ func (c CreateAlbum) Handle(request requests.CreateAlbumRequest, token string) (
 		Artist:      c.createArtist(request, userID),
 		UserID:      userID,
 	}
-	err := c.repository.Create(&album)
-	if err != nil {
-		return uuid.Nil, wrapper.InternalServerError(err)
-	}
-
-	err = c.messagePublisherService.Publish(topics.AlbumCreatedTopic, album)
+	err := c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().Create(&album)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumCreatedTopic,
+			album,
+		)
+	})
 	if err != nil {
 		return uuid.Nil, wrapper.InternalServerError(err)
 	}
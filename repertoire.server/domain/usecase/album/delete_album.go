@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,15 +17,18 @@ import (
 type DeleteAlbum struct {
 	repository              repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteAlbum(
 	repository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteAlbum {
 	return DeleteAlbum{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -43,16 +47,23 @@ func (d DeleteAlbum) Handle(request requests.DeleteAlbumRequest) *wrapper.ErrorC
 		return wrapper.NotFoundError(errors.New("album not found"))
 	}
 
-	if request.WithSongs {
-		err = d.repository.DeleteWithSongs([]uuid.UUID{request.ID})
-	} else {
-		err = d.repository.Delete([]uuid.UUID{request.ID})
-	}
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.AlbumsDeletedTopic, []model.Album{album})
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		albumRepository := factory.NewAlbumRepository()
+		var err error
+		if request.WithSongs {
+			err = albumRepository.DeleteWithSongs([]uuid.UUID{request.ID})
+		} else {
+			err = albumRepository.Delete([]uuid.UUID{request.ID})
+		}
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsDeletedTopic,
+			[]model.Album{album},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package album
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,17 +17,20 @@ type DeleteImageFromAlbum struct {
 	repository              repository.AlbumRepository
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteImageFromAlbum(
 	repository repository.AlbumRepository,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteImageFromAlbum {
 	return DeleteImageFromAlbum{
 		repository:              repository,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,12 +53,17 @@ func (d DeleteImageFromAlbum) Handle(id uuid.UUID) *wrapper.ErrorCode {
 	}
 
 	album.ImageURL = nil
-	err = d.repository.Update(&album)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.AlbumsUpdatedTopic, []uuid.UUID{album.ID})
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().Update(&album)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsUpdatedTopic,
+			[]uuid.UUID{album.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
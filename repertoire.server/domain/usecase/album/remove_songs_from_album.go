@@ -71,16 +71,15 @@ func (r RemoveSongsFromAlbum) Handle(request requests.RemoveSongsFromAlbumReques
 		if err := albumRepo.UpdateWithAssociations(&album); err != nil {
 			return err
 		}
-		return nil
+		return r.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			request.SongIDs,
+		)
 	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = r.messagePublisherService.Publish(topics.SongsUpdatedTopic, request.SongIDs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
@@ -21,6 +22,7 @@ type SaveImageToAlbum struct {
 	storageFilePathProvider provider.StorageFilePathProvider
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewSaveImageToAlbum(
@@ -28,12 +30,14 @@ func NewSaveImageToAlbum(
 	storageFilePathProvider provider.StorageFilePathProvider,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) SaveImageToAlbum {
 	return SaveImageToAlbum{
 		repository:              repository,
 		storageFilePathProvider: storageFilePathProvider,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -55,20 +59,25 @@ func (s SaveImageToAlbum) Handle(file *multipart.FileHeader, id uuid.UUID) *wrap
 	}
 
 	album.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetAlbumImagePath(file, album)
+	imagePath := s.storageFilePathProvider.GetAlbumImagePath(album)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode := s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	album.ImageURL = (*internal.FilePath)(&imagePath)
-	err = s.repository.Update(&album)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = s.messagePublisherService.Publish(topics.AlbumsUpdatedTopic, []uuid.UUID{album.ID})
+	err = s.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().Update(&album)
+		if err != nil {
+			return err
+		}
+		return s.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsUpdatedTopic,
+			[]uuid.UUID{album.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -15,19 +16,19 @@ import (
 
 type UpdateAlbum struct {
 	repository              repository.AlbumRepository
-	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewUpdateAlbum(
 	repository repository.AlbumRepository,
-	songRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) UpdateAlbum {
 	return UpdateAlbum{
 		repository:              repository,
-		songRepository:          songRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,19 +50,25 @@ func (u UpdateAlbum) Handle(request requests.UpdateAlbumRequest) *wrapper.ErrorC
 	album.ReleaseDate = request.ReleaseDate
 	album.ArtistID = request.ArtistID
 
-	err = u.repository.Update(&album)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().Update(&album)
+		if err != nil {
+			return err
+		}
 
-	if artistHasChanged {
-		errCode := u.updateAlbumSongsArtist(request)
-		if errCode != nil {
-			return errCode
+		if artistHasChanged {
+			err = u.updateAlbumSongsArtist(factory.NewSongRepository(), request)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	err = u.messagePublisherService.Publish(topics.AlbumsUpdatedTopic, []uuid.UUID{album.ID})
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsUpdatedTopic,
+			[]uuid.UUID{album.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -69,20 +76,18 @@ func (u UpdateAlbum) Handle(request requests.UpdateAlbumRequest) *wrapper.ErrorC
 	return nil
 }
 
-func (u UpdateAlbum) updateAlbumSongsArtist(request requests.UpdateAlbumRequest) *wrapper.ErrorCode {
+func (u UpdateAlbum) updateAlbumSongsArtist(
+	songRepository repository.SongRepository,
+	request requests.UpdateAlbumRequest,
+) error {
 	var songs []model.Song
-	err := u.songRepository.GetAllByAlbum(&songs, request.ID)
+	err := songRepository.GetAllByAlbum(&songs, request.ID)
 	if err != nil {
-		return wrapper.InternalServerError(err)
+		return err
 	}
 
 	for i := range songs {
 		songs[i].ArtistID = request.ArtistID
 	}
-	err = u.songRepository.UpdateAll(&songs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	return nil
+	return songRepository.UpdateAll(&songs)
 }
@@ -3,6 +3,7 @@ package artist
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type AddAlbumsToArtist struct {
 	albumRepository         repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewAddAlbumsToArtist(
 	albumRepository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) AddAlbumsToArtist {
 	return AddAlbumsToArtist{
 		albumRepository:         albumRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -44,12 +48,17 @@ func (a AddAlbumsToArtist) Handle(request requests.AddAlbumsToArtistRequest) *wr
 		}
 	}
 
-	err = a.albumRepository.UpdateAllWithSongs(&albums)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = a.messagePublisherService.Publish(topics.AlbumsUpdatedTopic, request.AlbumIDs)
+	err = a.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().UpdateAllWithSongs(&albums)
+		if err != nil {
+			return err
+		}
+		return a.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsUpdatedTopic,
+			request.AlbumIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
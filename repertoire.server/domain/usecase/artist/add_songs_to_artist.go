@@ -3,6 +3,7 @@ package artist
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type AddSongsToArtist struct {
 	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewAddSongsToArtist(
 	songRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) AddSongsToArtist {
 	return AddSongsToArtist{
 		songRepository:          songRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -48,12 +52,17 @@ func (a AddSongsToArtist) Handle(request requests.AddSongsToArtistRequest) *wrap
 		}
 	}
 
-	err = a.songRepository.UpdateAllWithAssociations(&songs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = a.messagePublisherService.Publish(topics.SongsUpdatedTopic, request.SongIDs)
+	err = a.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateAllWithAssociations(&songs)
+		if err != nil {
+			return err
+		}
+		return a.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			request.SongIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,7 +4,10 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
@@ -12,12 +15,20 @@ import (
 )
 
 type CreateBandMember struct {
-	artistRepository repository.ArtistRepository
+	artistRepository        repository.ArtistRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
-func NewCreateBandMember(repository repository.ArtistRepository) CreateBandMember {
+func NewCreateBandMember(
+	repository repository.ArtistRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) CreateBandMember {
 	return CreateBandMember{
-		artistRepository: repository,
+		artistRepository:        repository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -48,7 +59,17 @@ func (c CreateBandMember) Handle(request requests.CreateBandMemberRequest) (uuid
 		ArtistID: request.ArtistID,
 		Roles:    roles,
 	}
-	err = c.artistRepository.CreateBandMember(&member)
+	err = c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().CreateBandMember(&member)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			artist.ID,
+		)
+	})
 	if err != nil {
 		return uuid.Nil, wrapper.InternalServerError(err)
 	}
@@ -3,7 +3,10 @@ package member
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -12,12 +15,20 @@ import (
 )
 
 type DeleteBandMember struct {
-	artistRepository repository.ArtistRepository
+	artistRepository        repository.ArtistRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
-func NewDeleteBandMember(repository repository.ArtistRepository) DeleteBandMember {
+func NewDeleteBandMember(
+	repository repository.ArtistRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) DeleteBandMember {
 	return DeleteBandMember{
-		artistRepository: repository,
+		artistRepository:        repository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -43,11 +54,24 @@ func (d DeleteBandMember) Handle(id uuid.UUID, songID uuid.UUID) *wrapper.ErrorC
 		artist.BandMembers[i].Order = artist.BandMembers[i].Order - 1
 	}
 
-	err = d.artistRepository.UpdateWithAssociations(&artist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-	err = d.artistRepository.DeleteBandMember(id)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		artistRepository := factory.NewArtistRepository()
+
+		err := artistRepository.UpdateWithAssociations(&artist)
+		if err != nil {
+			return err
+		}
+		err = artistRepository.DeleteBandMember(id)
+		if err != nil {
+			return err
+		}
+
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			artist.ID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
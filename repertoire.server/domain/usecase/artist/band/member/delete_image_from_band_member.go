@@ -3,8 +3,10 @@ package member
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
@@ -12,17 +14,23 @@ import (
 )
 
 type DeleteImageFromBandMember struct {
-	repository     repository.ArtistRepository
-	storageService service.StorageService
+	repository              repository.ArtistRepository
+	storageService          service.StorageService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteImageFromBandMember(
 	repository repository.ArtistRepository,
 	storageService service.StorageService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteImageFromBandMember {
 	return DeleteImageFromBandMember{
-		repository:     repository,
-		storageService: storageService,
+		repository:              repository,
+		storageService:          storageService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -45,7 +53,17 @@ func (d DeleteImageFromBandMember) Handle(id uuid.UUID) *wrapper.ErrorCode {
 	}
 
 	member.ImageURL = nil
-	err = d.repository.UpdateBandMember(&member)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().UpdateBandMember(&member)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			member.ArtistID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
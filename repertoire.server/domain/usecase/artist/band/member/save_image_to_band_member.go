@@ -4,10 +4,12 @@ import (
 	"errors"
 	"mime/multipart"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
 	"repertoire/server/internal"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"time"
@@ -19,17 +21,23 @@ type SaveImageToBandMember struct {
 	repository              repository.ArtistRepository
 	storageFilePathProvider provider.StorageFilePathProvider
 	storageService          service.StorageService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewSaveImageToBandMember(
 	repository repository.ArtistRepository,
 	storageFilePathProvider provider.StorageFilePathProvider,
 	storageService service.StorageService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) SaveImageToBandMember {
 	return SaveImageToBandMember{
 		repository:              repository,
 		storageFilePathProvider: storageFilePathProvider,
 		storageService:          storageService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -51,15 +59,25 @@ func (s SaveImageToBandMember) Handle(file *multipart.FileHeader, id uuid.UUID)
 	}
 
 	member.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetBandMemberImagePath(file, member)
+	imagePath := s.storageFilePathProvider.GetBandMemberImagePath(member)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode := s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	member.ImageURL = (*internal.FilePath)(&imagePath)
-	err = s.repository.UpdateBandMember(&member)
+	err = s.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().UpdateBandMember(&member)
+		if err != nil {
+			return err
+		}
+		return s.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			member.ArtistID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,18 +4,29 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 )
 
 type UpdateBandMember struct {
-	artistRepository repository.ArtistRepository
+	artistRepository        repository.ArtistRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
-func NewUpdateBandMember(repository repository.ArtistRepository) UpdateBandMember {
+func NewUpdateBandMember(
+	repository repository.ArtistRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) UpdateBandMember {
 	return UpdateBandMember{
-		artistRepository: repository,
+		artistRepository:        repository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -35,14 +46,27 @@ func (u UpdateBandMember) Handle(request requests.UpdateBandMemberRequest) *wrap
 		return wrapper.InternalServerError(err)
 	}
 
-	err = u.artistRepository.ReplaceRolesFromBandMember(roles, &bandMember)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		artistRepository := factory.NewArtistRepository()
+
+		err := artistRepository.ReplaceRolesFromBandMember(roles, &bandMember)
+		if err != nil {
+			return err
+		}
+
+		bandMember.Name = request.Name
+		bandMember.Color = request.Color
+		err = artistRepository.UpdateBandMember(&bandMember)
+		if err != nil {
+			return err
+		}
 
-	bandMember.Name = request.Name
-	bandMember.Color = request.Color
-	err = u.artistRepository.UpdateBandMember(&bandMember)
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			bandMember.ArtistID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
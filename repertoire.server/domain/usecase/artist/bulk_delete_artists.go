@@ -59,16 +59,15 @@ func (b BulkDeleteArtists) Handle(request requests.BulkDeleteArtistsRequest) *wr
 		if err != nil {
 			return err
 		}
-		return nil
+		return b.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistsDeletedTopic,
+			artists,
+		)
 	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = b.messagePublisherService.Publish(topics.ArtistsDeletedTopic, artists)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	return nil
 }
@@ -2,7 +2,7 @@ package artist
 
 import (
 	"repertoire/server/api/requests"
-	"repertoire/server/data/repository"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
@@ -13,19 +13,19 @@ import (
 
 type CreateArtist struct {
 	jwtService              service.JwtService
-	repository              repository.ArtistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewCreateArtist(
 	jwtService service.JwtService,
-	repository repository.ArtistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) CreateArtist {
 	return CreateArtist{
 		jwtService:              jwtService,
-		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -41,12 +41,17 @@ func (c CreateArtist) Handle(request requests.CreateArtistRequest, token string)
 		IsBand: request.IsBand,
 		UserID: userID,
 	}
-	err := c.repository.Create(&artist)
-	if err != nil {
-		return uuid.Nil, wrapper.InternalServerError(err)
-	}
-
-	err = c.messagePublisherService.Publish(topics.ArtistCreatedTopic, artist)
+	err := c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().Create(&artist)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistCreatedTopic,
+			artist,
+		)
+	})
 	if err != nil {
 		return uuid.Nil, wrapper.InternalServerError(err)
 	}
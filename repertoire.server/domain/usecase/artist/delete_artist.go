@@ -62,16 +62,15 @@ func (d DeleteArtist) Handle(request requests.DeleteArtistRequest) *wrapper.Erro
 		if err != nil {
 			return err
 		}
-		return nil
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistsDeletedTopic,
+			artist,
+		)
 	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = d.messagePublisherService.Publish(topics.ArtistsDeletedTopic, artist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	return nil
 }
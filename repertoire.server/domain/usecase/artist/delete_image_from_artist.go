@@ -3,6 +3,7 @@ package artist
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,17 +17,20 @@ type DeleteImageFromArtist struct {
 	repository              repository.ArtistRepository
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteImageFromArtist(
 	repository repository.ArtistRepository,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteImageFromArtist {
 	return DeleteImageFromArtist{
 		repository:              repository,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,12 +53,17 @@ func (d DeleteImageFromArtist) Handle(id uuid.UUID) *wrapper.ErrorCode {
 	}
 
 	artist.ImageURL = nil
-	err = d.repository.Update(&artist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.ArtistUpdatedTopic, artist.ID)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().Update(&artist)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			artist.ID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package artist
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type RemoveAlbumsFromArtist struct {
 	albumRepository         repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewRemoveAlbumsFromArtist(
 	albumRepository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) RemoveAlbumsFromArtist {
 	return RemoveAlbumsFromArtist{
 		albumRepository:         albumRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -43,12 +47,17 @@ func (r RemoveAlbumsFromArtist) Handle(request requests.RemoveAlbumsFromArtistRe
 		}
 	}
 
-	err = r.albumRepository.UpdateAllWithSongs(&albums)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = r.messagePublisherService.Publish(topics.AlbumsUpdatedTopic, request.AlbumIDs)
+	err = r.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewAlbumRepository().UpdateAllWithSongs(&albums)
+		if err != nil {
+			return err
+		}
+		return r.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.AlbumsUpdatedTopic,
+			request.AlbumIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package artist
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type RemoveSongsFromArtist struct {
 	songRepository          repository.SongRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewRemoveSongsFromArtist(
 	songRepository repository.SongRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) RemoveSongsFromArtist {
 	return RemoveSongsFromArtist{
 		songRepository:          songRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -40,12 +44,17 @@ func (r RemoveSongsFromArtist) Handle(request requests.RemoveSongsFromArtistRequ
 		songs[i].ArtistID = nil
 	}
 
-	err = r.songRepository.UpdateAll(&songs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = r.messagePublisherService.Publish(topics.SongsUpdatedTopic, request.SongIDs)
+	err = r.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateAll(&songs)
+		if err != nil {
+			return err
+		}
+		return r.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			request.SongIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
@@ -21,6 +22,7 @@ type SaveImageToArtist struct {
 	storageFilePathProvider provider.StorageFilePathProvider
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewSaveImageToArtist(
@@ -28,12 +30,14 @@ func NewSaveImageToArtist(
 	storageFilePathProvider provider.StorageFilePathProvider,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) SaveImageToArtist {
 	return SaveImageToArtist{
 		repository:              repository,
 		storageFilePathProvider: storageFilePathProvider,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -55,20 +59,25 @@ func (s SaveImageToArtist) Handle(file *multipart.FileHeader, id uuid.UUID) *wra
 	}
 
 	artist.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetArtistImagePath(file, artist)
+	imagePath := s.storageFilePathProvider.GetArtistImagePath(artist)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode := s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	artist.ImageURL = (*internal.FilePath)(&imagePath)
-	err = s.repository.Update(&artist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = s.messagePublisherService.Publish(topics.ArtistUpdatedTopic, artist.ID)
+	err = s.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().Update(&artist)
+		if err != nil {
+			return err
+		}
+		return s.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			artist.ID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -14,15 +15,18 @@ import (
 type UpdateArtist struct {
 	repository              repository.ArtistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewUpdateArtist(
 	repository repository.ArtistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) UpdateArtist {
 	return UpdateArtist{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -39,12 +43,17 @@ func (u UpdateArtist) Handle(request requests.UpdateArtistRequest) *wrapper.Erro
 	artist.Name = request.Name
 	artist.IsBand = request.IsBand
 
-	err = u.repository.Update(&artist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = u.messagePublisherService.Publish(topics.ArtistUpdatedTopic, artist.ID)
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewArtistRepository().Update(&artist)
+		if err != nil {
+			return err
+		}
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.ArtistUpdatedTopic,
+			artist.ID,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -0,0 +1,38 @@
+package deadletter
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type DiscardDeadLetterMessage struct {
+	repository repository.DeadLetterRepository
+}
+
+func NewDiscardDeadLetterMessage(repository repository.DeadLetterRepository) DiscardDeadLetterMessage {
+	return DiscardDeadLetterMessage{
+		repository: repository,
+	}
+}
+
+func (d DiscardDeadLetterMessage) Handle(id uuid.UUID) *wrapper.ErrorCode {
+	var deadLetterMessage model.DeadLetterMessage
+	err := d.repository.Get(&deadLetterMessage, id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(deadLetterMessage).IsZero() {
+		return wrapper.NotFoundError(errors.New("dead letter message not found"))
+	}
+
+	err = d.repository.Delete(id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package deadletter
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type GetAllDeadLetterMessages struct {
+	repository repository.DeadLetterRepository
+}
+
+func NewGetAllDeadLetterMessages(repository repository.DeadLetterRepository) GetAllDeadLetterMessages {
+	return GetAllDeadLetterMessages{
+		repository: repository,
+	}
+}
+
+func (g GetAllDeadLetterMessages) Handle(
+	request requests.GetDeadLetterMessagesRequest,
+) (result wrapper.WithTotalCount[model.DeadLetterMessage], e *wrapper.ErrorCode) {
+	err := g.repository.GetAll(&result.Models, request.CurrentPage, request.PageSize, request.Topic)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	err = g.repository.GetAllCount(&result.TotalCount, request.Topic)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package deadletter
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type GetDeadLetterMessage struct {
+	repository repository.DeadLetterRepository
+}
+
+func NewGetDeadLetterMessage(repository repository.DeadLetterRepository) GetDeadLetterMessage {
+	return GetDeadLetterMessage{
+		repository: repository,
+	}
+}
+
+func (g GetDeadLetterMessage) Handle(id uuid.UUID) (deadLetterMessage model.DeadLetterMessage, e *wrapper.ErrorCode) {
+	err := g.repository.Get(&deadLetterMessage, id)
+	if err != nil {
+		return deadLetterMessage, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(deadLetterMessage).IsZero() {
+		return deadLetterMessage, wrapper.NotFoundError(errors.New("dead letter message not found"))
+	}
+	return deadLetterMessage, nil
+}
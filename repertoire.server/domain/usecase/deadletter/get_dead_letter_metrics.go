@@ -0,0 +1,27 @@
+package deadletter
+
+import (
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type GetDeadLetterMetrics struct {
+	repository repository.DeadLetterRepository
+}
+
+func NewGetDeadLetterMetrics(repository repository.DeadLetterRepository) GetDeadLetterMetrics {
+	return GetDeadLetterMetrics{
+		repository: repository,
+	}
+}
+
+// Handle returns how many messages are stuck in the dead letters for every topic
+func (g GetDeadLetterMetrics) Handle() ([]model.DeadLetterTopicMetrics, *wrapper.ErrorCode) {
+	metrics := make([]model.DeadLetterTopicMetrics, 0)
+	err := g.repository.GetMetricsByTopic(&metrics)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	return metrics, nil
+}
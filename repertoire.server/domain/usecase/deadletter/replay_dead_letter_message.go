@@ -0,0 +1,51 @@
+package deadletter
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type ReplayDeadLetterMessage struct {
+	repository              repository.DeadLetterRepository
+	messagePublisherService service.MessagePublisherService
+}
+
+func NewReplayDeadLetterMessage(
+	repository repository.DeadLetterRepository,
+	messagePublisherService service.MessagePublisherService,
+) ReplayDeadLetterMessage {
+	return ReplayDeadLetterMessage{
+		repository:              repository,
+		messagePublisherService: messagePublisherService,
+	}
+}
+
+// Handle publishes the message again and removes it from the dead letters
+// (if it fails once more, it gets back in the dead letters as a new entry)
+func (r ReplayDeadLetterMessage) Handle(id uuid.UUID) *wrapper.ErrorCode {
+	var deadLetterMessage model.DeadLetterMessage
+	err := r.repository.Get(&deadLetterMessage, id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(deadLetterMessage).IsZero() {
+		return wrapper.NotFoundError(errors.New("dead letter message not found"))
+	}
+
+	err = r.messagePublisherService.Replay(deadLetterMessage)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	err = r.repository.Delete(id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -4,11 +4,17 @@ import (
 	"repertoire/server/domain/usecase/album"
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/domain/usecase/artist/band/member"
+	"repertoire/server/domain/usecase/deadletter"
 	"repertoire/server/domain/usecase/playlist"
 	playlistSong "repertoire/server/domain/usecase/playlist/song"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/domain/usecase/progress"
 	"repertoire/server/domain/usecase/search"
+	"repertoire/server/domain/usecase/setlist"
+	setlistEntry "repertoire/server/domain/usecase/setlist/entry"
 	"repertoire/server/domain/usecase/song"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/domain/usecase/storage"
 	"repertoire/server/domain/usecase/udata/band/member/role"
 	"repertoire/server/domain/usecase/udata/guitar/tuning"
 	"repertoire/server/domain/usecase/udata/instrument"
@@ -60,6 +66,14 @@ var artistUseCases = fx.Options(
 	fx.Provide(member.NewGetBandMemberRoles),
 )
 
+var deadLetterUseCases = fx.Options(
+	fx.Provide(deadletter.NewDiscardDeadLetterMessage),
+	fx.Provide(deadletter.NewGetAllDeadLetterMessages),
+	fx.Provide(deadletter.NewGetDeadLetterMessage),
+	fx.Provide(deadletter.NewGetDeadLetterMetrics),
+	fx.Provide(deadletter.NewReplayDeadLetterMessage),
+)
+
 var playlistUseCases = fx.Options(
 	fx.Provide(playlist.NewAddAlbumsToPlaylist),
 	fx.Provide(playlist.NewAddArtistsToPlaylist),
@@ -68,11 +82,13 @@ var playlistUseCases = fx.Options(
 	fx.Provide(playlist.NewCreatePlaylist),
 	fx.Provide(playlist.NewDeletePlaylist),
 	fx.Provide(playlist.NewDeleteImageFromPlaylist),
+	fx.Provide(playlist.NewFreezePlaylist),
 	fx.Provide(playlist.NewGetAllPlaylists),
 	fx.Provide(playlist.NewGetPlaylist),
 	fx.Provide(playlist.NewGetPlaylistFiltersMetadata),
 	fx.Provide(playlist.NewSaveImageToPlaylist),
 	fx.Provide(playlist.NewUpdatePlaylist),
+	fx.Provide(playlist.NewUpdatePlaylistSmartRules),
 
 	fx.Provide(playlistSong.NewAddSongsToPlaylist),
 	fx.Provide(playlistSong.NewGetPlaylistSongs),
@@ -81,9 +97,37 @@ var playlistUseCases = fx.Options(
 	fx.Provide(playlistSong.NewShufflePlaylistSongs),
 )
 
+var practiceSessionUseCases = fx.Options(
+	fx.Provide(practice.NewAddSongToPracticeSession),
+	fx.Provide(practice.NewDeletePracticeSession),
+	fx.Provide(practice.NewFinishPracticeSession),
+	fx.Provide(practice.NewGetAllPracticeSessions),
+	fx.Provide(practice.NewGetPracticeSession),
+	fx.Provide(practice.NewStartPracticeSession),
+)
+
+var progressUseCases = fx.Options(
+	fx.Provide(progress.NewGetProgressTimeline),
+)
+
 var searchUseCases = fx.Options(
 	fx.Provide(search.NewGet),
 	fx.Provide(search.NewMeiliWebhook),
+	fx.Provide(search.NewReconcile),
+)
+
+var setlistUseCases = fx.Options(
+	fx.Provide(setlist.NewCreateSetlist),
+	fx.Provide(setlist.NewCreateSetlistFromPlaylist),
+	fx.Provide(setlist.NewDeleteSetlist),
+	fx.Provide(setlist.NewGetAllSetlists),
+	fx.Provide(setlist.NewGetSetlist),
+	fx.Provide(setlist.NewUpdateSetlist),
+
+	fx.Provide(setlistEntry.NewAddEntryToSetlist),
+	fx.Provide(setlistEntry.NewMoveEntryFromSetlist),
+	fx.Provide(setlistEntry.NewRemoveEntriesFromSetlist),
+	fx.Provide(setlistEntry.NewUpdateSetlistEntry),
 )
 
 var songUseCases = fx.Options(
@@ -97,7 +141,9 @@ var songUseCases = fx.Options(
 	fx.Provide(song.NewGetAllSongs),
 	fx.Provide(song.NewGetSong),
 	fx.Provide(song.NewGetSongFiltersMetadata),
+	fx.Provide(song.NewGetSongsPracticeQueue),
 	fx.Provide(song.NewSaveImageToSong),
+	fx.Provide(song.NewUndoSongRehearsal),
 	fx.Provide(song.NewUpdateSong),
 	fx.Provide(song.NewUpdateSongSettings),
 
@@ -117,6 +163,10 @@ var songUseCases = fx.Options(
 	fx.Provide(section.NewUpdateSongSectionsPartialOccurrences),
 )
 
+var storageUseCases = fx.Options(
+	fx.Provide(storage.NewCollectOrphanedFiles),
+)
+
 var userDataUseCases = fx.Options(
 	fx.Provide(role.NewCreateBandMemberRole),
 	fx.Provide(role.NewDeleteBandMemberRole),
@@ -138,18 +188,28 @@ var userDataUseCases = fx.Options(
 var userUseCases = fx.Options(
 	fx.Provide(user.NewDeleteUser),
 	fx.Provide(user.NewDeleteProfilePictureFromUser),
+	fx.Provide(user.NewExportUserData),
 	fx.Provide(user.NewGetUser),
+	fx.Provide(user.NewGetUserStorageUsage),
+	fx.Provide(user.NewImportUserData),
 	fx.Provide(user.NewSaveProfilePictureToUser),
 	fx.Provide(user.NewSignUp),
 	fx.Provide(user.NewUpdateUser),
+	fx.Provide(user.NewUpdateUserScoringStrategy),
+	fx.Provide(user.NewUpgradeScoringStrategies),
 )
 
 var Module = fx.Options(
 	albumUseCases,
 	artistUseCases,
+	deadLetterUseCases,
 	playlistUseCases,
+	practiceSessionUseCases,
+	progressUseCases,
 	searchUseCases,
+	setlistUseCases,
 	songUseCases,
+	storageUseCases,
 	userDataUseCases,
 	userUseCases,
 )
@@ -1,6 +1,7 @@
 package playlist
 
 import (
+	"errors"
 	"repertoire/server/api/requests"
 	"repertoire/server/api/responses"
 	"repertoire/server/data/repository"
@@ -29,8 +30,18 @@ func NewAddAlbumsToPlaylist(
 func (a AddAlbumsToPlaylist) Handle(
 	request requests.AddAlbumsToPlaylistRequest,
 ) (*responses.AddAlbumsToPlaylistResponse, *wrapper.ErrorCode) {
+	var playlist model.Playlist
+	err := a.repository.Get(&playlist, request.ID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	// the songs of the smart playlists are resolved from their rules
+	if playlist.SmartRules != nil {
+		return nil, wrapper.BadRequestError(errors.New("songs cannot be added to a smart playlist"))
+	}
+
 	var playlistSongs []model.PlaylistSong
-	err := a.repository.GetPlaylistSongs(&playlistSongs, request.ID)
+	err = a.repository.GetPlaylistSongs(&playlistSongs, request.ID)
 	if err != nil {
 		return nil, wrapper.InternalServerError(err)
 	}
@@ -8,23 +8,34 @@ import (
 	"repertoire/server/domain/processor"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+
+	"github.com/google/uuid"
 )
 
 type AddPerfectRehearsalsToPlaylists struct {
-	repository         repository.PlaylistRepository
-	songProcessor      processor.SongProcessor
-	transactionManager transaction.Manager
+	repository               repository.PlaylistRepository
+	songRepository           repository.SongRepository
+	playlistProcessor        processor.PlaylistProcessor
+	songProcessor            processor.SongProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
+	transactionManager       transaction.Manager
 }
 
 func NewAddPerfectRehearsalsToPlaylists(
 	repository repository.PlaylistRepository,
+	songRepository repository.SongRepository,
+	playlistProcessor processor.PlaylistProcessor,
 	songProcessor processor.SongProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 	transactionManager transaction.Manager,
 ) AddPerfectRehearsalsToPlaylists {
 	return AddPerfectRehearsalsToPlaylists{
-		repository:         repository,
-		songProcessor:      songProcessor,
-		transactionManager: transactionManager,
+		repository:               repository,
+		songRepository:           songRepository,
+		playlistProcessor:        playlistProcessor,
+		songProcessor:            songProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
+		transactionManager:       transactionManager,
 	}
 }
 
@@ -38,22 +49,26 @@ func (a AddPerfectRehearsalsToPlaylists) Handle(request requests.AddPerfectRehea
 		return wrapper.NotFoundError(errors.New("playlists not found"))
 	}
 
-	var errCode *wrapper.ErrorCode
+	songs, errCode := a.getSongs(playlists)
+	if errCode != nil {
+		return errCode
+	}
+
 	err = a.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
 		transactionSongSectionRepository := factory.NewSongSectionRepository()
 		transactionSongRepository := factory.NewSongRepository()
 
 		var newSongs []model.Song
-		for _, playlist := range playlists {
-			for _, playlistSong := range playlist.PlaylistSongs {
-				errC, isUpdated := a.songProcessor.AddPerfectRehearsal(&playlistSong.Song, transactionSongSectionRepository)
-				if errC != nil {
-					errCode = errC
-					return errCode.Error
-				}
-				if isUpdated {
-					newSongs = append(newSongs, playlistSong.Song)
-				}
+		var batchIDs []uuid.UUID
+		for _, song := range songs {
+			errC, batchID := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
+			if errC != nil {
+				errCode = errC
+				return errCode.Error
+			}
+			if batchID != nil {
+				newSongs = append(newSongs, song)
+				batchIDs = append(batchIDs, *batchID)
 			}
 		}
 
@@ -63,6 +78,14 @@ func (a AddPerfectRehearsalsToPlaylists) Handle(request requests.AddPerfectRehea
 				errCode = wrapper.InternalServerError(err)
 				return err
 			}
+
+			transactionPracticeSessionRepository := factory.NewPracticeSessionRepository()
+			for i, song := range newSongs {
+				errCode = a.practiceSessionProcessor.RecordPerfectRehearsal(song, batchIDs[i], transactionPracticeSessionRepository)
+				if errCode != nil {
+					return errCode.Error
+				}
+			}
 		}
 		return nil
 	})
@@ -75,3 +98,32 @@ func (a AddPerfectRehearsalsToPlaylists) Handle(request requests.AddPerfectRehea
 
 	return nil
 }
+
+// getSongs gathers the songs of all the playlists, resolving them from the rules for the smart playlists
+func (a AddPerfectRehearsalsToPlaylists) getSongs(playlists []model.Playlist) ([]model.Song, *wrapper.ErrorCode) {
+	var songs []model.Song
+	for _, playlist := range playlists {
+		if playlist.SmartRules == nil {
+			for _, playlistSong := range playlist.PlaylistSongs {
+				songs = append(songs, playlistSong.Song)
+			}
+			continue
+		}
+
+		songIDs, errCode := a.playlistProcessor.GetSmartPlaylistSongIDs(playlist)
+		if errCode != nil {
+			return nil, errCode
+		}
+		if len(songIDs) == 0 {
+			continue
+		}
+
+		var smartSongs []model.Song
+		err := a.songRepository.GetAllByIDsWithSections(&smartSongs, songIDs)
+		if err != nil {
+			return nil, wrapper.InternalServerError(err)
+		}
+		songs = append(songs, smartSongs...)
+	}
+	return songs, nil
+}
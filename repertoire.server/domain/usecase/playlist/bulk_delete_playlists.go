@@ -3,6 +3,7 @@ package playlist
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -13,15 +14,18 @@ import (
 type BulkDeletePlaylists struct {
 	repository              repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewBulkDeletePlaylists(
 	repository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) BulkDeletePlaylists {
 	return BulkDeletePlaylists{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -35,12 +39,17 @@ func (b BulkDeletePlaylists) Handle(request requests.BulkDeletePlaylistsRequest)
 		return wrapper.NotFoundError(errors.New("playlists not found"))
 	}
 
-	err = b.repository.Delete(request.IDs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = b.messagePublisherService.Publish(topics.PlaylistsDeletedTopic, playlists)
+	err = b.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Delete(request.IDs)
+		if err != nil {
+			return err
+		}
+		return b.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistsDeletedTopic,
+			playlists,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
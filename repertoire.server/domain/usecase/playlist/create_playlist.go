@@ -2,7 +2,7 @@ package playlist
 
 import (
 	"repertoire/server/api/requests"
-	"repertoire/server/data/repository"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
@@ -13,19 +13,19 @@ import (
 
 type CreatePlaylist struct {
 	jwtService              service.JwtService
-	repository              repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewCreatePlaylist(
 	jwtService service.JwtService,
-	repository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) CreatePlaylist {
 	return CreatePlaylist{
 		jwtService:              jwtService,
-		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -39,14 +39,20 @@ func (c CreatePlaylist) Handle(request requests.CreatePlaylistRequest, token str
 		ID:          uuid.New(),
 		Title:       request.Title,
 		Description: request.Description,
+		SmartRules:  toSmartRules(request.SmartRules),
 		UserID:      userID,
 	}
-	err := c.repository.Create(&playlist)
-	if err != nil {
-		return uuid.Nil, wrapper.InternalServerError(err)
-	}
-
-	err = c.messagePublisherService.Publish(topics.PlaylistCreatedTopic, playlist)
+	err := c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Create(&playlist)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistCreatedTopic,
+			playlist,
+		)
+	})
 	if err != nil {
 		return uuid.Nil, wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package playlist
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,18 +17,20 @@ type DeleteImageFromPlaylist struct {
 	repository              repository.PlaylistRepository
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteImageFromPlaylist(
 	repository repository.PlaylistRepository,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
-
+	transactionManager transaction.Manager,
 ) DeleteImageFromPlaylist {
 	return DeleteImageFromPlaylist{
 		repository:              repository,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -50,12 +53,17 @@ func (d DeleteImageFromPlaylist) Handle(id uuid.UUID) *wrapper.ErrorCode {
 	}
 
 	playlist.ImageURL = nil
-	err = d.repository.Update(&playlist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.PlaylistUpdatedTopic, playlist)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Update(&playlist)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistUpdatedTopic,
+			playlist,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package playlist
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -15,15 +16,18 @@ import (
 type DeletePlaylist struct {
 	repository              repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeletePlaylist(
 	repository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeletePlaylist {
 	return DeletePlaylist{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -37,12 +41,17 @@ func (d DeletePlaylist) Handle(id uuid.UUID) *wrapper.ErrorCode {
 		return wrapper.NotFoundError(errors.New("playlist not found"))
 	}
 
-	err = d.repository.Delete([]uuid.UUID{id})
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.PlaylistsDeletedTopic, []model.Playlist{playlist})
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Delete([]uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistsDeletedTopic,
+			[]model.Playlist{playlist},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -0,0 +1,89 @@
+package playlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/repository"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type FreezePlaylist struct {
+	repository         repository.PlaylistRepository
+	playlistProcessor  processor.PlaylistProcessor
+	transactionManager transaction.Manager
+}
+
+func NewFreezePlaylist(
+	repository repository.PlaylistRepository,
+	playlistProcessor processor.PlaylistProcessor,
+	transactionManager transaction.Manager,
+) FreezePlaylist {
+	return FreezePlaylist{
+		repository:         repository,
+		playlistProcessor:  playlistProcessor,
+		transactionManager: transactionManager,
+	}
+}
+
+// Handle turns a smart playlist into a static one, with the songs currently matching its rules
+func (f FreezePlaylist) Handle(request requests.FreezePlaylistRequest) *wrapper.ErrorCode {
+	var playlist model.Playlist
+	err := f.repository.Get(&playlist, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(playlist).IsZero() {
+		return wrapper.NotFoundError(errors.New("playlist not found"))
+	}
+	if playlist.SmartRules == nil {
+		return wrapper.BadRequestError(errors.New("playlist is not smart"))
+	}
+
+	songIDs, errCode := f.playlistProcessor.GetSmartPlaylistSongIDs(playlist)
+	if errCode != nil {
+		return errCode
+	}
+
+	var oldPlaylistSongs []model.PlaylistSong
+	err = f.repository.GetPlaylistSongs(&oldPlaylistSongs, playlist.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	var playlistSongs []model.PlaylistSong
+	for i, songID := range songIDs {
+		playlistSongs = append(playlistSongs, model.PlaylistSong{
+			ID:          uuid.New(),
+			PlaylistID:  playlist.ID,
+			SongID:      songID,
+			SongTrackNo: uint(i + 1),
+		})
+	}
+	playlist.SmartRules = nil
+
+	err = f.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		playlistRepository := factory.NewPlaylistRepository()
+		// the songs left from before the playlist became smart are replaced
+		if len(oldPlaylistSongs) > 0 {
+			if err := playlistRepository.RemoveSongs(&oldPlaylistSongs); err != nil {
+				return err
+			}
+		}
+		if len(playlistSongs) > 0 {
+			if err := playlistRepository.AddSongs(&playlistSongs); err != nil {
+				return err
+			}
+		}
+		return playlistRepository.Update(&playlist)
+	})
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
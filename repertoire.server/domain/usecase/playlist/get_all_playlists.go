@@ -9,14 +9,20 @@ import (
 )
 
 type GetAllPlaylists struct {
-	repository repository.PlaylistRepository
-	jwtService service.JwtService
+	repository     repository.PlaylistRepository
+	songRepository repository.SongRepository
+	jwtService     service.JwtService
 }
 
-func NewGetAllPlaylists(repository repository.PlaylistRepository, jwtService service.JwtService) GetAllPlaylists {
+func NewGetAllPlaylists(
+	repository repository.PlaylistRepository,
+	songRepository repository.SongRepository,
+	jwtService service.JwtService,
+) GetAllPlaylists {
 	return GetAllPlaylists{
-		repository: repository,
-		jwtService: jwtService,
+		repository:     repository,
+		songRepository: songRepository,
+		jwtService:     jwtService,
 	}
 }
 
@@ -41,6 +47,17 @@ func (g GetAllPlaylists) Handle(
 		return result, wrapper.InternalServerError(err)
 	}
 
+	// the smart playlists have no songs of their own, as they are resolved from their rules
+	for i := range result.Models {
+		if result.Models[i].SmartRules == nil {
+			continue
+		}
+		err = g.songRepository.GetSmartPlaylistTotals(&result.Models[i])
+		if err != nil {
+			return result, wrapper.InternalServerError(err)
+		}
+	}
+
 	err = g.repository.GetAllByUserCount(&result.TotalCount, userID, request.SearchBy)
 	if err != nil {
 		return result, wrapper.InternalServerError(err)
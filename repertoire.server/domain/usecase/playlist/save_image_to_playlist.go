@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
@@ -21,6 +22,7 @@ type SaveImageToPlaylist struct {
 	storageFilePathProvider provider.StorageFilePathProvider
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewSaveImageToPlaylist(
@@ -28,12 +30,14 @@ func NewSaveImageToPlaylist(
 	storageFilePathProvider provider.StorageFilePathProvider,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) SaveImageToPlaylist {
 	return SaveImageToPlaylist{
 		repository:              repository,
 		storageFilePathProvider: storageFilePathProvider,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -55,20 +59,25 @@ func (s SaveImageToPlaylist) Handle(file *multipart.FileHeader, id uuid.UUID) *w
 	}
 
 	playlist.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetPlaylistImagePath(file, playlist)
+	imagePath := s.storageFilePathProvider.GetPlaylistImagePath(playlist)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode := s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	playlist.ImageURL = (*internal.FilePath)(&imagePath)
-	err = s.repository.Update(&playlist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = s.messagePublisherService.Publish(topics.PlaylistUpdatedTopic, playlist)
+	err = s.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Update(&playlist)
+		if err != nil {
+			return err
+		}
+		return s.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistUpdatedTopic,
+			playlist,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -8,22 +8,36 @@ import (
 )
 
 type GetPlaylistSongs struct {
-	repository repository.PlaylistRepository
+	repository     repository.PlaylistRepository
+	songRepository repository.SongRepository
 }
 
-func NewGetPlaylistSongs(repository repository.PlaylistRepository) GetPlaylistSongs {
+func NewGetPlaylistSongs(
+	repository repository.PlaylistRepository,
+	songRepository repository.SongRepository,
+) GetPlaylistSongs {
 	return GetPlaylistSongs{
-		repository: repository,
+		repository:     repository,
+		songRepository: songRepository,
 	}
 }
 
 func (g GetPlaylistSongs) Handle(request requests.GetPlaylistSongsRequest) (result wrapper.WithTotalCount[model.Song], e *wrapper.ErrorCode) {
+	var playlist model.Playlist
+	err := g.repository.Get(&playlist, request.ID)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+	if playlist.SmartRules != nil {
+		return g.getSmartPlaylistSongs(playlist, request)
+	}
+
 	if len(request.OrderBy) == 0 {
 		request.OrderBy = []string{"song_track_no"}
 	}
 
 	var playlistSongs []model.PlaylistSong
-	err := g.repository.GetPlaylistSongsWithSongs(
+	err = g.repository.GetPlaylistSongsWithSongs(
 		&playlistSongs,
 		request.ID,
 		request.CurrentPage,
@@ -48,6 +62,59 @@ func (g GetPlaylistSongs) Handle(request requests.GetPlaylistSongsRequest) (resu
 	return result, nil
 }
 
+// getSmartPlaylistSongs resolves the songs matching the rules, always in the order of the rules,
+// and never going past their limit, not even when paginating
+func (g GetPlaylistSongs) getSmartPlaylistSongs(
+	playlist model.Playlist,
+	request requests.GetPlaylistSongsRequest,
+) (result wrapper.WithTotalCount[model.Song], e *wrapper.ErrorCode) {
+	rules := playlist.SmartRules
+
+	currentPage, pageSize := request.CurrentPage, request.PageSize
+	offset, remaining := 0, 0
+	if currentPage != nil && pageSize != nil {
+		offset = (*currentPage - 1) * *pageSize
+	}
+	if rules.Limit != nil {
+		if currentPage == nil || pageSize == nil {
+			currentPage, pageSize = &[]int{1}[0], &[]int{int(*rules.Limit)}[0]
+		}
+		remaining = max(int(*rules.Limit)-offset, 0)
+	}
+
+	var songs []model.EnhancedSong
+	if rules.Limit == nil || remaining > 0 {
+		err := g.songRepository.GetAllByUser(
+			&songs,
+			playlist.UserID,
+			currentPage,
+			pageSize,
+			rules.OrderBy,
+			rules.SearchBy,
+		)
+		if err != nil {
+			return result, wrapper.InternalServerError(err)
+		}
+	}
+	if rules.Limit != nil && len(songs) > remaining {
+		songs = songs[:remaining]
+	}
+
+	err := g.songRepository.GetAllByUserCount(&result.TotalCount, playlist.UserID, rules.SearchBy)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+	if rules.Limit != nil && result.TotalCount > int64(*rules.Limit) {
+		result.TotalCount = int64(*rules.Limit)
+	}
+
+	for i, song := range songs {
+		song.PlaylistTrackNo = uint(offset + i + 1)
+		result.Models = append(result.Models, song.Song)
+	}
+	return result, nil
+}
+
 func (g GetPlaylistSongs) mapToSong(playlistSong model.PlaylistSong) model.Song {
 	song := playlistSong.Song
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -14,15 +15,18 @@ import (
 type UpdatePlaylist struct {
 	repository              repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewUpdatePlaylist(
 	repository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) UpdatePlaylist {
 	return UpdatePlaylist{
 		repository:              repository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -39,12 +43,17 @@ func (u UpdatePlaylist) Handle(request requests.UpdatePlaylistRequest) *wrapper.
 	playlist.Title = request.Title
 	playlist.Description = request.Description
 
-	err = u.repository.Update(&playlist)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = u.messagePublisherService.Publish(topics.PlaylistUpdatedTopic, playlist)
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewPlaylistRepository().Update(&playlist)
+		if err != nil {
+			return err
+		}
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.PlaylistUpdatedTopic,
+			playlist,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -0,0 +1,51 @@
+package playlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type UpdatePlaylistSmartRules struct {
+	repository repository.PlaylistRepository
+}
+
+func NewUpdatePlaylistSmartRules(repository repository.PlaylistRepository) UpdatePlaylistSmartRules {
+	return UpdatePlaylistSmartRules{
+		repository: repository,
+	}
+}
+
+// Handle replaces the rules of the playlist, or turns it back into a static playlist when they are missing
+func (u UpdatePlaylistSmartRules) Handle(request requests.UpdatePlaylistSmartRulesRequest) *wrapper.ErrorCode {
+	var playlist model.Playlist
+	err := u.repository.Get(&playlist, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(playlist).IsZero() {
+		return wrapper.NotFoundError(errors.New("playlist not found"))
+	}
+
+	playlist.SmartRules = toSmartRules(request.SmartRules)
+
+	err = u.repository.Update(&playlist)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
+
+func toSmartRules(request *requests.PlaylistSmartRulesRequest) *model.PlaylistSmartRules {
+	if request == nil {
+		return nil
+	}
+	return &model.PlaylistSmartRules{
+		SearchBy: request.SearchBy,
+		OrderBy:  request.OrderBy,
+		Limit:    request.Limit,
+	}
+}
@@ -0,0 +1,78 @@
+package practice
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type AddSongToPracticeSession struct {
+	repository     repository.PracticeSessionRepository
+	songRepository repository.SongRepository
+}
+
+func NewAddSongToPracticeSession(
+	repository repository.PracticeSessionRepository,
+	songRepository repository.SongRepository,
+) AddSongToPracticeSession {
+	return AddSongToPracticeSession{
+		repository:     repository,
+		songRepository: songRepository,
+	}
+}
+
+func (a AddSongToPracticeSession) Handle(request requests.AddSongToPracticeSessionRequest) *wrapper.ErrorCode {
+	var session model.PracticeSession
+	err := a.repository.Get(&session, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(session).IsZero() {
+		return wrapper.NotFoundError(errors.New("practice session not found"))
+	}
+	if session.IsFinished() {
+		return wrapper.ConflictError(errors.New("practice session has already been finished"))
+	}
+
+	var song model.Song
+	err = a.songRepository.GetWithSections(&song, request.SongID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(song).IsZero() {
+		return wrapper.NotFoundError(errors.New("song not found"))
+	}
+
+	sessionSong := model.PracticeSessionSong{
+		ID:                uuid.New(),
+		PracticeSessionID: session.ID,
+		SongID:            song.ID,
+	}
+	for _, sectionRequest := range request.Sections {
+		isSectionOnSong := slices.ContainsFunc(song.Sections, func(s model.SongSection) bool {
+			return s.ID == sectionRequest.ID
+		})
+		if !isSectionOnSong {
+			return wrapper.NotFoundError(errors.New("song section not found"))
+		}
+
+		sessionSong.Sections = append(sessionSong.Sections, model.PracticeSessionSection{
+			ID:                    uuid.New(),
+			Occurrences:           sectionRequest.Occurrences,
+			PracticeSessionSongID: sessionSong.ID,
+			SongSectionID:         sectionRequest.ID,
+		})
+	}
+
+	err = a.repository.CreateSong(&sessionSong)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
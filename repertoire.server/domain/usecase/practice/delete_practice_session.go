@@ -0,0 +1,38 @@
+package practice
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type DeletePracticeSession struct {
+	repository repository.PracticeSessionRepository
+}
+
+func NewDeletePracticeSession(repository repository.PracticeSessionRepository) DeletePracticeSession {
+	return DeletePracticeSession{
+		repository: repository,
+	}
+}
+
+func (d DeletePracticeSession) Handle(id uuid.UUID) *wrapper.ErrorCode {
+	var session model.PracticeSession
+	err := d.repository.Get(&session, id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(session).IsZero() {
+		return wrapper.NotFoundError(errors.New("practice session not found"))
+	}
+
+	err = d.repository.Delete(id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
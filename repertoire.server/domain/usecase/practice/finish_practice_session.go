@@ -0,0 +1,46 @@
+package practice
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"time"
+)
+
+type FinishPracticeSession struct {
+	repository repository.PracticeSessionRepository
+}
+
+func NewFinishPracticeSession(repository repository.PracticeSessionRepository) FinishPracticeSession {
+	return FinishPracticeSession{
+		repository: repository,
+	}
+}
+
+func (f FinishPracticeSession) Handle(request requests.FinishPracticeSessionRequest) *wrapper.ErrorCode {
+	var session model.PracticeSession
+	err := f.repository.Get(&session, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(session).IsZero() {
+		return wrapper.NotFoundError(errors.New("practice session not found"))
+	}
+	if session.IsFinished() {
+		return wrapper.ConflictError(errors.New("practice session has already been finished"))
+	}
+
+	if request.Notes != nil {
+		session.Notes = *request.Notes
+	}
+	session.EndedAt = &[]time.Time{time.Now().UTC()}[0]
+
+	err = f.repository.Update(&session)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package practice
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type GetAllPracticeSessions struct {
+	repository repository.PracticeSessionRepository
+	jwtService service.JwtService
+}
+
+func NewGetAllPracticeSessions(
+	repository repository.PracticeSessionRepository,
+	jwtService service.JwtService,
+) GetAllPracticeSessions {
+	return GetAllPracticeSessions{
+		repository: repository,
+		jwtService: jwtService,
+	}
+}
+
+func (g GetAllPracticeSessions) Handle(
+	request requests.GetPracticeSessionsRequest,
+	token string,
+) (result wrapper.WithTotalCount[model.PracticeSession], e *wrapper.ErrorCode) {
+	userID, errCode := g.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return result, errCode
+	}
+
+	err := g.repository.GetAllByUser(
+		&result.Models,
+		userID,
+		request.CurrentPage,
+		request.PageSize,
+		request.OrderBy,
+		request.SearchBy,
+	)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	err = g.repository.GetAllByUserCount(&result.TotalCount, userID, request.SearchBy)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	return result, nil
+}
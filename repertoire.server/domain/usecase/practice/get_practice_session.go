@@ -0,0 +1,32 @@
+package practice
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type GetPracticeSession struct {
+	repository repository.PracticeSessionRepository
+}
+
+func NewGetPracticeSession(repository repository.PracticeSessionRepository) GetPracticeSession {
+	return GetPracticeSession{
+		repository: repository,
+	}
+}
+
+func (g GetPracticeSession) Handle(id uuid.UUID) (session model.PracticeSession, e *wrapper.ErrorCode) {
+	err := g.repository.GetWithSongs(&session, id)
+	if err != nil {
+		return session, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(session).IsZero() {
+		return session, wrapper.NotFoundError(errors.New("practice session not found"))
+	}
+	return session, nil
+}
@@ -0,0 +1,58 @@
+package practice
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StartPracticeSession struct {
+	jwtService service.JwtService
+	repository repository.PracticeSessionRepository
+}
+
+func NewStartPracticeSession(
+	jwtService service.JwtService,
+	repository repository.PracticeSessionRepository,
+) StartPracticeSession {
+	return StartPracticeSession{
+		jwtService: jwtService,
+		repository: repository,
+	}
+}
+
+func (s StartPracticeSession) Handle(request requests.StartPracticeSessionRequest, token string) (uuid.UUID, *wrapper.ErrorCode) {
+	userID, errCode := s.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return uuid.Nil, errCode
+	}
+
+	var sessionInProgress model.PracticeSession
+	err := s.repository.GetInProgressByUser(&sessionInProgress, userID)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+	if !reflect.ValueOf(sessionInProgress).IsZero() {
+		return uuid.Nil, wrapper.ConflictError(errors.New("another practice session is already in progress"))
+	}
+
+	session := model.PracticeSession{
+		ID:        uuid.New(),
+		Notes:     request.Notes,
+		StartedAt: time.Now().UTC(),
+		UserID:    userID,
+	}
+	err = s.repository.Create(&session)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+
+	return session.ID, nil
+}
@@ -0,0 +1,116 @@
+package progress
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type GetProgressTimeline struct {
+	jwtService                service.JwtService
+	userRepository            repository.UserRepository
+	playlistRepository        repository.PlaylistRepository
+	songSectionRepository     repository.SongSectionRepository
+	playlistProcessor         processor.PlaylistProcessor
+	progressTimelineProcessor processor.ProgressTimelineProcessor
+}
+
+func NewGetProgressTimeline(
+	jwtService service.JwtService,
+	userRepository repository.UserRepository,
+	playlistRepository repository.PlaylistRepository,
+	songSectionRepository repository.SongSectionRepository,
+	playlistProcessor processor.PlaylistProcessor,
+	progressTimelineProcessor processor.ProgressTimelineProcessor,
+) GetProgressTimeline {
+	return GetProgressTimeline{
+		jwtService:                jwtService,
+		userRepository:            userRepository,
+		playlistRepository:        playlistRepository,
+		songSectionRepository:     songSectionRepository,
+		playlistProcessor:         playlistProcessor,
+		progressTimelineProcessor: progressTimelineProcessor,
+	}
+}
+
+func (g GetProgressTimeline) Handle(
+	request requests.GetProgressTimelineRequest,
+	token string,
+) ([]model.ProgressTimelinePoint, *wrapper.ErrorCode) {
+	if request.From != nil && request.To != nil && request.From.After(*request.To) {
+		return nil, wrapper.BadRequestError(errors.New("from date must be before the to date"))
+	}
+
+	userID, errCode := g.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	var user model.User
+	err := g.userRepository.Get(&user, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(user).IsZero() {
+		return nil, wrapper.NotFoundError(errors.New("user not found"))
+	}
+
+	sections, errCode := g.getSections(userID, request)
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	return g.progressTimelineProcessor.BuildTimeline(
+		sections,
+		request.Interval,
+		request.From,
+		request.To,
+		user.ScoringStrategy,
+	), nil
+}
+
+// getSections returns the sections in the scope of the timeline,
+// resolving the songs from the rules when the scope is a smart playlist
+func (g GetProgressTimeline) getSections(
+	userID uuid.UUID,
+	request requests.GetProgressTimelineRequest,
+) ([]model.SongSection, *wrapper.ErrorCode) {
+	var sections []model.SongSection
+
+	if request.Scope != nil && *request.Scope == enums.PlaylistTimeline {
+		var playlist model.Playlist
+		err := g.playlistRepository.Get(&playlist, request.ScopeID)
+		if err != nil {
+			return nil, wrapper.InternalServerError(err)
+		}
+		if playlist.SmartRules != nil {
+			songIDs, errCode := g.playlistProcessor.GetSmartPlaylistSongIDs(playlist)
+			if errCode != nil {
+				return nil, errCode
+			}
+			if len(songIDs) == 0 {
+				return sections, nil
+			}
+
+			err = g.songSectionRepository.GetAllWithHistoryBySongs(&sections, userID, songIDs)
+			if err != nil {
+				return nil, wrapper.InternalServerError(err)
+			}
+			return sections, nil
+		}
+	}
+
+	err := g.songSectionRepository.GetAllWithHistoryByUser(&sections, userID, request.Scope, request.ScopeID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	return sections, nil
+}
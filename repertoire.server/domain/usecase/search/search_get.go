@@ -28,10 +28,10 @@ func NewGet(
 func (g Get) Handle(
 	request requests.SearchGetRequest,
 	token string,
-) (wrapper.WithTotalCount[any], *wrapper.ErrorCode) {
+) (model.SearchResults[any], *wrapper.ErrorCode) {
 	userID, errCode := g.jwtService.GetUserIdFromJwt(token)
 	if errCode != nil {
-		return wrapper.WithTotalCount[any]{}, errCode
+		return model.SearchResults[any]{}, errCode
 	}
 
 	if len(request.IDs) > 0 {
@@ -60,10 +60,16 @@ func (g Get) Handle(
 		userID,
 		request.Filter,
 		request.Order,
+		model.SearchOptions{
+			Facets:           request.Facets,
+			Highlight:        request.Highlight,
+			TypoTolerance:    request.TypoTolerance,
+			MatchingStrategy: request.MatchingStrategy,
+		},
 	)
 
 	if errCode != nil {
-		return wrapper.WithTotalCount[any]{}, errCode
+		return model.SearchResults[any]{}, errCode
 	}
 
 	var results []any
@@ -120,11 +126,40 @@ func (g Get) Handle(
 			playlist.ImageUrl = playlist.ImageUrl.ToFullURL()
 
 			results = append(results, playlist)
+
+		case enums.BandMember:
+			var member model.BandMemberSearch
+			jsonRes, _ := json.Marshal(curr)
+			_ = json.Unmarshal(jsonRes, &member)
+
+			member.ID = strings.Replace(member.ID, "bandMember-", "", 1)
+			member.ImageUrl = member.ImageUrl.ToFullURL()
+			if member.Artist != nil {
+				member.Artist.ImageUrl = member.Artist.ImageUrl.ToFullURL()
+			}
+
+			results = append(results, member)
+
+		case enums.SongSection:
+			var section model.SongSectionSearch
+			jsonRes, _ := json.Marshal(curr)
+			_ = json.Unmarshal(jsonRes, &section)
+
+			section.ID = strings.Replace(section.ID, "songSection-", "", 1)
+			if section.Song != nil {
+				section.Song.ImageUrl = section.Song.ImageUrl.ToFullURL()
+			}
+
+			results = append(results, section)
 		}
 	}
 
-	return wrapper.WithTotalCount[any]{
-		Models:     results,
-		TotalCount: searchResult.TotalCount,
+	return model.SearchResults[any]{
+		WithTotalCount: wrapper.WithTotalCount[any]{
+			Models:     results,
+			TotalCount: searchResult.TotalCount,
+		},
+		Facets:     searchResult.Facets,
+		FacetStats: searchResult.FacetStats,
 	}, nil
 }
@@ -0,0 +1,162 @@
+package search
+
+import (
+	"encoding/json"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type Reconcile struct {
+	userRepository          repository.UserRepository
+	searchEngineService     service.SearchEngineService
+	messagePublisherService service.MessagePublisherService
+}
+
+func NewReconcile(
+	userRepository repository.UserRepository,
+	searchEngineService service.SearchEngineService,
+	messagePublisherService service.MessagePublisherService,
+) Reconcile {
+	return Reconcile{
+		userRepository:          userRepository,
+		searchEngineService:     searchEngineService,
+		messagePublisherService: messagePublisherService,
+	}
+}
+
+// Handle reconciles the search documents of the given user, or of all users when the ID is missing
+func (r Reconcile) Handle(userID *uuid.UUID) (model.SearchReconciliation, *wrapper.ErrorCode) {
+	var userIDs []uuid.UUID
+	if userID != nil {
+		userIDs = []uuid.UUID{*userID}
+	} else {
+		err := r.userRepository.GetAllIDs(&userIDs)
+		if err != nil {
+			return model.SearchReconciliation{}, wrapper.InternalServerError(err)
+		}
+	}
+
+	var result model.SearchReconciliation
+	for _, id := range userIDs {
+		userResult, err := r.reconcileUser(id)
+		if err != nil {
+			return result, wrapper.InternalServerError(err)
+		}
+		result.Added += userResult.Added
+		result.Updated += userResult.Updated
+		result.Deleted += userResult.Deleted
+	}
+
+	return result, nil
+}
+
+func (r Reconcile) reconcileUser(userID uuid.UUID) (model.SearchReconciliation, error) {
+	var user model.User
+	err := r.userRepository.GetWithSearchableData(&user, userID)
+	if err != nil {
+		return model.SearchReconciliation{}, err
+	}
+
+	projections, err := r.getSearchProjections(user)
+	if err != nil {
+		return model.SearchReconciliation{}, err
+	}
+
+	documents, err := r.searchEngineService.GetDocuments("userId = " + userID.String())
+	if err != nil {
+		return model.SearchReconciliation{}, err
+	}
+	documentsByID := make(map[string]map[string]any, len(documents))
+	for _, document := range documents {
+		documentsByID[document["id"].(string)] = document
+	}
+
+	var documentsToAdd, documentsToUpdate []any
+	for _, projection := range projections {
+		document, exists := documentsByID[projection["id"].(string)]
+		if !exists {
+			documentsToAdd = append(documentsToAdd, projection)
+		} else if !reflect.DeepEqual(document, projection) {
+			documentsToUpdate = append(documentsToUpdate, projection)
+		}
+		delete(documentsByID, projection["id"].(string))
+	}
+
+	var idsToDelete []string
+	for _, document := range documents {
+		if _, isStale := documentsByID[document["id"].(string)]; isStale {
+			idsToDelete = append(idsToDelete, document["id"].(string))
+		}
+	}
+
+	if len(documentsToAdd) > 0 {
+		err = r.messagePublisherService.Publish(topics.AddToSearchEngineTopic, documentsToAdd)
+		if err != nil {
+			return model.SearchReconciliation{}, err
+		}
+	}
+	if len(documentsToUpdate) > 0 {
+		err = r.messagePublisherService.Publish(topics.UpdateFromSearchEngineTopic, documentsToUpdate)
+		if err != nil {
+			return model.SearchReconciliation{}, err
+		}
+	}
+	if len(idsToDelete) > 0 {
+		err = r.messagePublisherService.Publish(topics.DeleteFromSearchEngineTopic, idsToDelete)
+		if err != nil {
+			return model.SearchReconciliation{}, err
+		}
+	}
+
+	return model.SearchReconciliation{
+		Added:   len(documentsToAdd),
+		Updated: len(documentsToUpdate),
+		Deleted: len(idsToDelete),
+	}, nil
+}
+
+// getSearchProjections returns the documents as they are expected to be in the search engine,
+// in the same shape as the ones retrieved from it, so that they can be compared field by field
+func (r Reconcile) getSearchProjections(user model.User) ([]map[string]any, error) {
+	var searches []any
+	for _, artist := range user.Artists {
+		searches = append(searches, artist.ToSearch())
+		for _, member := range artist.BandMembers {
+			member.Artist = artist
+			searches = append(searches, member.ToSearch())
+		}
+	}
+	for _, album := range user.Albums {
+		searches = append(searches, album.ToSearch())
+	}
+	for _, song := range user.Songs {
+		searches = append(searches, song.ToSearch())
+		for _, section := range song.ToSectionsSearch() {
+			searches = append(searches, section)
+		}
+	}
+	for _, playlist := range user.Playlists {
+		searches = append(searches, playlist.ToSearch())
+	}
+
+	var projections []map[string]any
+	for _, search := range searches {
+		bytes, err := json.Marshal(search)
+		if err != nil {
+			return nil, err
+		}
+		var projection map[string]any
+		err = json.Unmarshal(bytes, &projection)
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, projection)
+	}
+	return projections, nil
+}
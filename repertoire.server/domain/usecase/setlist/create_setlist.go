@@ -0,0 +1,48 @@
+package setlist
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type CreateSetlist struct {
+	jwtService service.JwtService
+	repository repository.SetlistRepository
+}
+
+func NewCreateSetlist(
+	jwtService service.JwtService,
+	repository repository.SetlistRepository,
+) CreateSetlist {
+	return CreateSetlist{
+		jwtService: jwtService,
+		repository: repository,
+	}
+}
+
+func (c CreateSetlist) Handle(request requests.CreateSetlistRequest, token string) (uuid.UUID, *wrapper.ErrorCode) {
+	userID, errCode := c.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return uuid.Nil, errCode
+	}
+
+	setlist := model.Setlist{
+		ID:     uuid.New(),
+		Title:  request.Title,
+		Venue:  request.Venue,
+		Date:   request.Date,
+		Notes:  request.Notes,
+		UserID: userID,
+	}
+	err := c.repository.Create(&setlist)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+
+	return setlist.ID, nil
+}
@@ -0,0 +1,106 @@
+package setlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type CreateSetlistFromPlaylist struct {
+	jwtService         service.JwtService
+	repository         repository.SetlistRepository
+	playlistRepository repository.PlaylistRepository
+	playlistProcessor  processor.PlaylistProcessor
+}
+
+func NewCreateSetlistFromPlaylist(
+	jwtService service.JwtService,
+	repository repository.SetlistRepository,
+	playlistRepository repository.PlaylistRepository,
+	playlistProcessor processor.PlaylistProcessor,
+) CreateSetlistFromPlaylist {
+	return CreateSetlistFromPlaylist{
+		jwtService:         jwtService,
+		repository:         repository,
+		playlistRepository: playlistRepository,
+		playlistProcessor:  playlistProcessor,
+	}
+}
+
+func (c CreateSetlistFromPlaylist) Handle(
+	request requests.CreateSetlistFromPlaylistRequest,
+	token string,
+) (uuid.UUID, *wrapper.ErrorCode) {
+	userID, errCode := c.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return uuid.Nil, errCode
+	}
+
+	var playlist model.Playlist
+	err := c.playlistRepository.Get(&playlist, request.PlaylistID)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(playlist).IsZero() {
+		return uuid.Nil, wrapper.NotFoundError(errors.New("playlist not found"))
+	}
+
+	songIDs, errCode := c.getSongIDs(playlist)
+	if errCode != nil {
+		return uuid.Nil, errCode
+	}
+
+	setlist := model.Setlist{
+		ID:     uuid.New(),
+		Title:  request.Title,
+		Venue:  request.Venue,
+		Date:   request.Date,
+		Notes:  request.Notes,
+		UserID: userID,
+	}
+
+	// the running order follows the order of the songs in the playlist
+	for i, songID := range songIDs {
+		setlist.Entries = append(setlist.Entries, model.SetlistEntry{
+			ID:        uuid.New(),
+			Type:      enums.SongEntry,
+			EntryNo:   uint(i) + 1,
+			SetlistID: setlist.ID,
+			SongID:    &songID,
+		})
+	}
+
+	err = c.repository.Create(&setlist)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+
+	return setlist.ID, nil
+}
+
+// getSongIDs returns the songs of the playlist in order, resolving them from the rules when the playlist is smart
+func (c CreateSetlistFromPlaylist) getSongIDs(playlist model.Playlist) ([]uuid.UUID, *wrapper.ErrorCode) {
+	if playlist.SmartRules != nil {
+		return c.playlistProcessor.GetSmartPlaylistSongIDs(playlist)
+	}
+
+	var playlistSongs []model.PlaylistSong
+	err := c.playlistRepository.GetPlaylistSongs(&playlistSongs, playlist.ID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var songIDs []uuid.UUID
+	for _, playlistSong := range playlistSongs {
+		songIDs = append(songIDs, playlistSong.SongID)
+	}
+	return songIDs, nil
+}
@@ -0,0 +1,38 @@
+package setlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type DeleteSetlist struct {
+	repository repository.SetlistRepository
+}
+
+func NewDeleteSetlist(repository repository.SetlistRepository) DeleteSetlist {
+	return DeleteSetlist{
+		repository: repository,
+	}
+}
+
+func (d DeleteSetlist) Handle(id uuid.UUID) *wrapper.ErrorCode {
+	var setlist model.Setlist
+	err := d.repository.Get(&setlist, id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(setlist).IsZero() {
+		return wrapper.NotFoundError(errors.New("setlist not found"))
+	}
+
+	err = d.repository.Delete(id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package entry
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type AddEntryToSetlist struct {
+	repository     repository.SetlistRepository
+	songRepository repository.SongRepository
+}
+
+func NewAddEntryToSetlist(
+	repository repository.SetlistRepository,
+	songRepository repository.SongRepository,
+) AddEntryToSetlist {
+	return AddEntryToSetlist{
+		repository:     repository,
+		songRepository: songRepository,
+	}
+}
+
+func (a AddEntryToSetlist) Handle(request requests.AddEntryToSetlistRequest) (uuid.UUID, *wrapper.ErrorCode) {
+	var setlist model.Setlist
+	err := a.repository.Get(&setlist, request.ID)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(setlist).IsZero() {
+		return uuid.Nil, wrapper.NotFoundError(errors.New("setlist not found"))
+	}
+
+	if request.Type == enums.SongEntry {
+		var song model.Song
+		err = a.songRepository.Get(&song, *request.SongID)
+		if err != nil {
+			return uuid.Nil, wrapper.InternalServerError(err)
+		}
+		if reflect.ValueOf(song).IsZero() {
+			return uuid.Nil, wrapper.NotFoundError(errors.New("song not found"))
+		}
+	}
+
+	var entries []model.SetlistEntry
+	err = a.repository.GetEntries(&entries, request.ID)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+
+	// the new entry goes to the end of the running order
+	entryNo := uint(1)
+	if len(entries) > 0 {
+		entryNo = entries[len(entries)-1].EntryNo + 1
+	}
+
+	entry := model.SetlistEntry{
+		ID:              uuid.New(),
+		Type:            request.Type,
+		Title:           request.Title,
+		PlannedDuration: request.PlannedDuration,
+		EntryNo:         entryNo,
+		SetlistID:       request.ID,
+		SongID:          request.SongID,
+	}
+	err = a.repository.CreateEntry(&entry)
+	if err != nil {
+		return uuid.Nil, wrapper.InternalServerError(err)
+	}
+
+	return entry.ID, nil
+}
@@ -0,0 +1,79 @@
+package entry
+
+import (
+	"errors"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type MoveEntryFromSetlist struct {
+	repository repository.SetlistRepository
+}
+
+func NewMoveEntryFromSetlist(repository repository.SetlistRepository) MoveEntryFromSetlist {
+	return MoveEntryFromSetlist{repository: repository}
+}
+
+func (m MoveEntryFromSetlist) Handle(request requests.MoveEntryFromSetlistRequest) *wrapper.ErrorCode {
+	var entries []model.SetlistEntry
+	err := m.repository.GetEntries(&entries, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	index, overIndex, err := m.getIndexes(entries, request.EntryID, request.OverEntryID)
+	if err != nil {
+		return wrapper.NotFoundError(err)
+	}
+	entries = m.move(entries, index, overIndex)
+
+	err = m.repository.UpdateAllEntries(&entries)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
+
+func (MoveEntryFromSetlist) getIndexes(
+	entries []model.SetlistEntry,
+	id uuid.UUID,
+	overID uuid.UUID,
+) (int, int, error) {
+	var index *int
+	var overIndex *int
+	for i := 0; i < len(entries); i++ {
+		if entries[i].ID == id {
+			index = &i
+		} else if entries[i].ID == overID {
+			overIndex = &i
+		}
+	}
+
+	if index == nil {
+		return -1, -1, errors.New("entry not found")
+	}
+	if overIndex == nil {
+		return -1, -1, errors.New("over entry not found")
+	}
+
+	return *index, *overIndex, nil
+}
+
+// move renumbers all the entries from their new positions,
+// so that the gaps left behind by the deleted songs (whose entries go away with them) are closed as well
+func (MoveEntryFromSetlist) move(entries []model.SetlistEntry, index int, overIndex int) []model.SetlistEntry {
+	entry := entries[index]
+	entries = slices.Delete(entries, index, index+1)
+	entries = slices.Insert(entries, overIndex, entry)
+	for i := range entries {
+		entries[i].EntryNo = uint(i) + 1
+	}
+
+	return entries
+}
@@ -0,0 +1,70 @@
+package entry
+
+import (
+	"errors"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"slices"
+)
+
+type RemoveEntriesFromSetlist struct {
+	repository  repository.SetlistRepository
+	transaction transaction.Manager
+}
+
+func NewRemoveEntriesFromSetlist(
+	repository repository.SetlistRepository,
+	transaction transaction.Manager,
+) RemoveEntriesFromSetlist {
+	return RemoveEntriesFromSetlist{
+		repository:  repository,
+		transaction: transaction,
+	}
+}
+
+func (r RemoveEntriesFromSetlist) Handle(request requests.RemoveEntriesFromSetlistRequest) *wrapper.ErrorCode {
+	var entries []model.SetlistEntry
+	err := r.repository.GetEntries(&entries, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	var entriesToDelete []model.SetlistEntry
+	var entriesToPreserve []model.SetlistEntry
+
+	entryNo := uint(1)
+	for _, entry := range entries {
+		if slices.Contains(request.EntryIDs, entry.ID) {
+			entriesToDelete = append(entriesToDelete, entry)
+		} else {
+			// reorder preserved entries
+			entry.EntryNo = entryNo
+			entriesToPreserve = append(entriesToPreserve, entry)
+			entryNo++
+		}
+	}
+
+	if len(entriesToDelete) != len(request.EntryIDs) {
+		return wrapper.NotFoundError(errors.New("could not find all entries"))
+	}
+
+	err = r.transaction.Execute(func(factory transaction.RepositoryFactory) error {
+		setlistRepo := factory.NewSetlistRepository()
+
+		if err := setlistRepo.RemoveEntries(&entriesToDelete); err != nil {
+			return err
+		}
+		if err := setlistRepo.UpdateAllEntries(&entriesToPreserve); err != nil {
+			return err
+		} // preserve order
+		return nil
+	})
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
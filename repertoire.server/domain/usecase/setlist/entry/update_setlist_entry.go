@@ -0,0 +1,45 @@
+package entry
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type UpdateSetlistEntry struct {
+	repository repository.SetlistRepository
+}
+
+func NewUpdateSetlistEntry(repository repository.SetlistRepository) UpdateSetlistEntry {
+	return UpdateSetlistEntry{
+		repository: repository,
+	}
+}
+
+func (u UpdateSetlistEntry) Handle(request requests.UpdateSetlistEntryRequest) *wrapper.ErrorCode {
+	var entry model.SetlistEntry
+	err := u.repository.GetEntry(&entry, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(entry).IsZero() {
+		return wrapper.NotFoundError(errors.New("setlist entry not found"))
+	}
+	if entry.Type != enums.SongEntry && request.Title == "" {
+		return wrapper.BadRequestError(errors.New("title is required for entries that are not songs"))
+	}
+
+	entry.Title = request.Title
+	entry.PlannedDuration = request.PlannedDuration
+
+	err = u.repository.UpdateEntry(&entry)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package setlist
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type GetAllSetlists struct {
+	repository repository.SetlistRepository
+	jwtService service.JwtService
+}
+
+func NewGetAllSetlists(
+	repository repository.SetlistRepository,
+	jwtService service.JwtService,
+) GetAllSetlists {
+	return GetAllSetlists{
+		repository: repository,
+		jwtService: jwtService,
+	}
+}
+
+func (g GetAllSetlists) Handle(
+	request requests.GetSetlistsRequest,
+	token string,
+) (result wrapper.WithTotalCount[model.Setlist], e *wrapper.ErrorCode) {
+	userID, errCode := g.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return result, errCode
+	}
+
+	err := g.repository.GetAllByUser(
+		&result.Models,
+		userID,
+		request.CurrentPage,
+		request.PageSize,
+		request.OrderBy,
+		request.SearchBy,
+	)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	err = g.repository.GetAllByUserCount(&result.TotalCount, userID, request.SearchBy)
+	if err != nil {
+		return result, wrapper.InternalServerError(err)
+	}
+
+	for i := range result.Models {
+		result.Models[i].ComputeTotalDuration()
+	}
+
+	return result, nil
+}
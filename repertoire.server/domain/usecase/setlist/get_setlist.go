@@ -0,0 +1,34 @@
+package setlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+)
+
+type GetSetlist struct {
+	repository repository.SetlistRepository
+}
+
+func NewGetSetlist(repository repository.SetlistRepository) GetSetlist {
+	return GetSetlist{
+		repository: repository,
+	}
+}
+
+func (g GetSetlist) Handle(id uuid.UUID) (setlist model.Setlist, e *wrapper.ErrorCode) {
+	err := g.repository.GetWithEntries(&setlist, id)
+	if err != nil {
+		return setlist, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(setlist).IsZero() {
+		return setlist, wrapper.NotFoundError(errors.New("setlist not found"))
+	}
+
+	setlist.ComputeTotalDuration()
+	return setlist, nil
+}
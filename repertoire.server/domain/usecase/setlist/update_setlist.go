@@ -0,0 +1,43 @@
+package setlist
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type UpdateSetlist struct {
+	repository repository.SetlistRepository
+}
+
+func NewUpdateSetlist(repository repository.SetlistRepository) UpdateSetlist {
+	return UpdateSetlist{
+		repository: repository,
+	}
+}
+
+func (u UpdateSetlist) Handle(request requests.UpdateSetlistRequest) *wrapper.ErrorCode {
+	var setlist model.Setlist
+	err := u.repository.Get(&setlist, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(setlist).IsZero() {
+		return wrapper.NotFoundError(errors.New("setlist not found"))
+	}
+
+	setlist.Title = request.Title
+	setlist.Venue = request.Venue
+	setlist.Date = request.Date
+	setlist.Notes = request.Notes
+
+	err = u.repository.Update(&setlist)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/domain/processor"
 	"repertoire/server/internal/wrapper"
@@ -14,20 +15,26 @@ import (
 )
 
 type AddPartialSongRehearsal struct {
-	songSectionRepository repository.SongSectionRepository
-	songRepository        repository.SongRepository
-	progressProcessor     processor.ProgressProcessor
+	songRepository           repository.SongRepository
+	userRepository           repository.UserRepository
+	transactionManager       transaction.Manager
+	progressProcessor        processor.ProgressProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
 }
 
 func NewAddPartialSongRehearsal(
-	songSectionRepository repository.SongSectionRepository,
 	songRepository repository.SongRepository,
+	userRepository repository.UserRepository,
+	transactionManager transaction.Manager,
 	progressProcessor processor.ProgressProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 ) AddPartialSongRehearsal {
 	return AddPartialSongRehearsal{
-		songSectionRepository: songSectionRepository,
-		songRepository:        songRepository,
-		progressProcessor:     progressProcessor,
+		songRepository:           songRepository,
+		userRepository:           userRepository,
+		transactionManager:       transactionManager,
+		progressProcessor:        progressProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
 	}
 }
 
@@ -41,57 +48,110 @@ func (a AddPartialSongRehearsal) Handle(request requests.AddPartialSongRehearsal
 		return wrapper.NotFoundError(errors.New("song not found"))
 	}
 
-	var totalRehearsals float64 = 0
-	var totalProgress float64 = 0
-	for i, section := range song.Sections {
+	var user model.User
+	err = a.userRepository.Get(&user, song.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	// the history added by this rehearsal is grouped, so that it can be undone as a whole
+	batch := model.RehearsalBatch{
+		ID:                     uuid.New(),
+		SongID:                 song.ID,
+		PreviousLastTimePlayed: song.LastTimePlayed,
+	}
+
+	// add history of the rehearsals changes
+	var newHistory []model.SongSectionHistory
+	var rehearsedSections []model.PracticeSessionSection
+	for _, section := range song.Sections {
 		if section.PartialOccurrences == 0 {
 			continue
 		}
-
-		newRehearsals := section.Rehearsals + section.PartialOccurrences
-		// add history of the rehearsals change
-		newHistory := model.SongSectionHistory{
+		newHistory = append(newHistory, model.SongSectionHistory{
 			ID:            uuid.New(),
 			Property:      model.RehearsalsProperty,
 			From:          section.Rehearsals,
-			To:            newRehearsals,
+			To:            section.Rehearsals + section.PartialOccurrences,
 			SongSectionID: section.ID,
-		}
-		err = a.songSectionRepository.CreateHistory(&newHistory)
+			BatchID:       &batch.ID,
+		})
+		rehearsedSections = append(rehearsedSections, model.PracticeSessionSection{
+			SongSectionID: section.ID,
+			Occurrences:   section.PartialOccurrences,
+		})
+	}
+
+	// means that no section will get updated
+	if len(newHistory) == 0 {
+		return nil
+	}
+
+	var errCode *wrapper.ErrorCode
+	err = a.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		transactionSongSectionRepository := factory.NewSongSectionRepository()
+		transactionSongRepository := factory.NewSongRepository()
+
+		err := transactionSongSectionRepository.CreateRehearsalBatch(&batch)
 		if err != nil {
-			return wrapper.InternalServerError(err)
+			errCode = wrapper.InternalServerError(err)
+			return err
 		}
-
-		// update section's rehearsals score based on the history changes and update the rehearsals and progress too
-		var history []model.SongSectionHistory
-		err = a.songSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
+		err = transactionSongSectionRepository.CreateHistories(&newHistory)
 		if err != nil {
-			return wrapper.InternalServerError(err)
+			errCode = wrapper.InternalServerError(err)
+			return err
 		}
 
-		song.Sections[i].Rehearsals = newRehearsals
-		song.Sections[i].RehearsalsScore = a.progressProcessor.ComputeRehearsalsScore(history)
-		song.Sections[i].Progress = a.progressProcessor.ComputeProgress(song.Sections[i])
+		var totalRehearsals float64 = 0
+		var totalProgress float64 = 0
+		for i, section := range song.Sections {
+			if section.PartialOccurrences == 0 {
+				continue
+			}
 
-		// add to the total for the median
-		totalProgress += float64(song.Sections[i].Progress)
-		totalRehearsals += float64(song.Sections[i].Rehearsals)
-	}
+			// update section's rehearsals score based on the history changes and update the rehearsals and progress too
+			var history []model.SongSectionHistory
+			err = transactionSongSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
+			if err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
 
-	// means that no section got updated (because if it did, the total would be at least 1 from an occurrence)
-	if totalRehearsals == 0 {
-		return nil
-	}
+			song.Sections[i].Rehearsals = section.Rehearsals + section.PartialOccurrences
+			song.Sections[i].RehearsalsScore = a.progressProcessor.ComputeRehearsalsScore(history, user.ScoringStrategy)
+			song.Sections[i].Progress = a.progressProcessor.ComputeProgress(song.Sections[i], user.ScoringStrategy)
+
+			// add to the total for the median
+			totalProgress += float64(song.Sections[i].Progress)
+			totalRehearsals += float64(song.Sections[i].Rehearsals)
+		}
+
+		// update song media progress and rehearsals + update last time played
+		sectionsCount := len(song.Sections)
+		song.Rehearsals = totalRehearsals / float64(sectionsCount)
+		song.Progress = totalProgress / float64(sectionsCount)
+		song.LastTimePlayed = &[]time.Time{time.Now().UTC()}[0]
 
-	// update song media progress and rehearsals + update last time played
-	sectionsCount := len(song.Sections)
-	song.Rehearsals = totalRehearsals / float64(sectionsCount)
-	song.Progress = totalProgress / float64(sectionsCount)
-	song.LastTimePlayed = &[]time.Time{time.Now().UTC()}[0]
+		err = transactionSongRepository.UpdateWithAssociations(&song)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+
+		errCode = a.practiceSessionProcessor.RecordRehearsal(song, batch.ID, rehearsedSections, factory.NewPracticeSessionRepository())
+		if errCode != nil {
+			return errCode.Error
+		}
 
-	err = a.songRepository.UpdateWithAssociations(&song)
+		return nil
+	})
 	if err != nil {
+		if errCode != nil {
+			return errCode
+		}
 		return wrapper.InternalServerError(err)
 	}
+
 	return nil
 }
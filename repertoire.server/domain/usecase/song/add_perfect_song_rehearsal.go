@@ -12,20 +12,23 @@ import (
 )
 
 type AddPerfectSongRehearsal struct {
-	repository         repository.SongRepository
-	songProcessor      processor.SongProcessor
-	transactionManager transaction.Manager
+	repository               repository.SongRepository
+	songProcessor            processor.SongProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
+	transactionManager       transaction.Manager
 }
 
 func NewAddPerfectSongRehearsal(
 	repository repository.SongRepository,
 	songProcessor processor.SongProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 	transactionManager transaction.Manager,
 ) AddPerfectSongRehearsal {
 	return AddPerfectSongRehearsal{
-		repository:         repository,
-		songProcessor:      songProcessor,
-		transactionManager: transactionManager,
+		repository:               repository,
+		songProcessor:            songProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
+		transactionManager:       transactionManager,
 	}
 }
 
@@ -44,18 +47,23 @@ func (a AddPerfectSongRehearsal) Handle(request requests.AddPerfectSongRehearsal
 		transactionSongSectionRepository := factory.NewSongSectionRepository()
 		transactionSongRepository := factory.NewSongRepository()
 
-		errC, isUpdated := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
+		errC, batchID := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
 		if errC != nil {
 			errCode = errC
 			return errCode.Error
 		}
 
-		if isUpdated {
+		if batchID != nil {
 			err := transactionSongRepository.UpdateWithAssociations(&song)
 			if err != nil {
 				errCode = wrapper.InternalServerError(err)
 				return err
 			}
+
+			errCode = a.practiceSessionProcessor.RecordPerfectRehearsal(song, *batchID, factory.NewPracticeSessionRepository())
+			if errCode != nil {
+				return errCode.Error
+			}
 		}
 		return nil
 	})
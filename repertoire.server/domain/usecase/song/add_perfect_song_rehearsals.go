@@ -8,23 +8,28 @@ import (
 	"repertoire/server/domain/processor"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+
+	"github.com/google/uuid"
 )
 
 type AddPerfectSongRehearsals struct {
-	repository         repository.SongRepository
-	songProcessor      processor.SongProcessor
-	transactionManager transaction.Manager
+	repository               repository.SongRepository
+	songProcessor            processor.SongProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
+	transactionManager       transaction.Manager
 }
 
 func NewAddPerfectSongRehearsals(
 	repository repository.SongRepository,
 	songProcessor processor.SongProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 	transactionManager transaction.Manager,
 ) AddPerfectSongRehearsals {
 	return AddPerfectSongRehearsals{
-		repository:         repository,
-		songProcessor:      songProcessor,
-		transactionManager: transactionManager,
+		repository:               repository,
+		songProcessor:            songProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
+		transactionManager:       transactionManager,
 	}
 }
 
@@ -44,14 +49,16 @@ func (a AddPerfectSongRehearsals) Handle(request requests.AddPerfectSongRehearsa
 		transactionSongRepository := factory.NewSongRepository()
 
 		var newSongs []model.Song
+		var batchIDs []uuid.UUID
 		for _, song := range songs {
-			errC, isUpdated := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
+			errC, batchID := a.songProcessor.AddPerfectRehearsal(&song, transactionSongSectionRepository)
 			if errC != nil {
 				errCode = errC
 				return errCode.Error
 			}
-			if isUpdated {
+			if batchID != nil {
 				newSongs = append(newSongs, song)
+				batchIDs = append(batchIDs, *batchID)
 			}
 		}
 
@@ -61,6 +68,14 @@ func (a AddPerfectSongRehearsals) Handle(request requests.AddPerfectSongRehearsa
 				errCode = wrapper.InternalServerError(err)
 				return err
 			}
+
+			transactionPracticeSessionRepository := factory.NewPracticeSessionRepository()
+			for i, song := range newSongs {
+				errCode = a.practiceSessionProcessor.RecordPerfectRehearsal(song, batchIDs[i], transactionPracticeSessionRepository)
+				if errCode != nil {
+					return errCode.Error
+				}
+			}
 		}
 		return nil
 	})
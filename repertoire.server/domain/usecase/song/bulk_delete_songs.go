@@ -3,6 +3,7 @@ package song
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,17 +17,20 @@ type BulkDeleteSongs struct {
 	repository              repository.SongRepository
 	playlistRepository      repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewBulkDeleteSongs(
 	repository repository.SongRepository,
 	playlistRepository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) BulkDeleteSongs {
 	return BulkDeleteSongs{
 		repository:              repository,
 		playlistRepository:      playlistRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -60,12 +64,17 @@ func (b BulkDeleteSongs) Handle(request requests.BulkDeleteSongsRequest) *wrappe
 		}
 	}
 
-	err = b.repository.Delete(request.IDs)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = b.messagePublisherService.Publish(topics.SongsDeletedTopic, songs)
+	err = b.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().Delete(request.IDs)
+		if err != nil {
+			return err
+		}
+		return b.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsDeletedTopic,
+			songs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -15,22 +16,22 @@ import (
 
 type CreateSong struct {
 	jwtService              service.JwtService
-	repository              repository.SongRepository
 	albumRepository         repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewCreateSong(
 	jwtService service.JwtService,
-	repository repository.SongRepository,
 	albumRepository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) CreateSong {
 	return CreateSong{
 		jwtService:              jwtService,
-		repository:              repository,
 		albumRepository:         albumRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -46,6 +47,7 @@ func (c CreateSong) Handle(request requests.CreateSongRequest, token string) (uu
 		Title:          request.Title,
 		Description:    request.Description,
 		Bpm:            request.Bpm,
+		Duration:       request.Duration,
 		SongsterrLink:  request.SongsterrLink,
 		YoutubeLink:    request.YoutubeLink,
 		ReleaseDate:    request.ReleaseDate,
@@ -66,12 +68,18 @@ func (c CreateSong) Handle(request requests.CreateSongRequest, token string) (uu
 		return uuid.Nil, errCode
 	}
 
-	err := c.repository.Create(&song)
-	if err != nil {
-		return uuid.Nil, wrapper.InternalServerError(err)
-	}
-
-	err = c.messagePublisherService.Publish(topics.SongCreatedTopic, song)
+	// the message is saved with the song, so that it cannot get lost
+	err := c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().Create(&song)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongCreatedTopic,
+			song,
+		)
+	})
 	if err != nil {
 		return uuid.Nil, wrapper.InternalServerError(err)
 	}
@@ -100,6 +108,9 @@ func (c CreateSong) createSections(request []requests.CreateSectionRequest, song
 			Name:              sectionRequest.Name,
 			Confidence:        model.DefaultSongSectionConfidence,
 			SongSectionTypeID: sectionRequest.TypeID,
+			StartOffset:       sectionRequest.StartOffset,
+			BarCount:          sectionRequest.BarCount,
+			TimeSignature:     sectionRequest.TimeSignature,
 			Order:             uint(i),
 			SongID:            songID,
 		})
@@ -3,6 +3,7 @@ package song
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -16,17 +17,20 @@ type DeleteImageFromSong struct {
 	repository              repository.SongRepository
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteImageFromSong(
 	repository repository.SongRepository,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteImageFromSong {
 	return DeleteImageFromSong{
 		repository:              repository,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,12 +53,17 @@ func (d DeleteImageFromSong) Handle(id uuid.UUID) *wrapper.ErrorCode {
 	}
 
 	song.ImageURL = nil
-	err = d.repository.Update(&song)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.SongsUpdatedTopic, []uuid.UUID{song.ID})
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().Update(&song)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{song.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,6 +3,7 @@ package song
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -17,17 +18,20 @@ type DeleteSong struct {
 	repository              repository.SongRepository
 	playlistRepository      repository.PlaylistRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteSong(
 	repository repository.SongRepository,
 	playlistRepository repository.PlaylistRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteSong {
 	return DeleteSong{
 		repository:              repository,
 		playlistRepository:      playlistRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -61,12 +65,18 @@ func (d DeleteSong) Handle(id uuid.UUID) *wrapper.ErrorCode {
 		}
 	}
 
-	err = d.repository.Delete([]uuid.UUID{id})
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.SongsDeletedTopic, []model.Song{song})
+	// the message is saved with the deletion, so that the storage and search engine get cleaned up for sure
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().Delete([]uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsDeletedTopic,
+			[]model.Song{song},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
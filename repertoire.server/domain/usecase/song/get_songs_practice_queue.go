@@ -0,0 +1,56 @@
+package song
+
+import (
+	"repertoire/server/api/requests"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"time"
+)
+
+type GetSongsPracticeQueue struct {
+	jwtService             service.JwtService
+	repository             repository.SongRepository
+	practiceQueueProcessor processor.PracticeQueueProcessor
+}
+
+func NewGetSongsPracticeQueue(
+	jwtService service.JwtService,
+	repository repository.SongRepository,
+	practiceQueueProcessor processor.PracticeQueueProcessor,
+) GetSongsPracticeQueue {
+	return GetSongsPracticeQueue{
+		jwtService:             jwtService,
+		repository:             repository,
+		practiceQueueProcessor: practiceQueueProcessor,
+	}
+}
+
+func (g GetSongsPracticeQueue) Handle(
+	request requests.GetSongsPracticeQueueRequest,
+	token string,
+) ([]model.PracticeQueueSong, *wrapper.ErrorCode) {
+	userID, errCode := g.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	var songs []model.Song
+	err := g.repository.GetAllByUserWithSections(&songs, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var budget *time.Duration
+	if request.Budget != nil {
+		budget = &[]time.Duration{time.Duration(*request.Budget) * time.Minute}[0]
+	}
+
+	queue := g.practiceQueueProcessor.BuildQueue(songs, budget)
+	if queue == nil {
+		queue = []model.PracticeQueueSong{}
+	}
+	return queue, nil
+}
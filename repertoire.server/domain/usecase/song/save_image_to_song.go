@@ -4,6 +4,7 @@ import (
 	"errors"
 	"mime/multipart"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/domain/provider"
@@ -21,6 +22,7 @@ type SaveImageToSong struct {
 	storageFilePathProvider provider.StorageFilePathProvider
 	storageService          service.StorageService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewSaveImageToSong(
@@ -28,12 +30,14 @@ func NewSaveImageToSong(
 	storageFilePathProvider provider.StorageFilePathProvider,
 	storageService service.StorageService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) SaveImageToSong {
 	return SaveImageToSong{
 		repository:              repository,
 		storageFilePathProvider: storageFilePathProvider,
 		storageService:          storageService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -55,20 +59,25 @@ func (s SaveImageToSong) Handle(file *multipart.FileHeader, id uuid.UUID) *wrapp
 	}
 
 	song.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetSongImagePath(file, song)
+	imagePath := s.storageFilePathProvider.GetSongImagePath(song)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode := s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	song.ImageURL = (*internal.FilePath)(&imagePath)
-	err = s.repository.Update(&song)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = s.messagePublisherService.Publish(topics.SongsUpdatedTopic, []uuid.UUID{song.ID})
+	err = s.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().Update(&song)
+		if err != nil {
+			return err
+		}
+		return s.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{song.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
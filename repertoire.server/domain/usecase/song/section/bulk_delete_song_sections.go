@@ -4,7 +4,10 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -13,17 +16,20 @@ import (
 )
 
 type BulkDeleteSongSections struct {
-	songSectionRepository repository.SongSectionRepository
-	songRepository        repository.SongRepository
+	songRepository          repository.SongRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewBulkDeleteSongSections(
-	songSectionRepository repository.SongSectionRepository,
 	songRepository repository.SongRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) BulkDeleteSongSections {
 	return BulkDeleteSongSections{
-		songSectionRepository: songSectionRepository,
-		songRepository:        songRepository,
+		songRepository:          songRepository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -72,11 +78,22 @@ func (b BulkDeleteSongSections) Handle(request requests.BulkDeleteSongSectionsRe
 		song.Progress = (song.Progress*float64(sectionsLength) - float64(totalProgress)) / float64(sectionsLength-sectionsDeletedLength)
 	}
 
-	err = b.songRepository.UpdateWithAssociations(&song)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-	err = b.songSectionRepository.Delete(request.IDs)
+	err = b.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateWithAssociations(&song)
+		if err != nil {
+			return err
+		}
+		err = factory.NewSongSectionRepository().Delete(request.IDs)
+		if err != nil {
+			return err
+		}
+
+		return b.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{request.SongID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
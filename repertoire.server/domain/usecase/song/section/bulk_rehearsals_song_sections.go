@@ -16,20 +16,26 @@ import (
 )
 
 type BulkRehearsalsSongSections struct {
-	songRepository     repository.SongRepository
-	transactionManager transaction.Manager
-	progressProcessor  processor.ProgressProcessor
+	songRepository           repository.SongRepository
+	userRepository           repository.UserRepository
+	transactionManager       transaction.Manager
+	progressProcessor        processor.ProgressProcessor
+	practiceSessionProcessor processor.PracticeSessionProcessor
 }
 
 func NewBulkRehearsalsSongSections(
 	songRepository repository.SongRepository,
+	userRepository repository.UserRepository,
 	transactionManager transaction.Manager,
 	progressProcessor processor.ProgressProcessor,
+	practiceSessionProcessor processor.PracticeSessionProcessor,
 ) BulkRehearsalsSongSections {
 	return BulkRehearsalsSongSections{
-		songRepository:     songRepository,
-		transactionManager: transactionManager,
-		progressProcessor:  progressProcessor,
+		songRepository:           songRepository,
+		userRepository:           userRepository,
+		transactionManager:       transactionManager,
+		progressProcessor:        progressProcessor,
+		practiceSessionProcessor: practiceSessionProcessor,
 	}
 }
 
@@ -59,65 +65,94 @@ func (b BulkRehearsalsSongSections) Handle(request requests.BulkRehearsalsSongSe
 		return wrapper.NotFoundError(errors.New("song sections not found"))
 	}
 
+	var user model.User
+	err = b.userRepository.Get(&user, song.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
 	var errCode *wrapper.ErrorCode
 	err = b.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
 		transactionSongSectionRepository := factory.NewSongSectionRepository()
 		transactionSongRepository := factory.NewSongRepository()
 
-		totalOldRehearsals := uint(0)
-		totalNewRehearsals := uint(0)
-		totalOldProgress := uint64(0)
-		totalNewProgress := uint64(0)
-		for i, section := range song.Sections {
+		// the history added by these rehearsals is grouped, so that it can be undone as a whole
+		batch := model.RehearsalBatch{
+			ID:                     uuid.New(),
+			SongID:                 song.ID,
+			PreviousLastTimePlayed: song.LastTimePlayed,
+		}
+
+		// add history of the rehearsals changes
+		var newHistory []model.SongSectionHistory
+		var rehearsedSections []model.PracticeSessionSection
+		for _, section := range song.Sections {
 			ind := slices.IndexFunc(request.Sections, func(sec requests.BulkRehearsalsSongSectionRequest) bool {
 				return sec.ID == section.ID
 			})
 			if ind == -1 || request.Sections[ind].Rehearsals == 0 {
 				continue
 			}
-			oldProgress := section.Progress
-			oldRehearsals := section.Rehearsals
-			newRehearsals := section.Rehearsals + request.Sections[ind].Rehearsals
-
-			// add history of the rehearsals change
-			newHistory := model.SongSectionHistory{
+			newHistory = append(newHistory, model.SongSectionHistory{
 				ID:            uuid.New(),
 				Property:      model.RehearsalsProperty,
-				From:          oldRehearsals,
-				To:            newRehearsals,
+				From:          section.Rehearsals,
+				To:            section.Rehearsals + request.Sections[ind].Rehearsals,
 				SongSectionID: section.ID,
-			}
-			err = transactionSongSectionRepository.CreateHistory(&newHistory)
-			if err != nil {
-				errCode = wrapper.InternalServerError(err)
-				return err
-			}
+				BatchID:       &batch.ID,
+			})
+			rehearsedSections = append(rehearsedSections, model.PracticeSessionSection{
+				SongSectionID: section.ID,
+				Occurrences:   request.Sections[ind].Rehearsals,
+			})
+		}
+
+		// means that no section will get updated
+		if len(newHistory) == 0 {
+			return nil
+		}
+
+		err = transactionSongSectionRepository.CreateRehearsalBatch(&batch)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+		err = transactionSongSectionRepository.CreateHistories(&newHistory)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+
+		totalOldRehearsals := uint(0)
+		totalNewRehearsals := uint(0)
+		totalOldProgress := uint64(0)
+		totalNewProgress := uint64(0)
+		for _, sectionHistory := range newHistory {
+			i := slices.IndexFunc(song.Sections, func(section model.SongSection) bool {
+				return section.ID == sectionHistory.SongSectionID
+			})
+			oldProgress := song.Sections[i].Progress
 
 			// update section's rehearsals score based on the history changes
 			var history []model.SongSectionHistory
-			err = transactionSongSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
+			err = transactionSongSectionRepository.GetHistory(&history, sectionHistory.SongSectionID, model.RehearsalsProperty)
 			if err != nil {
 				errCode = wrapper.InternalServerError(err)
 				return err
 			}
-			song.Sections[i].RehearsalsScore = b.progressProcessor.ComputeRehearsalsScore(history)
+			song.Sections[i].RehearsalsScore = b.progressProcessor.ComputeRehearsalsScore(history, user.ScoringStrategy)
 
 			// update section's progress (depends on the rehearsals score)
-			newProgress := b.progressProcessor.ComputeProgress(section)
+			newProgress := b.progressProcessor.ComputeProgress(song.Sections[i], user.ScoringStrategy)
 			song.Sections[i].Progress = newProgress
 
-			song.Sections[i].Rehearsals = newRehearsals
-			totalOldRehearsals += oldRehearsals
-			totalNewRehearsals += newRehearsals
+			song.Sections[i].Rehearsals = sectionHistory.To
+			totalOldRehearsals += sectionHistory.From
+			totalNewRehearsals += sectionHistory.To
 			totalOldProgress += oldProgress
 			totalNewProgress += newProgress
 		}
 
-		// means that no section got updated (because if it did, the total would be at least 1)
-		if totalNewRehearsals == 0 {
-			return nil
-		}
-
 		// update song's new rehearsals and progress medians
 		sectionsLength := len(song.Sections)
 		song.Rehearsals =
@@ -134,6 +169,11 @@ func (b BulkRehearsalsSongSections) Handle(request requests.BulkRehearsalsSongSe
 			return err
 		}
 
+		errCode = b.practiceSessionProcessor.RecordRehearsal(song, batch.ID, rehearsedSections, factory.NewPracticeSessionRepository())
+		if errCode != nil {
+			return errCode.Error
+		}
+
 		return nil
 	})
 
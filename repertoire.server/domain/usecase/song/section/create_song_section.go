@@ -4,7 +4,10 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
@@ -12,17 +15,23 @@ import (
 )
 
 type CreateSongSection struct {
-	songSectionRepository repository.SongSectionRepository
-	songRepository        repository.SongRepository
+	songSectionRepository   repository.SongSectionRepository
+	songRepository          repository.SongRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewCreateSongSection(
 	songSectionRepository repository.SongSectionRepository,
 	songRepository repository.SongRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) CreateSongSection {
 	return CreateSongSection{
-		songSectionRepository: songSectionRepository,
-		songRepository:        songRepository,
+		songSectionRepository:   songSectionRepository,
+		songRepository:          songRepository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -60,18 +69,31 @@ func (c CreateSongSection) Handle(request requests.CreateSongSectionRequest) *wr
 		SongID:            request.SongID,
 		BandMemberID:      request.BandMemberID,
 		InstrumentID:      request.InstrumentID,
+		StartOffset:       request.StartOffset,
+		BarCount:          request.BarCount,
+		TimeSignature:     request.TimeSignature,
 	}
-	err = c.songSectionRepository.Create(&section)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	// update song's new confidence, rehearsals and progress medians
 	song.Confidence = (song.Confidence*float64(sectionsCount) + float64(section.Confidence)) / float64(sectionsCount+1)
 	song.Rehearsals = (song.Rehearsals*float64(sectionsCount) + float64(section.Rehearsals)) / float64(sectionsCount+1)
 	song.Progress = (song.Progress*float64(sectionsCount) + float64(section.Progress)) / float64(sectionsCount+1)
 
-	err = c.songRepository.Update(&song)
+	err = c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongSectionRepository().Create(&section)
+		if err != nil {
+			return err
+		}
+		err = factory.NewSongRepository().Update(&song)
+		if err != nil {
+			return err
+		}
+
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{request.SongID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
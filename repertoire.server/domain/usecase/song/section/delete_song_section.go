@@ -3,7 +3,10 @@ package section
 import (
 	"errors"
 	"reflect"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -12,17 +15,20 @@ import (
 )
 
 type DeleteSongSection struct {
-	songSectionRepository repository.SongSectionRepository
-	songRepository        repository.SongRepository
+	songRepository          repository.SongRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteSongSection(
-	songSectionRepository repository.SongSectionRepository,
 	songRepository repository.SongRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteSongSection {
 	return DeleteSongSection{
-		songSectionRepository: songSectionRepository,
-		songRepository:        songRepository,
+		songRepository:          songRepository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -60,11 +66,22 @@ func (d DeleteSongSection) Handle(id uuid.UUID, songID uuid.UUID) *wrapper.Error
 		song.Progress = (song.Progress*float64(sectionsLength) - float64(song.Sections[index].Progress)) / float64(sectionsLength-1)
 	}
 
-	err = d.songRepository.UpdateWithAssociations(&song)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-	err = d.songSectionRepository.Delete([]uuid.UUID{id})
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateWithAssociations(&song)
+		if err != nil {
+			return err
+		}
+		err = factory.NewSongSectionRepository().Delete([]uuid.UUID{id})
+		if err != nil {
+			return err
+		}
+
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{songID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
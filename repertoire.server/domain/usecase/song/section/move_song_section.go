@@ -4,7 +4,10 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
@@ -12,12 +15,20 @@ import (
 )
 
 type MoveSongSection struct {
-	songRepository repository.SongRepository
+	songRepository          repository.SongRepository
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
-func NewMoveSongSection(repository repository.SongRepository) MoveSongSection {
+func NewMoveSongSection(
+	repository repository.SongRepository,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) MoveSongSection {
 	return MoveSongSection{
-		songRepository: repository,
+		songRepository:          repository,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -37,7 +48,17 @@ func (c MoveSongSection) Handle(request requests.MoveSongSectionRequest) *wrappe
 	}
 	song.Sections = c.move(song.Sections, index, overIndex)
 
-	err = c.songRepository.UpdateWithAssociations(&song)
+	err = c.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewSongRepository().UpdateWithAssociations(&song)
+		if err != nil {
+			return err
+		}
+		return c.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{song.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
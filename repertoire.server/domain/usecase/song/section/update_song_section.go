@@ -4,8 +4,12 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
 	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"time"
@@ -14,20 +18,29 @@ import (
 )
 
 type UpdateSongSection struct {
-	songSectionRepository repository.SongSectionRepository
-	songRepository        repository.SongRepository
-	progressProcessor     processor.ProgressProcessor
+	songSectionRepository   repository.SongSectionRepository
+	songRepository          repository.SongRepository
+	userRepository          repository.UserRepository
+	transactionManager      transaction.Manager
+	progressProcessor       processor.ProgressProcessor
+	messagePublisherService service.MessagePublisherService
 }
 
 func NewUpdateSongSection(
 	songSectionRepository repository.SongSectionRepository,
 	songRepository repository.SongRepository,
+	userRepository repository.UserRepository,
+	transactionManager transaction.Manager,
 	progressProcessor processor.ProgressProcessor,
+	messagePublisherService service.MessagePublisherService,
 ) UpdateSongSection {
 	return UpdateSongSection{
-		songSectionRepository: songSectionRepository,
-		songRepository:        songRepository,
-		progressProcessor:     progressProcessor,
+		songSectionRepository:   songSectionRepository,
+		songRepository:          songRepository,
+		userRepository:          userRepository,
+		transactionManager:      transactionManager,
+		progressProcessor:       progressProcessor,
+		messagePublisherService: messagePublisherService,
 	}
 }
 
@@ -49,8 +62,10 @@ func (u UpdateSongSection) Handle(request requests.UpdateSongSectionRequest) *wr
 	hasBandMemberChanged := section.BandMemberID != nil && request.BandMemberID == nil ||
 		section.BandMemberID == nil && request.BandMemberID != nil ||
 		section.BandMemberID != nil && request.BandMemberID != nil && *section.BandMemberID != *request.BandMemberID
+	hasSearchableChanged := section.Name != request.Name || section.SongSectionTypeID != request.TypeID
 
 	var song model.Song
+	var user model.User
 	var sectionsCount int64
 	if hasRehearsalsChanged || hasConfidenceChanged {
 		err = u.songRepository.Get(&song, section.SongID)
@@ -61,6 +76,10 @@ func (u UpdateSongSection) Handle(request requests.UpdateSongSectionRequest) *wr
 		if err != nil {
 			return wrapper.InternalServerError(err)
 		}
+		err = u.userRepository.Get(&user, song.UserID)
+		if err != nil {
+			return wrapper.InternalServerError(err)
+		}
 	}
 
 	if hasBandMemberChanged && request.BandMemberID != nil {
@@ -73,35 +92,105 @@ func (u UpdateSongSection) Handle(request requests.UpdateSongSectionRequest) *wr
 		}
 	}
 
-	if hasRehearsalsChanged {
-		errCode := u.rehearsalsHasChanged(&section, request.Rehearsals, sectionsCount, &song)
-		if errCode != nil {
-			return errCode
+	var errCode *wrapper.ErrorCode
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		transactionSongSectionRepository := factory.NewSongSectionRepository()
+		transactionSongRepository := factory.NewSongRepository()
+
+		// add history of the rehearsals and confidence changes
+		var newHistory []model.SongSectionHistory
+		if hasRehearsalsChanged {
+			newHistory = append(newHistory, model.SongSectionHistory{
+				ID:            uuid.New(),
+				Property:      model.RehearsalsProperty,
+				From:          section.Rehearsals,
+				To:            request.Rehearsals,
+				SongSectionID: section.ID,
+			})
+		}
+		if hasConfidenceChanged {
+			newHistory = append(newHistory, model.SongSectionHistory{
+				ID:            uuid.New(),
+				Property:      model.ConfidenceProperty,
+				From:          section.Confidence,
+				To:            request.Confidence,
+				SongSectionID: section.ID,
+			})
+		}
+		if len(newHistory) > 0 {
+			err := transactionSongSectionRepository.CreateHistories(&newHistory)
+			if err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
 		}
-	}
 
-	if hasConfidenceChanged {
-		errCode := u.confidenceHasChanged(&section, request.Confidence, sectionsCount, &song)
-		if errCode != nil {
-			return errCode
+		if hasRehearsalsChanged {
+			errCode = u.rehearsalsHasChanged(
+				&section,
+				request.Rehearsals,
+				sectionsCount,
+				&song,
+				user.ScoringStrategy,
+				transactionSongSectionRepository,
+			)
+			if errCode != nil {
+				return errCode.Error
+			}
 		}
-	}
 
-	section.Name = request.Name
-	section.Confidence = request.Confidence
-	section.Rehearsals = request.Rehearsals
-	section.SongSectionTypeID = request.TypeID
-	section.BandMemberID = request.BandMemberID
-	section.InstrumentID = request.InstrumentID
+		if hasConfidenceChanged {
+			errCode = u.confidenceHasChanged(
+				&section,
+				request.Confidence,
+				sectionsCount,
+				&song,
+				user.ScoringStrategy,
+				transactionSongSectionRepository,
+			)
+			if errCode != nil {
+				return errCode.Error
+			}
+		}
 
-	if hasRehearsalsChanged || hasConfidenceChanged {
-		err = u.songRepository.Update(&song)
+		section.Name = request.Name
+		section.Confidence = request.Confidence
+		section.Rehearsals = request.Rehearsals
+		section.SongSectionTypeID = request.TypeID
+		section.BandMemberID = request.BandMemberID
+		section.InstrumentID = request.InstrumentID
+		section.StartOffset = request.StartOffset
+		section.BarCount = request.BarCount
+		section.TimeSignature = request.TimeSignature
+
+		if hasRehearsalsChanged || hasConfidenceChanged {
+			err := transactionSongRepository.Update(&song)
+			if err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+		err := transactionSongSectionRepository.Update(&section)
 		if err != nil {
-			return wrapper.InternalServerError(err)
+			errCode = wrapper.InternalServerError(err)
+			return err
 		}
-	}
-	err = u.songSectionRepository.Update(&section)
+
+		// the name and the type are part of the search documents of the section and its song
+		if hasSearchableChanged {
+			return u.messagePublisherService.PublishWithinTransaction(
+				factory.NewOutboxRepository(),
+				topics.SongsUpdatedTopic,
+				[]uuid.UUID{section.SongID},
+			)
+		}
+
+		return nil
+	})
 	if err != nil {
+		if errCode != nil {
+			return errCode
+		}
 		return wrapper.InternalServerError(err)
 	}
 
@@ -113,35 +202,24 @@ func (u UpdateSongSection) rehearsalsHasChanged(
 	newRehearsals uint,
 	sectionsCount int64,
 	song *model.Song,
+	strategy enums.ScoringStrategy,
+	songSectionRepository repository.SongSectionRepository,
 ) *wrapper.ErrorCode {
-	// add history of the rehearsals change
-	newHistory := model.SongSectionHistory{
-		ID:            uuid.New(),
-		Property:      model.RehearsalsProperty,
-		From:          section.Rehearsals,
-		To:            newRehearsals,
-		SongSectionID: section.ID,
-	}
-	err := u.songSectionRepository.CreateHistory(&newHistory)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	// remove section's rehearsals and progress from the song's median
 	song.Rehearsals = song.Rehearsals*float64(sectionsCount) - float64(section.Rehearsals)
 	song.Progress = song.Progress*float64(sectionsCount) - float64(section.Progress)
 
 	// update section's rehearsals score based on the history changes
 	var history []model.SongSectionHistory
-	err = u.songSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
+	err := songSectionRepository.GetHistory(&history, section.ID, model.RehearsalsProperty)
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	section.RehearsalsScore = u.progressProcessor.ComputeRehearsalsScore(history)
+	section.RehearsalsScore = u.progressProcessor.ComputeRehearsalsScore(history, strategy)
 
 	// update section's progress (depends on the rehearsals score)
-	section.Progress = u.progressProcessor.ComputeProgress(*section)
+	section.Progress = u.progressProcessor.ComputeProgress(*section, strategy)
 
 	// update the song's rehearsals and progress median with new section values
 	song.Rehearsals = (song.Rehearsals + float64(newRehearsals)) / float64(sectionsCount)
@@ -158,35 +236,24 @@ func (u UpdateSongSection) confidenceHasChanged(
 	newConfidence uint,
 	sectionsCount int64,
 	song *model.Song,
+	strategy enums.ScoringStrategy,
+	songSectionRepository repository.SongSectionRepository,
 ) *wrapper.ErrorCode {
-	// add history of the confidence change
-	newHistory := model.SongSectionHistory{
-		ID:            uuid.New(),
-		Property:      model.ConfidenceProperty,
-		From:          section.Confidence,
-		To:            newConfidence,
-		SongSectionID: section.ID,
-	}
-	err := u.songSectionRepository.CreateHistory(&newHistory)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
 	// remove section's confidence and progress from the song's median
 	song.Confidence = song.Confidence*float64(sectionsCount) - float64(section.Confidence)
 	song.Progress = song.Progress*float64(sectionsCount) - float64(section.Progress)
 
 	// update section's confidence score based on the history changes
 	var history []model.SongSectionHistory
-	err = u.songSectionRepository.GetHistory(&history, section.ID, model.ConfidenceProperty)
+	err := songSectionRepository.GetHistory(&history, section.ID, model.ConfidenceProperty)
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	section.ConfidenceScore = u.progressProcessor.ComputeConfidenceScore(history)
+	section.ConfidenceScore = u.progressProcessor.ComputeConfidenceScore(history, strategy)
 
 	// update section's progress (depends on the confidence score)
-	section.Progress = u.progressProcessor.ComputeProgress(*section)
+	section.Progress = u.progressProcessor.ComputeProgress(*section, strategy)
 
 	// update the song's confidence and progress median with new section values
 	song.Confidence = (song.Confidence + float64(newConfidence)) / float64(sectionsCount)
@@ -0,0 +1,138 @@
+package song
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/repository"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"slices"
+)
+
+type UndoSongRehearsal struct {
+	songRepository     repository.SongRepository
+	userRepository     repository.UserRepository
+	transactionManager transaction.Manager
+	progressProcessor  processor.ProgressProcessor
+}
+
+func NewUndoSongRehearsal(
+	songRepository repository.SongRepository,
+	userRepository repository.UserRepository,
+	transactionManager transaction.Manager,
+	progressProcessor processor.ProgressProcessor,
+) UndoSongRehearsal {
+	return UndoSongRehearsal{
+		songRepository:     songRepository,
+		userRepository:     userRepository,
+		transactionManager: transactionManager,
+		progressProcessor:  progressProcessor,
+	}
+}
+
+func (u UndoSongRehearsal) Handle(request requests.UndoSongRehearsalRequest) *wrapper.ErrorCode {
+	var song model.Song
+	err := u.songRepository.GetWithSections(&song, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(song).IsZero() {
+		return wrapper.NotFoundError(errors.New("song not found"))
+	}
+
+	var user model.User
+	err = u.userRepository.Get(&user, song.UserID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	var errCode *wrapper.ErrorCode
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		transactionSongSectionRepository := factory.NewSongSectionRepository()
+		transactionSongRepository := factory.NewSongRepository()
+
+		var batch model.RehearsalBatch
+		err := transactionSongSectionRepository.GetLastRehearsalBatch(&batch, song.ID)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+		if reflect.ValueOf(batch).IsZero() {
+			errCode = wrapper.NotFoundError(errors.New("there is no rehearsal to undo"))
+			return errCode.Error
+		}
+
+		// the history and the practice session songs of the batch go away with it
+		err = transactionSongSectionRepository.DeleteRehearsalBatch(batch.ID)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+
+		for _, batchHistory := range batch.History {
+			i := slices.IndexFunc(song.Sections, func(section model.SongSection) bool {
+				return section.ID == batchHistory.SongSectionID
+			})
+			if i == -1 {
+				continue
+			}
+
+			// revert only the change made by the batch, in case the rehearsals got changed afterward
+			rehearsedCount := batchHistory.To - batchHistory.From
+			if song.Sections[i].Rehearsals < rehearsedCount {
+				song.Sections[i].Rehearsals = 0
+			} else {
+				song.Sections[i].Rehearsals -= rehearsedCount
+			}
+
+			// update section's rehearsals score based on the remaining history and update the progress too
+			var history []model.SongSectionHistory
+			err = transactionSongSectionRepository.GetHistory(&history, song.Sections[i].ID, model.RehearsalsProperty)
+			if err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+			song.Sections[i].RehearsalsScore = u.progressProcessor.ComputeRehearsalsScore(history, user.ScoringStrategy)
+			song.Sections[i].Progress = u.progressProcessor.ComputeProgress(song.Sections[i], user.ScoringStrategy)
+
+			err = transactionSongSectionRepository.Update(&song.Sections[i])
+			if err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		// update song's rehearsals and progress medians + restore the last time played
+		if len(song.Sections) > 0 {
+			var totalRehearsals float64 = 0
+			var totalProgress float64 = 0
+			for _, section := range song.Sections {
+				totalRehearsals += float64(section.Rehearsals)
+				totalProgress += float64(section.Progress)
+			}
+			song.Rehearsals = totalRehearsals / float64(len(song.Sections))
+			song.Progress = totalProgress / float64(len(song.Sections))
+		}
+		song.LastTimePlayed = batch.PreviousLastTimePlayed
+
+		// the whole song is saved, as the last time played might have to be restored to nothing
+		err = transactionSongRepository.Update(&song)
+		if err != nil {
+			errCode = wrapper.InternalServerError(err)
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errCode != nil {
+			return errCode
+		}
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
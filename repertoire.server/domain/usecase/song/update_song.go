@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -17,17 +18,20 @@ type UpdateSong struct {
 	repository              repository.SongRepository
 	albumRepository         repository.AlbumRepository
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewUpdateSong(
 	repository repository.SongRepository,
 	albumRepository repository.AlbumRepository,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) UpdateSong {
 	return UpdateSong{
 		repository:              repository,
 		albumRepository:         albumRepository,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -64,31 +68,39 @@ func (u UpdateSong) Handle(request requests.UpdateSongRequest) *wrapper.ErrorCod
 		}
 	}
 
-	if albumHasChanged {
-		errCode := u.reorderAlbumSongs(request, &song)
-		if errCode != nil {
-			return errCode
-		}
-	}
-
 	song.Title = request.Title
 	song.Description = request.Description
 	song.IsRecorded = request.IsRecorded
 	song.Bpm = request.Bpm
+	song.Duration = request.Duration
 	song.SongsterrLink = request.SongsterrLink
 	song.YoutubeLink = request.YoutubeLink
 	song.ReleaseDate = request.ReleaseDate
 	song.Difficulty = request.Difficulty
 	song.GuitarTuningID = request.GuitarTuningID
 	song.ArtistID = request.ArtistID
-	song.AlbumID = request.AlbumID
 
-	err = u.repository.Update(&song)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		songRepository := factory.NewSongRepository()
 
-	err = u.messagePublisherService.Publish(topics.SongsUpdatedTopic, []uuid.UUID{song.ID})
+		if albumHasChanged {
+			err := u.reorderAlbumSongs(songRepository, request, &song)
+			if err != nil {
+				return err
+			}
+		}
+		song.AlbumID = request.AlbumID
+
+		err := songRepository.Update(&song)
+		if err != nil {
+			return err
+		}
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			[]uuid.UUID{song.ID},
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -96,13 +108,17 @@ func (u UpdateSong) Handle(request requests.UpdateSongRequest) *wrapper.ErrorCod
 	return nil
 }
 
-func (u UpdateSong) reorderAlbumSongs(request requests.UpdateSongRequest, song *model.Song) *wrapper.ErrorCode {
+func (u UpdateSong) reorderAlbumSongs(
+	songRepository repository.SongRepository,
+	request requests.UpdateSongRequest,
+	song *model.Song,
+) error {
 	// reorder old album, if any
 	if song.AlbumID != nil {
 		var songs []model.Song
-		err := u.repository.GetAllByAlbumAndTrackNo(&songs, *song.AlbumID, *song.AlbumTrackNo)
+		err := songRepository.GetAllByAlbumAndTrackNo(&songs, *song.AlbumID, *song.AlbumTrackNo)
 		if err != nil {
-			return wrapper.InternalServerError(err)
+			return err
 		}
 
 		for i := range songs {
@@ -110,9 +126,9 @@ func (u UpdateSong) reorderAlbumSongs(request requests.UpdateSongRequest, song *
 			songs[i].AlbumTrackNo = &trackNo
 		}
 
-		err = u.repository.UpdateAll(&songs)
+		err = songRepository.UpdateAll(&songs)
 		if err != nil {
-			return wrapper.InternalServerError(err)
+			return err
 		}
 	}
 
@@ -122,9 +138,9 @@ func (u UpdateSong) reorderAlbumSongs(request requests.UpdateSongRequest, song *
 	}
 
 	var songsCount int64
-	err := u.repository.CountByAlbum(&songsCount, *request.AlbumID)
+	err := songRepository.CountByAlbum(&songsCount, *request.AlbumID)
 	if err != nil {
-		return wrapper.InternalServerError(err)
+		return err
 	}
 
 	trackNo := uint(songsCount) + 1
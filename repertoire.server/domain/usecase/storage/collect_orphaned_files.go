@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"net/http"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// the age that the unreferenced files must have to be collected, when none is configured,
+// so that the files that have just been uploaded, but are not saved on their entity yet, are not collected
+const defaultOrphanedFilesGracePeriod = 24 * time.Hour
+
+type CollectOrphanedFiles struct {
+	userRepository repository.UserRepository
+	storageService service.StorageService
+	env            internal.Env
+}
+
+func NewCollectOrphanedFiles(
+	userRepository repository.UserRepository,
+	storageService service.StorageService,
+	env internal.Env,
+) CollectOrphanedFiles {
+	return CollectOrphanedFiles{
+		userRepository: userRepository,
+		storageService: storageService,
+		env:            env,
+	}
+}
+
+// Handle collects the files that are not referenced anymore of the given user, or of all users when the ID is missing.
+// The files are quarantined by the storage, unless the configured mode is to delete them,
+// while the dry run only reports them
+func (c CollectOrphanedFiles) Handle(userID *uuid.UUID, dryRun bool) (model.OrphanedFilesCollection, *wrapper.ErrorCode) {
+	gracePeriod := defaultOrphanedFilesGracePeriod
+	if c.env.OrphanedFilesGracePeriod != "" {
+		var err error
+		gracePeriod, err = time.ParseDuration(c.env.OrphanedFilesGracePeriod)
+		if err != nil {
+			return model.OrphanedFilesCollection{}, wrapper.InternalServerError(err)
+		}
+	}
+
+	var userIDs []uuid.UUID
+	if userID != nil {
+		userIDs = []uuid.UUID{*userID}
+	} else {
+		err := c.userRepository.GetAllIDs(&userIDs)
+		if err != nil {
+			return model.OrphanedFilesCollection{}, wrapper.InternalServerError(err)
+		}
+	}
+
+	result := model.OrphanedFilesCollection{DryRun: dryRun, Files: []model.OrphanedFile{}}
+	for _, id := range userIDs {
+		files, errCode := c.getOrphanedFiles(id, time.Now().Add(-gracePeriod))
+		if errCode != nil {
+			return result, errCode
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		if !dryRun {
+			errCode = c.collect(files)
+			if errCode != nil {
+				return result, errCode
+			}
+		}
+		for _, file := range files {
+			result.Size += file.Size
+		}
+		result.Files = append(result.Files, files...)
+	}
+
+	return result, nil
+}
+
+// getOrphanedFiles returns the files of the user that are not referenced anymore, and have not been modified since
+func (c CollectOrphanedFiles) getOrphanedFiles(userID uuid.UUID, modifiedBefore time.Time) ([]model.OrphanedFile, *wrapper.ErrorCode) {
+	var imagePaths []string
+	err := c.userRepository.GetImagePaths(&imagePaths, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	referencedPaths := make(map[string]bool, len(imagePaths))
+	for _, imagePath := range imagePaths {
+		filePath := internal.FilePath(imagePath)
+		referencedPaths[string(*filePath.StripURL())] = true
+	}
+
+	// the resized copies of the images are counted with their original by the storage, so they are not listed here
+	usage, errCode := c.storageService.GetUsage(userID)
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	var files []model.OrphanedFile
+	for _, file := range usage.Files {
+		if referencedPaths[file.Path] || !file.ModTime.Before(modifiedBefore) {
+			continue
+		}
+		files = append(files, model.OrphanedFile{
+			Path:       file.Path,
+			Size:       file.Size,
+			ModifiedAt: file.ModTime,
+		})
+	}
+	return files, nil
+}
+
+func (c CollectOrphanedFiles) collect(files []model.OrphanedFile) *wrapper.ErrorCode {
+	if c.env.OrphanedFilesCollectionMode != internal.DeleteOrphanedFilesCollectionMode {
+		filePaths := make([]string, len(files))
+		for i, file := range files {
+			filePaths[i] = file.Path
+		}
+		return c.storageService.QuarantineFiles(filePaths)
+	}
+
+	for _, file := range files {
+		errCode := c.storageService.DeleteFile(internal.FilePath(file.Path))
+		// the file might have been deleted in the meantime
+		if errCode != nil && errCode.Code != http.StatusNotFound {
+			return errCode
+		}
+	}
+	return nil
+}
@@ -2,8 +2,10 @@ package role
 
 import (
 	"errors"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -12,17 +14,26 @@ import (
 )
 
 type DeleteBandMemberRole struct {
-	repository repository.UserDataRepository
-	jwtService service.JwtService
+	repository              repository.UserDataRepository
+	artistRepository        repository.ArtistRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteBandMemberRole(
 	repository repository.UserDataRepository,
+	artistRepository repository.ArtistRepository,
 	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteBandMemberRole {
 	return DeleteBandMemberRole{
-		repository: repository,
-		jwtService: jwtService,
+		repository:              repository,
+		artistRepository:        artistRepository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,12 +60,39 @@ func (d DeleteBandMemberRole) Handle(id uuid.UUID, token string) *wrapper.ErrorC
 		roles[i].Order = roles[i].Order - 1
 	}
 
-	err = d.repository.UpdateAllBandMemberRoles(&roles)
+	// the band members lose this role, so they have to be updated
+	var artistIDs []uuid.UUID
+	err = d.artistRepository.GetAllIDsByBandMemberRole(&artistIDs, id)
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = d.repository.DeleteBandMemberRole(id)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		userDataRepository := factory.NewUserDataRepository()
+
+		err := userDataRepository.UpdateAllBandMemberRoles(&roles)
+		if err != nil {
+			return err
+		}
+
+		err = userDataRepository.DeleteBandMemberRole(id)
+		if err != nil {
+			return err
+		}
+
+		outboxRepository := factory.NewOutboxRepository()
+		for _, artistID := range artistIDs {
+			err = d.messagePublisherService.PublishWithinTransaction(
+				outboxRepository,
+				topics.ArtistUpdatedTopic,
+				artistID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -3,8 +3,10 @@ package role
 import (
 	"errors"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
@@ -12,17 +14,26 @@ import (
 )
 
 type MoveBandMemberRole struct {
-	repository repository.UserDataRepository
-	jwtService service.JwtService
+	repository              repository.UserDataRepository
+	artistRepository        repository.ArtistRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewMoveBandMemberRole(
 	repository repository.UserDataRepository,
+	artistRepository repository.ArtistRepository,
 	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) MoveBandMemberRole {
 	return MoveBandMemberRole{
-		repository: repository,
-		jwtService: jwtService,
+		repository:              repository,
+		artistRepository:        artistRepository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -44,7 +55,32 @@ func (m MoveBandMemberRole) Handle(request requests.MoveBandMemberRoleRequest, t
 	}
 	types = m.move(types, index, overIndex)
 
-	err = m.repository.UpdateAllBandMemberRoles(&types)
+	// the roles of the band members are ordered, so the band members having the moved role have to be updated
+	var artistIDs []uuid.UUID
+	err = m.artistRepository.GetAllIDsByBandMemberRole(&artistIDs, request.ID)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	err = m.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewUserDataRepository().UpdateAllBandMemberRoles(&types)
+		if err != nil {
+			return err
+		}
+
+		outboxRepository := factory.NewOutboxRepository()
+		for _, artistID := range artistIDs {
+			err = m.messagePublisherService.PublishWithinTransaction(
+				outboxRepository,
+				topics.ArtistUpdatedTopic,
+				artistID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -2,8 +2,10 @@ package tuning
 
 import (
 	"errors"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -12,14 +14,26 @@ import (
 )
 
 type DeleteGuitarTuning struct {
-	repository repository.UserDataRepository
-	jwtService service.JwtService
+	repository              repository.UserDataRepository
+	songRepository          repository.SongRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
-func NewDeleteGuitarTuning(repository repository.UserDataRepository, jwtService service.JwtService) DeleteGuitarTuning {
+func NewDeleteGuitarTuning(
+	repository repository.UserDataRepository,
+	songRepository repository.SongRepository,
+	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) DeleteGuitarTuning {
 	return DeleteGuitarTuning{
-		repository: repository,
-		jwtService: jwtService,
+		repository:              repository,
+		songRepository:          songRepository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -46,12 +60,35 @@ func (d DeleteGuitarTuning) Handle(id uuid.UUID, token string) *wrapper.ErrorCod
 		tunings[i].Order = tunings[i].Order - 1
 	}
 
-	err = d.repository.UpdateAllGuitarTunings(&tunings)
+	// the songs lose their guitar tuning, so they have to be updated
+	var songIDs []uuid.UUID
+	err = d.songRepository.GetAllIDsByGuitarTuning(&songIDs, id)
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = d.repository.DeleteGuitarTuning(id)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		userDataRepository := factory.NewUserDataRepository()
+
+		err := userDataRepository.UpdateAllGuitarTunings(&tunings)
+		if err != nil {
+			return err
+		}
+
+		err = userDataRepository.DeleteGuitarTuning(id)
+		if err != nil {
+			return err
+		}
+
+		if len(songIDs) == 0 {
+			return nil
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			songIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -2,8 +2,10 @@ package types
 
 import (
 	"errors"
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"slices"
@@ -12,17 +14,26 @@ import (
 )
 
 type DeleteSongSectionType struct {
-	repository repository.UserDataRepository
-	jwtService service.JwtService
+	repository              repository.UserDataRepository
+	songRepository          repository.SongRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteSongSectionType(
 	repository repository.UserDataRepository,
+	songRepository repository.SongRepository,
 	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteSongSectionType {
 	return DeleteSongSectionType{
-		repository: repository,
-		jwtService: jwtService,
+		repository:              repository,
+		songRepository:          songRepository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -49,12 +60,35 @@ func (d DeleteSongSectionType) Handle(id uuid.UUID, token string) *wrapper.Error
 		types[i].Order = types[i].Order - 1
 	}
 
-	err = d.repository.UpdateAllSectionTypes(&types)
+	// the songs lose the sections of this type, so they have to be updated
+	var songIDs []uuid.UUID
+	err = d.songRepository.GetAllIDsBySectionType(&songIDs, id)
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
 
-	err = d.repository.DeleteSectionType(id)
+	err = d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		userDataRepository := factory.NewUserDataRepository()
+
+		err := userDataRepository.UpdateAllSectionTypes(&types)
+		if err != nil {
+			return err
+		}
+
+		err = userDataRepository.DeleteSectionType(id)
+		if err != nil {
+			return err
+		}
+
+		if len(songIDs) == 0 {
+			return nil
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.SongsUpdatedTopic,
+			songIDs,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"repertoire/server/data/database/transaction"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/message/topics"
@@ -11,17 +12,20 @@ type DeleteUser struct {
 	repository              repository.UserRepository
 	jwtService              service.JwtService
 	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
 }
 
 func NewDeleteUser(
 	repository repository.UserRepository,
 	jwtService service.JwtService,
 	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
 ) DeleteUser {
 	return DeleteUser{
 		repository:              repository,
 		jwtService:              jwtService,
 		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
 	}
 }
 
@@ -31,12 +35,17 @@ func (d DeleteUser) Handle(token string) *wrapper.ErrorCode {
 		return errCode
 	}
 
-	err := d.repository.Delete(id)
-	if err != nil {
-		return wrapper.InternalServerError(err)
-	}
-
-	err = d.messagePublisherService.Publish(topics.UserDeletedTopic, id)
+	err := d.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewUserRepository().Delete(id)
+		if err != nil {
+			return err
+		}
+		return d.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.UserDeletedTopic,
+			id,
+		)
+	})
 	if err != nil {
 		return wrapper.InternalServerError(err)
 	}
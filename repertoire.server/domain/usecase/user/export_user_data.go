@@ -0,0 +1,79 @@
+package user
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+// libraries with more songs than this are exported in the background,
+// and the download link is sent to the user once the archive is ready
+const backgroundExportSongsThreshold int64 = 250
+
+type ExportUserData struct {
+	repository              repository.UserRepository
+	songRepository          repository.SongRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	userDataExportProcessor processor.UserDataExportProcessor
+}
+
+func NewExportUserData(
+	repository repository.UserRepository,
+	songRepository repository.SongRepository,
+	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	userDataExportProcessor processor.UserDataExportProcessor,
+) ExportUserData {
+	return ExportUserData{
+		repository:              repository,
+		songRepository:          songRepository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		userDataExportProcessor: userDataExportProcessor,
+	}
+}
+
+// Handle streams the archive to the writer returned by getWriter,
+// which is only requested once it is certain that the export is not queued
+func (e ExportUserData) Handle(token string, getWriter func() io.Writer) (isQueued bool, errCode *wrapper.ErrorCode) {
+	id, errCode := e.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return false, errCode
+	}
+
+	var songsCount int64
+	err := e.songRepository.GetAllByUserCount(&songsCount, id, []string{})
+	if err != nil {
+		return false, wrapper.InternalServerError(err)
+	}
+	if songsCount > backgroundExportSongsThreshold {
+		err = e.messagePublisherService.Publish(topics.UserDataExportRequestedTopic, id)
+		if err != nil {
+			return false, wrapper.InternalServerError(err)
+		}
+		return true, nil
+	}
+
+	var user model.User
+	err = e.repository.GetWithAllData(&user, id)
+	if err != nil {
+		return false, wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(user).IsZero() {
+		return false, wrapper.NotFoundError(errors.New("user not found"))
+	}
+
+	errCode = e.userDataExportProcessor.Export(getWriter(), user)
+	if errCode != nil {
+		return false, errCode
+	}
+
+	return false, nil
+}
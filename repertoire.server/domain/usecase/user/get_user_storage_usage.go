@@ -0,0 +1,58 @@
+package user
+
+import (
+	"repertoire/server/data/service"
+	"repertoire/server/domain/provider"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type GetUserStorageUsage struct {
+	jwtService              service.JwtService
+	storageService          service.StorageService
+	storageFilePathProvider provider.StorageFilePathProvider
+}
+
+func NewGetUserStorageUsage(
+	jwtService service.JwtService,
+	storageService service.StorageService,
+	storageFilePathProvider provider.StorageFilePathProvider,
+) GetUserStorageUsage {
+	return GetUserStorageUsage{
+		jwtService:              jwtService,
+		storageService:          storageService,
+		storageFilePathProvider: storageFilePathProvider,
+	}
+}
+
+func (g GetUserStorageUsage) Handle(token string) (model.StorageUsage, *wrapper.ErrorCode) {
+	id, errCode := g.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return model.StorageUsage{}, errCode
+	}
+
+	usage, errCode := g.storageService.GetUsage(id)
+	if errCode != nil {
+		return model.StorageUsage{}, errCode
+	}
+
+	result := model.StorageUsage{Size: usage.Size, Quota: usage.Quota}
+	for _, file := range usage.Files {
+		switch g.storageFilePathProvider.GetStorageUsageCategory(file.Path) {
+		case enums.AlbumStorageUsage:
+			result.Albums += file.Size
+		case enums.ArtistStorageUsage:
+			result.Artists += file.Size
+		case enums.BandMemberStorageUsage:
+			result.BandMembers += file.Size
+		case enums.PlaylistStorageUsage:
+			result.Playlists += file.Size
+		case enums.SongStorageUsage:
+			result.Songs += file.Size
+		default:
+			result.Others += file.Size
+		}
+	}
+	return result, nil
+}
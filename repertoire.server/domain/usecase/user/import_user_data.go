@@ -0,0 +1,932 @@
+package user
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"path"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/provider"
+	"repertoire/server/internal"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ImportUserData struct {
+	jwtService              service.JwtService
+	storageFilePathProvider provider.StorageFilePathProvider
+	storageService          service.StorageService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
+}
+
+func NewImportUserData(
+	jwtService service.JwtService,
+	storageFilePathProvider provider.StorageFilePathProvider,
+	storageService service.StorageService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) ImportUserData {
+	return ImportUserData{
+		jwtService:              jwtService,
+		storageFilePathProvider: storageFilePathProvider,
+		storageService:          storageService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
+	}
+}
+
+// the entries of the archive are read in memory, so their sizes are capped (e.g. against zip bombs)
+const (
+	maxImportedDataFileSize = 50 << 20
+	maxImportedImageSize    = 10 << 20
+)
+
+type importedArchive struct {
+	artists         []model.ExportedArtist
+	albums          []model.ExportedAlbum
+	songs           []model.ExportedSong
+	playlists       []model.ExportedPlaylist
+	sessions        []model.ExportedPracticeSession
+	setlists        []model.ExportedSetlist
+	bandMemberRoles []model.ExportedUserDataItem
+	guitarTunings   []model.ExportedUserDataItem
+	instruments     []model.ExportedUserDataItem
+	sectionTypes    []model.ExportedUserDataItem
+	images          map[string]*zip.File
+	pendingImages   []pendingImage
+}
+
+// pendingImage is an image of the archive that is uploaded to the storage only after the import is committed
+type pendingImage struct {
+	content  []byte
+	fileName string
+	path     string
+}
+
+func (i ImportUserData) Handle(file *multipart.FileHeader, token string) *wrapper.ErrorCode {
+	userID, errCode := i.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return errCode
+	}
+
+	// the images are read from the archive along with the entities, so it stays open
+	f, err := file.Open()
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	archive, errCode := i.readArchive(f, file.Size)
+	if errCode != nil {
+		return errCode
+	}
+
+	// every identifier from the archive is replaced with a new one,
+	// or with the one of an existing user data entry that has the same name
+	ids := make(map[uuid.UUID]uuid.UUID)
+
+	var artists []model.Artist
+	var albums []model.Album
+	var songs []model.Song
+	var playlists []model.Playlist
+	err = i.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		var roles []model.BandMemberRole
+		roles, errCode = i.mergeUserData(factory.NewUserDataRepository(), archive, userID, ids)
+		if errCode != nil {
+			return errCode.Error
+		}
+
+		artistRepository := factory.NewArtistRepository()
+		artists, errCode = i.importArtists(&archive, userID, ids, roles)
+		if errCode != nil {
+			return errCode.Error
+		}
+		for _, artist := range artists {
+			if err := artistRepository.Create(&artist); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		albumRepository := factory.NewAlbumRepository()
+		albums, errCode = i.importAlbums(&archive, userID, ids)
+		if errCode != nil {
+			return errCode.Error
+		}
+		for _, album := range albums {
+			if err := albumRepository.Create(&album); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		songRepository := factory.NewSongRepository()
+		songs, errCode = i.importSongs(&archive, userID, ids)
+		if errCode != nil {
+			return errCode.Error
+		}
+		for _, song := range songs {
+			if err := songRepository.Create(&song); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		playlistRepository := factory.NewPlaylistRepository()
+		playlists, errCode = i.importPlaylists(&archive, userID, ids)
+		if errCode != nil {
+			return errCode.Error
+		}
+		for _, playlist := range playlists {
+			if err := playlistRepository.Create(&playlist); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		practiceSessionRepository := factory.NewPracticeSessionRepository()
+		for _, session := range i.importPracticeSessions(archive, userID, ids) {
+			if err := practiceSessionRepository.Create(&session); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		setlistRepository := factory.NewSetlistRepository()
+		for _, setlist := range i.importSetlists(archive, userID, ids) {
+			if err := setlistRepository.Create(&setlist); err != nil {
+				errCode = wrapper.InternalServerError(err)
+				return err
+			}
+		}
+
+		return i.publishCreated(factory.NewOutboxRepository(), artists, albums, songs, playlists)
+	})
+	if err != nil {
+		if errCode != nil {
+			return errCode
+		}
+		return wrapper.InternalServerError(err)
+	}
+
+	// the storage is not part of the transaction, so the images are uploaded only once the data is saved,
+	// and the ones that fail are dropped, instead of failing an import that is already committed
+	failedImages := i.uploadImages(archive)
+	if len(failedImages) == 0 {
+		return nil
+	}
+	return i.clearFailedImages(failedImages, artists, albums, songs, playlists)
+}
+
+func (i ImportUserData) readArchive(f io.ReaderAt, size int64) (importedArchive, *wrapper.ErrorCode) {
+	reader, err := zip.NewReader(f, size)
+	if err != nil {
+		return importedArchive{}, wrapper.BadRequestError(errors.New("the archive is not valid"))
+	}
+
+	archive := importedArchive{images: make(map[string]*zip.File)}
+	files := map[string]any{
+		model.ExportedArtistsFile:          &archive.artists,
+		model.ExportedAlbumsFile:           &archive.albums,
+		model.ExportedSongsFile:            &archive.songs,
+		model.ExportedPlaylistsFile:        &archive.playlists,
+		model.ExportedPracticeSessionsFile: &archive.sessions,
+		model.ExportedSetlistsFile:         &archive.setlists,
+		model.ExportedBandMemberRolesFile:  &archive.bandMemberRoles,
+		model.ExportedGuitarTuningsFile:    &archive.guitarTunings,
+		model.ExportedInstrumentsFile:      &archive.instruments,
+		model.ExportedSectionTypesFile:     &archive.sectionTypes,
+	}
+	isExport := false
+	for _, zipFile := range reader.File {
+		// the profile is not imported, as everything is recreated under the current user
+		if zipFile.Name == model.ExportedUserFile {
+			isExport = true
+			continue
+		}
+
+		content, isDataFile := files[zipFile.Name]
+		if !isDataFile {
+			archive.images[zipFile.Name] = zipFile
+			continue
+		}
+
+		errCode := i.readJSON(zipFile, content)
+		if errCode != nil {
+			return importedArchive{}, errCode
+		}
+	}
+	if !isExport {
+		return importedArchive{}, wrapper.BadRequestError(errors.New("the archive is not a repertoire export"))
+	}
+
+	return archive, nil
+}
+
+func (i ImportUserData) readJSON(zipFile *zip.File, content any) *wrapper.ErrorCode {
+	bytes, errCode := i.readZipFile(zipFile, maxImportedDataFileSize)
+	if errCode != nil {
+		return errCode
+	}
+
+	err := json.Unmarshal(bytes, content)
+	if err != nil {
+		return wrapper.BadRequestError(errors.New(zipFile.Name + " is not valid: " + err.Error()))
+	}
+	return nil
+}
+
+// readZipFile reads the whole entry of the archive, as long as it is not larger than the max size
+// (which is checked on the content, as the size declared by the archive can be forged)
+func (i ImportUserData) readZipFile(zipFile *zip.File, maxSize int64) ([]byte, *wrapper.ErrorCode) {
+	f, err := zipFile.Open()
+	if err != nil {
+		return nil, wrapper.BadRequestError(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, wrapper.BadRequestError(err)
+	}
+	if int64(len(content)) > maxSize {
+		return nil, wrapper.BadRequestError(errors.New(zipFile.Name + " is too large"))
+	}
+	return content, nil
+}
+
+// User Data
+
+func (i ImportUserData) mergeUserData(
+	userDataRepository repository.UserDataRepository,
+	archive importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) ([]model.BandMemberRole, *wrapper.ErrorCode) {
+	var roles []model.BandMemberRole
+	err := userDataRepository.GetBandMemberRoles(&roles, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	existingRoles := make(map[string]uuid.UUID)
+	for _, role := range roles {
+		existingRoles[strings.ToLower(role.Name)] = role.ID
+	}
+	err = i.mergeUserDataItems(archive.bandMemberRoles, existingRoles, ids, func(id uuid.UUID, name string, order uint) error {
+		role := model.BandMemberRole{ID: id, Name: name, Order: order, UserID: userID}
+		roles = append(roles, role)
+		return userDataRepository.CreateBandMemberRole(&role)
+	})
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var tunings []model.GuitarTuning
+	err = userDataRepository.GetGuitarTunings(&tunings, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	existingTunings := make(map[string]uuid.UUID)
+	for _, tuning := range tunings {
+		existingTunings[strings.ToLower(tuning.Name)] = tuning.ID
+	}
+	err = i.mergeUserDataItems(archive.guitarTunings, existingTunings, ids, func(id uuid.UUID, name string, order uint) error {
+		return userDataRepository.CreateGuitarTuning(&model.GuitarTuning{ID: id, Name: name, Order: order, UserID: userID})
+	})
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var instruments []model.Instrument
+	err = userDataRepository.GetInstruments(&instruments, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	existingInstruments := make(map[string]uuid.UUID)
+	for _, instrument := range instruments {
+		existingInstruments[strings.ToLower(instrument.Name)] = instrument.ID
+	}
+	err = i.mergeUserDataItems(archive.instruments, existingInstruments, ids, func(id uuid.UUID, name string, order uint) error {
+		return userDataRepository.CreateInstrument(&model.Instrument{ID: id, Name: name, Order: order, UserID: userID})
+	})
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	var sectionTypes []model.SongSectionType
+	err = userDataRepository.GetSectionTypes(&sectionTypes, userID)
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+	existingSectionTypes := make(map[string]uuid.UUID)
+	for _, sectionType := range sectionTypes {
+		existingSectionTypes[strings.ToLower(sectionType.Name)] = sectionType.ID
+	}
+	err = i.mergeUserDataItems(archive.sectionTypes, existingSectionTypes, ids, func(id uuid.UUID, name string, order uint) error {
+		return userDataRepository.CreateSectionType(&model.SongSectionType{ID: id, Name: name, Order: order, UserID: userID})
+	})
+	if err != nil {
+		return nil, wrapper.InternalServerError(err)
+	}
+
+	return roles, nil
+}
+
+// mergeUserDataItems maps the imported items to the existing ones with the same name (case-insensitive),
+// and creates the rest of them at the end of the user's list
+func (i ImportUserData) mergeUserDataItems(
+	items []model.ExportedUserDataItem,
+	existing map[string]uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+	create func(id uuid.UUID, name string, order uint) error,
+) error {
+	order := uint(len(existing))
+	for _, item := range items {
+		name := strings.ToLower(item.Name)
+		if id, found := existing[name]; found {
+			ids[item.ID] = id
+			continue
+		}
+
+		id := uuid.New()
+		err := create(id, item.Name, order)
+		if err != nil {
+			return err
+		}
+		existing[name] = id
+		ids[item.ID] = id
+		order++
+	}
+	return nil
+}
+
+// Entities
+
+func (i ImportUserData) importArtists(
+	archive *importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+	roles []model.BandMemberRole,
+) ([]model.Artist, *wrapper.ErrorCode) {
+	var artists []model.Artist
+	for _, exported := range archive.artists {
+		artist := model.Artist{
+			ID:        uuid.New(),
+			Name:      exported.Name,
+			IsBand:    exported.IsBand,
+			CreatedAt: exported.CreatedAt,
+			UpdatedAt: exported.UpdatedAt,
+			UserID:    userID,
+		}
+		ids[exported.ID] = artist.ID
+
+		for _, exportedMember := range exported.BandMembers {
+			member := model.BandMember{
+				ID:        uuid.New(),
+				Name:      exportedMember.Name,
+				Order:     exportedMember.Order,
+				Color:     exportedMember.Color,
+				ArtistID:  artist.ID,
+				Artist:    artist,
+				CreatedAt: exportedMember.CreatedAt,
+				UpdatedAt: exportedMember.UpdatedAt,
+			}
+			ids[exportedMember.ID] = member.ID
+
+			for _, roleID := range exportedMember.RoleIDs {
+				for _, role := range roles {
+					if role.ID == ids[roleID] {
+						member.Roles = append(member.Roles, role)
+					}
+				}
+			}
+
+			imagePath, errCode := i.addImage(archive, exportedMember.Image, func() string {
+				return i.storageFilePathProvider.GetBandMemberImagePath(member)
+			})
+			if errCode != nil {
+				return nil, errCode
+			}
+			member.ImageURL = imagePath
+			// the artist is only needed for the image path, otherwise it would be created once again
+			member.Artist = model.Artist{}
+
+			artist.BandMembers = append(artist.BandMembers, member)
+		}
+
+		imagePath, errCode := i.addImage(archive, exported.Image, func() string {
+			return i.storageFilePathProvider.GetArtistImagePath(artist)
+		})
+		if errCode != nil {
+			return nil, errCode
+		}
+		artist.ImageURL = imagePath
+
+		artists = append(artists, artist)
+	}
+	return artists, nil
+}
+
+func (i ImportUserData) importAlbums(
+	archive *importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) ([]model.Album, *wrapper.ErrorCode) {
+	var albums []model.Album
+	for _, exported := range archive.albums {
+		album := model.Album{
+			ID:          uuid.New(),
+			Title:       exported.Title,
+			ReleaseDate: exported.ReleaseDate,
+			ArtistID:    i.remap(ids, exported.ArtistID),
+			CreatedAt:   exported.CreatedAt,
+			UpdatedAt:   exported.UpdatedAt,
+			UserID:      userID,
+		}
+		ids[exported.ID] = album.ID
+
+		imagePath, errCode := i.addImage(archive, exported.Image, func() string {
+			return i.storageFilePathProvider.GetAlbumImagePath(album)
+		})
+		if errCode != nil {
+			return nil, errCode
+		}
+		album.ImageURL = imagePath
+
+		albums = append(albums, album)
+	}
+	return albums, nil
+}
+
+func (i ImportUserData) importSongs(
+	archive *importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) ([]model.Song, *wrapper.ErrorCode) {
+	var songs []model.Song
+	for _, exported := range archive.songs {
+		song := model.Song{
+			ID:             uuid.New(),
+			Title:          exported.Title,
+			Description:    exported.Description,
+			ReleaseDate:    exported.ReleaseDate,
+			IsRecorded:     exported.IsRecorded,
+			Bpm:            exported.Bpm,
+			Duration:       exported.Duration,
+			Difficulty:     exported.Difficulty,
+			SongsterrLink:  exported.SongsterrLink,
+			YoutubeLink:    exported.YoutubeLink,
+			LastTimePlayed: exported.LastTimePlayed,
+			Rehearsals:     exported.Rehearsals,
+			Confidence:     exported.Confidence,
+			Progress:       exported.Progress,
+			AlbumID:        i.remap(ids, exported.AlbumID),
+			ArtistID:       i.remap(ids, exported.ArtistID),
+			GuitarTuningID: i.remap(ids, exported.GuitarTuningID),
+			CreatedAt:      exported.CreatedAt,
+			UpdatedAt:      exported.UpdatedAt,
+			UserID:         userID,
+		}
+		if song.AlbumID != nil {
+			song.AlbumTrackNo = exported.AlbumTrackNo
+		}
+		song.Settings = model.SongSettings{
+			ID:                  uuid.New(),
+			DefaultInstrumentID: i.remap(ids, exported.Settings.DefaultInstrumentID),
+			DefaultBandMemberID: i.remap(ids, exported.Settings.DefaultBandMemberID),
+			SongID:              song.ID,
+		}
+		ids[exported.ID] = song.ID
+
+		for _, exportedSection := range exported.Sections {
+			sectionTypeID, found := ids[exportedSection.SongSectionTypeID]
+			if !found {
+				return nil, wrapper.BadRequestError(errors.New("section type of " + exportedSection.Name + " not found"))
+			}
+
+			section := model.SongSection{
+				ID:                 uuid.New(),
+				Name:               exportedSection.Name,
+				Order:              exportedSection.Order,
+				Occurrences:        exportedSection.Occurrences,
+				PartialOccurrences: exportedSection.PartialOccurrences,
+				StartOffset:        exportedSection.StartOffset,
+				BarCount:           exportedSection.BarCount,
+				TimeSignature:      exportedSection.TimeSignature,
+				Rehearsals:         exportedSection.Rehearsals,
+				Confidence:         exportedSection.Confidence,
+				RehearsalsScore:    exportedSection.RehearsalsScore,
+				ConfidenceScore:    exportedSection.ConfidenceScore,
+				Progress:           exportedSection.Progress,
+				SongID:             song.ID,
+				SongSectionTypeID:  sectionTypeID,
+				InstrumentID:       i.remap(ids, exportedSection.InstrumentID),
+				BandMemberID:       i.remap(ids, exportedSection.BandMemberID),
+				CreatedAt:          exportedSection.CreatedAt,
+				UpdatedAt:          exportedSection.UpdatedAt,
+			}
+			ids[exportedSection.ID] = section.ID
+
+			for _, history := range exportedSection.History {
+				section.History = append(section.History, model.SongSectionHistory{
+					ID:            uuid.New(),
+					Property:      history.Property,
+					From:          history.From,
+					To:            history.To,
+					SongSectionID: section.ID,
+					CreatedAt:     history.CreatedAt,
+				})
+			}
+			song.Sections = append(song.Sections, section)
+		}
+
+		imagePath, errCode := i.addImage(archive, exported.Image, func() string {
+			return i.storageFilePathProvider.GetSongImagePath(song)
+		})
+		if errCode != nil {
+			return nil, errCode
+		}
+		song.ImageURL = imagePath
+
+		songs = append(songs, song)
+	}
+	return songs, nil
+}
+
+func (i ImportUserData) importPlaylists(
+	archive *importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) ([]model.Playlist, *wrapper.ErrorCode) {
+	var playlists []model.Playlist
+	for _, exported := range archive.playlists {
+		playlist := model.Playlist{
+			ID:          uuid.New(),
+			Title:       exported.Title,
+			Description: exported.Description,
+			SmartRules:  i.remapSmartRules(ids, exported.SmartRules),
+			CreatedAt:   exported.CreatedAt,
+			UpdatedAt:   exported.UpdatedAt,
+			UserID:      userID,
+		}
+		ids[exported.ID] = playlist.ID
+
+		for _, songID := range exported.SongIDs {
+			newSongID, found := ids[songID]
+			if !found {
+				continue
+			}
+			playlist.PlaylistSongs = append(playlist.PlaylistSongs, model.PlaylistSong{
+				ID:          uuid.New(),
+				PlaylistID:  playlist.ID,
+				SongID:      newSongID,
+				SongTrackNo: uint(len(playlist.PlaylistSongs)) + 1,
+			})
+		}
+
+		imagePath, errCode := i.addImage(archive, exported.Image, func() string {
+			return i.storageFilePathProvider.GetPlaylistImagePath(playlist)
+		})
+		if errCode != nil {
+			return nil, errCode
+		}
+		playlist.ImageURL = imagePath
+
+		playlists = append(playlists, playlist)
+	}
+	return playlists, nil
+}
+
+// importPracticeSessions leaves out the songs and sections that are not part of the archive
+func (i ImportUserData) importPracticeSessions(
+	archive importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) []model.PracticeSession {
+	var sessions []model.PracticeSession
+	for _, exported := range archive.sessions {
+		session := model.PracticeSession{
+			ID:        uuid.New(),
+			Notes:     exported.Notes,
+			StartedAt: exported.StartedAt,
+			EndedAt:   exported.EndedAt,
+			CreatedAt: exported.CreatedAt,
+			UpdatedAt: exported.UpdatedAt,
+			UserID:    userID,
+		}
+
+		for _, exportedSong := range exported.Songs {
+			songID, found := ids[exportedSong.SongID]
+			if !found {
+				continue
+			}
+			sessionSong := model.PracticeSessionSong{
+				ID:                uuid.New(),
+				PracticeSessionID: session.ID,
+				SongID:            songID,
+				CreatedAt:         exportedSong.CreatedAt,
+			}
+			for _, exportedSection := range exportedSong.Sections {
+				sectionID, found := ids[exportedSection.SongSectionID]
+				if !found {
+					continue
+				}
+				sessionSong.Sections = append(sessionSong.Sections, model.PracticeSessionSection{
+					ID:                    uuid.New(),
+					Occurrences:           exportedSection.Occurrences,
+					PracticeSessionSongID: sessionSong.ID,
+					SongSectionID:         sectionID,
+				})
+			}
+			session.Songs = append(session.Songs, sessionSong)
+		}
+
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// importSetlists leaves out the song entries whose songs are not part of the archive, and renumbers the rest
+func (i ImportUserData) importSetlists(
+	archive importedArchive,
+	userID uuid.UUID,
+	ids map[uuid.UUID]uuid.UUID,
+) []model.Setlist {
+	var setlists []model.Setlist
+	for _, exported := range archive.setlists {
+		setlist := model.Setlist{
+			ID:        uuid.New(),
+			Title:     exported.Title,
+			Venue:     exported.Venue,
+			Date:      exported.Date,
+			Notes:     exported.Notes,
+			CreatedAt: exported.CreatedAt,
+			UpdatedAt: exported.UpdatedAt,
+			UserID:    userID,
+		}
+
+		for _, exportedEntry := range exported.Entries {
+			songID := i.remap(ids, exportedEntry.SongID)
+			if exportedEntry.Type == enums.SongEntry && songID == nil {
+				continue
+			}
+			setlist.Entries = append(setlist.Entries, model.SetlistEntry{
+				ID:              uuid.New(),
+				Type:            exportedEntry.Type,
+				Title:           exportedEntry.Title,
+				PlannedDuration: exportedEntry.PlannedDuration,
+				EntryNo:         uint(len(setlist.Entries)) + 1,
+				SetlistID:       setlist.ID,
+				SongID:          songID,
+				CreatedAt:       exportedEntry.CreatedAt,
+			})
+		}
+
+		setlists = append(setlists, setlist)
+	}
+	return setlists
+}
+
+// remap returns the new identifier, or nil when the entity it pointed to is not part of the archive
+func (i ImportUserData) remap(ids map[uuid.UUID]uuid.UUID, id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	newID, found := ids[*id]
+	if !found {
+		return nil
+	}
+	return &newID
+}
+
+// remapSmartRules replaces the identifiers used by the rules (e.g. guitar_tuning_id = <id>) with the new ones
+func (i ImportUserData) remapSmartRules(
+	ids map[uuid.UUID]uuid.UUID,
+	rules *model.PlaylistSmartRules,
+) *model.PlaylistSmartRules {
+	if rules == nil {
+		return nil
+	}
+	remapped := &model.PlaylistSmartRules{OrderBy: rules.OrderBy, Limit: rules.Limit}
+	for _, searchBy := range rules.SearchBy {
+		for oldID, newID := range ids {
+			searchBy = strings.ReplaceAll(searchBy, oldID.String(), newID.String())
+		}
+		remapped.SearchBy = append(remapped.SearchBy, searchBy)
+	}
+	return remapped
+}
+
+// addImage returns the path of the image from the archive, on the path of its new entity,
+// and leaves it to be uploaded once the import is committed
+func (i ImportUserData) addImage(
+	archive *importedArchive,
+	image *string,
+	getImagePath func() string,
+) (*internal.FilePath, *wrapper.ErrorCode) {
+	if image == nil {
+		return nil, nil
+	}
+	zipFile, found := archive.images[*image]
+	if !found {
+		return nil, nil
+	}
+
+	// the image is read already, so that an invalid one fails the import before anything is saved
+	content, errCode := i.readZipFile(zipFile, maxImportedImageSize)
+	if errCode != nil {
+		return nil, errCode
+	}
+
+	imagePath := getImagePath()
+	archive.pendingImages = append(archive.pendingImages, pendingImage{
+		content:  content,
+		fileName: path.Base(*image),
+		path:     imagePath,
+	})
+
+	filePath := internal.FilePath(imagePath)
+	return &filePath, nil
+}
+
+// uploadImages returns the paths of the images that could not be uploaded
+func (i ImportUserData) uploadImages(archive importedArchive) map[string]bool {
+	failedImages := make(map[string]bool)
+	for _, image := range archive.pendingImages {
+		errCode := i.storageService.UploadFile(image.fileName, image.content, image.path)
+		if errCode != nil {
+			failedImages[image.path] = true
+		}
+	}
+	return failedImages
+}
+
+// clearFailedImages removes the images that could not be uploaded from the imported entities,
+// so that they do not point to missing files
+func (i ImportUserData) clearFailedImages(
+	failedImages map[string]bool,
+	artists []model.Artist,
+	albums []model.Album,
+	songs []model.Song,
+	playlists []model.Playlist,
+) *wrapper.ErrorCode {
+	isFailed := func(imageURL *internal.FilePath) bool {
+		return imageURL != nil && failedImages[string(*imageURL)]
+	}
+
+	err := i.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		outboxRepository := factory.NewOutboxRepository()
+
+		artistRepository := factory.NewArtistRepository()
+		for _, artist := range artists {
+			isUpdated := false
+			if isFailed(artist.ImageURL) {
+				var savedArtist model.Artist
+				if err := artistRepository.Get(&savedArtist, artist.ID); err != nil {
+					return err
+				}
+				savedArtist.ImageURL = nil
+				if err := artistRepository.Update(&savedArtist); err != nil {
+					return err
+				}
+				isUpdated = true
+			}
+			for _, member := range artist.BandMembers {
+				if !isFailed(member.ImageURL) {
+					continue
+				}
+				var savedMember model.BandMember
+				if err := artistRepository.GetBandMember(&savedMember, member.ID); err != nil {
+					return err
+				}
+				savedMember.ImageURL = nil
+				if err := artistRepository.UpdateBandMember(&savedMember); err != nil {
+					return err
+				}
+				isUpdated = true
+			}
+			if !isUpdated {
+				continue
+			}
+			err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.ArtistUpdatedTopic, artist.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		albumRepository := factory.NewAlbumRepository()
+		var albumIDs []uuid.UUID
+		for _, album := range albums {
+			if !isFailed(album.ImageURL) {
+				continue
+			}
+			var savedAlbum model.Album
+			if err := albumRepository.Get(&savedAlbum, album.ID); err != nil {
+				return err
+			}
+			savedAlbum.ImageURL = nil
+			if err := albumRepository.Update(&savedAlbum); err != nil {
+				return err
+			}
+			albumIDs = append(albumIDs, album.ID)
+		}
+		if len(albumIDs) > 0 {
+			err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.AlbumsUpdatedTopic, albumIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		songRepository := factory.NewSongRepository()
+		var songIDs []uuid.UUID
+		for _, song := range songs {
+			if !isFailed(song.ImageURL) {
+				continue
+			}
+			var savedSong model.Song
+			if err := songRepository.Get(&savedSong, song.ID); err != nil {
+				return err
+			}
+			savedSong.ImageURL = nil
+			if err := songRepository.Update(&savedSong); err != nil {
+				return err
+			}
+			songIDs = append(songIDs, song.ID)
+		}
+		if len(songIDs) > 0 {
+			err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.SongsUpdatedTopic, songIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		playlistRepository := factory.NewPlaylistRepository()
+		for _, playlist := range playlists {
+			if !isFailed(playlist.ImageURL) {
+				continue
+			}
+			var savedPlaylist model.Playlist
+			if err := playlistRepository.Get(&savedPlaylist, playlist.ID); err != nil {
+				return err
+			}
+			savedPlaylist.ImageURL = nil
+			if err := playlistRepository.Update(&savedPlaylist); err != nil {
+				return err
+			}
+			err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.PlaylistUpdatedTopic, savedPlaylist)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	return nil
+}
+
+// publishCreated lets the imported entities go through the same flow as the newly created ones (e.g. search indexing)
+func (i ImportUserData) publishCreated(
+	outboxRepository repository.OutboxRepository,
+	artists []model.Artist,
+	albums []model.Album,
+	songs []model.Song,
+	playlists []model.Playlist,
+) error {
+	for _, artist := range artists {
+		err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.ArtistCreatedTopic, artist)
+		if err != nil {
+			return err
+		}
+	}
+	for _, album := range albums {
+		err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.AlbumCreatedTopic, album)
+		if err != nil {
+			return err
+		}
+	}
+	for _, song := range songs {
+		err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.SongCreatedTopic, song)
+		if err != nil {
+			return err
+		}
+	}
+	for _, playlist := range playlists {
+		err := i.messagePublisherService.PublishWithinTransaction(outboxRepository, topics.PlaylistCreatedTopic, playlist)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -57,11 +57,11 @@ func (s SaveProfilePictureToUser) Handle(file *multipart.FileHeader, token strin
 	}
 
 	user.UpdatedAt = time.Now().UTC()
-	imagePath := s.storageFilePathProvider.GetUserProfilePicturePath(file, user)
+	imagePath := s.storageFilePathProvider.GetUserProfilePicturePath(user)
 
-	err = s.storageService.Upload(file, imagePath)
-	if err != nil {
-		return wrapper.InternalServerError(err)
+	errCode = s.storageService.Upload(file, imagePath)
+	if errCode != nil {
+		return errCode
 	}
 
 	user.ProfilePictureURL = (*internal.FilePath)(&imagePath)
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"reflect"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/http/auth"
+	"repertoire/server/data/logger"
 	"repertoire/server/data/repository"
 	"repertoire/server/data/service"
 	"repertoire/server/internal/wrapper"
@@ -11,43 +13,47 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type SignUp struct {
 	authService    service.AuthService
 	bCryptService  service.BCryptService
 	userRepository repository.UserRepository
+	logger         *logger.Logger
 }
 
 func NewSignUp(
 	authService service.AuthService,
 	bCryptService service.BCryptService,
 	userRepository repository.UserRepository,
+	logger *logger.Logger,
 ) SignUp {
 	return SignUp{
 		authService:    authService,
 		bCryptService:  bCryptService,
 		userRepository: userRepository,
+		logger:         logger,
 	}
 }
 
-func (s *SignUp) Handle(request requests.SignUpRequest) (string, *wrapper.ErrorCode) {
+func (s *SignUp) Handle(request requests.SignUpRequest) (auth.SignInResponse, *wrapper.ErrorCode) {
 	var user model.User
 
 	// check if the user already exists
 	email := strings.ToLower(request.Email)
 	err := s.userRepository.GetByEmail(&user, email)
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return auth.SignInResponse{}, wrapper.InternalServerError(err)
 	}
 	if !reflect.ValueOf(user).IsZero() {
-		return "", wrapper.BadRequestError(errors.New("user already exists"))
+		return auth.SignInResponse{}, wrapper.BadRequestError(errors.New("user already exists"))
 	}
 
 	// hash the password
 	hashedPassword, err := s.bCryptService.Hash(request.Password)
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return auth.SignInResponse{}, wrapper.InternalServerError(err)
 	}
 
 	// create user
@@ -60,10 +66,22 @@ func (s *SignUp) Handle(request requests.SignUpRequest) (string, *wrapper.ErrorC
 	s.createAndAttachDefaultData(&user)
 	err = s.userRepository.Create(&user)
 	if err != nil {
-		return "", wrapper.InternalServerError(err)
+		return auth.SignInResponse{}, wrapper.InternalServerError(err)
 	}
 
-	return s.authService.SignIn(user.Email, request.Password)
+	tokens, errCode := s.authService.SignIn(user.Email, request.Password)
+	if errCode != nil {
+		return auth.SignInResponse{}, errCode
+	}
+
+	// the user is already signed up, so failing to send the verification email does not fail the sign-up
+	// (it can be requested again later)
+	errCode = s.authService.RequestEmailVerification(tokens.Token)
+	if errCode != nil {
+		s.logger.Warn("Failed to request the email verification", zap.Error(errCode.Error))
+	}
+
+	return tokens, nil
 }
 
 func (s *SignUp) createAndAttachDefaultData(user *model.User) {
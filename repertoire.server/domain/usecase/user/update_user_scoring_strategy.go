@@ -0,0 +1,77 @@
+package user
+
+import (
+	"errors"
+	"reflect"
+	"repertoire/server/api/requests"
+	"repertoire/server/data/database/transaction"
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+)
+
+type UpdateUserScoringStrategy struct {
+	repository              repository.UserRepository
+	jwtService              service.JwtService
+	messagePublisherService service.MessagePublisherService
+	transactionManager      transaction.Manager
+}
+
+func NewUpdateUserScoringStrategy(
+	repository repository.UserRepository,
+	jwtService service.JwtService,
+	messagePublisherService service.MessagePublisherService,
+	transactionManager transaction.Manager,
+) UpdateUserScoringStrategy {
+	return UpdateUserScoringStrategy{
+		repository:              repository,
+		jwtService:              jwtService,
+		messagePublisherService: messagePublisherService,
+		transactionManager:      transactionManager,
+	}
+}
+
+func (u UpdateUserScoringStrategy) Handle(
+	request requests.UpdateUserScoringStrategyRequest,
+	token string,
+) *wrapper.ErrorCode {
+	id, errCode := u.jwtService.GetUserIdFromJwt(token)
+	if errCode != nil {
+		return errCode
+	}
+
+	var user model.User
+	err := u.repository.Get(&user, id)
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+	if reflect.ValueOf(user).IsZero() {
+		return wrapper.NotFoundError(errors.New("user not found"))
+	}
+	if user.ScoringStrategy == request.ScoringStrategy {
+		return nil
+	}
+
+	user.ScoringStrategy = request.ScoringStrategy
+
+	err = u.transactionManager.Execute(func(factory transaction.RepositoryFactory) error {
+		err := factory.NewUserRepository().Update(&user)
+		if err != nil {
+			return err
+		}
+
+		// the scores of all sections are recomputed in the background with the new strategy
+		return u.messagePublisherService.PublishWithinTransaction(
+			factory.NewOutboxRepository(),
+			topics.UserScoringStrategyUpdatedTopic,
+			user.ID,
+		)
+	})
+	if err != nil {
+		return wrapper.InternalServerError(err)
+	}
+
+	return nil
+}
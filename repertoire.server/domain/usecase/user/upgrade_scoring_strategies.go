@@ -0,0 +1,51 @@
+package user
+
+import (
+	"repertoire/server/data/repository"
+	"repertoire/server/data/service"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+
+	"github.com/google/uuid"
+)
+
+type UpgradeScoringStrategies struct {
+	repository              repository.UserRepository
+	progressProcessor       processor.ProgressProcessor
+	messagePublisherService service.MessagePublisherService
+}
+
+func NewUpgradeScoringStrategies(
+	repository repository.UserRepository,
+	progressProcessor processor.ProgressProcessor,
+	messagePublisherService service.MessagePublisherService,
+) UpgradeScoringStrategies {
+	return UpgradeScoringStrategies{
+		repository:              repository,
+		progressProcessor:       progressProcessor,
+		messagePublisherService: messagePublisherService,
+	}
+}
+
+// Handle requests the scores to be recomputed for the users whose scores were computed
+// with an older version of their strategy, and returns how many users have been found
+func (u UpgradeScoringStrategies) Handle() (int, *wrapper.ErrorCode) {
+	upgraded := 0
+	for strategy, version := range u.progressProcessor.GetScoringStrategyVersions() {
+		var ids []uuid.UUID
+		err := u.repository.GetAllIDsByOutdatedScoringStrategy(&ids, strategy, version)
+		if err != nil {
+			return upgraded, wrapper.InternalServerError(err)
+		}
+
+		for _, id := range ids {
+			err = u.messagePublisherService.Publish(topics.UserScoringStrategyUpdatedTopic, id)
+			if err != nil {
+				return upgraded, wrapper.InternalServerError(err)
+			}
+			upgraded++
+		}
+	}
+	return upgraded, nil
+}
@@ -4,6 +4,7 @@ go 1.25.6
 
 require (
 	github.com/ThreeDotsLabs/watermill v1.5.1
+	github.com/ThreeDotsLabs/watermill-sql/v3 v3.1.0
 	github.com/cenkalti/backoff/v4 v4.3.0
 	github.com/centrifugal/centrifuge-go v0.10.11
 	github.com/gin-contrib/cors v1.7.6
@@ -0,0 +1,19 @@
+package enums
+
+type TimelineInterval string
+
+const (
+	DayInterval   TimelineInterval = "day"
+	WeekInterval  TimelineInterval = "week"
+	MonthInterval TimelineInterval = "month"
+)
+
+type TimelineScope string
+
+const (
+	SectionTimeline  TimelineScope = "sections"
+	SongTimeline     TimelineScope = "songs"
+	AlbumTimeline    TimelineScope = "albums"
+	ArtistTimeline   TimelineScope = "artists"
+	PlaylistTimeline TimelineScope = "playlists"
+)
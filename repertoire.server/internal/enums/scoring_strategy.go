@@ -0,0 +1,9 @@
+package enums
+
+type ScoringStrategy string
+
+const (
+	ClassicScoring     ScoringStrategy = "classic"
+	ExponentialScoring ScoringStrategy = "exponential"
+	LinearScoring      ScoringStrategy = "linear"
+)
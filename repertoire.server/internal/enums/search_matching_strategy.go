@@ -0,0 +1,9 @@
+package enums
+
+type SearchMatchingStrategy string
+
+const (
+	LastMatchingStrategy      SearchMatchingStrategy = "last"
+	AllMatchingStrategy       SearchMatchingStrategy = "all"
+	FrequencyMatchingStrategy SearchMatchingStrategy = "frequency"
+)
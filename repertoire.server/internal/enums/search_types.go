@@ -3,8 +3,10 @@ package enums
 type SearchType string
 
 const (
-	Artist   SearchType = "artist"
-	Album    SearchType = "album"
-	Song     SearchType = "song"
-	Playlist SearchType = "playlist"
+	Artist      SearchType = "artist"
+	BandMember  SearchType = "bandMember"
+	Album       SearchType = "album"
+	Song        SearchType = "song"
+	SongSection SearchType = "songSection"
+	Playlist    SearchType = "playlist"
 )
@@ -0,0 +1,10 @@
+package enums
+
+type SetlistEntryType string
+
+const (
+	SongEntry         SetlistEntryType = "song"
+	TalkEntry         SetlistEntryType = "talk"
+	TuningBreakEntry  SetlistEntryType = "tuning-break"
+	EncoreMarkerEntry SetlistEntryType = "encore-marker"
+)
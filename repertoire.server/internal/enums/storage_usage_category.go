@@ -0,0 +1,12 @@
+package enums
+
+type StorageUsageCategory string
+
+const (
+	AlbumStorageUsage      StorageUsageCategory = "album"
+	ArtistStorageUsage     StorageUsageCategory = "artist"
+	BandMemberStorageUsage StorageUsageCategory = "bandMember"
+	PlaylistStorageUsage   StorageUsageCategory = "playlist"
+	SongStorageUsage       StorageUsageCategory = "song"
+	OtherStorageUsage      StorageUsageCategory = "other"
+)
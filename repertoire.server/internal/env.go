@@ -29,13 +29,26 @@ type Env struct {
 	AuthClientID     string
 	AuthClientSecret string
 
+	RevokedTokensRefreshInterval string
+
 	StorageUrl string
 
+	OrphanedFilesCollectionInterval string
+	OrphanedFilesCollectionMode     string
+	OrphanedFilesGracePeriod        string
+
 	MeiliUrl       string
 	MeiliMasterKey string
 	MeiliAuthKey   string
 
+	SearchEngine                 string
+	SearchReconciliationInterval string
+
 	CentrifugoUrl string
+
+	MessageBroker string
+
+	AdminAuthKey string
 }
 
 func NewEnv() Env {
@@ -69,13 +82,26 @@ func NewEnv() Env {
 		AuthClientID:     os.Getenv("AUTH_CLIENT_ID"),
 		AuthClientSecret: os.Getenv("AUTH_CLIENT_SECRET"),
 
+		RevokedTokensRefreshInterval: os.Getenv("REVOKED_TOKENS_REFRESH_INTERVAL"),
+
 		StorageUrl: os.Getenv("STORAGE_UPLOAD_URL"),
 
+		OrphanedFilesCollectionInterval: os.Getenv("ORPHANED_FILES_COLLECTION_INTERVAL"),
+		OrphanedFilesCollectionMode:     os.Getenv("ORPHANED_FILES_COLLECTION_MODE"),
+		OrphanedFilesGracePeriod:        os.Getenv("ORPHANED_FILES_GRACE_PERIOD"),
+
 		MeiliUrl:       os.Getenv("MEILI_URL"),
 		MeiliMasterKey: os.Getenv("MEILI_MASTER_KEY"),
 		MeiliAuthKey:   os.Getenv("MEILI_WEBHOOK_AUTHORIZATION_KEY"),
 
+		SearchEngine:                 os.Getenv("SEARCH_ENGINE"),
+		SearchReconciliationInterval: os.Getenv("SEARCH_RECONCILIATION_INTERVAL"),
+
 		CentrifugoUrl: os.Getenv("CENTRIFUGO_URL"),
+
+		MessageBroker: os.Getenv("MESSAGE_BROKER"),
+
+		AdminAuthKey: os.Getenv("ADMIN_AUTHORIZATION_KEY"),
 	}
 	env.JwtPublicKey = strings.Replace(env.JwtPublicKey, "\\n", "\n", -1)
 	return env
@@ -83,3 +109,6 @@ func NewEnv() Env {
 
 var DevelopmentEnvironment = "development"
 var DebugLogLevel = "DEBUG"
+var PostgresMessageBroker = "postgres"
+var PostgresSearchEngine = "postgres"
+var DeleteOrphanedFilesCollectionMode = "delete"
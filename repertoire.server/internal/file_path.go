@@ -1,20 +1,43 @@
 package internal
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// the expiration of the signed URLs, when none is configured
+const defaultSignedURLExpirationTime = time.Hour
+
+// the sizes of the resized copies that the storage generates for each uploaded image
+const (
+	smallImageSize  = 64
+	mediumImageSize = 256
+	largeImageSize  = 1024
 )
 
 type FilePath string
 
+// ImageVariants are the URLs of the resized copies of an image, to be used instead of the full image where it fits
+type ImageVariants struct {
+	Small  *FilePath `json:"small"`
+	Medium *FilePath `json:"medium"`
+	Large  *FilePath `json:"large"`
+}
+
 func (f *FilePath) ToFullURL() *FilePath {
 	if f == nil {
 		return nil
 	}
-	if strings.Contains(string(*f), f.getFullURL()) {
+	fullURL := f.getFullURL()
+	if fullURL != "" && strings.HasPrefix(string(*f), fullURL) {
 		return f
 	}
-	url := FilePath(f.getFullURL() + string(*f))
+	url := FilePath(fullURL + f.ToSignedPath())
 	return &url
 }
 
@@ -22,10 +45,48 @@ func (f *FilePath) StripURL() *FilePath {
 	if f == nil {
 		return nil
 	}
-	url := FilePath(strings.Replace(string(*f), f.getFullURL(), "", -1))
+	path, _, _ := strings.Cut(strings.Replace(string(*f), f.getFullURL(), "", -1), "?")
+	url := FilePath(path)
 	return &url
 }
 
+// ToSignedPath appends the expiration and the signature that the storage requires to read the file.
+// The expiration is rounded, so that the URL stays the same for a while and the file can still be cached
+func (f *FilePath) ToSignedPath() string {
+	expiresIn, err := time.ParseDuration(os.Getenv("STORAGE_SIGNED_URL_EXPIRATION_TIME"))
+	if err != nil || expiresIn <= 0 {
+		expiresIn = defaultSignedURLExpirationTime
+	}
+	expires := strconv.FormatInt(time.Now().UTC().Truncate(expiresIn).Add(2*expiresIn).Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(os.Getenv("STORAGE_SIGNED_URL_SECRET_KEY")))
+	mac.Write([]byte(string(*f) + ":" + expires))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	return string(*f) + "?expires=" + expires + "&signature=" + signature
+}
+
+func (f *FilePath) ToImageVariants() *ImageVariants {
+	if f == nil {
+		return nil
+	}
+	return &ImageVariants{
+		Small:  f.toImageVariantURL(smallImageSize),
+		Medium: f.toImageVariantURL(mediumImageSize),
+		Large:  f.toImageVariantURL(largeImageSize),
+	}
+}
+
+func (f *FilePath) toImageVariantURL(size int) *FilePath {
+	url := string(*f.ToFullURL())
+	separator := "?"
+	if strings.Contains(url, "?") {
+		separator = "&"
+	}
+	variantURL := FilePath(url + separator + "size=" + strconv.Itoa(size))
+	return &variantURL
+}
+
 func (f *FilePath) getFullURL() string {
 	return os.Getenv("STORAGE_FETCH_URL")
 }
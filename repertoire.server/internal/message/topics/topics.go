@@ -23,7 +23,9 @@ const (
 	SongsDeletedTopic Topic = "songs_deleted_topic"
 	SongsUpdatedTopic Topic = "songs_updated_topic"
 
-	UserDeletedTopic Topic = "user_deleted_topic"
+	UserDeletedTopic                Topic = "user_deleted_topic"
+	UserScoringStrategyUpdatedTopic Topic = "user_scoring_strategy_updated_topic"
+	UserDataExportRequestedTopic    Topic = "user_data_export_requested_topic"
 
 	AddToSearchEngineTopic      Topic = "add_to_search_engine_topic"
 	DeleteFromSearchEngineTopic Topic = "delete_from_search_engine_topic"
@@ -49,7 +51,9 @@ var TopicToQueueMap = map[Topic]queues.Queue{
 	SongsDeletedTopic: queues.MainQueue,
 	SongsUpdatedTopic: queues.MainQueue,
 
-	UserDeletedTopic: queues.MainQueue,
+	UserDeletedTopic:                queues.MainQueue,
+	UserScoringStrategyUpdatedTopic: queues.MainQueue,
+	UserDataExportRequestedTopic:    queues.MainQueue,
 
 	AddToSearchEngineTopic:      queues.SearchQueue,
 	DeleteFromSearchEngineTopic: queues.SearchQueue,
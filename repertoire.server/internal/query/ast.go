@@ -0,0 +1,92 @@
+package query
+
+type FieldType string
+
+const (
+	StringField FieldType = "string"
+	NumberField FieldType = "number"
+	DateField   FieldType = "date"
+	UUIDField   FieldType = "uuid"
+	BoolField   FieldType = "bool"
+	EnumField   FieldType = "enum"
+)
+
+type Operator string
+
+const (
+	Match          Operator = "~*"
+	Equal          Operator = "="
+	NotEqual       Operator = "<>"
+	Less           Operator = "<"
+	Greater        Operator = ">"
+	LessOrEqual    Operator = "<="
+	GreaterOrEqual Operator = ">="
+	In             Operator = "IN"
+	IsNull         Operator = "IS NULL"
+	IsNotNull      Operator = "IS NOT NULL"
+)
+
+var typeOperators = map[FieldType][]Operator{
+	StringField: {Match, Equal, NotEqual, Less, Greater, LessOrEqual, GreaterOrEqual, In, IsNull, IsNotNull},
+	NumberField: {Equal, NotEqual, Less, Greater, LessOrEqual, GreaterOrEqual, In, IsNull, IsNotNull},
+	DateField:   {Equal, NotEqual, Less, Greater, LessOrEqual, GreaterOrEqual, IsNull, IsNotNull},
+	UUIDField:   {Equal, NotEqual, In, IsNull, IsNotNull},
+	BoolField:   {Equal, NotEqual, IsNull, IsNotNull},
+	EnumField:   {Equal, NotEqual, In, IsNull, IsNotNull},
+}
+
+// Field is a property that clients can search or order by, mapped to the column (or expression) behind it
+type Field struct {
+	Column string
+	Type   FieldType
+	// Values are the accepted values of an enum field
+	Values []string
+	// Operators restrict the operators of the field, when it cannot use all the ones of its type
+	Operators []Operator
+	// Condition replaces the whole SQL condition of the fields that are not columns (e.g. subqueries)
+	Condition string
+}
+
+// Fields is the allow-list of an entity, keyed by the property name used by clients
+type Fields map[string]Field
+
+type Filter interface {
+	isFilter()
+}
+
+type LogicalOperator string
+
+const (
+	And LogicalOperator = "AND"
+	Or  LogicalOperator = "OR"
+)
+
+type Group struct {
+	Operator LogicalOperator
+	Filters  []Filter
+}
+
+type Condition struct {
+	Property string
+	Field    Field
+	Operator Operator
+	// Value is typed after the field: string, float64, time.Time, uuid.UUID, bool or a slice of them for IN
+	Value any
+}
+
+func (Group) isFilter()     {}
+func (Condition) isFilter() {}
+
+type Nulls string
+
+const (
+	NullsFirst Nulls = "FIRST"
+	NullsLast  Nulls = "LAST"
+)
+
+type Order struct {
+	Property   string
+	Field      Field
+	Descending bool
+	Nulls      Nulls
+}
@@ -0,0 +1,159 @@
+package query
+
+import (
+	"maps"
+	"repertoire/server/internal/enums"
+)
+
+var difficulties = []string{
+	string(enums.Easy),
+	string(enums.Medium),
+	string(enums.Hard),
+	string(enums.Impossible),
+}
+
+var songColumns = map[string]Field{
+	"id":               {Type: UUIDField},
+	"title":            {Type: StringField},
+	"description":      {Type: StringField},
+	"release_date":     {Type: DateField},
+	"is_recorded":      {Type: BoolField},
+	"bpm":              {Type: NumberField},
+	"duration":         {Type: NumberField},
+	"difficulty":       {Type: EnumField, Values: difficulties},
+	"songsterr_link":   {Type: StringField},
+	"youtube_link":     {Type: StringField},
+	"album_track_no":   {Type: NumberField},
+	"last_time_played": {Type: DateField},
+	"rehearsals":       {Type: NumberField},
+	"confidence":       {Type: NumberField},
+	"progress":         {Type: NumberField},
+	"album_id":         {Type: UUIDField},
+	"artist_id":        {Type: UUIDField},
+	"guitar_tuning_id": {Type: UUIDField},
+	"created_at":       {Type: DateField},
+	"updated_at":       {Type: DateField},
+}
+
+var SongFields = withFields(tableFields("songs", songColumns, "songs"), Fields{
+	`"Album".title`:          {Column: `"Album".title`, Type: StringField},
+	`"Artist".name`:          {Column: `"Artist".name`, Type: StringField},
+	`"GuitarTuning"."order"`: {Column: `"GuitarTuning"."order"`, Type: NumberField},
+	"sections_count":         {Column: "COALESCE(ss.sections_count, 0)", Type: NumberField},
+	"solos_count":            {Column: "COALESCE(ss.solos_count, 0)", Type: NumberField},
+	"riffs_count":            {Column: "COALESCE(ss.riffs_count, 0)", Type: NumberField},
+	"instrument_id": {
+		Type:      UUIDField,
+		Operators: []Operator{In},
+		Condition: "EXISTS (SELECT 1 FROM song_sections WHERE song_id = songs.id AND instrument_id IN (?))",
+	},
+	"playlist_id": {
+		Type:      UUIDField,
+		Operators: []Operator{NotEqual},
+		Condition: "NOT EXISTS (SELECT 1 FROM playlist_songs WHERE song_id = songs.id AND playlist_id = ?)",
+	},
+})
+
+// AlbumSongFields are the fields of the songs loaded together with their album
+var AlbumSongFields = tableFields("songs", songColumns, "songs")
+
+// PlaylistSongFields are the fields of the songs loaded together with their playlist
+var PlaylistSongFields = withFields(tableFields(`"Song"`, songColumns, "songs", `"Song"`), Fields{
+	"song_track_no": {Column: "playlist_songs.song_track_no", Type: NumberField},
+})
+
+var AlbumFields = withFields(tableFields("albums", map[string]Field{
+	"id":           {Type: UUIDField},
+	"title":        {Type: StringField},
+	"release_date": {Type: DateField},
+	"artist_id":    {Type: UUIDField},
+	"created_at":   {Type: DateField},
+	"updated_at":   {Type: DateField},
+}, "albums"), Fields{
+	`"Artist".name`:    {Column: `"Artist".name`, Type: StringField},
+	"songs_count":      {Column: "COALESCE(ss.songs_count, 0)", Type: NumberField},
+	"rehearsals":       {Column: "COALESCE(ss.rehearsals, 0)", Type: NumberField},
+	"confidence":       {Column: "COALESCE(ss.confidence, 0)", Type: NumberField},
+	"progress":         {Column: "COALESCE(ss.progress, 0)", Type: NumberField},
+	"total_duration":   {Column: "COALESCE(ss.total_duration, 0)", Type: NumberField},
+	"last_time_played": {Column: "ss.last_time_played", Type: DateField},
+})
+
+var ArtistFields = withFields(tableFields("artists", map[string]Field{
+	"id":         {Type: UUIDField},
+	"name":       {Type: StringField},
+	"is_band":    {Type: BoolField},
+	"created_at": {Type: DateField},
+	"updated_at": {Type: DateField},
+}, "artists"), Fields{
+	"band_members_count": {Column: "COALESCE(band_members_count, 0)", Type: NumberField},
+	"albums_count":       {Column: "COALESCE(albums_count, 0)", Type: NumberField},
+	"songs_count":        {Column: "COALESCE(ss.songs_count, 0)", Type: NumberField},
+	"rehearsals":         {Column: "COALESCE(ss.rehearsals, 0)", Type: NumberField},
+	"confidence":         {Column: "COALESCE(ss.confidence, 0)", Type: NumberField},
+	"progress":           {Column: "COALESCE(ss.progress, 0)", Type: NumberField},
+	"total_duration":     {Column: "COALESCE(ss.total_duration, 0)", Type: NumberField},
+	"last_time_played":   {Column: "ss.last_time_played", Type: DateField},
+})
+
+var PlaylistFields = withFields(tableFields("playlists", map[string]Field{
+	"id":          {Type: UUIDField},
+	"title":       {Type: StringField},
+	"description": {Type: StringField},
+	"created_at":  {Type: DateField},
+	"updated_at":  {Type: DateField},
+}, "playlists"), Fields{
+	"songs_count":    {Column: "COALESCE(ss.songs_count, 0)", Type: NumberField},
+	"total_duration": {Column: "COALESCE(ss.total_duration, 0)", Type: NumberField},
+})
+
+var SetlistFields = tableFields("setlists", map[string]Field{
+	"id":         {Type: UUIDField},
+	"title":      {Type: StringField},
+	"venue":      {Type: StringField},
+	"date":       {Type: DateField},
+	"notes":      {Type: StringField},
+	"created_at": {Type: DateField},
+	"updated_at": {Type: DateField},
+}, "setlists")
+
+var PracticeSessionFields = tableFields("practice_sessions", map[string]Field{
+	"id":         {Type: UUIDField},
+	"notes":      {Type: StringField},
+	"started_at": {Type: DateField},
+	"ended_at":   {Type: DateField},
+	"created_at": {Type: DateField},
+	"updated_at": {Type: DateField},
+}, "practice_sessions")
+
+// Entities are the allow-lists by the name used in the validation tags (e.g. search_by=songs)
+var Entities = map[string]Fields{
+	"songs":             SongFields,
+	"album_songs":       AlbumSongFields,
+	"playlist_songs":    PlaylistSongFields,
+	"albums":            AlbumFields,
+	"artists":           ArtistFields,
+	"playlists":         PlaylistFields,
+	"setlists":          SetlistFields,
+	"practice_sessions": PracticeSessionFields,
+}
+
+// tableFields maps the columns of a table to their qualified name,
+// accepting them both plain and prefixed by any of the given aliases (e.g. title and songs.title)
+func tableFields(table string, columns map[string]Field, aliases ...string) Fields {
+	fields := Fields{}
+	for column, field := range columns {
+		field.Column = table + "." + column
+		fields[column] = field
+		for _, alias := range aliases {
+			fields[alias+"."+column] = field
+		}
+	}
+	return fields
+}
+
+func withFields(fields Fields, others Fields) Fields {
+	result := maps.Clone(fields)
+	maps.Copy(result, others)
+	return result
+}
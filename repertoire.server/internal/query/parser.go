@@ -0,0 +1,383 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// relativeDateRegex matches the dates relative to the moment of the query, like now, now-14d or now+2w
+var relativeDateRegex = regexp.MustCompile(`^(?i)now(?:([+-])(\d+)([hdw]))?$`)
+
+var relativeDateUnits = map[string]time.Duration{
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseFilter parses a search expression like "title ~* abc" into a filter tree.
+//
+// Conditions have the form "<property> <operator> <value>" (or "<property> IS [NOT] NULL")
+// and can be combined with AND, OR and parentheses, AND binding tighter than OR.
+// Values can be quoted with single quotes (doubling them to escape), otherwise they stop
+// at the next " AND " or " OR " followed by another condition (or group), or at the parenthesis closing their group,
+// so that "title ~* Rock AND Roll" still matches "Rock AND Roll".
+// The values of IN are separated by commas and dates can also be relative (e.g. now-14d).
+func ParseFilter(fields Fields, input string) (Filter, error) {
+	p := parser{input: input, fields: fields}
+	filter, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.isDone() {
+		return nil, fmt.Errorf("unexpected '%s'", p.input[p.pos:])
+	}
+	return filter, nil
+}
+
+// ParseOrder parses an order like "title desc nulls last"
+func ParseOrder(fields Fields, input string) (Order, error) {
+	split := strings.Fields(input)
+	if len(split) == 0 {
+		return Order{}, errors.New("order is empty")
+	}
+
+	field, ok := fields[split[0]]
+	if !ok {
+		return Order{}, fmt.Errorf("unknown field '%s'", split[0])
+	}
+	if field.Column == "" {
+		return Order{}, fmt.Errorf("field '%s' cannot be ordered by", split[0])
+	}
+	order := Order{Property: split[0], Field: field}
+
+	rest := split[1:]
+	if len(rest) > 0 && (strings.EqualFold(rest[0], "asc") || strings.EqualFold(rest[0], "desc")) {
+		order.Descending = strings.EqualFold(rest[0], "desc")
+		rest = rest[1:]
+	}
+	if len(rest) == 2 && strings.EqualFold(rest[0], "nulls") {
+		switch strings.ToUpper(rest[1]) {
+		case string(NullsFirst):
+			order.Nulls = NullsFirst
+		case string(NullsLast):
+			order.Nulls = NullsLast
+		default:
+			return Order{}, fmt.Errorf("invalid nulls ordering '%s' for field '%s'", rest[1], order.Property)
+		}
+		rest = rest[2:]
+	}
+	if len(rest) > 0 {
+		return Order{}, fmt.Errorf("invalid order '%s' for field '%s'", strings.Join(rest, " "), order.Property)
+	}
+	return order, nil
+}
+
+type parser struct {
+	input  string
+	pos    int
+	fields Fields
+}
+
+func (p *parser) parseOr(depth int) (Filter, error) {
+	return p.parseGroup(depth, Or, p.parseAnd)
+}
+
+func (p *parser) parseAnd(depth int) (Filter, error) {
+	return p.parseGroup(depth, And, p.parseFactor)
+}
+
+func (p *parser) parseGroup(
+	depth int,
+	operator LogicalOperator,
+	parseOperand func(depth int) (Filter, error),
+) (Filter, error) {
+	first, err := parseOperand(depth)
+	if err != nil {
+		return nil, err
+	}
+	filters := []Filter{first}
+	for p.consumeKeyword(string(operator)) {
+		next, err := parseOperand(depth)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, next)
+	}
+	if len(filters) == 1 {
+		return first, nil
+	}
+	return Group{Operator: operator, Filters: filters}, nil
+}
+
+func (p *parser) parseFactor(depth int) (Filter, error) {
+	p.skipSpaces()
+	if p.isDone() {
+		return nil, errors.New("condition is missing")
+	}
+	if p.input[p.pos] != '(' {
+		return p.parseCondition(depth)
+	}
+
+	p.pos++
+	filter, err := p.parseOr(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.isDone() || p.input[p.pos] != ')' {
+		return nil, errors.New("parenthesis is not closed")
+	}
+	p.pos++
+	return filter, nil
+}
+
+func (p *parser) parseCondition(depth int) (Filter, error) {
+	property := p.readWord()
+	field, ok := p.fields[property]
+	if !ok {
+		return nil, fmt.Errorf("unknown field '%s'", property)
+	}
+
+	operator, err := p.readOperator(property)
+	if err != nil {
+		return nil, err
+	}
+	allowedOperators := field.Operators
+	if allowedOperators == nil {
+		allowedOperators = typeOperators[field.Type]
+	}
+	if !slices.Contains(allowedOperators, operator) {
+		return nil, fmt.Errorf("operator '%s' is not allowed on field '%s'", operator, property)
+	}
+
+	condition := Condition{Property: property, Field: field, Operator: operator}
+	if operator == IsNull || operator == IsNotNull {
+		return condition, nil
+	}
+
+	rawValue, err := p.readValue(depth)
+	if err != nil {
+		return nil, fmt.Errorf("%s for field '%s'", err.Error(), property)
+	}
+	if rawValue == "" {
+		return nil, fmt.Errorf("value is missing for field '%s'", property)
+	}
+
+	if operator != In {
+		condition.Value, err = convertValue(field, property, rawValue)
+		return condition, err
+	}
+	var values []any
+	for _, value := range strings.Split(rawValue, ",") {
+		converted, err := convertValue(field, property, strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, converted)
+	}
+	condition.Value = values
+	return condition, nil
+}
+
+func (p *parser) readOperator(property string) (Operator, error) {
+	p.skipSpaces()
+	for _, symbol := range []string{"~*", "!=", "<>", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], symbol) {
+			p.pos += len(symbol)
+			if symbol == "!=" {
+				return NotEqual, nil
+			}
+			return Operator(symbol), nil
+		}
+	}
+
+	word := strings.ToUpper(p.readWord())
+	if word == "IN" {
+		return In, nil
+	}
+	if word == "IS" {
+		switch next := strings.ToUpper(p.readWord()); next {
+		case "NULL":
+			return IsNull, nil
+		case "NOT":
+			if strings.ToUpper(p.readWord()) == "NULL" {
+				return IsNotNull, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("invalid operator for field '%s'", property)
+}
+
+func (p *parser) readValue(depth int) (string, error) {
+	p.skipSpaces()
+	if !p.isDone() && p.input[p.pos] == '\'' {
+		return p.readQuotedValue()
+	}
+
+	end := len(p.input)
+	if depth > 0 {
+		if i := strings.Index(p.input[p.pos:], ")"); i >= 0 {
+			end = p.pos + i
+		}
+	}
+	for _, keyword := range []string{" AND ", " OR "} {
+		for from := p.pos; ; {
+			i := strings.Index(p.input[from:end], keyword)
+			if i < 0 {
+				break
+			}
+			if p.isClauseStart(from + i + len(keyword)) {
+				end = from + i
+				break
+			}
+			from += i + 1
+		}
+	}
+	value := strings.TrimSpace(p.input[p.pos:end])
+	p.pos = end
+	return value, nil
+}
+
+func (p *parser) readQuotedValue() (string, error) {
+	var value strings.Builder
+	for i := p.pos + 1; i < len(p.input); i++ {
+		if p.input[i] != '\'' {
+			value.WriteByte(p.input[i])
+			continue
+		}
+		if i+1 < len(p.input) && p.input[i+1] == '\'' {
+			value.WriteByte('\'')
+			i++
+			continue
+		}
+		p.pos = i + 1
+		return value.String(), nil
+	}
+	return "", errors.New("quote is not closed")
+}
+
+func (p *parser) readWord() string {
+	p.skipSpaces()
+	start := p.pos
+	for !p.isDone() && !unicode.IsSpace(rune(p.input[p.pos])) && !strings.ContainsRune("()", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) consumeKeyword(keyword string) bool {
+	start := p.pos
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], keyword) {
+		next := p.pos + len(keyword)
+		if next < len(p.input) &&
+			(unicode.IsSpace(rune(p.input[next])) || p.input[next] == '(') &&
+			p.isClauseStart(next) {
+			p.pos = next
+			return true
+		}
+	}
+	p.pos = start
+	return false
+}
+
+// isClauseStart tells whether a condition (a word followed by an operator) or a group starts at the position,
+// as AND and OR are keywords only between clauses, and can otherwise be part of the unquoted values
+func (p *parser) isClauseStart(pos int) bool {
+	probe := parser{input: p.input, pos: pos, fields: p.fields}
+	probe.skipSpaces()
+	if probe.isDone() {
+		return false
+	}
+	if probe.input[probe.pos] == '(' {
+		return true
+	}
+	property := probe.readWord()
+	if property == "" {
+		return false
+	}
+	_, err := probe.readOperator(property)
+	return err == nil
+}
+
+func (p *parser) skipSpaces() {
+	for !p.isDone() && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *parser) isDone() bool {
+	return p.pos >= len(p.input)
+}
+
+func convertValue(field Field, property string, value string) (any, error) {
+	invalidValueError := fmt.Errorf("invalid %s value '%s' for field '%s'", field.Type, value, property)
+	switch field.Type {
+	case NumberField:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, invalidValueError
+		}
+		return number, nil
+	case DateField:
+		if date, ok := parseRelativeDate(value); ok {
+			return date, nil
+		}
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, value); err == nil {
+				return date, nil
+			}
+		}
+		return nil, invalidValueError
+	case UUIDField:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, invalidValueError
+		}
+		return id, nil
+	case BoolField:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, invalidValueError
+		}
+		return boolean, nil
+	case EnumField:
+		if !slices.Contains(field.Values, value) {
+			return nil, invalidValueError
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+func parseRelativeDate(value string) (time.Time, bool) {
+	match := relativeDateRegex.FindStringSubmatch(value)
+	if match == nil {
+		return time.Time{}, false
+	}
+	now := time.Now().UTC()
+	if match[1] == "" {
+		return now, true
+	}
+	amount, err := strconv.Atoi(match[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	offset := time.Duration(amount) * relativeDateUnits[strings.ToLower(match[3])]
+	if match[1] == "-" {
+		offset = -offset
+	}
+	return now.Add(offset), true
+}
@@ -42,6 +42,13 @@ func ConflictError(err error) *ErrorCode {
 	}
 }
 
+func PayloadTooLargeError(err error) *ErrorCode {
+	return &ErrorCode{
+		Error: err,
+		Code:  http.StatusRequestEntityTooLarge,
+	}
+}
+
 func InternalServerError(err error) *ErrorCode {
 	return &ErrorCode{
 		Error: err,
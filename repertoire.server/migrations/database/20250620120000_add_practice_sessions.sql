@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.practice_sessions
+(
+    id         uuid                                               not null primary key,
+    notes      text                                               not null,
+    started_at timestamp with time zone                           not null,
+    ended_at   timestamp with time zone,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    user_id    uuid                                               not null constraint fk_users_practice_sessions references public.users
+);
+
+CREATE TABLE public.practice_session_songs
+(
+    id                  uuid                                               not null primary key,
+    practice_session_id uuid                                               not null constraint fk_practice_sessions_songs references public.practice_sessions on delete cascade,
+    song_id             uuid                                               not null constraint fk_songs_practice_session_songs references public.songs on delete cascade,
+    created_at          timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE TABLE public.practice_session_sections
+(
+    id                       uuid   not null primary key,
+    occurrences              bigint not null,
+    practice_session_song_id uuid   not null constraint fk_practice_session_songs_sections references public.practice_session_songs on delete cascade,
+    song_section_id          uuid   not null constraint fk_song_sections_practice_session_sections references public.song_sections on delete cascade
+);
+
+CREATE INDEX idx_practice_sessions_user_id ON practice_sessions(user_id);
+CREATE INDEX idx_practice_session_songs_practice_session_id ON practice_session_songs(practice_session_id);
+CREATE INDEX idx_practice_session_sections_practice_session_song_id ON practice_session_sections(practice_session_song_id);
+
+-- only one practice session can be in progress at a time
+CREATE UNIQUE INDEX idx_practice_sessions_user_id_in_progress ON practice_sessions(user_id) WHERE ended_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.practice_session_sections;
+DROP TABLE public.practice_session_songs;
+DROP TABLE public.practice_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.rehearsal_batches
+(
+    id                        uuid                                               not null primary key,
+    previous_last_time_played timestamp with time zone,
+    created_at                timestamp with time zone default CURRENT_TIMESTAMP not null,
+    song_id                   uuid                                               not null constraint fk_songs_rehearsal_batches references public.songs on delete cascade
+);
+
+ALTER TABLE public.song_section_histories
+    ADD COLUMN batch_id uuid constraint fk_rehearsal_batches_song_section_histories references public.rehearsal_batches on delete cascade;
+
+CREATE INDEX idx_rehearsal_batches_song_id ON rehearsal_batches(song_id);
+CREATE INDEX idx_song_section_histories_batch_id ON song_section_histories(batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.song_section_histories
+    DROP COLUMN batch_id;
+DROP TABLE public.rehearsal_batches;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.setlists
+(
+    id         uuid                                               not null primary key,
+    title      varchar(100)                                       not null,
+    venue      varchar(100)                                       not null,
+    date       timestamp with time zone,
+    notes      text                                               not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    updated_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    user_id    uuid                                               not null constraint fk_users_setlists references public.users
+);
+
+CREATE TABLE public.setlist_entries
+(
+    id               uuid                                               not null primary key,
+    type             varchar(30)                                        not null,
+    title            varchar(100)                                       not null,
+    planned_duration bigint,
+    entry_no         bigint                                             not null,
+    setlist_id       uuid                                               not null constraint fk_setlists_entries references public.setlists on delete cascade,
+    song_id          uuid constraint fk_songs_setlist_entries references public.songs on delete cascade,
+    created_at       timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_setlists_user_id ON setlists(user_id);
+CREATE INDEX idx_setlist_entries_setlist_id ON setlist_entries(setlist_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.setlist_entries;
+DROP TABLE public.setlists;
+-- +goose StatementEnd
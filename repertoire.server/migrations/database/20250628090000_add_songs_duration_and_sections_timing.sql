@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.songs
+    ADD COLUMN duration bigint;
+
+ALTER TABLE public.song_sections
+    ADD COLUMN start_offset   bigint,
+    ADD COLUMN bar_count      bigint,
+    ADD COLUMN time_signature varchar(10);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.song_sections
+    DROP COLUMN time_signature,
+    DROP COLUMN bar_count,
+    DROP COLUMN start_offset;
+
+ALTER TABLE public.songs
+    DROP COLUMN duration;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.outbox_messages
+(
+    id         uuid                                               not null primary key,
+    queue      varchar(100)                                       not null,
+    topic      varchar(100)                                       not null,
+    payload    bytea                                              not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_outbox_messages_created_at ON outbox_messages(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.outbox_messages;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.dead_letter_messages
+(
+    id         uuid                                               not null primary key,
+    message_id varchar(100)                                       not null,
+    queue      varchar(100)                                       not null,
+    topic      varchar(100)                                       not null,
+    handler    varchar(100)                                       not null,
+    payload    bytea                                              not null,
+    error      text                                               not null,
+    attempts   bigint                                             not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_dead_letter_messages_topic ON dead_letter_messages(topic);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.dead_letter_messages;
+-- +goose StatementEnd
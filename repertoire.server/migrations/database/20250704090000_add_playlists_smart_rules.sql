@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.playlists
+    ADD COLUMN smart_rules jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.playlists
+    DROP COLUMN smart_rules;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE public.search_documents
+(
+    id             varchar(100) not null primary key,
+    user_id        uuid         not null,
+    type           varchar(50)  not null,
+    document       jsonb        not null,
+    content        text         not null,
+    content_vector tsvector generated always as (to_tsvector('simple', content)) stored
+);
+
+CREATE INDEX idx_search_documents_user_id_type ON search_documents(user_id, type);
+CREATE INDEX idx_search_documents_content_vector ON search_documents USING gin(content_vector);
+CREATE INDEX idx_search_documents_content_trigram ON search_documents USING gin(content gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.search_documents;
+-- +goose StatementEnd
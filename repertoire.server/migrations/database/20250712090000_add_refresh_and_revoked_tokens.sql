@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.refresh_tokens
+(
+    id              uuid                                               not null primary key,
+    user_id         uuid                                               not null constraint fk_users_refresh_tokens references public.users on delete cascade,
+    family_id       uuid                                               not null,
+    token_hash      varchar(64)                                        not null unique,
+    access_token_id uuid                                               not null,
+    expires_at      timestamp with time zone                           not null,
+    revoked_at      timestamp with time zone,
+    replaced_by_id  uuid,
+    created_at      timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
+
+CREATE TABLE public.revoked_tokens
+(
+    id         uuid                                               not null primary key,
+    expires_at timestamp with time zone                           not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.revoked_tokens;
+DROP TABLE public.refresh_tokens;
+-- +goose StatementEnd
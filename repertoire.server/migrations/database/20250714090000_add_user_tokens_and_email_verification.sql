@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.users ADD email_verified boolean default false not null;
+
+CREATE TABLE public.user_tokens
+(
+    id         uuid                                               not null primary key,
+    user_id    uuid                                               not null constraint fk_users_user_tokens references public.users on delete cascade,
+    type       varchar(30)                                        not null,
+    token_hash varchar(64)                                        not null unique,
+    expires_at timestamp with time zone                           not null,
+    used_at    timestamp with time zone,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null
+);
+
+CREATE INDEX idx_user_tokens_user_id_type ON user_tokens(user_id, type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.user_tokens;
+
+ALTER TABLE public.users DROP COLUMN email_verified;
+-- +goose StatementEnd
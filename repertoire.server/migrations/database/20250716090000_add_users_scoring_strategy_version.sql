@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.users
+    ADD COLUMN scoring_strategy_version integer DEFAULT 1 NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.users
+    DROP COLUMN scoring_strategy_version;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.practice_session_songs
+    ADD COLUMN batch_id uuid constraint fk_rehearsal_batches_practice_session_songs references public.rehearsal_batches on delete cascade;
+
+CREATE INDEX idx_practice_session_songs_batch_id ON practice_session_songs(batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.practice_session_songs
+    DROP COLUMN batch_id;
+-- +goose StatementEnd
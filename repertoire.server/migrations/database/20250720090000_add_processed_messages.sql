@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public.processed_messages
+(
+    message_id varchar(100)                                       not null,
+    handler    varchar(100)                                       not null,
+    created_at timestamp with time zone default CURRENT_TIMESTAMP not null,
+    primary key (message_id, handler)
+);
+
+CREATE INDEX idx_processed_messages_created_at ON processed_messages(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE public.processed_messages;
+-- +goose StatementEnd
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/data/logger"
+	"repertoire/server/data/search"
+	"repertoire/server/internal"
+	"repertoire/server/internal/migration/utils"
+	"repertoire/server/model"
+
+	"github.com/meilisearch/meilisearch-go"
+)
+
+var uid = "20250706090000"
+var name = "add_facets"
+
+func main() {
+	env := internal.NewEnv()
+	log := logger.NewLogger(env)
+	meiliClient := search.NewMeiliClient(env)
+	dbClient := database.NewClient(logger.NewGormLogger(log), env)
+
+	if utils.HasMigrationAlreadyBeenApplied(meiliClient, uid) {
+		return
+	}
+
+	_, err := meiliClient.Index("search").UpdateFilterableAttributes(&[]interface{}{
+		"id", "type", "userId", "album", "album.id", "artist", "artist.id",
+		"releaseYear", "difficulty", "guitarTuningId",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = meiliClient.Index("search").UpdateSortableAttributes(&[]string{
+		"title", "name", "updatedAt", "createdAt", "album", "album.title", "artist", "artist.name", "releaseYear",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	// titles are usually short, so typos are allowed sooner, but never on identifiers or numbers
+	_, err = meiliClient.Index("search").UpdateTypoTolerance(&meilisearch.TypoTolerance{
+		Enabled: true,
+		MinWordSizeForTypos: meilisearch.MinWordSizeForTypos{
+			OneTypo:  4,
+			TwoTypos: 8,
+		},
+		DisableOnAttributes: []string{"id", "userId", "guitarTuningId", "artist.id", "album.id"},
+		DisableOnNumbers:    true,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info("Importing albums...")
+	addAlbums(dbClient, meiliClient)
+	log.Info("Albums imported!")
+
+	log.Info("Importing songs...")
+	addSongs(dbClient, meiliClient)
+	log.Info("Songs imported!")
+
+	utils.SaveMigrationStatus(meiliClient, uid, name)
+}
+
+func addAlbums(dbClient database.Client, meiliClient search.MeiliClient) {
+	var albums []model.Album
+	err := dbClient.Joins("Artist").Find(&albums).Error
+	if err != nil {
+		panic(err)
+	}
+
+	if len(albums) == 0 {
+		return
+	}
+
+	var meiliAlbums []model.AlbumSearch
+	for _, album := range albums {
+		meiliAlbums = append(meiliAlbums, album.ToSearch())
+	}
+	_, err = meiliClient.Index("search").AddDocuments(meiliAlbums, nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func addSongs(dbClient database.Client, meiliClient search.MeiliClient) {
+	var songs []model.Song
+	err := dbClient.Joins("Album").Joins("Artist").Find(&songs).Error
+	if err != nil {
+		panic(err)
+	}
+
+	if len(songs) == 0 {
+		return
+	}
+
+	var meiliSongs []model.SongSearch
+	for _, song := range songs {
+		meiliSongs = append(meiliSongs, song.ToSearch())
+	}
+	_, err = meiliClient.Index("search").AddDocuments(meiliSongs, nil)
+	if err != nil {
+		panic(err)
+	}
+}
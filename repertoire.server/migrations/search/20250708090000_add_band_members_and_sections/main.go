@@ -0,0 +1,114 @@
+package main
+
+import (
+	"repertoire/server/data/database"
+	"repertoire/server/data/logger"
+	"repertoire/server/data/search"
+	"repertoire/server/internal"
+	"repertoire/server/internal/migration/utils"
+	"repertoire/server/model"
+)
+
+var uid = "20250708090000"
+var name = "add_band_members_and_sections"
+
+func main() {
+	env := internal.NewEnv()
+	log := logger.NewLogger(env)
+	meiliClient := search.NewMeiliClient(env)
+	dbClient := database.NewClient(logger.NewGormLogger(log), env)
+
+	if utils.HasMigrationAlreadyBeenApplied(meiliClient, uid) {
+		return
+	}
+
+	// the sections are filtered by their song, to keep them in sync when the song changes
+	_, err := meiliClient.Index("search").UpdateFilterableAttributes(&[]interface{}{
+		"id", "type", "userId", "album", "album.id", "artist", "artist.id", "song", "song.id",
+		"releaseYear", "difficulty", "guitarTuningId",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info("Importing band members...")
+	addBandMembers(dbClient, meiliClient)
+	log.Info("Band members imported!")
+
+	log.Info("Importing songs and their sections...")
+	addSongsAndSections(dbClient, meiliClient)
+	log.Info("Songs and sections imported!")
+
+	utils.SaveMigrationStatus(meiliClient, uid, name)
+}
+
+func addBandMembers(dbClient database.Client, meiliClient search.MeiliClient) {
+	var artists []model.Artist
+	err := dbClient.
+		Preload("BandMembers").
+		Preload("BandMembers.Roles").
+		Where("is_band = ?", true).
+		Find(&artists).
+		Error
+	if err != nil {
+		panic(err)
+	}
+
+	var meiliBandMembers []model.BandMemberSearch
+	for _, artist := range artists {
+		for _, member := range artist.BandMembers {
+			member.Artist = artist
+			meiliBandMembers = append(meiliBandMembers, member.ToSearch())
+		}
+	}
+
+	if len(meiliBandMembers) == 0 {
+		return
+	}
+
+	_, err = meiliClient.Index("search").AddDocuments(meiliBandMembers, nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func addSongsAndSections(dbClient database.Client, meiliClient search.MeiliClient) {
+	var songs []model.Song
+	err := dbClient.
+		Joins("Album").
+		Joins("Artist").
+		Joins("GuitarTuning").
+		Preload("Sections").
+		Preload("Sections.SongSectionType").
+		Find(&songs).
+		Error
+	if err != nil {
+		panic(err)
+	}
+
+	if len(songs) == 0 {
+		return
+	}
+
+	var meiliSongs []model.SongSearch
+	var meiliSections []model.SongSectionSearch
+	for _, song := range songs {
+		meiliSongs = append(meiliSongs, song.ToSearch())
+		meiliSections = append(meiliSections, song.ToSectionsSearch()...)
+	}
+
+	// songs are re-imported, as their documents now hold the description, tuning and section names
+	_, err = meiliClient.Index("search").AddDocuments(meiliSongs, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(meiliSections) == 0 {
+		return
+	}
+
+	_, err = meiliClient.Index("search").AddDocuments(meiliSections, nil)
+	if err != nil {
+		panic(err)
+	}
+}
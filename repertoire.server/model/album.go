@@ -16,16 +16,18 @@ type EnhancedAlbum struct {
 	Confidence     float64    `gorm:"->" json:"confidence"`
 	Progress       float64    `gorm:"->" json:"progress"`
 	LastTimePlayed *time.Time `gorm:"->" json:"lastTimePlayed"`
+	TotalDuration  uint       `gorm:"->" json:"totalDuration"`
 }
 
 type Album struct {
-	ID          uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Title       string             `gorm:"size:100; not null" json:"title"`
-	ReleaseDate *internal.Date     `json:"releaseDate"`
-	ImageURL    *internal.FilePath `json:"imageUrl"`
-	ArtistID    *uuid.UUID         `json:"artistId"`
-	Artist      *Artist            `json:"artist"`
-	Songs       []Song             `gorm:"constraint:OnDelete:SET NULL" json:"songs"`
+	ID            uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Title         string                  `gorm:"size:100; not null" json:"title"`
+	ReleaseDate   *internal.Date          `json:"releaseDate"`
+	ImageURL      *internal.FilePath      `json:"imageUrl"`
+	ImageVariants *internal.ImageVariants `gorm:"-" json:"imageVariants"`
+	ArtistID      *uuid.UUID              `json:"artistId"`
+	Artist        *Artist                 `json:"artist"`
+	Songs         []Song                  `gorm:"constraint:OnDelete:SET NULL" json:"songs"`
 
 	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"default:current_timestamp; not null" json:"updatedAt"`
@@ -39,9 +41,11 @@ func (a *Album) BeforeSave(*gorm.DB) error {
 
 func (a *Album) AfterFind(*gorm.DB) error {
 	a.ImageURL = a.ImageURL.ToFullURL()
+	a.ImageVariants = a.ImageURL.ToImageVariants()
 	// When Joins instead of Preload, AfterFind Hook is not used
 	if a.Artist != nil {
 		a.Artist.ImageURL = a.Artist.ImageURL.ToFullURL()
+		a.Artist.ImageVariants = a.Artist.ImageURL.ToImageVariants()
 	}
 	return nil
 }
@@ -18,13 +18,15 @@ type EnhancedArtist struct {
 	Confidence       float64    `gorm:"->" json:"confidence"`
 	Progress         float64    `gorm:"->" json:"progress"`
 	LastTimePlayed   *time.Time `gorm:"->" json:"lastTimePlayed"`
+	TotalDuration    uint       `gorm:"->" json:"totalDuration"`
 }
 
 type Artist struct {
-	ID       uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Name     string             `gorm:"size:100; not null" json:"name"`
-	IsBand   bool               `gorm:"not null" json:"isBand"`
-	ImageURL *internal.FilePath `json:"imageUrl"`
+	ID            uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Name          string                  `gorm:"size:100; not null" json:"name"`
+	IsBand        bool                    `gorm:"not null" json:"isBand"`
+	ImageURL      *internal.FilePath      `json:"imageUrl"`
+	ImageVariants *internal.ImageVariants `gorm:"-" json:"imageVariants"`
 
 	Albums      []Album      `gorm:"constraint:OnDelete:SET NULL" json:"albums"`
 	Songs       []Song       `gorm:"constraint:OnDelete:SET NULL" json:"songs"`
@@ -42,17 +44,19 @@ func (a *Artist) BeforeSave(*gorm.DB) error {
 
 func (a *Artist) AfterFind(*gorm.DB) error {
 	a.ImageURL = a.ImageURL.ToFullURL()
+	a.ImageVariants = a.ImageURL.ToImageVariants()
 	return nil
 }
 
 // Band Member
 
 type BandMember struct {
-	ID       uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Name     string             `gorm:"size:100; not null" json:"name"`
-	Order    uint               `gorm:"not null" json:"-"`
-	Color    *string            `gorm:"size:7" json:"color"`
-	ImageURL *internal.FilePath `json:"imageUrl"`
+	ID            uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Name          string                  `gorm:"size:100; not null" json:"name"`
+	Order         uint                    `gorm:"not null" json:"-"`
+	Color         *string                 `gorm:"size:7" json:"color"`
+	ImageURL      *internal.FilePath      `json:"imageUrl"`
+	ImageVariants *internal.ImageVariants `gorm:"-" json:"imageVariants"`
 
 	ArtistID     uuid.UUID        `gorm:"not null" json:"-"`
 	Artist       Artist           `json:"-"`
@@ -71,5 +75,6 @@ func (b *BandMember) BeforeSave(*gorm.DB) error {
 
 func (b *BandMember) AfterFind(*gorm.DB) error {
 	b.ImageURL = b.ImageURL.ToFullURL()
+	b.ImageVariants = b.ImageURL.ToImageVariants()
 	return nil
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterMessage is a message that could not be handled, even after retrying it,
+// which is kept until it gets replayed or discarded
+type DeadLetterMessage struct {
+	ID        uuid.UUID       `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	MessageID string          `gorm:"size:100; not null" json:"messageId"`
+	Queue     string          `gorm:"size:100; not null" json:"queue"`
+	Topic     string          `gorm:"size:100; not null" json:"topic"`
+	Handler   string          `gorm:"size:100; not null" json:"handler"`
+	Payload   json.RawMessage `gorm:"not null" json:"payload"`
+	Error     string          `gorm:"not null" json:"error"`
+	Attempts  uint            `gorm:"not null" json:"attempts"`
+	CreatedAt time.Time       `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
+}
+
+type DeadLetterTopicMetrics struct {
+	Topic string `json:"topic"`
+	Count int64  `json:"count"`
+}
@@ -72,6 +72,9 @@ type SongFiltersMetadata struct {
 	MinBpm *uint `gorm:"->" json:"minBpm"`
 	MaxBpm *uint `gorm:"->" json:"maxBpm"`
 
+	MinDuration *uint `gorm:"->" json:"minDuration"`
+	MaxDuration *uint `gorm:"->" json:"maxDuration"`
+
 	DifficultiesAgg string             `gorm:"->; column:difficulties" json:"-"`
 	Difficulties    []enums.Difficulty `gorm:"-" json:"difficulties"`
 
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// OrphanedFilesCollection reports the files that are not referenced by anything anymore,
+// which have also been collected, unless it is a dry run
+type OrphanedFilesCollection struct {
+	DryRun bool           `json:"dryRun"`
+	Size   int64          `json:"size"`
+	Files  []OrphanedFile `json:"files"`
+}
+
+type OrphanedFile struct {
+	Path       string    `json:"path"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is a message saved in the same transaction as the change that produced it,
+// which is relayed to the message broker afterward
+type OutboxMessage struct {
+	ID        uuid.UUID `gorm:"primaryKey; type:uuid; <-:create"`
+	Queue     string    `gorm:"size:100; not null"`
+	Topic     string    `gorm:"size:100; not null"`
+	Payload   []byte    `gorm:"not null"`
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create"`
+}
@@ -11,22 +11,32 @@ import (
 
 type EnhancedPlaylist struct {
 	Playlist
-	SongsCount float64 `gorm:"->" json:"songsCount"`
+	SongsCount    float64 `gorm:"->" json:"songsCount"`
+	TotalDuration uint    `gorm:"->" json:"totalDuration"`
 }
 
 type Playlist struct {
-	ID            uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Title         string             `gorm:"size:100; not null" json:"title"`
-	Description   string             `gorm:"not null" json:"description"`
-	ImageURL      *internal.FilePath `json:"imageUrl"`
-	Songs         []Song             `gorm:"many2many:playlist_songs" json:"songs"`
-	PlaylistSongs []PlaylistSong     `gorm:"foreignKey:PlaylistID; constraint:OnDelete:CASCADE" json:"-"`
+	ID            uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Title         string                  `gorm:"size:100; not null" json:"title"`
+	Description   string                  `gorm:"not null" json:"description"`
+	ImageURL      *internal.FilePath      `json:"imageUrl"`
+	ImageVariants *internal.ImageVariants `gorm:"-" json:"imageVariants"`
+	Songs         []Song                  `gorm:"many2many:playlist_songs" json:"songs"`
+	PlaylistSongs []PlaylistSong          `gorm:"foreignKey:PlaylistID; constraint:OnDelete:CASCADE" json:"-"`
+	SmartRules    *PlaylistSmartRules     `gorm:"type:jsonb; serializer:json" json:"smartRules"`
 
 	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"default:current_timestamp; not null" json:"updatedAt"`
 	UserID    uuid.UUID `gorm:"foreignKey:UserID; references:ID; notnull" json:"userId"`
 }
 
+// PlaylistSmartRules make the playlist resolve its songs live, by searching the songs of the user
+type PlaylistSmartRules struct {
+	SearchBy []string `json:"searchBy"`
+	OrderBy  []string `json:"orderBy"`
+	Limit    *uint    `json:"limit"`
+}
+
 type PlaylistSong struct {
 	ID          uuid.UUID `gorm:"primaryKey; type:uuid; <-:create"`
 	PlaylistID  uuid.UUID `gorm:"type:uuid; not null; <-:create"`
@@ -45,5 +55,6 @@ func (p *Playlist) BeforeSave(*gorm.DB) error {
 
 func (p *Playlist) AfterFind(*gorm.DB) error {
 	p.ImageURL = p.ImageURL.ToFullURL()
+	p.ImageVariants = p.ImageURL.ToImageVariants()
 	return nil
 }
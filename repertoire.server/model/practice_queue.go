@@ -0,0 +1,14 @@
+package model
+
+type PracticeQueueSong struct {
+	Song              Song                   `json:"song"`
+	Priority          float64                `json:"priority"`
+	EstimatedDuration uint                   `json:"estimatedDuration"`
+	DueSections       []PracticeQueueSection `json:"dueSections"`
+}
+
+type PracticeQueueSection struct {
+	SongSection  SongSection `json:"songSection"`
+	Priority     float64     `json:"priority"`
+	IntervalDays uint        `json:"intervalDays"`
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PracticeSession struct {
+	ID        uuid.UUID             `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Notes     string                `gorm:"not null" json:"notes"`
+	StartedAt time.Time             `gorm:"not null" json:"startedAt"`
+	EndedAt   *time.Time            `json:"endedAt"`
+	Songs     []PracticeSessionSong `gorm:"constraint:OnDelete:CASCADE" json:"songs"`
+
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"default:current_timestamp; not null" json:"updatedAt"`
+	UserID    uuid.UUID `gorm:"foreignKey:UserID; references:ID; notnull" json:"userId"`
+}
+
+type PracticeSessionSong struct {
+	ID                uuid.UUID                `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	PracticeSessionID uuid.UUID                `gorm:"not null" json:"-"`
+	SongID            uuid.UUID                `gorm:"not null" json:"-"`
+	Song              *Song                    `json:"song"`
+	BatchID           *uuid.UUID               `json:"-"`
+	Sections          []PracticeSessionSection `gorm:"constraint:OnDelete:CASCADE" json:"sections"`
+
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
+}
+
+type PracticeSessionSection struct {
+	ID                    uuid.UUID    `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Occurrences           uint         `gorm:"not null" json:"occurrences"`
+	PracticeSessionSongID uuid.UUID    `gorm:"not null" json:"-"`
+	SongSectionID         uuid.UUID    `gorm:"not null" json:"-"`
+	SongSection           *SongSection `json:"songSection"`
+}
+
+func (p *PracticeSession) IsFinished() bool {
+	return p.EndedAt != nil
+}
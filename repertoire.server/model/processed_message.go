@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// ProcessedMessage records that a handler has handled a message,
+// so that the message is not handled again when it gets delivered twice
+type ProcessedMessage struct {
+	MessageID string    `gorm:"primaryKey; size:100"`
+	Handler   string    `gorm:"primaryKey; size:100"`
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create"`
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type ProgressTimelinePoint struct {
+	Date       time.Time `json:"date"`
+	Rehearsals uint      `json:"rehearsals"`
+	Confidence float64   `json:"confidence"`
+	Progress   float64   `json:"progress"`
+}
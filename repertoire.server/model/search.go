@@ -1,8 +1,11 @@
 package model
 
 import (
+	"cmp"
 	"repertoire/server/internal"
 	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,12 +13,55 @@ import (
 
 type SearchResult []string
 
+type SearchOptions struct {
+	Facets           bool
+	Highlight        bool
+	TypoTolerance    *bool
+	MatchingStrategy *enums.SearchMatchingStrategy
+}
+
+type SearchResults[T any] struct {
+	wrapper.WithTotalCount[T]
+	// Facets count the results by value, for each faceted attribute (e.g. difficulty: easy: 3)
+	Facets map[string]map[string]int64 `json:"facets,omitempty"`
+	// FacetStats are the ranges of the numeric faceted attributes (e.g. releaseYear)
+	FacetStats map[string]SearchFacetStats `json:"facetStats,omitempty"`
+}
+
+type SearchFacetStats struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// SearchFormatted holds the searchable texts with the matches highlighted and cropped around them
+type SearchFormatted struct {
+	Title string `json:"title,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// SearchDocument is a search document stored in Postgres, when it is used as the search engine instead of Meilisearch
+type SearchDocument struct {
+	ID       string         `gorm:"primaryKey; size:100"`
+	UserID   uuid.UUID      `gorm:"not null"`
+	Type     string         `gorm:"size:50; not null"`
+	Document map[string]any `gorm:"type:jsonb; serializer:json; not null"`
+	// Content holds all the searchable texts of the document, from which the full-text vector is generated
+	Content string `gorm:"not null"`
+}
+
+type SearchReconciliation struct {
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
 type SearchBase struct {
 	ID        string           `json:"id"`
 	UpdatedAt time.Time        `json:"updatedAt"`
 	CreatedAt time.Time        `json:"createdAt"`
 	Type      enums.SearchType `json:"type"`
 	UserID    uuid.UUID        `json:"userId"`
+	Formatted *SearchFormatted `json:"formatted,omitempty"`
 }
 
 // Artist
@@ -40,6 +86,54 @@ func (a *Artist) ToSearch() ArtistSearch {
 	}
 }
 
+// Band Member
+
+type BandMemberSearch struct {
+	ImageUrl *internal.FilePath      `json:"imageUrl"`
+	Name     string                  `json:"name"`
+	Roles    []string                `json:"roles"`
+	Artist   *BandMemberArtistSearch `json:"artist"`
+	SearchBase
+}
+
+type BandMemberArtistSearch struct {
+	ID        uuid.UUID          `json:"id"`
+	ImageUrl  *internal.FilePath `json:"imageUrl"`
+	Name      string             `json:"name"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// ToSearch expects the artist of the band member to be populated, as the member does not belong to a user directly
+func (b *BandMember) ToSearch() BandMemberSearch {
+	roles := slices.Clone(b.Roles)
+	slices.SortFunc(roles, func(a, b BandMemberRole) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+
+	return BandMemberSearch{
+		ImageUrl: b.ImageURL.StripURL(),
+		Name:     b.Name,
+		Roles:    roleNames,
+		Artist: &BandMemberArtistSearch{
+			ID:        b.Artist.ID,
+			Name:      b.Artist.Name,
+			UpdatedAt: b.Artist.UpdatedAt.UTC(),
+			ImageUrl:  b.Artist.ImageURL.StripURL(),
+		},
+		SearchBase: SearchBase{
+			ID:        "bandMember-" + b.ID.String(),
+			UpdatedAt: b.UpdatedAt.UTC(),
+			CreatedAt: b.CreatedAt.UTC(),
+			Type:      enums.BandMember,
+			UserID:    b.Artist.UserID,
+		},
+	}
+}
+
 // Album
 
 type AlbumSearch struct {
@@ -47,6 +141,7 @@ type AlbumSearch struct {
 	Title       string             `json:"title"`
 	Artist      *AlbumArtistSearch `json:"artist"`
 	ReleaseDate *string            `json:"releaseDate"`
+	ReleaseYear *int               `json:"releaseYear"`
 	SearchBase
 }
 
@@ -67,6 +162,7 @@ func (a *Album) ToSearch() AlbumSearch {
 		ImageUrl:    a.ImageURL.StripURL(),
 		Title:       a.Title,
 		ReleaseDate: releaseDate,
+		ReleaseYear: releaseYear(a.ReleaseDate),
 		SearchBase: SearchBase{
 			ID:        "album-" + a.ID.String(),
 			UpdatedAt: a.UpdatedAt.UTC(),
@@ -98,11 +194,18 @@ func (a *Artist) ToAlbumSearch() *AlbumArtistSearch {
 // Song
 
 type SongSearch struct {
-	ImageUrl    *internal.FilePath `json:"imageUrl"`
-	Title       string             `json:"title"`
-	ReleaseDate *string            `json:"releaseDate"`
-	Artist      *SongArtistSearch  `json:"artist"`
-	Album       *SongAlbumSearch   `json:"album"`
+	ImageUrl       *internal.FilePath `json:"imageUrl"`
+	Title          string             `json:"title"`
+	Description    string             `json:"description"`
+	ReleaseDate    *string            `json:"releaseDate"`
+	ReleaseYear    *int               `json:"releaseYear"`
+	Bpm            *uint              `json:"bpm"`
+	Difficulty     *enums.Difficulty  `json:"difficulty"`
+	GuitarTuningID *uuid.UUID         `json:"guitarTuningId"`
+	GuitarTuning   *string            `json:"guitarTuning"`
+	Sections       []string           `json:"sections"`
+	Artist         *SongArtistSearch  `json:"artist"`
+	Album          *SongAlbumSearch   `json:"album"`
 	SearchBase
 }
 
@@ -121,16 +224,34 @@ type SongArtistSearch struct {
 	UpdatedAt time.Time          `json:"updatedAt"`
 }
 
+// ToSearch expects the guitar tuning and the sections to be populated,
+// otherwise they are going to be emptied when the document gets updated
 func (s *Song) ToSearch() SongSearch {
 	var releaseDate *string
 	if s.ReleaseDate != nil {
 		rd := (*time.Time)(s.ReleaseDate).Format("2006-01-02")
 		releaseDate = &rd
 	}
+	var guitarTuning *string
+	if s.GuitarTuning != nil {
+		guitarTuning = &s.GuitarTuning.Name
+	}
+	sectionNames := make([]string, 0, len(s.Sections))
+	for _, section := range sortedSections(s.Sections) {
+		sectionNames = append(sectionNames, section.Name)
+	}
+
 	search := SongSearch{
-		ImageUrl:    s.ImageURL.StripURL(),
-		Title:       s.Title,
-		ReleaseDate: releaseDate,
+		ImageUrl:       s.ImageURL.StripURL(),
+		Title:          s.Title,
+		Description:    s.Description,
+		ReleaseDate:    releaseDate,
+		ReleaseYear:    releaseYear(s.ReleaseDate),
+		Bpm:            s.Bpm,
+		Difficulty:     s.Difficulty,
+		GuitarTuningID: s.GuitarTuningID,
+		GuitarTuning:   guitarTuning,
+		Sections:       sectionNames,
 		SearchBase: SearchBase{
 			ID:        "song-" + s.ID.String(),
 			UpdatedAt: s.UpdatedAt.UTC(),
@@ -181,6 +302,53 @@ func (a *Album) ToSongSearch() *SongAlbumSearch {
 	}
 }
 
+// Song Section
+
+type SongSectionSearch struct {
+	Name            string                 `json:"name"`
+	SongSectionType string                 `json:"songSectionType"`
+	Song            *SongSectionSongSearch `json:"song"`
+	SearchBase
+}
+
+type SongSectionSongSearch struct {
+	ID        uuid.UUID          `json:"id"`
+	ImageUrl  *internal.FilePath `json:"imageUrl"`
+	Title     string             `json:"title"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// ToSearch expects the song and the type of the section to be populated
+func (s *SongSection) ToSearch() SongSectionSearch {
+	return SongSectionSearch{
+		Name:            s.Name,
+		SongSectionType: s.SongSectionType.Name,
+		Song: &SongSectionSongSearch{
+			ID:        s.Song.ID,
+			Title:     s.Song.Title,
+			UpdatedAt: s.Song.UpdatedAt.UTC(),
+			ImageUrl:  s.Song.ImageURL.StripURL(),
+		},
+		SearchBase: SearchBase{
+			ID:        "songSection-" + s.ID.String(),
+			UpdatedAt: s.UpdatedAt.UTC(),
+			CreatedAt: s.CreatedAt.UTC(),
+			Type:      enums.SongSection,
+			UserID:    s.Song.UserID,
+		},
+	}
+}
+
+// ToSectionsSearch returns the search documents of the song's sections, which expect their type to be populated
+func (s *Song) ToSectionsSearch() []SongSectionSearch {
+	var searches []SongSectionSearch
+	for _, section := range sortedSections(s.Sections) {
+		section.Song = *s
+		searches = append(searches, section.ToSearch())
+	}
+	return searches
+}
+
 // Playlist
 
 type PlaylistSearch struct {
@@ -202,3 +370,17 @@ func (p *Playlist) ToSearch() PlaylistSearch {
 		},
 	}
 }
+
+func releaseYear(date *internal.Date) *int {
+	if date == nil {
+		return nil
+	}
+	year := (*time.Time)(date).Year()
+	return &year
+}
+
+func sortedSections(sections []SongSection) []SongSection {
+	return slices.SortedStableFunc(slices.Values(sections), func(a, b SongSection) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"repertoire/server/internal/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Setlist struct {
+	ID      uuid.UUID      `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Title   string         `gorm:"size:100; not null" json:"title"`
+	Venue   string         `gorm:"size:100; not null" json:"venue"`
+	Date    *time.Time     `json:"date"`
+	Notes   string         `gorm:"not null" json:"notes"`
+	Entries []SetlistEntry `gorm:"constraint:OnDelete:CASCADE" json:"entries"`
+
+	// running time of the whole setlist in seconds, computed from the entries
+	TotalDuration uint `gorm:"-" json:"totalDuration"`
+
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"default:current_timestamp; not null" json:"updatedAt"`
+	UserID    uuid.UUID `gorm:"foreignKey:UserID; references:ID; notnull" json:"userId"`
+}
+
+type SetlistEntry struct {
+	ID              uuid.UUID              `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Type            enums.SetlistEntryType `gorm:"size:30; not null" json:"type"`
+	Title           string                 `gorm:"size:100; not null" json:"title"`
+	PlannedDuration *uint                  `json:"plannedDuration"`
+	EntryNo         uint                   `gorm:"not null" json:"entryNo"`
+	SetlistID       uuid.UUID              `gorm:"not null; <-:create" json:"-"`
+	SongID          *uuid.UUID             `json:"-"`
+	Song            *Song                  `json:"song"`
+
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create" json:"createdAt"`
+}
+
+// ComputeTotalDuration sums up the planned durations (in seconds) of the entries,
+// song entries without a planned duration fall back to the duration of the song
+func (s *Setlist) ComputeTotalDuration() {
+	s.TotalDuration = 0
+	for _, entry := range s.Entries {
+		if entry.PlannedDuration != nil {
+			s.TotalDuration += *entry.PlannedDuration
+		} else if entry.Song != nil && entry.Song.Duration != nil {
+			s.TotalDuration += *entry.Song.Duration
+		}
+	}
+}
@@ -18,21 +18,23 @@ type EnhancedSong struct {
 }
 
 type Song struct {
-	ID             uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Title          string             `gorm:"size:100; not null" json:"title"`
-	Description    string             `gorm:"not null" json:"description"`
-	ReleaseDate    *internal.Date     `json:"releaseDate"`
-	ImageURL       *internal.FilePath `json:"imageUrl"`
-	IsRecorded     bool               `json:"isRecorded"`
-	Bpm            *uint              `json:"bpm"`
-	Difficulty     *enums.Difficulty  `json:"difficulty"`
-	SongsterrLink  *string            `json:"songsterrLink"`
-	YoutubeLink    *string            `json:"youtubeLink"`
-	AlbumTrackNo   *uint              `json:"albumTrackNo"`
-	LastTimePlayed *time.Time         `json:"lastTimePlayed"`
-	Rehearsals     float64            `gorm:"not null" json:"rehearsals"`
-	Confidence     float64            `gorm:"not null" json:"confidence"`
-	Progress       float64            `gorm:"not null" json:"progress"`
+	ID             uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Title          string                  `gorm:"size:100; not null" json:"title"`
+	Description    string                  `gorm:"not null" json:"description"`
+	ReleaseDate    *internal.Date          `json:"releaseDate"`
+	ImageURL       *internal.FilePath      `json:"imageUrl"`
+	ImageVariants  *internal.ImageVariants `gorm:"-" json:"imageVariants"`
+	IsRecorded     bool                    `json:"isRecorded"`
+	Bpm            *uint                   `json:"bpm"`
+	Duration       *uint                   `json:"duration"`
+	Difficulty     *enums.Difficulty       `json:"difficulty"`
+	SongsterrLink  *string                 `json:"songsterrLink"`
+	YoutubeLink    *string                 `json:"youtubeLink"`
+	AlbumTrackNo   *uint                   `json:"albumTrackNo"`
+	LastTimePlayed *time.Time              `json:"lastTimePlayed"`
+	Rehearsals     float64                 `gorm:"not null" json:"rehearsals"`
+	Confidence     float64                 `gorm:"not null" json:"confidence"`
+	Progress       float64                 `gorm:"not null" json:"progress"`
 
 	Settings       SongSettings   `gorm:"constraint:OnDelete:CASCADE" json:"settings"`
 	AlbumID        *uuid.UUID     `json:"albumId"`
@@ -69,16 +71,21 @@ func (s *Song) AfterFind(*gorm.DB) error {
 
 func (s *Song) ToFullImageURL() {
 	s.ImageURL = s.ImageURL.ToFullURL()
+	s.ImageVariants = s.ImageURL.ToImageVariants()
 	// When Joins instead of Preload, AfterFind Hook is not used
 	if s.Artist != nil {
 		s.Artist.ImageURL = s.Artist.ImageURL.ToFullURL()
+		s.Artist.ImageVariants = s.Artist.ImageURL.ToImageVariants()
 	}
 	if s.Album != nil {
 		s.Album.ImageURL = s.Album.ImageURL.ToFullURL()
+		s.Album.ImageVariants = s.Album.ImageURL.ToImageVariants()
 	}
 	if s.Settings.DefaultBandMember != nil {
 		s.Settings.DefaultBandMember.ImageURL =
 			s.Settings.DefaultBandMember.ImageURL.ToFullURL()
+		s.Settings.DefaultBandMember.ImageVariants =
+			s.Settings.DefaultBandMember.ImageURL.ToImageVariants()
 	}
 }
 
@@ -12,6 +12,9 @@ type SongSection struct {
 	Order              uint      `gorm:"not null" json:"-"`
 	Occurrences        uint      `gorm:"not null" json:"occurrences"`
 	PartialOccurrences uint      `gorm:"not null" json:"partialOccurrences"`
+	StartOffset        *uint     `json:"startOffset"`
+	BarCount           *uint     `json:"barCount"`
+	TimeSignature      *string   `gorm:"size:10" json:"timeSignature"`
 
 	Rehearsals      uint   `gorm:"not null" json:"rehearsals"`
 	Confidence      uint   `gorm:"not null; size:100" json:"confidence"`
@@ -41,6 +44,19 @@ type SongSectionHistory struct {
 	From          uint                `gorm:"not null"`
 	To            uint                `gorm:"not null"`
 	SongSectionID uuid.UUID           `gorm:"not null"`
+	BatchID       *uuid.UUID
+
+	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create"`
+}
+
+// RehearsalBatch groups the history added by one rehearsal operation on a song,
+// so that it can be undone as a single unit
+type RehearsalBatch struct {
+	ID                     uuid.UUID `gorm:"primaryKey; type:uuid; <-:create"`
+	SongID                 uuid.UUID `gorm:"not null"`
+	PreviousLastTimePlayed *time.Time
+
+	History []SongSectionHistory `gorm:"foreignKey:BatchID; constraint:OnDelete:CASCADE"`
 
 	CreatedAt time.Time `gorm:"default:current_timestamp; not null; <-:create"`
 }
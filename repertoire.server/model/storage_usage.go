@@ -0,0 +1,14 @@
+package model
+
+// StorageUsage is the space (in bytes) taken by the files of the user, broken down by what they belong to.
+// The quota is 0 when the storage is unlimited
+type StorageUsage struct {
+	Size        int64 `json:"size"`
+	Quota       int64 `json:"quota"`
+	Albums      int64 `json:"albums"`
+	Artists     int64 `json:"artists"`
+	BandMembers int64 `json:"bandMembers"`
+	Playlists   int64 `json:"playlists"`
+	Songs       int64 `json:"songs"`
+	Others      int64 `json:"others"`
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"repertoire/server/internal"
+	"repertoire/server/internal/enums"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,15 +11,21 @@ import (
 )
 
 type User struct {
-	ID                uuid.UUID          `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
-	Email             string             `gorm:"size:256; unique; not null" json:"email"`
-	Password          string             `gorm:"not null" json:"-"`
-	Name              string             `gorm:"size:100; not null" json:"name"`
-	ProfilePictureURL *internal.FilePath `json:"profilePictureUrl"`
+	ID                     uuid.UUID               `gorm:"primaryKey; type:uuid; <-:create" json:"id"`
+	Email                  string                  `gorm:"size:256; unique; not null" json:"email"`
+	Password               string                  `gorm:"not null" json:"-"`
+	Name                   string                  `gorm:"size:100; not null" json:"name"`
+	EmailVerified          bool                    `gorm:"not null; default:false" json:"emailVerified"`
+	ProfilePictureURL      *internal.FilePath      `json:"profilePictureUrl"`
+	ProfilePictureVariants *internal.ImageVariants `gorm:"-" json:"profilePictureVariants"`
+	ScoringStrategy        enums.ScoringStrategy   `gorm:"size:30; not null; default:classic" json:"scoringStrategy"`
+	ScoringStrategyVersion uint                    `gorm:"not null; default:1" json:"-"`
 
 	Albums           []Album           `json:"-"`
 	Artists          []Artist          `json:"-"`
 	Playlists        []Playlist        `json:"-"`
+	PracticeSessions []PracticeSession `json:"-"`
+	Setlists         []Setlist         `json:"-"`
 	Songs            []Song            `json:"-"`
 	SongSectionTypes []SongSectionType `json:"-"`
 	Instruments      []Instrument      `json:"-"`
@@ -36,5 +43,6 @@ func (u *User) BeforeSave(*gorm.DB) error {
 
 func (u *User) AfterFind(*gorm.DB) error {
 	u.ProfilePictureURL = u.ProfilePictureURL.ToFullURL()
+	u.ProfilePictureVariants = u.ProfilePictureURL.ToImageVariants()
 	return nil
 }
@@ -0,0 +1,186 @@
+package model
+
+import (
+	"repertoire/server/internal"
+	"repertoire/server/internal/enums"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// the files inside the archive of a user data export
+const (
+	ExportedUserFile             = "user.json"
+	ExportedArtistsFile          = "artists.json"
+	ExportedAlbumsFile           = "albums.json"
+	ExportedSongsFile            = "songs.json"
+	ExportedPlaylistsFile        = "playlists.json"
+	ExportedPracticeSessionsFile = "practice_sessions.json"
+	ExportedSetlistsFile         = "setlists.json"
+	ExportedBandMemberRolesFile  = "band_member_roles.json"
+	ExportedGuitarTuningsFile    = "guitar_tunings.json"
+	ExportedInstrumentsFile      = "instruments.json"
+	ExportedSectionTypesFile     = "section_types.json"
+)
+
+// The exported models keep all the identifiers and the order of the entities,
+// so that the archive can be read back without losing any of the relationships.
+// The images point to their location inside the archive
+
+type ExportedUser struct {
+	ID              uuid.UUID             `json:"id"`
+	Name            string                `json:"name"`
+	Email           string                `json:"email"`
+	ProfilePicture  *string               `json:"profilePicture"`
+	ScoringStrategy enums.ScoringStrategy `json:"scoringStrategy"`
+	CreatedAt       time.Time             `json:"createdAt"`
+}
+
+type ExportedUserDataItem struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Order uint      `json:"order"`
+}
+
+type ExportedArtist struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	IsBand      bool                 `json:"isBand"`
+	Image       *string              `json:"image"`
+	BandMembers []ExportedBandMember `json:"bandMembers"`
+	CreatedAt   time.Time            `json:"createdAt"`
+	UpdatedAt   time.Time            `json:"updatedAt"`
+}
+
+type ExportedBandMember struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	Order     uint        `json:"order"`
+	Color     *string     `json:"color"`
+	Image     *string     `json:"image"`
+	RoleIDs   []uuid.UUID `json:"roleIds"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type ExportedAlbum struct {
+	ID          uuid.UUID      `json:"id"`
+	Title       string         `json:"title"`
+	ReleaseDate *internal.Date `json:"releaseDate"`
+	Image       *string        `json:"image"`
+	ArtistID    *uuid.UUID     `json:"artistId"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type ExportedSong struct {
+	ID             uuid.UUID             `json:"id"`
+	Title          string                `json:"title"`
+	Description    string                `json:"description"`
+	ReleaseDate    *internal.Date        `json:"releaseDate"`
+	Image          *string               `json:"image"`
+	IsRecorded     bool                  `json:"isRecorded"`
+	Bpm            *uint                 `json:"bpm"`
+	Duration       *uint                 `json:"duration"`
+	Difficulty     *enums.Difficulty     `json:"difficulty"`
+	SongsterrLink  *string               `json:"songsterrLink"`
+	YoutubeLink    *string               `json:"youtubeLink"`
+	LastTimePlayed *time.Time            `json:"lastTimePlayed"`
+	Rehearsals     float64               `json:"rehearsals"`
+	Confidence     float64               `json:"confidence"`
+	Progress       float64               `json:"progress"`
+	AlbumID        *uuid.UUID            `json:"albumId"`
+	AlbumTrackNo   *uint                 `json:"albumTrackNo"`
+	ArtistID       *uuid.UUID            `json:"artistId"`
+	GuitarTuningID *uuid.UUID            `json:"guitarTuningId"`
+	Settings       ExportedSongSettings  `json:"settings"`
+	Sections       []ExportedSongSection `json:"sections"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+}
+
+type ExportedSongSettings struct {
+	DefaultInstrumentID *uuid.UUID `json:"defaultInstrumentId"`
+	DefaultBandMemberID *uuid.UUID `json:"defaultBandMemberId"`
+}
+
+type ExportedSongSection struct {
+	ID                 uuid.UUID                    `json:"id"`
+	Name               string                       `json:"name"`
+	Order              uint                         `json:"order"`
+	Occurrences        uint                         `json:"occurrences"`
+	PartialOccurrences uint                         `json:"partialOccurrences"`
+	StartOffset        *uint                        `json:"startOffset"`
+	BarCount           *uint                        `json:"barCount"`
+	TimeSignature      *string                      `json:"timeSignature"`
+	Rehearsals         uint                         `json:"rehearsals"`
+	Confidence         uint                         `json:"confidence"`
+	RehearsalsScore    uint64                       `json:"rehearsalsScore"`
+	ConfidenceScore    uint                         `json:"confidenceScore"`
+	Progress           uint64                       `json:"progress"`
+	SongSectionTypeID  uuid.UUID                    `json:"songSectionTypeId"`
+	InstrumentID       *uuid.UUID                   `json:"instrumentId"`
+	BandMemberID       *uuid.UUID                   `json:"bandMemberId"`
+	History            []ExportedSongSectionHistory `json:"history"`
+	CreatedAt          time.Time                    `json:"createdAt"`
+	UpdatedAt          time.Time                    `json:"updatedAt"`
+}
+
+type ExportedSongSectionHistory struct {
+	Property  SongSectionProperty `json:"property"`
+	From      uint                `json:"from"`
+	To        uint                `json:"to"`
+	CreatedAt time.Time           `json:"createdAt"`
+}
+
+type ExportedPlaylist struct {
+	ID          uuid.UUID           `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Image       *string             `json:"image"`
+	SongIDs     []uuid.UUID         `json:"songIds"`
+	SmartRules  *PlaylistSmartRules `json:"smartRules"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+type ExportedPracticeSession struct {
+	ID        uuid.UUID                     `json:"id"`
+	Notes     string                        `json:"notes"`
+	StartedAt time.Time                     `json:"startedAt"`
+	EndedAt   *time.Time                    `json:"endedAt"`
+	Songs     []ExportedPracticeSessionSong `json:"songs"`
+	CreatedAt time.Time                     `json:"createdAt"`
+	UpdatedAt time.Time                     `json:"updatedAt"`
+}
+
+type ExportedPracticeSessionSong struct {
+	SongID    uuid.UUID                        `json:"songId"`
+	Sections  []ExportedPracticeSessionSection `json:"sections"`
+	CreatedAt time.Time                        `json:"createdAt"`
+}
+
+type ExportedPracticeSessionSection struct {
+	SongSectionID uuid.UUID `json:"songSectionId"`
+	Occurrences   uint      `json:"occurrences"`
+}
+
+type ExportedSetlist struct {
+	ID        uuid.UUID              `json:"id"`
+	Title     string                 `json:"title"`
+	Venue     string                 `json:"venue"`
+	Date      *time.Time             `json:"date"`
+	Notes     string                 `json:"notes"`
+	Entries   []ExportedSetlistEntry `json:"entries"`
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+}
+
+type ExportedSetlistEntry struct {
+	Type            enums.SetlistEntryType `json:"type"`
+	Title           string                 `json:"title"`
+	PlannedDuration *uint                  `json:"plannedDuration"`
+	EntryNo         uint                   `json:"entryNo"`
+	SongID          *uuid.UUID             `json:"songId"`
+	CreatedAt       time.Time              `json:"createdAt"`
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	artistData "repertoire/server/test/integration/test/data/artist"
 	"repertoire/server/test/integration/test/utils"
@@ -63,6 +65,8 @@ func TestCreateBandMember_WhenSuccessful_ShouldCreateMember(t *testing.T) {
 		RoleIDs:  []uuid.UUID{artistData.Users[0].BandMemberRoles[2].ID},
 	}
 
+	messages := utils.SubscribeToTopic(topics.ArtistUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().POST(w, "/api/artists/band-members", request)
@@ -79,6 +83,10 @@ func TestCreateBandMember_WhenSuccessful_ShouldCreateMember(t *testing.T) {
 	db.Preload("Roles").Find(&member, &model.BandMember{Name: request.Name})
 
 	assertCreatedBandMember(t, member, request, len(artist.BandMembers))
+
+	assertion.AssertMessage(t, messages, func(id uuid.UUID) {
+		assert.Equal(t, artist.ID, id)
+	})
 }
 
 func assertCreatedBandMember(
@@ -3,7 +3,9 @@ package member
 import (
 	"net/http"
 	"net/http/httptest"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	artistData "repertoire/server/test/integration/test/data/artist"
 	"repertoire/server/test/integration/test/utils"
@@ -47,6 +49,8 @@ func TestDeleteBandMember_WhenSuccessful_ShouldDeleteMember(t *testing.T) {
 	artist := artistData.Artists[0]
 	bandMember := artist.BandMembers[1]
 
+	messages := utils.SubscribeToTopic(topics.ArtistUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().DELETE(w, "/api/artists/band-members/"+bandMember.ID.String()+"/from/"+artist.ID.String())
@@ -69,4 +73,8 @@ func TestDeleteBandMember_WhenSuccessful_ShouldDeleteMember(t *testing.T) {
 	for i := range members {
 		assert.Equal(t, uint(i), members[i].Order)
 	}
+
+	assertion.AssertMessage(t, messages, func(id uuid.UUID) {
+		assert.Equal(t, artist.ID, id)
+	})
 }
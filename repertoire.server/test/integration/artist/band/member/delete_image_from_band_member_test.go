@@ -3,6 +3,8 @@ package member
 import (
 	"net/http"
 	"net/http/httptest"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	artistData "repertoire/server/test/integration/test/data/artist"
 	"repertoire/server/test/integration/test/utils"
@@ -44,6 +46,8 @@ func TestDeleteImageFromBandMember_WhenSuccessful_ShouldUpdateArtistAndDeleteIma
 
 	bandMember := artistData.Artists[0].BandMembers[0]
 
+	messages := utils.SubscribeToTopic(topics.ArtistUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().DELETE(w, "/api/artists/band-members/images/"+bandMember.ID.String())
@@ -55,4 +59,8 @@ func TestDeleteImageFromBandMember_WhenSuccessful_ShouldUpdateArtistAndDeleteIma
 	db.Find(&bandMember, bandMember.ID)
 
 	assert.Nil(t, bandMember.ImageURL)
+
+	assertion.AssertMessage(t, messages, func(id uuid.UUID) {
+		assert.Equal(t, artistData.Artists[0].ID, id)
+	})
 }
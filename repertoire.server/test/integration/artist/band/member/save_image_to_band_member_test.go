@@ -5,6 +5,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	artistData "repertoire/server/test/integration/test/data/artist"
 	"repertoire/server/test/integration/test/utils"
@@ -44,6 +46,8 @@ func TestSaveImageToBandMember_WhenSuccessful_ShouldUpdateBandMemberAndSaveImage
 	_ = multiWriter.WriteField("id", bandMember.ID.String())
 	_ = multiWriter.Close()
 
+	messages := utils.SubscribeToTopic(topics.ArtistUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().PUTForm(w, "/api/artists/band-members/images", &requestBody, multiWriter.FormDataContentType())
@@ -55,4 +59,8 @@ func TestSaveImageToBandMember_WhenSuccessful_ShouldUpdateBandMemberAndSaveImage
 	db.Find(&bandMember, bandMember.ID)
 
 	assert.NotNil(t, bandMember.ImageURL)
+
+	assertion.AssertMessage(t, messages, func(id uuid.UUID) {
+		assert.Equal(t, artistData.Artists[0].ID, id)
+	})
 }
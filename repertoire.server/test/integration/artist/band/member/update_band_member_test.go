@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	artistData "repertoire/server/test/integration/test/data/artist"
 	"repertoire/server/test/integration/test/utils"
@@ -44,6 +46,8 @@ func TestUpdateBandMember_WhenSuccessful_ShouldUpdateBandMember(t *testing.T) {
 		RoleIDs: []uuid.UUID{artistData.Users[0].BandMemberRoles[2].ID},
 	}
 
+	messages := utils.SubscribeToTopic(topics.ArtistUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().PUT(w, "/api/artists/band-members", request)
@@ -55,6 +59,10 @@ func TestUpdateBandMember_WhenSuccessful_ShouldUpdateBandMember(t *testing.T) {
 	db.Preload("Roles").Find(&bandMember, bandMember.ID)
 
 	assertUpdatedBandMember(t, request, bandMember)
+
+	assertion.AssertMessage(t, messages, func(id uuid.UUID) {
+		assert.Equal(t, artistData.Artists[0].ID, id)
+	})
 }
 
 func assertUpdatedBandMember(t *testing.T, request requests.UpdateBandMemberRequest, bandMember model.BandMember) {
@@ -0,0 +1,168 @@
+package deadletter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
+	"repertoire/server/test/integration/test/core"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var deadLetterMessages = []model.DeadLetterMessage{
+	{
+		ID:        uuid.New(),
+		MessageID: uuid.New().String(),
+		Queue:     string(topics.TopicToQueueMap[topics.UpdateFromSearchEngineTopic]),
+		Topic:     string(topics.UpdateFromSearchEngineTopic),
+		Handler:   "update_from_search_engine_handler",
+		Payload:   json.RawMessage(`[]`),
+		Error:     "search engine is down",
+		Attempts:  3,
+	},
+	{
+		ID:        uuid.New(),
+		MessageID: uuid.New().String(),
+		Queue:     string(topics.TopicToQueueMap[topics.UpdateFromSearchEngineTopic]),
+		Topic:     string(topics.UpdateFromSearchEngineTopic),
+		Handler:   "update_from_search_engine_handler",
+		Payload:   json.RawMessage(`[]`),
+		Error:     "search engine is down",
+		Attempts:  3,
+	},
+	{
+		ID:        uuid.New(),
+		MessageID: uuid.New().String(),
+		Queue:     string(topics.TopicToQueueMap[topics.DeleteDirectoriesStorageTopic]),
+		Topic:     string(topics.DeleteDirectoriesStorageTopic),
+		Handler:   "delete_directories_storage_handler",
+		Payload:   json.RawMessage(`["some-directory"]`),
+		Error:     "storage is down",
+		Attempts:  3,
+	},
+}
+
+func seedDeadLetterMessages(t *testing.T) {
+	db := utils.GetDatabase(t)
+	for _, deadLetterMessage := range deadLetterMessages {
+		db.Create(&deadLetterMessage)
+	}
+	t.Cleanup(func() {
+		db.Where("1 = 1").Delete(&model.DeadLetterMessage{})
+	})
+}
+
+func TestDeadLetters_WhenNotAuthorizedAsAdmin_ShouldReturnUnauthorizedError(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/admin/dead-letters")
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGetAllDeadLetterMessages_WhenSuccessful_ShouldReturnTheMessagesOfTheTopic(t *testing.T) {
+	// given
+	seedDeadLetterMessages(t)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		GET(w, "/api/admin/dead-letters?topic="+string(topics.UpdateFromSearchEngineTopic))
+
+	// then
+	var response wrapper.WithTotalCount[model.DeadLetterMessage]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(2), response.TotalCount)
+	assert.Len(t, response.Models, 2)
+	for _, deadLetterMessage := range response.Models {
+		assert.Equal(t, string(topics.UpdateFromSearchEngineTopic), deadLetterMessage.Topic)
+	}
+}
+
+func TestGetDeadLetterMessage_WhenNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		GET(w, "/api/admin/dead-letters/"+uuid.New().String())
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetDeadLetterMetrics_WhenSuccessful_ShouldReturnTheCountPerTopic(t *testing.T) {
+	// given
+	seedDeadLetterMessages(t)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		GET(w, "/api/admin/dead-letters/metrics")
+
+	// then
+	var response []model.DeadLetterTopicMetrics
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []model.DeadLetterTopicMetrics{
+		{Topic: string(topics.UpdateFromSearchEngineTopic), Count: 2},
+		{Topic: string(topics.DeleteDirectoriesStorageTopic), Count: 1},
+	}, response)
+}
+
+func TestReplayDeadLetterMessage_WhenSuccessful_ShouldPublishTheMessageAndRemoveIt(t *testing.T) {
+	// given
+	seedDeadLetterMessages(t)
+
+	deadLetterMessage := deadLetterMessages[2]
+	messages := utils.SubscribeToTopic(topics.DeleteDirectoriesStorageTopic)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		POST(w, "/api/admin/dead-letters/"+deadLetterMessage.ID.String()+"/replay", nil)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assertion.AssertMessage(t, messages, func(directories []string) {
+		assert.Equal(t, []string{"some-directory"}, directories)
+	})
+
+	var count int64
+	utils.GetDatabase(t).Model(&model.DeadLetterMessage{}).Where("id = ?", deadLetterMessage.ID).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestDiscardDeadLetterMessage_WhenSuccessful_ShouldRemoveIt(t *testing.T) {
+	// given
+	seedDeadLetterMessages(t)
+
+	deadLetterMessage := deadLetterMessages[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		DELETE(w, "/api/admin/dead-letters/"+deadLetterMessage.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var count int64
+	utils.GetDatabase(t).Model(&model.DeadLetterMessage{}).Where("id = ?", deadLetterMessage.ID).Count(&count)
+	assert.Zero(t, count)
+}
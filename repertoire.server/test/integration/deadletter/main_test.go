@@ -0,0 +1,19 @@
+package deadletter
+
+import (
+	"os"
+	"repertoire/server/test/integration/test/core"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	_ = os.Setenv("ADMIN_AUTHORIZATION_KEY", "some-admin-key")
+
+	ts := &core.TestServer{}
+	ts.Start()
+
+	code := m.Run()
+
+	ts.Stop()
+	os.Exit(code)
+}
@@ -0,0 +1,21 @@
+package message
+
+import (
+	"os"
+	"repertoire/server/test/integration/test/core"
+	"testing"
+)
+
+var ts *core.TestServer
+
+func TestMain(m *testing.M) {
+	ts = &core.TestServer{
+		WithStorage: true,
+	}
+	ts.Start()
+
+	code := m.Run()
+
+	ts.Stop()
+	os.Exit(code)
+}
@@ -0,0 +1,57 @@
+package message
+
+import (
+	"encoding/json"
+	"repertoire/server/data/message"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+)
+
+func TestOutboxRelay_WhenTheApplicationStoppedBeforeRelaying_ShouldRelayAndRemoveTheMessagesAfterRestart(t *testing.T) {
+	// given
+	ts.StopApplication()
+
+	// a message committed right before a crash, which none of the handlers has received
+	songs := []model.Song{{ID: uuid.New()}}
+	payload, _ := json.Marshal(songs)
+	outboxMessage := model.OutboxMessage{
+		ID:      uuid.New(),
+		Queue:   string(topics.TopicToQueueMap[topics.SongsDeletedTopic]),
+		Topic:   string(topics.SongsDeletedTopic),
+		Payload: payload,
+	}
+
+	db := utils.GetDatabase(t)
+	db.Create(&outboxMessage)
+
+	var count int64
+	db.Model(&model.OutboxMessage{}).Where("id = ?", outboxMessage.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// when
+	var messages utils.SubscribedToTopic
+	ts.StartApplication(fx.Invoke(func(message.Publisher) {
+		// subscribed before the relay starts, as the relay waits for the router to be running
+		messages = utils.SubscribeToTopic(topics.SongsDeletedTopic)
+	}))
+
+	// then
+	assertion.AssertMessage(t, messages, func(payloadSongs []model.Song) {
+		assert.Len(t, payloadSongs, len(songs))
+		assert.Equal(t, songs[0].ID, payloadSongs[0].ID)
+	})
+
+	assert.Eventually(t, func() bool {
+		var count int64
+		utils.GetDatabase(t).Model(&model.OutboxMessage{}).Where("id = ?", outboxMessage.ID).Count(&count)
+		return count == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,78 @@
+package playlist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	playlistData "repertoire/server/test/integration/test/data/playlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFreezePlaylist_WhenPlaylistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/playlists/freeze", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFreezePlaylist_WhenPlaylistIsNotSmart_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	request := requests.FreezePlaylistRequest{ID: playlistData.Playlists[0].ID}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/playlists/freeze", request)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFreezePlaylist_WhenSuccessful_ShouldSaveTheMatchingSongsAndRemoveTheRules(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	playlist := playlistData.Playlists[3]
+	request := requests.FreezePlaylistRequest{ID: playlist.ID}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/playlists/freeze", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+	var frozenPlaylist model.Playlist
+	db.Preload("PlaylistSongs", func(db *gorm.DB) *gorm.DB {
+		return db.Order("song_track_no")
+	}).Find(&frozenPlaylist, playlist.ID)
+
+	var songs []model.Song
+	db.Where(model.Song{AlbumID: &playlistData.Albums[1].ID}).
+		Order("title desc").
+		Limit(int(*playlist.SmartRules.Limit)).
+		Find(&songs)
+
+	assert.Nil(t, frozenPlaylist.SmartRules)
+	assert.Len(t, frozenPlaylist.PlaylistSongs, len(songs))
+	for i, playlistSong := range frozenPlaylist.PlaylistSongs {
+		assert.Equal(t, songs[i].ID, playlistSong.SongID)
+		assert.Equal(t, uint(i+1), playlistSong.SongTrackNo)
+	}
+}
@@ -34,6 +34,12 @@ func TestGetAllPlaylists_WhenSuccessful_ShouldReturnPlaylists(t *testing.T) {
 	db.Preload("Songs").Find(&playlists)
 
 	for i := range responsePlaylists {
+		if playlists[i].SmartRules != nil {
+			// the smart playlists count the songs matching their rules, up to their limit
+			assertion.ResponsePlaylist(t, playlists[i], responsePlaylists[i].Playlist)
+			assert.Equal(t, float64(*playlists[i].SmartRules.Limit), responsePlaylists[i].SongsCount)
+			continue
+		}
 		assertion.ResponseEnhancedPlaylist(t, playlists[i], responsePlaylists[i])
 	}
 }
@@ -18,6 +18,29 @@ import (
 	"gorm.io/gorm"
 )
 
+func TestAddSongsToPlaylist_WhenPlaylistIsSmart_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	playlist := playlistData.Playlists[3]
+	request := requests.AddSongsToPlaylistRequest{
+		ID:      playlist.ID,
+		SongIDs: []uuid.UUID{playlistData.Songs[0].ID},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/playlists/songs/add", request)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	db := utils.GetDatabase(t)
+	var count int64
+	db.Model(&model.PlaylistSong{}).Where("playlist_id = ?", playlist.ID).Count(&count)
+	assert.Zero(t, count)
+}
+
 func TestAddSongsToPlaylist_WhenWithDuplicatesButWithoutForceAdd_ShouldReturnNoSuccess(t *testing.T) {
 	// given
 	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
@@ -74,3 +74,34 @@ func TestGetPlaylist_WhenRequestHasSongsOrderBy_ShouldReturnPlaylistAndSongsOrde
 		assertion.ResponsePlaylistSong(t, playlistSongs[i], response.Models[i])
 	}
 }
+
+func TestGetPlaylistSongs_WhenPlaylistIsSmart_ShouldReturnTheSongsMatchingItsRules(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	playlist := playlistData.Playlists[3]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/playlists/songs/"+playlist.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response wrapper.WithTotalCount[model.Song]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	var songs []model.Song
+	db := utils.GetDatabase(t)
+	db.Where(model.Song{AlbumID: &playlistData.Albums[1].ID}).
+		Order("title desc").
+		Limit(int(*playlist.SmartRules.Limit)).
+		Find(&songs)
+
+	assert.Equal(t, int64(*playlist.SmartRules.Limit), response.TotalCount)
+	assert.Len(t, response.Models, len(songs))
+	for i := range songs {
+		assert.Equal(t, songs[i].ID, response.Models[i].ID)
+		assert.Equal(t, uint(i+1), response.Models[i].PlaylistTrackNo)
+	}
+}
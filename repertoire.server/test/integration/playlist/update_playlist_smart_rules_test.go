@@ -0,0 +1,61 @@
+package playlist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	playlistData "repertoire/server/test/integration/test/data/playlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePlaylistSmartRules_WhenPlaylistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	request := requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/playlists/smart-rules", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdatePlaylistSmartRules_WhenSuccessful_ShouldUpdateTheRules(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, playlistData.Users, playlistData.SeedData)
+
+	playlist := playlistData.Playlists[1]
+
+	request := requests.UpdatePlaylistSmartRulesRequest{
+		ID: playlist.ID,
+		SmartRules: &requests.PlaylistSmartRulesRequest{
+			SearchBy: []string{"confidence < 60", "last_time_played IS NULL OR last_time_played < now-14d"},
+			OrderBy:  []string{"progress asc"},
+			Limit:    &[]uint{10}[0],
+		},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/playlists/smart-rules", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+	var updatedPlaylist model.Playlist
+	db.Find(&updatedPlaylist, playlist.ID)
+
+	assert.NotNil(t, updatedPlaylist.SmartRules)
+	assert.Equal(t, request.SmartRules.SearchBy, updatedPlaylist.SmartRules.SearchBy)
+	assert.Equal(t, request.SmartRules.OrderBy, updatedPlaylist.SmartRules.OrderBy)
+	assert.Equal(t, request.SmartRules.Limit, updatedPlaylist.SmartRules.Limit)
+}
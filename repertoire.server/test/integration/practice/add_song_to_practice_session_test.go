@@ -0,0 +1,116 @@
+package practice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSongToPracticeSession_WhenSessionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	song := practiceData.Songs[0]
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     uuid.New(),
+		SongID: song.ID,
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: song.Sections[0].ID, Occurrences: 1},
+		},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/practice-sessions/songs/add", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddSongToPracticeSession_WhenSessionIsFinished_ShouldReturnConflictError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	song := practiceData.Songs[0]
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     practiceData.PracticeSessions[0].ID,
+		SongID: song.ID,
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: song.Sections[0].ID, Occurrences: 1},
+		},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/practice-sessions/songs/add", request)
+
+	// then
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestAddSongToPracticeSession_WhenSectionIsNotOnSong_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     practiceData.PracticeSessions[2].ID,
+		SongID: practiceData.Songs[0].ID,
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: practiceData.Songs[1].Sections[0].ID, Occurrences: 1},
+		},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/practice-sessions/songs/add", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddSongToPracticeSession_WhenSuccessful_ShouldAddSongToSession(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	song := practiceData.Songs[0]
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     practiceData.PracticeSessions[2].ID,
+		SongID: song.ID,
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: song.Sections[0].ID, Occurrences: 2},
+			{ID: song.Sections[1].ID, Occurrences: 1},
+		},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/practice-sessions/songs/add", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+
+	var session model.PracticeSession
+	db.Preload("Songs").Preload("Songs.Sections").Find(&session, request.ID)
+
+	assert.Len(t, session.Songs, 1)
+	assert.Equal(t, request.SongID, session.Songs[0].SongID)
+	assert.Len(t, session.Songs[0].Sections, len(request.Sections))
+	for _, sectionRequest := range request.Sections {
+		ind := slices.IndexFunc(session.Songs[0].Sections, func(s model.PracticeSessionSection) bool {
+			return s.SongSectionID == sectionRequest.ID
+		})
+		assert.NotEqual(t, -1, ind)
+		assert.Equal(t, sectionRequest.Occurrences, session.Songs[0].Sections[ind].Occurrences)
+	}
+}
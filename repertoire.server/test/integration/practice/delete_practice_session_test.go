@@ -0,0 +1,50 @@
+package practice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletePracticeSession_WhenSessionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().DELETE(w, "/api/practice-sessions/"+uuid.New().String())
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeletePracticeSession_WhenSuccessful_ShouldDeleteSession(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	session := practiceData.PracticeSessions[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().DELETE(w, "/api/practice-sessions/"+session.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+
+	var deletedSession model.PracticeSession
+	db.Find(&deletedSession, session.ID)
+	assert.Empty(t, deletedSession)
+
+	var sessionSongs []model.PracticeSessionSong
+	db.Find(&sessionSongs, model.PracticeSessionSong{PracticeSessionID: session.ID})
+	assert.Empty(t, sessionSongs)
+}
@@ -0,0 +1,70 @@
+package practice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinishPracticeSession_WhenSessionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	request := requests.FinishPracticeSessionRequest{ID: uuid.New()}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/practice-sessions/finish", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFinishPracticeSession_WhenSessionIsAlreadyFinished_ShouldReturnConflictError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	request := requests.FinishPracticeSessionRequest{ID: practiceData.PracticeSessions[0].ID}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/practice-sessions/finish", request)
+
+	// then
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestFinishPracticeSession_WhenSuccessful_ShouldFinishSession(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	request := requests.FinishPracticeSessionRequest{
+		ID:    practiceData.PracticeSessions[2].ID,
+		Notes: &[]string{"Done for today"}[0],
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/practice-sessions/finish", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+
+	var session model.PracticeSession
+	db.Find(&session, request.ID)
+
+	assert.Equal(t, *request.Notes, session.Notes)
+	assert.NotNil(t, session.EndedAt)
+	assert.WithinDuration(t, time.Now(), *session.EndedAt, 1*time.Minute)
+}
@@ -0,0 +1,46 @@
+package practice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllPracticeSessions_WhenSuccessful_ShouldReturnSessions(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	user := practiceData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/practice-sessions?orderBy=started_at desc")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response wrapper.WithTotalCount[model.PracticeSession]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var sessions []model.PracticeSession
+	db.Where(model.PracticeSession{UserID: user.ID}).Order("started_at desc").Find(&sessions)
+
+	assert.Equal(t, int64(len(sessions)), response.TotalCount)
+	assert.Len(t, response.Models, len(sessions))
+	for i := range sessions {
+		assert.Equal(t, sessions[i].ID, response.Models[i].ID)
+		assert.Equal(t, sessions[i].Notes, response.Models[i].Notes)
+	}
+}
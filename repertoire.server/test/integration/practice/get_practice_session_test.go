@@ -0,0 +1,60 @@
+package practice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPracticeSession_WhenSessionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/practice-sessions/"+uuid.New().String())
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetPracticeSession_WhenSuccessful_ShouldReturnSession(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	session := practiceData.PracticeSessions[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/practice-sessions/"+session.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseSession model.PracticeSession
+	_ = json.Unmarshal(w.Body.Bytes(), &responseSession)
+
+	assert.Equal(t, session.ID, responseSession.ID)
+	assert.Equal(t, session.Notes, responseSession.Notes)
+	assert.NotNil(t, responseSession.EndedAt)
+	assert.Len(t, responseSession.Songs, len(session.Songs))
+	for i, song := range responseSession.Songs {
+		assert.Equal(t, session.Songs[i].ID, song.ID)
+		assert.Equal(t, session.Songs[i].SongID, song.Song.ID)
+		assert.Len(t, song.Sections, len(session.Songs[i].Sections))
+		for j, section := range song.Sections {
+			assert.Equal(t, session.Songs[i].Sections[j].ID, section.ID)
+			assert.Equal(t, session.Songs[i].Sections[j].Occurrences, section.Occurrences)
+			assert.Equal(t, session.Songs[i].Sections[j].SongSectionID, section.SongSection.ID)
+			assert.NotNil(t, section.SongSection.SongSectionType)
+		}
+	}
+}
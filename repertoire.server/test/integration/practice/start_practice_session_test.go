@@ -0,0 +1,66 @@
+package practice
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	practiceData "repertoire/server/test/integration/test/data/practice"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartPracticeSession_WhenAnotherSessionIsInProgress_ShouldReturnConflictError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	request := requests.StartPracticeSessionRequest{}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(practiceData.Users[0]).
+		POST(w, "/api/practice-sessions/start", request)
+
+	// then
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestStartPracticeSession_WhenSuccessful_ShouldStartSession(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, practiceData.Users, practiceData.SeedData)
+
+	user := practiceData.Users[1]
+	request := requests.StartPracticeSessionRequest{
+		Notes: "Some notes",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		POST(w, "/api/practice-sessions/start", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct{ ID uuid.UUID }
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var session model.PracticeSession
+	db.Find(&session, response.ID)
+
+	assert.Equal(t, response.ID, session.ID)
+	assert.Equal(t, request.Notes, session.Notes)
+	assert.WithinDuration(t, time.Now(), session.StartedAt, 1*time.Minute)
+	assert.Nil(t, session.EndedAt)
+	assert.Equal(t, user.ID, session.UserID)
+}
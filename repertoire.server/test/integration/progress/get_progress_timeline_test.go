@@ -0,0 +1,124 @@
+package progress
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	progressData "repertoire/server/test/integration/test/data/progress"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgressTimeline_WhenIntervalIsInvalid_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, progressData.Users, progressData.SeedData)
+
+	user := progressData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/progress/timeline?interval=year")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetProgressTimeline_WhenScopeIdIsInvalid_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, progressData.Users, progressData.SeedData)
+
+	user := progressData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/progress/timeline/songs/something?interval=day")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetProgressTimeline_WhenSuccessful_ShouldReturnTimeline(t *testing.T) {
+	tests := []struct {
+		name               string
+		user               model.User
+		url                string
+		expectedRehearsals []uint
+	}{
+		{
+			"Whole user",
+			progressData.Users[0],
+			"/api/progress/timeline",
+			[]uint{2, 4, 1},
+		},
+		{
+			"Section",
+			progressData.Users[0],
+			"/api/progress/timeline/sections/" + progressData.Songs[0].Sections[0].ID.String(),
+			[]uint{2, 0, 0},
+		},
+		{
+			"Song",
+			progressData.Users[0],
+			"/api/progress/timeline/songs/" + progressData.Songs[0].ID.String(),
+			[]uint{2, 0, 1},
+		},
+		{
+			"Album",
+			progressData.Users[0],
+			"/api/progress/timeline/albums/" + progressData.Albums[0].ID.String(),
+			[]uint{2, 0, 1},
+		},
+		{
+			"Artist",
+			progressData.Users[0],
+			"/api/progress/timeline/artists/" + progressData.Artists[0].ID.String(),
+			[]uint{2, 0, 1},
+		},
+		{
+			"Playlist",
+			progressData.Users[0],
+			"/api/progress/timeline/playlists/" + progressData.Playlists[0].ID.String(),
+			[]uint{0, 4, 0},
+		},
+		{
+			"Song of another user",
+			progressData.Users[1],
+			"/api/progress/timeline/songs/" + progressData.Songs[0].ID.String(),
+			[]uint{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			utils.SeedAndCleanupData(t, progressData.Users, progressData.SeedData)
+
+			// when
+			w := httptest.NewRecorder()
+			core.NewTestHandler().
+				WithUser(tt.user).
+				GET(w, tt.url+"?interval=day&from=2024-01-01&to=2024-01-03")
+
+			// then
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var timeline []model.ProgressTimelinePoint
+			_ = json.Unmarshal(w.Body.Bytes(), &timeline)
+
+			assert.Len(t, timeline, len(tt.expectedRehearsals))
+			for i, point := range timeline {
+				assert.Equal(t, time.Date(2024, time.January, 1+i, 0, 0, 0, 0, time.UTC), point.Date.UTC())
+				assert.Equal(t, tt.expectedRehearsals[i], point.Rehearsals)
+			}
+		})
+	}
+}
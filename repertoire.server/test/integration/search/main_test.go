@@ -7,6 +7,8 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	_ = os.Setenv("ADMIN_AUTHORIZATION_KEY", "some-admin-key")
+
 	ts := &core.TestServer{
 		WithMeili:      true,
 		WithCentrifugo: true,
@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"os"
+	"repertoire/server/test/integration/test/core"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	_ = os.Setenv("SEARCH_ENGINE", "postgres")
+
+	ts := &core.TestServer{}
+	ts.Start()
+
+	code := m.Run()
+
+	ts.Stop()
+	os.Exit(code)
+}
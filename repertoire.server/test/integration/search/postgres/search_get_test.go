@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	searchData "repertoire/server/test/integration/test/data/search"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchGet_WhenSuccessful_ShouldReturnSearchResults(t *testing.T) {
+	// given
+	utils.SeedAndCleanupPostgresSearchData(t, searchData.GetSearchDocuments())
+
+	query := "justice"
+
+	expectedResults := []any{
+		searchData.SongSearches[0],
+		searchData.AlbumSearches[0],
+		searchData.SongSearches[1],
+		searchData.PlaylistSearches[1],
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?query="+query)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response wrapper.WithTotalCount[map[string]any]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, int64(len(expectedResults)), response.TotalCount)
+	var actualIDs []string
+	for _, result := range response.Models {
+		actualIDs = append(actualIDs, result["type"].(string)+"-"+result["id"].(string))
+	}
+	for _, expectedResult := range expectedResults {
+		assert.Contains(t, actualIDs, utils.UnmarshalDocument[map[string]any](expectedResult)["id"])
+	}
+}
+
+func TestSearchGet_WhenWithSearchOptions_ShouldReturnExactMatchesWithFacetsAndHighlights(t *testing.T) {
+	// given
+	utils.SeedAndCleanupPostgresSearchData(t, searchData.GetSearchDocuments())
+
+	query := "justice"
+	expectedSong := searchData.SongSearches[0].(model.SongSearch)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?query="+query+"&type=song&facets=true&highlight=true&typoTolerance=false")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.SearchResults[model.SongSearch]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, int64(1), response.TotalCount)
+	assert.Len(t, response.Models, 1)
+	assert.Equal(t, expectedSong.ID, "song-"+response.Models[0].ID)
+	assert.NotNil(t, response.Models[0].Formatted)
+	assert.Equal(t, "<em>Justice</em>", response.Models[0].Formatted.Title)
+
+	assert.Equal(t, int64(1), response.Facets["type"][string(enums.Song)])
+	assert.Equal(t, int64(1), response.Facets["difficulty"][string(*expectedSong.Difficulty)])
+}
+
+func TestSearchGet_WhenWithFilter_ShouldReturnTheFilteredResults(t *testing.T) {
+	// given
+	utils.SeedAndCleanupPostgresSearchData(t, searchData.GetSearchDocuments())
+
+	expectedSong := searchData.SongSearches[0].(model.SongSearch)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?type=song&filter=difficulty%20%3D%20"+string(*expectedSong.Difficulty))
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response wrapper.WithTotalCount[model.SongSearch]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.NotEmpty(t, response.Models)
+	for _, song := range response.Models {
+		assert.Equal(t, expectedSong.Difficulty, song.Difficulty)
+	}
+}
+
+func TestSearchGet_WhenFilterAttributeIsNotFilterable_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupPostgresSearchData(t, searchData.GetSearchDocuments())
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?filter=password%20%3D%20something")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSearchGet_WhenOrderAttributeIsNotSortable_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupPostgresSearchData(t, searchData.GetSearchDocuments())
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?order=password:asc")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -62,3 +62,32 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResults(t *testing.T) {
 		assert.Equal(t, expectedID, prefix+actualID)
 	}
 }
+
+func TestSearchGet_WhenWithSearchOptions_ShouldReturnExactMatchesWithFacetsAndHighlights(t *testing.T) {
+	// given
+	utils.SeedAndCleanupSearchData(t, searchData.GetSearchDocuments())
+
+	query := "justice"
+	expectedSong := searchData.SongSearches[0].(model.SongSearch)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(model.User{ID: searchData.UserID}).
+		GET(w, "/api/search?query="+query+"&type=song&facets=true&highlight=true&typoTolerance=false")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.SearchResults[model.SongSearch]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, int64(1), response.TotalCount)
+	assert.Len(t, response.Models, 1)
+	assert.Equal(t, expectedSong.ID, "song-"+response.Models[0].ID)
+	assert.NotNil(t, response.Models[0].Formatted)
+	assert.Equal(t, "<em>Justice</em>", response.Models[0].Formatted.Title)
+
+	assert.Equal(t, int64(1), response.Facets["type"][string(enums.Song)])
+	assert.Equal(t, int64(1), response.Facets["difficulty"][string(*expectedSong.Difficulty)])
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSearchReconcile_WhenWithoutAdminAuthentication_ShouldReturnUnauthorized(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/admin/search/reconcile", nil)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSearchReconcile_WhenUserIDIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		POST(w, "/api/admin/search/reconcile?userId=something", nil)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSearchReconcile_WhenSuccessful_ShouldFixTheDriftedDocuments(t *testing.T) {
+	// given
+	user := model.User{ID: uuid.New(), Name: "Reconciled User", Email: uuid.New().String() + "@mail.com"}
+	artist := model.Artist{ID: uuid.New(), Name: "Missing Artist", UserID: user.ID}
+	playlist := model.Playlist{ID: uuid.New(), Title: "Renamed Playlist", UserID: user.ID}
+	utils.SeedAndCleanupData(t, []model.User{user}, func(db *gorm.DB) {
+		db.Create(&user)
+		db.Create(&artist)
+		db.Create(&playlist)
+	})
+	_ = utils.GetDatabase(t).Find(&playlist, playlist.ID)
+
+	outdatedPlaylist := playlist.ToSearch()
+	outdatedPlaylist.Title = "Old Title"
+	outdatedPlaylist.UpdatedAt = outdatedPlaylist.UpdatedAt.Add(-time.Hour)
+	deletedSong := model.Song{ID: uuid.New(), Title: "Deleted Song", UserID: user.ID}
+	utils.SeedAndCleanupSearchData(t, []any{outdatedPlaylist, deletedSong.ToSearch()})
+
+	searchClient := utils.GetSearchClient(t)
+	tasks, _ := searchClient.GetTasks(nil)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		POST(w, "/api/admin/search/reconcile?userId="+user.ID.String(), nil)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.SearchReconciliation
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, model.SearchReconciliation{Added: 1, Updated: 1, Deleted: 1}, response)
+
+	for { // the add, update and delete batches are handled separately
+		currentTasks, _ := searchClient.GetTasks(nil)
+		if currentTasks.Total >= tasks.Total+3 {
+			break
+		}
+	}
+	utils.WaitForAllSearchTasks(searchClient)
+
+	var result meilisearch.DocumentsResult
+	_ = searchClient.Index("search").GetDocuments(&meilisearch.DocumentsQuery{
+		Filter: "userId = " + user.ID.String(),
+	}, &result)
+	assert.Len(t, result.Results, 2)
+	for _, document := range result.Results {
+		actual := utils.UnmarshalDocument[map[string]any](document)
+		switch actual["id"] {
+		case "artist-" + artist.ID.String():
+			assert.Equal(t, artist.Name, actual["name"])
+		case "playlist-" + playlist.ID.String():
+			assert.Equal(t, playlist.Title, actual["title"])
+		default:
+			assert.Fail(t, "unexpected document", actual["id"])
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package setlist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateSetlistFromPlaylist_WhenPlaylistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "New Setlist",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(setlistData.Users[0]).
+		POST(w, "/api/setlists/from-playlist", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreateSetlistFromPlaylist_WhenSuccessful_ShouldCreateSetlistWithPlaylistSongs(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	user := setlistData.Users[0]
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: setlistData.Playlists[0].ID,
+		Title:      "New Setlist",
+		Venue:      "New Venue",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		POST(w, "/api/setlists/from-playlist", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct{ ID uuid.UUID }
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var setlist model.Setlist
+	db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("entry_no")
+	}).Find(&setlist, response.ID)
+
+	assert.Equal(t, response.ID, setlist.ID)
+	assert.Equal(t, request.Title, setlist.Title)
+	assert.Equal(t, request.Venue, setlist.Venue)
+	assert.Equal(t, user.ID, setlist.UserID)
+	assert.Len(t, setlist.Entries, len(setlistData.PlaylistSongs))
+	for i, entry := range setlist.Entries {
+		assert.Equal(t, enums.SongEntry, entry.Type)
+		assert.Equal(t, uint(i)+1, entry.EntryNo)
+		assert.Equal(t, setlistData.PlaylistSongs[i].SongID, *entry.SongID)
+	}
+}
+
+func TestCreateSetlistFromPlaylist_WhenPlaylistIsSmart_ShouldCreateSetlistWithTheSongsMatchingTheRules(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	user := setlistData.Users[0]
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: setlistData.Playlists[1].ID,
+		Title:      "New Setlist",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		POST(w, "/api/setlists/from-playlist", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct{ ID uuid.UUID }
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var setlist model.Setlist
+	db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("entry_no")
+	}).Find(&setlist, response.ID)
+
+	expectedSongIDs := []uuid.UUID{setlistData.Songs[2].ID, setlistData.Songs[0].ID}
+	assert.Len(t, setlist.Entries, len(expectedSongIDs))
+	for i, entry := range setlist.Entries {
+		assert.Equal(t, uint(i)+1, entry.EntryNo)
+		assert.Equal(t, expectedSongIDs[i], *entry.SongID)
+	}
+}
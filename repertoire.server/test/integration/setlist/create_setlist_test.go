@@ -0,0 +1,55 @@
+package setlist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSetlist_WhenSuccessful_ShouldCreateSetlist(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	user := setlistData.Users[0]
+	request := requests.CreateSetlistRequest{
+		Title: "New Setlist",
+		Venue: "New Venue",
+		Date:  &[]time.Time{time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)}[0],
+		Notes: "Bring spare strings",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		POST(w, "/api/setlists", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct{ ID uuid.UUID }
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var setlist model.Setlist
+	db.Preload("Entries").Find(&setlist, response.ID)
+
+	assert.Equal(t, response.ID, setlist.ID)
+	assert.Equal(t, request.Title, setlist.Title)
+	assert.Equal(t, request.Venue, setlist.Venue)
+	assert.WithinDuration(t, *request.Date, *setlist.Date, time.Second)
+	assert.Equal(t, request.Notes, setlist.Notes)
+	assert.Equal(t, user.ID, setlist.UserID)
+	assert.Empty(t, setlist.Entries)
+}
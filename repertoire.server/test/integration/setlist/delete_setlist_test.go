@@ -0,0 +1,54 @@
+package setlist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSetlist_WhenSetlistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().DELETE(w, "/api/setlists/"+uuid.New().String())
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteSetlist_WhenSuccessful_ShouldDeleteSetlistAndEntries(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	setlist := setlistData.Setlists[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().DELETE(w, "/api/setlists/"+setlist.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+
+	var deletedSetlist model.Setlist
+	db.Find(&deletedSetlist, setlist.ID)
+	assert.Empty(t, deletedSetlist)
+
+	var entries []model.SetlistEntry
+	db.Find(&entries, model.SetlistEntry{SetlistID: setlist.ID})
+	assert.Empty(t, entries)
+
+	var songs []model.Song
+	db.Find(&songs, []uuid.UUID{*setlist.Entries[1].SongID, *setlist.Entries[3].SongID})
+	assert.Len(t, songs, 2)
+}
@@ -0,0 +1,111 @@
+package entry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEntryToSetlist_WhenSetlistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:    uuid.New(),
+		Type:  enums.TalkEntry,
+		Title: "Introduction",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/setlists/entries/add", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddEntryToSetlist_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:     setlistData.Setlists[0].ID,
+		Type:   enums.SongEntry,
+		SongID: &[]uuid.UUID{uuid.New()}[0],
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/setlists/entries/add", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAddEntryToSetlist_WhenSuccessful_ShouldAppendEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		setlist model.Setlist
+		request requests.AddEntryToSetlistRequest
+	}{
+		{
+			"Song entry",
+			setlistData.Setlists[0],
+			requests.AddEntryToSetlistRequest{
+				Type:            enums.SongEntry,
+				SongID:          &setlistData.Songs[2].ID,
+				PlannedDuration: &[]uint{200}[0],
+			},
+		},
+		{
+			"Encore marker on empty setlist",
+			setlistData.Setlists[1],
+			requests.AddEntryToSetlistRequest{
+				Type:  enums.EncoreMarkerEntry,
+				Title: "Encore",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// given
+			utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+			request := test.request
+			request.ID = test.setlist.ID
+
+			// when
+			w := httptest.NewRecorder()
+			core.NewTestHandler().POST(w, "/api/setlists/entries/add", request)
+
+			// then
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response struct{ ID uuid.UUID }
+			_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+			var entry model.SetlistEntry
+			db := utils.GetDatabase(t)
+			db.Find(&entry, response.ID)
+
+			assert.Equal(t, response.ID, entry.ID)
+			assert.Equal(t, request.ID, entry.SetlistID)
+			assert.Equal(t, request.Type, entry.Type)
+			assert.Equal(t, request.Title, entry.Title)
+			assert.Equal(t, request.SongID, entry.SongID)
+			assert.Equal(t, request.PlannedDuration, entry.PlannedDuration)
+			assert.Equal(t, uint(len(test.setlist.Entries))+1, entry.EntryNo)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package entry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMoveEntryFromSetlist_WhenEntryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          setlistData.Setlists[0].ID,
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries/move", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMoveEntryFromSetlist_WhenOverEntryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          setlistData.Setlists[0].ID,
+		EntryID:     setlistData.Setlists[0].Entries[0].ID,
+		OverEntryID: uuid.New(),
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries/move", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMoveEntryFromSetlist_WhenSuccessful_ShouldMoveEntries(t *testing.T) {
+	tests := []struct {
+		name      string
+		index     int
+		overIndex int
+	}{
+		{
+			"From upper position to lower",
+			3,
+			0,
+		},
+		{
+			"From lower position to upper",
+			0,
+			2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// given
+			utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+			setlist := setlistData.Setlists[0]
+			request := requests.MoveEntryFromSetlistRequest{
+				ID:          setlist.ID,
+				EntryID:     setlist.Entries[test.index].ID,
+				OverEntryID: setlist.Entries[test.overIndex].ID,
+			}
+
+			// when
+			w := httptest.NewRecorder()
+			core.NewTestHandler().PUT(w, "/api/setlists/entries/move", request)
+
+			// then
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var newSetlist model.Setlist
+			db := utils.GetDatabase(t)
+			db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+				return db.Order("entry_no")
+			}).Find(&newSetlist, request.ID)
+
+			expectedIDs := make([]uuid.UUID, 0, len(setlist.Entries))
+			for _, entry := range setlist.Entries {
+				expectedIDs = append(expectedIDs, entry.ID)
+			}
+			movedID := expectedIDs[test.index]
+			expectedIDs = slices.Delete(expectedIDs, test.index, test.index+1)
+			expectedIDs = slices.Insert(expectedIDs, test.overIndex, movedID)
+
+			assert.Len(t, newSetlist.Entries, len(expectedIDs))
+			for i, entry := range newSetlist.Entries {
+				assert.Equal(t, expectedIDs[i], entry.ID)
+				assert.Equal(t, uint(i)+1, entry.EntryNo)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package entry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRemoveEntriesFromSetlist_WhenNotAllEntriesAreFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       setlistData.Setlists[0].ID,
+		EntryIDs: []uuid.UUID{setlistData.Setlists[0].Entries[0].ID, uuid.New()},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries/remove", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRemoveEntriesFromSetlist_WhenSuccessful_ShouldRemoveEntriesAndReorder(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	setlist := setlistData.Setlists[0]
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       setlist.ID,
+		EntryIDs: []uuid.UUID{setlist.Entries[0].ID, setlist.Entries[2].ID},
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries/remove", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var newSetlist model.Setlist
+	db := utils.GetDatabase(t)
+	db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("entry_no")
+	}).Find(&newSetlist, request.ID)
+
+	assert.Len(t, newSetlist.Entries, len(setlist.Entries)-len(request.EntryIDs))
+	for i, entry := range newSetlist.Entries {
+		assert.NotContains(t, request.EntryIDs, entry.ID)
+		assert.Equal(t, uint(i)+1, entry.EntryNo)
+	}
+}
@@ -0,0 +1,76 @@
+package entry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSetlistEntry_WhenEntryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID:    uuid.New(),
+		Title: "New Title",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateSetlistEntry_WhenTitleIsMissingOnNonSongEntry_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID: setlistData.Setlists[0].Entries[0].ID,
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries", request)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateSetlistEntry_WhenSuccessful_ShouldUpdateEntry(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	entry := setlistData.Setlists[0].Entries[2]
+	request := requests.UpdateSetlistEntryRequest{
+		ID:              entry.ID,
+		Title:           "Capo on 2",
+		PlannedDuration: &[]uint{45}[0],
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists/entries", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updatedEntry model.SetlistEntry
+	db := utils.GetDatabase(t)
+	db.Find(&updatedEntry, entry.ID)
+
+	assert.Equal(t, request.Title, updatedEntry.Title)
+	assert.Equal(t, request.PlannedDuration, updatedEntry.PlannedDuration)
+	assert.Equal(t, entry.Type, updatedEntry.Type)
+	assert.Equal(t, entry.EntryNo, updatedEntry.EntryNo)
+}
@@ -0,0 +1,48 @@
+package setlist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllSetlists_WhenSuccessful_ShouldReturnSetlists(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	user := setlistData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/setlists?orderBy=title")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response wrapper.WithTotalCount[model.Setlist]
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	db := utils.GetDatabase(t)
+
+	var setlists []model.Setlist
+	db.Preload("Entries").Where(model.Setlist{UserID: user.ID}).Order("title").Find(&setlists)
+
+	assert.Equal(t, int64(len(setlists)), response.TotalCount)
+	assert.Len(t, response.Models, len(setlists))
+	for i := range setlists {
+		setlists[i].ComputeTotalDuration()
+		assert.Equal(t, setlists[i].ID, response.Models[i].ID)
+		assert.Equal(t, setlists[i].Title, response.Models[i].Title)
+		assert.Equal(t, setlists[i].TotalDuration, response.Models[i].TotalDuration)
+	}
+}
@@ -0,0 +1,62 @@
+package setlist
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSetlist_WhenSetlistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/setlists/"+uuid.New().String())
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetSetlist_WhenSuccessful_ShouldReturnSetlistWithRunningOrder(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	setlist := setlistData.Setlists[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().GET(w, "/api/setlists/"+setlist.ID.String())
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseSetlist model.Setlist
+	_ = json.Unmarshal(w.Body.Bytes(), &responseSetlist)
+
+	assert.Equal(t, setlist.ID, responseSetlist.ID)
+	assert.Equal(t, setlist.Title, responseSetlist.Title)
+	assert.Equal(t, setlist.Venue, responseSetlist.Venue)
+	assert.Equal(t, uint(450), responseSetlist.TotalDuration)
+	assert.Len(t, responseSetlist.Entries, len(setlist.Entries))
+	for i, entry := range responseSetlist.Entries {
+		assert.Equal(t, setlist.Entries[i].ID, entry.ID)
+		assert.Equal(t, setlist.Entries[i].Type, entry.Type)
+		assert.Equal(t, setlist.Entries[i].Title, entry.Title)
+		assert.Equal(t, setlist.Entries[i].PlannedDuration, entry.PlannedDuration)
+		assert.Equal(t, setlist.Entries[i].EntryNo, entry.EntryNo)
+		if setlist.Entries[i].SongID != nil {
+			assert.Equal(t, *setlist.Entries[i].SongID, entry.Song.ID)
+		} else {
+			assert.Nil(t, entry.Song)
+		}
+	}
+}
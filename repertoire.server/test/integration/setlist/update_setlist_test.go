@@ -0,0 +1,62 @@
+package setlist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	setlistData "repertoire/server/test/integration/test/data/setlist"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSetlist_WhenSetlistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: "New Title",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateSetlist_WhenSuccessful_ShouldUpdateSetlist(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, setlistData.Users, setlistData.SeedData)
+
+	setlist := setlistData.Setlists[0]
+	request := requests.UpdateSetlistRequest{
+		ID:    setlist.ID,
+		Title: "New Title",
+		Venue: "New Venue",
+		Notes: "New notes",
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/setlists", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var updatedSetlist model.Setlist
+	db := utils.GetDatabase(t)
+	db.Preload("Entries").Find(&updatedSetlist, setlist.ID)
+
+	assert.Equal(t, request.Title, updatedSetlist.Title)
+	assert.Equal(t, request.Venue, updatedSetlist.Venue)
+	assert.Equal(t, request.Date, updatedSetlist.Date)
+	assert.Equal(t, request.Notes, updatedSetlist.Notes)
+	assert.Len(t, updatedSetlist.Entries, len(setlist.Entries))
+}
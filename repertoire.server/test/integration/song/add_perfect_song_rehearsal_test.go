@@ -58,6 +58,38 @@ func TestAddPerfectSongRehearsal_WhenSectionsHaveNoOccurrences_ShouldNotMakeAnyU
 	assert.Equal(t, song, newSong)
 }
 
+func TestAddPerfectSongRehearsal_WhenTransactionFailsMidway_ShouldRollbackAllChanges(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	song := songData.Songs[4]
+	request := requests.AddPerfectSongRehearsalRequest{
+		ID: song.ID,
+	}
+
+	db := utils.GetDatabase(t)
+	db.Preload("Sections").Preload("Sections.History").Find(&song, song.ID)
+
+	// the history is written first, so failing the song update happens mid-way
+	utils.FailOnUpdate(t, "songs")
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/songs/perfect-rehearsal", request)
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var newSong model.Song
+	db = db.Session(&gorm.Session{NewDB: true})
+	db.Preload("Sections").Preload("Sections.History").Find(&newSong, song.ID)
+	assert.Equal(t, song, newSong)
+
+	var batchesCount int64
+	db.Model(&model.RehearsalBatch{}).Where("song_id = ?", song.ID).Count(&batchesCount)
+	assert.Zero(t, batchesCount)
+}
+
 func TestAddPerfectSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testing.T) {
 	// given
 	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
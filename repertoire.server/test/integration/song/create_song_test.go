@@ -37,6 +37,7 @@ func TestCreateSong_WhenSuccessful_ShouldCreateSong(t *testing.T) {
 				Title:          "New Song",
 				Description:    "New Song Description",
 				Bpm:            &[]uint{123}[0],
+				Duration:       &[]uint{215}[0],
 				SongsterrLink:  &[]string{"https://songsterr.com/some-song"}[0],
 				YoutubeLink:    &[]string{"https://youtu.be/9DyxtUCW84o?si=2pNX8eaV4KwKfOaF"}[0],
 				ReleaseDate:    &[]internal.Date{internal.Date(time.Now())}[0],
@@ -91,8 +92,11 @@ func TestCreateSong_WhenSuccessful_ShouldCreateSong(t *testing.T) {
 						TypeID: songData.Users[0].SongSectionTypes[0].ID,
 					},
 					{
-						Name:   "Song Section 2",
-						TypeID: songData.Users[0].SongSectionTypes[1].ID,
+						Name:          "Song Section 2",
+						TypeID:        songData.Users[0].SongSectionTypes[1].ID,
+						StartOffset:   &[]uint{30}[0],
+						BarCount:      &[]uint{8}[0],
+						TimeSignature: &[]string{"6/8"}[0],
 					},
 				},
 			},
@@ -151,6 +155,7 @@ func assertCreatedSong(
 	assert.Equal(t, request.Description, song.Description)
 	assert.False(t, song.IsRecorded)
 	assert.Equal(t, request.Bpm, song.Bpm)
+	assert.Equal(t, request.Duration, song.Duration)
 	assert.Equal(t, request.SongsterrLink, song.SongsterrLink)
 	assert.Equal(t, request.YoutubeLink, song.YoutubeLink)
 	assert.Nil(t, song.LastTimePlayed)
@@ -168,6 +173,9 @@ func assertCreatedSong(
 	for i, sectionRequest := range request.Sections {
 		assert.NotEmpty(t, song.Sections[i].ID)
 		assert.Equal(t, sectionRequest.Name, song.Sections[i].Name)
+		assert.Equal(t, sectionRequest.StartOffset, song.Sections[i].StartOffset)
+		assert.Equal(t, sectionRequest.BarCount, song.Sections[i].BarCount)
+		assert.Equal(t, sectionRequest.TimeSignature, song.Sections[i].TimeSignature)
 		assert.Zero(t, song.Sections[i].Rehearsals)
 		assert.Equal(t, model.DefaultSongSectionConfidence, song.Sections[i].Confidence)
 		assert.Zero(t, song.Sections[i].RehearsalsScore)
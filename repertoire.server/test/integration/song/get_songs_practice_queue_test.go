@@ -0,0 +1,89 @@
+package song
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	songData "repertoire/server/test/integration/test/data/song"
+	"repertoire/server/test/integration/test/utils"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSongsPracticeQueue_WhenSuccessful_ShouldReturnRankedSongs(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	user := songData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/songs/practice-queue")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseQueue []model.PracticeQueueSong
+	_ = json.Unmarshal(w.Body.Bytes(), &responseQueue)
+
+	assert.NotEmpty(t, responseQueue)
+	for i, queueSong := range responseQueue {
+		assert.Equal(t, user.ID, queueSong.Song.UserID)
+		assert.Positive(t, queueSong.Priority)
+		assert.Positive(t, queueSong.EstimatedDuration)
+		if i > 0 {
+			assert.GreaterOrEqual(t, responseQueue[i-1].Priority, queueSong.Priority)
+		}
+		for _, dueSection := range queueSong.DueSections {
+			assert.True(t, slices.ContainsFunc(queueSong.Song.Sections, func(s model.SongSection) bool {
+				return s.ID == dueSection.SongSection.ID
+			}))
+		}
+	}
+}
+
+func TestGetSongsPracticeQueue_WhenBudgetIsGiven_ShouldFitSongsInBudget(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	user := songData.Users[0]
+	budgetInMinutes := 12
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/songs/practice-queue?budget=12")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseQueue []model.PracticeQueueSong
+	_ = json.Unmarshal(w.Body.Bytes(), &responseQueue)
+
+	var totalDuration uint = 0
+	for _, queueSong := range responseQueue {
+		totalDuration += queueSong.EstimatedDuration
+	}
+	assert.LessOrEqual(t, totalDuration, uint(budgetInMinutes*60))
+}
+
+func TestGetSongsPracticeQueue_WhenBudgetIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	user := songData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/songs/practice-queue?budget=0")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	songData "repertoire/server/test/integration/test/data/song"
 	"repertoire/server/test/integration/test/utils"
@@ -61,6 +63,8 @@ func TestBulkDeleteSongSections_WhenSuccessful_ShouldDeleteSections(t *testing.T
 		SongID: songData.Songs[0].ID,
 	}
 
+	messages := utils.SubscribeToTopic(topics.SongsUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().PUT(w, "/api/songs/sections/bulk-delete", request)
@@ -88,4 +92,8 @@ func TestBulkDeleteSongSections_WhenSuccessful_ShouldDeleteSections(t *testing.T
 	assert.LessOrEqual(t, newSong.Confidence, song.Confidence)
 	assert.LessOrEqual(t, newSong.Rehearsals, song.Rehearsals)
 	assert.LessOrEqual(t, newSong.Progress, song.Progress)
+
+	assertion.AssertMessage(t, messages, func(ids []uuid.UUID) {
+		assert.Equal(t, []uuid.UUID{song.ID}, ids)
+	})
 }
@@ -51,6 +51,42 @@ func TestBulkRehearsalsSongSections_WhenSectionIsNotFound_ShouldReturnNotFoundEr
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestBulkRehearsalsSongSections_WhenTransactionFailsMidway_ShouldRollbackAllChanges(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	song := songData.Songs[0]
+	request := requests.BulkRehearsalsSongSectionsRequest{
+		Sections: []requests.BulkRehearsalsSongSectionRequest{
+			{ID: song.Sections[0].ID, Rehearsals: 10},
+			{ID: song.Sections[2].ID, Rehearsals: 5},
+		},
+		SongID: song.ID,
+	}
+
+	db := utils.GetDatabase(t)
+	db.Preload("Sections").Preload("Sections.History").Find(&song, song.ID)
+
+	// the history is written first, so failing the song update happens mid-way
+	utils.FailOnUpdate(t, "songs")
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/songs/sections/bulk-rehearsals", request)
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var newSong model.Song
+	db = db.Session(&gorm.Session{NewDB: true})
+	db.Preload("Sections").Preload("Sections.History").Find(&newSong, song.ID)
+	assert.Equal(t, song, newSong)
+
+	var batchesCount int64
+	db.Model(&model.RehearsalBatch{}).Where("song_id = ?", song.ID).Count(&batchesCount)
+	assert.Zero(t, batchesCount)
+}
+
 func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldDeleteSections(t *testing.T) {
 	// given
 	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	songData "repertoire/server/test/integration/test/data/song"
 	"repertoire/server/test/integration/test/utils"
@@ -104,13 +106,18 @@ func TestCreateSongSection_WhenSuccessful_ShouldCreateSection(t *testing.T) {
 			// song with sections and previous stats
 			song := songData.Songs[0]
 			request := requests.CreateSongSectionRequest{
-				SongID:       song.ID,
-				Name:         "Chorus 1-New",
-				TypeID:       songData.Users[0].SongSectionTypes[0].ID,
-				BandMemberID: test.bandMemberID,
-				InstrumentID: test.instrumentID,
+				SongID:        song.ID,
+				Name:          "Chorus 1-New",
+				TypeID:        songData.Users[0].SongSectionTypes[0].ID,
+				BandMemberID:  test.bandMemberID,
+				InstrumentID:  test.instrumentID,
+				StartOffset:   &[]uint{120}[0],
+				BarCount:      &[]uint{4}[0],
+				TimeSignature: &[]string{"7/8"}[0],
 			}
 
+			messages := utils.SubscribeToTopic(topics.SongsUpdatedTopic)
+
 			// when
 			w := httptest.NewRecorder()
 			core.NewTestHandler().POST(w, "/api/songs/sections", request)
@@ -128,6 +135,10 @@ func TestCreateSongSection_WhenSuccessful_ShouldCreateSection(t *testing.T) {
 			assert.LessOrEqual(t, section.Song.Progress, song.Progress)
 
 			assertCreatedSongSection(t, section, request, len(song.Sections))
+
+			assertion.AssertMessage(t, messages, func(ids []uuid.UUID) {
+				assert.Equal(t, []uuid.UUID{song.ID}, ids)
+			})
 		})
 	}
 }
@@ -144,6 +155,9 @@ func assertCreatedSongSection(
 	assert.Equal(t, request.TypeID, songSection.SongSectionTypeID)
 	assert.Equal(t, request.BandMemberID, songSection.BandMemberID)
 	assert.Equal(t, request.InstrumentID, songSection.InstrumentID)
+	assert.Equal(t, request.StartOffset, songSection.StartOffset)
+	assert.Equal(t, request.BarCount, songSection.BarCount)
+	assert.Equal(t, request.TimeSignature, songSection.TimeSignature)
 	assert.Zero(t, songSection.Rehearsals)
 	assert.Equal(t, model.DefaultSongSectionConfidence, songSection.Confidence)
 	assert.Zero(t, songSection.RehearsalsScore)
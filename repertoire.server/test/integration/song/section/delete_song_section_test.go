@@ -3,7 +3,9 @@ package section
 import (
 	"net/http"
 	"net/http/httptest"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	songData "repertoire/server/test/integration/test/data/song"
 	"repertoire/server/test/integration/test/utils"
@@ -49,6 +51,8 @@ func TestDeleteSongSection_WhenSuccessful_ShouldDeleteSection(t *testing.T) {
 	song := songData.Songs[0]
 	section := song.Sections[1]
 
+	messages := utils.SubscribeToTopic(topics.SongsUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().DELETE(w, "/api/songs/sections/"+section.ID.String()+"/from/"+section.SongID.String())
@@ -77,4 +81,8 @@ func TestDeleteSongSection_WhenSuccessful_ShouldDeleteSection(t *testing.T) {
 	assert.LessOrEqual(t, newSong.Confidence, song.Confidence)
 	assert.LessOrEqual(t, newSong.Rehearsals, song.Rehearsals)
 	assert.LessOrEqual(t, newSong.Progress, song.Progress)
+
+	assertion.AssertMessage(t, messages, func(ids []uuid.UUID) {
+		assert.Equal(t, []uuid.UUID{song.ID}, ids)
+	})
 }
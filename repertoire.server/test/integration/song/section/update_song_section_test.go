@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
 	songData "repertoire/server/test/integration/test/data/song"
 	"repertoire/server/test/integration/test/utils"
@@ -100,11 +102,16 @@ func TestUpdateSongSection_WhenSuccessful_ShouldUpdateSection(t *testing.T) {
 	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
 
 	request := requests.UpdateSongSectionRequest{
-		ID:     songData.Songs[0].Sections[2].ID,
-		Name:   "New Chorus Name",
-		TypeID: songData.Users[0].SongSectionTypes[0].ID,
+		ID:            songData.Songs[0].Sections[2].ID,
+		Name:          "New Chorus Name",
+		TypeID:        songData.Users[0].SongSectionTypes[0].ID,
+		StartOffset:   &[]uint{95}[0],
+		BarCount:      &[]uint{12}[0],
+		TimeSignature: &[]string{"3/4"}[0],
 	}
 
+	messages := utils.SubscribeToTopic(topics.SongsUpdatedTopic)
+
 	// when
 	w := httptest.NewRecorder()
 	core.NewTestHandler().PUT(w, "/api/songs/sections", request)
@@ -118,6 +125,42 @@ func TestUpdateSongSection_WhenSuccessful_ShouldUpdateSection(t *testing.T) {
 	db.Find(&section, &model.SongSection{ID: request.ID})
 
 	assertUpdatedSongSection(t, section, request)
+
+	assertion.AssertMessage(t, messages, func(ids []uuid.UUID) {
+		assert.Equal(t, []uuid.UUID{songData.Songs[0].ID}, ids)
+	})
+}
+
+func TestUpdateSongSection_WhenTransactionFailsMidway_ShouldRollbackAllChanges(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	section := songData.Songs[0].Sections[0]
+	request := requests.UpdateSongSectionRequest{
+		ID:         section.ID,
+		Name:       "New Chorus Name",
+		Rehearsals: 15,
+		Confidence: section.Confidence,
+		TypeID:     songData.Users[0].SongSectionTypes[0].ID,
+	}
+
+	db := utils.GetDatabase(t)
+	db.Preload("Song").Preload("History").Find(&section, section.ID)
+
+	// the history is written first, so failing the song update happens mid-way
+	utils.FailOnUpdate(t, "songs")
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().PUT(w, "/api/songs/sections", request)
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var newSection model.SongSection
+	db = db.Session(&gorm.Session{NewDB: true})
+	db.Preload("Song").Preload("History").Find(&newSection, section.ID)
+	assert.Equal(t, section, newSection)
 }
 
 func TestUpdateSongSection_WhenSuccessfulWithRehearsals_ShouldUpdateSectionUpdateSongAddHistoryAndChangeScore(t *testing.T) {
@@ -278,4 +321,7 @@ func assertUpdatedSongSection(
 	assert.Equal(t, request.TypeID, songSection.SongSectionTypeID)
 	assert.Equal(t, request.BandMemberID, songSection.BandMemberID)
 	assert.Equal(t, request.InstrumentID, songSection.InstrumentID)
+	assert.Equal(t, request.StartOffset, songSection.StartOffset)
+	assert.Equal(t, request.BarCount, songSection.BarCount)
+	assert.Equal(t, request.TimeSignature, songSection.TimeSignature)
 }
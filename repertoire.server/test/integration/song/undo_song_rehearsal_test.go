@@ -0,0 +1,106 @@
+package song
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	songData "repertoire/server/test/integration/test/data/song"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUndoSongRehearsal_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/songs/undo-rehearsal", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUndoSongRehearsal_WhenThereIsNoRehearsal_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: songData.Songs[4].ID,
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/songs/undo-rehearsal", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUndoSongRehearsal_WhenSuccessful_ShouldRevertTheLastRehearsal(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, songData.Users, songData.SeedData)
+
+	song := songData.Songs[4]
+
+	db := utils.GetDatabase(t)
+	db.Preload("Sections").Preload("Sections.History").Find(&song, song.ID)
+
+	// the rehearsal is recorded in the practice session in progress
+	session := model.PracticeSession{ID: uuid.New(), StartedAt: time.Now().UTC(), UserID: song.UserID}
+	db.Create(&session)
+
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(
+		w,
+		"/api/songs/partial-rehearsal",
+		requests.AddPartialSongRehearsalRequest{ID: song.ID},
+	)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var sessionSongsCount int64
+	db.Model(&model.PracticeSessionSong{}).Where("practice_session_id = ?", session.ID).Count(&sessionSongsCount)
+	assert.Equal(t, int64(1), sessionSongsCount)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: song.ID,
+	}
+
+	// when
+	w = httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/songs/undo-rehearsal", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var newSong model.Song
+	db = db.Session(&gorm.Session{NewDB: true})
+	db.Preload("Sections").Preload("Sections.History").Find(&newSong, song.ID)
+
+	assert.Nil(t, newSong.LastTimePlayed)
+	var totalRehearsals uint = 0
+	for i, section := range newSong.Sections {
+		assert.Equal(t, song.Sections[i].Rehearsals, section.Rehearsals)
+		assert.Len(t, section.History, len(song.Sections[i].History))
+		totalRehearsals += section.Rehearsals
+	}
+	assert.Equal(t, float64(totalRehearsals)/float64(len(newSong.Sections)), newSong.Rehearsals)
+
+	var batchesCount int64
+	db.Model(&model.RehearsalBatch{}).Where("song_id = ?", song.ID).Count(&batchesCount)
+	assert.Zero(t, batchesCount)
+
+	db.Model(&model.PracticeSessionSong{}).Where("practice_session_id = ?", session.ID).Count(&sessionSongsCount)
+	assert.Zero(t, sessionSongsCount)
+}
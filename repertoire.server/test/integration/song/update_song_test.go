@@ -45,6 +45,7 @@ func TestUpdateSong_WhenSuccessful_ShouldUpdateSong(t *testing.T) {
 	request := requests.UpdateSongRequest{
 		ID:          song.ID,
 		Title:       "New Title",
+		Duration:    &[]uint{190}[0],
 		ReleaseDate: &[]internal.Date{internal.Date(time.Now())}[0],
 		AlbumID:     song.AlbumID,
 		ArtistID:    song.ArtistID,
@@ -132,6 +133,7 @@ func assertUpdatedSong(t *testing.T, request requests.UpdateSongRequest, song mo
 	assert.Equal(t, request.Description, song.Description)
 	assert.Equal(t, request.IsRecorded, song.IsRecorded)
 	assert.Equal(t, request.Bpm, song.Bpm)
+	assert.Equal(t, request.Duration, song.Duration)
 	assert.Equal(t, request.SongsterrLink, song.SongsterrLink)
 	assert.Equal(t, request.YoutubeLink, song.YoutubeLink)
 	assertion.Date(t, request.ReleaseDate, song.ReleaseDate)
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/internal"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCollectOrphanedFiles_WhenWithoutAdminAuthentication_ShouldReturnUnauthorized(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().POST(w, "/api/admin/storage/orphaned-files/collect", nil)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestCollectOrphanedFiles_WhenUserIDIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithAdminAuthentication().
+		POST(w, "/api/admin/storage/orphaned-files/collect?userId=something", nil)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCollectOrphanedFiles_WhenSuccessful_ShouldReportTheUnreferencedFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		dryRun bool
+	}{
+		{"Dry Run", true},
+		{"Collect", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			user := model.User{
+				ID:                uuid.New(),
+				Name:              "Orphaned User",
+				Email:             uuid.New().String() + "@mail.com",
+				ProfilePictureURL: &[]internal.FilePath{"user/image.jpg"}[0],
+			}
+			utils.SeedAndCleanupData(t, []model.User{user}, func(db *gorm.DB) {
+				db.Create(&user)
+			})
+
+			// when
+			url := "/api/admin/storage/orphaned-files/collect?userId=" + user.ID.String()
+			if tt.dryRun {
+				url += "&dryRun=true"
+			}
+			w := httptest.NewRecorder()
+			core.NewTestHandler().
+				WithAdminAuthentication().
+				POST(w, url, nil)
+
+			// then
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response model.OrphanedFilesCollection
+			_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+			assert.Equal(t, tt.dryRun, response.DryRun)
+			assert.Equal(t, int64(30), response.Size)
+			assert.Len(t, response.Files, 5)
+			for _, file := range response.Files {
+				assert.NotEqual(t, "user/image.jpg", file.Path)
+			}
+		})
+	}
+}
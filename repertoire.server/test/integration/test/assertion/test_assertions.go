@@ -85,6 +85,7 @@ func ResponseEnhancedAlbum(t *testing.T, album model.Album, response model.Enhan
 	assert.Equal(t, confidence, response.Confidence)
 	assert.Equal(t, progress, response.Progress)
 	assert.Equal(t, lastTimePlayed, response.LastTimePlayed)
+	assert.Equal(t, getSongsTotalDuration(album.Songs), response.TotalDuration)
 }
 
 func ResponseAlbum(t *testing.T, album model.Album, response model.Album, withArtist bool, withSongs bool) {
@@ -134,6 +135,7 @@ func ResponseEnhancedArtist(
 	assert.Equal(t, confidence, response.Confidence)
 	assert.Equal(t, progress, response.Progress)
 	assert.Equal(t, lastTimePlayed, response.LastTimePlayed)
+	assert.Equal(t, getSongsTotalDuration(artist.Songs), response.TotalDuration)
 }
 
 func ResponseArtist(t *testing.T, artist model.Artist, response model.Artist, withBandMembers bool) {
@@ -178,6 +180,7 @@ func ResponseEnhancedSong(
 	assert.Equal(t, song.ImageURL, response.ImageURL)
 	assert.Equal(t, song.IsRecorded, response.IsRecorded)
 	assert.Equal(t, song.Bpm, response.Bpm)
+	assert.Equal(t, song.Duration, response.Duration)
 	assert.Equal(t, song.Difficulty, response.Difficulty)
 	assert.Equal(t, song.SongsterrLink, response.SongsterrLink)
 	assert.Equal(t, song.YoutubeLink, response.YoutubeLink)
@@ -241,6 +244,7 @@ func ResponseSong(
 	assert.Equal(t, song.ImageURL, response.ImageURL)
 	assert.Equal(t, song.IsRecorded, response.IsRecorded)
 	assert.Equal(t, song.Bpm, response.Bpm)
+	assert.Equal(t, song.Duration, response.Duration)
 	assert.Equal(t, song.Difficulty, response.Difficulty)
 	assert.Equal(t, song.SongsterrLink, response.SongsterrLink)
 	assert.Equal(t, song.YoutubeLink, response.YoutubeLink)
@@ -317,6 +321,9 @@ func ResponseSongSection(
 	assert.Equal(t, songSection.ID, response.ID)
 	assert.Equal(t, songSection.Name, response.Name)
 	assert.Equal(t, songSection.Occurrences, response.Occurrences)
+	assert.Equal(t, songSection.StartOffset, response.StartOffset)
+	assert.Equal(t, songSection.BarCount, response.BarCount)
+	assert.Equal(t, songSection.TimeSignature, response.TimeSignature)
 	assert.Equal(t, songSection.Rehearsals, response.Rehearsals)
 	assert.Equal(t, songSection.Confidence, response.Confidence)
 	assert.Equal(t, songSection.RehearsalsScore, response.RehearsalsScore)
@@ -350,6 +357,7 @@ func ResponseEnhancedPlaylist(t *testing.T, playlist model.Playlist, response mo
 	assert.Equal(t, playlist.ImageURL, response.ImageURL)
 
 	assert.Equal(t, response.SongsCount, len(playlist.Songs))
+	assert.Equal(t, getSongsTotalDuration(playlist.Songs), response.TotalDuration)
 }
 
 func ResponsePlaylist(t *testing.T, playlist model.Playlist, response model.Playlist) {
@@ -408,3 +416,13 @@ func getAverageSongsStats(songs []model.Song) (float64, float64, float64, *time.
 
 	return rehearsals, confidence, progress, lastTimePlayed
 }
+
+func getSongsTotalDuration(songs []model.Song) uint {
+	var totalDuration uint = 0
+	for _, song := range songs {
+		if song.Duration != nil {
+			totalDuration += *song.Duration
+		}
+	}
+	return totalDuration
+}
@@ -341,6 +341,9 @@ func SongFiltersMetadata(t *testing.T, metadata model.SongFiltersMetadata, songs
 	var minBpm *uint
 	var maxBpm *uint
 
+	var minDuration *uint
+	var maxDuration *uint
+
 	var minSectionsCount *int64
 	var maxSectionsCount int64 = 0
 
@@ -394,6 +397,15 @@ func SongFiltersMetadata(t *testing.T, metadata model.SongFiltersMetadata, songs
 			maxBpm = song.Bpm
 		}
 
+		if song.Duration != nil && minDuration != nil && *song.Duration < *minDuration ||
+			song.Duration != nil && minDuration == nil {
+			minDuration = song.Duration
+		}
+		if song.Duration != nil && maxDuration != nil && *song.Duration > *maxDuration ||
+			song.Duration != nil && maxDuration == nil {
+			maxDuration = song.Duration
+		}
+
 		if minRehearsals == nil || *minRehearsals > song.Rehearsals {
 			minRehearsals = &song.Rehearsals
 		}
@@ -493,6 +505,9 @@ func SongFiltersMetadata(t *testing.T, metadata model.SongFiltersMetadata, songs
 	assert.Equal(t, minBpm, metadata.MinBpm)
 	assert.Equal(t, maxBpm, metadata.MaxBpm)
 
+	assert.Equal(t, minDuration, metadata.MinDuration)
+	assert.Equal(t, maxDuration, metadata.MaxDuration)
+
 	if minSectionsCount == nil {
 		assert.Zero(t, metadata.MinSectionsCount)
 	} else {
@@ -10,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// RevokedTokenID is the jti of the access token that the test auth server reports as revoked
+var RevokedTokenID = uuid.New()
+
 type testAuthServer struct {
 }
 
@@ -35,8 +38,19 @@ func (t *testAuthServer) handle() http.HandlerFunc {
 			_, _ = w.Write(res)
 		}
 		if r.RequestURI == "/sign-in" {
-			token, _ := json.Marshal(t.createToken())
-			_, _ = w.Write(token)
+			authResp := auth.SignInResponse{
+				Token:        t.createToken(),
+				RefreshToken: uuid.New().String(),
+			}
+			res, _ := json.Marshal(authResp)
+			_, _ = w.Write(res)
+		}
+		if r.RequestURI == "/revoked-tokens" {
+			authResp := []auth.RevokedTokenResponse{
+				{ID: RevokedTokenID, ExpiresAt: time.Now().UTC().Add(time.Hour)},
+			}
+			res, _ := json.Marshal(authResp)
+			_, _ = w.Write(res)
 		}
 	}
 }
@@ -18,7 +18,9 @@ import (
 type TestHandler interface {
 	WithoutAuthentication() TestHandler
 	WithMeiliAuthentication() TestHandler
+	WithAdminAuthentication() TestHandler
 	WithInvalidToken() TestHandler
+	WithRevokedToken() TestHandler
 	WithUser(user model.User) TestHandler
 	GET(w http.ResponseWriter, url string)
 	POST(w http.ResponseWriter, url string, body any)
@@ -31,7 +33,9 @@ type TestHandler interface {
 type settings struct {
 	authentication bool
 	withMeiliAuth  bool
+	withAdminAuth  bool
 	invalidToken   bool
+	revokedToken   bool
 	user           *model.User
 }
 
@@ -60,11 +64,21 @@ func (t *testHandler) WithMeiliAuthentication() TestHandler {
 	return t
 }
 
+func (t *testHandler) WithAdminAuthentication() TestHandler {
+	t.settings.withAdminAuth = true
+	return t
+}
+
 func (t *testHandler) WithInvalidToken() TestHandler {
 	t.settings.invalidToken = true
 	return t
 }
 
+func (t *testHandler) WithRevokedToken() TestHandler {
+	t.settings.revokedToken = true
+	return t
+}
+
 func (t *testHandler) WithUser(user model.User) TestHandler {
 	t.settings.user = &user
 	return t
@@ -125,7 +139,7 @@ func (t *testHandler) DELETE(w http.ResponseWriter, url string) {
 }
 
 func (t *testHandler) requestWithAuthentication(req *http.Request) {
-	if !t.settings.authentication && !t.settings.withMeiliAuth {
+	if !t.settings.authentication && !t.settings.withMeiliAuth && !t.settings.withAdminAuth {
 		return
 	}
 
@@ -134,6 +148,11 @@ func (t *testHandler) requestWithAuthentication(req *http.Request) {
 		return
 	}
 
+	if t.settings.withAdminAuth {
+		req.Header.Set("Authorization", internal.NewEnv().AdminAuthKey)
+		return
+	}
+
 	if t.settings.invalidToken {
 		req.Header.Set("Authorization", "bearer "+t.createInvalidToken())
 		return
@@ -147,7 +166,12 @@ func (t *testHandler) requestWithAuthentication(req *http.Request) {
 		user = *t.settings.user
 	}
 
-	token := t.createToken(user)
+	tokenID := uuid.New()
+	if t.settings.revokedToken {
+		tokenID = RevokedTokenID
+	}
+
+	token := t.createToken(user, tokenID)
 	req.Header.Set("Authorization", "bearer "+token)
 }
 
@@ -165,9 +189,9 @@ func (t *testHandler) createInvalidToken() string {
 	return token
 }
 
-func (t *testHandler) createToken(user model.User) string {
+func (t *testHandler) createToken(user model.User, tokenID uuid.UUID) string {
 	claims := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"jti": uuid.New().String(),
+		"jti": tokenID.String(),
 		"sub": user.ID.String(),
 		"iss": jwtInfo.Issuer,
 		"aud": jwtInfo.Audience,
@@ -14,6 +14,7 @@ import (
 	"repertoire/server/data/message"
 	"repertoire/server/domain"
 	"repertoire/server/internal"
+	"strings"
 	"time"
 
 	"github.com/meilisearch/meilisearch-go"
@@ -162,18 +163,27 @@ func (ts *TestServer) Start() {
 		ts.setupCentrifugoContainer()
 	}
 
+	ts.StartApplication()
+}
+
+// StartApplication starts (or restarts) only the application, on top of the running containers,
+// with the additional options being applied after the modules are populated
+func (ts *TestServer) StartApplication(options ...fx.Option) {
 	// Setup application modules and populate the router
 	// Implicitly, the application will connect to the database
 	gin.SetMode(gin.TestMode)
-	ts.app = fx.New(
-		internal.Module,
-		data.Module,
-		domain.Module,
-		api.Module,
-		fx.Populate(&httpServer),
-		fx.Populate(&MessageBroker),
-		fx.Populate(&MeiliCache),
-	)
+	ts.app = fx.New(append(
+		[]fx.Option{
+			internal.Module,
+			data.Module,
+			domain.Module,
+			api.Module,
+			fx.Populate(&httpServer),
+			fx.Populate(&MessageBroker),
+			fx.Populate(&MeiliCache),
+		},
+		options...,
+	)...)
 
 	// Start application
 	if err := ts.app.Start(context.Background()); err != nil {
@@ -181,10 +191,15 @@ func (ts *TestServer) Start() {
 	}
 }
 
-func (ts *TestServer) Stop() {
+// StopApplication stops only the application, like a crash would, while keeping the containers running
+func (ts *TestServer) StopApplication() {
 	if err := ts.app.Stop(context.Background()); err != nil {
 		log.Fatal(err)
 	}
+}
+
+func (ts *TestServer) Stop() {
+	ts.StopApplication()
 	if err := testcontainers.TerminateContainer(ts.dbContainer); err != nil {
 		log.Printf("failed to terminate postgres db container: %s", err)
 	}
@@ -244,8 +259,27 @@ func (ts *TestServer) setupPostgresContainer(env internal.Env, relativePath stri
 	_ = db.Close()
 }
 
+// StorageUsageResponse is what the test storage responds with, when asked about the usage of any user
+var StorageUsageResponse = `{
+	"size": 31,
+	"quota": 100,
+	"files": [
+		{"path": "user/image.jpg", "size": 1, "modTime": "2025-01-01T00:00:00Z"},
+		{"path": "user/albums/album/image.jpg", "size": 2, "modTime": "2025-01-01T00:00:00Z"},
+		{"path": "user/artists/artist/image.jpg", "size": 3, "modTime": "2025-01-01T00:00:00Z"},
+		{"path": "user/artists/artist/members/member/image.jpg", "size": 4, "modTime": "2025-01-01T00:00:00Z"},
+		{"path": "user/playlists/playlist/image.jpg", "size": 5, "modTime": "2025-01-01T00:00:00Z"},
+		{"path": "user/songs/song/image.jpg", "size": 16, "modTime": "2025-01-01T00:00:00Z"}
+	]
+}`
+
 func (ts *TestServer) setupStorageServer() {
 	ts.storageServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/usage") {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(StorageUsageResponse))
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 	}))
 	_ = os.Setenv("STORAGE_UPLOAD_URL", ts.storageServer.URL)
@@ -314,14 +348,15 @@ func (ts *TestServer) setupMeiliContainer(env internal.Env) {
 	}
 
 	_, err = meiliClient.Index("search").UpdateFilterableAttributes(&[]interface{}{
-		"type", "userId", "album", "album.id", "artist", "artist.id",
+		"type", "userId", "album", "album.id", "artist", "artist.id", "song", "song.id",
+		"releaseYear", "difficulty", "guitarTuningId",
 	})
 	if err != nil {
 		log.Println(err)
 	}
 
 	_, err = meiliClient.Index("search").UpdateSortableAttributes(&[]string{
-		"title", "name", "updatedAt", "createdAt", "album", "album.title", "artist", "artist.name",
+		"title", "name", "updatedAt", "createdAt", "album", "album.title", "artist", "artist.name", "releaseYear",
 	})
 	if err != nil {
 		log.Println(err)
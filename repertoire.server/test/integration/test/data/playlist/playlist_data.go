@@ -70,6 +70,16 @@ var Playlists = []model.Playlist{
 		Title:  "Test Playlist 3",
 		UserID: Users[0].ID,
 	},
+	{
+		ID:    uuid.New(),
+		Title: "Test Smart Playlist",
+		SmartRules: &model.PlaylistSmartRules{
+			SearchBy: []string{"album_id = " + Albums[1].ID.String()},
+			OrderBy:  []string{"title desc"},
+			Limit:    &[]uint{2}[0],
+		},
+		UserID: Users[0].ID,
+	},
 }
 
 var Songs = []model.Song{
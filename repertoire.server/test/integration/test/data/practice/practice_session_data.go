@@ -0,0 +1,112 @@
+package practice
+
+import (
+	"repertoire/server/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func SeedData(db *gorm.DB) {
+	db.Create(&Users)
+	db.Create(&Songs)
+	db.Create(&PracticeSessions)
+}
+
+var Users = []model.User{
+	{
+		ID:       uuid.New(),
+		Name:     "John Doe",
+		Email:    "johndoe@gmail.com",
+		Password: "",
+		SongSectionTypes: []model.SongSectionType{
+			{
+				ID:    uuid.New(),
+				Name:  "Chorus",
+				Order: 0,
+			},
+			{
+				ID:    uuid.New(),
+				Name:  "Verse",
+				Order: 1,
+			},
+		},
+	},
+	{
+		ID:       uuid.New(),
+		Name:     "Jane Doe",
+		Email:    "janedoe@gmail.com",
+		Password: "",
+	},
+}
+
+var Songs = []model.Song{
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 1",
+		UserID: Users[0].ID,
+		Sections: []model.SongSection{
+			{
+				ID:                uuid.New(),
+				Name:              "Verse 1",
+				Order:             0,
+				SongSectionTypeID: Users[0].SongSectionTypes[1].ID,
+			},
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 1",
+				Order:             1,
+				SongSectionTypeID: Users[0].SongSectionTypes[0].ID,
+			},
+		},
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 2",
+		UserID: Users[0].ID,
+		Sections: []model.SongSection{
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 1",
+				Order:             0,
+				SongSectionTypeID: Users[0].SongSectionTypes[0].ID,
+			},
+		},
+	},
+}
+
+var PracticeSessions = []model.PracticeSession{
+	{
+		ID:        uuid.New(),
+		Notes:     "Worked on the verses",
+		StartedAt: time.Now().UTC().Add(-48 * time.Hour),
+		EndedAt:   &[]time.Time{time.Now().UTC().Add(-47 * time.Hour)}[0],
+		UserID:    Users[0].ID,
+		Songs: []model.PracticeSessionSong{
+			{
+				ID:     uuid.New(),
+				SongID: Songs[0].ID,
+				Sections: []model.PracticeSessionSection{
+					{
+						ID:            uuid.New(),
+						Occurrences:   2,
+						SongSectionID: Songs[0].Sections[0].ID,
+					},
+				},
+			},
+		},
+	},
+	{
+		ID:        uuid.New(),
+		StartedAt: time.Now().UTC().Add(-24 * time.Hour),
+		EndedAt:   &[]time.Time{time.Now().UTC().Add(-23 * time.Hour)}[0],
+		UserID:    Users[0].ID,
+	},
+	{
+		ID:        uuid.New(),
+		Notes:     "Practicing right now",
+		StartedAt: time.Now().UTC().Add(-1 * time.Hour),
+		UserID:    Users[0].ID,
+	},
+}
@@ -0,0 +1,130 @@
+package progress
+
+import (
+	"repertoire/server/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func SeedData(db *gorm.DB) {
+	db.Create(&Users)
+	db.Create(&Artists)
+	db.Create(&Albums)
+	db.Create(&Songs)
+	db.Create(&Playlists)
+	db.Create(&PlaylistSongs)
+}
+
+var Users = []model.User{
+	{
+		ID:       uuid.New(),
+		Name:     "John Doe",
+		Email:    "johndoe@gmail.com",
+		Password: "",
+		SongSectionTypes: []model.SongSectionType{
+			{
+				ID:    uuid.New(),
+				Name:  "Chorus",
+				Order: 0,
+			},
+		},
+	},
+	{
+		ID:       uuid.New(),
+		Name:     "Jane Doe",
+		Email:    "janedoe@gmail.com",
+		Password: "",
+	},
+}
+
+var Artists = []model.Artist{
+	{ID: uuid.New(), Name: "Test Artist 1", UserID: Users[0].ID},
+}
+
+var Albums = []model.Album{
+	{ID: uuid.New(), Title: "Test Album 1", ArtistID: &Artists[0].ID, UserID: Users[0].ID},
+}
+
+var Songs = []model.Song{
+	{
+		ID:       uuid.New(),
+		Title:    "Test Song 1",
+		UserID:   Users[0].ID,
+		ArtistID: &Artists[0].ID,
+		AlbumID:  &Albums[0].ID,
+		Sections: []model.SongSection{
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 1",
+				Order:             0,
+				SongSectionTypeID: Users[0].SongSectionTypes[0].ID,
+				CreatedAt:         time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				History: []model.SongSectionHistory{
+					{
+						ID:        uuid.New(),
+						Property:  model.RehearsalsProperty,
+						From:      0,
+						To:        2,
+						CreatedAt: time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:        uuid.New(),
+						Property:  model.ConfidenceProperty,
+						From:      0,
+						To:        50,
+						CreatedAt: time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 2",
+				Order:             1,
+				SongSectionTypeID: Users[0].SongSectionTypes[0].ID,
+				CreatedAt:         time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				History: []model.SongSectionHistory{
+					{
+						ID:        uuid.New(),
+						Property:  model.RehearsalsProperty,
+						From:      0,
+						To:        1,
+						CreatedAt: time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 2",
+		UserID: Users[0].ID,
+		Sections: []model.SongSection{
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 1",
+				Order:             0,
+				SongSectionTypeID: Users[0].SongSectionTypes[0].ID,
+				CreatedAt:         time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				History: []model.SongSectionHistory{
+					{
+						ID:        uuid.New(),
+						Property:  model.RehearsalsProperty,
+						From:      0,
+						To:        4,
+						CreatedAt: time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+	},
+}
+
+var Playlists = []model.Playlist{
+	{ID: uuid.New(), Title: "Test Playlist 1", UserID: Users[0].ID},
+}
+
+var PlaylistSongs = []model.PlaylistSong{
+	{ID: uuid.New(), PlaylistID: Playlists[0].ID, SongID: Songs[1].ID, SongTrackNo: 1},
+}
@@ -86,6 +86,7 @@ var SongSearches = []any{
 	model.SongSearch{
 		Title:       "Justice",
 		ReleaseDate: &[]string{time.Now().Format("YYYY-MM-DD")}[0],
+		Difficulty:  &[]enums.Difficulty{enums.Easy}[0],
 		ImageUrl:    &[]internal.FilePath{"song-image.png"}[0],
 		Artist:      fromArtistSearchToSongArtistSearch(ArtistSearches[0].(model.ArtistSearch)),
 		Album:       fromAlbumSearchToSongAlbumSearch(AlbumSearches[0].(model.AlbumSearch)),
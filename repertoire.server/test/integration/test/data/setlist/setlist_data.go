@@ -0,0 +1,132 @@
+package setlist
+
+import (
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func SeedData(db *gorm.DB) {
+	db.Create(&Users)
+	db.Create(&Songs)
+	db.Create(&Playlists)
+	db.Create(&PlaylistSongs)
+	db.Create(&Setlists)
+}
+
+var Users = []model.User{
+	{
+		ID:       uuid.New(),
+		Name:     "John Doe",
+		Email:    "johndoe@gmail.com",
+		Password: "",
+	},
+	{
+		ID:       uuid.New(),
+		Name:     "Jane Doe",
+		Email:    "janedoe@gmail.com",
+		Password: "",
+	},
+}
+
+var Songs = []model.Song{
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 1",
+		UserID: Users[0].ID,
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 2",
+		UserID: Users[0].ID,
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Song 3",
+		UserID: Users[0].ID,
+	},
+}
+
+var Playlists = []model.Playlist{
+	{
+		ID:     uuid.New(),
+		Title:  "Test Playlist 1",
+		UserID: Users[0].ID,
+	},
+	{
+		ID:    uuid.New(),
+		Title: "Test Smart Playlist",
+		SmartRules: &model.PlaylistSmartRules{
+			SearchBy: []string{"title <> 'Test Song 2'"},
+			OrderBy:  []string{"title desc"},
+		},
+		UserID: Users[0].ID,
+	},
+}
+
+var PlaylistSongs = []model.PlaylistSong{
+	{
+		ID:          uuid.New(),
+		PlaylistID:  Playlists[0].ID,
+		SongID:      Songs[2].ID,
+		SongTrackNo: 1,
+	},
+	{
+		ID:          uuid.New(),
+		PlaylistID:  Playlists[0].ID,
+		SongID:      Songs[0].ID,
+		SongTrackNo: 2,
+	},
+}
+
+var Setlists = []model.Setlist{
+	{
+		ID:     uuid.New(),
+		Title:  "Test Setlist 1",
+		Venue:  "Some Club",
+		Date:   &[]time.Time{time.Now().UTC().Add(7 * 24 * time.Hour)}[0],
+		UserID: Users[0].ID,
+		Entries: []model.SetlistEntry{
+			{
+				ID:      uuid.New(),
+				Type:    enums.TalkEntry,
+				Title:   "Introduction",
+				EntryNo: 1,
+			},
+			{
+				ID:              uuid.New(),
+				Type:            enums.SongEntry,
+				PlannedDuration: &[]uint{240}[0],
+				EntryNo:         2,
+				SongID:          &Songs[0].ID,
+			},
+			{
+				ID:              uuid.New(),
+				Type:            enums.TuningBreakEntry,
+				Title:           "Drop D",
+				PlannedDuration: &[]uint{30}[0],
+				EntryNo:         3,
+			},
+			{
+				ID:              uuid.New(),
+				Type:            enums.SongEntry,
+				PlannedDuration: &[]uint{180}[0],
+				EntryNo:         4,
+				SongID:          &Songs[1].ID,
+			},
+		},
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Setlist 2",
+		UserID: Users[0].ID,
+	},
+	{
+		ID:     uuid.New(),
+		Title:  "Test Setlist 3",
+		UserID: Users[1].ID,
+	},
+}
@@ -170,6 +170,9 @@ var songSections = []model.SongSection{
 		SongSectionTypeID: Users[0].SongSectionTypes[2].ID,
 		BandMemberID:      &Artists[0].BandMembers[0].ID,
 		InstrumentID:      &Users[0].Instruments[1].ID,
+		StartOffset:       &[]uint{0}[0],
+		BarCount:          &[]uint{16}[0],
+		TimeSignature:     &[]string{"4/4"}[0],
 		Order:             0,
 		Confidence:        10,
 		Rehearsals:        10,
@@ -234,6 +237,7 @@ var Songs = []model.Song{
 		ImageURL:      &[]internal.FilePath{"userId/Some image path/somewhere.jpeg"}[0],
 		IsRecorded:    true,
 		Bpm:           &[]uint{123}[0],
+		Duration:      &[]uint{245}[0],
 		Difficulty:    &[]enums.Difficulty{enums.Easy}[0],
 		SongsterrLink: &[]string{"https://songster.com/some-song"}[0],
 		YoutubeLink:   &[]string{"https://youtube.com/some-song"}[0],
@@ -266,6 +270,7 @@ var Songs = []model.Song{
 		AlbumID:      &[]uuid.UUID{Albums[0].ID}[0],
 		AlbumTrackNo: &[]uint{3}[0],
 		Bpm:          &[]uint{81}[0],
+		Duration:     &[]uint{312}[0],
 		UserID:       Users[0].ID,
 	},
 	{
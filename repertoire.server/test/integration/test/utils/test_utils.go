@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"mime/multipart"
 	"os"
+	"repertoire/server/data/search"
 	"repertoire/server/internal"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
@@ -119,8 +120,25 @@ type SubscribedToTopic struct {
 	Topic    topics.Topic
 }
 
+// SubscribeToTopic acknowledges all the messages of the queue right away,
+// as the in-memory broker waits for every subscriber to acknowledge a message before publishing the next one
 func SubscribeToTopic(topic topics.Topic) SubscribedToTopic {
-	messages, _ := core.MessageBroker.Subscribe(context.Background(), string(topics.TopicToQueueMap[topic]))
+	queueMessages, _ := core.MessageBroker.Subscribe(context.Background(), string(topics.TopicToQueueMap[topic]))
+
+	messages := make(chan *message.Message, 100)
+	go func() {
+		for msg := range queueMessages {
+			msg.Ack()
+			if msg.Metadata.Get("topic") != string(topic) {
+				continue
+			}
+			select {
+			case messages <- msg:
+			default:
+			}
+		}
+	}()
+
 	return SubscribedToTopic{
 		Messages: messages,
 		Topic:    topic,
@@ -150,6 +168,46 @@ func SeedAndCleanupSearchData(t *testing.T, items []any) {
 	})
 }
 
+// SeedAndCleanupPostgresSearchData seeds the search documents straight into the database,
+// for when Postgres is used as the search engine
+func SeedAndCleanupPostgresSearchData(t *testing.T, items []any) {
+	db := GetDatabase(t)
+
+	for _, item := range items {
+		document, _ := search.NewPostgresDocument(UnmarshalDocument[map[string]any](item))
+		db.Create(&document)
+	}
+
+	t.Cleanup(func() {
+		db.Where("1 = 1").Delete(&model.SearchDocument{})
+	})
+}
+
+// Failure Injection
+
+// FailOnUpdate makes every update on the given table raise an error until the end of the test,
+// so that the transactions writing into it can be proven to roll back
+func FailOnUpdate(t *testing.T, table string) {
+	db := GetDatabase(t)
+	function := fmt.Sprintf("fail_on_update_%s", table)
+	trigger := fmt.Sprintf("trigger_fail_on_update_%s", table)
+
+	db.Exec(fmt.Sprintf(`CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'injected failure on %s';
+		END;
+		$$ LANGUAGE plpgsql`, function, table))
+	db.Exec(fmt.Sprintf(
+		"CREATE TRIGGER %s BEFORE UPDATE ON %s FOR EACH ROW EXECUTE FUNCTION %s()",
+		trigger, table, function,
+	))
+
+	t.Cleanup(func() {
+		db.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s", trigger, table))
+		db.Exec(fmt.Sprintf("DROP FUNCTION IF EXISTS %s()", function))
+	})
+}
+
 // Misc Utils
 
 func AttachFileToMultipartBody(fileName string, formName string, multiWriter *multipart.Writer) {
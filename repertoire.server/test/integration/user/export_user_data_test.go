@@ -0,0 +1,89 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	userData "repertoire/server/test/integration/test/data/user"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportUserData_WhenUserIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithInvalidToken().
+		GET(w, "/api/users/export")
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestExportUserData_WhenSuccessful_ShouldStreamTheArchive(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, userData.Users, userData.SeedData)
+
+	user := userData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/users/export")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	reader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		f, _ := file.Open()
+		files[file.Name], _ = io.ReadAll(f)
+		_ = f.Close()
+	}
+
+	var exportedUser model.ExportedUser
+	_ = json.Unmarshal(files["user.json"], &exportedUser)
+	assert.Equal(t, user.ID, exportedUser.ID)
+	assert.Equal(t, user.Email, exportedUser.Email)
+
+	var artists []model.ExportedArtist
+	_ = json.Unmarshal(files["artists.json"], &artists)
+	assert.Len(t, artists, len(userData.Artists))
+
+	var albums []model.ExportedAlbum
+	_ = json.Unmarshal(files["albums.json"], &albums)
+	assert.Len(t, albums, len(userData.Albums))
+	assert.Equal(t, userData.Albums[0].ArtistID, albums[0].ArtistID)
+
+	var songs []model.ExportedSong
+	_ = json.Unmarshal(files["songs.json"], &songs)
+	assert.Len(t, songs, len(userData.Songs))
+	assert.Equal(t, userData.Songs[0].ID, songs[0].ID)
+	assert.Equal(t, userData.Songs[0].GuitarTuningID, songs[0].GuitarTuningID)
+	assert.Len(t, songs[0].Sections, len(userData.Songs[0].Sections))
+
+	var playlists []model.ExportedPlaylist
+	_ = json.Unmarshal(files["playlists.json"], &playlists)
+	assert.Len(t, playlists, len(userData.Playlists))
+
+	var guitarTunings []model.ExportedUserDataItem
+	_ = json.Unmarshal(files["guitar_tunings.json"], &guitarTunings)
+	assert.Len(t, guitarTunings, len(user.GuitarTunings))
+
+	var sectionTypes []model.ExportedUserDataItem
+	_ = json.Unmarshal(files["section_types.json"], &sectionTypes)
+	assert.Len(t, sectionTypes, len(user.SongSectionTypes))
+}
@@ -26,6 +26,21 @@ func TestGetCurrentUser_WhenUserIsNotFound_ShouldReturnNotFoundError(t *testing.
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestGetCurrentUser_WhenTokenIsRevoked_ShouldReturnUnauthorizedError(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, userData.Users, userData.SeedData)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(userData.Users[0]).
+		WithRevokedToken().
+		GET(w, "/api/users/current")
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
 func TestGetCurrentUser_WhenSuccessful_ShouldReturnCurrentUser(t *testing.T) {
 	// given
 	utils.SeedAndCleanupData(t, userData.Users, userData.SeedData)
@@ -0,0 +1,44 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/core"
+	userData "repertoire/server/test/integration/test/data/user"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserStorageUsage_WhenSuccessful_ShouldReturnTheUsageBrokenDown(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, userData.Users, userData.SeedData)
+
+	user := userData.Users[0]
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		GET(w, "/api/users/storage-usage")
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var usage model.StorageUsage
+	_ = json.Unmarshal(w.Body.Bytes(), &usage)
+
+	assert.Equal(t, model.StorageUsage{
+		Size:        31,
+		Quota:       100,
+		Albums:      2,
+		Artists:     3,
+		BandMembers: 4,
+		Playlists:   5,
+		Songs:       16,
+		Others:      1,
+	}, usage)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"repertoire/server/api/requests"
+	httpAuth "repertoire/server/data/http/auth"
 	"repertoire/server/model"
 	"repertoire/server/test/integration/test/assertion"
 	"repertoire/server/test/integration/test/core"
@@ -38,7 +39,7 @@ func TestSignUp_WhenUserAlreadyExists_ShouldReturnBadRequestError(t *testing.T)
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestSignUp_WhenSuccessful_ShouldCreateUserAndReturnToken(t *testing.T) {
+func TestSignUp_WhenSuccessful_ShouldCreateUserAndReturnTokens(t *testing.T) {
 	// given
 	utils.SeedAndCleanupData(t, []model.User{}, func(*gorm.DB) {})
 
@@ -59,9 +60,10 @@ func TestSignUp_WhenSuccessful_ShouldCreateUserAndReturnToken(t *testing.T) {
 
 	assertCreatedUser(t, request)
 
-	var token string
-	_ = json.Unmarshal(w.Body.Bytes(), &token)
-	assertion.Token(t, token)
+	var tokens httpAuth.SignInResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &tokens)
+	assertion.Token(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
 }
 
 func assertCreatedUser(t *testing.T, request requests.SignUpRequest) {
@@ -78,6 +80,7 @@ func assertCreatedUser(t *testing.T, request requests.SignUpRequest) {
 	assert.Equal(t, request.Name, user.Name)
 	assert.Equal(t, strings.ToLower(request.Email), user.Email)
 	assert.NotEmpty(t, user.Password)
+	assert.False(t, user.EmailVerified)
 	assert.Nil(t, user.ProfilePictureURL)
 
 	assert.Len(t, user.GuitarTunings, len(model.DefaultGuitarTunings))
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"repertoire/server/api/requests"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+	"repertoire/server/test/integration/test/assertion"
+	"repertoire/server/test/integration/test/core"
+	userData "repertoire/server/test/integration/test/data/user"
+	"repertoire/server/test/integration/test/utils"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUserScoringStrategy_WhenUserIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithInvalidToken().
+		PUT(w, "/api/users/scoring-strategy", request)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUpdateUserScoringStrategy_WhenSuccessful_ShouldUpdateUserAndPublishMessage(t *testing.T) {
+	// given
+	utils.SeedAndCleanupData(t, userData.Users, userData.SeedData)
+
+	user := userData.Users[0]
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.ExponentialScoring,
+	}
+
+	messages := utils.SubscribeToTopic(topics.UserScoringStrategyUpdatedTopic)
+
+	// when
+	w := httptest.NewRecorder()
+	core.NewTestHandler().
+		WithUser(user).
+		PUT(w, "/api/users/scoring-strategy", request)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	db := utils.GetDatabase(t)
+	var newUser model.User
+	db.Find(&newUser, user.ID)
+	assert.Equal(t, request.ScoringStrategy, newUser.ScoringStrategy)
+
+	assertion.AssertMessage(t, messages, func(userID uuid.UUID) {
+		assert.Equal(t, user.ID, userID)
+	})
+}
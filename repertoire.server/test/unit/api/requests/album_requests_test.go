@@ -100,7 +100,7 @@ func TestValidateGetAlbumsRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				CurrentPage: &[]int{1}[0],
 				PageSize:    &[]int{1}[0],
 				OrderBy:     []string{"title nulls first", "created_at desc nulls last"},
-				SearchBy:    []string{"title = something", "is_recorded <> false"},
+				SearchBy:    []string{"title = something", "songs_count > 0"},
 			},
 		},
 	}
@@ -197,7 +197,7 @@ func TestValidateGetAlbumFiltersMetadataRequest_WhenIsValid_ShouldReturnNil(t *t
 		{
 			"Nothing Null",
 			requests.GetAlbumFiltersMetadataRequest{
-				SearchBy: []string{"title = something", "is_recorded <> false"},
+				SearchBy: []string{"title = something", "songs_count > 0"},
 			},
 		},
 	}
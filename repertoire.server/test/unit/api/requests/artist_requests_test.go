@@ -26,7 +26,7 @@ func TestValidateGetArtistsRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				CurrentPage: &[]int{1}[0],
 				PageSize:    &[]int{1}[0],
 				OrderBy:     []string{"name asc"},
-				SearchBy:    []string{"name = Metallica", "release_date is null"},
+				SearchBy:    []string{"name = Metallica", "is_band = true"},
 			},
 		},
 	}
@@ -123,7 +123,7 @@ func TestValidateGetArtistFiltersMetadataRequest_WhenIsValid_ShouldReturnNil(t *
 		{
 			"Nothing Null",
 			requests.GetArtistFiltersMetadataRequest{
-				SearchBy: []string{"name = Metallica", "release_date is null"},
+				SearchBy: []string{"name = Metallica", "is_band = true"},
 			},
 		},
 	}
@@ -241,6 +241,11 @@ func TestValidateCreatePlaylistRequest_WhenIsValid_ShouldReturnNil(t *testing.T)
 
 	request := requests.CreatePlaylistRequest{
 		Title: validPlaylistTitle,
+		SmartRules: &requests.PlaylistSmartRulesRequest{
+			SearchBy: []string{"guitar_tuning_id = " + uuid.New().String(), "last_time_played < now-14d"},
+			OrderBy:  []string{"progress asc"},
+			Limit:    &[]uint{20}[0],
+		},
 	}
 
 	// when
@@ -270,6 +275,34 @@ func TestValidateCreatePlaylistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadR
 			"Title",
 			"max",
 		},
+		// Smart Rules Test Cases
+		{
+			"Smart Rules Search By is invalid because the field is unknown",
+			requests.CreatePlaylistRequest{
+				Title:      validPlaylistTitle,
+				SmartRules: &requests.PlaylistSmartRulesRequest{SearchBy: []string{"tuning = Drop D"}},
+			},
+			"SmartRules.SearchBy",
+			"search_by",
+		},
+		{
+			"Smart Rules Order By is invalid because the field is unknown",
+			requests.CreatePlaylistRequest{
+				Title:      validPlaylistTitle,
+				SmartRules: &requests.PlaylistSmartRulesRequest{OrderBy: []string{"song_track_no"}},
+			},
+			"SmartRules.OrderBy",
+			"order_by",
+		},
+		{
+			"Smart Rules Limit is invalid because it should be greater than 0",
+			requests.CreatePlaylistRequest{
+				Title:      validPlaylistTitle,
+				SmartRules: &requests.PlaylistSmartRulesRequest{Limit: &[]uint{0}[0]},
+			},
+			"SmartRules.Limit",
+			"gt",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -289,6 +322,114 @@ func TestValidateCreatePlaylistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadR
 	}
 }
 
+func TestValidateUpdatePlaylistSmartRulesRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.UpdatePlaylistSmartRulesRequest
+	}{
+		{
+			"Without Smart Rules",
+			requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()},
+		},
+		{
+			"With Smart Rules",
+			requests.UpdatePlaylistSmartRulesRequest{
+				ID: uuid.New(),
+				SmartRules: &requests.PlaylistSmartRulesRequest{
+					SearchBy: []string{"confidence < 60"},
+					OrderBy:  []string{"progress asc"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateUpdatePlaylistSmartRulesRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.UpdatePlaylistSmartRulesRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.UpdatePlaylistSmartRulesRequest{ID: uuid.Nil},
+			"ID",
+			"required",
+		},
+		// Smart Rules Test Cases
+		{
+			"Smart Rules Search By is invalid because the value does not match the type of the field",
+			requests.UpdatePlaylistSmartRulesRequest{
+				ID:         uuid.New(),
+				SmartRules: &requests.PlaylistSmartRulesRequest{SearchBy: []string{"confidence < low"}},
+			},
+			"SmartRules.SearchBy",
+			"search_by",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "UpdatePlaylistSmartRulesRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateFreezePlaylistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateFreezePlaylistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.Nil}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Len(t, errCode.Error, 1)
+	assert.Contains(t, errCode.Error.Error(), "FreezePlaylistRequest.ID")
+	assert.Contains(t, errCode.Error.Error(), "'required' tag")
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+}
+
 func TestValidateAddAlbumsToPlaylistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 	// given
 	_uut := validation.NewValidator(nil)
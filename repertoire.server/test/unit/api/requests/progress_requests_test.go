@@ -0,0 +1,87 @@
+package requests
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/validation"
+	"repertoire/server/internal/enums"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGetProgressTimelineRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.GetProgressTimelineRequest
+	}{
+		{
+			"Minimal",
+			requests.GetProgressTimelineRequest{Interval: enums.DayInterval},
+		},
+		{
+			"Full",
+			requests.GetProgressTimelineRequest{
+				Interval: enums.MonthInterval,
+				From:     &[]time.Time{time.Now().Add(-24 * time.Hour)}[0],
+				To:       &[]time.Time{time.Now()}[0],
+				Scope:    &[]enums.TimelineScope{enums.SongTimeline}[0],
+				ScopeID:  uuid.New(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateGetProgressTimelineRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.GetProgressTimelineRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Interval Test Cases
+		{
+			"Interval is invalid because it's required",
+			requests.GetProgressTimelineRequest{Interval: ""},
+			"Interval",
+			"required",
+		},
+		{
+			"Interval is invalid because it is not a Timeline Interval Enum",
+			requests.GetProgressTimelineRequest{Interval: "year"},
+			"Interval",
+			"timeline_interval_enum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "GetProgressTimelineRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
@@ -66,6 +66,15 @@ func TestValidateSearchGetRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				NotIDs: []string{"1", "2"},
 			},
 		},
+		{
+			"With Search Options",
+			requests.SearchGetRequest{
+				Facets:           true,
+				Highlight:        true,
+				TypoTolerance:    &[]bool{false}[0],
+				MatchingStrategy: &[]enums.SearchMatchingStrategy{enums.FrequencyMatchingStrategy}[0],
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -176,6 +185,15 @@ func TestValidateSearchGetRequest_WhenSingleFieldIsInvalid_ShouldReturnBadReques
 			"Order",
 			"search_order",
 		},
+		// Matching Strategy
+		{
+			"Matching Strategy is invalid because it is not part of the enum",
+			requests.SearchGetRequest{
+				MatchingStrategy: &[]enums.SearchMatchingStrategy{"something"}[0],
+			},
+			"MatchingStrategy",
+			"search_matching_strategy_enum",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
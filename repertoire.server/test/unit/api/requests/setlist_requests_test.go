@@ -0,0 +1,712 @@
+package requests
+
+import (
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/api/validation"
+	"repertoire/server/internal/enums"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var validSetlistTitle = "Friday Gig"
+
+func TestValidateGetSetlistsRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.GetSetlistsRequest
+	}{
+		{
+			"All Null",
+			requests.GetSetlistsRequest{},
+		},
+		{
+			"Pagination",
+			requests.GetSetlistsRequest{CurrentPage: &[]int{1}[0], PageSize: &[]int{10}[0]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateGetSetlistsRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.GetSetlistsRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Current Page Test Cases
+		{
+			"Current Page is invalid because it is required when Page Size is set",
+			requests.GetSetlistsRequest{PageSize: &[]int{10}[0]},
+			"CurrentPage",
+			"required_with",
+		},
+		{
+			"Current Page is invalid because it should be greater than 0",
+			requests.GetSetlistsRequest{CurrentPage: &[]int{0}[0], PageSize: &[]int{10}[0]},
+			"CurrentPage",
+			"gt",
+		},
+
+		// Page Size Test Cases
+		{
+			"Page Size is invalid because it is required when Current Page is set",
+			requests.GetSetlistsRequest{CurrentPage: &[]int{1}[0]},
+			"PageSize",
+			"required_with",
+		},
+		{
+			"Page Size is invalid because it should be greater than 0",
+			requests.GetSetlistsRequest{CurrentPage: &[]int{1}[0], PageSize: &[]int{0}[0]},
+			"PageSize",
+			"gt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "GetSetlistsRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateCreateSetlistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.CreateSetlistRequest
+	}{
+		{
+			"Minimal",
+			requests.CreateSetlistRequest{Title: validSetlistTitle},
+		},
+		{
+			"Maximal",
+			requests.CreateSetlistRequest{
+				Title: validSetlistTitle,
+				Venue: "The Garage",
+				Date:  &[]time.Time{time.Now()}[0],
+				Notes: "Bring the spare strings",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateCreateSetlistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.CreateSetlistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Title Test Cases
+		{
+			"Title is invalid because it's required",
+			requests.CreateSetlistRequest{Title: ""},
+			"Title",
+			"required",
+		},
+		{
+			"Title is invalid because it has more than 100 characters",
+			requests.CreateSetlistRequest{Title: strings.Repeat("a", 101)},
+			"Title",
+			"max",
+		},
+
+		// Venue Test Cases
+		{
+			"Venue is invalid because it has more than 100 characters",
+			requests.CreateSetlistRequest{Title: validSetlistTitle, Venue: strings.Repeat("a", 101)},
+			"Venue",
+			"max",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "CreateSetlistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateCreateSetlistFromPlaylistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      validSetlistTitle,
+	}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateCreateSetlistFromPlaylistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.CreateSetlistFromPlaylistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Playlist ID Test Cases
+		{
+			"Playlist ID is invalid because it's required",
+			requests.CreateSetlistFromPlaylistRequest{PlaylistID: uuid.Nil, Title: validSetlistTitle},
+			"PlaylistID",
+			"required",
+		},
+
+		// Title Test Cases
+		{
+			"Title is invalid because it's required",
+			requests.CreateSetlistFromPlaylistRequest{PlaylistID: uuid.New(), Title: ""},
+			"Title",
+			"required",
+		},
+		{
+			"Title is invalid because it has more than 100 characters",
+			requests.CreateSetlistFromPlaylistRequest{PlaylistID: uuid.New(), Title: strings.Repeat("a", 101)},
+			"Title",
+			"max",
+		},
+
+		// Venue Test Cases
+		{
+			"Venue is invalid because it has more than 100 characters",
+			requests.CreateSetlistFromPlaylistRequest{
+				PlaylistID: uuid.New(),
+				Title:      validSetlistTitle,
+				Venue:      strings.Repeat("a", 101),
+			},
+			"Venue",
+			"max",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "CreateSetlistFromPlaylistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateUpdateSetlistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: validSetlistTitle,
+	}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateUpdateSetlistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.UpdateSetlistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.UpdateSetlistRequest{ID: uuid.Nil, Title: validSetlistTitle},
+			"ID",
+			"required",
+		},
+
+		// Title Test Cases
+		{
+			"Title is invalid because it's required",
+			requests.UpdateSetlistRequest{ID: uuid.New(), Title: ""},
+			"Title",
+			"required",
+		},
+		{
+			"Title is invalid because it has more than 100 characters",
+			requests.UpdateSetlistRequest{ID: uuid.New(), Title: strings.Repeat("a", 101)},
+			"Title",
+			"max",
+		},
+
+		// Venue Test Cases
+		{
+			"Venue is invalid because it has more than 100 characters",
+			requests.UpdateSetlistRequest{
+				ID:    uuid.New(),
+				Title: validSetlistTitle,
+				Venue: strings.Repeat("a", 101),
+			},
+			"Venue",
+			"max",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "UpdateSetlistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+// Entries
+
+func TestValidateAddEntryToSetlistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.AddEntryToSetlistRequest
+	}{
+		{
+			"Song",
+			requests.AddEntryToSetlistRequest{
+				ID:     uuid.New(),
+				Type:   enums.SongEntry,
+				SongID: &[]uuid.UUID{uuid.New()}[0],
+			},
+		},
+		{
+			"Song with Planned Duration",
+			requests.AddEntryToSetlistRequest{
+				ID:              uuid.New(),
+				Type:            enums.SongEntry,
+				SongID:          &[]uuid.UUID{uuid.New()}[0],
+				PlannedDuration: &[]uint{240}[0],
+			},
+		},
+		{
+			"Talk",
+			requests.AddEntryToSetlistRequest{
+				ID:    uuid.New(),
+				Type:  enums.TalkEntry,
+				Title: "Introduce the band",
+			},
+		},
+		{
+			"Tuning Break",
+			requests.AddEntryToSetlistRequest{
+				ID:              uuid.New(),
+				Type:            enums.TuningBreakEntry,
+				Title:           "Drop D",
+				PlannedDuration: &[]uint{60}[0],
+			},
+		},
+		{
+			"Encore Marker",
+			requests.AddEntryToSetlistRequest{
+				ID:    uuid.New(),
+				Type:  enums.EncoreMarkerEntry,
+				Title: "Encore",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateAddEntryToSetlistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.AddEntryToSetlistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.AddEntryToSetlistRequest{
+				ID:     uuid.Nil,
+				Type:   enums.SongEntry,
+				SongID: &[]uuid.UUID{uuid.New()}[0],
+			},
+			"ID",
+			"required",
+		},
+
+		// Type Test Cases
+		{
+			"Type is invalid because it's required",
+			requests.AddEntryToSetlistRequest{ID: uuid.New(), Type: "", Title: "Talk"},
+			"Type",
+			"required",
+		},
+		{
+			"Type is invalid because it is not part of the enum",
+			requests.AddEntryToSetlistRequest{ID: uuid.New(), Type: "something", Title: "Talk"},
+			"Type",
+			"setlist_entry_type_enum",
+		},
+
+		// Song ID Test Cases
+		{
+			"Song ID is invalid because it's required for songs",
+			requests.AddEntryToSetlistRequest{ID: uuid.New(), Type: enums.SongEntry},
+			"SongID",
+			"required_if",
+		},
+		{
+			"Song ID is invalid because it's excluded for non-songs",
+			requests.AddEntryToSetlistRequest{
+				ID:     uuid.New(),
+				Type:   enums.TalkEntry,
+				Title:  "Talk",
+				SongID: &[]uuid.UUID{uuid.New()}[0],
+			},
+			"SongID",
+			"excluded_unless",
+		},
+
+		// Title Test Cases
+		{
+			"Title is invalid because it's required for non-songs",
+			requests.AddEntryToSetlistRequest{ID: uuid.New(), Type: enums.TalkEntry},
+			"Title",
+			"required_unless",
+		},
+		{
+			"Title is invalid because it has more than 100 characters",
+			requests.AddEntryToSetlistRequest{
+				ID:    uuid.New(),
+				Type:  enums.TalkEntry,
+				Title: strings.Repeat("a", 101),
+			},
+			"Title",
+			"max",
+		},
+
+		// Planned Duration Test Cases
+		{
+			"Planned Duration is invalid because it should be greater than 0",
+			requests.AddEntryToSetlistRequest{
+				ID:              uuid.New(),
+				Type:            enums.TalkEntry,
+				Title:           "Talk",
+				PlannedDuration: &[]uint{0}[0],
+			},
+			"PlannedDuration",
+			"gt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "AddEntryToSetlistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateUpdateSetlistEntryRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.UpdateSetlistEntryRequest
+	}{
+		{
+			"Minimal",
+			requests.UpdateSetlistEntryRequest{ID: uuid.New()},
+		},
+		{
+			"Maximal",
+			requests.UpdateSetlistEntryRequest{
+				ID:              uuid.New(),
+				Title:           "Talk",
+				PlannedDuration: &[]uint{30}[0],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+		})
+	}
+}
+
+func TestValidateUpdateSetlistEntryRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.UpdateSetlistEntryRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.UpdateSetlistEntryRequest{ID: uuid.Nil},
+			"ID",
+			"required",
+		},
+
+		// Title Test Cases
+		{
+			"Title is invalid because it has more than 100 characters",
+			requests.UpdateSetlistEntryRequest{ID: uuid.New(), Title: strings.Repeat("a", 101)},
+			"Title",
+			"max",
+		},
+
+		// Planned Duration Test Cases
+		{
+			"Planned Duration is invalid because it should be greater than 0",
+			requests.UpdateSetlistEntryRequest{ID: uuid.New(), PlannedDuration: &[]uint{0}[0]},
+			"PlannedDuration",
+			"gt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "UpdateSetlistEntryRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateMoveEntryFromSetlistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          uuid.New(),
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateMoveEntryFromSetlistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.MoveEntryFromSetlistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.MoveEntryFromSetlistRequest{
+				ID:          uuid.Nil,
+				EntryID:     uuid.New(),
+				OverEntryID: uuid.New(),
+			},
+			"ID",
+			"required",
+		},
+
+		// Entry ID Test Cases
+		{
+			"Entry ID is invalid because it's required",
+			requests.MoveEntryFromSetlistRequest{
+				ID:          uuid.New(),
+				EntryID:     uuid.Nil,
+				OverEntryID: uuid.New(),
+			},
+			"EntryID",
+			"required",
+		},
+
+		// Over Entry ID Test Cases
+		{
+			"Over Entry ID is invalid because it's required",
+			requests.MoveEntryFromSetlistRequest{
+				ID:          uuid.New(),
+				EntryID:     uuid.New(),
+				OverEntryID: uuid.Nil,
+			},
+			"OverEntryID",
+			"required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "MoveEntryFromSetlistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
+
+func TestValidateRemoveEntriesFromSetlistRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateRemoveEntriesFromSetlistRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.RemoveEntriesFromSetlistRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.RemoveEntriesFromSetlistRequest{ID: uuid.Nil, EntryIDs: []uuid.UUID{uuid.New()}},
+			"ID",
+			"required",
+		},
+
+		// Entry IDs Test Cases
+		{
+			"Entry IDs is invalid because it requires at least 1 ID",
+			requests.RemoveEntriesFromSetlistRequest{ID: uuid.New(), EntryIDs: []uuid.UUID{}},
+			"EntryIDs",
+			"min",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "RemoveEntriesFromSetlistRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
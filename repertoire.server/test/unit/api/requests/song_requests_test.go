@@ -29,7 +29,11 @@ func TestValidateGetSongsRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				CurrentPage: &[]int{1}[0],
 				PageSize:    &[]int{1}[0],
 				OrderBy:     []string{"title asc nulls first", "created_at desc"},
-				SearchBy:    []string{"title ~* something entirely different", "is_recorded <> false"},
+				SearchBy: []string{
+					"title ~* something entirely different",
+					"is_recorded <> false",
+					"(bpm >= 100 AND difficulty IN easy, medium) OR title = 'Rock AND Roll'",
+				},
 			},
 		},
 	}
@@ -88,6 +92,18 @@ func TestValidateGetSongsRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest
 			"OrderBy",
 			"order_by",
 		},
+		{
+			"Order By is invalid because the field is unknown",
+			requests.GetSongsRequest{OrderBy: []string{"password desc"}},
+			"OrderBy",
+			"order_by",
+		},
+		{
+			"Order By is invalid because it is not a field",
+			requests.GetSongsRequest{OrderBy: []string{"(SELECT 1) desc"}},
+			"OrderBy",
+			"order_by",
+		},
 		// Search By Test Cases
 		{
 			"Search By is invalid because the operator is not supported",
@@ -95,6 +111,30 @@ func TestValidateGetSongsRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest
 			"SearchBy",
 			"search_by",
 		},
+		{
+			"Search By is invalid because the field is unknown",
+			requests.GetSongsRequest{SearchBy: []string{"user_id = 1"}},
+			"SearchBy",
+			"search_by",
+		},
+		{
+			"Search By is invalid because the value does not match the type of the field",
+			requests.GetSongsRequest{SearchBy: []string{"bpm > 1; DROP TABLE songs"}},
+			"SearchBy",
+			"search_by",
+		},
+		{
+			"Search By is invalid because the operator is not allowed on the field",
+			requests.GetSongsRequest{SearchBy: []string{"release_date ~* 2020"}},
+			"SearchBy",
+			"search_by",
+		},
+		{
+			"Search By is invalid because the parenthesis is not closed",
+			requests.GetSongsRequest{SearchBy: []string{"(title = a OR bpm > 100"}},
+			"SearchBy",
+			"search_by",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -114,6 +154,22 @@ func TestValidateGetSongsRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest
 	}
 }
 
+func TestValidateGetSongsRequest_WhenSearchByIsInvalid_ShouldDescribeTheError(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+	request := requests.GetSongsRequest{SearchBy: []string{"title = a", "user_id = 1"}}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Len(t, errCode.Error, 1)
+	assert.Contains(t, errCode.Error.Error(), "GetSongsRequest.SearchBy")
+	assert.Contains(t, errCode.Error.Error(), "unknown field 'user_id'")
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+}
+
 func TestValidateGetSongFiltersMetadataRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -211,6 +267,7 @@ func TestValidateCreateSongRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				Title:          validSongTitle,
 				Description:    "Something",
 				Bpm:            &[]uint{12}[0],
+				Duration:       &[]uint{240}[0],
 				SongsterrLink:  &[]string{"https://songsterr.com/some-other"}[0],
 				YoutubeLink:    &[]string{"https://youtu.be/9DyxtUCW84o?si=2pNX8eaV4KwKfOaF"}[0],
 				ReleaseDate:    &[]internal.Date{internal.Date(time.Now())}[0],
@@ -219,8 +276,20 @@ func TestValidateCreateSongRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				AlbumTitle:     &[]string{"New Album Title"}[0],
 				ArtistName:     &[]string{"New Artist Name"}[0],
 				Sections: []requests.CreateSectionRequest{
-					{Name: "A section", TypeID: uuid.New()},
-					{Name: "A Second Section", TypeID: uuid.New()},
+					{
+						Name:          "A section",
+						TypeID:        uuid.New(),
+						StartOffset:   &[]uint{0}[0],
+						BarCount:      &[]uint{16}[0],
+						TimeSignature: &[]string{"4/4"}[0],
+					},
+					{
+						Name:          "A Second Section",
+						TypeID:        uuid.New(),
+						StartOffset:   &[]uint{32}[0],
+						BarCount:      &[]uint{8}[0],
+						TimeSignature: &[]string{"12/8"}[0],
+					},
 				},
 			},
 		},
@@ -259,6 +328,13 @@ func TestValidateCreateSongRequest_WhenSingleFieldIsInvalid_ShouldReturnBadReque
 			[]string{"Title"},
 			[]string{"max"},
 		},
+		// Duration Test Cases
+		{
+			"Duration is invalid because it is zero",
+			requests.CreateSongRequest{Title: validSongTitle, Duration: &[]uint{0}[0]},
+			[]string{"Duration"},
+			[]string{"gt"},
+		},
 		// SongsterrLink Test Cases
 		{
 			"Songsterr Link is invalid because it is not an url",
@@ -397,6 +473,30 @@ func TestValidateCreateSongRequest_WhenSingleFieldIsInvalid_ShouldReturnBadReque
 			[]string{"Sections[0].TypeID"},
 			[]string{"required"},
 		},
+		// Sections - Bar Count Test Cases
+		{
+			"Sections are invalid because the first element has a zero Bar Count",
+			requests.CreateSongRequest{
+				Title: validSongTitle,
+				Sections: []requests.CreateSectionRequest{
+					{Name: "some Name", TypeID: uuid.New(), BarCount: &[]uint{0}[0]},
+				},
+			},
+			[]string{"Sections[0].BarCount"},
+			[]string{"gt"},
+		},
+		// Sections - Time Signature Test Cases
+		{
+			"Sections are invalid because the first element has an invalid Time Signature",
+			requests.CreateSongRequest{
+				Title: validSongTitle,
+				Sections: []requests.CreateSectionRequest{
+					{Name: "some Name", TypeID: uuid.New(), TimeSignature: &[]string{"4/3"}[0]},
+				},
+			},
+			[]string{"Sections[0].TimeSignature"},
+			[]string{"time_signature"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -441,6 +541,7 @@ func TestValidateUpdateSongRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
 				Description:    "Something",
 				IsRecorded:     true,
 				Bpm:            &[]uint{120}[0],
+				Duration:       &[]uint{185}[0],
 				SongsterrLink:  &[]string{"http://songsterr.com/some-song"}[0],
 				YoutubeLink:    &[]string{"https://www.youtube.com/watch?v=IHgFJEJgUrg&t=120s"}[0],
 				ReleaseDate:    &[]internal.Date{internal.Date(time.Now())}[0],
@@ -492,6 +593,13 @@ func TestValidateUpdateSongRequest_WhenSingleFieldIsInvalid_ShouldReturnBadReque
 			"Title",
 			"max",
 		},
+		// Duration Test Cases
+		{
+			"Duration is invalid because it is zero",
+			requests.UpdateSongRequest{ID: uuid.New(), Title: validSongTitle, Duration: &[]uint{0}[0]},
+			"Duration",
+			"gt",
+		},
 		// SongsterrLink Test Cases
 		{
 			"Songsterr Link is invalid because it is not an url",
@@ -668,3 +776,51 @@ func TestValidateBulkDeleteSongsRequest_WhenSingleFieldIsInvalid_ShouldReturnBad
 		})
 	}
 }
+
+func TestValidateUndoSongRehearsalRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	// when
+	errCode := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, errCode)
+}
+
+func TestValidateUndoSongRehearsalRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.UndoSongRehearsalRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// ID Test Cases
+		{
+			"ID is invalid because it's required",
+			requests.UndoSongRehearsalRequest{ID: uuid.Nil},
+			"ID",
+			"required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "UndoSongRehearsalRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
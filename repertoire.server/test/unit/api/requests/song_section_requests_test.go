@@ -29,11 +29,14 @@ func TestValidateCreateSongSectionRequest_WhenIsValid_ShouldReturnNil(t *testing
 		{
 			"Maximal",
 			requests.CreateSongSectionRequest{
-				SongID:       uuid.New(),
-				Name:         validSectionName,
-				TypeID:       uuid.New(),
-				BandMemberID: &[]uuid.UUID{uuid.New()}[0],
-				InstrumentID: &[]uuid.UUID{uuid.New()}[0],
+				SongID:        uuid.New(),
+				Name:          validSectionName,
+				TypeID:        uuid.New(),
+				BandMemberID:  &[]uuid.UUID{uuid.New()}[0],
+				InstrumentID:  &[]uuid.UUID{uuid.New()}[0],
+				StartOffset:   &[]uint{45}[0],
+				BarCount:      &[]uint{16}[0],
+				TimeSignature: &[]string{"4/4"}[0],
 			},
 		},
 	}
@@ -103,6 +106,41 @@ func TestValidateCreateSongSectionRequest_WhenSingleFieldIsInvalid_ShouldReturnB
 			"TypeID",
 			"required",
 		},
+		// Bar Count Test Cases
+		{
+			"Bar Count is invalid because it is zero",
+			requests.CreateSongSectionRequest{
+				SongID:   uuid.New(),
+				Name:     validSectionName,
+				TypeID:   uuid.New(),
+				BarCount: &[]uint{0}[0],
+			},
+			"BarCount",
+			"gt",
+		},
+		// Time Signature Test Cases
+		{
+			"Time Signature is invalid because the note value is not a power of two",
+			requests.CreateSongSectionRequest{
+				SongID:        uuid.New(),
+				Name:          validSectionName,
+				TypeID:        uuid.New(),
+				TimeSignature: &[]string{"4/5"}[0],
+			},
+			"TimeSignature",
+			"time_signature",
+		},
+		{
+			"Time Signature is invalid because it is not a fraction",
+			requests.CreateSongSectionRequest{
+				SongID:        uuid.New(),
+				Name:          validSectionName,
+				TypeID:        uuid.New(),
+				TimeSignature: &[]string{"common"}[0],
+			},
+			"TimeSignature",
+			"time_signature",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -287,13 +325,16 @@ func TestValidateUpdateSongSectionRequest_WhenIsValid_ShouldReturnNil(t *testing
 		{
 			"Maximal",
 			requests.UpdateSongSectionRequest{
-				ID:           uuid.New(),
-				Name:         validSectionName,
-				Confidence:   100,
-				Rehearsals:   23,
-				TypeID:       uuid.New(),
-				BandMemberID: &[]uuid.UUID{uuid.New()}[0],
-				InstrumentID: &[]uuid.UUID{uuid.New()}[0],
+				ID:            uuid.New(),
+				Name:          validSectionName,
+				Confidence:    100,
+				Rehearsals:    23,
+				TypeID:        uuid.New(),
+				BandMemberID:  &[]uuid.UUID{uuid.New()}[0],
+				InstrumentID:  &[]uuid.UUID{uuid.New()}[0],
+				StartOffset:   &[]uint{0}[0],
+				BarCount:      &[]uint{8}[0],
+				TimeSignature: &[]string{"7/8"}[0],
 			},
 		},
 	}
@@ -374,6 +415,30 @@ func TestValidateUpdateSongSectionRequest_WhenSingleFieldIsInvalid_ShouldReturnB
 			"TypeID",
 			"required",
 		},
+		// Bar Count Test Cases
+		{
+			"Bar Count is invalid because it is zero",
+			requests.UpdateSongSectionRequest{
+				ID:       uuid.New(),
+				Name:     validSectionName,
+				TypeID:   uuid.New(),
+				BarCount: &[]uint{0}[0],
+			},
+			"BarCount",
+			"gt",
+		},
+		// Time Signature Test Cases
+		{
+			"Time Signature is invalid because the beats are zero",
+			requests.UpdateSongSectionRequest{
+				ID:            uuid.New(),
+				Name:          validSectionName,
+				TypeID:        uuid.New(),
+				TimeSignature: &[]string{"0/4"}[0],
+			},
+			"TimeSignature",
+			"time_signature",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
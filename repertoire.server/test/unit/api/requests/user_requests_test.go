@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/api/validation"
+	"repertoire/server/internal/enums"
 	"strings"
 	"testing"
 
@@ -179,3 +180,57 @@ func TestValidateUpdateUserRequest_WhenSingleFieldIsInvalid_ShouldReturnBadReque
 		})
 	}
 }
+
+func TestValidateUpdateUserScoringStrategyRequest_WhenIsValid_ShouldReturnNil(t *testing.T) {
+	// given
+	_uut := validation.NewValidator(nil)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.ExponentialScoring,
+	}
+
+	// when
+	err := _uut.Validate(request)
+
+	// then
+	assert.Nil(t, err)
+}
+
+func TestValidateUpdateUserScoringStrategyRequest_WhenSingleFieldIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		request              requests.UpdateUserScoringStrategyRequest
+		expectedInvalidField string
+		expectedFailedTag    string
+	}{
+		// Scoring Strategy Test Cases
+		{
+			"Scoring Strategy is invalid because it's required",
+			requests.UpdateUserScoringStrategyRequest{ScoringStrategy: ""},
+			"ScoringStrategy",
+			"required",
+		},
+		{
+			"Scoring Strategy is invalid because it is not a Scoring Strategy Enum",
+			requests.UpdateUserScoringStrategyRequest{ScoringStrategy: "something else"},
+			"ScoringStrategy",
+			"scoring_strategy_enum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := validation.NewValidator(nil)
+
+			// when
+			errCode := _uut.Validate(tt.request)
+
+			// then
+			assert.NotNil(t, errCode)
+			assert.Len(t, errCode.Error, 1)
+			assert.Contains(t, errCode.Error.Error(), "UpdateUserScoringStrategyRequest."+tt.expectedInvalidField)
+			assert.Contains(t, errCode.Error.Error(), "'"+tt.expectedFailedTag+"' tag")
+			assert.Equal(t, http.StatusBadRequest, errCode.Code)
+		})
+	}
+}
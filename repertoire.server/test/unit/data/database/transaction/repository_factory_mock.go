@@ -20,11 +20,26 @@ func (m *RepositoryFactoryMock) NewAlbumRepository() repository.AlbumRepository
 	return args.Get(0).(repository.AlbumRepository)
 }
 
+func (m *RepositoryFactoryMock) NewOutboxRepository() repository.OutboxRepository {
+	args := m.Called()
+	return args.Get(0).(repository.OutboxRepository)
+}
+
 func (m *RepositoryFactoryMock) NewPlaylistRepository() repository.PlaylistRepository {
 	args := m.Called()
 	return args.Get(0).(repository.PlaylistRepository)
 }
 
+func (m *RepositoryFactoryMock) NewPracticeSessionRepository() repository.PracticeSessionRepository {
+	args := m.Called()
+	return args.Get(0).(repository.PracticeSessionRepository)
+}
+
+func (m *RepositoryFactoryMock) NewSetlistRepository() repository.SetlistRepository {
+	args := m.Called()
+	return args.Get(0).(repository.SetlistRepository)
+}
+
 func (m *RepositoryFactoryMock) NewSongRepository() repository.SongRepository {
 	args := m.Called()
 	return args.Get(0).(repository.SongRepository)
@@ -42,6 +42,16 @@ func (a *ArtistRepositoryMock) GetWithBandMembers(artist *model.Artist, id uuid.
 	return args.Error(0)
 }
 
+func (a *ArtistRepositoryMock) GetWithSearchAssociations(artist *model.Artist, id uuid.UUID) error {
+	args := a.Called(artist, id)
+
+	if len(args) > 1 {
+		*artist = *args.Get(1).(*model.Artist)
+	}
+
+	return args.Error(0)
+}
+
 func (a *ArtistRepositoryMock) GetWithSongsOrAlbums(
 	artist *model.Artist,
 	id uuid.UUID,
@@ -101,6 +111,16 @@ func (a *ArtistRepositoryMock) GetAllByIDsWithSongSections(artists *[]model.Arti
 	return args.Error(0)
 }
 
+func (a *ArtistRepositoryMock) GetAllIDsByBandMemberRole(ids *[]uuid.UUID, roleID uuid.UUID) error {
+	args := a.Called(ids, roleID)
+
+	if len(args) > 1 {
+		*ids = *args.Get(1).(*[]uuid.UUID)
+	}
+
+	return args.Error(0)
+}
+
 func (a *ArtistRepositoryMock) GetAllByUser(
 	artists *[]model.EnhancedArtist,
 	userID uuid.UUID,
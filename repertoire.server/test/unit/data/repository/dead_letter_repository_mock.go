@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"repertoire/server/model"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/google/uuid"
+)
+
+type DeadLetterRepositoryMock struct {
+	mock.Mock
+}
+
+func (d *DeadLetterRepositoryMock) Get(deadLetterMessage *model.DeadLetterMessage, id uuid.UUID) error {
+	args := d.Called(deadLetterMessage, id)
+
+	if len(args) > 1 {
+		*deadLetterMessage = *args.Get(1).(*model.DeadLetterMessage)
+	}
+
+	return args.Error(0)
+}
+
+func (d *DeadLetterRepositoryMock) GetAll(
+	deadLetterMessages *[]model.DeadLetterMessage,
+	currentPage *int,
+	pageSize *int,
+	topic *string,
+) error {
+	args := d.Called(deadLetterMessages, currentPage, pageSize, topic)
+
+	if len(args) > 1 {
+		*deadLetterMessages = *args.Get(1).(*[]model.DeadLetterMessage)
+	}
+
+	return args.Error(0)
+}
+
+func (d *DeadLetterRepositoryMock) GetAllCount(count *int64, topic *string) error {
+	args := d.Called(count, topic)
+
+	if len(args) > 1 {
+		*count = *args.Get(1).(*int64)
+	}
+
+	return args.Error(0)
+}
+
+func (d *DeadLetterRepositoryMock) GetMetricsByTopic(metrics *[]model.DeadLetterTopicMetrics) error {
+	args := d.Called(metrics)
+
+	if len(args) > 1 {
+		*metrics = *args.Get(1).(*[]model.DeadLetterTopicMetrics)
+	}
+
+	return args.Error(0)
+}
+
+func (d *DeadLetterRepositoryMock) Create(deadLetterMessage *model.DeadLetterMessage) error {
+	args := d.Called(deadLetterMessage)
+	return args.Error(0)
+}
+
+func (d *DeadLetterRepositoryMock) Delete(id uuid.UUID) error {
+	args := d.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"repertoire/server/model"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/google/uuid"
+)
+
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (o *OutboxRepositoryMock) GetOldestAndLock(messages *[]model.OutboxMessage, limit int) error {
+	args := o.Called(messages, limit)
+
+	if len(args) > 1 {
+		*messages = *args.Get(1).(*[]model.OutboxMessage)
+	}
+
+	return args.Error(0)
+}
+
+func (o *OutboxRepositoryMock) Create(message *model.OutboxMessage) error {
+	args := o.Called(message)
+	return args.Error(0)
+}
+
+func (o *OutboxRepositoryMock) Delete(ids []uuid.UUID) error {
+	args := o.Called(ids)
+	return args.Error(0)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"repertoire/server/model"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/google/uuid"
+)
+
+type PracticeSessionRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *PracticeSessionRepositoryMock) Get(session *model.PracticeSession, id uuid.UUID) error {
+	args := p.Called(session, id)
+
+	if len(args) > 1 {
+		*session = *args.Get(1).(*model.PracticeSession)
+	}
+
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) GetWithSongs(session *model.PracticeSession, id uuid.UUID) error {
+	args := p.Called(session, id)
+
+	if len(args) > 1 {
+		*session = *args.Get(1).(*model.PracticeSession)
+	}
+
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) GetInProgressByUser(session *model.PracticeSession, userID uuid.UUID) error {
+	args := p.Called(session, userID)
+
+	if len(args) > 1 {
+		*session = *args.Get(1).(*model.PracticeSession)
+	}
+
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) GetAllByUser(
+	sessions *[]model.PracticeSession,
+	userID uuid.UUID,
+	currentPage *int,
+	pageSize *int,
+	orderBy []string,
+	searchBy []string,
+) error {
+	args := p.Called(sessions, userID, currentPage, pageSize, orderBy, searchBy)
+
+	if len(args) > 1 {
+		*sessions = *args.Get(1).(*[]model.PracticeSession)
+	}
+
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error {
+	args := p.Called(count, userID, searchBy)
+
+	if len(args) > 1 {
+		*count = *args.Get(1).(*int64)
+	}
+
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) Create(session *model.PracticeSession) error {
+	args := p.Called(session)
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) CreateSong(sessionSong *model.PracticeSessionSong) error {
+	args := p.Called(sessionSong)
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) Update(session *model.PracticeSession) error {
+	args := p.Called(session)
+	return args.Error(0)
+}
+
+func (p *PracticeSessionRepositoryMock) Delete(id uuid.UUID) error {
+	args := p.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"repertoire/server/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProcessedMessageRepositoryMock struct {
+	mock.Mock
+}
+
+func (p *ProcessedMessageRepositoryMock) IsProcessed(messageID string, handler string) (bool, error) {
+	args := p.Called(messageID, handler)
+	return args.Bool(0), args.Error(1)
+}
+
+func (p *ProcessedMessageRepositoryMock) Create(processedMessage *model.ProcessedMessage) error {
+	args := p.Called(processedMessage)
+	return args.Error(0)
+}
+
+func (p *ProcessedMessageRepositoryMock) DeleteOlderThan(date time.Time) error {
+	args := p.Called(date)
+	return args.Error(0)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"repertoire/server/model"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/google/uuid"
+)
+
+type SetlistRepositoryMock struct {
+	mock.Mock
+}
+
+func (s *SetlistRepositoryMock) Get(setlist *model.Setlist, id uuid.UUID) error {
+	args := s.Called(setlist, id)
+
+	if len(args) > 1 {
+		*setlist = *args.Get(1).(*model.Setlist)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) GetWithEntries(setlist *model.Setlist, id uuid.UUID) error {
+	args := s.Called(setlist, id)
+
+	if len(args) > 1 {
+		*setlist = *args.Get(1).(*model.Setlist)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) GetAllByUser(
+	setlists *[]model.Setlist,
+	userID uuid.UUID,
+	currentPage *int,
+	pageSize *int,
+	orderBy []string,
+	searchBy []string,
+) error {
+	args := s.Called(setlists, userID, currentPage, pageSize, orderBy, searchBy)
+
+	if len(args) > 1 {
+		*setlists = *args.Get(1).(*[]model.Setlist)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) GetAllByUserCount(count *int64, userID uuid.UUID, searchBy []string) error {
+	args := s.Called(count, userID, searchBy)
+
+	if len(args) > 1 {
+		*count = *args.Get(1).(*int64)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) Create(setlist *model.Setlist) error {
+	args := s.Called(setlist)
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) Update(setlist *model.Setlist) error {
+	args := s.Called(setlist)
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) Delete(id uuid.UUID) error {
+	args := s.Called(id)
+	return args.Error(0)
+}
+
+// Entries
+
+func (s *SetlistRepositoryMock) GetEntry(entry *model.SetlistEntry, id uuid.UUID) error {
+	args := s.Called(entry, id)
+
+	if len(args) > 1 {
+		*entry = *args.Get(1).(*model.SetlistEntry)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) GetEntries(entries *[]model.SetlistEntry, id uuid.UUID) error {
+	args := s.Called(entries, id)
+
+	if len(args) > 1 {
+		*entries = *args.Get(1).(*[]model.SetlistEntry)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) CreateEntry(entry *model.SetlistEntry) error {
+	args := s.Called(entry)
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) UpdateEntry(entry *model.SetlistEntry) error {
+	args := s.Called(entry)
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) UpdateAllEntries(entries *[]model.SetlistEntry) error {
+	args := s.Called(entries)
+	return args.Error(0)
+}
+
+func (s *SetlistRepositoryMock) RemoveEntries(entries *[]model.SetlistEntry) error {
+	args := s.Called(entries)
+	return args.Error(0)
+}
@@ -66,6 +66,16 @@ func (s *SongRepositoryMock) GetFiltersMetadata(
 	return args.Error(0)
 }
 
+func (s *SongRepositoryMock) GetSmartPlaylistTotals(playlist *model.EnhancedPlaylist) error {
+	args := s.Called(playlist)
+
+	if len(args) > 1 {
+		*playlist = *args.Get(1).(*model.EnhancedPlaylist)
+	}
+
+	return args.Error(0)
+}
+
 func (s *SongRepositoryMock) GetAllByAlbum(songs *[]model.Song, albumID uuid.UUID) error {
 	args := s.Called(songs, albumID)
 
@@ -76,6 +86,26 @@ func (s *SongRepositoryMock) GetAllByAlbum(songs *[]model.Song, albumID uuid.UUI
 	return args.Error(0)
 }
 
+func (s *SongRepositoryMock) GetAllIDsByGuitarTuning(ids *[]uuid.UUID, guitarTuningID uuid.UUID) error {
+	args := s.Called(ids, guitarTuningID)
+
+	if len(args) > 1 {
+		*ids = *args.Get(1).(*[]uuid.UUID)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SongRepositoryMock) GetAllIDsBySectionType(ids *[]uuid.UUID, sectionTypeID uuid.UUID) error {
+	args := s.Called(ids, sectionTypeID)
+
+	if len(args) > 1 {
+		*ids = *args.Get(1).(*[]uuid.UUID)
+	}
+
+	return args.Error(0)
+}
+
 func (s *SongRepositoryMock) GetAllByAlbumAndTrackNo(songs *[]model.Song, albumID uuid.UUID, trackNo uint) error {
 	args := s.Called(songs, albumID, trackNo)
 
@@ -96,6 +126,16 @@ func (s *SongRepositoryMock) GetAllByIDs(songs *[]model.Song, ids []uuid.UUID) e
 	return args.Error(0)
 }
 
+func (s *SongRepositoryMock) GetAllByUserWithSections(songs *[]model.Song, userID uuid.UUID) error {
+	args := s.Called(songs, userID)
+
+	if len(args) > 1 {
+		*songs = *args.Get(1).(*[]model.Song)
+	}
+
+	return args.Error(0)
+}
+
 func (s *SongRepositoryMock) GetAllByIDsWithSections(songs *[]model.Song, ids []uuid.UUID) error {
 	args := s.Called(songs, ids)
 
@@ -116,7 +156,7 @@ func (s *SongRepositoryMock) GetAllByIDsWithSongs(songs *[]model.Song, ids []uui
 	return args.Error(0)
 }
 
-func (s *SongRepositoryMock) GetAllByIDsWithArtistAndAlbum(songs *[]model.Song, ids []uuid.UUID) error {
+func (s *SongRepositoryMock) GetAllByIDsWithSearchAssociations(songs *[]model.Song, ids []uuid.UUID) error {
 	args := s.Called(songs, ids)
 
 	if len(args) > 1 {
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,35 @@ func (s *SongSectionRepositoryMock) Get(section *model.SongSection, id uuid.UUID
 	return args.Error(0)
 }
 
+func (s *SongSectionRepositoryMock) GetAllWithHistoryByUser(
+	sections *[]model.SongSection,
+	userID uuid.UUID,
+	scope *enums.TimelineScope,
+	scopeID uuid.UUID,
+) error {
+	args := s.Called(sections, userID, scope, scopeID)
+
+	if len(args) > 1 {
+		*sections = *args.Get(1).(*[]model.SongSection)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SongSectionRepositoryMock) GetAllWithHistoryBySongs(
+	sections *[]model.SongSection,
+	userID uuid.UUID,
+	songIDs []uuid.UUID,
+) error {
+	args := s.Called(sections, userID, songIDs)
+
+	if len(args) > 1 {
+		*sections = *args.Get(1).(*[]model.SongSection)
+	}
+
+	return args.Error(0)
+}
+
 func (s *SongSectionRepositoryMock) CountAllBySong(count *int64, songID uuid.UUID) error {
 	args := s.Called(count, songID)
 
@@ -75,7 +105,29 @@ func (s *SongSectionRepositoryMock) GetHistory(
 	return args.Error(0)
 }
 
-func (s *SongSectionRepositoryMock) CreateHistory(history *model.SongSectionHistory) error {
+func (s *SongSectionRepositoryMock) CreateHistories(history *[]model.SongSectionHistory) error {
 	args := s.Called(history)
 	return args.Error(0)
 }
+
+// Rehearsal Batches
+
+func (s *SongSectionRepositoryMock) GetLastRehearsalBatch(batch *model.RehearsalBatch, songID uuid.UUID) error {
+	args := s.Called(batch, songID)
+
+	if len(args) > 1 {
+		*batch = *args.Get(1).(*model.RehearsalBatch)
+	}
+
+	return args.Error(0)
+}
+
+func (s *SongSectionRepositoryMock) CreateRehearsalBatch(batch *model.RehearsalBatch) error {
+	args := s.Called(batch)
+	return args.Error(0)
+}
+
+func (s *SongSectionRepositoryMock) DeleteRehearsalBatch(id uuid.UUID) error {
+	args := s.Called(id)
+	return args.Error(0)
+}
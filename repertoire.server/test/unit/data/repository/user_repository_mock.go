@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
@@ -31,6 +32,60 @@ func (u *UserRepositoryMock) GetByEmail(user *model.User, email string) error {
 	return args.Error(0)
 }
 
+func (u *UserRepositoryMock) GetWithAllData(user *model.User, id uuid.UUID) error {
+	args := u.Called(user, id)
+
+	if len(args) > 1 {
+		*user = *args.Get(1).(*model.User)
+	}
+
+	return args.Error(0)
+}
+
+func (u *UserRepositoryMock) GetWithSearchableData(user *model.User, id uuid.UUID) error {
+	args := u.Called(user, id)
+
+	if len(args) > 1 {
+		*user = *args.Get(1).(*model.User)
+	}
+
+	return args.Error(0)
+}
+
+func (u *UserRepositoryMock) GetAllIDs(ids *[]uuid.UUID) error {
+	args := u.Called(ids)
+
+	if len(args) > 1 {
+		*ids = *args.Get(1).(*[]uuid.UUID)
+	}
+
+	return args.Error(0)
+}
+
+func (u *UserRepositoryMock) GetAllIDsByOutdatedScoringStrategy(
+	ids *[]uuid.UUID,
+	strategy enums.ScoringStrategy,
+	version uint,
+) error {
+	args := u.Called(ids, strategy, version)
+
+	if len(args) > 1 {
+		*ids = *args.Get(1).(*[]uuid.UUID)
+	}
+
+	return args.Error(0)
+}
+
+func (u *UserRepositoryMock) GetImagePaths(paths *[]string, id uuid.UUID) error {
+	args := u.Called(paths, id)
+
+	if len(args) > 1 {
+		*paths = *args.Get(1).(*[]string)
+	}
+
+	return args.Error(0)
+}
+
 func (u *UserRepositoryMock) Create(user *model.User) error {
 	args := u.Called(user)
 	return args.Error(0)
@@ -41,6 +96,15 @@ func (u *UserRepositoryMock) Update(user *model.User) error {
 	return args.Error(0)
 }
 
+func (u *UserRepositoryMock) UpdateScoringStrategyVersion(
+	id uuid.UUID,
+	strategy enums.ScoringStrategy,
+	version uint,
+) error {
+	args := u.Called(id, strategy, version)
+	return args.Error(0)
+}
+
 func (u *UserRepositoryMock) Delete(id uuid.UUID) error {
 	args := u.Called(id)
 	return args.Error(0)
@@ -0,0 +1,126 @@
+package search
+
+import (
+	"repertoire/server/data/search"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPostgresDocument_WhenDocumentIsValid_ShouldReturnTheDocumentWithItsSearchableContent(t *testing.T) {
+	// given
+	userID := uuid.New()
+	document := map[string]any{
+		"id":             "song-" + uuid.NewString(),
+		"type":           "song",
+		"userId":         userID.String(),
+		"title":          "Nothing Else Matters",
+		"imageUrl":       "songs/image.png",
+		"guitarTuningId": uuid.NewString(),
+		"releaseYear":    1991.0,
+		"artist": map[string]any{
+			"id":   uuid.NewString(),
+			"name": "Metallica",
+		},
+		"sections": []any{"Intro", "Chorus"},
+	}
+
+	// when
+	result, err := search.NewPostgresDocument(document)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, document["id"], result.ID)
+	assert.Equal(t, userID, result.UserID)
+	assert.Equal(t, "song", result.Type)
+	assert.Equal(t, document, result.Document)
+	assert.Equal(t, "Metallica 1991 Intro Chorus Nothing Else Matters", result.Content)
+}
+
+func TestNewPostgresDocument_WhenDocumentIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name     string
+		document map[string]any
+	}{
+		{"Missing ID", map[string]any{"type": "song", "userId": uuid.NewString()}},
+		{"Missing Type", map[string]any{"id": "song-1", "userId": uuid.NewString()}},
+		{"Invalid User ID", map[string]any{"id": "song-1", "type": "song", "userId": "something"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			_, err := search.NewPostgresDocument(tt.document)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestToPostgresTextQuery_WhenQueryHasWords_ShouldCombineThem(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		prefix   bool
+		matchAll bool
+		expected string
+	}{
+		{"Any With Prefix", "Master of, Puppets!", true, false, "master:* | of:* | puppets:*"},
+		{"All Without Prefix", "master of puppets", false, true, "master & of & puppets"},
+		{"Injection", "a:* & !b", false, false, "a | b"},
+		{"Empty", "  ", true, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result := search.ToPostgresTextQuery(search.QueryWords(tt.query), tt.prefix, tt.matchAll)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestHighlight_WhenTextMatches_ShouldWrapTheMatchesAndCrop(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		words      []string
+		prefix     bool
+		cropLength int
+		expected   string
+	}{
+		{"Exact", "Master of Puppets", []string{"puppets"}, false, 10, "Master of <em>Puppets</em>"},
+		{"Prefix", "Master of Puppets", []string{"pup"}, true, 10, "Master of <em>Puppets</em>"},
+		{"Not Prefix", "Master of Puppets", []string{"pup"}, false, 10, "Master of Puppets"},
+		{
+			"Cropped",
+			"one two three four five six seven eight",
+			[]string{"six"},
+			false,
+			4,
+			"…four five <em>six</em> seven…",
+		},
+		{
+			"Cropped At Start",
+			"one two three four five six seven eight",
+			[]string{"one"},
+			false,
+			3,
+			"<em>one</em> two three…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result := search.Highlight(tt.text, tt.words, tt.prefix, tt.cropLength)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
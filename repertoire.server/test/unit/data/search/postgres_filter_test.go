@@ -0,0 +1,121 @@
+package search
+
+import (
+	"repertoire/server/data/search"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var filterableAttributes = []string{"type", "userId", "artist.id", "releaseYear", "difficulty"}
+
+func TestToPostgresCondition_WhenFilterIsValid_ShouldReturnTheConditionAndArgs(t *testing.T) {
+	tests := []struct {
+		name              string
+		filter            string
+		expectedCondition string
+		expectedArgs      []any
+	}{
+		{
+			"Equal",
+			"type = song",
+			"COALESCE(LOWER(document #>> '{type}') = LOWER(?), FALSE)",
+			[]any{"song"},
+		},
+		{
+			"Equal Number",
+			"releaseYear = 2001",
+			"COALESCE(LOWER(document #>> '{releaseYear}') = LOWER(?) OR " +
+				"CASE WHEN jsonb_typeof(document #> '{releaseYear}') = 'number' " +
+				"THEN (document #>> '{releaseYear}')::numeric = ? END, FALSE)",
+			[]any{"2001", 2001.0},
+		},
+		{
+			"Not Equal Nested",
+			"artist.id != 'some-id'",
+			"NOT COALESCE(LOWER(document #>> '{artist,id}') = LOWER(?), FALSE)",
+			[]any{"some-id"},
+		},
+		{
+			"Greater",
+			"releaseYear > 2000",
+			"COALESCE(CASE WHEN jsonb_typeof(document #> '{releaseYear}') = 'number' " +
+				"THEN (document #>> '{releaseYear}')::numeric > ? END, FALSE)",
+			[]any{2000.0},
+		},
+		{
+			"Range",
+			"releaseYear 2000 TO 2010",
+			"(COALESCE(CASE WHEN jsonb_typeof(document #> '{releaseYear}') = 'number' " +
+				"THEN (document #>> '{releaseYear}')::numeric >= ? END, FALSE) AND " +
+				"COALESCE(CASE WHEN jsonb_typeof(document #> '{releaseYear}') = 'number' " +
+				"THEN (document #>> '{releaseYear}')::numeric <= ? END, FALSE))",
+			[]any{2000.0, 2010.0},
+		},
+		{
+			"In",
+			"difficulty IN [easy, hard]",
+			"(COALESCE(LOWER(document #>> '{difficulty}') = LOWER(?), FALSE) OR " +
+				"COALESCE(LOWER(document #>> '{difficulty}') = LOWER(?), FALSE))",
+			[]any{"easy", "hard"},
+		},
+		{
+			"Not Exists",
+			"artist.id NOT EXISTS",
+			"(document #> '{artist,id}' IS NULL)",
+			nil,
+		},
+		{
+			"Is Null",
+			"difficulty IS NULL",
+			"COALESCE(document #> '{difficulty}' = 'null'::jsonb, FALSE)",
+			nil,
+		},
+		{
+			"And Or",
+			"type = song AND (difficulty = easy OR NOT difficulty EXISTS)",
+			"(COALESCE(LOWER(document #>> '{type}') = LOWER(?), FALSE) AND " +
+				"(COALESCE(LOWER(document #>> '{difficulty}') = LOWER(?), FALSE) OR " +
+				"NOT (document #> '{difficulty}' IS NOT NULL)))",
+			[]any{"song", "easy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			condition, args, err := search.ToPostgresCondition(tt.filter, filterableAttributes)
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCondition, condition)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestToPostgresCondition_WhenFilterIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{"Not Filterable Attribute", "title = something"},
+		{"Attribute Injection", "type = song OR document IS NULL"},
+		{"Missing Value", "type ="},
+		{"Unterminated String", "type = 'song"},
+		{"Unclosed Parenthesis", "(type = song"},
+		{"Unexpected Token", "type = song song"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			condition, args, err := search.ToPostgresCondition(tt.filter, filterableAttributes)
+
+			// then
+			assert.Error(t, err)
+			assert.Empty(t, condition)
+			assert.Empty(t, args)
+		})
+	}
+}
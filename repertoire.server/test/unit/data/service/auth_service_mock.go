@@ -1,6 +1,7 @@
 package service
 
 import (
+	"repertoire/server/data/http/auth"
 	"repertoire/server/internal/wrapper"
 
 	"github.com/stretchr/testify/mock"
@@ -10,7 +11,7 @@ type AuthServiceMock struct {
 	mock.Mock
 }
 
-func (a *AuthServiceMock) SignIn(email string, password string) (string, *wrapper.ErrorCode) {
+func (a *AuthServiceMock) SignIn(email string, password string) (auth.SignInResponse, *wrapper.ErrorCode) {
 	args := a.Called(email, password)
 
 	var errCode *wrapper.ErrorCode
@@ -18,5 +19,16 @@ func (a *AuthServiceMock) SignIn(email string, password string) (string, *wrappe
 		errCode = e.(*wrapper.ErrorCode)
 	}
 
-	return args.String(0), errCode
+	return args.Get(0).(auth.SignInResponse), errCode
+}
+
+func (a *AuthServiceMock) RequestEmailVerification(token string) *wrapper.ErrorCode {
+	args := a.Called(token)
+
+	var errCode *wrapper.ErrorCode
+	if e := args.Get(0); e != nil {
+		errCode = e.(*wrapper.ErrorCode)
+	}
+
+	return errCode
 }
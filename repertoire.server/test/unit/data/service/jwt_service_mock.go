@@ -11,15 +11,15 @@ type JwtServiceMock struct {
 	mock.Mock
 }
 
-func (m *JwtServiceMock) Authorize(tokenString string) *wrapper.ErrorCode {
+func (m *JwtServiceMock) Authorize(tokenString string) (uuid.UUID, *wrapper.ErrorCode) {
 	args := m.Called(tokenString)
 
 	var errCode *wrapper.ErrorCode
-	if a := args.Get(0); a != nil {
+	if a := args.Get(1); a != nil {
 		errCode = a.(*wrapper.ErrorCode)
 	}
 
-	return errCode
+	return args.Get(0).(uuid.UUID), errCode
 }
 
 func (m *JwtServiceMock) GetUserIdFromJwt(token string) (uuid.UUID, *wrapper.ErrorCode) {
@@ -170,6 +170,23 @@ func TestJwtService_Authorize_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 			otherPublicKey,
 			privateKey,
 		},
+		{
+			"when jti is missing",
+			jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+				"exp": time.Now().UTC().Add(time.Minute).Unix(),
+			}),
+			publicKey,
+			privateKey,
+		},
+		{
+			"when jti is not uuid",
+			jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+				"jti": "something else",
+				"exp": time.Now().UTC().Add(time.Minute).Unix(),
+			}),
+			publicKey,
+			privateKey,
+		},
 		{
 			"when private key does not match with the public key",
 			jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
@@ -189,9 +206,10 @@ func TestJwtService_Authorize_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 			token, _ := tt.claims.SignedString(key)
 
 			// when
-			errCode := _uut.Authorize(token)
+			tokenID, errCode := _uut.Authorize(token)
 
 			// then
+			assert.Empty(t, tokenID)
 			assert.NotNil(t, errCode)
 			assert.Equal(t, http.StatusUnauthorized, errCode.Code)
 			assert.Error(t, errCode.Error)
@@ -199,24 +217,27 @@ func TestJwtService_Authorize_WhenTokenIsInvalid_ShouldReturnUnauthorizedError(t
 	}
 }
 
-func TestJwtService_Authorize_WhenTokenIsValid_ShouldNotReturnAnyError(t *testing.T) {
+func TestJwtService_Authorize_WhenTokenIsValid_ShouldReturnTokenID(t *testing.T) {
 	// given
 	env := internal.Env{
 		JwtPublicKey: publicKey,
 	}
 	_uut := service.NewJwtService(env)
 
+	jti := uuid.New()
 	claims := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"jti": jti.String(),
 		"exp": time.Now().UTC().Add(time.Hour).Unix(),
 	})
 	key, _ := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey))
 	token, _ := claims.SignedString(key)
 
 	// when
-	errCode := _uut.Authorize(token)
+	tokenID, errCode := _uut.Authorize(token)
 
 	// then
 	assert.Nil(t, errCode)
+	assert.Equal(t, jti, tokenID)
 }
 
 func TestJwtService_GetUserIdFromJwt_WhenKeysAreNotMatching_ShouldReturnForbiddenError(t *testing.T) {
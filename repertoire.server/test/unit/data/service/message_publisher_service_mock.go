@@ -2,7 +2,9 @@ package service
 
 import (
 	"repertoire/server/data/message"
+	"repertoire/server/data/repository"
 	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -20,3 +22,22 @@ func (m *MessagePublisherServiceMock) Publish(topic topics.Topic, messagePayload
 	args := m.Called(topic, messagePayload)
 	return args.Error(0)
 }
+
+func (m *MessagePublisherServiceMock) PublishWithinTransaction(
+	outboxRepository repository.OutboxRepository,
+	topic topics.Topic,
+	messagePayload any,
+) error {
+	args := m.Called(outboxRepository, topic, messagePayload)
+	return args.Error(0)
+}
+
+func (m *MessagePublisherServiceMock) Relay(outboxMessage model.OutboxMessage) error {
+	args := m.Called(outboxMessage)
+	return args.Error(0)
+}
+
+func (m *MessagePublisherServiceMock) Replay(deadLetterMessage model.DeadLetterMessage) error {
+	args := m.Called(deadLetterMessage)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"repertoire/server/internal/enums"
 	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -20,15 +21,16 @@ func (s *SearchEngineServiceMock) Search(
 	userID uuid.UUID,
 	filter []string,
 	sort []string,
-) (wrapper.WithTotalCount[map[string]any], *wrapper.ErrorCode) {
-	args := s.Called(query, currentPage, pageSize, searchType, userID, filter, sort)
+	options model.SearchOptions,
+) (model.SearchResults[map[string]any], *wrapper.ErrorCode) {
+	args := s.Called(query, currentPage, pageSize, searchType, userID, filter, sort, options)
 
 	var errCode *wrapper.ErrorCode
 	if a := args.Get(1); a != nil {
 		errCode = a.(*wrapper.ErrorCode)
 	}
 
-	return args.Get(0).(wrapper.WithTotalCount[map[string]any]), errCode
+	return args.Get(0).(model.SearchResults[map[string]any]), errCode
 }
 
 func (s *SearchEngineServiceMock) GetDocument(id string) (map[string]any, error) {
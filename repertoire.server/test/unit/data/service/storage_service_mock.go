@@ -1,10 +1,13 @@
 package service
 
 import (
+	"io"
 	"mime/multipart"
+	"repertoire/server/data/http/storage"
 	"repertoire/server/internal"
 	"repertoire/server/internal/wrapper"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -12,9 +15,64 @@ type StorageServiceMock struct {
 	mock.Mock
 }
 
-func (s *StorageServiceMock) Upload(fileHeader *multipart.FileHeader, filePath string) error {
+func (s *StorageServiceMock) Get(filePath internal.FilePath) ([]byte, *wrapper.ErrorCode) {
+	args := s.Called(filePath)
+
+	var content []byte
+	if a := args.Get(0); a != nil {
+		content = a.([]byte)
+	}
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(1); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return content, errCode
+}
+
+func (s *StorageServiceMock) GetUsage(userID uuid.UUID) (storage.UsageResponse, *wrapper.ErrorCode) {
+	args := s.Called(userID)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(1); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return args.Get(0).(storage.UsageResponse), errCode
+}
+
+func (s *StorageServiceMock) Upload(fileHeader *multipart.FileHeader, filePath string) *wrapper.ErrorCode {
 	args := s.Called(fileHeader, filePath)
-	return args.Error(0)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
+
+func (s *StorageServiceMock) UploadFile(fileName string, content []byte, filePath string) *wrapper.ErrorCode {
+	args := s.Called(fileName, content, filePath)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
+
+func (s *StorageServiceMock) UploadStream(fileName string, reader io.Reader, filePath string) *wrapper.ErrorCode {
+	args := s.Called(fileName, reader, filePath)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
 }
 
 func (s *StorageServiceMock) DeleteFile(filePath internal.FilePath) *wrapper.ErrorCode {
@@ -38,3 +96,14 @@ func (s *StorageServiceMock) DeleteDirectories(directoryPaths []string) *wrapper
 
 	return errCode
 }
+
+func (s *StorageServiceMock) QuarantineFiles(filePaths []string) *wrapper.ErrorCode {
+	args := s.Called(filePaths)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
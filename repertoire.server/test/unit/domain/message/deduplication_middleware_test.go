@@ -0,0 +1,144 @@
+package message
+
+import (
+	"errors"
+	"repertoire/server/domain/message"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	watermillMessage "github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicationMiddleware_WhenIsProcessedFails_ShouldReturnErrorWithoutHandlingTheMessage(t *testing.T) {
+	// given
+	processedMessageRepository := new(repository.ProcessedMessageRepositoryMock)
+	_uut := message.DeduplicationMiddleware{ProcessedMessageRepository: processedMessageRepository}
+
+	msg := watermillMessage.NewMessage(uuid.NewString(), nil)
+
+	// given - mocking
+	internalError := errors.New("internal error")
+	processedMessageRepository.On("IsProcessed", msg.UUID, "").Return(false, internalError).Once()
+
+	handled := false
+	handler := _uut.Middleware(func(*watermillMessage.Message) ([]*watermillMessage.Message, error) {
+		handled = true
+		return nil, nil
+	})
+
+	// when
+	_, err := handler(msg)
+
+	// then
+	assert.Equal(t, internalError, err)
+	assert.False(t, handled)
+
+	processedMessageRepository.AssertExpectations(t)
+}
+
+func TestDeduplicationMiddleware_WhenMessageIsAlreadyProcessed_ShouldSkipIt(t *testing.T) {
+	// given
+	processedMessageRepository := new(repository.ProcessedMessageRepositoryMock)
+	_uut := message.DeduplicationMiddleware{ProcessedMessageRepository: processedMessageRepository}
+
+	msg := watermillMessage.NewMessage(uuid.NewString(), nil)
+
+	// given - mocking
+	processedMessageRepository.On("IsProcessed", msg.UUID, "").Return(true, nil).Once()
+
+	handled := false
+	handler := _uut.Middleware(func(*watermillMessage.Message) ([]*watermillMessage.Message, error) {
+		handled = true
+		return nil, nil
+	})
+
+	// when
+	_, err := handler(msg)
+
+	// then
+	assert.NoError(t, err)
+	assert.False(t, handled)
+
+	processedMessageRepository.AssertExpectations(t)
+}
+
+func TestDeduplicationMiddleware_WhenHandlerFails_ShouldReturnErrorWithoutMarkingTheMessage(t *testing.T) {
+	// given
+	processedMessageRepository := new(repository.ProcessedMessageRepositoryMock)
+	_uut := message.DeduplicationMiddleware{ProcessedMessageRepository: processedMessageRepository}
+
+	msg := watermillMessage.NewMessage(uuid.NewString(), nil)
+
+	// given - mocking
+	processedMessageRepository.On("IsProcessed", msg.UUID, "").Return(false, nil).Once()
+
+	handlerError := errors.New("handler error")
+	handler := _uut.Middleware(func(*watermillMessage.Message) ([]*watermillMessage.Message, error) {
+		return nil, handlerError
+	})
+
+	// when
+	_, err := handler(msg)
+
+	// then
+	assert.Equal(t, handlerError, err)
+
+	processedMessageRepository.AssertExpectations(t)
+	processedMessageRepository.AssertNotCalled(t, "Create")
+}
+
+func TestDeduplicationMiddleware_WhenMarkingFails_ShouldNotReturnError(t *testing.T) {
+	// given
+	processedMessageRepository := new(repository.ProcessedMessageRepositoryMock)
+	_uut := message.DeduplicationMiddleware{ProcessedMessageRepository: processedMessageRepository}
+
+	msg := watermillMessage.NewMessage(uuid.NewString(), nil)
+
+	// given - mocking
+	processedMessageRepository.On("IsProcessed", msg.UUID, "").Return(false, nil).Once()
+	processedMessageRepository.On("Create", &model.ProcessedMessage{MessageID: msg.UUID}).
+		Return(errors.New("internal error")).
+		Once()
+
+	handler := _uut.Middleware(func(*watermillMessage.Message) ([]*watermillMessage.Message, error) {
+		return nil, nil
+	})
+
+	// when
+	_, err := handler(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	processedMessageRepository.AssertExpectations(t)
+}
+
+func TestDeduplicationMiddleware_WhenSuccessful_ShouldHandleTheMessageAndMarkItAsProcessed(t *testing.T) {
+	// given
+	processedMessageRepository := new(repository.ProcessedMessageRepositoryMock)
+	_uut := message.DeduplicationMiddleware{ProcessedMessageRepository: processedMessageRepository}
+
+	msg := watermillMessage.NewMessage(uuid.NewString(), nil)
+
+	// given - mocking
+	processedMessageRepository.On("IsProcessed", msg.UUID, "").Return(false, nil).Once()
+	processedMessageRepository.On("Create", &model.ProcessedMessage{MessageID: msg.UUID}).Return(nil).Once()
+
+	handled := false
+	handler := _uut.Middleware(func(*watermillMessage.Message) ([]*watermillMessage.Message, error) {
+		handled = true
+		return nil, nil
+	})
+
+	// when
+	_, err := handler(msg)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, handled)
+
+	processedMessageRepository.AssertExpectations(t)
+}
@@ -307,17 +307,25 @@ func TestAlbumsDeletedHandler_WhenWithSongs_ShouldPublishMessageToDeleteFromSear
 		},
 	}
 
+	var songIDs []string
 	var songSearchIDs []string
 	for _, alb := range mockAlbums {
 		for _, song := range alb.Songs {
+			songIDs = append(songIDs, song.ID.String())
 			songSearchIDs = append(songSearchIDs, song.ToSearch().ID)
 		}
 	}
 
+	sectionSearchID := "songSection-" + uuid.New().String()
+	sectionsFilter := fmt.Sprintf("type = %s AND song.id IN [%s]", enums.SongSection, strings.Join(songIDs, ","))
+	searchEngineService.On("GetDocuments", sectionsFilter).
+		Return([]map[string]any{{"id": sectionSearchID}}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Run(func(args mock.Arguments) {
 			ids := args.Get(1).([]string)
-			assert.Len(t, ids, len(mockAlbums)+len(songSearchIDs))
+			assert.Len(t, ids, len(mockAlbums)+len(songSearchIDs)+1)
 
 			for i, alb := range mockAlbums {
 				assert.Equal(t, alb.ToSearch().ID, ids[i])
@@ -325,6 +333,7 @@ func TestAlbumsDeletedHandler_WhenWithSongs_ShouldPublishMessageToDeleteFromSear
 			for i, id := range songSearchIDs {
 				assert.Equal(t, ids[i+len(mockAlbums)], id)
 			}
+			assert.Equal(t, sectionSearchID, ids[len(mockAlbums)+len(songSearchIDs)])
 		}).
 		Return(nil).
 		Once()
@@ -19,12 +19,12 @@ import (
 func TestArtistUpdatedHandler_WhenGetArtistFails_ShouldReturnError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewArtistUpdatedHandler(artistRepository, nil)
+	_uut := artist.NewArtistUpdatedHandler(artistRepository, nil, nil)
 
 	artistID := uuid.New()
 
 	internalError := errors.New("internal error")
-	artistRepository.On("GetWithSongsOrAlbums", new(model.Artist), artistID, true, true).
+	artistRepository.On("GetWithSearchAssociations", new(model.Artist), artistID).
 		Return(internalError).
 		Once()
 
@@ -44,10 +44,10 @@ func TestArtistUpdatedHandler_WhenPublishFails_ShouldReturnError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewArtistUpdatedHandler(artistRepository, messagePublisherService)
+	_uut := artist.NewArtistUpdatedHandler(artistRepository, messagePublisherService, nil)
 
 	mockArtist := model.Artist{ID: uuid.New()}
-	artistRepository.On("GetWithSongsOrAlbums", new(model.Artist), mockArtist.ID, true, true).
+	artistRepository.On("GetWithSearchAssociations", new(model.Artist), mockArtist.ID).
 		Return(nil, &mockArtist).
 		Once()
 
@@ -100,6 +100,16 @@ func TestArtistUpdatedHandler_WhenSuccessful_ShouldNotReturnAnyError(t *testing.
 				},
 			},
 		},
+		{
+			"with Band Members",
+			model.Artist{
+				ID: uuid.New(),
+				BandMembers: []model.BandMember{
+					{ID: uuid.New()},
+					{ID: uuid.New()},
+				},
+			},
+		},
 		{
 			"with Albums and Songs",
 			model.Artist{
@@ -123,16 +133,17 @@ func TestArtistUpdatedHandler_WhenSuccessful_ShouldNotReturnAnyError(t *testing.
 			// given
 			artistRepository := new(repository.ArtistRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := artist.NewArtistUpdatedHandler(artistRepository, messagePublisherService)
+			searchEngineService := new(service.SearchEngineServiceMock)
+			_uut := artist.NewArtistUpdatedHandler(artistRepository, messagePublisherService, searchEngineService)
 
-			artistRepository.On("GetWithSongsOrAlbums", new(model.Artist), tt.artist.ID, true, true).
+			artistRepository.On("GetWithSearchAssociations", new(model.Artist), tt.artist.ID).
 				Return(nil, &tt.artist).
 				Once()
 
 			messagePublisherService.On("Publish", topics.UpdateFromSearchEngineTopic, mock.IsType([]any{})).
 				Run(func(args mock.Arguments) {
 					searches := args.Get(1).([]any)
-					assert.Len(t, searches, len(tt.artist.Songs)+len(tt.artist.Albums)+1)
+					assert.Len(t, searches, len(tt.artist.Songs)+len(tt.artist.Albums)+len(tt.artist.BandMembers)+1)
 
 					assert.Contains(t, searches[0].(model.ArtistSearch).ID, tt.artist.ID.String())
 					for i, song := range tt.artist.Songs {
@@ -143,10 +154,20 @@ func TestArtistUpdatedHandler_WhenSuccessful_ShouldNotReturnAnyError(t *testing.
 						assert.Contains(t, searches[1+len(tt.artist.Songs)+i].(model.AlbumSearch).ID, album.ID.String())
 						assert.Equal(t, searches[1+len(tt.artist.Songs)+i].(model.AlbumSearch).Artist.ID, tt.artist.ID)
 					}
+					for i, member := range tt.artist.BandMembers {
+						index := 1 + len(tt.artist.Songs) + len(tt.artist.Albums) + i
+						assert.Contains(t, searches[index].(model.BandMemberSearch).ID, member.ID.String())
+						assert.Equal(t, searches[index].(model.BandMemberSearch).Artist.ID, tt.artist.ID)
+					}
 				}).
 				Return(nil).
 				Once()
 
+			searchEngineService.
+				On("GetDocuments", "type = bandMember AND artist.id = "+tt.artist.ID.String()).
+				Return([]map[string]any{}, nil).
+				Once()
+
 			// when
 			payload, _ := json.Marshal(tt.artist.ID)
 			msg := message.NewMessage("1", payload)
@@ -157,6 +178,52 @@ func TestArtistUpdatedHandler_WhenSuccessful_ShouldNotReturnAnyError(t *testing.
 
 			artistRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
+			searchEngineService.AssertExpectations(t)
 		})
 	}
 }
+
+func TestArtistUpdatedHandler_WhenBandMembersWereRemoved_ShouldDeleteThemFromSearchEngine(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := artist.NewArtistUpdatedHandler(artistRepository, messagePublisherService, searchEngineService)
+
+	mockArtist := model.Artist{
+		ID:          uuid.New(),
+		BandMembers: []model.BandMember{{ID: uuid.New()}},
+	}
+	artistRepository.On("GetWithSearchAssociations", new(model.Artist), mockArtist.ID).
+		Return(nil, &mockArtist).
+		Once()
+
+	messagePublisherService.On("Publish", topics.UpdateFromSearchEngineTopic, mock.IsType([]any{})).
+		Return(nil).
+		Once()
+
+	removedBandMemberSearchID := "bandMember-" + uuid.New().String()
+	searchEngineService.
+		On("GetDocuments", "type = bandMember AND artist.id = "+mockArtist.ID.String()).
+		Return([]map[string]any{
+			{"id": mockArtist.BandMembers[0].ToSearch().ID},
+			{"id": removedBandMemberSearchID},
+		}, nil).
+		Once()
+
+	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, []string{removedBandMemberSearchID}).
+		Return(nil).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockArtist.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+}
@@ -22,11 +22,16 @@ import (
 func TestArtistsDeletedHandler_WhenPublishDeleteFromSearchEngineFails_ShouldReturnError(t *testing.T) {
 	// given
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewArtistsDeletedHandler(messagePublisherService, nil, nil)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := artist.NewArtistsDeletedHandler(messagePublisherService, searchEngineService, nil)
 
 	mockArtists := []model.Artist{{ID: uuid.New()}}
 
 	internalError := errors.New("internal error")
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(internalError).
 		Once()
@@ -41,6 +46,7 @@ func TestArtistsDeletedHandler_WhenPublishDeleteFromSearchEngineFails_ShouldRetu
 	assert.Equal(t, err, internalError)
 
 	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
 }
 
 func TestArtistsDeletedHandler_WhenGetDocumentsFails_ShouldReturnError(t *testing.T) {
@@ -51,6 +57,10 @@ func TestArtistsDeletedHandler_WhenGetDocumentsFails_ShouldReturnError(t *testin
 
 	mockArtists := []model.Artist{{ID: uuid.New()}}
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(nil).
 		Once()
@@ -81,6 +91,10 @@ func TestArtistsDeletedHandler_WhenPublishUpdateFromSearchEngineFails_ShouldRetu
 
 	mockArtists := []model.Artist{{ID: uuid.New()}}
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(nil).
 		Once()
@@ -119,6 +133,10 @@ func TestArtistsDeletedHandler_WhenPublishDeleteStorageFails_ShouldReturnError(t
 
 	mockArtists := []model.Artist{{ID: uuid.New()}}
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(nil).
 		Once()
@@ -160,6 +178,10 @@ func TestArtistsDeletedHandler_WhenDocumentsLengthIs0_ShouldNotReturnAnyError(t
 
 	mockArtists := []model.Artist{{ID: uuid.New()}}
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(nil).
 		Once()
@@ -197,13 +219,25 @@ func TestArtistsDeletedHandler_WhenWithoutSongsOrAlbums_ShouldPublishMessagesToD
 
 	mockArtists := []model.Artist{{ID: uuid.New()}, {ID: uuid.New()}}
 
+	var artistIDs []string
+	for _, art := range mockArtists {
+		artistIDs = append(artistIDs, art.ID.String())
+	}
+
+	bandMemberSearchID := "bandMember-" + uuid.New().String()
+	bandMembersFilter := fmt.Sprintf("(type = %s AND artist.id IN [%s])", enums.BandMember, strings.Join(artistIDs, ","))
+	searchEngineService.On("GetDocuments", bandMembersFilter).
+		Return([]map[string]any{{"id": bandMemberSearchID}}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Run(func(args mock.Arguments) {
 			ids := args.Get(1).([]string)
-			assert.Len(t, ids, len(mockArtists))
+			assert.Len(t, ids, len(mockArtists)+1)
 			for i, art := range mockArtists {
 				assert.Equal(t, art.ToSearch().ID, ids[i])
 			}
+			assert.Equal(t, bandMemberSearchID, ids[len(mockArtists)])
 		}).
 		Return(nil).
 		Once()
@@ -226,10 +260,6 @@ func TestArtistsDeletedHandler_WhenWithoutSongsOrAlbums_ShouldPublishMessagesToD
 		},
 	}
 
-	var artistIDs []string
-	for _, art := range mockArtists {
-		artistIDs = append(artistIDs, art.ID.String())
-	}
 	filter := fmt.Sprintf(
 		"(type = %s OR type = %s) AND artist.id IN [%s]",
 		enums.Song,
@@ -316,21 +346,37 @@ func TestArtistsDeletedHandler_WhenWithSongsAndOrAlbums_ShouldPublishMessageToDe
 		},
 	}
 
+	var artistIDs []string
+	var songIDs []string
 	var albumSearchIDs []string
 	var songSearchIDs []string
 	for _, art := range mockArtists {
+		artistIDs = append(artistIDs, art.ID.String())
 		for _, album := range art.Albums {
 			albumSearchIDs = append(albumSearchIDs, album.ToSearch().ID)
 		}
 		for _, song := range art.Songs {
+			songIDs = append(songIDs, song.ID.String())
 			songSearchIDs = append(songSearchIDs, song.ToSearch().ID)
 		}
 	}
 
+	dependentSearchIDs := []string{"bandMember-" + uuid.New().String(), "songSection-" + uuid.New().String()}
+	dependentFilter := fmt.Sprintf(
+		"(type = %s AND artist.id IN [%s]) OR (type = %s AND song.id IN [%s])",
+		enums.BandMember,
+		strings.Join(artistIDs, ","),
+		enums.SongSection,
+		strings.Join(songIDs, ","),
+	)
+	searchEngineService.On("GetDocuments", dependentFilter).
+		Return([]map[string]any{{"id": dependentSearchIDs[0]}, {"id": dependentSearchIDs[1]}}, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Run(func(args mock.Arguments) {
 			ids := args.Get(1).([]string)
-			assert.Len(t, ids, len(mockArtists)+len(albumSearchIDs)+len(songSearchIDs))
+			assert.Len(t, ids, len(mockArtists)+len(albumSearchIDs)+len(songSearchIDs)+len(dependentSearchIDs))
 
 			// assert IDs one by one
 			for i, art := range mockArtists {
@@ -342,6 +388,9 @@ func TestArtistsDeletedHandler_WhenWithSongsAndOrAlbums_ShouldPublishMessageToDe
 			for i, songID := range songSearchIDs {
 				assert.Equal(t, songID, ids[len(mockArtists)+len(albumSearchIDs)+i])
 			}
+			for i, dependentID := range dependentSearchIDs {
+				assert.Equal(t, dependentID, ids[len(mockArtists)+len(albumSearchIDs)+len(songSearchIDs)+i])
+			}
 		}).
 		Return(nil).
 		Once()
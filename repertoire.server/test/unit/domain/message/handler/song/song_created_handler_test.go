@@ -16,15 +16,15 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func TestSongCreatedHandler_WhenGetArtistFails_ShouldReturnError(t *testing.T) {
+func TestSongCreatedHandler_WhenGetSongsFails_ShouldReturnError(t *testing.T) {
 	// given
-	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := song.NewSongCreatedHandler(artistRepository, nil, nil)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewSongCreatedHandler(songRepository, nil)
 
-	mockSong := model.Song{ID: uuid.New(), ArtistID: &[]uuid.UUID{uuid.New()}[0]}
+	mockSong := model.Song{ID: uuid.New()}
 
 	internalError := errors.New("internal error")
-	artistRepository.On("Get", new(model.Artist), *mockSong.ArtistID).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), []uuid.UUID{mockSong.ID}).
 		Return(internalError).
 		Once()
 
@@ -37,19 +37,18 @@ func TestSongCreatedHandler_WhenGetArtistFails_ShouldReturnError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err, internalError)
 
-	artistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
 }
 
-func TestSongCreatedHandler_WhenGetAlbumFails_ShouldReturnError(t *testing.T) {
+func TestSongCreatedHandler_WhenSongIsNotFound_ShouldReturnNoError(t *testing.T) {
 	// given
-	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := song.NewSongCreatedHandler(nil, albumRepository, nil)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewSongCreatedHandler(songRepository, nil)
 
-	mockSong := model.Song{ID: uuid.New(), AlbumID: &[]uuid.UUID{uuid.New()}[0]}
+	mockSong := model.Song{ID: uuid.New()}
 
-	internalError := errors.New("internal error")
-	albumRepository.On("Get", new(model.Album), *mockSong.AlbumID).
-		Return(internalError).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), []uuid.UUID{mockSong.ID}).
+		Return(nil).
 		Once()
 
 	// when
@@ -58,19 +57,23 @@ func TestSongCreatedHandler_WhenGetAlbumFails_ShouldReturnError(t *testing.T) {
 	err := _uut.Handle(msg)
 
 	// then
-	assert.Error(t, err)
-	assert.Equal(t, err, internalError)
+	assert.NoError(t, err)
 
-	albumRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
 }
 
 func TestSongCreatedHandler_WhenPublishFails_ShouldReturnError(t *testing.T) {
 	// given
+	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongCreatedHandler(nil, nil, messagePublisherService)
+	_uut := song.NewSongCreatedHandler(songRepository, messagePublisherService)
 
 	mockSong := model.Song{ID: uuid.New()}
 
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), []uuid.UUID{mockSong.ID}).
+		Return(nil, &[]model.Song{mockSong}).
+		Once()
+
 	internalError := errors.New("internal error")
 	messagePublisherService.On("Publish", topics.AddToSearchEngineTopic, mock.IsType([]any{})).
 		Return(internalError).
@@ -85,6 +88,7 @@ func TestSongCreatedHandler_WhenPublishFails_ShouldReturnError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err, internalError)
 
+	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
 
@@ -98,7 +102,7 @@ func TestSongCreatedHandler_WhenSuccessful_ShouldPublishMessageToAddToSearchEngi
 			model.Song{ID: uuid.New()},
 		},
 		{
-			"with Artist and album",
+			"with new Artist and Album",
 			model.Song{
 				ID:     uuid.New(),
 				Artist: &model.Artist{ID: uuid.New()},
@@ -106,18 +110,21 @@ func TestSongCreatedHandler_WhenSuccessful_ShouldPublishMessageToAddToSearchEngi
 			},
 		},
 		{
-			"with Artist ID",
+			"with existing Artist and new Album",
 			model.Song{
 				ID:       uuid.New(),
 				ArtistID: &[]uuid.UUID{uuid.New()}[0],
+				Album:    &model.Album{ID: uuid.New()},
 			},
 		},
 		{
-			"with Album and Artist ID",
+			"with Sections",
 			model.Song{
-				ID:       uuid.New(),
-				ArtistID: &[]uuid.UUID{uuid.New()}[0],
-				Album:    &model.Album{ID: uuid.New()},
+				ID: uuid.New(),
+				Sections: []model.SongSection{
+					{ID: uuid.New(), Name: "Solo", Order: 1},
+					{ID: uuid.New(), Name: "Intro", Order: 0},
+				},
 			},
 		},
 	}
@@ -125,48 +132,61 @@ func TestSongCreatedHandler_WhenSuccessful_ShouldPublishMessageToAddToSearchEngi
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
-			artistRepository := new(repository.ArtistRepositoryMock)
-			albumRepository := new(repository.AlbumRepositoryMock)
+			songRepository := new(repository.SongRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := song.NewSongCreatedHandler(artistRepository, albumRepository, messagePublisherService)
+			_uut := song.NewSongCreatedHandler(songRepository, messagePublisherService)
 
-			var artist model.Artist
+			loadedSong := tt.song
 			if tt.song.ArtistID != nil {
-				artist = model.Artist{ID: *tt.song.ArtistID}
-				artistRepository.On("Get", new(model.Artist), *tt.song.ArtistID).
-					Return(nil, &artist).
-					Once()
+				loadedSong.Artist = &model.Artist{ID: *tt.song.ArtistID}
 			}
+			guitarTuning := model.GuitarTuning{ID: uuid.New(), Name: "Drop D"}
+			loadedSong.GuitarTuning = &guitarTuning
+			for i := range loadedSong.Sections {
+				loadedSong.Sections[i].SongSectionType = model.SongSectionType{Name: "Type " + loadedSong.Sections[i].Name}
+			}
+			songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), []uuid.UUID{tt.song.ID}).
+				Return(nil, &[]model.Song{loadedSong}).
+				Once()
 
 			messagePublisherService.On("Publish", topics.AddToSearchEngineTopic, mock.IsType([]any{})).
 				Run(func(args mock.Arguments) {
 					searches := args.Get(1).([]any)
-					var artistIndex *int
-					var albumIndex *int
-					if tt.song.Artist != nil && tt.song.Album != nil {
-						assert.Len(t, searches, 3)
-						artistIndex = &[]int{1}[0]
-						albumIndex = &[]int{2}[0]
-					} else if tt.song.Artist != nil {
-						assert.Len(t, searches, 2)
-						artistIndex = &[]int{1}[0]
-					} else if tt.song.Album != nil {
-						assert.Len(t, searches, 2)
-						albumIndex = &[]int{1}[0]
+
+					expectedLen := 1 + len(tt.song.Sections)
+					if tt.song.Artist != nil {
+						expectedLen++
+					}
+					if tt.song.Album != nil {
+						expectedLen++
 					}
+					assert.Len(t, searches, expectedLen)
 
-					assert.Contains(t, searches[0].(model.SongSearch).ID, tt.song.ID.String())
+					songSearch := searches[0].(model.SongSearch)
+					assert.Contains(t, songSearch.ID, tt.song.ID.String())
+					assert.Equal(t, guitarTuning.Name, *songSearch.GuitarTuning)
 					if tt.song.ArtistID != nil {
-						assert.Equal(t, searches[0].(model.SongSearch).Artist.ID, *tt.song.ArtistID)
+						assert.Equal(t, *tt.song.ArtistID, songSearch.Artist.ID)
 					}
-					if tt.song.AlbumID != nil {
-						assert.Equal(t, searches[0].(model.SongSearch).Album.ID, *tt.song.AlbumID)
+
+					// sections are ordered
+					assert.Len(t, songSearch.Sections, len(tt.song.Sections))
+					for i, section := range tt.song.Sections {
+						sectionSearch := searches[1+int(section.Order)].(model.SongSectionSearch)
+						assert.Equal(t, section.Name, songSearch.Sections[section.Order])
+						assert.Contains(t, sectionSearch.ID, section.ID.String())
+						assert.Equal(t, section.Name, sectionSearch.Name)
+						assert.Equal(t, loadedSong.Sections[i].SongSectionType.Name, sectionSearch.SongSectionType)
+						assert.Equal(t, tt.song.ID, sectionSearch.Song.ID)
 					}
-					if artistIndex != nil {
-						assert.Contains(t, searches[*artistIndex].(model.ArtistSearch).ID, tt.song.Artist.ID.String())
+
+					index := 1 + len(tt.song.Sections)
+					if tt.song.Artist != nil {
+						assert.Contains(t, searches[index].(model.ArtistSearch).ID, tt.song.Artist.ID.String())
+						index++
 					}
-					if albumIndex != nil {
-						assert.Contains(t, searches[*albumIndex].(model.AlbumSearch).ID, tt.song.Album.ID.String())
+					if tt.song.Album != nil {
+						assert.Contains(t, searches[index].(model.AlbumSearch).ID, tt.song.Album.ID.String())
 					}
 				}).
 				Return(nil).
@@ -180,8 +200,7 @@ func TestSongCreatedHandler_WhenSuccessful_ShouldPublishMessageToAddToSearchEngi
 			// then
 			assert.NoError(t, err)
 
-			artistRepository.AssertExpectations(t)
-			albumRepository.AssertExpectations(t)
+			songRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
 		})
 	}
@@ -16,13 +16,42 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func TestSongsDeletedHandler_WhenGetSectionDocumentsFails_ShouldReturnError(t *testing.T) {
+	// given
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsDeletedHandler(nil, nil, searchEngineService)
+
+	mockSongs := []model.Song{{ID: uuid.New()}}
+
+	internalError := errors.New("internal error")
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockSongs)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, err, internalError)
+
+	searchEngineService.AssertExpectations(t)
+}
+
 func TestSongsDeletedHandler_WhenPublishDeleteFromSearchEngineFails_ShouldReturnError(t *testing.T) {
 	// given
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongsDeletedHandler(nil, messagePublisherService)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsDeletedHandler(nil, messagePublisherService, searchEngineService)
 
 	mockSongs := []model.Song{{ID: uuid.New()}}
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
 	internalError := errors.New("internal error")
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(internalError).
@@ -38,15 +67,20 @@ func TestSongsDeletedHandler_WhenPublishDeleteFromSearchEngineFails_ShouldReturn
 	assert.Equal(t, err, internalError)
 
 	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
 }
 
 func TestSongsDeletedHandler_WhenPublishDeleteStorageFails_ShouldReturnError(t *testing.T) {
 	// given
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongsDeletedHandler(storageFilePathProvider, messagePublisherService)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsDeletedHandler(storageFilePathProvider, messagePublisherService, searchEngineService)
 
 	mockSongs := []model.Song{{ID: uuid.New()}}
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Return(nil).
 		Once()
@@ -72,25 +106,40 @@ func TestSongsDeletedHandler_WhenPublishDeleteStorageFails_ShouldReturnError(t *
 
 	storageFilePathProvider.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
 }
 
 func TestSongsDeletedHandler_WhenSuccessful_ShouldPublishMessageToDeleteFromSearchEngine(t *testing.T) {
 	// given
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongsDeletedHandler(storageFilePathProvider, messagePublisherService)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsDeletedHandler(storageFilePathProvider, messagePublisherService, searchEngineService)
 
 	mockSongs := []model.Song{
 		{ID: uuid.New()},
 		{ID: uuid.New()},
 	}
+
+	sectionSearches := []map[string]any{
+		{"id": "songSection-" + uuid.New().String()},
+	}
+	searchEngineService.
+		On(
+			"GetDocuments",
+			"type = songSection AND song.id IN ["+mockSongs[0].ID.String()+","+mockSongs[1].ID.String()+"]",
+		).
+		Return(sectionSearches, nil).
+		Once()
+
 	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, mock.IsType([]string{})).
 		Run(func(args mock.Arguments) {
 			ids := args.Get(1).([]string)
-			assert.Len(t, ids, len(mockSongs))
-			for i := range ids {
+			assert.Len(t, ids, len(mockSongs)+len(sectionSearches))
+			for i := range mockSongs {
 				assert.Equal(t, mockSongs[i].ToSearch().ID, ids[i])
 			}
+			assert.Equal(t, sectionSearches[0]["id"], ids[len(mockSongs)])
 		}).
 		Return(nil).
 		Once()
@@ -116,4 +165,5 @@ func TestSongsDeletedHandler_WhenSuccessful_ShouldPublishMessageToDeleteFromSear
 
 	storageFilePathProvider.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
 }
@@ -19,12 +19,12 @@ import (
 func TestSongUpdatedHandler_WhenGetSongsFails_ShouldReturnError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewSongsUpdatedHandler(songRepository, nil)
+	_uut := song.NewSongsUpdatedHandler(songRepository, nil, nil)
 
 	songIDs := []uuid.UUID{uuid.New()}
 
 	internalError := errors.New("internal error")
-	songRepository.On("GetAllByIDsWithArtistAndAlbum", new([]model.Song), songIDs).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
 		Return(internalError).
 		Once()
 
@@ -44,12 +44,12 @@ func TestSongUpdatedHandler_WhenPublishFails_ShouldReturnError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService)
+	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService, nil)
 
 	songIDs := []uuid.UUID{uuid.New()}
 
 	songs := []model.Song{{ID: uuid.New()}}
-	songRepository.On("GetAllByIDsWithArtistAndAlbum", new([]model.Song), songIDs).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
 		Return(nil, &songs).
 		Once()
 
@@ -74,11 +74,11 @@ func TestSongUpdatedHandler_WhenPublishFails_ShouldReturnError(t *testing.T) {
 func TestSongUpdatedHandler_WhenThereAreNoSongs_ShouldReturnNoError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewSongsUpdatedHandler(songRepository, nil)
+	_uut := song.NewSongsUpdatedHandler(songRepository, nil, nil)
 
 	songIDs := []uuid.UUID{uuid.New()}
 
-	songRepository.On("GetAllByIDsWithArtistAndAlbum", new([]model.Song), songIDs).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
 		Return(nil).
 		Once()
 
@@ -93,16 +93,54 @@ func TestSongUpdatedHandler_WhenThereAreNoSongs_ShouldReturnNoError(t *testing.T
 	songRepository.AssertExpectations(t)
 }
 
+func TestSongUpdatedHandler_WhenGetDocumentsFails_ShouldReturnError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService, searchEngineService)
+
+	songIDs := []uuid.UUID{uuid.New()}
+
+	songs := []model.Song{{ID: uuid.New()}}
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
+		Return(nil, &songs).
+		Once()
+
+	messagePublisherService.On("Publish", topics.UpdateFromSearchEngineTopic, mock.IsType([]any{})).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(songIDs)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, err, internalError)
+
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+}
+
 func TestSongUpdatedHandler_WhenSuccessful_ShouldPublishMessageToUpdateFromSearchEngine(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService, searchEngineService)
 
 	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
 
 	songs := []model.Song{{ID: uuid.New()}}
-	songRepository.On("GetAllByIDsWithArtistAndAlbum", new([]model.Song), songIDs).
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
 		Return(nil, &songs).
 		Once()
 
@@ -117,6 +155,68 @@ func TestSongUpdatedHandler_WhenSuccessful_ShouldPublishMessageToUpdateFromSearc
 		Return(nil).
 		Once()
 
+	searchEngineService.On("GetDocuments", mock.IsType("")).
+		Return([]map[string]any{}, nil).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(songIDs)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+}
+
+func TestSongUpdatedHandler_WhenSongsHaveSections_ShouldUpdateThemAndDeleteTheRemovedOnes(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := song.NewSongsUpdatedHandler(songRepository, messagePublisherService, searchEngineService)
+
+	songIDs := []uuid.UUID{uuid.New()}
+
+	section := model.SongSection{
+		ID:              uuid.New(),
+		Name:            "Solo",
+		SongSectionType: model.SongSectionType{Name: "Solo"},
+	}
+	songs := []model.Song{{ID: songIDs[0], Sections: []model.SongSection{section}}}
+	songRepository.On("GetAllByIDsWithSearchAssociations", new([]model.Song), songIDs).
+		Return(nil, &songs).
+		Once()
+
+	messagePublisherService.On("Publish", topics.UpdateFromSearchEngineTopic, mock.IsType([]any{})).
+		Run(func(args mock.Arguments) {
+			searches := args.Get(1).([]any)
+			assert.Len(t, searches, 2)
+			assert.Equal(t, []string{section.Name}, searches[0].(model.SongSearch).Sections)
+			sectionSearch := searches[1].(model.SongSectionSearch)
+			assert.Equal(t, "songSection-"+section.ID.String(), sectionSearch.ID)
+			assert.Equal(t, section.SongSectionType.Name, sectionSearch.SongSectionType)
+			assert.Equal(t, songs[0].ID, sectionSearch.Song.ID)
+		}).
+		Return(nil).
+		Once()
+
+	removedSectionSearchID := "songSection-" + uuid.New().String()
+	searchEngineService.
+		On("GetDocuments", "type = songSection AND song.id IN ["+songIDs[0].String()+"]").
+		Return([]map[string]any{
+			{"id": "songSection-" + section.ID.String()},
+			{"id": removedSectionSearchID},
+		}, nil).
+		Once()
+
+	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, []string{removedSectionSearchID}).
+		Return(nil).
+		Once()
+
 	// when
 	payload, _ := json.Marshal(songIDs)
 	msg := message.NewMessage("1", payload)
@@ -127,4 +227,5 @@ func TestSongUpdatedHandler_WhenSuccessful_ShouldPublishMessageToUpdateFromSearc
 
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
 }
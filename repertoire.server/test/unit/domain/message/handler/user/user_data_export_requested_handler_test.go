@@ -0,0 +1,193 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"repertoire/server/domain/message/handler/user"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"repertoire/server/test/unit/domain/provider"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserDataExportRequestedHandler_WhenGetUserFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUserDataExportRequestedHandler(userRepository, nil, nil, nil, nil)
+
+	userID := uuid.New()
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetWithAllData", new(model.User), userID).Return(internalError).Once()
+
+	// when
+	payload, _ := json.Marshal(userID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestUserDataExportRequestedHandler_WhenUserIsNotFound_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUserDataExportRequestedHandler(userRepository, nil, nil, nil, nil)
+
+	userID := uuid.New()
+
+	userRepository.On("GetWithAllData", new(model.User), userID).Return(nil).Once()
+
+	// when
+	payload, _ := json.Marshal(userID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestUserDataExportRequestedHandler_WhenExportFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	userDataExportProcessor := new(processor.UserDataExportProcessorMock)
+	_uut := user.NewUserDataExportRequestedHandler(userRepository, userDataExportProcessor, nil, nil, nil)
+
+	mockUser := &model.User{ID: uuid.New()}
+	userRepository.On("GetWithAllData", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+
+	internalError := errors.New("internal error")
+	userDataExportProcessor.On("Export", mock.Anything, *mockUser).
+		Return(wrapper.InternalServerError(internalError)).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	userDataExportProcessor.AssertExpectations(t)
+}
+
+func TestUserDataExportRequestedHandler_WhenUploadFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	userDataExportProcessor := new(processor.UserDataExportProcessorMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	storageService := new(service.StorageServiceMock)
+	_uut := user.NewUserDataExportRequestedHandler(
+		userRepository,
+		userDataExportProcessor,
+		storageFilePathProvider,
+		storageService,
+		nil,
+	)
+
+	mockUser := &model.User{ID: uuid.New()}
+	userRepository.On("GetWithAllData", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+
+	userDataExportProcessor.On("Export", mock.Anything, *mockUser).Return(nil).Once()
+
+	exportPath := mockUser.ID.String() + "/exports/some_export.zip"
+	storageFilePathProvider.On("GetUserDataExportPath", mockUser.ID).Return(exportPath).Once()
+
+	internalError := errors.New("internal error")
+	storageService.On("UploadStream", "some_export.zip", mock.Anything, exportPath).
+		Return(wrapper.InternalServerError(internalError)).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	userDataExportProcessor.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+}
+
+func TestUserDataExportRequestedHandler_WhenSuccessful_ShouldUploadTheExportAndSendTheLink(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	userDataExportProcessor := new(processor.UserDataExportProcessorMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	storageService := new(service.StorageServiceMock)
+	realTimeService := new(service.RealTimeServiceMock)
+	_uut := user.NewUserDataExportRequestedHandler(
+		userRepository,
+		userDataExportProcessor,
+		storageFilePathProvider,
+		storageService,
+		realTimeService,
+	)
+
+	mockUser := &model.User{ID: uuid.New()}
+	userRepository.On("GetWithAllData", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+
+	archive := []byte("archive")
+	userDataExportProcessor.On("Export", mock.Anything, *mockUser).
+		Run(func(args mock.Arguments) {
+			_, _ = args.Get(0).(io.Writer).Write(archive)
+		}).
+		Return(nil).
+		Once()
+
+	exportPath := mockUser.ID.String() + "/exports/some_export.zip"
+	storageFilePathProvider.On("GetUserDataExportPath", mockUser.ID).Return(exportPath).Once()
+
+	storageService.On("UploadStream", "some_export.zip", mock.Anything, exportPath).
+		Run(func(args mock.Arguments) {
+			content, err := io.ReadAll(args.Get(1).(io.Reader))
+			assert.NoError(t, err)
+			assert.Equal(t, archive, content)
+		}).
+		Return(nil).
+		Once()
+
+	realTimeService.On("Publish", "export", mockUser.ID.String(), mock.IsType(map[string]any{})).
+		Run(func(args mock.Arguments) {
+			newPayload := args.Get(2).(map[string]any)
+			assert.Equal(t, "USER_DATA_EXPORT_READY", newPayload["action"])
+			assert.NotEmpty(t, newPayload["url"])
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	userRepository.AssertExpectations(t)
+	userDataExportProcessor.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	realTimeService.AssertExpectations(t)
+}
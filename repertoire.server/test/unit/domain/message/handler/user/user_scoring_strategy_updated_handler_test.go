@@ -0,0 +1,355 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"repertoire/server/domain/message/handler/user"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserScoringStrategyUpdatedHandler_WhenGetUserFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, nil, nil, nil)
+
+	userID := uuid.New()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), userID).Return(internalError).Once()
+
+	// when
+	payload, _ := json.Marshal(userID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenUserIsNotFound_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, nil, nil, nil)
+
+	userID := uuid.New()
+
+	userRepository.On("Get", new(model.User), userID).Return(nil).Once()
+
+	// when
+	payload, _ := json.Marshal(userID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenGetSongsFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, songRepository, nil, nil)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.LinearScoring}
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenGetHistoryFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, songRepository, songSectionRepository, nil)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.LinearScoring}
+	mockSongs := &[]model.Song{
+		{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New()}}},
+	}
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(nil, mockSongs).
+		Once()
+
+	internalError := errors.New("internal error")
+	songSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			(*mockSongs)[0].Sections[0].ID,
+			model.RehearsalsProperty,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenSongsHaveNoSections_ShouldNotUpdateSongs(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, songRepository, nil, progressProcessor)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.LinearScoring}
+	mockSongs := &[]model.Song{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(nil, mockSongs).
+		Once()
+
+	version := uint(2)
+	progressProcessor.On("GetScoringStrategyVersion", mockUser.ScoringStrategy).Return(version).Once()
+	userRepository.On("UpdateScoringStrategyVersion", mockUser.ID, mockUser.ScoringStrategy, version).
+		Return(nil).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenUpdateScoringStrategyVersionFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(userRepository, songRepository, nil, progressProcessor)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.LinearScoring}
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(nil, &[]model.Song{}).
+		Once()
+
+	version := uint(2)
+	progressProcessor.On("GetScoringStrategyVersion", mockUser.ScoringStrategy).Return(version).Once()
+	internalError := errors.New("internal error")
+	userRepository.On("UpdateScoringStrategyVersion", mockUser.ID, mockUser.ScoringStrategy, version).
+		Return(internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenUpdateSongsFails_ShouldReturnError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(
+		userRepository,
+		songRepository,
+		songSectionRepository,
+		progressProcessor,
+	)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.LinearScoring}
+	mockSongs := &[]model.Song{
+		{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New()}}},
+	}
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(nil, mockSongs).
+		Once()
+
+	songSectionRepository.
+		On("GetHistory", new([]model.SongSectionHistory), mock.IsType(uuid.UUID{}), mock.Anything).
+		Return(nil, &[]model.SongSectionHistory{}).
+		Times(2)
+
+	progressProcessor.On("ComputeRehearsalsScore", []model.SongSectionHistory{}, mockUser.ScoringStrategy).
+		Return(uint64(10)).
+		Once()
+	progressProcessor.On("ComputeConfidenceScore", []model.SongSectionHistory{}, mockUser.ScoringStrategy).
+		Return(uint(50)).
+		Once()
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), mockUser.ScoringStrategy).
+		Return(uint64(5)).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Return(internalError).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.Error(t, err)
+	assert.Equal(t, internalError, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+}
+
+func TestUserScoringStrategyUpdatedHandler_WhenSuccessful_ShouldRecomputeScoresAndUpdateSongs(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := user.NewUserScoringStrategyUpdatedHandler(
+		userRepository,
+		songRepository,
+		songSectionRepository,
+		progressProcessor,
+	)
+
+	mockUser := &model.User{ID: uuid.New(), ScoringStrategy: enums.ExponentialScoring}
+	sectionType := model.SongSectionType{ID: uuid.New(), Name: "Chorus"}
+	mockSongs := &[]model.Song{
+		{
+			ID:       uuid.New(),
+			Artist:   &model.Artist{ID: uuid.New()},
+			Sections: []model.SongSection{{ID: uuid.New(), SongSectionType: sectionType}, {ID: uuid.New()}},
+		},
+		{ID: uuid.New()},
+		{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New(), SongSectionType: sectionType}}},
+	}
+	sectionsCount := 3
+
+	userRepository.On("Get", new(model.User), mockUser.ID).Return(nil, mockUser).Once()
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), mockUser.ID).
+		Return(nil, mockSongs).
+		Once()
+
+	rehearsalsHistory := []model.SongSectionHistory{{ID: uuid.New(), Property: model.RehearsalsProperty}}
+	confidenceHistory := []model.SongSectionHistory{{ID: uuid.New(), Property: model.ConfidenceProperty}}
+	songSectionRepository.
+		On("GetHistory", new([]model.SongSectionHistory), mock.IsType(uuid.UUID{}), model.RehearsalsProperty).
+		Return(nil, &rehearsalsHistory).
+		Times(sectionsCount)
+	songSectionRepository.
+		On("GetHistory", new([]model.SongSectionHistory), mock.IsType(uuid.UUID{}), model.ConfidenceProperty).
+		Return(nil, &confidenceHistory).
+		Times(sectionsCount)
+
+	var newRehearsalsScore uint64 = 40
+	var newConfidenceScore uint = 60
+	var newProgress uint64 = 24
+	progressProcessor.On("ComputeRehearsalsScore", rehearsalsHistory, mockUser.ScoringStrategy).
+		Return(newRehearsalsScore).
+		Times(sectionsCount)
+	progressProcessor.On("ComputeConfidenceScore", confidenceHistory, mockUser.ScoringStrategy).
+		Return(newConfidenceScore).
+		Times(sectionsCount)
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), mockUser.ScoringStrategy).
+		Run(func(args mock.Arguments) {
+			section := args.Get(0).(model.SongSection)
+			assert.Equal(t, newRehearsalsScore, section.RehearsalsScore)
+			assert.Equal(t, newConfidenceScore, section.ConfidenceScore)
+		}).
+		Return(newProgress).
+		Times(sectionsCount)
+
+	songRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.Len(t, *newSongs, 2)
+			for _, song := range *newSongs {
+				assert.NotEmpty(t, song.Sections)
+				assert.Equal(t, float64(newProgress), song.Progress)
+				assert.Nil(t, song.Artist)
+				for _, section := range song.Sections {
+					assert.Equal(t, newRehearsalsScore, section.RehearsalsScore)
+					assert.Equal(t, newConfidenceScore, section.ConfidenceScore)
+					assert.Equal(t, newProgress, section.Progress)
+					assert.Empty(t, section.SongSectionType)
+				}
+			}
+		}).
+		Return(nil).
+		Once()
+
+	version := uint(2)
+	progressProcessor.On("GetScoringStrategyVersion", mockUser.ScoringStrategy).Return(version).Once()
+	userRepository.On("UpdateScoringStrategyVersion", mockUser.ID, mockUser.ScoringStrategy, version).
+		Return(nil).
+		Once()
+
+	// when
+	payload, _ := json.Marshal(mockUser.ID)
+	msg := message.NewMessage("1", payload)
+	err := _uut.Handle(msg)
+
+	// then
+	assert.NoError(t, err)
+
+	userRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+}
@@ -0,0 +1,280 @@
+package message
+
+import (
+	"errors"
+	"repertoire/server/domain/message"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOutboxRelay_WhenGetOldestAndLockFails_ShouldReturnError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	_uut := message.NewOutboxRelay(transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	internalError := errors.New("internal error")
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Return(internalError).
+		Once()
+
+	// when
+	relayed, err := _uut.Relay()
+
+	// then
+	assert.Zero(t, relayed)
+	assert.Equal(t, internalError, err)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestOutboxRelay_WhenOutboxIsEmpty_ShouldNotRelayAnything(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := message.NewOutboxRelay(transactionManager, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Return(nil, &[]model.OutboxMessage{}).
+		Once()
+
+	// when
+	relayed, err := _uut.Relay()
+
+	// then
+	assert.Zero(t, relayed)
+	assert.NoError(t, err)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	messagePublisherService.AssertNotCalled(t, "Relay", mock.Anything)
+	outboxRepository.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestOutboxRelay_WhenDeleteFails_ShouldReturnError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := message.NewOutboxRelay(transactionManager, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	messages := []model.OutboxMessage{newOutboxMessage()}
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Return(nil, &messages).
+		Once()
+	messagePublisherService.On("Relay", messages[0]).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	outboxRepository.On("Delete", []uuid.UUID{messages[0].ID}).Return(internalError).Once()
+
+	// when
+	relayed, err := _uut.Relay()
+
+	// then
+	assert.Zero(t, relayed)
+	assert.Equal(t, internalError, err)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestOutboxRelay_WhenRelayFails_ShouldDeleteOnlyTheRelayedMessagesAndReturnError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := message.NewOutboxRelay(transactionManager, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	messages := []model.OutboxMessage{newOutboxMessage(), newOutboxMessage(), newOutboxMessage()}
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Return(nil, &messages).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("Relay", messages[0]).Return(nil).Once()
+	messagePublisherService.On("Relay", messages[1]).Return(internalError).Once()
+
+	outboxRepository.On("Delete", []uuid.UUID{messages[0].ID}).Return(nil).Once()
+
+	// when
+	relayed, err := _uut.Relay()
+
+	// then
+	assert.Equal(t, 1, relayed)
+	assert.Equal(t, internalError, err)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	messagePublisherService.AssertNotCalled(t, "Relay", messages[2])
+}
+
+func TestOutboxRelay_WhenSuccessful_ShouldRelayAndDeleteTheMessages(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := message.NewOutboxRelay(transactionManager, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	messages := []model.OutboxMessage{newOutboxMessage(), newOutboxMessage()}
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Return(nil, &messages).
+		Once()
+	for _, m := range messages {
+		messagePublisherService.On("Relay", m).Return(nil).Once()
+	}
+	outboxRepository.On("Delete", []uuid.UUID{messages[0].ID, messages[1].ID}).Return(nil).Once()
+
+	// when
+	relayed, err := _uut.Relay()
+
+	// then
+	assert.Equal(t, len(messages), relayed)
+	assert.NoError(t, err)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestOutboxRelay_WhenCrashingMidway_ShouldNotLoseAnyMessage(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := message.NewOutboxRelay(transactionManager, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	// the outbox table, as seen by the committed transactions
+	outbox := []model.OutboxMessage{newOutboxMessage(), newOutboxMessage(), newOutboxMessage(), newOutboxMessage()}
+	allMessages := slices.Clone(outbox)
+	var delivered []model.OutboxMessage
+
+	// given - mocking
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository)
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory)
+
+	outboxRepository.On("GetOldestAndLock", new([]model.OutboxMessage), mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(0).(*[]model.OutboxMessage) = slices.Clone(outbox)
+		}).
+		Return(nil)
+
+	// 1st run - the broker goes down after receiving the first message
+	crashError := errors.New("broker is down")
+	messagePublisherService.On("Relay", allMessages[0]).
+		Run(func(args mock.Arguments) { delivered = append(delivered, allMessages[0]) }).
+		Return(nil).
+		Once()
+	messagePublisherService.On("Relay", allMessages[1]).Return(crashError).Once()
+
+	// 2nd run - the server goes down before committing, so nothing gets removed from the outbox
+	for _, m := range allMessages[1:] {
+		messagePublisherService.On("Relay", m).
+			Run(func(args mock.Arguments) { delivered = append(delivered, m) }).
+			Return(nil).
+			Once()
+	}
+	outboxRepository.On("Delete", []uuid.UUID{allMessages[1].ID, allMessages[2].ID, allMessages[3].ID}).
+		Return(crashError).
+		Once()
+
+	// 3rd run - everything is back up
+	for _, m := range allMessages[1:] {
+		messagePublisherService.On("Relay", m).
+			Run(func(args mock.Arguments) { delivered = append(delivered, m) }).
+			Return(nil).
+			Once()
+	}
+
+	outboxRepository.On("Delete", mock.Anything).
+		Run(func(args mock.Arguments) {
+			ids := args.Get(0).([]uuid.UUID)
+			outbox = slices.DeleteFunc(outbox, func(m model.OutboxMessage) bool {
+				return slices.Contains(ids, m.ID)
+			})
+		}).
+		Return(nil)
+
+	// when
+	relayed1, err1 := _uut.Relay()
+	relayed2, err2 := _uut.Relay()
+	relayed3, err3 := _uut.Relay()
+
+	// then
+	assert.Equal(t, 1, relayed1)
+	assert.Equal(t, crashError, err1)
+	assert.Zero(t, relayed2)
+	assert.Equal(t, crashError, err2)
+	assert.Equal(t, 3, relayed3)
+	assert.NoError(t, err3)
+
+	// every message got delivered (at least once) and the outbox got emptied
+	assert.Empty(t, outbox)
+	for _, m := range allMessages {
+		assert.Contains(t, delivered, m)
+	}
+
+	messagePublisherService.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+}
+
+func newOutboxMessage() model.OutboxMessage {
+	return model.OutboxMessage{
+		ID:      uuid.New(),
+		Queue:   string(topics.TopicToQueueMap[topics.SongCreatedTopic]),
+		Topic:   string(topics.SongCreatedTopic),
+		Payload: []byte(uuid.New().String()),
+	}
+}
@@ -0,0 +1,29 @@
+package processor
+
+import (
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type PlaylistProcessorMock struct {
+	mock.Mock
+}
+
+func (p *PlaylistProcessorMock) GetSmartPlaylistSongIDs(playlist model.Playlist) ([]uuid.UUID, *wrapper.ErrorCode) {
+	args := p.Called(playlist)
+
+	var ids []uuid.UUID
+	if i := args.Get(0); i != nil {
+		ids = i.([]uuid.UUID)
+	}
+
+	var errCode *wrapper.ErrorCode
+	if e := args.Get(1); e != nil {
+		errCode = e.(*wrapper.ErrorCode)
+	}
+
+	return ids, errCode
+}
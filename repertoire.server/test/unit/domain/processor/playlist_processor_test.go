@@ -0,0 +1,137 @@
+package processor
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/processor"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSmartPlaylistSongIDs_WhenGetAllSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := processor.NewPlaylistProcessor(songRepository)
+
+	mockPlaylist := model.Playlist{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+
+	internalError := errors.New("internal error")
+	songRepository.
+		On(
+			"GetAllByUser",
+			new([]model.EnhancedSong),
+			mockPlaylist.UserID,
+			(*int)(nil),
+			(*int)(nil),
+			mockPlaylist.SmartRules.OrderBy,
+			mockPlaylist.SmartRules.SearchBy,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	ids, errCode := _uut.GetSmartPlaylistSongIDs(mockPlaylist)
+
+	// then
+	assert.Nil(t, ids)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+}
+
+func TestGetSmartPlaylistSongIDs_WhenRulesHaveNoLimit_ShouldReturnAllTheMatchingSongs(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := processor.NewPlaylistProcessor(songRepository)
+
+	mockPlaylist := model.Playlist{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{
+			SearchBy: []string{"confidence < 60"},
+			OrderBy:  []string{"progress asc"},
+		},
+	}
+
+	songs := &[]model.EnhancedSong{
+		{Song: model.Song{ID: uuid.New()}},
+		{Song: model.Song{ID: uuid.New()}},
+		{Song: model.Song{ID: uuid.New()}},
+	}
+	songRepository.
+		On(
+			"GetAllByUser",
+			new([]model.EnhancedSong),
+			mockPlaylist.UserID,
+			(*int)(nil),
+			(*int)(nil),
+			mockPlaylist.SmartRules.OrderBy,
+			mockPlaylist.SmartRules.SearchBy,
+		).
+		Return(nil, songs).
+		Once()
+
+	// when
+	ids, errCode := _uut.GetSmartPlaylistSongIDs(mockPlaylist)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Len(t, ids, len(*songs))
+	for i, id := range ids {
+		assert.Equal(t, (*songs)[i].ID, id)
+	}
+
+	songRepository.AssertExpectations(t)
+}
+
+func TestGetSmartPlaylistSongIDs_WhenRulesHaveLimit_ShouldReturnOnlyTheFirstMatchingSongs(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := processor.NewPlaylistProcessor(songRepository)
+
+	mockPlaylist := model.Playlist{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{
+			SearchBy: []string{"confidence < 60"},
+			OrderBy:  []string{"progress asc"},
+			Limit:    &[]uint{2}[0],
+		},
+	}
+
+	songs := &[]model.EnhancedSong{
+		{Song: model.Song{ID: uuid.New()}},
+		{Song: model.Song{ID: uuid.New()}},
+	}
+	songRepository.
+		On(
+			"GetAllByUser",
+			new([]model.EnhancedSong),
+			mockPlaylist.UserID,
+			&[]int{1}[0],
+			&[]int{2}[0],
+			mockPlaylist.SmartRules.OrderBy,
+			mockPlaylist.SmartRules.SearchBy,
+		).
+		Return(nil, songs).
+		Once()
+
+	// when
+	ids, errCode := _uut.GetSmartPlaylistSongIDs(mockPlaylist)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, []uuid.UUID{(*songs)[0].ID, (*songs)[1].ID}, ids)
+
+	songRepository.AssertExpectations(t)
+}
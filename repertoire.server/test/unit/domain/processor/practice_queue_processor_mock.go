@@ -0,0 +1,23 @@
+package processor
+
+import (
+	"repertoire/server/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PracticeQueueProcessorMock struct {
+	mock.Mock
+}
+
+func (p *PracticeQueueProcessorMock) BuildQueue(songs []model.Song, budget *time.Duration) []model.PracticeQueueSong {
+	args := p.Called(songs, budget)
+
+	var queue []model.PracticeQueueSong
+	if q := args.Get(0); q != nil {
+		queue = q.([]model.PracticeQueueSong)
+	}
+
+	return queue
+}
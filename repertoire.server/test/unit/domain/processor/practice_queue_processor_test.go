@@ -0,0 +1,209 @@
+package processor
+
+import (
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildQueue_WhenThereAreNoSongs_ShouldReturnEmptyQueue(t *testing.T) {
+	// given
+	_uut := processor.NewPracticeQueueProcessor()
+
+	// when
+	queue := _uut.BuildQueue([]model.Song{}, nil)
+
+	// then
+	assert.Empty(t, queue)
+}
+
+func TestBuildQueue_WhenSongsAreNotDue_ShouldNotQueueThem(t *testing.T) {
+	// given
+	_uut := processor.NewPracticeQueueProcessor()
+
+	songs := []model.Song{
+		{
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now()}[0],
+			Sections:       []model.SongSection{{ID: uuid.New(), ConfidenceScore: 0}},
+		},
+		{
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-10 * 24 * time.Hour)}[0],
+			Sections:       []model.SongSection{{ID: uuid.New(), ConfidenceScore: 100}},
+		},
+		{
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-12 * time.Hour)}[0],
+			Confidence:     10,
+		},
+	}
+
+	// when
+	queue := _uut.BuildQueue(songs, nil)
+
+	// then
+	assert.Empty(t, queue)
+}
+
+func TestBuildQueue_WhenSongsAreDue_ShouldRankThemByPriority(t *testing.T) {
+	// given
+	_uut := processor.NewPracticeQueueProcessor()
+
+	hard := enums.Hard
+	songs := []model.Song{
+		{
+			// due on a confident section
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-20 * 24 * time.Hour)}[0],
+			Sections:       []model.SongSection{{ID: uuid.New(), ConfidenceScore: 80}},
+		},
+		{
+			// never played
+			ID:       uuid.New(),
+			Sections: []model.SongSection{{ID: uuid.New()}},
+		},
+		{
+			// not due
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now()}[0],
+			Sections:       []model.SongSection{{ID: uuid.New()}},
+		},
+		{
+			// same decay as the second one, but harder
+			ID:         uuid.New(),
+			Difficulty: &hard,
+			Sections:   []model.SongSection{{ID: uuid.New()}},
+		},
+		{
+			// long overdue with one section not yet due
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-6 * 24 * time.Hour)}[0],
+			Sections: []model.SongSection{
+				{ID: uuid.New(), ConfidenceScore: 100},
+				{ID: uuid.New(), ConfidenceScore: 10},
+			},
+		},
+	}
+
+	// when
+	queue := _uut.BuildQueue(songs, nil)
+
+	// then
+	assert.Len(t, queue, 4)
+	assert.Equal(t, songs[4].ID, queue[0].Song.ID)
+	assert.Equal(t, songs[3].ID, queue[1].Song.ID)
+	assert.Equal(t, songs[1].ID, queue[2].Song.ID)
+	assert.Equal(t, songs[0].ID, queue[3].Song.ID)
+
+	for i := 1; i < len(queue); i++ {
+		assert.GreaterOrEqual(t, queue[i-1].Priority, queue[i].Priority)
+	}
+	for _, queueSong := range queue {
+		assert.NotZero(t, queueSong.EstimatedDuration)
+		assert.NotEmpty(t, queueSong.DueSections)
+	}
+
+	assert.Len(t, queue[0].DueSections, 1)
+	assert.Equal(t, songs[4].Sections[1].ID, queue[0].DueSections[0].SongSection.ID)
+	assert.Equal(t, uint(1), queue[0].DueSections[0].IntervalDays)
+	assert.Equal(t, uint(16), queue[3].DueSections[0].IntervalDays)
+}
+
+func TestBuildQueue_WhenSongHasNoSections_ShouldRankBySongConfidence(t *testing.T) {
+	// given
+	_uut := processor.NewPracticeQueueProcessor()
+
+	songs := []model.Song{
+		{
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-3 * 24 * time.Hour)}[0],
+			Confidence:     90,
+		},
+		{
+			ID:             uuid.New(),
+			LastTimePlayed: &[]time.Time{time.Now().Add(-3 * 24 * time.Hour)}[0],
+			Confidence:     10,
+		},
+	}
+
+	// when
+	queue := _uut.BuildQueue(songs, nil)
+
+	// then
+	assert.Len(t, queue, 1)
+	assert.Equal(t, songs[1].ID, queue[0].Song.ID)
+	assert.Empty(t, queue[0].DueSections)
+}
+
+func TestBuildQueue_WhenBudgetIsGiven_ShouldOnlyQueueSongsThatFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		budget        time.Duration
+		expectedCount int
+	}{
+		{"Not enough for one song", 3 * time.Minute, 0},
+		{"Enough for one song", 5 * time.Minute, 1},
+		{"Enough for two songs", 14 * time.Minute, 2},
+		{"Enough for all songs", 45 * time.Minute, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewPracticeQueueProcessor()
+
+			songs := []model.Song{
+				{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New()}}},
+				{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New()}}},
+				{ID: uuid.New(), Sections: []model.SongSection{{ID: uuid.New()}}},
+			}
+
+			// when
+			queue := _uut.BuildQueue(songs, &tt.budget)
+
+			// then
+			assert.Len(t, queue, tt.expectedCount)
+			var totalDuration uint = 0
+			for _, queueSong := range queue {
+				totalDuration += queueSong.EstimatedDuration
+			}
+			assert.LessOrEqual(t, totalDuration, uint(tt.budget.Seconds()))
+		})
+	}
+}
+
+func TestBuildQueue_WhenSongHasDuration_ShouldEstimateByItsDuration(t *testing.T) {
+	// given
+	_uut := processor.NewPracticeQueueProcessor()
+
+	songs := []model.Song{
+		{
+			ID:       uuid.New(),
+			Duration: &[]uint{240}[0],
+			Sections: []model.SongSection{{ID: uuid.New()}},
+		},
+		{
+			ID:       uuid.New(),
+			Sections: []model.SongSection{{ID: uuid.New()}},
+		},
+	}
+
+	// when
+	queue := _uut.BuildQueue(songs, nil)
+
+	// then
+	assert.Len(t, queue, 2)
+	for _, queueSong := range queue {
+		if queueSong.Song.ID == songs[0].ID {
+			assert.Equal(t, uint(240), queueSong.EstimatedDuration)
+		} else {
+			assert.Equal(t, uint(5*time.Minute/time.Second), queueSong.EstimatedDuration)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package processor
+
+import (
+	"repertoire/server/data/repository"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type PracticeSessionProcessorMock struct {
+	mock.Mock
+}
+
+func (p *PracticeSessionProcessorMock) RecordPerfectRehearsal(
+	song model.Song,
+	batchID uuid.UUID,
+	practiceSessionRepository repository.PracticeSessionRepository,
+) *wrapper.ErrorCode {
+	args := p.Called(song, batchID, practiceSessionRepository)
+
+	var errCode *wrapper.ErrorCode
+	if e := args.Get(0); e != nil {
+		errCode = e.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
+
+func (p *PracticeSessionProcessorMock) RecordRehearsal(
+	song model.Song,
+	batchID uuid.UUID,
+	sections []model.PracticeSessionSection,
+	practiceSessionRepository repository.PracticeSessionRepository,
+) *wrapper.ErrorCode {
+	args := p.Called(song, batchID, sections, practiceSessionRepository)
+
+	var errCode *wrapper.ErrorCode
+	if e := args.Get(0); e != nil {
+		errCode = e.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
@@ -0,0 +1,214 @@
+package processor
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/processor"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecordPerfectRehearsal_WhenSectionsHaveZeroOccurrences_ShouldNotRecordAnything(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New()}, {ID: uuid.New()}},
+	}
+
+	// when
+	errCode := _uut.RecordPerfectRehearsal(mockSong, uuid.New(), practiceSessionRepository)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordPerfectRehearsal_WhenSuccessful_ShouldRecordSectionsWithOccurrences(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		Sections: []model.SongSection{
+			{ID: uuid.New(), Occurrences: 2},
+			{ID: uuid.New()},
+			{ID: uuid.New(), Occurrences: 1},
+		},
+	}
+
+	mockSession := &model.PracticeSession{ID: uuid.New(), UserID: mockSong.UserID}
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), mockSong.UserID).
+		Return(nil, mockSession).
+		Once()
+
+	var sessionSong *model.PracticeSessionSong
+	practiceSessionRepository.On("CreateSong", mock.IsType(new(model.PracticeSessionSong))).
+		Run(func(args mock.Arguments) {
+			sessionSong = args.Get(0).(*model.PracticeSessionSong)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.RecordPerfectRehearsal(mockSong, uuid.New(), practiceSessionRepository)
+
+	// then
+	assert.Nil(t, errCode)
+
+	assert.NotEmpty(t, sessionSong.ID)
+	assert.Equal(t, mockSession.ID, sessionSong.PracticeSessionID)
+	assert.Equal(t, mockSong.ID, sessionSong.SongID)
+	assert.Len(t, sessionSong.Sections, 2)
+	assert.Equal(t, mockSong.Sections[0].ID, sessionSong.Sections[0].SongSectionID)
+	assert.Equal(t, mockSong.Sections[0].Occurrences, sessionSong.Sections[0].Occurrences)
+	assert.Equal(t, mockSong.Sections[2].ID, sessionSong.Sections[1].SongSectionID)
+	assert.Equal(t, mockSong.Sections[2].Occurrences, sessionSong.Sections[1].Occurrences)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordRehearsal_WhenThereAreNoSections_ShouldNotRecordAnything(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{ID: uuid.New(), UserID: uuid.New()}
+
+	// when
+	errCode := _uut.RecordRehearsal(mockSong, uuid.New(), []model.PracticeSessionSection{}, practiceSessionRepository)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordRehearsal_WhenGetInProgressSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{ID: uuid.New(), UserID: uuid.New()}
+	sections := []model.PracticeSessionSection{{SongSectionID: uuid.New(), Occurrences: 1}}
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), mockSong.UserID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.RecordRehearsal(mockSong, uuid.New(), sections, practiceSessionRepository)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordRehearsal_WhenNoSessionIsInProgress_ShouldNotRecordAnything(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{ID: uuid.New(), UserID: uuid.New()}
+	sections := []model.PracticeSessionSection{{SongSectionID: uuid.New(), Occurrences: 1}}
+
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), mockSong.UserID).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.RecordRehearsal(mockSong, uuid.New(), sections, practiceSessionRepository)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordRehearsal_WhenCreateSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{ID: uuid.New(), UserID: uuid.New()}
+	sections := []model.PracticeSessionSection{{SongSectionID: uuid.New(), Occurrences: 1}}
+
+	mockSession := &model.PracticeSession{ID: uuid.New(), UserID: mockSong.UserID}
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), mockSong.UserID).
+		Return(nil, mockSession).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("CreateSong", mock.IsType(new(model.PracticeSessionSong))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.RecordRehearsal(mockSong, uuid.New(), sections, practiceSessionRepository)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestRecordRehearsal_WhenSuccessful_ShouldAppendSongToTheSessionInProgress(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := processor.NewPracticeSessionProcessor()
+
+	mockSong := model.Song{ID: uuid.New(), UserID: uuid.New()}
+	batchID := uuid.New()
+	sections := []model.PracticeSessionSection{
+		{SongSectionID: uuid.New(), Occurrences: 1},
+		{SongSectionID: uuid.New(), Occurrences: 3},
+	}
+
+	mockSession := &model.PracticeSession{ID: uuid.New(), UserID: mockSong.UserID}
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), mockSong.UserID).
+		Return(nil, mockSession).
+		Once()
+
+	practiceSessionRepository.On("CreateSong", mock.IsType(new(model.PracticeSessionSong))).
+		Run(func(args mock.Arguments) {
+			newSessionSong := args.Get(0).(*model.PracticeSessionSong)
+			assert.NotEmpty(t, newSessionSong.ID)
+			assert.Equal(t, mockSession.ID, newSessionSong.PracticeSessionID)
+			assert.Equal(t, mockSong.ID, newSessionSong.SongID)
+			assert.Equal(t, &batchID, newSessionSong.BatchID)
+			assert.Len(t, newSessionSong.Sections, len(sections))
+			for i, section := range newSessionSong.Sections {
+				assert.NotEmpty(t, section.ID)
+				assert.Equal(t, newSessionSong.ID, section.PracticeSessionSongID)
+				assert.Equal(t, sections[i].SongSectionID, section.SongSectionID)
+				assert.Equal(t, sections[i].Occurrences, section.Occurrences)
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.RecordRehearsal(mockSong, batchID, sections, practiceSessionRepository)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
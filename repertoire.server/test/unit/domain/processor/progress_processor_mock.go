@@ -1,6 +1,7 @@
 package processor
 
 import (
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
 	"github.com/stretchr/testify/mock"
@@ -10,17 +11,33 @@ type ProgressProcessorMock struct {
 	mock.Mock
 }
 
-func (p *ProgressProcessorMock) ComputeRehearsalsScore(history []model.SongSectionHistory) uint64 {
-	args := p.Called(history)
+func (p *ProgressProcessorMock) GetScoringStrategyVersions() map[enums.ScoringStrategy]uint {
+	args := p.Called()
+	return args.Get(0).(map[enums.ScoringStrategy]uint)
+}
+
+func (p *ProgressProcessorMock) GetScoringStrategyVersion(strategy enums.ScoringStrategy) uint {
+	args := p.Called(strategy)
+	return args.Get(0).(uint)
+}
+
+func (p *ProgressProcessorMock) ComputeRehearsalsScore(
+	history []model.SongSectionHistory,
+	strategy enums.ScoringStrategy,
+) uint64 {
+	args := p.Called(history, strategy)
 	return args.Get(0).(uint64)
 }
 
-func (p *ProgressProcessorMock) ComputeConfidenceScore(history []model.SongSectionHistory) uint {
-	args := p.Called(history)
+func (p *ProgressProcessorMock) ComputeConfidenceScore(
+	history []model.SongSectionHistory,
+	strategy enums.ScoringStrategy,
+) uint {
+	args := p.Called(history, strategy)
 	return args.Get(0).(uint)
 }
 
-func (p *ProgressProcessorMock) ComputeProgress(section model.SongSection) uint64 {
-	args := p.Called(section)
+func (p *ProgressProcessorMock) ComputeProgress(section model.SongSection, strategy enums.ScoringStrategy) uint64 {
+	args := p.Called(section, strategy)
 	return args.Get(0).(uint64)
 }
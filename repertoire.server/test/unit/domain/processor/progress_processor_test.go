@@ -2,6 +2,7 @@ package processor
 
 import (
 	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ func TestComputeRehearsalsScore_WhenHistoryLengthIs0_ShouldReturn0(t *testing.T)
 	_uut := processor.NewProgressProcessor()
 
 	// when
-	rehearsalsScore := _uut.ComputeRehearsalsScore([]model.SongSectionHistory{})
+	rehearsalsScore := _uut.ComputeRehearsalsScore([]model.SongSectionHistory{}, enums.ClassicScoring)
 
 	// then
 	assert.Zero(t, rehearsalsScore)
@@ -28,7 +29,7 @@ func TestComputeRehearsalsScore_WhenHistoryLengthIs1_ShouldReturnLatestRehearsal
 	}
 
 	// when
-	rehearsalsScore := _uut.ComputeRehearsalsScore(history)
+	rehearsalsScore := _uut.ComputeRehearsalsScore(history, enums.ClassicScoring)
 
 	// then
 	assert.Equal(t, uint64(history[0].To), rehearsalsScore)
@@ -96,7 +97,7 @@ func TestComputeRehearsalsScore_WhenSuccessful_ShouldComputeAndReturnRehearsalsS
 			_uut := processor.NewProgressProcessor()
 
 			// when
-			rehearsalsScore := _uut.ComputeRehearsalsScore(tt.history)
+			rehearsalsScore := _uut.ComputeRehearsalsScore(tt.history, enums.ClassicScoring)
 
 			// then
 			assert.Equal(t, tt.expectedResult, rehearsalsScore)
@@ -109,7 +110,7 @@ func TestComputeConfidenceScore_WhenHistoryLengthIs0_ShouldReturnDefaultConfiden
 	_uut := processor.NewProgressProcessor()
 
 	// when
-	confidenceScore := _uut.ComputeConfidenceScore([]model.SongSectionHistory{})
+	confidenceScore := _uut.ComputeConfidenceScore([]model.SongSectionHistory{}, enums.ClassicScoring)
 
 	// then
 	assert.Equal(t, model.DefaultSongSectionConfidence, confidenceScore)
@@ -274,7 +275,7 @@ func TestComputeConfidenceScore_WhenSuccessful_ShouldComputeAndReturnConfidenceS
 			_uut := processor.NewProgressProcessor()
 
 			// when
-			confidenceScore := _uut.ComputeConfidenceScore(tt.history)
+			confidenceScore := _uut.ComputeConfidenceScore(tt.history, enums.ClassicScoring)
 
 			// then
 			assert.Equal(t, tt.expectedResult, confidenceScore)
@@ -320,10 +321,140 @@ func TestComputeProgress_WhenSuccessful_ShouldComputeAndReturnProgress(t *testin
 			_uut := processor.NewProgressProcessor()
 
 			// when
-			progress := _uut.ComputeProgress(tt.section)
+			progress := _uut.ComputeProgress(tt.section, enums.ClassicScoring)
 
 			// then
 			assert.Equal(t, tt.expectedProgress, progress)
 		})
 	}
 }
+
+func TestComputeRehearsalsScore_WhenStrategyIsNotClassic_ShouldComputeWithThatStrategy(t *testing.T) {
+	history := []model.SongSectionHistory{
+		{
+			From:      0,
+			To:        1,
+			Property:  model.RehearsalsProperty,
+			CreatedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			From:      1,
+			To:        3,
+			Property:  model.RehearsalsProperty,
+			CreatedAt: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		strategy       enums.ScoringStrategy
+		expectedResult uint64
+	}{
+		{"Classic", enums.ClassicScoring, 30},
+		{"Exponential", enums.ExponentialScoring, 54},
+		{"Linear", enums.LinearScoring, 58},
+		{"Unknown falls back to Classic", "something", 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewProgressProcessor()
+
+			// when
+			rehearsalsScore := _uut.ComputeRehearsalsScore(history, tt.strategy)
+
+			// then
+			assert.Equal(t, tt.expectedResult, rehearsalsScore)
+		})
+	}
+}
+
+func TestComputeConfidenceScore_WhenStrategyIsNotClassic_ShouldComputeWithThatStrategy(t *testing.T) {
+	history := []model.SongSectionHistory{
+		{From: 50, To: 70, Property: model.ConfidenceProperty},
+		{From: 70, To: 60, Property: model.ConfidenceProperty},
+	}
+
+	tests := []struct {
+		name           string
+		strategy       enums.ScoringStrategy
+		history        []model.SongSectionHistory
+		expectedResult uint
+	}{
+		{"Exponential - Empty History", enums.ExponentialScoring, []model.SongSectionHistory{}, model.DefaultSongSectionConfidence},
+		{"Exponential", enums.ExponentialScoring, history, 60},
+		{"Linear - Empty History", enums.LinearScoring, []model.SongSectionHistory{}, model.DefaultSongSectionConfidence},
+		{"Linear", enums.LinearScoring, history, 63},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewProgressProcessor()
+
+			// when
+			confidenceScore := _uut.ComputeConfidenceScore(tt.history, tt.strategy)
+
+			// then
+			assert.Equal(t, tt.expectedResult, confidenceScore)
+		})
+	}
+}
+
+func TestComputeProgress_WhenStrategyIsNotClassic_ShouldComputeWithThatStrategy(t *testing.T) {
+	tests := []struct {
+		name             string
+		strategy         enums.ScoringStrategy
+		section          model.SongSection
+		expectedProgress uint64
+	}{
+		{"Exponential - Full Confidence", enums.ExponentialScoring, model.SongSection{ConfidenceScore: 100, RehearsalsScore: 50}, 50},
+		{"Exponential - Half Confidence", enums.ExponentialScoring, model.SongSection{ConfidenceScore: 50, RehearsalsScore: 100}, 38},
+		{"Exponential - No Confidence", enums.ExponentialScoring, model.SongSection{ConfidenceScore: 0, RehearsalsScore: 50}, 1},
+		{"Linear", enums.LinearScoring, model.SongSection{ConfidenceScore: 60, RehearsalsScore: 50}, 55},
+		{"Linear - Nothing", enums.LinearScoring, model.SongSection{}, 1},
+		{"Unknown falls back to Classic", "something", model.SongSection{ConfidenceScore: 60, RehearsalsScore: 50}, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewProgressProcessor()
+
+			// when
+			progress := _uut.ComputeProgress(tt.section, tt.strategy)
+
+			// then
+			assert.Equal(t, tt.expectedProgress, progress)
+		})
+	}
+}
+
+func TestGetScoringStrategyVersions_WhenSuccessful_ShouldReturnTheVersionsOfAllStrategies(t *testing.T) {
+	// given
+	_uut := processor.NewProgressProcessor()
+
+	// when
+	versions := _uut.GetScoringStrategyVersions()
+
+	// then
+	assert.Len(t, versions, 3)
+	assert.Contains(t, versions, enums.ClassicScoring)
+	assert.Contains(t, versions, enums.ExponentialScoring)
+	assert.Contains(t, versions, enums.LinearScoring)
+	for _, version := range versions {
+		assert.NotZero(t, version)
+	}
+}
+
+func TestGetScoringStrategyVersion_WhenStrategyIsUnknown_ShouldReturnTheClassicVersion(t *testing.T) {
+	// given
+	_uut := processor.NewProgressProcessor()
+
+	// when
+	version := _uut.GetScoringStrategyVersion("something")
+
+	// then
+	assert.Equal(t, _uut.GetScoringStrategyVersion(enums.ClassicScoring), version)
+}
@@ -0,0 +1,30 @@
+package processor
+
+import (
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ProgressTimelineProcessorMock struct {
+	mock.Mock
+}
+
+func (p *ProgressTimelineProcessorMock) BuildTimeline(
+	sections []model.SongSection,
+	interval enums.TimelineInterval,
+	from *time.Time,
+	to *time.Time,
+	strategy enums.ScoringStrategy,
+) []model.ProgressTimelinePoint {
+	args := p.Called(sections, interval, from, to, strategy)
+
+	var timeline []model.ProgressTimelinePoint
+	if t := args.Get(0); t != nil {
+		timeline = t.([]model.ProgressTimelinePoint)
+	}
+
+	return timeline
+}
@@ -0,0 +1,225 @@
+package processor
+
+import (
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBuildTimeline_WhenThereIsNoHistoryAndNoFrom_ShouldReturnEmptyTimeline(t *testing.T) {
+	// given
+	_uut := processor.NewProgressTimelineProcessor(nil)
+
+	sections := []model.SongSection{{ID: uuid.New()}}
+
+	// when
+	timeline := _uut.BuildTimeline(sections, enums.DayInterval, nil, nil, enums.ClassicScoring)
+
+	// then
+	assert.NotNil(t, timeline)
+	assert.Empty(t, timeline)
+}
+
+func TestBuildTimeline_WhenSuccessful_ShouldReplayTheHistoryOnEachBucket(t *testing.T) {
+	// given
+	progressProcessor := new(ProgressProcessorMock)
+	_uut := processor.NewProgressTimelineProcessor(progressProcessor)
+
+	sections := []model.SongSection{
+		{
+			ID:        uuid.New(),
+			CreatedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			History: []model.SongSectionHistory{
+				{
+					Property:  model.RehearsalsProperty,
+					From:      0,
+					To:        2,
+					CreatedAt: time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					Property:  model.ConfidenceProperty,
+					From:      0,
+					To:        40,
+					CreatedAt: time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					Property:  model.RehearsalsProperty,
+					From:      2,
+					To:        5,
+					CreatedAt: time.Date(2024, time.January, 3, 10, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			ID:        uuid.New(),
+			CreatedAt: time.Date(2024, time.January, 2, 12, 0, 0, 0, time.UTC),
+			History: []model.SongSectionHistory{
+				{
+					Property:  model.ConfidenceProperty,
+					From:      0,
+					To:        80,
+					CreatedAt: time.Date(2024, time.January, 2, 13, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+	to := time.Date(2024, time.January, 3, 23, 0, 0, 0, time.UTC)
+	strategy := enums.LinearScoring
+
+	var progress uint64 = 15
+	progressProcessor.On("ComputeRehearsalsScore", mock.IsType([]model.SongSectionHistory{}), strategy).
+		Return(uint64(10))
+	progressProcessor.On("ComputeConfidenceScore", mock.IsType([]model.SongSectionHistory{}), strategy).
+		Return(uint(20))
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), strategy).
+		Run(func(args mock.Arguments) {
+			section := args.Get(0).(model.SongSection)
+			assert.Equal(t, uint64(10), section.RehearsalsScore)
+			assert.Equal(t, uint(20), section.ConfidenceScore)
+		}).
+		Return(progress)
+
+	// when
+	timeline := _uut.BuildTimeline(sections, enums.DayInterval, nil, &to, strategy)
+
+	// then
+	expectedTimeline := []model.ProgressTimelinePoint{
+		{
+			Date:       time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Rehearsals: 2,
+			Confidence: 0,
+			Progress:   float64(progress),
+		},
+		{
+			Date:       time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Rehearsals: 0,
+			Confidence: 60,
+			Progress:   float64(progress),
+		},
+		{
+			Date:       time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC),
+			Rehearsals: 3,
+			Confidence: 60,
+			Progress:   float64(progress),
+		},
+	}
+	assert.Equal(t, expectedTimeline, timeline)
+
+	progressProcessor.AssertExpectations(t)
+}
+
+func TestBuildTimeline_WhenSectionsDoNotExistYet_ShouldReturnEmptyPoints(t *testing.T) {
+	// given
+	_uut := processor.NewProgressTimelineProcessor(nil)
+
+	sections := []model.SongSection{
+		{ID: uuid.New(), CreatedAt: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)
+
+	// when
+	timeline := _uut.BuildTimeline(sections, enums.DayInterval, &from, &to, enums.ClassicScoring)
+
+	// then
+	assert.Equal(t, []model.ProgressTimelinePoint{{Date: from}, {Date: to}}, timeline)
+}
+
+func TestBuildTimeline_WhenIntervalIsNotDay_ShouldAlignTheBuckets(t *testing.T) {
+	tests := []struct {
+		name          string
+		interval      enums.TimelineInterval
+		from          time.Time
+		to            time.Time
+		expectedDates []time.Time
+	}{
+		{
+			"Week starts on monday",
+			enums.WeekInterval,
+			time.Date(2024, time.January, 10, 15, 0, 0, 0, time.UTC),
+			time.Date(2024, time.January, 24, 0, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 22, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"Month starts on the first day",
+			enums.MonthInterval,
+			time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewProgressTimelineProcessor(nil)
+
+			// when
+			timeline := _uut.BuildTimeline([]model.SongSection{}, tt.interval, &tt.from, &tt.to, enums.ClassicScoring)
+
+			// then
+			assert.Len(t, timeline, len(tt.expectedDates))
+			for i := range timeline {
+				assert.Equal(t, tt.expectedDates[i], timeline[i].Date)
+			}
+		})
+	}
+}
+
+func TestBuildTimeline_WhenThereAreTooManyBuckets_ShouldReturnOnlyTheLatestOnes(t *testing.T) {
+	// given
+	_uut := processor.NewProgressTimelineProcessor(nil)
+
+	from := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// when
+	timeline := _uut.BuildTimeline([]model.SongSection{}, enums.DayInterval, &from, &to, enums.ClassicScoring)
+
+	// then
+	assert.Len(t, timeline, 366)
+	assert.Equal(t, to, timeline[len(timeline)-1].Date)
+}
+
+func TestBuildTimeline_WhenRangeStartsFarAway_ShouldClampItToTheLatestBuckets(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval enums.TimelineInterval
+		expected time.Time
+	}{
+		{"Day", enums.DayInterval, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"Week", enums.WeekInterval, time.Date(2017, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{"Month", enums.MonthInterval, time.Date(1993, time.August, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := processor.NewProgressTimelineProcessor(nil)
+
+			from := time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			to := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+			// when
+			timeline := _uut.BuildTimeline([]model.SongSection{}, tt.interval, &from, &to, enums.ClassicScoring)
+
+			// then
+			assert.Len(t, timeline, 366)
+			assert.Equal(t, tt.expected, timeline[0].Date)
+		})
+	}
+}
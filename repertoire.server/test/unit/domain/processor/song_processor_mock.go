@@ -5,6 +5,7 @@ import (
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,7 +16,7 @@ type SongProcessorMock struct {
 func (s *SongProcessorMock) AddPerfectRehearsal(
 	song *model.Song,
 	songSectionRepository repository.SongSectionRepository,
-) (*wrapper.ErrorCode, bool) {
+) (*wrapper.ErrorCode, *uuid.UUID) {
 	args := s.Called(song, songSectionRepository)
 
 	var errCode *wrapper.ErrorCode
@@ -23,5 +24,10 @@ func (s *SongProcessorMock) AddPerfectRehearsal(
 		errCode = e.(*wrapper.ErrorCode)
 	}
 
-	return errCode, args.Bool(1)
+	var batchID *uuid.UUID
+	if b := args.Get(1); b != nil {
+		batchID = b.(*uuid.UUID)
+	}
+
+	return errCode, batchID
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/domain/processor"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 	"repertoire/server/test/unit/data/repository"
 	"slices"
@@ -15,48 +16,120 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func TestAddPerfectRehearsal_WhenCreateHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestAddPerfectRehearsal_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
+	userRepository := new(repository.UserRepositoryMock)
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := processor.NewSongProcessor(nil)
+	_uut := processor.NewSongProcessor(nil, userRepository)
+
+	mockSong := &model.Song{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), Occurrences: 2}},
+	}
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(internalError).Once()
+
+	// when
+	errCode, batchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+
+	// then
+	assert.Nil(t, batchID)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+}
+
+func TestAddPerfectRehearsal_WhenCreateRehearsalBatchFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	_uut := processor.NewSongProcessor(nil, userRepository)
 
 	mockSong := &model.Song{
 		ID:       uuid.New(),
 		Sections: []model.SongSection{{ID: uuid.New(), Occurrences: 2}},
 	}
-	sectionsCount := len(mockSong.Sections)
+
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{}).Once()
 
 	internalError := errors.New("internal error")
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	songSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(internalError).
-		Times(sectionsCount)
+		Once()
 
 	// when
-	errCode, updated := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+	errCode, batchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
 
 	// then
-	assert.False(t, updated)
+	assert.Nil(t, batchID)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	userRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+}
+
+func TestAddPerfectRehearsal_WhenCreateHistoriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	_uut := processor.NewSongProcessor(nil, userRepository)
+
+	mockSong := &model.Song{
+		ID:       uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), Occurrences: 2}},
+	}
+
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{}).Once()
+
+	songSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	songSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode, batchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+
+	// then
+	assert.Nil(t, batchID)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 }
 
 func TestAddPerfectRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
+	userRepository := new(repository.UserRepositoryMock)
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := processor.NewSongProcessor(nil)
+	_uut := processor.NewSongProcessor(nil, userRepository)
 
 	mockSong := &model.Song{
 		ID:       uuid.New(),
 		Sections: []model.SongSection{{ID: uuid.New(), Occurrences: 2}},
 	}
+
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{}).Once()
 	sectionsCount := len(mockSong.Sections)
 
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	songSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(nil).
-		Times(sectionsCount)
+		Once()
+	songSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
 
 	internalError := errors.New("internal error")
 	songSectionRepository.
@@ -70,14 +143,15 @@ func TestAddPerfectRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerError
 		Times(sectionsCount)
 
 	// when
-	errCode, updated := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+	errCode, batchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
 
 	// then
-	assert.False(t, updated)
+	assert.Nil(t, batchID)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	userRepository.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 }
 
@@ -85,7 +159,8 @@ func TestAddPerfectRehearsal_WhenSectionsHaveZeroOccurrences_ShouldNotUpdateTheS
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	progressProcessor := new(ProgressProcessorMock)
-	_uut := processor.NewSongProcessor(progressProcessor)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := processor.NewSongProcessor(progressProcessor, userRepository)
 
 	mockSong := &model.Song{
 		Sections: []model.SongSection{
@@ -99,13 +174,16 @@ func TestAddPerfectRehearsal_WhenSectionsHaveZeroOccurrences_ShouldNotUpdateTheS
 		},
 	}
 
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{}).Once()
+
 	// when
-	errCode, updated := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+	errCode, batchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
 
 	// then
 	assert.Nil(t, errCode)
-	assert.False(t, updated)
+	assert.Nil(t, batchID)
 
+	userRepository.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 }
@@ -114,7 +192,8 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	progressProcessor := new(ProgressProcessorMock)
-	_uut := processor.NewSongProcessor(progressProcessor)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := processor.NewSongProcessor(progressProcessor, userRepository)
 
 	mockSong := &model.Song{
 		Sections: []model.SongSection{
@@ -134,6 +213,8 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 		},
 	}
 
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ExponentialScoring}).Once()
+
 	oldSections := slices.Clone(mockSong.Sections)
 	sectionsCount := len(mockSong.Sections)
 
@@ -141,22 +222,38 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 		return section.Occurrences == 0
 	}))
 
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	var batchID uuid.UUID
+	songSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Run(func(args mock.Arguments) {
-			newHistory := args.Get(0).(*model.SongSectionHistory)
-			assert.NotEmpty(t, newHistory.ID)
-			assert.Equal(t, model.RehearsalsProperty, newHistory.Property)
-
-			sections := slices.Clone(mockSong.Sections)
-			sections = slices.DeleteFunc(sections, func(section model.SongSection) bool {
-				return section.ID != newHistory.SongSectionID
-			})
+			newBatch := args.Get(0).(*model.RehearsalBatch)
+			assert.NotEmpty(t, newBatch.ID)
+			assert.Equal(t, mockSong.ID, newBatch.SongID)
+			assert.Equal(t, mockSong.LastTimePlayed, newBatch.PreviousLastTimePlayed)
+			batchID = newBatch.ID
+		}).
+		Return(nil).
+		Once()
 
-			assert.Equal(t, sections[0].Rehearsals, newHistory.From)
-			assert.Equal(t, sections[0].Rehearsals+sections[0].Occurrences, newHistory.To)
+	songSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Run(func(args mock.Arguments) {
+			newHistory := args.Get(0).(*[]model.SongSectionHistory)
+			assert.Len(t, *newHistory, sectionsCountWithOcc)
+			for _, h := range *newHistory {
+				assert.NotEmpty(t, h.ID)
+				assert.Equal(t, &batchID, h.BatchID)
+				assert.Equal(t, model.RehearsalsProperty, h.Property)
+
+				sections := slices.Clone(mockSong.Sections)
+				sections = slices.DeleteFunc(sections, func(section model.SongSection) bool {
+					return section.ID != h.SongSectionID
+				})
+
+				assert.Equal(t, sections[0].Rehearsals, h.From)
+				assert.Equal(t, sections[0].Rehearsals+sections[0].Occurrences, h.To)
+			}
 		}).
 		Return(nil).
-		Times(sectionsCountWithOcc)
+		Once()
 
 	history := &[]model.SongSectionHistory{}
 	songSectionRepository.
@@ -179,12 +276,12 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 		Times(sectionsCountWithOcc)
 
 	var newRehearsalScore uint64 = 23
-	progressProcessor.On("ComputeRehearsalsScore", *history).
+	progressProcessor.On("ComputeRehearsalsScore", *history, enums.ExponentialScoring).
 		Return(newRehearsalScore).
 		Times(sectionsCountWithOcc)
 
 	var newProgress uint64 = 123
-	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{})).
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ExponentialScoring).
 		Run(func(args mock.Arguments) {
 			sec := args.Get(0).(model.SongSection)
 			assert.Contains(t, mockSong.Sections, sec)
@@ -193,11 +290,11 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 		Times(sectionsCountWithOcc)
 
 	// when
-	errCode, updated := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
+	errCode, rehearsalBatchID := _uut.AddPerfectRehearsal(mockSong, songSectionRepository)
 
 	// then
 	assert.Nil(t, errCode)
-	assert.True(t, updated)
+	assert.Equal(t, &batchID, rehearsalBatchID)
 
 	var newSongRehearsals uint = 0
 	var newSongProgress uint64 = 0
@@ -216,6 +313,7 @@ func TestAddPerfectRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testi
 	assert.Equal(t, float64(newSongRehearsals)/float64(sectionsCount), mockSong.Rehearsals)
 	assert.WithinDuration(t, time.Now(), *mockSong.LastTimePlayed, 1*time.Minute)
 
+	userRepository.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 }
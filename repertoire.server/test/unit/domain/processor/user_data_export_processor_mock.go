@@ -0,0 +1,24 @@
+package processor
+
+import (
+	"io"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UserDataExportProcessorMock struct {
+	mock.Mock
+}
+
+func (u *UserDataExportProcessorMock) Export(writer io.Writer, user model.User) *wrapper.ErrorCode {
+	args := u.Called(writer, user)
+
+	var errCode *wrapper.ErrorCode
+	if a := args.Get(0); a != nil {
+		errCode = a.(*wrapper.ErrorCode)
+	}
+
+	return errCode
+}
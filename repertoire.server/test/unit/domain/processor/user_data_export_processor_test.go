@@ -0,0 +1,242 @@
+package processor
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"repertoire/server/domain/processor"
+	"repertoire/server/internal"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_WhenGetImageFails_ShouldReturnError(t *testing.T) {
+	// given
+	storageService := new(service.StorageServiceMock)
+	_uut := processor.NewUserDataExportProcessor(storageService)
+
+	userID := uuid.New()
+	user := model.User{
+		ID: userID,
+		Artists: []model.Artist{
+			{ID: uuid.New(), ImageURL: &[]internal.FilePath{internal.FilePath(userID.String() + "/artists/image.png")}[0]},
+		},
+	}
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	storageService.On("Get", *user.Artists[0].ImageURL).Return(nil, internalError).Once()
+
+	// when
+	errCode := _uut.Export(new(bytes.Buffer), user)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	storageService.AssertExpectations(t)
+}
+
+func TestExport_WhenImageIsNotFound_ShouldLeaveItOutOfTheArchive(t *testing.T) {
+	// given
+	storageService := new(service.StorageServiceMock)
+	_uut := processor.NewUserDataExportProcessor(storageService)
+
+	userID := uuid.New()
+	user := model.User{
+		ID: userID,
+		Songs: []model.Song{
+			{ID: uuid.New(), ImageURL: &[]internal.FilePath{internal.FilePath(userID.String() + "/songs/image.png")}[0]},
+		},
+	}
+
+	notFoundError := wrapper.NotFoundError(errors.New("not found"))
+	storageService.On("Get", *user.Songs[0].ImageURL).Return(nil, notFoundError).Once()
+
+	// when
+	archive := new(bytes.Buffer)
+	errCode := _uut.Export(archive, user)
+
+	// then
+	assert.Nil(t, errCode)
+
+	files := readArchive(t, archive)
+	assert.Len(t, files, 11)
+
+	var songs []model.ExportedSong
+	_ = json.Unmarshal(files["songs.json"], &songs)
+	assert.Len(t, songs, 1)
+	assert.Nil(t, songs[0].Image)
+
+	storageService.AssertExpectations(t)
+}
+
+func TestExport_WhenSuccessful_ShouldWriteTheDataAndTheImagesInTheArchive(t *testing.T) {
+	// given
+	storageService := new(service.StorageServiceMock)
+	_uut := processor.NewUserDataExportProcessor(storageService)
+
+	userID := uuid.New()
+	role := model.BandMemberRole{ID: uuid.New(), Name: "Vocalist", Order: 0}
+	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	user := model.User{
+		ID:                userID,
+		Name:              "John Doe",
+		Email:             "johndoe@gmail.com",
+		ProfilePictureURL: &[]internal.FilePath{internal.FilePath(userID.String() + "/profile.png")}[0],
+		Artists: []model.Artist{
+			{
+				ID:     uuid.New(),
+				Name:   "Some Band",
+				IsBand: true,
+				BandMembers: []model.BandMember{
+					{
+						ID:       uuid.New(),
+						Name:     "Member",
+						ImageURL: &[]internal.FilePath{internal.FilePath(userID.String() + "/artists/members/member.png")}[0],
+						Roles:    []model.BandMemberRole{role},
+					},
+				},
+			},
+		},
+		Albums: []model.Album{{ID: uuid.New(), Title: "Some Album"}},
+		Songs: []model.Song{
+			{
+				ID:    songIDs[0],
+				Title: "Song 1",
+				Sections: []model.SongSection{
+					{
+						ID:   uuid.New(),
+						Name: "Chorus",
+						History: []model.SongSectionHistory{
+							{Property: model.RehearsalsProperty, From: 0, To: 1},
+						},
+					},
+				},
+			},
+			{ID: songIDs[1], Title: "Song 2"},
+		},
+		Playlists: []model.Playlist{
+			{
+				ID:    uuid.New(),
+				Title: "Some Playlist",
+				PlaylistSongs: []model.PlaylistSong{
+					{SongID: songIDs[1], SongTrackNo: 1},
+					{SongID: songIDs[0], SongTrackNo: 2},
+				},
+			},
+		},
+		PracticeSessions: []model.PracticeSession{
+			{
+				ID: uuid.New(),
+				Songs: []model.PracticeSessionSong{
+					{
+						SongID:   songIDs[0],
+						Sections: []model.PracticeSessionSection{{SongSectionID: uuid.New(), Occurrences: 2}},
+					},
+				},
+			},
+		},
+		Setlists: []model.Setlist{
+			{
+				ID:    uuid.New(),
+				Title: "Some Setlist",
+				Entries: []model.SetlistEntry{
+					{Type: enums.SongEntry, SongID: &songIDs[1], EntryNo: 1},
+					{Type: enums.TalkEntry, Title: "Intro", EntryNo: 2},
+				},
+			},
+		},
+		BandMemberRoles:  []model.BandMemberRole{role},
+		GuitarTunings:    []model.GuitarTuning{{ID: uuid.New(), Name: "Drop D"}},
+		Instruments:      []model.Instrument{{ID: uuid.New(), Name: "Piano"}},
+		SongSectionTypes: []model.SongSectionType{{ID: uuid.New(), Name: "Chorus"}},
+	}
+
+	profilePicture := []byte("profile picture")
+	memberImage := []byte("member image")
+	storageService.On("Get", *user.ProfilePictureURL).Return(profilePicture, nil).Once()
+	storageService.On("Get", *user.Artists[0].BandMembers[0].ImageURL).Return(memberImage, nil).Once()
+
+	// when
+	archive := new(bytes.Buffer)
+	errCode := _uut.Export(archive, user)
+
+	// then
+	assert.Nil(t, errCode)
+
+	files := readArchive(t, archive)
+	assert.Len(t, files, 13)
+	assert.Equal(t, profilePicture, files["images/profile.png"])
+	assert.Equal(t, memberImage, files["images/artists/members/member.png"])
+
+	var exportedUser model.ExportedUser
+	_ = json.Unmarshal(files["user.json"], &exportedUser)
+	assert.Equal(t, user.ID, exportedUser.ID)
+	assert.Equal(t, user.Email, exportedUser.Email)
+	assert.Equal(t, "images/profile.png", *exportedUser.ProfilePicture)
+
+	var artists []model.ExportedArtist
+	_ = json.Unmarshal(files["artists.json"], &artists)
+	assert.Len(t, artists, 1)
+	assert.Len(t, artists[0].BandMembers, 1)
+	assert.Equal(t, []uuid.UUID{role.ID}, artists[0].BandMembers[0].RoleIDs)
+	assert.Equal(t, "images/artists/members/member.png", *artists[0].BandMembers[0].Image)
+
+	var songs []model.ExportedSong
+	_ = json.Unmarshal(files["songs.json"], &songs)
+	assert.Len(t, songs, 2)
+	assert.Len(t, songs[0].Sections, 1)
+	assert.Len(t, songs[0].Sections[0].History, 1)
+
+	var playlists []model.ExportedPlaylist
+	_ = json.Unmarshal(files["playlists.json"], &playlists)
+	assert.Len(t, playlists, 1)
+	assert.Equal(t, []uuid.UUID{songIDs[1], songIDs[0]}, playlists[0].SongIDs)
+
+	var sessions []model.ExportedPracticeSession
+	_ = json.Unmarshal(files["practice_sessions.json"], &sessions)
+	assert.Len(t, sessions, 1)
+	assert.Len(t, sessions[0].Songs, 1)
+	assert.Equal(t, songIDs[0], sessions[0].Songs[0].SongID)
+	assert.Equal(t, user.PracticeSessions[0].Songs[0].Sections[0].SongSectionID, sessions[0].Songs[0].Sections[0].SongSectionID)
+	assert.Equal(t, uint(2), sessions[0].Songs[0].Sections[0].Occurrences)
+
+	var setlists []model.ExportedSetlist
+	_ = json.Unmarshal(files["setlists.json"], &setlists)
+	assert.Len(t, setlists, 1)
+	assert.Len(t, setlists[0].Entries, 2)
+	assert.Equal(t, &songIDs[1], setlists[0].Entries[0].SongID)
+	assert.Equal(t, enums.TalkEntry, setlists[0].Entries[1].Type)
+
+	for _, name := range []string{
+		"albums.json", "band_member_roles.json", "guitar_tunings.json", "instruments.json", "section_types.json",
+	} {
+		var items []map[string]any
+		_ = json.Unmarshal(files[name], &items)
+		assert.Len(t, items, 1, name)
+	}
+
+	storageService.AssertExpectations(t)
+}
+
+func readArchive(t *testing.T, archive *bytes.Buffer) map[string][]byte {
+	reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	assert.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		f, _ := file.Open()
+		content, _ := io.ReadAll(f)
+		_ = f.Close()
+		files[file.Name] = content
+	}
+	return files
+}
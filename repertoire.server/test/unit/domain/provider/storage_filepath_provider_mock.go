@@ -1,7 +1,7 @@
 package provider
 
 import (
-	"mime/multipart"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
 
 	"github.com/google/uuid"
@@ -13,33 +13,38 @@ type StorageFilePathProviderMock struct {
 	mock.Mock
 }
 
-func (s *StorageFilePathProviderMock) GetUserProfilePicturePath(file *multipart.FileHeader, user model.User) string {
-	args := s.Called(file, user)
+func (s *StorageFilePathProviderMock) GetUserProfilePicturePath(user model.User) string {
+	args := s.Called(user)
 	return args.String(0)
 }
 
-func (s *StorageFilePathProviderMock) GetAlbumImagePath(file *multipart.FileHeader, album model.Album) string {
-	args := s.Called(file, album)
+func (s *StorageFilePathProviderMock) GetAlbumImagePath(album model.Album) string {
+	args := s.Called(album)
 	return args.String(0)
 }
 
-func (s *StorageFilePathProviderMock) GetArtistImagePath(file *multipart.FileHeader, artist model.Artist) string {
-	args := s.Called(file, artist)
+func (s *StorageFilePathProviderMock) GetArtistImagePath(artist model.Artist) string {
+	args := s.Called(artist)
 	return args.String(0)
 }
 
-func (s *StorageFilePathProviderMock) GetBandMemberImagePath(file *multipart.FileHeader, artist model.BandMember) string {
-	args := s.Called(file, artist)
+func (s *StorageFilePathProviderMock) GetBandMemberImagePath(artist model.BandMember) string {
+	args := s.Called(artist)
 	return args.String(0)
 }
 
-func (s *StorageFilePathProviderMock) GetPlaylistImagePath(file *multipart.FileHeader, playlist model.Playlist) string {
-	args := s.Called(file, playlist)
+func (s *StorageFilePathProviderMock) GetPlaylistImagePath(playlist model.Playlist) string {
+	args := s.Called(playlist)
 	return args.String(0)
 }
 
-func (s *StorageFilePathProviderMock) GetSongImagePath(file *multipart.FileHeader, song model.Song) string {
-	args := s.Called(file, song)
+func (s *StorageFilePathProviderMock) GetSongImagePath(song model.Song) string {
+	args := s.Called(song)
+	return args.String(0)
+}
+
+func (s *StorageFilePathProviderMock) GetUserDataExportPath(userID uuid.UUID) string {
+	args := s.Called(userID)
 	return args.String(0)
 }
 
@@ -68,6 +73,11 @@ func (s *StorageFilePathProviderMock) GetSongDirectoryPath(song model.Song) stri
 	return args.String(0)
 }
 
+func (s *StorageFilePathProviderMock) GetStorageUsageCategory(filePath string) enums.StorageUsageCategory {
+	args := s.Called(filePath)
+	return args.Get(0).(enums.StorageUsageCategory)
+}
+
 func (s *StorageFilePathProviderMock) HasAlbumFiles(album model.Album) bool {
 	args := s.Called(album)
 	return args.Bool(0)
@@ -1,9 +1,10 @@
 package provider
 
 import (
-	"mime/multipart"
 	"repertoire/server/domain/provider"
+	"repertoire/server/internal/enums"
 	"repertoire/server/model"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,12 +17,10 @@ func TestStorageFilePathProvider_GetUserProfilePicturePath_ShouldReturnPlaylistI
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	user := model.User{ID: uuid.New(), UpdatedAt: time.Now()}
 
 	// when
-	imagePath := _uut.GetUserProfilePicturePath(file, user)
+	imagePath := _uut.GetUserProfilePicturePath(user)
 
 	// then
 	expectedImagePath := user.ID.String() + "/" +
@@ -35,8 +34,6 @@ func TestStorageFilePathProvider_GetAlbumImagePath_ShouldReturnAlbumImagePath(t
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	album := model.Album{
 		ID:        uuid.New(),
 		UserID:    uuid.New(),
@@ -44,7 +41,7 @@ func TestStorageFilePathProvider_GetAlbumImagePath_ShouldReturnAlbumImagePath(t
 	}
 
 	// when
-	imagePath := _uut.GetAlbumImagePath(file, album)
+	imagePath := _uut.GetAlbumImagePath(album)
 
 	// then
 	expectedImagePath := album.UserID.String() + "/albums/" + album.ID.String() + "/" +
@@ -58,8 +55,6 @@ func TestStorageFilePathProvider_GetArtistImagePath_ShouldReturnArtistImagePath(
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	artist := model.Artist{
 		ID:        uuid.New(),
 		UserID:    uuid.New(),
@@ -67,7 +62,7 @@ func TestStorageFilePathProvider_GetArtistImagePath_ShouldReturnArtistImagePath(
 	}
 
 	// when
-	imagePath := _uut.GetArtistImagePath(file, artist)
+	imagePath := _uut.GetArtistImagePath(artist)
 
 	// then
 	expectedImagePath := artist.UserID.String() + "/artists/" + artist.ID.String() + "/" +
@@ -81,8 +76,6 @@ func TestStorageFilePathProvider_GetBandMemberImagePath_ShouldReturnBandMemberIm
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	artist := model.Artist{
 		ID:     uuid.New(),
 		UserID: uuid.New(),
@@ -94,7 +87,7 @@ func TestStorageFilePathProvider_GetBandMemberImagePath_ShouldReturnBandMemberIm
 	}
 
 	// when
-	imagePath := _uut.GetBandMemberImagePath(file, bandMember)
+	imagePath := _uut.GetBandMemberImagePath(bandMember)
 
 	// then
 	expectedImagePath := artist.UserID.String() + "/artists/" + artist.ID.String() +
@@ -109,8 +102,6 @@ func TestStorageFilePathProvider_GetPlaylistImagePath_ShouldReturnPlaylistImageP
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	playlist := model.Playlist{
 		ID:        uuid.New(),
 		UserID:    uuid.New(),
@@ -118,7 +109,7 @@ func TestStorageFilePathProvider_GetPlaylistImagePath_ShouldReturnPlaylistImageP
 	}
 
 	// when
-	imagePath := _uut.GetPlaylistImagePath(file, playlist)
+	imagePath := _uut.GetPlaylistImagePath(playlist)
 
 	// then
 	expectedImagePath := playlist.UserID.String() + "/playlists/" + playlist.ID.String() + "/" +
@@ -132,8 +123,6 @@ func TestStorageFilePathProvider_GetSongImagePath_ShouldReturnSongImagePath(t *t
 	_uut := provider.NewStorageFilePathProvider()
 
 	fileExtension := ".jpg"
-	file := new(multipart.FileHeader)
-	file.Filename = "something" + fileExtension
 	song := model.Song{
 		ID:        uuid.New(),
 		UserID:    uuid.New(),
@@ -141,7 +130,7 @@ func TestStorageFilePathProvider_GetSongImagePath_ShouldReturnSongImagePath(t *t
 	}
 
 	// when
-	imagePath := _uut.GetSongImagePath(file, song)
+	imagePath := _uut.GetSongImagePath(song)
 
 	// then
 	expectedImagePath := song.UserID.String() + "/songs/" + song.ID.String() + "/" +
@@ -150,6 +139,20 @@ func TestStorageFilePathProvider_GetSongImagePath_ShouldReturnSongImagePath(t *t
 	assert.Equal(t, expectedImagePath, imagePath)
 }
 
+func TestStorageFilePathProvider_GetUserDataExportPath_ShouldReturnUserDataExportPath(t *testing.T) {
+	// given
+	_uut := provider.NewStorageFilePathProvider()
+
+	userID := uuid.New()
+
+	// when
+	exportPath := _uut.GetUserDataExportPath(userID)
+
+	// then
+	assert.True(t, strings.HasPrefix(exportPath, userID.String()+"/exports/"))
+	assert.True(t, strings.HasSuffix(exportPath, ".zip"))
+}
+
 func TestStorageFilePathProvider_GetUserDirectoryPath_ShouldReturnUserDirectoryPath(t *testing.T) {
 	// given
 	_uut := provider.NewStorageFilePathProvider()
@@ -236,3 +239,35 @@ func TestStorageFilePathProvider_GetSongDirectoryPath_ShouldReturnAlbumDirectory
 
 	assert.Equal(t, expectedDirectoryPath, directoryPath)
 }
+
+func TestStorageFilePathProvider_GetStorageUsageCategory_ShouldReturnWhatTheFileBelongsTo(t *testing.T) {
+	userID := uuid.New().String()
+	id := uuid.New().String()
+	tests := []struct {
+		name     string
+		filePath string
+		expected enums.StorageUsageCategory
+	}{
+		{"Album", userID + "/albums/" + id + "/image.jpg", enums.AlbumStorageUsage},
+		{"Artist", userID + "/artists/" + id + "/image_256.jpg", enums.ArtistStorageUsage},
+		{"Band Member", userID + "/artists/" + id + "/members/" + id + "/image.jpg", enums.BandMemberStorageUsage},
+		{"Playlist", userID + "/playlists/" + id + "/image.jpg", enums.PlaylistStorageUsage},
+		{"Song", "/" + userID + "/songs/" + id + "/image.jpg", enums.SongStorageUsage},
+		{"Profile Picture", userID + "/image.jpg", enums.OtherStorageUsage},
+		{"Export", userID + "/exports/export.zip", enums.OtherStorageUsage},
+		{"Unknown", userID + "/something/" + id + "/file.txt", enums.OtherStorageUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := provider.NewStorageFilePathProvider()
+
+			// when
+			result := _uut.GetStorageUsageCategory(tt.filePath)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
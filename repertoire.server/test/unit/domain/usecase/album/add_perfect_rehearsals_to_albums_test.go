@@ -20,7 +20,7 @@ import (
 func TestAddPerfectAlbumRehearsals_WhenGetAlbumsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, nil, nil)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -45,7 +45,7 @@ func TestAddPerfectAlbumRehearsals_WhenGetAlbumsFails_ShouldReturnInternalServer
 func TestAddPerfectAlbumRehearsals_WhenAlbumsLenIs0_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, nil, nil)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -71,7 +71,7 @@ func TestAddPerfectAlbumRehearsals_WhenTransactionExecuteFails_ShouldReturnError
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, transactionManager)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, nil, transactionManager)
 
 	request := requests.AddPerfectRehearsalsToAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -103,7 +103,7 @@ func TestAddPerfectAlbumRehearsals_WhenProcessorFails_ShouldReturnInternalServer
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, transactionManager)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -127,7 +127,7 @@ func TestAddPerfectAlbumRehearsals_WhenProcessorFails_ShouldReturnInternalServer
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(internalError, false).
+		Return(internalError, nil).
 		Times(len(mockAlbums[0].Songs))
 
 	// when
@@ -150,7 +150,7 @@ func TestAddPerfectAlbumRehearsals_WhenUpdateFails_ShouldReturnInternalServerErr
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, transactionManager)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -173,7 +173,7 @@ func TestAddPerfectAlbumRehearsals_WhenUpdateFails_ShouldReturnInternalServerErr
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 		Times(len(mockAlbums[0].Songs))
 
 	internalError := errors.New("internal error")
@@ -202,7 +202,7 @@ func TestAddPerfectAlbumRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, transactionManager)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -230,7 +230,7 @@ func TestAddPerfectAlbumRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, false).
+		Return(nil, nil).
 		Times(len(mockAlbums[0].Songs))
 
 	// when
@@ -247,16 +247,105 @@ func TestAddPerfectAlbumRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	transactionSongRepository.AssertExpectations(t)
 }
 
+func TestAddPerfectAlbumRehearsals_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	albumRepository := new(repository.AlbumRepositoryMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, practiceSessionProcessor, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectRehearsalsToAlbumsRequest{
+		IDs: []uuid.UUID{
+			uuid.New(),
+			uuid.New(),
+			uuid.New(),
+		},
+	}
+
+	mockAlbums := []model.Album{
+		{
+			ID: request.IDs[0],
+			Songs: []model.Song{
+				{ID: uuid.New()},
+				{ID: uuid.New()},
+			},
+		},
+		{
+			ID: request.IDs[1],
+			Songs: []model.Song{
+				{ID: uuid.New()},
+			},
+		},
+		{ID: request.IDs[2]},
+	}
+	albumRepository.On("GetAllByIDsWithSongSections", new([]model.Album), request.IDs).
+		Return(nil, &mockAlbums).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	var albumSongs []model.Song
+	for _, a := range mockAlbums {
+		for _, s := range a.Songs {
+			songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+				Once()
+			albumSongs = append(albumSongs, s)
+		}
+	}
+
+	transactionSongRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.Len(t, *newSongs, len(albumSongs))
+			assert.ElementsMatch(t, *newSongs, albumSongs)
+		}).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mock.IsType(model.Song{}), mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	albumRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
 func TestAddPerfectAlbumRehearsals_WhenSuccessful_ShouldUpdateAlbums(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, transactionManager)
+	_uut := album.NewAddPerfectRehearsalsToAlbums(albumRepository, songProcessor, practiceSessionProcessor, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPerfectRehearsalsToAlbumsRequest{
 		IDs: []uuid.UUID{
@@ -288,13 +377,14 @@ func TestAddPerfectAlbumRehearsals_WhenSuccessful_ShouldUpdateAlbums(t *testing.
 
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	var albumSongs []model.Song
 	for _, a := range mockAlbums {
 		for _, s := range a.Songs {
 			songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
-				Return(nil, true).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 				Once()
 			albumSongs = append(albumSongs, s)
 		}
@@ -309,6 +399,12 @@ func TestAddPerfectAlbumRehearsals_WhenSuccessful_ShouldUpdateAlbums(t *testing.
 		Return(nil).
 		Once()
 
+	for _, s := range albumSongs {
+		practiceSessionProcessor.On("RecordPerfectRehearsal", s, mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+			Return(nil).
+			Once()
+	}
+
 	// when
 	errCode := _uut.Handle(request)
 
@@ -317,8 +413,10 @@ func TestAddPerfectAlbumRehearsals_WhenSuccessful_ShouldUpdateAlbums(t *testing.
 
 	albumRepository.AssertExpectations(t)
 	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	repositoryFactory.AssertExpectations(t)
 	transactionSongSectionRepository.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
 }
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -21,7 +22,7 @@ import (
 func TestAddSongsToAlbum_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, nil, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, nil, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -48,7 +49,7 @@ func TestAddSongsToAlbum_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerEr
 func TestAddSongsToAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, nil, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, nil, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -75,7 +76,7 @@ func TestAddSongsToAlbum_WhenGetAllSongsFails_ShouldReturnInternalServerError(t
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -108,7 +109,7 @@ func TestAddSongsToAlbum_WhenAlbumAndSongArtistDoesNotMatch_ShouldReturnConflict
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -142,7 +143,7 @@ func TestAddSongsToAlbum_WhenAlbumDoesNotHaveArtistButSongHasArtist_ShouldReturn
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -176,7 +177,7 @@ func TestAddSongsToAlbum_WhenOneSongHasAlbum_ShouldReturnConflictError(t *testin
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil, nil)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -210,7 +211,10 @@ func TestAddSongsToAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -228,6 +232,9 @@ func TestAddSongsToAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateAll", mock.IsType(songs)).
 		Return(internalError).
@@ -241,6 +248,8 @@ func TestAddSongsToAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
@@ -250,7 +259,11 @@ func TestAddSongsToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.AddSongsToAlbumRequest{
 		ID:      uuid.New(),
@@ -268,12 +281,16 @@ func TestAddSongsToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateAll", mock.IsType(songs)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(internalError).
 		Once()
 
@@ -285,6 +302,8 @@ func TestAddSongsToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -352,7 +371,11 @@ func TestAddSongsToAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 			albumRepository := new(repository.AlbumRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := album.NewAddSongsToAlbum(albumRepository, songRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			// given - mocking
 			albumRepository.On("GetWithSongs", mock.IsType(tt.album), tt.request.ID).
@@ -363,6 +386,10 @@ func TestAddSongsToAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil, tt.songs).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			songRepository.On("UpdateAll", mock.IsType(tt.songs)).
 				Run(func(args mock.Arguments) {
 					newSongs := args.Get(0).(*[]model.Song)
@@ -381,7 +408,7 @@ func TestAddSongsToAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.SongsUpdatedTopic, tt.request.SongIDs).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, tt.request.SongIDs).
 				Return(nil).
 				Once()
 
@@ -391,6 +418,8 @@ func TestAddSongsToAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 		})
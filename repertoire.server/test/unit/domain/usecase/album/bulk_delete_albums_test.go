@@ -7,18 +7,20 @@ import (
 	"repertoire/server/domain/usecase/album"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestBulkDeleteAlbums_WhenGetAlbumsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, nil)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, nil, nil)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -43,7 +45,7 @@ func TestBulkDeleteAlbums_WhenGetAlbumsFails_ShouldReturnInternalServerError(t *
 func TestBulkDeleteAlbums_WhenGetAlbumsWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, nil)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, nil, nil)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs:       []uuid.UUID{uuid.New()},
@@ -69,7 +71,7 @@ func TestBulkDeleteAlbums_WhenGetAlbumsWithSongsFails_ShouldReturnInternalServer
 func TestBulkDeleteAlbums_WhenAlbumsAreLen0_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, nil)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, nil, nil)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -93,7 +95,10 @@ func TestBulkDeleteAlbums_WhenAlbumsAreLen0_ShouldReturnNotFoundError(t *testing
 func TestBulkDeleteAlbums_WhenDeleteAlbumsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -104,6 +109,9 @@ func TestBulkDeleteAlbums_WhenDeleteAlbumsFails_ShouldReturnInternalServerError(
 		Return(nil, mockAlbums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Delete", request.IDs).Return(internalError).Once()
 
@@ -115,13 +123,18 @@ func TestBulkDeleteAlbums_WhenDeleteAlbumsFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
 func TestBulkDeleteAlbums_WhenDeleteAlbumsWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs:       []uuid.UUID{uuid.New()},
@@ -133,6 +146,9 @@ func TestBulkDeleteAlbums_WhenDeleteAlbumsWithSongsFails_ShouldReturnInternalSer
 		Return(nil, mockAlbums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("DeleteWithSongs", request.IDs).Return(internalError).Once()
 
@@ -144,6 +160,8 @@ func TestBulkDeleteAlbums_WhenDeleteAlbumsWithSongsFails_ShouldReturnInternalSer
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
@@ -151,7 +169,11 @@ func TestBulkDeleteAlbums_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewBulkDeleteAlbums(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewBulkDeleteAlbums(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.BulkDeleteAlbumsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -162,10 +184,14 @@ func TestBulkDeleteAlbums_WhenPublishFails_ShouldReturnInternalServerError(t *te
 		Return(nil, mockAlbums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Delete", request.IDs).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsDeletedTopic, *mockAlbums).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsDeletedTopic, *mockAlbums).
 		Return(internalError).
 		Once()
 
@@ -177,6 +203,8 @@ func TestBulkDeleteAlbums_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -204,7 +232,11 @@ func TestBulkDeleteAlbums_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 			// given
 			albumRepository := new(repository.AlbumRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := album.NewBulkDeleteAlbums(albumRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := album.NewBulkDeleteAlbums(albumRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			var ids []uuid.UUID
 			for _, a := range tt.albums {
@@ -225,13 +257,17 @@ func TestBulkDeleteAlbums_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 					Once()
 			}
 
+			repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			if tt.withSongs {
 				albumRepository.On("DeleteWithSongs", request.IDs).Return(nil).Once()
 			} else {
 				albumRepository.On("Delete", request.IDs).Return(nil).Once()
 			}
 
-			messagePublisherService.On("Publish", topics.AlbumsDeletedTopic, tt.albums).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsDeletedTopic, tt.albums).
 				Return(nil).
 				Once()
 
@@ -241,6 +277,8 @@ func TestBulkDeleteAlbums_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
 		})
@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -47,7 +48,10 @@ func TestCreateAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testin
 	// given
 	jwtService := new(service.JwtServiceMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewCreateAlbum(jwtService, albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewCreateAlbum(jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreateAlbumRequest{
 		Title: "Some Album",
@@ -56,6 +60,9 @@ func TestCreateAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testin
 	userID := uuid.New()
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Create", mock.IsType(new(model.Album))).
 		Return(internalError).
@@ -70,6 +77,8 @@ func TestCreateAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testin
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
@@ -79,7 +88,11 @@ func TestCreateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	jwtService := new(service.JwtServiceMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewCreateAlbum(jwtService, albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewCreateAlbum(jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreateAlbumRequest{
 		Title:    "Some Album",
@@ -90,12 +103,16 @@ func TestCreateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Create", mock.IsType(new(model.Album))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
 		Return(internalError).
 		Once()
 
@@ -108,6 +125,8 @@ func TestCreateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -145,7 +164,11 @@ func TestCreateAlbum_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			jwtService := new(service.JwtServiceMock)
 			albumRepository := new(repository.AlbumRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := album.NewCreateAlbum(jwtService, albumRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := album.NewCreateAlbum(jwtService, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.CreateAlbumRequest{
 				Title: "Some Album",
@@ -155,6 +178,10 @@ func TestCreateAlbum_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 
 			jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+			repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			var createdAlbum model.Album
 			albumRepository.On("Create", mock.IsType(new(model.Album))).
 				Run(func(args mock.Arguments) {
@@ -165,9 +192,9 @@ func TestCreateAlbum_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
 				Run(func(args mock.Arguments) {
-					assert.Equal(t, createdAlbum, args.Get(1).(model.Album))
+					assert.Equal(t, createdAlbum, args.Get(2).(model.Album))
 				}).
 				Return(nil).
 				Once()
@@ -179,6 +206,8 @@ func TestCreateAlbum_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			assert.Equal(t, createdAlbum.ID, id)
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			jwtService.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
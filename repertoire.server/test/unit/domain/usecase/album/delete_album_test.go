@@ -7,18 +7,20 @@ import (
 	"repertoire/server/domain/usecase/album"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteAlbum(albumRepository, nil)
+	_uut := album.NewDeleteAlbum(albumRepository, nil, nil)
 
 	request := requests.DeleteAlbumRequest{
 		ID: uuid.New(),
@@ -41,7 +43,7 @@ func TestDeleteAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testin
 func TestDeleteAlbum_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteAlbum(albumRepository, nil)
+	_uut := album.NewDeleteAlbum(albumRepository, nil, nil)
 
 	request := requests.DeleteAlbumRequest{
 		ID:        uuid.New(),
@@ -67,7 +69,7 @@ func TestDeleteAlbum_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerError(
 func TestDeleteAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteAlbum(albumRepository, nil)
+	_uut := album.NewDeleteAlbum(albumRepository, nil, nil)
 
 	request := requests.DeleteAlbumRequest{ID: uuid.New()}
 
@@ -87,13 +89,19 @@ func TestDeleteAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 func TestDeleteAlbum_WhenDeleteAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteAlbum(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteAlbum(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.DeleteAlbumRequest{ID: uuid.New()}
 
 	mockAlbum := &model.Album{ID: request.ID}
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Delete", []uuid.UUID{request.ID}).Return(internalError).Once()
 
@@ -105,13 +113,18 @@ func TestDeleteAlbum_WhenDeleteAlbumFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
 func TestDeleteAlbum_WhenDeleteAlbumWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteAlbum(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteAlbum(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.DeleteAlbumRequest{
 		ID:        uuid.New(),
@@ -125,6 +138,9 @@ func TestDeleteAlbum_WhenDeleteAlbumWithSongsFails_ShouldReturnInternalServerErr
 		Return(nil, mockAlbum).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("DeleteWithSongs", []uuid.UUID{request.ID}).Return(internalError).Once()
 
@@ -136,6 +152,8 @@ func TestDeleteAlbum_WhenDeleteAlbumWithSongsFails_ShouldReturnInternalServerErr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
@@ -143,17 +161,25 @@ func TestDeleteAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewDeleteAlbum(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteAlbum(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.DeleteAlbumRequest{ID: uuid.New()}
 
 	mockAlbum := &model.Album{ID: request.ID}
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Delete", []uuid.UUID{request.ID}).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsDeletedTopic, []model.Album{*mockAlbum}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsDeletedTopic, []model.Album{*mockAlbum}).
 		Return(internalError).
 		Once()
 
@@ -165,6 +191,8 @@ func TestDeleteAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -192,7 +220,11 @@ func TestDeleteAlbum_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 			// given
 			albumRepository := new(repository.AlbumRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := album.NewDeleteAlbum(albumRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := album.NewDeleteAlbum(albumRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.DeleteAlbumRequest{
 				ID:        tt.album.ID,
@@ -209,13 +241,17 @@ func TestDeleteAlbum_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 					Once()
 			}
 
+			repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			if tt.withSongs {
 				albumRepository.On("DeleteWithSongs", []uuid.UUID{request.ID}).Return(nil).Once()
 			} else {
 				albumRepository.On("Delete", []uuid.UUID{request.ID}).Return(nil).Once()
 			}
 
-			messagePublisherService.On("Publish", topics.AlbumsDeletedTopic, []model.Album{tt.album}).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsDeletedTopic, []model.Album{tt.album}).
 				Return(nil).
 				Once()
 
@@ -225,6 +261,8 @@ func TestDeleteAlbum_WhenSuccessful_ShouldDeleteAlbum(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
 		})
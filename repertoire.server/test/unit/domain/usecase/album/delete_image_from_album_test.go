@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -20,7 +21,7 @@ import (
 func TestDeleteImageFromAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -42,7 +43,7 @@ func TestDeleteImageFromAlbum_WhenGetAlbumFails_ShouldReturnInternalServerError(
 func TestDeleteImageFromAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -63,7 +64,7 @@ func TestDeleteImageFromAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *test
 func TestDeleteImageFromAlbum_WhenAlbumHasNoImage_ShouldReturnConflictError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -86,7 +87,7 @@ func TestDeleteImageFromAlbum_WhenDeleteImageFails_ShouldReturnInternalServerErr
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, nil)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, nil, nil)
 
 	id := uuid.New()
 
@@ -112,7 +113,10 @@ func TestDeleteImageFromAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerErr
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -122,6 +126,9 @@ func TestDeleteImageFromAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerErr
 
 	storageService.On("DeleteFile", *mockAlbum.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Return(internalError).
@@ -135,6 +142,8 @@ func TestDeleteImageFromAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerErr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
@@ -144,7 +153,11 @@ func TestDeleteImageFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -154,12 +167,16 @@ func TestDeleteImageFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 
 	storageService.On("DeleteFile", *mockAlbum.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(internalError).
 		Once()
 
@@ -171,6 +188,8 @@ func TestDeleteImageFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -181,7 +200,11 @@ func TestDeleteImageFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewDeleteImageFromAlbum(albumRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -191,6 +214,10 @@ func TestDeleteImageFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 
 	storageService.On("DeleteFile", *mockAlbum.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Run(func(args mock.Arguments) {
 			newAlbum := args.Get(0).(*model.Album)
@@ -199,7 +226,7 @@ func TestDeleteImageFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(nil).
 		Once()
 
@@ -209,6 +236,8 @@ func TestDeleteImageFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
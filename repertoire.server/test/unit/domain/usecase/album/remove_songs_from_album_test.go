@@ -245,6 +245,7 @@ func TestRemoveSongsFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionAlbumRepository := new(repository.AlbumRepositoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveSongsFromAlbumRequest{
 		ID:      uuid.New(),
@@ -263,6 +264,7 @@ func TestRemoveSongsFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 		Once()
 
 	repositoryFactory.On("NewAlbumRepository").Return(transactionAlbumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	transactionAlbumRepository.On("RemoveSongs", mock.IsType(mockAlbum), mock.IsType(&mockAlbum.Songs)).
@@ -274,7 +276,7 @@ func TestRemoveSongsFromAlbum_WhenPublishFails_ShouldReturnInternalServerError(t
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(internalError).
 		Once()
 
@@ -301,6 +303,7 @@ func TestRemoveSongsFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionAlbumRepository := new(repository.AlbumRepositoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveSongsFromAlbumRequest{
 		ID:      uuid.New(),
@@ -322,6 +325,7 @@ func TestRemoveSongsFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 		Once()
 
 	repositoryFactory.On("NewAlbumRepository").Return(transactionAlbumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	transactionAlbumRepository.On("RemoveSongs", mock.IsType(mockAlbum), mock.IsType(&mockAlbum.Songs)).
@@ -350,7 +354,7 @@ func TestRemoveSongsFromAlbum_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(nil).
 		Once()
 
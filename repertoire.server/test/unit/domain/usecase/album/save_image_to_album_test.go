@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/provider"
@@ -23,7 +24,7 @@ import (
 func TestSaveImageToAlbum_WhenGetAlbumFails_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewSaveImageToAlbum(albumRepository, nil, nil, nil)
+	_uut := album.NewSaveImageToAlbum(albumRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -46,7 +47,7 @@ func TestSaveImageToAlbum_WhenGetAlbumFails_ShouldReturnNotFoundError(t *testing
 func TestSaveImageToAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewSaveImageToAlbum(albumRepository, nil, nil, nil)
+	_uut := album.NewSaveImageToAlbum(albumRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -69,7 +70,7 @@ func TestSaveImageToAlbum_WhenStorageDeleteFileFails_ShouldReturnError(t *testin
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := album.NewSaveImageToAlbum(albumRepository, nil, storageService, nil)
+	_uut := album.NewSaveImageToAlbum(albumRepository, nil, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -92,12 +93,12 @@ func TestSaveImageToAlbum_WhenStorageDeleteFileFails_ShouldReturnError(t *testin
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveImageToAlbum_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveImageToAlbum_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := album.NewSaveImageToAlbum(albumRepository, storageFilePathProvider, storageService, nil)
+	_uut := album.NewSaveImageToAlbum(albumRepository, storageFilePathProvider, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -107,11 +108,11 @@ func TestSaveImageToAlbum_WhenStorageUploadFails_ShouldReturnInternalServerError
 	albumRepository.On("Get", new(model.Album), id).Return(nil, mockAlbum).Once()
 
 	imagePath := "albums file path"
-	storageFilePathProvider.On("GetAlbumImagePath", file, mock.IsType(*mockAlbum)).
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(*mockAlbum)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -119,8 +120,7 @@ func TestSaveImageToAlbum_WhenStorageUploadFails_ShouldReturnInternalServerError
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	albumRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
@@ -132,7 +132,10 @@ func TestSaveImageToAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t
 	albumRepository := new(repository.AlbumRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := album.NewSaveImageToAlbum(albumRepository, storageFilePathProvider, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewSaveImageToAlbum(albumRepository, storageFilePathProvider, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -142,12 +145,15 @@ func TestSaveImageToAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t
 	albumRepository.On("Get", new(model.Album), id).Return(nil, mockAlbum).Once()
 
 	imagePath := "albums file path"
-	storageFilePathProvider.On("GetAlbumImagePath", file, mock.IsType(*mockAlbum)).
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(*mockAlbum)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Update", mock.IsType(new(model.Album))).
 		Return(internalError).
@@ -161,6 +167,8 @@ func TestSaveImageToAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -172,13 +180,18 @@ func TestSaveImageToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := album.NewSaveImageToAlbum(
 		albumRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -187,18 +200,22 @@ func TestSaveImageToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	albumRepository.On("Get", new(model.Album), id).Return(nil, mockAlbum).Once()
 
 	imagePath := "albums file path"
-	storageFilePathProvider.On("GetAlbumImagePath", file, mock.IsType(*mockAlbum)).
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(*mockAlbum)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(new(model.Album))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(internalError).
 		Once()
 
@@ -210,6 +227,8 @@ func TestSaveImageToAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -222,13 +241,18 @@ func TestSaveImageToAlbum_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErr
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := album.NewSaveImageToAlbum(
 		albumRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -237,9 +261,9 @@ func TestSaveImageToAlbum_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErr
 	albumRepository.On("Get", new(model.Album), id).Return(nil, mockAlbum).Once()
 
 	imagePath := "albums file path"
-	storageFilePathProvider.On("GetAlbumImagePath", file, mock.IsType(*mockAlbum)).
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(*mockAlbum)).
 		Run(func(args mock.Arguments) {
-			newAlbum := args.Get(1).(model.Album)
+			newAlbum := args.Get(0).(model.Album)
 			assert.Equal(t, newAlbum.ID, mockAlbum.ID)
 			assert.WithinDuration(t, time.Now(), newAlbum.UpdatedAt, time.Minute)
 		}).
@@ -248,6 +272,10 @@ func TestSaveImageToAlbum_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErr
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(new(model.Album))).
 		Run(func(args mock.Arguments) {
 			newAlbum := args.Get(0).(*model.Album)
@@ -256,7 +284,7 @@ func TestSaveImageToAlbum_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErr
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(nil).
 		Once()
 
@@ -266,6 +294,8 @@ func TestSaveImageToAlbum_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErr
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -278,13 +308,18 @@ func TestSaveImageToAlbum_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotR
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := album.NewSaveImageToAlbum(
 		albumRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -295,9 +330,9 @@ func TestSaveImageToAlbum_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotR
 	storageService.On("DeleteFile", *mockAlbum.ImageURL).Return(nil).Once()
 
 	imagePath := "albums file path"
-	storageFilePathProvider.On("GetAlbumImagePath", file, mock.IsType(*mockAlbum)).
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(*mockAlbum)).
 		Run(func(args mock.Arguments) {
-			newAlbum := args.Get(1).(model.Album)
+			newAlbum := args.Get(0).(model.Album)
 			assert.Equal(t, newAlbum.ID, mockAlbum.ID)
 			assert.WithinDuration(t, time.Now(), newAlbum.UpdatedAt, time.Minute)
 		}).
@@ -306,6 +341,10 @@ func TestSaveImageToAlbum_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotR
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Run(func(args mock.Arguments) {
 			newAlbum := args.Get(0).(*model.Album)
@@ -314,7 +353,7 @@ func TestSaveImageToAlbum_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotR
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(nil).
 		Once()
 
@@ -324,6 +363,8 @@ func TestSaveImageToAlbum_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotR
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -68,7 +69,10 @@ func TestUpdateAlbum_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 func TestUpdateAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := album.NewUpdateAlbum(albumRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewUpdateAlbum(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateAlbumRequest{
 		ID:    uuid.New(),
@@ -82,6 +86,9 @@ func TestUpdateAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t *tes
 
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Return(internalError).
@@ -94,6 +101,8 @@ func TestUpdateAlbum_WhenUpdateAlbumFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
@@ -101,7 +110,10 @@ func TestUpdateAlbum_WhenGetSongsFails_ShouldReturnInternalServerError(t *testin
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewUpdateAlbum(albumRepository, songRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewUpdateAlbum(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateAlbumRequest{
 		ID:       uuid.New(),
@@ -115,6 +127,10 @@ func TestUpdateAlbum_WhenGetSongsFails_ShouldReturnInternalServerError(t *testin
 	}
 
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).Return(nil).Once()
 
 	internalError := errors.New("internal error")
@@ -129,6 +145,8 @@ func TestUpdateAlbum_WhenGetSongsFails_ShouldReturnInternalServerError(t *testin
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
@@ -137,7 +155,10 @@ func TestUpdateAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t *tes
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := album.NewUpdateAlbum(albumRepository, songRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewUpdateAlbum(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateAlbumRequest{
 		ID:       uuid.New(),
@@ -151,6 +172,10 @@ func TestUpdateAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t *tes
 	}
 
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).Return(nil).Once()
 
 	songs := []model.Song{{ID: uuid.New()}}
@@ -171,6 +196,8 @@ func TestUpdateAlbum_WhenUpdateSongsFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
@@ -179,7 +206,11 @@ func TestUpdateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewUpdateAlbum(albumRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewUpdateAlbum(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateAlbumRequest{
 		ID:    uuid.New(),
@@ -192,10 +223,14 @@ func TestUpdateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	}
 
 	albumRepository.On("Get", new(model.Album), request.ID).Return(nil, mockAlbum).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(internalError).
 		Once()
 
@@ -206,6 +241,8 @@ func TestUpdateAlbum_WhenPublishFails_ShouldReturnInternalServerError(t *testing
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -214,7 +251,11 @@ func TestUpdateAlbum_WhenArtistHasNotChanged_ShouldUpdateOnlyAlbumAndNotReturnAn
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := album.NewUpdateAlbum(albumRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := album.NewUpdateAlbum(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateAlbumRequest{
 		ID:          uuid.New(),
@@ -231,6 +272,10 @@ func TestUpdateAlbum_WhenArtistHasNotChanged_ShouldUpdateOnlyAlbumAndNotReturnAn
 		Return(nil, mockAlbum).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("Update", mock.IsType(mockAlbum)).
 		Run(func(args mock.Arguments) {
 			newAlbum := args.Get(0).(*model.Album)
@@ -239,7 +284,7 @@ func TestUpdateAlbum_WhenArtistHasNotChanged_ShouldUpdateOnlyAlbumAndNotReturnAn
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{mockAlbum.ID}).
 		Return(nil).
 		Once()
 
@@ -249,6 +294,8 @@ func TestUpdateAlbum_WhenArtistHasNotChanged_ShouldUpdateOnlyAlbumAndNotReturnAn
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -306,11 +353,20 @@ func TestUpdateAlbum_WhenArtistHasChanged_ShouldUpdateAlbumAndSongsAndNotReturnA
 			albumRepository := new(repository.AlbumRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := album.NewUpdateAlbum(albumRepository, songRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := album.NewUpdateAlbum(albumRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			albumRepository.On("Get", new(model.Album), tt.request.ID).
 				Return(nil, &tt.album).
 				Once()
+			repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			albumRepository.On("Update", mock.IsType(&tt.album)).
 				Run(func(args mock.Arguments) {
 					newAlbum := args.Get(0).(*model.Album)
@@ -335,7 +391,7 @@ func TestUpdateAlbum_WhenArtistHasChanged_ShouldUpdateAlbumAndSongsAndNotReturnA
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, []uuid.UUID{tt.request.ID}).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, []uuid.UUID{tt.request.ID}).
 				Return(nil).
 				Once()
 
@@ -345,6 +401,8 @@ func TestUpdateAlbum_WhenArtistHasChanged_ShouldUpdateAlbumAndSongsAndNotReturnA
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestAddAlbumsToArtist_WhenGetAlbumsWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil)
+	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil, nil)
 
 	request := requests.AddAlbumsToArtistRequest{
 		ID:       uuid.New(),
@@ -45,7 +46,7 @@ func TestAddAlbumsToArtist_WhenGetAlbumsWithSongsFails_ShouldReturnInternalServe
 func TestAddAlbumsToArtist_WhenOneAlbumAlreadyHasArtist_ShouldReturnConflictError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil)
+	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil, nil)
 
 	request := requests.AddAlbumsToArtistRequest{
 		ID:       uuid.New(),
@@ -76,7 +77,10 @@ func TestAddAlbumsToArtist_WhenOneAlbumAlreadyHasArtist_ShouldReturnConflictErro
 func TestAddAlbumsToArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddAlbumsToArtist(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.AddAlbumsToArtistRequest{
 		ID:       uuid.New(),
@@ -88,6 +92,9 @@ func TestAddAlbumsToArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalServerEr
 		Return(nil, albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(albums)).
 		Return(internalError).
@@ -101,6 +108,8 @@ func TestAddAlbumsToArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalServerEr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
@@ -108,7 +117,11 @@ func TestAddAlbumsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewAddAlbumsToArtist(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddAlbumsToArtist(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.AddAlbumsToArtistRequest{
 		ID:       uuid.New(),
@@ -120,12 +133,16 @@ func TestAddAlbumsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 		Return(nil, albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(albums)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, request.AlbumIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, request.AlbumIDs).
 		Return(internalError).
 		Once()
 
@@ -137,6 +154,8 @@ func TestAddAlbumsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -145,7 +164,11 @@ func TestAddAlbumsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewAddAlbumsToArtist(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddAlbumsToArtist(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.AddAlbumsToArtistRequest{
 		ID:       uuid.New(),
@@ -167,6 +190,10 @@ func TestAddAlbumsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 		Return(nil, albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(albums)).
 		Run(func(args mock.Arguments) {
 			newAlbums := args.Get(0).(*[]model.Album)
@@ -180,7 +207,7 @@ func TestAddAlbumsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, request.AlbumIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, request.AlbumIDs).
 		Return(nil).
 		Once()
 
@@ -190,6 +217,8 @@ func TestAddAlbumsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -20,7 +20,7 @@ import (
 func TestAddPerfectArtistRehearsals_WhenGetArtistsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, nil, nil)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToArtistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -45,7 +45,7 @@ func TestAddPerfectArtistRehearsals_WhenGetArtistsFails_ShouldReturnInternalServ
 func TestAddPerfectArtistRehearsals_WhenArtistsLenIs0_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, nil, nil)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToArtistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -71,7 +71,7 @@ func TestAddPerfectArtistRehearsals_WhenTransactionExecuteFails_ShouldReturnErro
 	artistRepository := new(repository.ArtistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, transactionManager)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, nil, transactionManager)
 
 	request := requests.AddPerfectRehearsalsToArtistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -103,7 +103,7 @@ func TestAddPerfectArtistRehearsals_WhenProcessorFails_ShouldReturnInternalServe
 	artistRepository := new(repository.ArtistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, transactionManager)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -127,7 +127,7 @@ func TestAddPerfectArtistRehearsals_WhenProcessorFails_ShouldReturnInternalServe
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(internalError, false).
+		Return(internalError, nil).
 		Times(len(mockArtists[0].Songs))
 
 	// when
@@ -150,7 +150,7 @@ func TestAddPerfectArtistRehearsals_WhenUpdateFails_ShouldReturnInternalServerEr
 	artistRepository := new(repository.ArtistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, transactionManager)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -173,7 +173,7 @@ func TestAddPerfectArtistRehearsals_WhenUpdateFails_ShouldReturnInternalServerEr
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 		Times(len(mockArtists[0].Songs))
 
 	internalError := errors.New("internal error")
@@ -202,7 +202,7 @@ func TestAddPerfectArtistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(
 	artistRepository := new(repository.ArtistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, transactionManager)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -230,7 +230,7 @@ func TestAddPerfectArtistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, false).
+		Return(nil, nil).
 		Times(len(mockArtists[0].Songs))
 
 	// when
@@ -247,16 +247,105 @@ func TestAddPerfectArtistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(
 	transactionSongRepository.AssertExpectations(t)
 }
 
+func TestAddPerfectArtistRehearsals_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, practiceSessionProcessor, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectRehearsalsToArtistsRequest{
+		IDs: []uuid.UUID{
+			uuid.New(),
+			uuid.New(),
+			uuid.New(),
+		},
+	}
+
+	mockArtists := []model.Artist{
+		{
+			ID: request.IDs[0],
+			Songs: []model.Song{
+				{ID: uuid.New()},
+				{ID: uuid.New()},
+			},
+		},
+		{
+			ID: request.IDs[1],
+			Songs: []model.Song{
+				{ID: uuid.New()},
+			},
+		},
+		{ID: request.IDs[2]},
+	}
+	artistRepository.On("GetAllByIDsWithSongSections", new([]model.Artist), request.IDs).
+		Return(nil, &mockArtists).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	var artistSongs []model.Song
+	for _, a := range mockArtists {
+		for _, s := range a.Songs {
+			songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+				Once()
+			artistSongs = append(artistSongs, s)
+		}
+	}
+
+	transactionSongRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.Len(t, *newSongs, len(artistSongs))
+			assert.ElementsMatch(t, *newSongs, artistSongs)
+		}).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mock.IsType(model.Song{}), mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	artistRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
 func TestAddPerfectArtistRehearsals_WhenSuccessful_ShouldUpdateArtists(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, transactionManager)
+	_uut := artist.NewAddPerfectRehearsalsToArtists(artistRepository, songProcessor, practiceSessionProcessor, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPerfectRehearsalsToArtistsRequest{
 		IDs: []uuid.UUID{
@@ -288,13 +377,14 @@ func TestAddPerfectArtistRehearsals_WhenSuccessful_ShouldUpdateArtists(t *testin
 
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	var artistSongs []model.Song
 	for _, a := range mockArtists {
 		for _, s := range a.Songs {
 			songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
-				Return(nil, true).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 				Once()
 			artistSongs = append(artistSongs, s)
 		}
@@ -309,6 +399,12 @@ func TestAddPerfectArtistRehearsals_WhenSuccessful_ShouldUpdateArtists(t *testin
 		Return(nil).
 		Once()
 
+	for _, s := range artistSongs {
+		practiceSessionProcessor.On("RecordPerfectRehearsal", s, mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+			Return(nil).
+			Once()
+	}
+
 	// when
 	errCode := _uut.Handle(request)
 
@@ -317,8 +413,10 @@ func TestAddPerfectArtistRehearsals_WhenSuccessful_ShouldUpdateArtists(t *testin
 
 	artistRepository.AssertExpectations(t)
 	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	repositoryFactory.AssertExpectations(t)
 	transactionSongSectionRepository.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
 }
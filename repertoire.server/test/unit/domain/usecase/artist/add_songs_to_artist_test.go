@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestAddSongsToArtist_WhenGetSongWithSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewAddSongsToArtist(songRepository, nil)
+	_uut := artist.NewAddSongsToArtist(songRepository, nil, nil)
 
 	request := requests.AddSongsToArtistRequest{
 		ID:      uuid.New(),
@@ -45,7 +46,7 @@ func TestAddSongsToArtist_WhenGetSongWithSongsFails_ShouldReturnInternalServerEr
 func TestAddSongsToArtist_WhenOneSongHasArtist_ShouldReturnConflictError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewAddSongsToArtist(songRepository, nil)
+	_uut := artist.NewAddSongsToArtist(songRepository, nil, nil)
 
 	request := requests.AddSongsToArtistRequest{
 		ID:      uuid.New(),
@@ -76,7 +77,10 @@ func TestAddSongsToArtist_WhenOneSongHasArtist_ShouldReturnConflictError(t *test
 func TestAddSongsToArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewAddSongsToArtist(songRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddSongsToArtist(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.AddSongsToArtistRequest{
 		ID:      uuid.New(),
@@ -93,6 +97,9 @@ func TestAddSongsToArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServerErro
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateAllWithAssociations", mock.IsType(songs)).
 		Return(internalError).
@@ -106,6 +113,8 @@ func TestAddSongsToArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServerErro
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -113,7 +122,11 @@ func TestAddSongsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewAddSongsToArtist(songRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddSongsToArtist(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.AddSongsToArtistRequest{
 		ID:      uuid.New(),
@@ -130,12 +143,16 @@ func TestAddSongsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *te
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateAllWithAssociations", mock.IsType(songs)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(internalError).
 		Once()
 
@@ -147,6 +164,8 @@ func TestAddSongsToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *te
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -155,7 +174,11 @@ func TestAddSongsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewAddSongsToArtist(songRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewAddSongsToArtist(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.AddSongsToArtistRequest{
 		ID:      uuid.New(),
@@ -185,6 +208,10 @@ func TestAddSongsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil, &songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateAllWithAssociations", mock.IsType(&songs)).
 		Run(func(args mock.Arguments) {
 			newSongs := args.Get(0).(*[]model.Song)
@@ -202,7 +229,7 @@ func TestAddSongsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(nil).
 		Once()
 
@@ -212,6 +239,8 @@ func TestAddSongsToArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
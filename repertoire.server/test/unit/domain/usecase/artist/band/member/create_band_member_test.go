@@ -5,8 +5,11 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/artist/band/member"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
@@ -17,7 +20,7 @@ import (
 func TestCreateBandMember_WhenGetArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	_uut := member.NewCreateBandMember(artistRepository, nil, nil)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -45,7 +48,7 @@ func TestCreateBandMember_WhenGetArtistFails_ShouldReturnInternalServerError(t *
 func TestCreateBandMember_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	_uut := member.NewCreateBandMember(artistRepository, nil, nil)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -72,7 +75,7 @@ func TestCreateBandMember_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing
 func TestCreateBandMember_WhenArtistIsNotBand_ShouldReturnConflictError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	_uut := member.NewCreateBandMember(artistRepository, nil, nil)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -100,7 +103,7 @@ func TestCreateBandMember_WhenArtistIsNotBand_ShouldReturnConflictError(t *testi
 func TestCreateBandMember_WhenGetBandMemberRolesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	_uut := member.NewCreateBandMember(artistRepository, nil, nil)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -133,7 +136,10 @@ func TestCreateBandMember_WhenGetBandMemberRolesFails_ShouldReturnInternalServer
 func TestCreateBandMember_WhenCreateBandMemberFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewCreateBandMember(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -153,6 +159,9 @@ func TestCreateBandMember_WhenCreateBandMemberFails_ShouldReturnInternalServerEr
 		Return(nil, roles).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("CreateBandMember", mock.IsType(new(model.BandMember))).
 		Return(internalError).
@@ -167,13 +176,76 @@ func TestCreateBandMember_WhenCreateBandMemberFails_ShouldReturnInternalServerEr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+}
+
+func TestCreateBandMember_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewCreateBandMember(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.CreateBandMemberRequest{
+		ArtistID: uuid.New(),
+		Name:     "Some Artist",
+		RoleIDs:  []uuid.UUID{uuid.New()},
+	}
+
+	artist := &model.Artist{ID: request.ArtistID, IsBand: true}
+	artistRepository.On("GetWithBandMembers", mock.IsType(artist), request.ArtistID).
+		Return(nil, artist).
+		Once()
+
+	roles := &[]model.BandMemberRole{
+		{ID: request.RoleIDs[0]},
+	}
+	artistRepository.On("GetBandMemberRolesByIDs", mock.IsType(roles), request.RoleIDs).
+		Return(nil, roles).
+		Once()
+
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	artistRepository.On("CreateBandMember", mock.IsType(new(model.BandMember))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, request.ArtistID).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestCreateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewCreateBandMember(artistRepository)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewCreateBandMember(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreateBandMemberRequest{
 		ArtistID: uuid.New(),
@@ -194,6 +266,10 @@ func TestCreateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil, roles).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var bandMemberID uuid.UUID
 	artistRepository.On("CreateBandMember", mock.IsType(new(model.BandMember))).
 		Run(func(args mock.Arguments) {
@@ -204,6 +280,10 @@ func TestCreateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, request.ArtistID).
+		Return(nil).
+		Once()
+
 	// when
 	id, errCode := _uut.Handle(request)
 
@@ -211,7 +291,10 @@ func TestCreateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	assert.Equal(t, bandMemberID, id)
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func assertCreatedBandMember(
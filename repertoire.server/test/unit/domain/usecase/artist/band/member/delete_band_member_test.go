@@ -4,8 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/domain/usecase/artist/band/member"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"slices"
 	"testing"
 
@@ -17,7 +20,7 @@ import (
 func TestDeleteBandMember_WhenGetArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteBandMember(artistRepository)
+	_uut := member.NewDeleteBandMember(artistRepository, nil, nil)
 
 	id := uuid.New()
 	artistID := uuid.New()
@@ -41,7 +44,7 @@ func TestDeleteBandMember_WhenGetArtistFails_ShouldReturnInternalServerError(t *
 func TestDeleteBandMember_WhenArtistIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteBandMember(artistRepository)
+	_uut := member.NewDeleteBandMember(artistRepository, nil, nil)
 
 	id := uuid.New()
 	artistID := uuid.New()
@@ -64,7 +67,7 @@ func TestDeleteBandMember_WhenArtistIsNotFound_ShouldReturnNotFoundError(t *test
 func TestDeleteBandMember_WhenBandMemberIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteBandMember(artistRepository)
+	_uut := member.NewDeleteBandMember(artistRepository, nil, nil)
 
 	id := uuid.New()
 	artistID := uuid.New()
@@ -94,7 +97,10 @@ func TestDeleteBandMember_WhenBandMemberIsNotFound_ShouldReturnNotFoundError(t *
 func TestDeleteBandMember_WhenUpdateArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteBandMember(artistRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteBandMember(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	artistID := uuid.New()
@@ -110,6 +116,9 @@ func TestDeleteBandMember_WhenUpdateArtistFails_ShouldReturnInternalServerError(
 		Return(nil, artist).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("UpdateWithAssociations", mock.IsType(artist)).
 		Return(internalError).
@@ -123,13 +132,18 @@ func TestDeleteBandMember_WhenUpdateArtistFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 }
 
 func TestDeleteBandMember_WhenDeleteBandMemberFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteBandMember(artistRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteBandMember(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	artistID := uuid.New()
@@ -145,6 +159,9 @@ func TestDeleteBandMember_WhenDeleteBandMemberFails_ShouldReturnInternalServerEr
 		Return(nil, artist).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("UpdateWithAssociations", mock.IsType(artist)).
 		Return(nil).
 		Once()
@@ -160,7 +177,62 @@ func TestDeleteBandMember_WhenDeleteBandMemberFails_ShouldReturnInternalServerEr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+}
+
+func TestDeleteBandMember_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteBandMember(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	id := uuid.New()
+	artistID := uuid.New()
+
+	// given - mocking
+	artist := &model.Artist{
+		ID: artistID,
+		BandMembers: []model.BandMember{
+			{ID: id, Order: 0},
+		},
+	}
+	artistRepository.On("GetWithBandMembers", new(model.Artist), artistID).
+		Return(nil, artist).
+		Once()
+
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	artistRepository.On("UpdateWithAssociations", mock.IsType(artist)).
+		Return(nil).
+		Once()
+
+	artistRepository.On("DeleteBandMember", id).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, artistID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id, artistID)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestDeleteBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
@@ -197,7 +269,12 @@ func TestDeleteBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			artistRepository := new(repository.ArtistRepositoryMock)
-			_uut := member.NewDeleteBandMember(artistRepository)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := member.NewDeleteBandMember(artistRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			id := tt.artist.BandMembers[tt.memberIndex].ID
 			artistID := tt.artist.ID
@@ -207,6 +284,10 @@ func TestDeleteBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil, &tt.artist).
 				Once()
 
+			repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			artistRepository.On("UpdateWithAssociations", mock.IsType(&tt.artist)).
 				Run(func(args mock.Arguments) {
 					newArtist := args.Get(0).(*model.Artist)
@@ -225,13 +306,20 @@ func TestDeleteBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 
 			artistRepository.On("DeleteBandMember", id).Return(nil).Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, artistID).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(id, artistID)
 
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			artistRepository.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 		})
 	}
 }
@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"repertoire/server/domain/usecase/artist/band/member"
 	"repertoire/server/internal"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +21,7 @@ import (
 func TestDeleteImageFromBandMember_WhenGetBandMemberFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -43,7 +45,7 @@ func TestDeleteImageFromBandMember_WhenGetBandMemberFails_ShouldReturnInternalSe
 func TestDeleteImageFromBandMember_WhenMemberIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -66,7 +68,7 @@ func TestDeleteImageFromBandMember_WhenMemberIsEmpty_ShouldReturnNotFoundError(t
 func TestDeleteImageFromBandMember_WhenMemberHasNoImage_ShouldReturnConflictError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -91,7 +93,7 @@ func TestDeleteImageFromBandMember_WhenDeleteImageFails_ShouldReturnInternalServ
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService, nil, nil)
 
 	id := uuid.New()
 
@@ -122,7 +124,10 @@ func TestDeleteImageFromBandMember_WhenUpdateBandMemberFails_ShouldReturnInterna
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -134,6 +139,9 @@ func TestDeleteImageFromBandMember_WhenUpdateBandMemberFails_ShouldReturnInterna
 
 	storageService.On("DeleteFile", *mockBandMember.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("UpdateBandMember", mock.IsType(mockBandMember)).
 		Return(internalError).
@@ -147,20 +155,84 @@ func TestDeleteImageFromBandMember_WhenUpdateBandMemberFails_ShouldReturnInterna
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
 
+func TestDeleteImageFromBandMember_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	storageService := new(service.StorageServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	id := uuid.New()
+
+	// given - mocking
+	mockBandMember := &model.BandMember{
+		ID:       id,
+		ImageURL: &[]internal.FilePath{"This is some url"}[0],
+		ArtistID: uuid.New(),
+	}
+	artistRepository.On("GetBandMember", new(model.BandMember), id).
+		Return(nil, mockBandMember).
+		Once()
+
+	storageService.On("DeleteFile", *mockBandMember.ImageURL).Return(nil).Once()
+
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	artistRepository.On("UpdateBandMember", mock.IsType(mockBandMember)).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
 func TestDeleteImageFromBandMember_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewDeleteImageFromBandMember(artistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
 	// given - mocking
-	mockBandMember := &model.BandMember{ID: id, ImageURL: &[]internal.FilePath{"This is some url"}[0]}
+	mockBandMember := &model.BandMember{
+		ID:       id,
+		ImageURL: &[]internal.FilePath{"This is some url"}[0],
+		ArtistID: uuid.New(),
+	}
 	artistRepository.On("GetBandMember", new(model.BandMember), id).
 		Return(nil, mockBandMember).
 		Once()
@@ -169,6 +241,10 @@ func TestDeleteImageFromBandMember_WhenIsValid_ShouldNotReturnAnyError(t *testin
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("UpdateBandMember", mock.IsType(mockBandMember)).
 		Run(func(args mock.Arguments) {
 			newBandMember := args.Get(0).(*model.BandMember)
@@ -177,12 +253,19 @@ func TestDeleteImageFromBandMember_WhenIsValid_ShouldNotReturnAnyError(t *testin
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(id)
 
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
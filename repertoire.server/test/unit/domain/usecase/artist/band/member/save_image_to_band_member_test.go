@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"repertoire/server/domain/usecase/artist/band/member"
 	"repertoire/server/internal"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/provider"
@@ -22,7 +24,7 @@ import (
 func TestSaveImageToBandMember_WhenGetBandMemberFails_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, nil, nil)
+	_uut := member.NewSaveImageToBandMember(artistRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -47,7 +49,7 @@ func TestSaveImageToBandMember_WhenGetBandMemberFails_ShouldReturnNotFoundError(
 func TestSaveImageToBandMember_WhenMEmberIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, nil, nil)
+	_uut := member.NewSaveImageToBandMember(artistRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -72,7 +74,7 @@ func TestSaveImageToBandMember_WhenStorageDeleteFileFails_ShouldReturnInternalSe
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, nil, storageService)
+	_uut := member.NewSaveImageToBandMember(artistRepository, nil, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -97,12 +99,12 @@ func TestSaveImageToBandMember_WhenStorageDeleteFileFails_ShouldReturnInternalSe
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveImageToBandMember_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveImageToBandMember_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService)
+	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -114,11 +116,11 @@ func TestSaveImageToBandMember_WhenStorageUploadFails_ShouldReturnInternalServer
 		Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetBandMemberImagePath", file, mock.IsType(*mockBandMember)).
+	storageFilePathProvider.On("GetBandMemberImagePath", mock.IsType(*mockBandMember)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -126,8 +128,7 @@ func TestSaveImageToBandMember_WhenStorageUploadFails_ShouldReturnInternalServer
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
@@ -139,7 +140,10 @@ func TestSaveImageToBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalSer
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -151,12 +155,15 @@ func TestSaveImageToBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalSer
 		Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetBandMemberImagePath", file, mock.IsType(*mockBandMember)).
+	storageFilePathProvider.On("GetBandMemberImagePath", mock.IsType(*mockBandMember)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("UpdateBandMember", mock.IsType(new(model.BandMember))).
 		Return(internalError).
@@ -170,31 +177,95 @@ func TestSaveImageToBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalSer
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
 
+func TestSaveImageToBandMember_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	storageService := new(service.StorageServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	file := new(multipart.FileHeader)
+	id := uuid.New()
+
+	// given - mocking
+	mockBandMember := &model.BandMember{ID: id, ImageURL: nil, ArtistID: uuid.New()}
+	artistRepository.On("GetBandMemberWithArtist", new(model.BandMember), id).
+		Return(nil, mockBandMember).
+		Once()
+
+	imagePath := "artists file path"
+	storageFilePathProvider.On("GetBandMemberImagePath", mock.IsType(*mockBandMember)).
+		Return(imagePath).
+		Once()
+
+	storageService.On("Upload", file, imagePath).Return(nil).Once()
+
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	artistRepository.On("UpdateBandMember", mock.IsType(new(model.BandMember))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(file, id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
 func TestSaveImageToBandMember_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
 	// given - mocking
-	mockBandMember := &model.BandMember{ID: id, ImageURL: nil}
+	mockBandMember := &model.BandMember{ID: id, ImageURL: nil, ArtistID: uuid.New()}
 	artistRepository.On("GetBandMemberWithArtist", new(model.BandMember), id).
 		Return(nil, mockBandMember).
 		Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetBandMemberImagePath", file, mock.IsType(*mockBandMember)).
+	storageFilePathProvider.On("GetBandMemberImagePath", mock.IsType(*mockBandMember)).
 		Run(func(args mock.Arguments) {
-			newMember := args.Get(1).(model.BandMember)
+			newMember := args.Get(0).(model.BandMember)
 			assert.Equal(t, newMember.ID, mockBandMember.ID)
 			assert.WithinDuration(t, time.Now(), newMember.UpdatedAt, time.Minute)
 		}).
@@ -203,6 +274,10 @@ func TestSaveImageToBandMember_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnA
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("UpdateBandMember", mock.IsType(new(model.BandMember))).
 		Run(func(args mock.Arguments) {
 			newBandMember := args.Get(0).(*model.BandMember)
@@ -211,15 +286,22 @@ func TestSaveImageToBandMember_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnA
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(file, id)
 
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestSaveImageToBandMember_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNotReturnAnyError(t *testing.T) {
@@ -227,13 +309,22 @@ func TestSaveImageToBandMember_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAn
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewSaveImageToBandMember(artistRepository, storageFilePathProvider, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
 	// given - mocking
-	mockBandMember := &model.BandMember{ID: id, ImageURL: &[]internal.FilePath{"file_path"}[0]}
+	mockBandMember := &model.BandMember{
+		ID:       id,
+		ImageURL: &[]internal.FilePath{"file_path"}[0],
+		ArtistID: uuid.New(),
+	}
 	artistRepository.On("GetBandMemberWithArtist", new(model.BandMember), id).
 		Return(nil, mockBandMember).
 		Once()
@@ -241,9 +332,9 @@ func TestSaveImageToBandMember_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAn
 	storageService.On("DeleteFile", *mockBandMember.ImageURL).Return(nil).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetBandMemberImagePath", file, mock.IsType(*mockBandMember)).
+	storageFilePathProvider.On("GetBandMemberImagePath", mock.IsType(*mockBandMember)).
 		Run(func(args mock.Arguments) {
-			newMember := args.Get(1).(model.BandMember)
+			newMember := args.Get(0).(model.BandMember)
 			assert.Equal(t, newMember.ID, mockBandMember.ID)
 			assert.WithinDuration(t, time.Now(), newMember.UpdatedAt, time.Minute)
 		}).
@@ -252,6 +343,10 @@ func TestSaveImageToBandMember_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAn
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("UpdateBandMember", mock.IsType(new(model.BandMember))).
 		Run(func(args mock.Arguments) {
 			newBandMember := args.Get(0).(*model.BandMember)
@@ -260,13 +355,20 @@ func TestSaveImageToBandMember_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAn
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(file, id)
 
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
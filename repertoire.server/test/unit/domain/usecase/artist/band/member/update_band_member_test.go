@@ -5,8 +5,11 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/artist/band/member"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
@@ -17,7 +20,7 @@ import (
 func TestUpdateBandMember_WhenGetBandMembersFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	_uut := member.NewUpdateBandMember(artistRepository, nil, nil)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -44,7 +47,7 @@ func TestUpdateBandMember_WhenGetBandMembersFails_ShouldReturnInternalServerErro
 func TestUpdateBandMember_WhenBandMembersIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	_uut := member.NewUpdateBandMember(artistRepository, nil, nil)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -70,7 +73,7 @@ func TestUpdateBandMember_WhenBandMembersIsEmpty_ShouldReturnNotFoundError(t *te
 func TestUpdateBandMember_WhenGetRolesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	_uut := member.NewUpdateBandMember(artistRepository, nil, nil)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -103,7 +106,10 @@ func TestUpdateBandMember_WhenGetRolesFails_ShouldReturnInternalServerError(t *t
 func TestUpdateBandMember_WhenReplaceRolesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewUpdateBandMember(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -121,6 +127,9 @@ func TestUpdateBandMember_WhenReplaceRolesFails_ShouldReturnInternalServerError(
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.
 		On(
@@ -139,13 +148,18 @@ func TestUpdateBandMember_WhenReplaceRolesFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 }
 
 func TestUpdateBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewUpdateBandMember(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -163,6 +177,9 @@ func TestUpdateBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalServerEr
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.
 		On(
 			"ReplaceRolesFromBandMember",
@@ -185,13 +202,82 @@ func TestUpdateBandMember_WhenUpdateBandMemberFails_ShouldReturnInternalServerEr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+}
+
+func TestUpdateBandMember_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	artistRepository := new(repository.ArtistRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewUpdateBandMember(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.UpdateBandMemberRequest{
+		ID:      uuid.New(),
+		Name:    "Some Artist",
+		RoleIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	mockBandMember := &model.BandMember{ID: request.ID, ArtistID: uuid.New()}
+	artistRepository.On("GetBandMember", new(model.BandMember), request.ID).
+		Return(nil, mockBandMember).
+		Once()
+
+	artistRepository.On("GetBandMemberRolesByIDs", new([]model.BandMemberRole), request.RoleIDs).
+		Return(nil).
+		Once()
+
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	artistRepository.
+		On(
+			"ReplaceRolesFromBandMember",
+			mock.IsType([]model.BandMemberRole{}),
+			mock.IsType(new(model.BandMember)),
+		).
+		Return(nil).
+		Once()
+
+	artistRepository.On("UpdateBandMember", mock.IsType(new(model.BandMember))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestUpdateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := member.NewUpdateBandMember(artistRepository)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := member.NewUpdateBandMember(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateBandMemberRequest{
 		ID:      uuid.New(),
@@ -200,7 +286,7 @@ func TestUpdateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	}
 
 	// given - mocking
-	mockBandMember := &model.BandMember{ID: request.ID}
+	mockBandMember := &model.BandMember{ID: request.ID, ArtistID: uuid.New()}
 	artistRepository.On("GetBandMember", new(model.BandMember), request.ID).
 		Return(nil, mockBandMember).
 		Once()
@@ -212,6 +298,10 @@ func TestUpdateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil, &roles).
 		Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("ReplaceRolesFromBandMember", roles, mockBandMember).
 		Return(nil).
 		Once()
@@ -224,13 +314,20 @@ func TestUpdateBandMember_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockBandMember.ArtistID).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(request)
 
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func assertUpdatedBandMember(
@@ -232,6 +232,7 @@ func TestBulkDeleteArtists_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	_uut := artist.NewBulkDeleteArtists(artistRepository, messagePublisherService, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 	transactionArtistRepository := new(repository.ArtistRepositoryMock)
 
 	request := requests.BulkDeleteArtistsRequest{
@@ -251,6 +252,7 @@ func TestBulkDeleteArtists_WhenPublishFails_ShouldReturnInternalServerError(t *t
 		Once()
 
 	repositoryFactory.On("NewArtistRepository").Return(transactionArtistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	transactionArtistRepository.On("Delete", request.IDs).
@@ -258,7 +260,7 @@ func TestBulkDeleteArtists_WhenPublishFails_ShouldReturnInternalServerError(t *t
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistsDeletedTopic, *mockArtists).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistsDeletedTopic, *mockArtists).
 		Return(internalError).
 		Once()
 
@@ -319,6 +321,7 @@ func TestBulkDeleteArtists_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 			_uut := artist.NewBulkDeleteArtists(artistRepository, messagePublisherService, transactionManager)
 
 			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 			transactionArtistRepository := new(repository.ArtistRepositoryMock)
 
 			var ids []uuid.UUID
@@ -343,6 +346,7 @@ func TestBulkDeleteArtists_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 				Once()
 
 			repositoryFactory.On("NewArtistRepository").Return(transactionArtistRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 			if tt.withAlbums {
@@ -360,7 +364,7 @@ func TestBulkDeleteArtists_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.ArtistsDeletedTopic, tt.artists).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistsDeletedTopic, tt.artists).
 				Return(nil).
 				Once()
 
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -45,7 +46,10 @@ func TestCreateArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *test
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := artist.NewCreateArtist(jwtService, artistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewCreateArtist(jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreateArtistRequest{
 		Name: "Some Artist",
@@ -54,6 +58,9 @@ func TestCreateArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *test
 	userID := uuid.New()
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("Create", mock.IsType(new(model.Artist))).
 		Return(internalError).
@@ -68,6 +75,8 @@ func TestCreateArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *test
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 }
@@ -77,7 +86,11 @@ func TestCreateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 	artistRepository := new(repository.ArtistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewCreateArtist(jwtService, artistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewCreateArtist(jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreateArtistRequest{
 		Name:   "Some Artist",
@@ -88,12 +101,16 @@ func TestCreateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Create", mock.IsType(new(model.Artist))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistCreatedTopic, mock.IsType(model.Artist{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistCreatedTopic, mock.IsType(model.Artist{})).
 		Return(internalError).
 		Once()
 
@@ -106,6 +123,8 @@ func TestCreateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -116,7 +135,11 @@ func TestCreateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	artistRepository := new(repository.ArtistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewCreateArtist(jwtService, artistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewCreateArtist(jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreateArtistRequest{
 		Name:   "Some Artist",
@@ -127,6 +150,10 @@ func TestCreateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var createdArtist model.Artist
 	artistRepository.On("Create", mock.IsType(new(model.Artist))).
 		Run(func(args mock.Arguments) {
@@ -137,9 +164,9 @@ func TestCreateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.ArtistCreatedTopic, mock.IsType(createdArtist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistCreatedTopic, mock.IsType(createdArtist)).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, createdArtist, args.Get(1).(model.Artist))
+			assert.Equal(t, createdArtist, args.Get(2).(model.Artist))
 		}).
 		Return(nil).
 		Once()
@@ -151,6 +178,8 @@ func TestCreateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	assert.Equal(t, createdArtist.ID, id)
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -237,6 +237,7 @@ func TestDeleteArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 	_uut := artist.NewDeleteArtist(artistRepository, messagePublisherService, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 	transactionArtistRepository := new(repository.ArtistRepositoryMock)
 
 	request := requests.DeleteArtistRequest{
@@ -258,6 +259,7 @@ func TestDeleteArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 		Once()
 
 	repositoryFactory.On("NewArtistRepository").Return(transactionArtistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	transactionArtistRepository.On("Delete", []uuid.UUID{request.ID}).
@@ -265,7 +267,7 @@ func TestDeleteArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistsDeletedTopic, *mockArtist).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistsDeletedTopic, *mockArtist).
 		Return(internalError).
 		Once()
 
@@ -326,6 +328,7 @@ func TestDeleteArtist_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 			_uut := artist.NewDeleteArtist(artistRepository, messagePublisherService, transactionManager)
 
 			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 			transactionArtistRepository := new(repository.ArtistRepositoryMock)
 
 			request := requests.DeleteArtistRequest{
@@ -346,6 +349,7 @@ func TestDeleteArtist_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 				Once()
 
 			repositoryFactory.On("NewArtistRepository").Return(transactionArtistRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
 			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 			if tt.withAlbums {
@@ -363,7 +367,7 @@ func TestDeleteArtist_WhenSuccessful_ShouldDeleteArtist(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.ArtistsDeletedTopic, tt.artist).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistsDeletedTopic, tt.artist).
 				Return(nil).
 				Once()
 
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -20,7 +21,7 @@ import (
 func TestDeleteImageFromArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -42,7 +43,7 @@ func TestDeleteImageFromArtist_WhenGetArtistFails_ShouldReturnInternalServerErro
 func TestDeleteImageFromArtist_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -63,7 +64,7 @@ func TestDeleteImageFromArtist_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *te
 func TestDeleteImageFromArtist_WhenArtistHasNoImage_ShouldReturnConflictError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -86,7 +87,7 @@ func TestDeleteImageFromArtist_WhenDeleteImageFails_ShouldReturnInternalServerEr
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, nil)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, nil, nil)
 
 	id := uuid.New()
 
@@ -113,7 +114,10 @@ func TestDeleteImageFromArtist_WhenUpdateArtistFails_ShouldReturnInternalServerE
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -123,6 +127,9 @@ func TestDeleteImageFromArtist_WhenUpdateArtistFails_ShouldReturnInternalServerE
 
 	storageService.On("DeleteFile", *mockArtist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Return(internalError).
@@ -136,6 +143,8 @@ func TestDeleteImageFromArtist_WhenUpdateArtistFails_ShouldReturnInternalServerE
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
@@ -145,7 +154,11 @@ func TestDeleteImageFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -155,12 +168,16 @@ func TestDeleteImageFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 
 	storageService.On("DeleteFile", *mockArtist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(internalError).
 		Once()
 
@@ -172,6 +189,8 @@ func TestDeleteImageFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -182,7 +201,11 @@ func TestDeleteImageFromArtist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewDeleteImageFromArtist(artistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -192,6 +215,10 @@ func TestDeleteImageFromArtist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 
 	storageService.On("DeleteFile", *mockArtist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Run(func(args mock.Arguments) {
 			newArtist := args.Get(0).(*model.Artist)
@@ -200,7 +227,7 @@ func TestDeleteImageFromArtist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(nil).
 		Once()
 
@@ -210,6 +237,8 @@ func TestDeleteImageFromArtist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T)
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestRemoveAlbumsFromArtist_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil)
+	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil, nil)
 
 	request := requests.RemoveAlbumsFromArtistRequest{
 		ID:       uuid.New(),
@@ -45,7 +46,7 @@ func TestRemoveAlbumsFromArtist_WhenGetAlbumFails_ShouldReturnInternalServerErro
 func TestRemoveAlbumsFromArtist_WhenOneAlbumArtistDoesNotMatch_ShouldReturnConflictError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil)
+	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil, nil)
 
 	request := requests.RemoveAlbumsFromArtistRequest{
 		ID:       uuid.New(),
@@ -71,7 +72,10 @@ func TestRemoveAlbumsFromArtist_WhenOneAlbumArtistDoesNotMatch_ShouldReturnConfl
 func TestRemoveAlbumsFromArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.RemoveAlbumsFromArtistRequest{
 		ID:       uuid.New(),
@@ -88,6 +92,9 @@ func TestRemoveAlbumsFromArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalSer
 		Return(nil, albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(albums)).
 		Return(internalError).
@@ -101,6 +108,8 @@ func TestRemoveAlbumsFromArtist_WhenUpdateAllAlbumsFails_ShouldReturnInternalSer
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
 
@@ -108,7 +117,11 @@ func TestRemoveAlbumsFromArtist_WhenPublishFails_ShouldReturnInternalServerError
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveAlbumsFromArtistRequest{
 		ID:       uuid.New(),
@@ -125,12 +138,16 @@ func TestRemoveAlbumsFromArtist_WhenPublishFails_ShouldReturnInternalServerError
 		Return(nil, albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(albums)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, request.AlbumIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, request.AlbumIDs).
 		Return(internalError).
 		Once()
 
@@ -142,6 +159,8 @@ func TestRemoveAlbumsFromArtist_WhenPublishFails_ShouldReturnInternalServerError
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -150,7 +169,11 @@ func TestRemoveAlbumsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveAlbumsFromArtist(albumRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveAlbumsFromArtistRequest{
 		ID:       uuid.New(),
@@ -172,6 +195,10 @@ func TestRemoveAlbumsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 		Return(nil, &albums).
 		Once()
 
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	albumRepository.On("UpdateAllWithSongs", mock.IsType(&albums)).
 		Run(func(args mock.Arguments) {
 			newAlbums := args.Get(0).(*[]model.Album)
@@ -185,7 +212,7 @@ func TestRemoveAlbumsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.AlbumsUpdatedTopic, request.AlbumIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumsUpdatedTopic, request.AlbumIDs).
 		Return(nil).
 		Once()
 
@@ -195,6 +222,8 @@ func TestRemoveAlbumsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
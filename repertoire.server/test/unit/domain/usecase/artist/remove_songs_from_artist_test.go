@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestRemoveSongsFromArtist_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil)
+	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil, nil)
 
 	request := requests.RemoveSongsFromArtistRequest{
 		ID:      uuid.New(),
@@ -45,7 +46,7 @@ func TestRemoveSongsFromArtist_WhenGetSongFails_ShouldReturnInternalServerError(
 func TestRemoveSongsFromArtist_WhenOneSongArtistDoesNotMatch_ShouldReturnConflictError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil)
+	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil, nil)
 
 	request := requests.RemoveSongsFromArtistRequest{
 		ID:      uuid.New(),
@@ -76,7 +77,10 @@ func TestRemoveSongsFromArtist_WhenOneSongArtistDoesNotMatch_ShouldReturnConflic
 func TestRemoveSongsFromArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveSongsFromArtist(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.RemoveSongsFromArtistRequest{
 		ID:      uuid.New(),
@@ -93,6 +97,9 @@ func TestRemoveSongsFromArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServe
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateAll", mock.IsType(songs)).
 		Return(internalError).
@@ -106,6 +113,8 @@ func TestRemoveSongsFromArtist_WhenUpdateAllSongsFails_ShouldReturnInternalServe
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -113,7 +122,11 @@ func TestRemoveSongsFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewRemoveSongsFromArtist(songRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveSongsFromArtist(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveSongsFromArtistRequest{
 		ID:      uuid.New(),
@@ -130,12 +143,16 @@ func TestRemoveSongsFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 		Return(nil, songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateAll", mock.IsType(songs)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(internalError).
 		Once()
 
@@ -147,6 +164,8 @@ func TestRemoveSongsFromArtist_WhenPublishFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -155,7 +174,11 @@ func TestRemoveSongsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewRemoveSongsFromArtist(songRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewRemoveSongsFromArtist(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.RemoveSongsFromArtistRequest{
 		ID:      uuid.New(),
@@ -176,6 +199,10 @@ func TestRemoveSongsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing
 		Return(nil, &songs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateAll", mock.IsType(&songs)).
 		Run(func(args mock.Arguments) {
 			newSongs := args.Get(0).(*[]model.Song)
@@ -186,7 +213,7 @@ func TestRemoveSongsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, request.SongIDs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, request.SongIDs).
 		Return(nil).
 		Once()
 
@@ -196,6 +223,8 @@ func TestRemoveSongsFromArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/provider"
@@ -23,7 +24,7 @@ import (
 func TestSaveImageToArtist_WhenGetArtistFails_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewSaveImageToArtist(artistRepository, nil, nil, nil)
+	_uut := artist.NewSaveImageToArtist(artistRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -46,7 +47,7 @@ func TestSaveImageToArtist_WhenGetArtistFails_ShouldReturnNotFoundError(t *testi
 func TestSaveImageToArtist_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewSaveImageToArtist(artistRepository, nil, nil, nil)
+	_uut := artist.NewSaveImageToArtist(artistRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -69,7 +70,7 @@ func TestSaveImageToArtist_WhenStorageDeleteFileFails_ShouldReturnError(t *testi
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := artist.NewSaveImageToArtist(artistRepository, nil, storageService, nil)
+	_uut := artist.NewSaveImageToArtist(artistRepository, nil, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -92,12 +93,12 @@ func TestSaveImageToArtist_WhenStorageDeleteFileFails_ShouldReturnError(t *testi
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveImageToArtist_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveImageToArtist_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := artist.NewSaveImageToArtist(artistRepository, storageFilePathProvider, storageService, nil)
+	_uut := artist.NewSaveImageToArtist(artistRepository, storageFilePathProvider, storageService, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -107,11 +108,11 @@ func TestSaveImageToArtist_WhenStorageUploadFails_ShouldReturnInternalServerErro
 	artistRepository.On("Get", new(model.Artist), id).Return(nil, mockArtist).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetArtistImagePath", file, mock.IsType(*mockArtist)).
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(*mockArtist)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -119,8 +120,7 @@ func TestSaveImageToArtist_WhenStorageUploadFails_ShouldReturnInternalServerErro
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
@@ -132,7 +132,10 @@ func TestSaveImageToArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError
 	artistRepository := new(repository.ArtistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := artist.NewSaveImageToArtist(artistRepository, storageFilePathProvider, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewSaveImageToArtist(artistRepository, storageFilePathProvider, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -142,12 +145,15 @@ func TestSaveImageToArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError
 	artistRepository.On("Get", new(model.Artist), id).Return(nil, mockArtist).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetArtistImagePath", file, mock.IsType(*mockArtist)).
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(*mockArtist)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("Update", mock.IsType(new(model.Artist))).
 		Return(internalError).
@@ -161,6 +167,8 @@ func TestSaveImageToArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -172,13 +180,18 @@ func TestSaveImageToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := artist.NewSaveImageToArtist(
 		artistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -187,18 +200,22 @@ func TestSaveImageToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	artistRepository.On("Get", new(model.Artist), id).Return(nil, mockArtist).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetArtistImagePath", file, mock.IsType(*mockArtist)).
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(*mockArtist)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(new(model.Artist))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(internalError).
 		Once()
 
@@ -210,6 +227,8 @@ func TestSaveImageToArtist_WhenPublishFails_ShouldReturnInternalServerError(t *t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -222,13 +241,18 @@ func TestSaveImageToArtist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyEr
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := artist.NewSaveImageToArtist(
 		artistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -239,9 +263,9 @@ func TestSaveImageToArtist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyEr
 	storageService.On("DeleteFile", *mockArtist.ImageURL).Return(nil).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetArtistImagePath", file, mock.IsType(*mockArtist)).
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(*mockArtist)).
 		Run(func(args mock.Arguments) {
-			newArtist := args.Get(1).(model.Artist)
+			newArtist := args.Get(0).(model.Artist)
 			assert.Equal(t, newArtist.ID, mockArtist.ID)
 			assert.WithinDuration(t, time.Now(), newArtist.UpdatedAt, time.Minute)
 		}).
@@ -250,6 +274,10 @@ func TestSaveImageToArtist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyEr
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(new(model.Artist))).
 		Run(func(args mock.Arguments) {
 			newArtist := args.Get(0).(*model.Artist)
@@ -258,7 +286,7 @@ func TestSaveImageToArtist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyEr
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(nil).
 		Once()
 
@@ -268,6 +296,8 @@ func TestSaveImageToArtist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyEr
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -280,13 +310,18 @@ func TestSaveImageToArtist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNot
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := artist.NewSaveImageToArtist(
 		artistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -295,9 +330,9 @@ func TestSaveImageToArtist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNot
 	artistRepository.On("Get", new(model.Artist), id).Return(nil, mockArtist).Once()
 
 	imagePath := "artists file path"
-	storageFilePathProvider.On("GetArtistImagePath", file, mock.IsType(*mockArtist)).
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(*mockArtist)).
 		Run(func(args mock.Arguments) {
-			newArtist := args.Get(1).(model.Artist)
+			newArtist := args.Get(0).(model.Artist)
 			assert.Equal(t, newArtist.ID, mockArtist.ID)
 			assert.WithinDuration(t, time.Now(), newArtist.UpdatedAt, time.Minute)
 		}).
@@ -306,6 +341,10 @@ func TestSaveImageToArtist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNot
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(new(model.Artist))).
 		Run(func(args mock.Arguments) {
 			newArtist := args.Get(0).(*model.Artist)
@@ -314,7 +353,7 @@ func TestSaveImageToArtist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNot
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(nil).
 		Once()
 
@@ -324,6 +363,8 @@ func TestSaveImageToArtist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndNot
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
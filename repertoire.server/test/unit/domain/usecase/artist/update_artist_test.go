@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/artist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestUpdateArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewUpdateArtist(artistRepository, nil)
+	_uut := artist.NewUpdateArtist(artistRepository, nil, nil)
 
 	request := requests.UpdateArtistRequest{
 		ID:   uuid.New(),
@@ -43,7 +44,7 @@ func TestUpdateArtist_WhenGetArtistFails_ShouldReturnInternalServerError(t *test
 func TestUpdateArtist_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewUpdateArtist(artistRepository, nil)
+	_uut := artist.NewUpdateArtist(artistRepository, nil, nil)
 
 	request := requests.UpdateArtistRequest{
 		ID:   uuid.New(),
@@ -66,7 +67,10 @@ func TestUpdateArtist_WhenArtistIsEmpty_ShouldReturnNotFoundError(t *testing.T)
 func TestUpdateArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
-	_uut := artist.NewUpdateArtist(artistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewUpdateArtist(artistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateArtistRequest{
 		ID:   uuid.New(),
@@ -79,6 +83,9 @@ func TestUpdateArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError(t *t
 	}
 
 	artistRepository.On("Get", new(model.Artist), request.ID).Return(nil, mockArtist).Once()
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Return(internalError).
@@ -91,6 +98,8 @@ func TestUpdateArtist_WhenUpdateArtistFails_ShouldReturnInternalServerError(t *t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 }
 
@@ -98,7 +107,11 @@ func TestUpdateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewUpdateArtist(artistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewUpdateArtist(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateArtistRequest{
 		ID:   uuid.New(),
@@ -112,12 +125,16 @@ func TestUpdateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 
 	artistRepository.On("Get", new(model.Artist), request.ID).Return(nil, mockArtist).Once()
 
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(internalError).
 		Once()
 
@@ -128,6 +145,8 @@ func TestUpdateArtist_WhenPublishFails_ShouldReturnInternalServerError(t *testin
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -136,7 +155,11 @@ func TestUpdateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	artistRepository := new(repository.ArtistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := artist.NewUpdateArtist(artistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := artist.NewUpdateArtist(artistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateArtistRequest{
 		ID:     uuid.New(),
@@ -151,6 +174,10 @@ func TestUpdateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	}
 
 	artistRepository.On("Get", new(model.Artist), request.ID).Return(nil, mockArtist).Once()
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	artistRepository.On("Update", mock.IsType(mockArtist)).
 		Run(func(args mock.Arguments) {
 			newArtist := args.Get(0).(*model.Artist)
@@ -160,7 +187,7 @@ func TestUpdateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.ArtistUpdatedTopic, mockArtist.ID).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, mockArtist.ID).
 		Return(nil).
 		Once()
 
@@ -170,6 +197,8 @@ func TestUpdateArtist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	artistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
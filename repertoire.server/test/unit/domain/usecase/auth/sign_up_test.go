@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/api/requests"
+	"repertoire/server/data/http/auth"
 	"repertoire/server/domain/usecase/user"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/logger"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"strings"
@@ -20,7 +22,7 @@ import (
 func TestAuthService_SignUp_WhenUserRepositoryReturnsError_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(nil, nil, userRepository)
+	_uut := user.NewSignUp(nil, nil, userRepository, nil)
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -34,10 +36,10 @@ func TestAuthService_SignUp_WhenUserRepositoryReturnsError_ShouldReturnInternalS
 		Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
@@ -48,7 +50,7 @@ func TestAuthService_SignUp_WhenUserRepositoryReturnsError_ShouldReturnInternalS
 func TestAuthService_SignUp_WhenUserIsNotEmpty_ShouldReturnUnauthorizedError(t *testing.T) {
 	// given
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(nil, nil, userRepository)
+	_uut := user.NewSignUp(nil, nil, userRepository, nil)
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -61,10 +63,10 @@ func TestAuthService_SignUp_WhenUserIsNotEmpty_ShouldReturnUnauthorizedError(t *
 		Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusBadRequest, errCode.Code)
 	assert.Equal(t, "user already exists", errCode.Error.Error())
@@ -76,7 +78,7 @@ func TestAuthService_SignUp_WhenHashPasswordFails_ShouldReturnInternalServerErro
 	// given
 	bCryptService := new(service.BCryptServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(nil, bCryptService, userRepository)
+	_uut := user.NewSignUp(nil, bCryptService, userRepository, nil)
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -92,10 +94,10 @@ func TestAuthService_SignUp_WhenHashPasswordFails_ShouldReturnInternalServerErro
 	bCryptService.On("Hash", request.Password).Return("", internalError).Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
@@ -108,7 +110,7 @@ func TestAuthService_SignUp_WhenCreateUserFails_ShouldReturnInternalServerError(
 	// given
 	bCryptService := new(service.BCryptServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(nil, bCryptService, userRepository)
+	_uut := user.NewSignUp(nil, bCryptService, userRepository, nil)
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -129,10 +131,10 @@ func TestAuthService_SignUp_WhenCreateUserFails_ShouldReturnInternalServerError(
 		Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
@@ -146,7 +148,7 @@ func TestAuthService_SignUp_WhenSignInFails_ShouldReturnInternalServerError(t *t
 	authService := new(service.AuthServiceMock)
 	bCryptService := new(service.BCryptServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(authService, bCryptService, userRepository)
+	_uut := user.NewSignUp(authService, bCryptService, userRepository, logger.NewLoggerMock())
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -167,14 +169,14 @@ func TestAuthService_SignUp_WhenSignInFails_ShouldReturnInternalServerError(t *t
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	authService.On("SignIn", strings.ToLower(request.Email), request.Password).
-		Return("", internalError).
+		Return(auth.SignInResponse{}, internalError).
 		Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
-	assert.Empty(t, token)
+	assert.Empty(t, tokens)
 	assert.NotNil(t, errCode)
 	assert.Equal(t, internalError, errCode)
 
@@ -183,12 +185,12 @@ func TestAuthService_SignUp_WhenSignInFails_ShouldReturnInternalServerError(t *t
 	bCryptService.AssertExpectations(t)
 }
 
-func TestAuthService_SignUp_WhenSuccessful_ShouldReturnNewToken(t *testing.T) {
+func TestAuthService_SignUp_WhenRequestEmailVerificationFails_ShouldStillReturnNewTokens(t *testing.T) {
 	// given
 	authService := new(service.AuthServiceMock)
 	bCryptService := new(service.BCryptServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewSignUp(authService, bCryptService, userRepository)
+	_uut := user.NewSignUp(authService, bCryptService, userRepository, logger.NewLoggerMock())
 
 	request := requests.SignUpRequest{
 		Name:     "Samuel",
@@ -213,17 +215,74 @@ func TestAuthService_SignUp_WhenSuccessful_ShouldReturnNewToken(t *testing.T) {
 		Return(nil).
 		Once()
 
-	expectedToken := "This is the generated token"
+	expectedTokens := auth.SignInResponse{
+		Token:        "This is the generated token",
+		RefreshToken: "This is the generated refresh token",
+	}
+	authService.On("SignIn", strings.ToLower(request.Email), request.Password).
+		Return(expectedTokens, nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	authService.On("RequestEmailVerification", expectedTokens.Token).Return(internalError).Once()
+
+	// when
+	tokens, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedTokens, tokens)
+
+	authService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	bCryptService.AssertExpectations(t)
+}
+
+func TestAuthService_SignUp_WhenSuccessful_ShouldReturnNewTokens(t *testing.T) {
+	// given
+	authService := new(service.AuthServiceMock)
+	bCryptService := new(service.BCryptServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewSignUp(authService, bCryptService, userRepository, logger.NewLoggerMock())
+
+	request := requests.SignUpRequest{
+		Name:     "Samuel",
+		Email:    "Samuel@yahoo.com",
+		Password: "Password123",
+	}
+
+	// given - mocking
+	userRepository.On("GetByEmail", new(model.User), strings.ToLower(request.Email)).
+		Return(nil).
+		Once()
+
+	hashedPassword := "hashed password"
+	bCryptService.On("Hash", request.Password).Return(hashedPassword, nil).Once()
+
+	var expectedUser *model.User
+	userRepository.On("Create", mock.IsType(expectedUser)).
+		Run(func(args mock.Arguments) {
+			expectedUser = args.Get(0).(*model.User)
+			assertCreatedUser(t, *expectedUser, request, hashedPassword)
+		}).
+		Return(nil).
+		Once()
+
+	expectedTokens := auth.SignInResponse{
+		Token:        "This is the generated token",
+		RefreshToken: "This is the generated refresh token",
+	}
 	authService.On("SignIn", strings.ToLower(request.Email), request.Password).
-		Return(expectedToken, nil).
+		Return(expectedTokens, nil).
 		Once()
+	authService.On("RequestEmailVerification", expectedTokens.Token).Return(nil).Once()
 
 	// when
-	token, errCode := _uut.Handle(request)
+	tokens, errCode := _uut.Handle(request)
 
 	// then
 	assert.Nil(t, errCode)
-	assert.Equal(t, expectedToken, token)
+	assert.Equal(t, expectedTokens, tokens)
 
 	authService.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
@@ -0,0 +1,100 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscardDeadLetterMessage_WhenGetFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewDiscardDeadLetterMessage(deadLetterRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestDiscardDeadLetterMessage_WhenMessageIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewDiscardDeadLetterMessage(deadLetterRepository)
+
+	id := uuid.New()
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "dead letter message not found", errCode.Error.Error())
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestDiscardDeadLetterMessage_WhenDeleteFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewDiscardDeadLetterMessage(deadLetterRepository)
+
+	deadLetterMessage := &model.DeadLetterMessage{ID: uuid.New()}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), deadLetterMessage.ID).
+		Return(nil, deadLetterMessage).
+		Once()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("Delete", deadLetterMessage.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(deadLetterMessage.ID)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestDiscardDeadLetterMessage_WhenSuccessful_ShouldDeleteTheMessage(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewDiscardDeadLetterMessage(deadLetterRepository)
+
+	deadLetterMessage := &model.DeadLetterMessage{ID: uuid.New()}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), deadLetterMessage.ID).
+		Return(nil, deadLetterMessage).
+		Once()
+	deadLetterRepository.On("Delete", deadLetterMessage.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(deadLetterMessage.ID)
+
+	// then
+	assert.Nil(t, errCode)
+
+	deadLetterRepository.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAllDeadLetterMessages_WhenGetAllFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetAllDeadLetterMessages(deadLetterRepository)
+
+	request := requests.GetDeadLetterMessagesRequest{}
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.
+		On("GetAll", new([]model.DeadLetterMessage), request.CurrentPage, request.PageSize, request.Topic).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestGetAllDeadLetterMessages_WhenGetAllCountFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetAllDeadLetterMessages(deadLetterRepository)
+
+	request := requests.GetDeadLetterMessagesRequest{}
+
+	deadLetterRepository.
+		On("GetAll", new([]model.DeadLetterMessage), request.CurrentPage, request.PageSize, request.Topic).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("GetAllCount", new(int64), request.Topic).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestGetAllDeadLetterMessages_WhenSuccessful_ShouldReturnMessagesWithTotalCount(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetAllDeadLetterMessages(deadLetterRepository)
+
+	request := requests.GetDeadLetterMessagesRequest{
+		CurrentPage: &[]int{1}[0],
+		PageSize:    &[]int{10}[0],
+		Topic:       &[]string{"some_topic"}[0],
+	}
+
+	expectedMessages := &[]model.DeadLetterMessage{
+		{ID: uuid.New(), Topic: "some_topic"},
+		{ID: uuid.New(), Topic: "some_topic"},
+	}
+	expectedTotalCount := &[]int64{20}[0]
+
+	deadLetterRepository.
+		On("GetAll", new([]model.DeadLetterMessage), request.CurrentPage, request.PageSize, request.Topic).
+		Return(nil, expectedMessages).
+		Once()
+	deadLetterRepository.On("GetAllCount", new(int64), request.Topic).
+		Return(nil, expectedTotalCount).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, *expectedMessages, result.Models)
+	assert.Equal(t, *expectedTotalCount, result.TotalCount)
+
+	deadLetterRepository.AssertExpectations(t)
+}
@@ -0,0 +1,77 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeadLetterMessage_WhenGetFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetDeadLetterMessage(deadLetterRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(internalError).Once()
+
+	// when
+	deadLetterMessage, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, deadLetterMessage)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestGetDeadLetterMessage_WhenMessageIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetDeadLetterMessage(deadLetterRepository)
+
+	id := uuid.New()
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(nil).Once()
+
+	// when
+	deadLetterMessage, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, deadLetterMessage)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "dead letter message not found", errCode.Error.Error())
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestGetDeadLetterMessage_WhenSuccessful_ShouldReturnTheMessage(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetDeadLetterMessage(deadLetterRepository)
+
+	expectedMessage := &model.DeadLetterMessage{ID: uuid.New(), Topic: "some_topic"}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), expectedMessage.ID).
+		Return(nil, expectedMessage).
+		Once()
+
+	// when
+	deadLetterMessage, errCode := _uut.Handle(expectedMessage.ID)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, *expectedMessage, deadLetterMessage)
+
+	deadLetterRepository.AssertExpectations(t)
+}
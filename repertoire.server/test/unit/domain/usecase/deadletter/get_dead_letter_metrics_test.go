@@ -0,0 +1,58 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetDeadLetterMetrics_WhenGetMetricsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetDeadLetterMetrics(deadLetterRepository)
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("GetMetricsByTopic", mock.IsType(new([]model.DeadLetterTopicMetrics))).
+		Return(internalError).
+		Once()
+
+	// when
+	metrics, errCode := _uut.Handle()
+
+	// then
+	assert.Nil(t, metrics)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestGetDeadLetterMetrics_WhenSuccessful_ShouldReturnMetrics(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewGetDeadLetterMetrics(deadLetterRepository)
+
+	expectedMetrics := &[]model.DeadLetterTopicMetrics{
+		{Topic: "some_topic", Count: 12},
+		{Topic: "another_topic", Count: 1},
+	}
+	deadLetterRepository.On("GetMetricsByTopic", mock.IsType(new([]model.DeadLetterTopicMetrics))).
+		Return(nil, expectedMetrics).
+		Once()
+
+	// when
+	metrics, errCode := _uut.Handle()
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, *expectedMetrics, metrics)
+
+	deadLetterRepository.AssertExpectations(t)
+}
@@ -0,0 +1,135 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/deadletter"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayDeadLetterMessage_WhenGetFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewReplayDeadLetterMessage(deadLetterRepository, nil)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestReplayDeadLetterMessage_WhenMessageIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	_uut := deadletter.NewReplayDeadLetterMessage(deadLetterRepository, nil)
+
+	id := uuid.New()
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), id).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "dead letter message not found", errCode.Error.Error())
+
+	deadLetterRepository.AssertExpectations(t)
+}
+
+func TestReplayDeadLetterMessage_WhenReplayFails_ShouldReturnInternalServerErrorAndKeepTheMessage(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := deadletter.NewReplayDeadLetterMessage(deadLetterRepository, messagePublisherService)
+
+	deadLetterMessage := &model.DeadLetterMessage{ID: uuid.New(), Topic: "some_topic", Handler: "some_handler"}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), deadLetterMessage.ID).
+		Return(nil, deadLetterMessage).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("Replay", *deadLetterMessage).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(deadLetterMessage.ID)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	deadLetterRepository.AssertNotCalled(t, "Delete")
+}
+
+func TestReplayDeadLetterMessage_WhenDeleteFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := deadletter.NewReplayDeadLetterMessage(deadLetterRepository, messagePublisherService)
+
+	deadLetterMessage := &model.DeadLetterMessage{ID: uuid.New(), Topic: "some_topic", Handler: "some_handler"}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), deadLetterMessage.ID).
+		Return(nil, deadLetterMessage).
+		Once()
+	messagePublisherService.On("Replay", *deadLetterMessage).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	deadLetterRepository.On("Delete", deadLetterMessage.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(deadLetterMessage.ID)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	deadLetterRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestReplayDeadLetterMessage_WhenSuccessful_ShouldReplayAndDeleteTheMessage(t *testing.T) {
+	// given
+	deadLetterRepository := new(repository.DeadLetterRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := deadletter.NewReplayDeadLetterMessage(deadLetterRepository, messagePublisherService)
+
+	deadLetterMessage := &model.DeadLetterMessage{ID: uuid.New(), Topic: "some_topic", Handler: "some_handler"}
+
+	deadLetterRepository.On("Get", new(model.DeadLetterMessage), deadLetterMessage.ID).
+		Return(nil, deadLetterMessage).
+		Once()
+	messagePublisherService.On("Replay", *deadLetterMessage).Return(nil).Once()
+	deadLetterRepository.On("Delete", deadLetterMessage.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(deadLetterMessage.ID)
+
+	// then
+	assert.Nil(t, errCode)
+
+	deadLetterRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
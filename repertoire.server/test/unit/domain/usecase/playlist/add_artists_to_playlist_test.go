@@ -15,6 +15,62 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func TestAddArtistToPlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewAddArtistsToPlaylist(playlistRepository, nil)
+
+	request := requests.AddArtistsToPlaylistRequest{
+		ID:        uuid.New(),
+		ArtistIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	res, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, res)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestAddArtistToPlaylist_WhenPlaylistIsSmart_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewAddArtistsToPlaylist(playlistRepository, nil)
+
+	request := requests.AddArtistsToPlaylistRequest{
+		ID:        uuid.New(),
+		ArtistIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	mockPlaylist := &model.Playlist{ID: request.ID, SmartRules: &model.PlaylistSmartRules{}}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	// when
+	res, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, res)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "songs cannot be added to a smart playlist", errCode.Error.Error())
+
+	playlistRepository.AssertExpectations(t)
+}
+
 func TestAddArtistToPlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
@@ -26,6 +82,10 @@ func TestAddArtistToPlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServe
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("GetPlaylistSongs", mock.Anything, request.ID).
 		Return(internalError).
@@ -55,6 +115,10 @@ func TestAddArtistsToPlaylist_WhenGetArtistsFails_ShouldReturnInternalServerErro
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := &[]model.PlaylistSong{}
 	playlistRepository.On("GetPlaylistSongs", mock.IsType(playlistSongs), request.ID).
 		Return(nil, playlistSongs).
@@ -90,6 +154,10 @@ func TestAddArtistsToPlaylist_WhenAddSongsFails_ShouldReturnInternalServerError(
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := &[]model.PlaylistSong{}
 	playlistRepository.On("GetPlaylistSongs", mock.IsType(playlistSongs), request.ID).
 		Return(nil, playlistSongs).
@@ -130,6 +198,10 @@ func TestAddArtistsToPlaylist_WhenWithDuplicatesButWithoutForceAdd_ShouldReturnN
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := []model.PlaylistSong{
 		{SongID: uuid.New()},
 		{SongID: uuid.New()},
@@ -176,6 +248,10 @@ func TestAddArtistsToPlaylist_WhenWithoutDuplicatesNorForceAdd_ShouldReturnSucce
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := []model.PlaylistSong{
 		{SongID: uuid.New()},
 	}
@@ -251,6 +327,10 @@ func TestAddArtistsToPlaylist_WhenWithDuplicatesAndForceAddTrue_ShouldAddDuplica
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistRepository.On("GetPlaylistSongs", mock.IsType(new([]model.PlaylistSong)), request.ID).
 		Return(nil, &playlistSongs).
 		Once()
@@ -321,6 +401,10 @@ func TestAddArtistsToPlaylist_WhenWithDuplicatesAndForceAddFalse_ShouldSkipDupli
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistRepository.On("GetPlaylistSongs", mock.IsType(new([]model.PlaylistSong)), request.ID).
 		Return(nil, &playlistSongs).
 		Once()
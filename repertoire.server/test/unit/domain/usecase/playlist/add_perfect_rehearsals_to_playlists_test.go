@@ -20,7 +20,7 @@ import (
 func TestAddPerfectPlaylistRehearsals_WhenGetPlaylistsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -45,7 +45,7 @@ func TestAddPerfectPlaylistRehearsals_WhenGetPlaylistsFails_ShouldReturnInternal
 func TestAddPerfectPlaylistRehearsals_WhenPlaylistsLenIs0_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, nil, nil, nil)
 
 	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -71,7 +71,7 @@ func TestAddPerfectPlaylistRehearsals_WhenTransactionExecuteFails_ShouldReturnEr
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songProcessor, transactionManager)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, nil, transactionManager)
 
 	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -103,7 +103,7 @@ func TestAddPerfectPlaylistRehearsals_WhenProcessorFails_ShouldReturnInternalSer
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songProcessor, transactionManager)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -127,7 +127,7 @@ func TestAddPerfectPlaylistRehearsals_WhenProcessorFails_ShouldReturnInternalSer
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(internalError, false).
+		Return(internalError, nil).
 		Times(len(mockPlaylists[0].Songs))
 
 	// when
@@ -150,7 +150,7 @@ func TestAddPerfectPlaylistRehearsals_WhenUpdateFails_ShouldReturnInternalServer
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songProcessor, transactionManager)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -173,7 +173,7 @@ func TestAddPerfectPlaylistRehearsals_WhenUpdateFails_ShouldReturnInternalServer
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 		Times(len(mockPlaylists[0].Songs))
 
 	internalError := errors.New("internal error")
@@ -202,7 +202,7 @@ func TestAddPerfectPlaylistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSong
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songProcessor, transactionManager)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -230,7 +230,7 @@ func TestAddPerfectPlaylistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSong
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, false).
+		Return(nil, nil).
 		Times(len(mockPlaylists[0].Songs))
 
 	// when
@@ -247,16 +247,105 @@ func TestAddPerfectPlaylistRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSong
 	transactionSongRepository.AssertExpectations(t)
 }
 
+func TestAddPerfectPlaylistRehearsals_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, practiceSessionProcessor, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
+		IDs: []uuid.UUID{
+			uuid.New(),
+			uuid.New(),
+			uuid.New(),
+		},
+	}
+
+	mockPlaylists := []model.Playlist{
+		{
+			ID: request.IDs[0],
+			PlaylistSongs: []model.PlaylistSong{
+				{ID: uuid.New(), Song: model.Song{ID: uuid.New()}},
+				{ID: uuid.New(), Song: model.Song{ID: uuid.New()}},
+			},
+		},
+		{
+			ID: request.IDs[1],
+			PlaylistSongs: []model.PlaylistSong{
+				{ID: uuid.New(), Song: model.Song{ID: uuid.New()}},
+			},
+		},
+		{ID: request.IDs[2]},
+	}
+	playlistRepository.On("GetAllByIDsWithSongSections", new([]model.Playlist), request.IDs).
+		Return(nil, &mockPlaylists).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	var playlistSongs []model.Song
+	for _, a := range mockPlaylists {
+		for _, ps := range a.PlaylistSongs {
+			songProcessor.On("AddPerfectRehearsal", &ps.Song, transactionSongSectionRepository).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+				Once()
+			playlistSongs = append(playlistSongs, ps.Song)
+		}
+	}
+
+	transactionSongRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.Len(t, *newSongs, len(playlistSongs))
+			assert.ElementsMatch(t, *newSongs, playlistSongs)
+		}).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mock.IsType(model.Song{}), mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	playlistRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
 func TestAddPerfectPlaylistRehearsals_WhenSuccessful_ShouldUpdatePlaylists(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songProcessor, transactionManager)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, nil, songProcessor, practiceSessionProcessor, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
 		IDs: []uuid.UUID{
@@ -288,13 +377,14 @@ func TestAddPerfectPlaylistRehearsals_WhenSuccessful_ShouldUpdatePlaylists(t *te
 
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	var playlistSongs []model.Song
 	for _, a := range mockPlaylists {
 		for _, ps := range a.PlaylistSongs {
 			songProcessor.On("AddPerfectRehearsal", &ps.Song, transactionSongSectionRepository).
-				Return(nil, true).
+				Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 				Once()
 			playlistSongs = append(playlistSongs, ps.Song)
 		}
@@ -309,6 +399,179 @@ func TestAddPerfectPlaylistRehearsals_WhenSuccessful_ShouldUpdatePlaylists(t *te
 		Return(nil).
 		Once()
 
+	for _, s := range playlistSongs {
+		practiceSessionProcessor.On("RecordPerfectRehearsal", s, mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+			Return(nil).
+			Once()
+	}
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	playlistRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
+func TestAddPerfectPlaylistRehearsals_WhenGetSmartPlaylistSongIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, nil, playlistProcessor, nil, nil, nil)
+
+	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
+		IDs: []uuid.UUID{uuid.New()},
+	}
+
+	mockPlaylists := []model.Playlist{
+		{
+			ID:         request.IDs[0],
+			SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+		},
+	}
+	playlistRepository.On("GetAllByIDsWithSongSections", new([]model.Playlist), request.IDs).
+		Return(nil, &mockPlaylists).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	playlistProcessor.On("GetSmartPlaylistSongIDs", mockPlaylists[0]).Return(nil, internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestAddPerfectPlaylistRehearsals_WhenGetSmartPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(playlistRepository, songRepository, playlistProcessor, nil, nil, nil)
+
+	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
+		IDs: []uuid.UUID{uuid.New()},
+	}
+
+	mockPlaylists := []model.Playlist{
+		{
+			ID:         request.IDs[0],
+			SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+		},
+	}
+	playlistRepository.On("GetAllByIDsWithSongSections", new([]model.Playlist), request.IDs).
+		Return(nil, &mockPlaylists).
+		Once()
+
+	songIDs := []uuid.UUID{uuid.New()}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", mockPlaylists[0]).Return(songIDs, nil).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllByIDsWithSections", new([]model.Song), songIDs).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestAddPerfectPlaylistRehearsals_WhenPlaylistIsSmart_ShouldAddRehearsalsToTheSongsMatchingTheRules(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewAddPerfectRehearsalsToPlaylists(
+		playlistRepository,
+		songRepository,
+		playlistProcessor,
+		songProcessor,
+		practiceSessionProcessor,
+		transactionManager,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectRehearsalsToPlaylistsRequest{
+		IDs: []uuid.UUID{uuid.New(), uuid.New()},
+	}
+
+	mockPlaylists := []model.Playlist{
+		{
+			ID:         request.IDs[0],
+			SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+		},
+		{
+			ID:         request.IDs[1],
+			SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence > 90"}},
+		},
+	}
+	playlistRepository.On("GetAllByIDsWithSongSections", new([]model.Playlist), request.IDs).
+		Return(nil, &mockPlaylists).
+		Once()
+
+	smartSongs := &[]model.Song{{ID: uuid.New()}, {ID: uuid.New()}}
+	songIDs := []uuid.UUID{(*smartSongs)[0].ID, (*smartSongs)[1].ID}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", mockPlaylists[0]).Return(songIDs, nil).Once()
+	songRepository.On("GetAllByIDsWithSections", new([]model.Song), songIDs).
+		Return(nil, smartSongs).
+		Once()
+
+	// the songs of a smart playlist that matches nothing are not fetched
+	playlistProcessor.On("GetSmartPlaylistSongIDs", mockPlaylists[1]).Return([]uuid.UUID{}, nil).Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	for _, s := range *smartSongs {
+		songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
+			Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+			Once()
+	}
+
+	transactionSongRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.ElementsMatch(t, *newSongs, *smartSongs)
+		}).
+		Return(nil).
+		Once()
+
+	for _, s := range *smartSongs {
+		practiceSessionProcessor.On("RecordPerfectRehearsal", s, mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+			Return(nil).
+			Once()
+	}
+
 	// when
 	errCode := _uut.Handle(request)
 
@@ -316,9 +579,13 @@ func TestAddPerfectPlaylistRehearsals_WhenSuccessful_ShouldUpdatePlaylists(t *te
 	assert.Nil(t, errCode)
 
 	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
 	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	repositoryFactory.AssertExpectations(t)
 	transactionSongSectionRepository.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
 }
@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/playlist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestBulkDeletePlaylists_WhenGetPlaylistsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil)
+	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil, nil)
 
 	request := requests.BulkDeletePlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -44,7 +45,7 @@ func TestBulkDeletePlaylists_WhenGetPlaylistsFails_ShouldReturnInternalServerErr
 func TestBulkDeletePlaylists_WhenPlaylistsAreEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil)
+	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil, nil)
 
 	request := requests.BulkDeletePlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -68,7 +69,10 @@ func TestBulkDeletePlaylists_WhenPlaylistsAreEmpty_ShouldReturnNotFoundError(t *
 func TestBulkDeletePlaylists_WhenDeletePlaylistsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeletePlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -79,6 +83,9 @@ func TestBulkDeletePlaylists_WhenDeletePlaylistsFails_ShouldReturnInternalServer
 		Return(nil, mockPlaylists).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Delete", request.IDs).Return(internalError).Once()
 
@@ -90,6 +97,8 @@ func TestBulkDeletePlaylists_WhenDeletePlaylistsFails_ShouldReturnInternalServer
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 }
 
@@ -97,7 +106,11 @@ func TestBulkDeletePlaylists_WhenPublishFails_ShouldReturnInternalServerError(t
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.BulkDeletePlaylistsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -108,10 +121,14 @@ func TestBulkDeletePlaylists_WhenPublishFails_ShouldReturnInternalServerError(t
 		Return(nil, mockPlaylists).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Delete", request.IDs).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistsDeletedTopic, *mockPlaylists).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistsDeletedTopic, *mockPlaylists).
 		Return(internalError).
 		Once()
 
@@ -123,6 +140,8 @@ func TestBulkDeletePlaylists_WhenPublishFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -131,7 +150,11 @@ func TestBulkDeletePlaylists_WhenIsSuccessful_ShouldDeletePlaylists(t *testing.T
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewBulkDeletePlaylists(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.BulkDeletePlaylistsRequest{
 		IDs: []uuid.UUID{
@@ -150,9 +173,13 @@ func TestBulkDeletePlaylists_WhenIsSuccessful_ShouldDeletePlaylists(t *testing.T
 	playlistRepository.On("GetAllByIDs", mock.IsType(mockPlaylists), request.IDs).
 		Return(nil, mockPlaylists).
 		Once()
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Delete", request.IDs).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistsDeletedTopic, *mockPlaylists).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistsDeletedTopic, *mockPlaylists).
 		Return(nil).
 		Once()
 
@@ -162,6 +189,8 @@ func TestBulkDeletePlaylists_WhenIsSuccessful_ShouldDeletePlaylists(t *testing.T
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -45,7 +46,10 @@ func TestCreatePlaylist_WhenCreateFails_ShouldReturnInternalServerError(t *testi
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := playlist.NewCreatePlaylist(jwtService, playlistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewCreatePlaylist(jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreatePlaylistRequest{
 		Title: "Some Playlist",
@@ -54,6 +58,9 @@ func TestCreatePlaylist_WhenCreateFails_ShouldReturnInternalServerError(t *testi
 	userID := uuid.New()
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Create", mock.IsType(new(model.Playlist))).
 		Return(internalError).
@@ -68,6 +75,8 @@ func TestCreatePlaylist_WhenCreateFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 }
@@ -77,7 +86,11 @@ func TestCreatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewCreatePlaylist(jwtService, playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewCreatePlaylist(jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreatePlaylistRequest{
 		Title: "Some Playlist",
@@ -87,12 +100,16 @@ func TestCreatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Create", mock.IsType(new(model.Playlist))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistCreatedTopic, mock.IsType(model.Playlist{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistCreatedTopic, mock.IsType(model.Playlist{})).
 		Return(internalError).
 		Once()
 
@@ -105,6 +122,8 @@ func TestCreatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -115,16 +134,29 @@ func TestCreatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	jwtService := new(service.JwtServiceMock)
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewCreatePlaylist(jwtService, playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewCreatePlaylist(jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreatePlaylistRequest{
 		Title: "Some Playlist",
+		SmartRules: &requests.PlaylistSmartRulesRequest{
+			SearchBy: []string{"confidence < 60"},
+			OrderBy:  []string{"progress asc"},
+			Limit:    &[]uint{10}[0],
+		},
 	}
 	token := "this is a token"
 	userID := uuid.New()
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var createdPlaylist model.Playlist
 	playlistRepository.On("Create", mock.IsType(new(model.Playlist))).
 		Run(func(args mock.Arguments) {
@@ -135,9 +167,9 @@ func TestCreatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistCreatedTopic, mock.IsType(model.Playlist{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistCreatedTopic, mock.IsType(model.Playlist{})).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, createdPlaylist, args.Get(1).(model.Playlist))
+			assert.Equal(t, createdPlaylist, args.Get(2).(model.Playlist))
 		}).
 		Return(nil).
 		Once()
@@ -149,6 +181,8 @@ func TestCreatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	assert.Equal(t, createdPlaylist.ID, id)
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -164,4 +198,11 @@ func assertCreatedPlaylist(
 	assert.Equal(t, request.Description, playlist.Description)
 	assert.Equal(t, userID, playlist.UserID)
 	assert.Nil(t, playlist.ImageURL)
+	if request.SmartRules == nil {
+		assert.Nil(t, playlist.SmartRules)
+	} else {
+		assert.Equal(t, request.SmartRules.SearchBy, playlist.SmartRules.SearchBy)
+		assert.Equal(t, request.SmartRules.OrderBy, playlist.SmartRules.OrderBy)
+		assert.Equal(t, request.SmartRules.Limit, playlist.SmartRules.Limit)
+	}
 }
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -20,7 +21,7 @@ import (
 func TestDeleteImageFromPlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -42,7 +43,7 @@ func TestDeleteImageFromPlaylist_WhenGetPlaylistFails_ShouldReturnInternalServer
 func TestDeleteImageFromPlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -63,7 +64,7 @@ func TestDeleteImageFromPlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t
 func TestDeleteImageFromPlaylist_WhenPlaylistHasNoImage_ShouldReturnConflictError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -86,7 +87,7 @@ func TestDeleteImageFromPlaylist_WhenDeleteImageFails_ShouldReturnInternalServer
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, nil)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, nil, nil)
 
 	id := uuid.New()
 
@@ -112,7 +113,10 @@ func TestDeleteImageFromPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalSer
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -122,6 +126,9 @@ func TestDeleteImageFromPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalSer
 
 	storageService.On("DeleteFile", *mockPlaylist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Return(internalError).
@@ -135,6 +142,8 @@ func TestDeleteImageFromPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalSer
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
@@ -144,7 +153,11 @@ func TestDeleteImageFromPlaylist_WhenPublishFails_ShouldReturnInternalServerErro
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -154,12 +167,16 @@ func TestDeleteImageFromPlaylist_WhenPublishFails_ShouldReturnInternalServerErro
 
 	storageService.On("DeleteFile", *mockPlaylist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Return(internalError).
 		Once()
 	// when
@@ -170,6 +187,8 @@ func TestDeleteImageFromPlaylist_WhenPublishFails_ShouldReturnInternalServerErro
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -180,7 +199,11 @@ func TestDeleteImageFromPlaylist_WhenIsValid_ShouldNotReturnAnyError(t *testing.
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeleteImageFromPlaylist(playlistRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -190,6 +213,10 @@ func TestDeleteImageFromPlaylist_WhenIsValid_ShouldNotReturnAnyError(t *testing.
 
 	storageService.On("DeleteFile", *mockPlaylist.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var newPlaylist *model.Playlist
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Run(func(args mock.Arguments) {
@@ -199,9 +226,9 @@ func TestDeleteImageFromPlaylist_WhenIsValid_ShouldNotReturnAnyError(t *testing.
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, *newPlaylist, args.Get(1).(model.Playlist))
+			assert.Equal(t, *newPlaylist, args.Get(2).(model.Playlist))
 		}).
 		Return(nil).
 		Once()
@@ -212,6 +239,8 @@ func TestDeleteImageFromPlaylist_WhenIsValid_ShouldNotReturnAnyError(t *testing.
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -6,6 +6,7 @@ import (
 	"repertoire/server/domain/usecase/playlist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestDeletePlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeletePlaylist(playlistRepository, nil)
+	_uut := playlist.NewDeletePlaylist(playlistRepository, nil, nil)
 
 	id := uuid.New()
 
@@ -40,7 +41,7 @@ func TestDeletePlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *
 func TestDeletePlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeletePlaylist(playlistRepository, nil)
+	_uut := playlist.NewDeletePlaylist(playlistRepository, nil, nil)
 
 	id := uuid.New()
 
@@ -60,13 +61,19 @@ func TestDeletePlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing
 func TestDeletePlaylist_WhenDeletePlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewDeletePlaylist(playlistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeletePlaylist(playlistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
 	mockPlaylist := &model.Playlist{ID: id}
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Delete", []uuid.UUID{id}).Return(internalError).Once()
 
@@ -78,6 +85,8 @@ func TestDeletePlaylist_WhenDeletePlaylistFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 }
 
@@ -85,7 +94,11 @@ func TestDeletePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewDeletePlaylist(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeletePlaylist(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -94,10 +107,14 @@ func TestDeletePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	}
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistsDeletedTopic, mock.IsType([]model.Playlist{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistsDeletedTopic, mock.IsType([]model.Playlist{})).
 		Return(internalError).
 		Once()
 
@@ -109,6 +126,8 @@ func TestDeletePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -117,16 +136,24 @@ func TestDeletePlaylist_WhenSuccessful_ShouldDeletePlaylist(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewDeletePlaylist(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewDeletePlaylist(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	expectedPlaylist := model.Playlist{ID: uuid.New()}
 	playlistRepository.On("Get", new(model.Playlist), expectedPlaylist.ID).
 		Return(nil, &expectedPlaylist).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Delete", []uuid.UUID{expectedPlaylist.ID}).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistsDeletedTopic, []model.Playlist{expectedPlaylist}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistsDeletedTopic, []model.Playlist{expectedPlaylist}).
 		Return(nil).
 		Once()
 
@@ -136,6 +163,8 @@ func TestDeletePlaylist_WhenSuccessful_ShouldDeletePlaylist(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
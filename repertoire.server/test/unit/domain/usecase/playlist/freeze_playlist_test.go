@@ -0,0 +1,249 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/playlist"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFreezePlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, nil, nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, nil, nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "playlist not found", errCode.Error.Error())
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenPlaylistIsNotSmart_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, nil, nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{ID: request.ID}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "playlist is not smart", errCode.Error.Error())
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenGetSmartPlaylistSongIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, playlistProcessor, nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ID,
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(nil, internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, playlistProcessor, nil)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ID,
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return([]uuid.UUID{}, nil).Once()
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenTransactionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, playlistProcessor, transactionManager)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ID,
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return([]uuid.UUID{}, nil).Once()
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionManager.On("Execute", mock.Anything).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+}
+
+func TestFreezePlaylist_WhenSuccessful_ShouldReplaceTheSongsWithTheMatchingOnesAndRemoveTheRules(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewFreezePlaylist(playlistRepository, playlistProcessor, transactionManager)
+
+	request := requests.FreezePlaylistRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{
+		ID:     request.ID,
+		UserID: uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{
+			SearchBy: []string{"confidence < 60"},
+			OrderBy:  []string{"progress asc"},
+			Limit:    &[]uint{2}[0],
+		},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(songIDs, nil).Once()
+
+	oldPlaylistSongs := &[]model.PlaylistSong{{ID: uuid.New(), PlaylistID: request.ID, SongID: uuid.New()}}
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
+		Return(nil, oldPlaylistSongs).
+		Once()
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionPlaylistRepository := new(repository.PlaylistRepositoryMock)
+	repositoryFactory.On("NewPlaylistRepository").Return(transactionPlaylistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	transactionPlaylistRepository.On("RemoveSongs", oldPlaylistSongs).Return(nil).Once()
+	transactionPlaylistRepository.On("AddSongs", mock.IsType(new([]model.PlaylistSong))).
+		Run(func(args mock.Arguments) {
+			playlistSongs := args.Get(0).(*[]model.PlaylistSong)
+			assert.Len(t, *playlistSongs, len(songIDs))
+			for i, playlistSong := range *playlistSongs {
+				assert.NotEmpty(t, playlistSong.ID)
+				assert.Equal(t, request.ID, playlistSong.PlaylistID)
+				assert.Equal(t, songIDs[i], playlistSong.SongID)
+				assert.Equal(t, uint(i+1), playlistSong.SongTrackNo)
+			}
+		}).
+		Return(nil).
+		Once()
+	transactionPlaylistRepository.On("Update", mock.IsType(new(model.Playlist))).
+		Run(func(args mock.Arguments) {
+			newPlaylist := args.Get(0).(*model.Playlist)
+			assert.Equal(t, request.ID, newPlaylist.ID)
+			assert.Nil(t, newPlaylist.SmartRules)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionPlaylistRepository.AssertExpectations(t)
+}
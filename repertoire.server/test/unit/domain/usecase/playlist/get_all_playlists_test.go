@@ -19,7 +19,7 @@ import (
 func TestGetAll_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	_uut := playlist.NewGetAllPlaylists(nil, jwtService)
+	_uut := playlist.NewGetAllPlaylists(nil, nil, jwtService)
 
 	request := requests.GetPlaylistsRequest{}
 	token := "This is a token"
@@ -42,7 +42,7 @@ func TestGetAll_WhenGetPlaylistsFails_ShouldReturnInternalServerError(t *testing
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := playlist.NewGetAllPlaylists(playlistRepository, jwtService)
+	_uut := playlist.NewGetAllPlaylists(playlistRepository, nil, jwtService)
 
 	request := requests.GetPlaylistsRequest{}
 	token := "This is a token"
@@ -77,11 +77,60 @@ func TestGetAll_WhenGetPlaylistsFails_ShouldReturnInternalServerError(t *testing
 	jwtService.AssertExpectations(t)
 }
 
+func TestGetAll_WhenGetSmartPlaylistTotalsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := playlist.NewGetAllPlaylists(playlistRepository, songRepository, jwtService)
+
+	request := requests.GetPlaylistsRequest{}
+	token := "This is a token"
+
+	expectedPlaylists := &[]model.EnhancedPlaylist{
+		{Playlist: model.Playlist{Title: "Some Playlist", SmartRules: &model.PlaylistSmartRules{}}},
+	}
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	playlistRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(expectedPlaylists),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, expectedPlaylists).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetSmartPlaylistTotals", mock.IsType(new(model.EnhancedPlaylist))).
+		Return(internalError).
+		Once()
+
+	// when
+	_, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
 func TestGetAll_WhenGetPlaylistsCountFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := playlist.NewGetAllPlaylists(playlistRepository, jwtService)
+	_uut := playlist.NewGetAllPlaylists(playlistRepository, nil, jwtService)
 
 	request := requests.GetPlaylistsRequest{}
 	token := "This is a token"
@@ -136,7 +185,7 @@ func TestGetAll_WhenSuccessful_ShouldReturnPlaylistsWithTotalCount(t *testing.T)
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := playlist.NewGetAllPlaylists(playlistRepository, jwtService)
+	_uut := playlist.NewGetAllPlaylists(playlistRepository, nil, jwtService)
 
 	request := requests.GetPlaylistsRequest{}
 	token := "This is a token"
@@ -185,3 +234,68 @@ func TestGetAll_WhenSuccessful_ShouldReturnPlaylistsWithTotalCount(t *testing.T)
 	playlistRepository.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 }
+
+func TestGetAll_WhenPlaylistsAreSmart_ShouldReturnThemWithTheTotalsOfTheirRules(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := playlist.NewGetAllPlaylists(playlistRepository, songRepository, jwtService)
+
+	request := requests.GetPlaylistsRequest{}
+	token := "This is a token"
+
+	playlists := &[]model.EnhancedPlaylist{
+		{Playlist: model.Playlist{Title: "Some Playlist"}, SongsCount: 2, TotalDuration: 300},
+		{Playlist: model.Playlist{Title: "Some Smart Playlist", SmartRules: &model.PlaylistSmartRules{}}},
+	}
+	expectedTotalCount := &[]int64{2}[0]
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	playlistRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(playlists),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, playlists).
+		Once()
+
+	smartPlaylist := (*playlists)[1]
+	smartPlaylist.SongsCount = 5
+	smartPlaylist.TotalDuration = 1000
+	songRepository.On("GetSmartPlaylistTotals", mock.IsType(new(model.EnhancedPlaylist))).
+		Return(nil, &smartPlaylist).
+		Once()
+
+	playlistRepository.
+		On(
+			"GetAllByUserCount",
+			mock.IsType(expectedTotalCount),
+			userID,
+			request.SearchBy,
+		).
+		Return(nil, expectedTotalCount).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedTotalCount, &result.TotalCount)
+	assert.Len(t, result.Models, 2)
+	assert.Equal(t, (*playlists)[0], result.Models[0])
+	assert.Equal(t, smartPlaylist, result.Models[1])
+
+	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/provider"
@@ -28,6 +29,7 @@ func TestSaveImageToPlaylist_WhenGetPlaylistFails_ShouldReturnNotFoundError(t *t
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -56,6 +58,7 @@ func TestSaveImageToPlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *te
 		nil,
 		nil,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -84,6 +87,7 @@ func TestSaveImageToPlaylist_WhenStorageDeleteFileFails_ShouldReturnError(t *tes
 		nil,
 		storageService,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -107,7 +111,7 @@ func TestSaveImageToPlaylist_WhenStorageDeleteFileFails_ShouldReturnError(t *tes
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveImageToPlaylist_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveImageToPlaylist_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
@@ -117,6 +121,7 @@ func TestSaveImageToPlaylist_WhenStorageUploadFails_ShouldReturnInternalServerEr
 		storageFilePathProvider,
 		storageService,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -127,11 +132,11 @@ func TestSaveImageToPlaylist_WhenStorageUploadFails_ShouldReturnInternalServerEr
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
 	imagePath := "playlists file path"
-	storageFilePathProvider.On("GetPlaylistImagePath", file, mock.IsType(*mockPlaylist)).
+	storageFilePathProvider.On("GetPlaylistImagePath", mock.IsType(*mockPlaylist)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -139,8 +144,7 @@ func TestSaveImageToPlaylist_WhenStorageUploadFails_ShouldReturnInternalServerEr
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	playlistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
@@ -152,13 +156,17 @@ func TestSaveImageToPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerE
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := playlist.NewSaveImageToPlaylist(
 		playlistRepository,
 		storageFilePathProvider,
 		storageService,
 		nil,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -167,12 +175,15 @@ func TestSaveImageToPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerE
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
 	imagePath := "playlists file path"
-	storageFilePathProvider.On("GetPlaylistImagePath", file, mock.IsType(*mockPlaylist)).
+	storageFilePathProvider.On("GetPlaylistImagePath", mock.IsType(*mockPlaylist)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Update", mock.IsType(new(model.Playlist))).
 		Return(internalError).
@@ -186,6 +197,8 @@ func TestSaveImageToPlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerE
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -197,13 +210,18 @@ func TestSaveImageToPlaylist_WhenPublishFails_ShouldReturnInternalServerError(t
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := playlist.NewSaveImageToPlaylist(
 		playlistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -212,18 +230,22 @@ func TestSaveImageToPlaylist_WhenPublishFails_ShouldReturnInternalServerError(t
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
 	imagePath := "playlists file path"
-	storageFilePathProvider.On("GetPlaylistImagePath", file, mock.IsType(*mockPlaylist)).
+	storageFilePathProvider.On("GetPlaylistImagePath", mock.IsType(*mockPlaylist)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Update", mock.IsType(new(model.Playlist))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Return(internalError).
 		Once()
 
@@ -235,6 +257,8 @@ func TestSaveImageToPlaylist_WhenPublishFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -247,13 +271,18 @@ func TestSaveImageToPlaylist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAny
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := playlist.NewSaveImageToPlaylist(
 		playlistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -262,9 +291,9 @@ func TestSaveImageToPlaylist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAny
 	playlistRepository.On("Get", new(model.Playlist), id).Return(nil, mockPlaylist).Once()
 
 	imagePath := "playlists file path"
-	storageFilePathProvider.On("GetPlaylistImagePath", file, mock.IsType(*mockPlaylist)).
+	storageFilePathProvider.On("GetPlaylistImagePath", mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			newPlaylist := args.Get(1).(model.Playlist)
+			newPlaylist := args.Get(0).(model.Playlist)
 			assert.Equal(t, newPlaylist.ID, mockPlaylist.ID)
 			assert.WithinDuration(t, time.Now(), newPlaylist.UpdatedAt, time.Minute)
 		}).
@@ -273,6 +302,10 @@ func TestSaveImageToPlaylist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAny
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var newPlaylist *model.Playlist
 	playlistRepository.On("Update", mock.IsType(new(model.Playlist))).
 		Run(func(args mock.Arguments) {
@@ -282,9 +315,9 @@ func TestSaveImageToPlaylist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAny
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, *newPlaylist, args.Get(1).(model.Playlist))
+			assert.Equal(t, *newPlaylist, args.Get(2).(model.Playlist))
 		}).
 		Return(nil).
 		Once()
@@ -295,6 +328,8 @@ func TestSaveImageToPlaylist_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAny
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -307,13 +342,18 @@ func TestSaveImageToPlaylist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndN
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := playlist.NewSaveImageToPlaylist(
 		playlistRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -324,9 +364,9 @@ func TestSaveImageToPlaylist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndN
 	storageService.On("DeleteFile", *mockPlaylist.ImageURL).Return(nil).Once()
 
 	imagePath := "playlists file path"
-	storageFilePathProvider.On("GetPlaylistImagePath", file, mock.IsType(*mockPlaylist)).
+	storageFilePathProvider.On("GetPlaylistImagePath", mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			newPlaylist := args.Get(1).(model.Playlist)
+			newPlaylist := args.Get(0).(model.Playlist)
 			assert.Equal(t, newPlaylist.ID, mockPlaylist.ID)
 			assert.WithinDuration(t, time.Now(), newPlaylist.UpdatedAt, time.Minute)
 		}).
@@ -335,6 +375,10 @@ func TestSaveImageToPlaylist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndN
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var newPlaylist *model.Playlist
 	playlistRepository.On("Update", mock.IsType(new(model.Playlist))).
 		Run(func(args mock.Arguments) {
@@ -344,9 +388,9 @@ func TestSaveImageToPlaylist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndN
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, *newPlaylist, args.Get(1).(model.Playlist))
+			assert.Equal(t, *newPlaylist, args.Get(2).(model.Playlist))
 		}).
 		Return(nil).
 		Once()
@@ -357,6 +401,8 @@ func TestSaveImageToPlaylist_WhenWithOldImage_ShouldDeleteOldImageSaveNewOneAndN
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -15,6 +15,62 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func TestAddSongsToPlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := song.NewAddSongsToPlaylist(playlistRepository)
+
+	request := requests.AddSongsToPlaylistRequest{
+		ID:      uuid.New(),
+		SongIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	res, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, res)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestAddSongsToPlaylist_WhenPlaylistIsSmart_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := song.NewAddSongsToPlaylist(playlistRepository)
+
+	request := requests.AddSongsToPlaylistRequest{
+		ID:      uuid.New(),
+		SongIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	mockPlaylist := &model.Playlist{ID: request.ID, SmartRules: &model.PlaylistSmartRules{}}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	// when
+	res, errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, res)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "songs cannot be added to a smart playlist", errCode.Error.Error())
+
+	playlistRepository.AssertExpectations(t)
+}
+
 func TestAddSongsToPlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
@@ -26,6 +82,10 @@ func TestAddSongsToPlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServer
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("GetPlaylistSongs", mock.Anything, request.ID).
 		Return(internalError).
@@ -54,6 +114,10 @@ func TestAddSongsToPlaylist_WhenAddSongsToPlaylistFails_ShouldReturnInternalServ
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := &[]model.PlaylistSong{}
 	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
 		Return(nil, playlistSongs).
@@ -87,6 +151,10 @@ func TestAddSongsToPlaylist_WhenWithDuplicatesButWithoutForceAdd_ShouldReturnNoS
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := []model.PlaylistSong{
 		{SongID: uuid.New()},
 		{SongID: request.SongIDs[0]},
@@ -122,6 +190,10 @@ func TestAddSongsToPlaylist_WhenWithoutDuplicatesNorForceAdd_ShouldReturnSuccess
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistSongs := &[]model.PlaylistSong{
 		{SongID: uuid.New()},
 	}
@@ -174,6 +246,10 @@ func TestAddSongsToPlaylist_WhenWithDuplicatesAndForceAddTrue_ShouldAddDuplicate
 	duplicateSongIDs := getDuplicateSongIDs(playlistSongs, request.SongIDs)
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
 		Return(nil, &playlistSongs).
 		Once()
@@ -232,6 +308,10 @@ func TestAddSongsToPlaylist_WhenWithDuplicatesAndForceAddFalse_ShouldSkipDuplica
 	}
 
 	// given - mocking
+	playlistRepository.On("Get", new(model.Playlist), request.ID).
+		Return(nil).
+		Once()
+
 	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.ID).
 		Return(nil, &playlistSongs).
 		Once()
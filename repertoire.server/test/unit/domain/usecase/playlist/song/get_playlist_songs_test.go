@@ -16,16 +16,40 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestGetPlaylistSongs_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := song.NewGetPlaylistSongs(playlistRepository, nil)
+
+	request := requests.GetPlaylistSongsRequest{ID: uuid.New()}
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(internalError).Once()
+
+	// when
+	resultPlaylistSongs, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, resultPlaylistSongs)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
 func TestGetPlaylistSongs_WhenGetPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := song.NewGetPlaylistSongs(playlistRepository)
+	_uut := song.NewGetPlaylistSongs(playlistRepository, nil)
 
 	request := requests.GetPlaylistSongsRequest{
 		ID:      uuid.New(),
 		OrderBy: []string{"title asc"},
 	}
 
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.
 		On(
@@ -54,13 +78,15 @@ func TestGetPlaylistSongs_WhenGetPlaylistSongsFails_ShouldReturnInternalServerEr
 func TestGetPlaylistSongs_WhenGetPlaylistSongsCountFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := song.NewGetPlaylistSongs(playlistRepository)
+	_uut := song.NewGetPlaylistSongs(playlistRepository, nil)
 
 	request := requests.GetPlaylistSongsRequest{
 		ID:      uuid.New(),
 		OrderBy: []string{"title asc"},
 	}
 
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil).Once()
+
 	playlistRepository.
 		On(
 			"GetPlaylistSongsWithSongs",
@@ -114,7 +140,7 @@ func TestGetPlaylistSongs_WhenSuccessful_ShouldReturnPlaylist(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			playlistRepository := new(repository.PlaylistRepositoryMock)
-			_uut := song.NewGetPlaylistSongs(playlistRepository)
+			_uut := song.NewGetPlaylistSongs(playlistRepository, nil)
 
 			expectedPlaylistSongs := []model.PlaylistSong{
 				{
@@ -128,6 +154,8 @@ func TestGetPlaylistSongs_WhenSuccessful_ShouldReturnPlaylist(t *testing.T) {
 				},
 			}
 
+			playlistRepository.On("Get", new(model.Playlist), tt.request.ID).Return(nil).Once()
+
 			if len(tt.request.OrderBy) != 0 {
 				playlistRepository.
 					On(
@@ -183,3 +211,198 @@ func TestGetPlaylistSongs_WhenSuccessful_ShouldReturnPlaylist(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPlaylistSongs_WhenSmartPlaylistGetSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewGetPlaylistSongs(playlistRepository, songRepository)
+
+	request := requests.GetPlaylistSongsRequest{ID: uuid.New()}
+
+	playlist := &model.Playlist{
+		ID:         request.ID,
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, playlist).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.
+		On(
+			"GetAllByUser",
+			new([]model.EnhancedSong),
+			playlist.UserID,
+			request.CurrentPage,
+			request.PageSize,
+			playlist.SmartRules.OrderBy,
+			playlist.SmartRules.SearchBy,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestGetPlaylistSongs_WhenSmartPlaylistGetSongsCountFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewGetPlaylistSongs(playlistRepository, songRepository)
+
+	request := requests.GetPlaylistSongsRequest{ID: uuid.New()}
+
+	playlist := &model.Playlist{
+		ID:         request.ID,
+		UserID:     uuid.New(),
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, playlist).Once()
+
+	songRepository.
+		On(
+			"GetAllByUser",
+			new([]model.EnhancedSong),
+			playlist.UserID,
+			request.CurrentPage,
+			request.PageSize,
+			playlist.SmartRules.OrderBy,
+			playlist.SmartRules.SearchBy,
+		).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllByUserCount", new(int64), playlist.UserID, playlist.SmartRules.SearchBy).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestGetPlaylistSongs_WhenSmartPlaylistIsSuccessful_ShouldReturnTheSongsMatchingTheRulesWithinTheLimit(t *testing.T) {
+	limit := uint(5)
+
+	tests := []struct {
+		name                string
+		request             requests.GetPlaylistSongsRequest
+		limit               *uint
+		expectedCurrentPage *int
+		expectedPageSize    *int
+		foundSongs          int
+		expectedSongs       int
+		expectedFirstTrack  uint
+		expectedTotalCount  int64
+	}{
+		{
+			"Without Limit",
+			requests.GetPlaylistSongsRequest{ID: uuid.New()},
+			nil,
+			nil,
+			nil,
+			8,
+			8,
+			1,
+			8,
+		},
+		{
+			"With Limit - Will fetch only the limit",
+			requests.GetPlaylistSongsRequest{ID: uuid.New()},
+			&limit,
+			&[]int{1}[0],
+			&[]int{5}[0],
+			5,
+			5,
+			1,
+			5,
+		},
+		{
+			"With Limit and Pagination - Will cut the page at the limit",
+			requests.GetPlaylistSongsRequest{ID: uuid.New(), CurrentPage: &[]int{2}[0], PageSize: &[]int{3}[0]},
+			&limit,
+			&[]int{2}[0],
+			&[]int{3}[0],
+			3,
+			2,
+			4,
+			5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			playlistRepository := new(repository.PlaylistRepositoryMock)
+			songRepository := new(repository.SongRepositoryMock)
+			_uut := song.NewGetPlaylistSongs(playlistRepository, songRepository)
+
+			playlist := &model.Playlist{
+				ID:     tt.request.ID,
+				UserID: uuid.New(),
+				SmartRules: &model.PlaylistSmartRules{
+					SearchBy: []string{"confidence < 60", "last_time_played < now-14d"},
+					OrderBy:  []string{"progress asc"},
+					Limit:    tt.limit,
+				},
+			}
+			playlistRepository.On("Get", new(model.Playlist), tt.request.ID).Return(nil, playlist).Once()
+
+			var songs []model.EnhancedSong
+			for range tt.foundSongs {
+				songs = append(songs, model.EnhancedSong{Song: model.Song{ID: uuid.New()}})
+			}
+			songRepository.
+				On(
+					"GetAllByUser",
+					new([]model.EnhancedSong),
+					playlist.UserID,
+					tt.expectedCurrentPage,
+					tt.expectedPageSize,
+					playlist.SmartRules.OrderBy,
+					playlist.SmartRules.SearchBy,
+				).
+				Return(nil, &songs).
+				Once()
+
+			count := &[]int64{8}[0]
+			songRepository.On("GetAllByUserCount", new(int64), playlist.UserID, playlist.SmartRules.SearchBy).
+				Return(nil, count).
+				Once()
+
+			// when
+			result, errCode := _uut.Handle(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+			assert.Equal(t, tt.expectedTotalCount, result.TotalCount)
+			assert.Len(t, result.Models, tt.expectedSongs)
+			for i, s := range result.Models {
+				assert.Equal(t, songs[i].ID, s.ID)
+				assert.Equal(t, tt.expectedFirstTrack+uint(i), s.PlaylistTrackNo)
+			}
+
+			playlistRepository.AssertExpectations(t)
+			songRepository.AssertExpectations(t)
+		})
+	}
+}
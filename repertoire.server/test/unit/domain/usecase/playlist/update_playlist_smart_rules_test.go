@@ -0,0 +1,142 @@
+package playlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/playlist"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdatePlaylistSmartRules_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewUpdatePlaylistSmartRules(playlistRepository)
+
+	request := requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()}
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistSmartRules_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewUpdatePlaylistSmartRules(playlistRepository)
+
+	request := requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()}
+
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "playlist not found", errCode.Error.Error())
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistSmartRules_WhenUpdatePlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := playlist.NewUpdatePlaylistSmartRules(playlistRepository)
+
+	request := requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()}
+
+	mockPlaylist := &model.Playlist{ID: request.ID, Title: "Some Playlist"}
+	playlistRepository.On("Get", new(model.Playlist), request.ID).Return(nil, mockPlaylist).Once()
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Update", mock.IsType(new(model.Playlist))).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestUpdatePlaylistSmartRules_WhenSuccessful_ShouldReplaceTheRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  requests.UpdatePlaylistSmartRulesRequest
+		expected *model.PlaylistSmartRules
+	}{
+		{
+			"With Rules",
+			requests.UpdatePlaylistSmartRulesRequest{
+				ID: uuid.New(),
+				SmartRules: &requests.PlaylistSmartRulesRequest{
+					SearchBy: []string{"confidence < 60", "last_time_played < now-14d"},
+					OrderBy:  []string{"progress asc"},
+					Limit:    &[]uint{20}[0],
+				},
+			},
+			&model.PlaylistSmartRules{
+				SearchBy: []string{"confidence < 60", "last_time_played < now-14d"},
+				OrderBy:  []string{"progress asc"},
+				Limit:    &[]uint{20}[0],
+			},
+		},
+		{
+			"Without Rules - Will become static",
+			requests.UpdatePlaylistSmartRulesRequest{ID: uuid.New()},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			playlistRepository := new(repository.PlaylistRepositoryMock)
+			_uut := playlist.NewUpdatePlaylistSmartRules(playlistRepository)
+
+			mockPlaylist := &model.Playlist{
+				ID:         tt.request.ID,
+				Title:      "Some Playlist",
+				SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"bpm > 100"}},
+			}
+			playlistRepository.On("Get", new(model.Playlist), tt.request.ID).Return(nil, mockPlaylist).Once()
+
+			playlistRepository.On("Update", mock.IsType(new(model.Playlist))).
+				Run(func(args mock.Arguments) {
+					newPlaylist := args.Get(0).(*model.Playlist)
+					assert.Equal(t, mockPlaylist.Title, newPlaylist.Title)
+					assert.Equal(t, tt.expected, newPlaylist.SmartRules)
+				}).
+				Return(nil).
+				Once()
+
+			// when
+			errCode := _uut.Handle(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+
+			playlistRepository.AssertExpectations(t)
+		})
+	}
+}
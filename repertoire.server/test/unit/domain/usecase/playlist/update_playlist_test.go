@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/playlist"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestUpdatePlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil)
+	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil, nil)
 
 	request := requests.UpdatePlaylistRequest{
 		ID:    uuid.New(),
@@ -43,7 +44,7 @@ func TestUpdatePlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *
 func TestUpdatePlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil)
+	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil, nil)
 
 	request := requests.UpdatePlaylistRequest{
 		ID:    uuid.New(),
@@ -66,7 +67,10 @@ func TestUpdatePlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing
 func TestUpdatePlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewUpdatePlaylist(playlistRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdatePlaylistRequest{
 		ID:    uuid.New(),
@@ -81,6 +85,9 @@ func TestUpdatePlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerError(
 		Return(nil, mockPlaylist).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Return(internalError).
@@ -93,6 +100,8 @@ func TestUpdatePlaylist_WhenUpdatePlaylistFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 }
 
@@ -100,7 +109,11 @@ func TestUpdatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewUpdatePlaylist(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewUpdatePlaylist(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdatePlaylistRequest{
 		ID:    uuid.New(),
@@ -115,12 +128,16 @@ func TestUpdatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 		Return(nil, mockPlaylist).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Return(internalError).
 		Once()
 
@@ -131,6 +148,8 @@ func TestUpdatePlaylist_WhenPublishFails_ShouldReturnInternalServerError(t *test
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 }
 
@@ -138,7 +157,11 @@ func TestUpdatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// given
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := playlist.NewUpdatePlaylist(playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := playlist.NewUpdatePlaylist(playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdatePlaylistRequest{
 		ID:    uuid.New(),
@@ -153,6 +176,10 @@ func TestUpdatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil, mockPlaylist).
 		Once()
 
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	var newPlaylist *model.Playlist
 	playlistRepository.On("Update", mock.IsType(mockPlaylist)).
 		Run(func(args mock.Arguments) {
@@ -162,9 +189,9 @@ func TestUpdatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistUpdatedTopic, mock.IsType(*mockPlaylist)).
 		Run(func(args mock.Arguments) {
-			assert.Equal(t, *newPlaylist, args.Get(1).(model.Playlist))
+			assert.Equal(t, *newPlaylist, args.Get(2).(model.Playlist))
 		}).
 		Return(nil).
 		Once()
@@ -175,6 +202,8 @@ func TestUpdatePlaylist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -0,0 +1,286 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddSongToPracticeSession_WhenGetSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, nil)
+
+	request := requests.AddSongToPracticeSessionRequest{ID: uuid.New(), SongID: uuid.New()}
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenSessionIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, nil)
+
+	request := requests.AddSongToPracticeSessionRequest{ID: uuid.New(), SongID: uuid.New()}
+
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "practice session not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenSessionIsFinished_ShouldReturnConflictError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, nil)
+
+	request := requests.AddSongToPracticeSessionRequest{ID: uuid.New(), SongID: uuid.New()}
+
+	mockSession := &model.PracticeSession{ID: request.ID, EndedAt: &[]time.Time{time.Now()}[0]}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusConflict, errCode.Code)
+	assert.Equal(t, "practice session has already been finished", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, songRepository)
+
+	request := requests.AddSongToPracticeSessionRequest{ID: uuid.New(), SongID: uuid.New()}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, songRepository)
+
+	request := requests.AddSongToPracticeSessionRequest{ID: uuid.New(), SongID: uuid.New()}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "song not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenSectionIsNotOnSong_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, songRepository)
+
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     uuid.New(),
+		SongID: uuid.New(),
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: uuid.New(), Occurrences: 1},
+		},
+	}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	mockSong := &model.Song{
+		ID:       request.SongID,
+		Sections: []model.SongSection{{ID: uuid.New()}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, mockSong).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "song section not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenCreateSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, songRepository)
+
+	sectionID := uuid.New()
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     uuid.New(),
+		SongID: uuid.New(),
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: sectionID, Occurrences: 1},
+		},
+	}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	mockSong := &model.Song{
+		ID:       request.SongID,
+		Sections: []model.SongSection{{ID: sectionID}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, mockSong).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("CreateSong", mock.IsType(new(model.PracticeSessionSong))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddSongToPracticeSession_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := practice.NewAddSongToPracticeSession(practiceSessionRepository, songRepository)
+
+	mockSong := &model.Song{
+		ID: uuid.New(),
+		Sections: []model.SongSection{
+			{ID: uuid.New()},
+			{ID: uuid.New()},
+			{ID: uuid.New()},
+		},
+	}
+	request := requests.AddSongToPracticeSessionRequest{
+		ID:     uuid.New(),
+		SongID: mockSong.ID,
+		Sections: []requests.AddSongToPracticeSessionSectionRequest{
+			{ID: mockSong.Sections[0].ID, Occurrences: 2},
+			{ID: mockSong.Sections[2].ID, Occurrences: 1},
+		},
+	}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, mockSong).
+		Once()
+
+	practiceSessionRepository.On("CreateSong", mock.IsType(new(model.PracticeSessionSong))).
+		Run(func(args mock.Arguments) {
+			newSessionSong := args.Get(0).(*model.PracticeSessionSong)
+			assert.NotEmpty(t, newSessionSong.ID)
+			assert.Equal(t, mockSession.ID, newSessionSong.PracticeSessionID)
+			assert.Equal(t, mockSong.ID, newSessionSong.SongID)
+			assert.Len(t, newSessionSong.Sections, len(request.Sections))
+			for i, section := range newSessionSong.Sections {
+				assert.NotEmpty(t, section.ID)
+				assert.Equal(t, newSessionSong.ID, section.PracticeSessionSongID)
+				assert.Equal(t, request.Sections[i].ID, section.SongSectionID)
+				assert.Equal(t, request.Sections[i].Occurrences, section.Occurrences)
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
@@ -0,0 +1,111 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletePracticeSession_WhenGetSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewDeletePracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Get", new(model.PracticeSession), id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestDeletePracticeSession_WhenSessionIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewDeletePracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	practiceSessionRepository.On("Get", new(model.PracticeSession), id).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "practice session not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestDeletePracticeSession_WhenDeleteFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewDeletePracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	mockSession := &model.PracticeSession{ID: id}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), id).
+		Return(nil, mockSession).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Delete", id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestDeletePracticeSession_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewDeletePracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	mockSession := &model.PracticeSession{ID: id}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), id).
+		Return(nil, mockSession).
+		Once()
+
+	practiceSessionRepository.On("Delete", id).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,167 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFinishPracticeSession_WhenGetSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewFinishPracticeSession(practiceSessionRepository)
+
+	request := requests.FinishPracticeSessionRequest{ID: uuid.New()}
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestFinishPracticeSession_WhenSessionIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewFinishPracticeSession(practiceSessionRepository)
+
+	request := requests.FinishPracticeSessionRequest{ID: uuid.New()}
+
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "practice session not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestFinishPracticeSession_WhenSessionIsAlreadyFinished_ShouldReturnConflictError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewFinishPracticeSession(practiceSessionRepository)
+
+	request := requests.FinishPracticeSessionRequest{ID: uuid.New()}
+
+	mockSession := &model.PracticeSession{
+		ID:      request.ID,
+		EndedAt: &[]time.Time{time.Now()}[0],
+	}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusConflict, errCode.Code)
+	assert.Equal(t, "practice session has already been finished", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestFinishPracticeSession_WhenUpdateFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewFinishPracticeSession(practiceSessionRepository)
+
+	request := requests.FinishPracticeSessionRequest{ID: uuid.New()}
+
+	mockSession := &model.PracticeSession{ID: request.ID}
+	practiceSessionRepository.On("Get", new(model.PracticeSession), request.ID).
+		Return(nil, mockSession).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Update", mock.IsType(new(model.PracticeSession))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestFinishPracticeSession_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.FinishPracticeSessionRequest
+	}{
+		{
+			"Without notes",
+			requests.FinishPracticeSessionRequest{ID: uuid.New()},
+		},
+		{
+			"With notes",
+			requests.FinishPracticeSessionRequest{ID: uuid.New(), Notes: &[]string{"Good session"}[0]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+			_uut := practice.NewFinishPracticeSession(practiceSessionRepository)
+
+			mockSession := &model.PracticeSession{ID: tt.request.ID, Notes: "Initial notes"}
+			practiceSessionRepository.On("Get", new(model.PracticeSession), tt.request.ID).
+				Return(nil, mockSession).
+				Once()
+
+			practiceSessionRepository.On("Update", mock.IsType(new(model.PracticeSession))).
+				Run(func(args mock.Arguments) {
+					newSession := args.Get(0).(*model.PracticeSession)
+					assert.Equal(t, mockSession.ID, newSession.ID)
+					assert.NotNil(t, newSession.EndedAt)
+					assert.WithinDuration(t, time.Now(), *newSession.EndedAt, 1*time.Minute)
+					if tt.request.Notes != nil {
+						assert.Equal(t, *tt.request.Notes, newSession.Notes)
+					} else {
+						assert.Equal(t, mockSession.Notes, newSession.Notes)
+					}
+				}).
+				Return(nil).
+				Once()
+
+			// when
+			errCode := _uut.Handle(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+
+			practiceSessionRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllPracticeSessions_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := practice.NewGetAllPracticeSessions(nil, jwtService)
+
+	request := requests.GetPracticeSessionsRequest{}
+	token := "This is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	sessions, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, sessions)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllPracticeSessions_WhenGetSessionsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := practice.NewGetAllPracticeSessions(practiceSessionRepository, jwtService)
+
+	request := requests.GetPracticeSessionsRequest{}
+	token := "This is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.
+		On(
+			"GetAllByUser",
+			mock.Anything,
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	sessions, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, sessions)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllPracticeSessions_WhenGetSessionsCountFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := practice.NewGetAllPracticeSessions(practiceSessionRepository, jwtService)
+
+	request := requests.GetPracticeSessionsRequest{}
+	token := "This is a token"
+
+	expectedSessions := &[]model.PracticeSession{{Notes: "Some session"}}
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	practiceSessionRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(expectedSessions),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, expectedSessions).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.
+		On("GetAllByUserCount", mock.Anything, userID, request.SearchBy).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Equal(t, expectedSessions, &result.Models)
+	assert.Empty(t, result.TotalCount)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllPracticeSessions_WhenSuccessful_ShouldReturnSessionsWithTotalCount(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := practice.NewGetAllPracticeSessions(practiceSessionRepository, jwtService)
+
+	request := requests.GetPracticeSessionsRequest{}
+	token := "This is a token"
+
+	expectedSessions := &[]model.PracticeSession{
+		{Notes: "Some session"},
+		{Notes: "Some other session"},
+	}
+	expectedTotalCount := &[]int64{20}[0]
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	practiceSessionRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(expectedSessions),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, expectedSessions).
+		Once()
+
+	practiceSessionRepository.
+		On("GetAllByUserCount", mock.IsType(expectedTotalCount), userID, request.SearchBy).
+		Return(nil, expectedTotalCount).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Equal(t, expectedSessions, &result.Models)
+	assert.Equal(t, expectedTotalCount, &result.TotalCount)
+	assert.Nil(t, errCode)
+
+	practiceSessionRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPracticeSession_WhenGetSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewGetPracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("GetWithSongs", new(model.PracticeSession), id).
+		Return(internalError).
+		Once()
+
+	// when
+	session, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, session)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestGetPracticeSession_WhenSessionIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewGetPracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	practiceSessionRepository.On("GetWithSongs", new(model.PracticeSession), id).
+		Return(nil).
+		Once()
+
+	// when
+	session, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, session)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "practice session not found", errCode.Error.Error())
+
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestGetPracticeSession_WhenSuccessful_ShouldReturnSession(t *testing.T) {
+	// given
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewGetPracticeSession(practiceSessionRepository)
+
+	id := uuid.New()
+
+	expectedSession := &model.PracticeSession{
+		ID:    id,
+		Notes: "Some notes",
+	}
+	practiceSessionRepository.On("GetWithSongs", new(model.PracticeSession), id).
+		Return(nil, expectedSession).
+		Once()
+
+	// when
+	session, errCode := _uut.Handle(id)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedSession, &session)
+
+	practiceSessionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,174 @@
+package practice
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/practice"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartPracticeSession_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := practice.NewStartPracticeSession(jwtService, nil)
+
+	request := requests.StartPracticeSessionRequest{}
+	token := "this is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestStartPracticeSession_WhenGetInProgressSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewStartPracticeSession(jwtService, practiceSessionRepository)
+
+	request := requests.StartPracticeSessionRequest{}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), userID).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestStartPracticeSession_WhenAnotherSessionIsInProgress_ShouldReturnConflictError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewStartPracticeSession(jwtService, practiceSessionRepository)
+
+	request := requests.StartPracticeSessionRequest{}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	mockSession := &model.PracticeSession{ID: uuid.New(), UserID: userID}
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), userID).
+		Return(nil, mockSession).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusConflict, errCode.Code)
+	assert.Equal(t, "another practice session is already in progress", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestStartPracticeSession_WhenCreateFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewStartPracticeSession(jwtService, practiceSessionRepository)
+
+	request := requests.StartPracticeSessionRequest{}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), userID).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	practiceSessionRepository.On("Create", mock.IsType(new(model.PracticeSession))).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	practiceSessionRepository.AssertExpectations(t)
+}
+
+func TestStartPracticeSession_WhenSuccessful_ShouldReturnNewSessionID(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	_uut := practice.NewStartPracticeSession(jwtService, practiceSessionRepository)
+
+	request := requests.StartPracticeSessionRequest{Notes: "Warming up"}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	practiceSessionRepository.On("GetInProgressByUser", new(model.PracticeSession), userID).
+		Return(nil).
+		Once()
+
+	var newSession *model.PracticeSession
+	practiceSessionRepository.On("Create", mock.IsType(new(model.PracticeSession))).
+		Run(func(args mock.Arguments) {
+			newSession = args.Get(0).(*model.PracticeSession)
+			assert.NotEmpty(t, newSession.ID)
+			assert.Equal(t, request.Notes, newSession.Notes)
+			assert.WithinDuration(t, time.Now(), newSession.StartedAt, 1*time.Minute)
+			assert.Nil(t, newSession.EndedAt)
+			assert.Equal(t, userID, newSession.UserID)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, newSession.ID, id)
+
+	jwtService.AssertExpectations(t)
+	practiceSessionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,483 @@
+package progress
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/progress"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProgressTimeline_WhenFromIsAfterTo_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	_uut := progress.NewGetProgressTimeline(nil, nil, nil, nil, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.DayInterval,
+		From:     &[]time.Time{time.Now()}[0],
+		To:       &[]time.Time{time.Now().Add(-24 * time.Hour)}[0],
+	}
+	token := "this is a token"
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "from date must be before the to date", errCode.Error.Error())
+}
+
+func TestGetProgressTimeline_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, nil, nil, nil, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{Interval: enums.DayInterval}
+	token := "this is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, userRepository, nil, nil, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{Interval: enums.DayInterval}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), userID).Return(internalError).Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenUserIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, userRepository, nil, nil, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{Interval: enums.DayInterval}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+	userRepository.On("Get", new(model.User), userID).Return(nil).Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "user not found", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenGetSectionsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, userRepository, nil, songSectionRepository, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{Interval: enums.DayInterval}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New()}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	internalError := errors.New("internal error")
+	songSectionRepository.
+		On("GetAllWithHistoryByUser", new([]model.SongSection), user.ID, request.Scope, request.ScopeID).
+		Return(internalError).
+		Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, userRepository, playlistRepository, nil, nil, nil)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.DayInterval,
+		Scope:    &[]enums.TimelineScope{enums.PlaylistTimeline}[0],
+		ScopeID:  uuid.New(),
+	}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New()}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.ScopeID).Return(internalError).Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenGetSmartPlaylistSongIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := progress.NewGetProgressTimeline(jwtService, userRepository, playlistRepository, nil, playlistProcessor, nil)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.DayInterval,
+		Scope:    &[]enums.TimelineScope{enums.PlaylistTimeline}[0],
+		ScopeID:  uuid.New(),
+	}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New()}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ScopeID,
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ScopeID).Return(nil, mockPlaylist).Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(nil, internalError).Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenGetSmartPlaylistSectionsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := progress.NewGetProgressTimeline(
+		jwtService,
+		userRepository,
+		playlistRepository,
+		songSectionRepository,
+		playlistProcessor,
+		nil,
+	)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.DayInterval,
+		Scope:    &[]enums.TimelineScope{enums.PlaylistTimeline}[0],
+		ScopeID:  uuid.New(),
+	}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New()}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ScopeID,
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ScopeID).Return(nil, mockPlaylist).Once()
+
+	songIDs := []uuid.UUID{uuid.New()}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(songIDs, nil).Once()
+
+	internalError := errors.New("internal error")
+	songSectionRepository.
+		On("GetAllWithHistoryBySongs", new([]model.SongSection), user.ID, songIDs).
+		Return(internalError).
+		Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, timeline)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenSuccessful_ShouldReturnTimeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		request requests.GetProgressTimelineRequest
+	}{
+		{
+			"Whole user",
+			requests.GetProgressTimelineRequest{
+				Interval: enums.WeekInterval,
+			},
+		},
+		{
+			"Scoped",
+			requests.GetProgressTimelineRequest{
+				Interval: enums.DayInterval,
+				From:     &[]time.Time{time.Now().Add(-48 * time.Hour)}[0],
+				To:       &[]time.Time{time.Now()}[0],
+				Scope:    &[]enums.TimelineScope{enums.AlbumTimeline}[0],
+				ScopeID:  uuid.New(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			jwtService := new(service.JwtServiceMock)
+			userRepository := new(repository.UserRepositoryMock)
+			songSectionRepository := new(repository.SongSectionRepositoryMock)
+			progressTimelineProcessor := new(processor.ProgressTimelineProcessorMock)
+			_uut := progress.NewGetProgressTimeline(
+				jwtService,
+				userRepository,
+				nil,
+				songSectionRepository,
+				nil,
+				progressTimelineProcessor,
+			)
+
+			token := "this is a token"
+
+			user := &model.User{ID: uuid.New(), ScoringStrategy: enums.ExponentialScoring}
+			jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+			userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+			sections := &[]model.SongSection{{ID: uuid.New()}, {ID: uuid.New()}}
+			songSectionRepository.
+				On("GetAllWithHistoryByUser", new([]model.SongSection), user.ID, tt.request.Scope, tt.request.ScopeID).
+				Return(nil, sections).
+				Once()
+
+			expectedTimeline := []model.ProgressTimelinePoint{{Date: time.Now(), Rehearsals: 2}}
+			progressTimelineProcessor.
+				On(
+					"BuildTimeline",
+					*sections,
+					tt.request.Interval,
+					tt.request.From,
+					tt.request.To,
+					user.ScoringStrategy,
+				).
+				Return(expectedTimeline).
+				Once()
+
+			// when
+			timeline, errCode := _uut.Handle(tt.request, token)
+
+			// then
+			assert.Nil(t, errCode)
+			assert.Equal(t, expectedTimeline, timeline)
+
+			jwtService.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
+			songSectionRepository.AssertExpectations(t)
+			progressTimelineProcessor.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetProgressTimeline_WhenPlaylistIsNotSmart_ShouldReturnTimelineOfThePlaylistSongs(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	progressTimelineProcessor := new(processor.ProgressTimelineProcessorMock)
+	_uut := progress.NewGetProgressTimeline(
+		jwtService,
+		userRepository,
+		playlistRepository,
+		songSectionRepository,
+		nil,
+		progressTimelineProcessor,
+	)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.WeekInterval,
+		Scope:    &[]enums.TimelineScope{enums.PlaylistTimeline}[0],
+		ScopeID:  uuid.New(),
+	}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New(), ScoringStrategy: enums.ClassicScoring}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	mockPlaylist := &model.Playlist{ID: request.ScopeID}
+	playlistRepository.On("Get", new(model.Playlist), request.ScopeID).Return(nil, mockPlaylist).Once()
+
+	sections := &[]model.SongSection{{ID: uuid.New()}}
+	songSectionRepository.
+		On("GetAllWithHistoryByUser", new([]model.SongSection), user.ID, request.Scope, request.ScopeID).
+		Return(nil, sections).
+		Once()
+
+	expectedTimeline := []model.ProgressTimelinePoint{{Date: time.Now(), Rehearsals: 1}}
+	progressTimelineProcessor.
+		On("BuildTimeline", *sections, request.Interval, request.From, request.To, user.ScoringStrategy).
+		Return(expectedTimeline).
+		Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedTimeline, timeline)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	progressTimelineProcessor.AssertExpectations(t)
+}
+
+func TestGetProgressTimeline_WhenPlaylistIsSmart_ShouldReturnTimelineOfTheSongsMatchingTheRules(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	progressTimelineProcessor := new(processor.ProgressTimelineProcessorMock)
+	_uut := progress.NewGetProgressTimeline(
+		jwtService,
+		userRepository,
+		playlistRepository,
+		songSectionRepository,
+		playlistProcessor,
+		progressTimelineProcessor,
+	)
+
+	request := requests.GetProgressTimelineRequest{
+		Interval: enums.WeekInterval,
+		Scope:    &[]enums.TimelineScope{enums.PlaylistTimeline}[0],
+		ScopeID:  uuid.New(),
+	}
+	token := "this is a token"
+
+	user := &model.User{ID: uuid.New(), ScoringStrategy: enums.ClassicScoring}
+	jwtService.On("GetUserIdFromJwt", token).Return(user.ID, nil).Once()
+	userRepository.On("Get", new(model.User), user.ID).Return(nil, user).Once()
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.ScopeID,
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.ScopeID).Return(nil, mockPlaylist).Once()
+
+	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(songIDs, nil).Once()
+
+	sections := &[]model.SongSection{{ID: uuid.New(), SongID: songIDs[0]}, {ID: uuid.New(), SongID: songIDs[1]}}
+	songSectionRepository.
+		On("GetAllWithHistoryBySongs", new([]model.SongSection), user.ID, songIDs).
+		Return(nil, sections).
+		Once()
+
+	expectedTimeline := []model.ProgressTimelinePoint{{Date: time.Now(), Rehearsals: 3}}
+	progressTimelineProcessor.
+		On("BuildTimeline", *sections, request.Interval, request.From, request.To, user.ScoringStrategy).
+		Return(expectedTimeline).
+		Once()
+
+	// when
+	timeline, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedTimeline, timeline)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+	progressTimelineProcessor.AssertExpectations(t)
+}
@@ -66,8 +66,9 @@ func TestSearchGet_WhenSearchEngineGetFails_ShouldReturnErrorCode(t *testing.T)
 			userID,
 			request.Filter,
 			request.Order,
+			model.SearchOptions{},
 		).
-		Return(wrapper.WithTotalCount[map[string]any]{}, errorCode).
+		Return(model.SearchResults[map[string]any]{}, errorCode).
 		Once()
 
 	// when
@@ -157,11 +158,36 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			"title":  "Playlist 1",
 			"userID": userID,
 		},
+		{
+			"id":       "bandMember-" + uuid.New().String(),
+			"type":     enums.BandMember,
+			"name":     "Member 1",
+			"imageUrl": "something.png",
+			"artist": model.BandMemberArtistSearch{
+				ID:       uuid.New(),
+				Name:     "Member Artist",
+				ImageUrl: &[]internal.FilePath{"something.png"}[0],
+			},
+			"userID": userID,
+		},
+		{
+			"id":     "songSection-" + uuid.New().String(),
+			"type":   enums.SongSection,
+			"name":   "Chorus 1",
+			"userID": userID,
+			"song": model.SongSectionSongSearch{
+				ID:       uuid.New(),
+				Title:    "Section Song",
+				ImageUrl: &[]internal.FilePath{"something.png"}[0],
+			},
+		},
 	}
 
-	searchResult := wrapper.WithTotalCount[map[string]any]{
-		Models:     modelsResult,
-		TotalCount: int64(len(modelsResult)),
+	searchResult := model.SearchResults[map[string]any]{
+		WithTotalCount: wrapper.WithTotalCount[map[string]any]{
+			Models:     modelsResult,
+			TotalCount: int64(len(modelsResult)),
+		},
 	}
 	searchEngineService.
 		On(
@@ -173,6 +199,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			userID,
 			request.Filter,
 			request.Order,
+			model.SearchOptions{},
 		).
 		Return(searchResult, nil).
 		Once()
@@ -201,7 +228,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			assert.Equal(t, expectedMap["name"], curr.Name)
 			if expectedMap["imageUrl"] != nil {
 				filePath := internal.FilePath(expectedMap["imageUrl"].(string))
-				assert.Equal(t, filePath.StripURL(), curr.ImageUrl)
+				assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
 			} else {
 				assert.Nil(t, curr.ImageUrl)
 			}
@@ -211,7 +238,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			assert.Equal(t, expectedMap["title"], curr.Title)
 			if expectedMap["imageUrl"] != nil {
 				filePath := internal.FilePath(expectedMap["imageUrl"].(string))
-				assert.Equal(t, filePath.StripURL(), curr.ImageUrl)
+				assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
 			} else {
 				assert.Nil(t, curr.ImageUrl)
 			}
@@ -221,7 +248,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 				expectedArtist := expectedMap["artist"].(model.AlbumArtistSearch)
 				assert.Equal(t, expectedArtist.ID, curr.Artist.ID)
 				assert.Equal(t, expectedArtist.Name, curr.Artist.Name)
-				assert.Equal(t, expectedArtist.ImageUrl.StripURL(), curr.Artist.ImageUrl)
+				assert.Equal(t, expectedArtist.ImageUrl.StripURL().ToFullURL(), curr.Artist.ImageUrl)
 			}
 		case enums.Song:
 			curr := result.Models[i].(model.SongSearch)
@@ -229,7 +256,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			assert.Equal(t, expectedMap["title"], curr.Title)
 			if expectedMap["imageUrl"] != nil {
 				filePath := internal.FilePath(expectedMap["imageUrl"].(string))
-				assert.Equal(t, filePath.StripURL(), curr.ImageUrl)
+				assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
 			} else {
 				assert.Nil(t, curr.ImageUrl)
 			}
@@ -239,7 +266,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 				expectedArtist := expectedMap["artist"].(model.SongArtistSearch)
 				assert.Equal(t, expectedArtist.ID, curr.Artist.ID)
 				assert.Equal(t, expectedArtist.Name, curr.Artist.Name)
-				assert.Equal(t, expectedArtist.ImageUrl.StripURL(), curr.Artist.ImageUrl)
+				assert.Equal(t, expectedArtist.ImageUrl.StripURL().ToFullURL(), curr.Artist.ImageUrl)
 			}
 			if expectedMap["album"] == nil {
 				assert.Nil(t, curr.Album)
@@ -247,7 +274,7 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 				expectedAlbum := expectedMap["album"].(model.SongAlbumSearch)
 				assert.Equal(t, expectedAlbum.ID, curr.Album.ID)
 				assert.Equal(t, expectedAlbum.Title, curr.Album.Title)
-				assert.Equal(t, expectedAlbum.ImageUrl.StripURL(), curr.Album.ImageUrl)
+				assert.Equal(t, expectedAlbum.ImageUrl.StripURL().ToFullURL(), curr.Album.ImageUrl)
 			}
 		case enums.Playlist:
 			curr := result.Models[i].(model.PlaylistSearch)
@@ -255,10 +282,28 @@ func TestSearchGet_WhenSuccessful_ShouldReturnSearchResult(t *testing.T) {
 			assert.Equal(t, expectedMap["title"], curr.Title)
 			if expectedMap["imageUrl"] != nil {
 				filePath := internal.FilePath(expectedMap["imageUrl"].(string))
-				assert.Equal(t, filePath.StripURL(), curr.ImageUrl)
+				assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
 			} else {
 				assert.Nil(t, curr.ImageUrl)
 			}
+		case enums.BandMember:
+			curr := result.Models[i].(model.BandMemberSearch)
+			assert.Equal(t, strings.Replace((expectedMap["id"]).(string), "bandMember-", "", 1), currBase.ID)
+			assert.Equal(t, expectedMap["name"], curr.Name)
+			filePath := internal.FilePath(expectedMap["imageUrl"].(string))
+			assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
+			expectedArtist := expectedMap["artist"].(model.BandMemberArtistSearch)
+			assert.Equal(t, expectedArtist.ID, curr.Artist.ID)
+			assert.Equal(t, expectedArtist.Name, curr.Artist.Name)
+			assert.Equal(t, expectedArtist.ImageUrl.StripURL().ToFullURL(), curr.Artist.ImageUrl)
+		case enums.SongSection:
+			curr := result.Models[i].(model.SongSectionSearch)
+			assert.Equal(t, strings.Replace((expectedMap["id"]).(string), "songSection-", "", 1), currBase.ID)
+			assert.Equal(t, expectedMap["name"], curr.Name)
+			expectedSong := expectedMap["song"].(model.SongSectionSongSearch)
+			assert.Equal(t, expectedSong.ID, curr.Song.ID)
+			assert.Equal(t, expectedSong.Title, curr.Song.Title)
+			assert.Equal(t, expectedSong.ImageUrl.StripURL().ToFullURL(), curr.Song.ImageUrl)
 		}
 	}
 
@@ -315,9 +360,11 @@ func TestSearchGet_WhenArtistsWithIDsAndNotIDs_ShouldReturnSearchResult(t *testi
 	notIDsFilter = strings.TrimSuffix(notIDsFilter, ", ") + "]"
 
 	filter := append(request.Filter, idsFilter, notIDsFilter)
-	searchResult := wrapper.WithTotalCount[map[string]any]{
-		Models:     modelsResult,
-		TotalCount: int64(len(modelsResult)),
+	searchResult := model.SearchResults[map[string]any]{
+		WithTotalCount: wrapper.WithTotalCount[map[string]any]{
+			Models:     modelsResult,
+			TotalCount: int64(len(modelsResult)),
+		},
 	}
 	searchEngineService.
 		On(
@@ -329,6 +376,7 @@ func TestSearchGet_WhenArtistsWithIDsAndNotIDs_ShouldReturnSearchResult(t *testi
 			userID,
 			filter,
 			request.Order,
+			model.SearchOptions{},
 		).
 		Return(searchResult, nil).
 		Once()
@@ -355,7 +403,7 @@ func TestSearchGet_WhenArtistsWithIDsAndNotIDs_ShouldReturnSearchResult(t *testi
 		assert.Equal(t, expectedMap["name"], curr.Name)
 		if expectedMap["imageUrl"] != nil {
 			filePath := internal.FilePath(expectedMap["imageUrl"].(string))
-			assert.Equal(t, filePath.StripURL(), curr.ImageUrl)
+			assert.Equal(t, filePath.StripURL().ToFullURL(), curr.ImageUrl)
 		} else {
 			assert.Nil(t, curr.ImageUrl)
 		}
@@ -364,3 +412,88 @@ func TestSearchGet_WhenArtistsWithIDsAndNotIDs_ShouldReturnSearchResult(t *testi
 	jwtService.AssertExpectations(t)
 	searchEngineService.AssertExpectations(t)
 }
+
+func TestSearchGet_WhenSearchOptionsAreRequested_ShouldReturnFacetsAndHighlightedResult(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := search.NewGet(jwtService, searchEngineService)
+
+	request := requests.SearchGetRequest{
+		Query:            "tset",
+		Type:             &[]enums.SearchType{enums.Song}[0],
+		Facets:           true,
+		Highlight:        true,
+		TypoTolerance:    &[]bool{false}[0],
+		MatchingStrategy: &[]enums.SearchMatchingStrategy{enums.AllMatchingStrategy}[0],
+	}
+	token := "some token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	options := model.SearchOptions{
+		Facets:           request.Facets,
+		Highlight:        request.Highlight,
+		TypoTolerance:    request.TypoTolerance,
+		MatchingStrategy: request.MatchingStrategy,
+	}
+	searchResult := model.SearchResults[map[string]any]{
+		WithTotalCount: wrapper.WithTotalCount[map[string]any]{
+			Models: []map[string]any{
+				{
+					"id":          "song-" + uuid.New().String(),
+					"type":        enums.Song,
+					"title":       "Test Song",
+					"releaseYear": 2024,
+					"difficulty":  enums.Easy,
+					"userID":      userID,
+					"formatted":   map[string]any{"title": "<em>Test</em> Song"},
+				},
+			},
+			TotalCount: 1,
+		},
+		Facets: map[string]map[string]int64{
+			"difficulty":  {"easy": 1},
+			"releaseYear": {"2024": 1},
+		},
+		FacetStats: map[string]model.SearchFacetStats{
+			"releaseYear": {Min: 2024, Max: 2024},
+		},
+	}
+	searchEngineService.
+		On(
+			"Search",
+			request.Query,
+			request.CurrentPage,
+			request.PageSize,
+			request.Type,
+			userID,
+			request.Filter,
+			request.Order,
+			options,
+		).
+		Return(searchResult, nil).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+
+	assert.Equal(t, searchResult.TotalCount, result.TotalCount)
+	assert.Equal(t, searchResult.Facets, result.Facets)
+	assert.Equal(t, searchResult.FacetStats, result.FacetStats)
+
+	assert.Len(t, result.Models, 1)
+	song := result.Models[0].(model.SongSearch)
+	assert.Equal(t, "Test Song", song.Title)
+	assert.Equal(t, 2024, *song.ReleaseYear)
+	assert.Equal(t, enums.Easy, *song.Difficulty)
+	assert.NotNil(t, song.Formatted)
+	assert.Equal(t, "<em>Test</em> Song", song.Formatted.Title)
+
+	jwtService.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+}
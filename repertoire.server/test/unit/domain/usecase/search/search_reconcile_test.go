@@ -0,0 +1,336 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/search"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchReconcile_WhenGetAllUserIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := search.NewReconcile(userRepository, nil, nil)
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetAllIDs", new([]uuid.UUID)).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(nil)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := search.NewReconcile(userRepository, nil, nil)
+
+	userID := uuid.New()
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetWithSearchableData", new(model.User), userID).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(&userID)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenGetDocumentsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, nil)
+
+	user := &model.User{ID: uuid.New()}
+
+	userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+
+	internalError := errors.New("internal error")
+	searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).
+		Return([]map[string]any{}, internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(&user.ID)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, messagePublisherService)
+
+	user := &model.User{
+		ID:      uuid.New(),
+		Artists: []model.Artist{{ID: uuid.New(), Name: "Some Artist"}},
+	}
+
+	userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+	searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).
+		Return([]map[string]any{}, nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("Publish", topics.AddToSearchEngineTopic, mock.Anything).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(&user.ID)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenDocumentsAreInSync_ShouldNotPublishAnything(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, messagePublisherService)
+
+	artist := model.Artist{ID: uuid.New(), Name: "Some Artist"}
+	user := &model.User{
+		ID:        uuid.New(),
+		Artists:   []model.Artist{artist},
+		Albums:    []model.Album{{ID: uuid.New(), Title: "Some Album", Artist: &artist}},
+		Songs:     []model.Song{{ID: uuid.New(), Title: "Some Song", Artist: &artist}},
+		Playlists: []model.Playlist{{ID: uuid.New(), Title: "Some Playlist"}},
+	}
+
+	documents := []map[string]any{
+		toDocument(user.Artists[0].ToSearch()),
+		toDocument(user.Albums[0].ToSearch()),
+		toDocument(user.Songs[0].ToSearch()),
+		toDocument(user.Playlists[0].ToSearch()),
+	}
+
+	userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+	searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).Return(documents, nil).Once()
+
+	// when
+	result, errCode := _uut.Handle(&user.ID)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, model.SearchReconciliation{}, result)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+	messagePublisherService.AssertNotCalled(t, "Publish")
+}
+
+func TestSearchReconcile_WhenDocumentsHaveDrifted_ShouldPublishTheBatchesAndReturnTheCounts(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, messagePublisherService)
+
+	artist := model.Artist{ID: uuid.New(), Name: "Some Artist", UpdatedAt: time.Now()}
+	user := &model.User{
+		ID:      uuid.New(),
+		Artists: []model.Artist{artist},
+		Albums: []model.Album{
+			{ID: uuid.New(), Title: "Some Album", UpdatedAt: time.Now()},
+		},
+		Songs: []model.Song{
+			{ID: uuid.New(), Title: "Some Song", Artist: &artist},
+			{ID: uuid.New(), Title: "Another Song"},
+		},
+	}
+
+	outdatedAlbum := user.Albums[0]
+	outdatedAlbum.UpdatedAt = outdatedAlbum.UpdatedAt.Add(-time.Hour)
+
+	outdatedArtist := artist
+	outdatedArtist.Name = "Old Name"
+	songWithOutdatedArtist := user.Songs[0]
+	songWithOutdatedArtist.Artist = &outdatedArtist
+
+	deletedPlaylist := model.Playlist{ID: uuid.New(), Title: "Deleted Playlist"}
+
+	documents := []map[string]any{
+		toDocument(outdatedAlbum.ToSearch()),
+		toDocument(songWithOutdatedArtist.ToSearch()),
+		toDocument(user.Songs[1].ToSearch()),
+		toDocument(deletedPlaylist.ToSearch()),
+	}
+
+	userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+	searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).Return(documents, nil).Once()
+
+	expectedDocumentsToAdd := []any{toDocument(user.Artists[0].ToSearch())}
+	messagePublisherService.On("Publish", topics.AddToSearchEngineTopic, expectedDocumentsToAdd).
+		Return(nil).
+		Once()
+	expectedDocumentsToUpdate := []any{
+		toDocument(user.Albums[0].ToSearch()),
+		toDocument(user.Songs[0].ToSearch()),
+	}
+	messagePublisherService.On("Publish", topics.UpdateFromSearchEngineTopic, expectedDocumentsToUpdate).
+		Return(nil).
+		Once()
+	expectedIDsToDelete := []string{"playlist-" + deletedPlaylist.ID.String()}
+	messagePublisherService.On("Publish", topics.DeleteFromSearchEngineTopic, expectedIDsToDelete).
+		Return(nil).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(&user.ID)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, model.SearchReconciliation{Added: 1, Updated: 2, Deleted: 1}, result)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenBandMembersAndSectionsAreMissing_ShouldAddTheirDocuments(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, messagePublisherService)
+
+	artist := model.Artist{
+		ID:     uuid.New(),
+		Name:   "Some Band",
+		UserID: uuid.New(),
+		BandMembers: []model.BandMember{
+			{ID: uuid.New(), Name: "Some Member", Roles: []model.BandMemberRole{{Name: "Guitarist"}}},
+		},
+	}
+	song := model.Song{
+		ID:     uuid.New(),
+		Title:  "Some Song",
+		UserID: artist.UserID,
+		Sections: []model.SongSection{
+			{ID: uuid.New(), Name: "Chorus 1", SongSectionType: model.SongSectionType{Name: "Chorus"}},
+		},
+	}
+	user := &model.User{
+		ID:      artist.UserID,
+		Artists: []model.Artist{artist},
+		Songs:   []model.Song{song},
+	}
+
+	documents := []map[string]any{
+		toDocument(artist.ToSearch()),
+		toDocument(song.ToSearch()),
+	}
+
+	userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+	searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).Return(documents, nil).Once()
+
+	member := artist.BandMembers[0]
+	member.Artist = artist
+	expectedDocumentsToAdd := []any{
+		toDocument(member.ToSearch()),
+		toDocument(song.ToSectionsSearch()[0]),
+	}
+	messagePublisherService.On("Publish", topics.AddToSearchEngineTopic, expectedDocumentsToAdd).
+		Return(nil).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(&user.ID)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, model.SearchReconciliation{Added: 2}, result)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestSearchReconcile_WhenUserIsMissing_ShouldReconcileAllUsersAndSumTheCounts(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	searchEngineService := new(service.SearchEngineServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := search.NewReconcile(userRepository, searchEngineService, messagePublisherService)
+
+	users := []*model.User{
+		{ID: uuid.New(), Playlists: []model.Playlist{{ID: uuid.New(), Title: "Some Playlist"}}},
+		{ID: uuid.New(), Playlists: []model.Playlist{{ID: uuid.New(), Title: "Another Playlist"}}},
+	}
+	userIDs := &[]uuid.UUID{users[0].ID, users[1].ID}
+
+	userRepository.On("GetAllIDs", new([]uuid.UUID)).Return(nil, userIDs).Once()
+	for _, user := range users {
+		userRepository.On("GetWithSearchableData", new(model.User), user.ID).Return(nil, user).Once()
+		searchEngineService.On("GetDocuments", "userId = "+user.ID.String()).
+			Return([]map[string]any{}, nil).
+			Once()
+		messagePublisherService.On(
+			"Publish",
+			topics.AddToSearchEngineTopic,
+			[]any{toDocument(user.Playlists[0].ToSearch())},
+		).
+			Return(nil).
+			Once()
+	}
+
+	// when
+	result, errCode := _uut.Handle(nil)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, model.SearchReconciliation{Added: 2}, result)
+
+	userRepository.AssertExpectations(t)
+	searchEngineService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func toDocument(search any) map[string]any {
+	bytes, _ := json.Marshal(search)
+	var document map[string]any
+	_ = json.Unmarshal(bytes, &document)
+	return document
+}
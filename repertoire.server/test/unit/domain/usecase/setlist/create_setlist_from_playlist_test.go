@@ -0,0 +1,346 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSetlistFromPlaylist_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, nil, nil, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenGetPlaylistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, nil, playlistRepository, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenPlaylistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, nil, playlistRepository, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).Return(nil).Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "playlist not found", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenGetPlaylistSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, nil, playlistRepository, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	mockPlaylist := &model.Playlist{ID: request.PlaylistID}
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	internalError := errors.New("internal error")
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.PlaylistID).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenCreateFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, setlistRepository, playlistRepository, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	mockPlaylist := &model.Playlist{ID: request.PlaylistID}
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.PlaylistID).
+		Return(nil, &[]model.PlaylistSong{}).
+		Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, setlistRepository, playlistRepository, nil)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+		Venue:      "Some Venue",
+		Notes:      "Some notes",
+	}
+	token := "this is a token"
+	userID := uuid.New()
+
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	mockPlaylist := &model.Playlist{ID: request.PlaylistID}
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	playlistSongs := &[]model.PlaylistSong{
+		{ID: uuid.New(), SongID: uuid.New(), SongTrackNo: 1},
+		{ID: uuid.New(), SongID: uuid.New(), SongTrackNo: 2},
+		{ID: uuid.New(), SongID: uuid.New(), SongTrackNo: 3},
+	}
+	playlistRepository.On("GetPlaylistSongs", new([]model.PlaylistSong), request.PlaylistID).
+		Return(nil, playlistSongs).
+		Once()
+
+	var setlistID uuid.UUID
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Run(func(args mock.Arguments) {
+			newSetlist := args.Get(0).(*model.Setlist)
+			assert.NotEmpty(t, newSetlist.ID)
+			assert.Equal(t, request.Title, newSetlist.Title)
+			assert.Equal(t, request.Venue, newSetlist.Venue)
+			assert.Equal(t, request.Date, newSetlist.Date)
+			assert.Equal(t, request.Notes, newSetlist.Notes)
+			assert.Equal(t, userID, newSetlist.UserID)
+			assert.Len(t, newSetlist.Entries, len(*playlistSongs))
+			for i, entry := range newSetlist.Entries {
+				assert.NotEmpty(t, entry.ID)
+				assert.Equal(t, enums.SongEntry, entry.Type)
+				assert.Equal(t, uint(i)+1, entry.EntryNo)
+				assert.Equal(t, newSetlist.ID, entry.SetlistID)
+				assert.Equal(t, (*playlistSongs)[i].SongID, *entry.SongID)
+				assert.Nil(t, entry.PlannedDuration)
+			}
+			setlistID = newSetlist.ID
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Equal(t, setlistID, id)
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenGetSmartPlaylistSongIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, nil, playlistRepository, playlistProcessor)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.PlaylistID,
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(nil, internalError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	jwtService.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
+
+func TestCreateSetlistFromPlaylist_WhenPlaylistIsSmart_ShouldCreateTheEntriesFromTheRules(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	playlistProcessor := new(processor.PlaylistProcessorMock)
+	_uut := setlist.NewCreateSetlistFromPlaylist(jwtService, setlistRepository, playlistRepository, playlistProcessor)
+
+	request := requests.CreateSetlistFromPlaylistRequest{
+		PlaylistID: uuid.New(),
+		Title:      "Some Setlist",
+	}
+	token := "this is a token"
+	userID := uuid.New()
+
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	mockPlaylist := &model.Playlist{
+		ID:         request.PlaylistID,
+		SmartRules: &model.PlaylistSmartRules{SearchBy: []string{"confidence < 60"}},
+	}
+	playlistRepository.On("Get", new(model.Playlist), request.PlaylistID).
+		Return(nil, mockPlaylist).
+		Once()
+
+	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	playlistProcessor.On("GetSmartPlaylistSongIDs", *mockPlaylist).Return(songIDs, nil).Once()
+
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Run(func(args mock.Arguments) {
+			newSetlist := args.Get(0).(*model.Setlist)
+			assert.Equal(t, userID, newSetlist.UserID)
+			assert.Len(t, newSetlist.Entries, len(songIDs))
+			for i, entry := range newSetlist.Entries {
+				assert.Equal(t, enums.SongEntry, entry.Type)
+				assert.Equal(t, uint(i)+1, entry.EntryNo)
+				assert.Equal(t, songIDs[i], *entry.SongID)
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotEmpty(t, id)
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	playlistProcessor.AssertExpectations(t)
+}
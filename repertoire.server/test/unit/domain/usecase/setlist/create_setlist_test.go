@@ -0,0 +1,118 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSetlist_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewCreateSetlist(jwtService, nil)
+
+	request := requests.CreateSetlistRequest{
+		Title: "Some Setlist",
+	}
+	token := "this is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestCreateSetlist_WhenCreateFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewCreateSetlist(jwtService, setlistRepository)
+
+	request := requests.CreateSetlistRequest{
+		Title: "Some Setlist",
+	}
+	token := "this is a token"
+	userID := uuid.New()
+
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+	internalError := errors.New("internal error")
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestCreateSetlist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewCreateSetlist(jwtService, setlistRepository)
+
+	date := time.Now()
+	request := requests.CreateSetlistRequest{
+		Title: "Some Setlist",
+		Venue: "Some Venue",
+		Date:  &date,
+		Notes: "Some notes",
+	}
+	token := "this is a token"
+	userID := uuid.New()
+
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	var setlistID uuid.UUID
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Run(func(args mock.Arguments) {
+			newSetlist := args.Get(0).(*model.Setlist)
+			assert.NotEmpty(t, newSetlist.ID)
+			assert.Equal(t, request.Title, newSetlist.Title)
+			assert.Equal(t, request.Venue, newSetlist.Venue)
+			assert.Equal(t, request.Date, newSetlist.Date)
+			assert.Equal(t, request.Notes, newSetlist.Notes)
+			assert.Equal(t, userID, newSetlist.UserID)
+			assert.Empty(t, newSetlist.Entries)
+			setlistID = newSetlist.ID
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Equal(t, setlistID, id)
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+}
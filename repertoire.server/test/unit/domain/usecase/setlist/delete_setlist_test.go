@@ -0,0 +1,98 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSetlist_WhenGetSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewDeleteSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Get", new(model.Setlist), id).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestDeleteSetlist_WhenSetlistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewDeleteSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	setlistRepository.On("Get", new(model.Setlist), id).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "setlist not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestDeleteSetlist_WhenDeleteSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewDeleteSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	mockSetlist := &model.Setlist{ID: id}
+	setlistRepository.On("Get", new(model.Setlist), id).Return(nil, mockSetlist).Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Delete", id).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestDeleteSetlist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewDeleteSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	mockSetlist := &model.Setlist{ID: id}
+	setlistRepository.On("Get", new(model.Setlist), id).Return(nil, mockSetlist).Once()
+	setlistRepository.On("Delete", id).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(id)
+
+	// then
+	assert.Nil(t, errCode)
+
+	setlistRepository.AssertExpectations(t)
+}
@@ -0,0 +1,291 @@
+package entry
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist/entry"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddEntryToSetlist_WhenGetSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, nil)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:    uuid.New(),
+		Type:  enums.TalkEntry,
+		Title: "Introduction",
+	}
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(internalError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenSetlistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, nil)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:    uuid.New(),
+		Type:  enums.TalkEntry,
+		Title: "Introduction",
+	}
+
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil).Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "setlist not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, songRepository)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:     uuid.New(),
+		Type:   enums.SongEntry,
+		SongID: &[]uuid.UUID{uuid.New()}[0],
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("Get", new(model.Song), *request.SongID).Return(internalError).Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, songRepository)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:     uuid.New(),
+		Type:   enums.SongEntry,
+		SongID: &[]uuid.UUID{uuid.New()}[0],
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+	songRepository.On("Get", new(model.Song), *request.SongID).Return(nil).Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "song not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenGetEntriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, nil)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:    uuid.New(),
+		Type:  enums.TalkEntry,
+		Title: "Introduction",
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenCreateEntryFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewAddEntryToSetlist(setlistRepository, nil)
+
+	request := requests.AddEntryToSetlistRequest{
+		ID:    uuid.New(),
+		Type:  enums.TalkEntry,
+		Title: "Introduction",
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, &[]model.SetlistEntry{}).
+		Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("CreateEntry", mock.IsType(new(model.SetlistEntry))).
+		Return(internalError).
+		Once()
+
+	// when
+	id, errCode := _uut.Handle(request)
+
+	// then
+	assert.Empty(t, id)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestAddEntryToSetlist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         requests.AddEntryToSetlistRequest
+		entries         []model.SetlistEntry
+		expectedEntryNo uint
+	}{
+		{
+			"Song entry on empty setlist",
+			requests.AddEntryToSetlistRequest{
+				ID:              uuid.New(),
+				Type:            enums.SongEntry,
+				SongID:          &[]uuid.UUID{uuid.New()}[0],
+				PlannedDuration: &[]uint{240}[0],
+			},
+			[]model.SetlistEntry{},
+			1,
+		},
+		{
+			"Talk entry after existing entries",
+			requests.AddEntryToSetlistRequest{
+				ID:    uuid.New(),
+				Type:  enums.TalkEntry,
+				Title: "Introduction",
+			},
+			[]model.SetlistEntry{
+				{ID: uuid.New(), EntryNo: 1},
+				{ID: uuid.New(), EntryNo: 2},
+			},
+			3,
+		},
+		{
+			"Encore marker after entries with gaps",
+			requests.AddEntryToSetlistRequest{
+				ID:    uuid.New(),
+				Type:  enums.EncoreMarkerEntry,
+				Title: "Encore",
+			},
+			[]model.SetlistEntry{
+				{ID: uuid.New(), EntryNo: 1},
+				{ID: uuid.New(), EntryNo: 4},
+			},
+			5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			setlistRepository := new(repository.SetlistRepositoryMock)
+			songRepository := new(repository.SongRepositoryMock)
+			_uut := entry.NewAddEntryToSetlist(setlistRepository, songRepository)
+
+			mockSetlist := &model.Setlist{ID: tt.request.ID}
+			setlistRepository.On("Get", new(model.Setlist), tt.request.ID).
+				Return(nil, mockSetlist).
+				Once()
+
+			if tt.request.Type == enums.SongEntry {
+				mockSong := &model.Song{ID: *tt.request.SongID}
+				songRepository.On("Get", new(model.Song), *tt.request.SongID).
+					Return(nil, mockSong).
+					Once()
+			}
+
+			setlistRepository.On("GetEntries", new([]model.SetlistEntry), tt.request.ID).
+				Return(nil, &tt.entries).
+				Once()
+
+			var entryID uuid.UUID
+			setlistRepository.On("CreateEntry", mock.IsType(new(model.SetlistEntry))).
+				Run(func(args mock.Arguments) {
+					newEntry := args.Get(0).(*model.SetlistEntry)
+					assert.NotEmpty(t, newEntry.ID)
+					assert.Equal(t, tt.request.Type, newEntry.Type)
+					assert.Equal(t, tt.request.Title, newEntry.Title)
+					assert.Equal(t, tt.request.PlannedDuration, newEntry.PlannedDuration)
+					assert.Equal(t, tt.request.SongID, newEntry.SongID)
+					assert.Equal(t, tt.request.ID, newEntry.SetlistID)
+					assert.Equal(t, tt.expectedEntryNo, newEntry.EntryNo)
+					entryID = newEntry.ID
+				}).
+				Return(nil).
+				Once()
+
+			// when
+			id, errCode := _uut.Handle(tt.request)
+
+			// then
+			assert.Equal(t, entryID, id)
+			assert.Nil(t, errCode)
+
+			setlistRepository.AssertExpectations(t)
+			songRepository.AssertExpectations(t)
+		})
+	}
+}
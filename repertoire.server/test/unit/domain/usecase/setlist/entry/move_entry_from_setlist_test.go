@@ -0,0 +1,234 @@
+package entry
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist/entry"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMoveEntryFromSetlist_WhenGetEntriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewMoveEntryFromSetlist(setlistRepository)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          uuid.New(),
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// given - mocking
+	internalError := errors.New("internal error")
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestMoveEntryFromSetlist_WhenEntryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewMoveEntryFromSetlist(setlistRepository)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          uuid.New(),
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: uuid.New()},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "entry not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestMoveEntryFromSetlist_WhenOverEntryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewMoveEntryFromSetlist(setlistRepository)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          uuid.New(),
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryID},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "over entry not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestMoveEntryFromSetlist_WhenUpdateAllFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewMoveEntryFromSetlist(setlistRepository)
+
+	request := requests.MoveEntryFromSetlistRequest{
+		ID:          uuid.New(),
+		EntryID:     uuid.New(),
+		OverEntryID: uuid.New(),
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryID},
+		{ID: request.OverEntryID},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("UpdateAllEntries", mock.IsType(new([]model.SetlistEntry))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestMoveEntryFromSetlist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   *[]model.SetlistEntry
+		index     uint
+		overIndex uint
+	}{
+		{
+			"Use case 1",
+			&[]model.SetlistEntry{
+				{ID: uuid.New(), EntryNo: 1},
+				{ID: uuid.New(), EntryNo: 2},
+				{ID: uuid.New(), EntryNo: 3},
+				{ID: uuid.New(), EntryNo: 4},
+				{ID: uuid.New(), EntryNo: 5},
+			},
+			1,
+			3,
+		},
+		{
+			"Use case 2",
+			&[]model.SetlistEntry{
+				{ID: uuid.New(), EntryNo: 1},
+				{ID: uuid.New(), EntryNo: 2},
+				{ID: uuid.New(), EntryNo: 3},
+				{ID: uuid.New(), EntryNo: 4},
+				{ID: uuid.New(), EntryNo: 5},
+			},
+			3,
+			1,
+		},
+		{
+			"Use case 3 - with gaps left by the deleted songs",
+			&[]model.SetlistEntry{
+				{ID: uuid.New(), EntryNo: 1},
+				{ID: uuid.New(), EntryNo: 2},
+				{ID: uuid.New(), EntryNo: 4},
+				{ID: uuid.New(), EntryNo: 7},
+				{ID: uuid.New(), EntryNo: 8},
+			},
+			4,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			setlistRepository := new(repository.SetlistRepositoryMock)
+			_uut := entry.NewMoveEntryFromSetlist(setlistRepository)
+
+			request := requests.MoveEntryFromSetlistRequest{
+				ID:          uuid.New(),
+				EntryID:     (*tt.entries)[tt.index].ID,
+				OverEntryID: (*tt.entries)[tt.overIndex].ID,
+			}
+
+			// given - mocking
+			setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+				Return(nil, tt.entries).
+				Once()
+
+			setlistRepository.On("UpdateAllEntries", mock.IsType(new([]model.SetlistEntry))).
+				Run(func(args mock.Arguments) {
+					newEntries := args.Get(0).(*[]model.SetlistEntry)
+					entries := slices.Clone(*newEntries)
+					slices.SortFunc(entries, func(a, b model.SetlistEntry) int {
+						return cmp.Compare(a.EntryNo, b.EntryNo)
+					})
+					if tt.index < tt.overIndex {
+						assert.Equal(t, entries[tt.overIndex-1].ID, request.OverEntryID)
+					} else if tt.index > tt.overIndex {
+						assert.Equal(t, entries[tt.overIndex+1].ID, request.OverEntryID)
+					}
+					assert.Equal(t, entries[tt.overIndex].ID, request.EntryID)
+					for i, s := range entries {
+						assert.Equal(t, uint(i)+1, s.EntryNo)
+					}
+				}).
+				Return(nil).
+				Once()
+
+			// when
+			errCode := _uut.Handle(request)
+
+			// then
+			assert.Nil(t, errCode)
+
+			setlistRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package entry
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist/entry"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRemoveEntriesFromSetlist_WhenGetEntriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, nil)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	internalError := errors.New("internal error")
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestRemoveEntriesFromSetlist_WhenNotAllEntriesFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, nil)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New(), uuid.New()},
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryIDs[0], EntryNo: 1},
+		{ID: uuid.New(), EntryNo: 2},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "could not find all entries", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestRemoveEntriesFromSetlist_WhenTransactionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, transactionManager)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryIDs[0], EntryNo: 1},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionManager.On("Execute", mock.Anything).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+}
+
+func TestRemoveEntriesFromSetlist_WhenRemoveEntriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSetlistRepository := new(repository.SetlistRepositoryMock)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryIDs[0], EntryNo: 1},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	repositoryFactory.On("NewSetlistRepository").Return(transactionSetlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	internalError := errors.New("internal error")
+	transactionSetlistRepository.On("RemoveEntries", mock.IsType(entries)).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transactionSetlistRepository.AssertExpectations(t)
+}
+
+func TestRemoveEntriesFromSetlist_WhenUpdateAllEntriesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSetlistRepository := new(repository.SetlistRepositoryMock)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New()},
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: request.EntryIDs[0], EntryNo: 1},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	repositoryFactory.On("NewSetlistRepository").Return(transactionSetlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	transactionSetlistRepository.On("RemoveEntries", mock.IsType(entries)).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionSetlistRepository.On("UpdateAllEntries", mock.IsType(entries)).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transactionSetlistRepository.AssertExpectations(t)
+}
+
+func TestRemoveEntriesFromSetlist_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	transactionManager := new(transaction.ManagerMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewRemoveEntriesFromSetlist(setlistRepository, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSetlistRepository := new(repository.SetlistRepositoryMock)
+
+	request := requests.RemoveEntriesFromSetlistRequest{
+		ID:       uuid.New(),
+		EntryIDs: []uuid.UUID{uuid.New(), uuid.New()},
+	}
+
+	// given - mocking
+	entries := &[]model.SetlistEntry{
+		{ID: uuid.New(), EntryNo: 1},
+		{ID: request.EntryIDs[0], EntryNo: 2},
+		{ID: uuid.New(), EntryNo: 3},
+		{ID: request.EntryIDs[1], EntryNo: 4},
+		{ID: uuid.New(), EntryNo: 5},
+	}
+	setlistRepository.On("GetEntries", new([]model.SetlistEntry), request.ID).
+		Return(nil, entries).
+		Once()
+
+	repositoryFactory.On("NewSetlistRepository").Return(transactionSetlistRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	transactionSetlistRepository.On("RemoveEntries", mock.IsType(entries)).
+		Run(func(args mock.Arguments) {
+			removedEntries := args.Get(0).(*[]model.SetlistEntry)
+			assert.Len(t, *removedEntries, len(request.EntryIDs))
+			for _, s := range *removedEntries {
+				assert.Contains(t, request.EntryIDs, s.ID)
+			}
+		}).
+		Return(nil).
+		Once()
+
+	transactionSetlistRepository.On("UpdateAllEntries", mock.IsType(entries)).
+		Run(func(args mock.Arguments) {
+			newEntries := args.Get(0).(*[]model.SetlistEntry)
+			assert.Len(t, *newEntries, len(*entries)-len(request.EntryIDs))
+			for i, s := range *newEntries {
+				assert.NotContains(t, request.EntryIDs, s.ID)
+				assert.Equal(t, uint(i)+1, s.EntryNo)
+			}
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	setlistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transactionSetlistRepository.AssertExpectations(t)
+}
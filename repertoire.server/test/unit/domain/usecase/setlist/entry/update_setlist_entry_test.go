@@ -0,0 +1,173 @@
+package entry
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist/entry"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateSetlistEntry_WhenGetEntryFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewUpdateSetlistEntry(setlistRepository)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID:    uuid.New(),
+		Title: "New Title",
+	}
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("GetEntry", new(model.SetlistEntry), request.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlistEntry_WhenEntryIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewUpdateSetlistEntry(setlistRepository)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID:    uuid.New(),
+		Title: "New Title",
+	}
+
+	setlistRepository.On("GetEntry", new(model.SetlistEntry), request.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "setlist entry not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlistEntry_WhenTitleIsMissingOnNonSongEntry_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewUpdateSetlistEntry(setlistRepository)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID: uuid.New(),
+	}
+
+	mockEntry := &model.SetlistEntry{ID: request.ID, Type: enums.TalkEntry, Title: "Introduction"}
+	setlistRepository.On("GetEntry", new(model.SetlistEntry), request.ID).Return(nil, mockEntry).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "title is required for entries that are not songs", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlistEntry_WhenUpdateEntryFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := entry.NewUpdateSetlistEntry(setlistRepository)
+
+	request := requests.UpdateSetlistEntryRequest{
+		ID:    uuid.New(),
+		Title: "New Title",
+	}
+
+	mockEntry := &model.SetlistEntry{ID: request.ID, Type: enums.TalkEntry, Title: "Introduction"}
+	setlistRepository.On("GetEntry", new(model.SetlistEntry), request.ID).Return(nil, mockEntry).Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("UpdateEntry", mock.IsType(new(model.SetlistEntry))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlistEntry_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   model.SetlistEntry
+		request requests.UpdateSetlistEntryRequest
+	}{
+		{
+			"Song entry without title",
+			model.SetlistEntry{Type: enums.SongEntry},
+			requests.UpdateSetlistEntryRequest{
+				PlannedDuration: &[]uint{300}[0],
+			},
+		},
+		{
+			"Tuning break with title",
+			model.SetlistEntry{Type: enums.TuningBreakEntry, Title: "Tuning"},
+			requests.UpdateSetlistEntryRequest{
+				Title:           "Drop D Tuning",
+				PlannedDuration: &[]uint{45}[0],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			setlistRepository := new(repository.SetlistRepositoryMock)
+			_uut := entry.NewUpdateSetlistEntry(setlistRepository)
+
+			tt.request.ID = uuid.New()
+			tt.entry.ID = tt.request.ID
+
+			setlistRepository.On("GetEntry", new(model.SetlistEntry), tt.request.ID).
+				Return(nil, &tt.entry).
+				Once()
+
+			setlistRepository.On("UpdateEntry", mock.IsType(new(model.SetlistEntry))).
+				Run(func(args mock.Arguments) {
+					newEntry := args.Get(0).(*model.SetlistEntry)
+					assert.Equal(t, tt.request.ID, newEntry.ID)
+					assert.Equal(t, tt.entry.Type, newEntry.Type)
+					assert.Equal(t, tt.request.Title, newEntry.Title)
+					assert.Equal(t, tt.request.PlannedDuration, newEntry.PlannedDuration)
+				}).
+				Return(nil).
+				Once()
+
+			// when
+			errCode := _uut.Handle(tt.request)
+
+			// then
+			assert.Nil(t, errCode)
+
+			setlistRepository.AssertExpectations(t)
+		})
+	}
+}
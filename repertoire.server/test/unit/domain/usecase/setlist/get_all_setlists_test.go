@@ -0,0 +1,194 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllSetlists_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewGetAllSetlists(nil, jwtService)
+
+	request := requests.GetSetlistsRequest{}
+	token := "This is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	setlists, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, setlists)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllSetlists_WhenGetSetlistsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewGetAllSetlists(setlistRepository, jwtService)
+
+	request := requests.GetSetlistsRequest{}
+	token := "This is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.
+		On(
+			"GetAllByUser",
+			mock.Anything,
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	setlists, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, setlists)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllSetlists_WhenGetSetlistsCountFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewGetAllSetlists(setlistRepository, jwtService)
+
+	request := requests.GetSetlistsRequest{}
+	token := "This is a token"
+
+	expectedSetlists := &[]model.Setlist{
+		{Title: "Some Setlist"},
+	}
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	setlistRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(expectedSetlists),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, expectedSetlists).
+		Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.
+		On(
+			"GetAllByUserCount",
+			mock.Anything,
+			userID,
+			request.SearchBy,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Empty(t, result.TotalCount)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetAllSetlists_WhenSuccessful_ShouldReturnSetlistsWithTotalCountAndDurations(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := setlist.NewGetAllSetlists(setlistRepository, jwtService)
+
+	request := requests.GetSetlistsRequest{}
+	token := "This is a token"
+
+	expectedSetlists := &[]model.Setlist{
+		{
+			Title: "Some Setlist",
+			Entries: []model.SetlistEntry{
+				{PlannedDuration: &[]uint{200}[0]},
+				{PlannedDuration: &[]uint{100}[0]},
+			},
+		},
+		{Title: "Some other Setlist"},
+	}
+	expectedTotalCount := &[]int64{20}[0]
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	setlistRepository.
+		On(
+			"GetAllByUser",
+			mock.IsType(expectedSetlists),
+			userID,
+			request.CurrentPage,
+			request.PageSize,
+			request.OrderBy,
+			request.SearchBy,
+		).
+		Return(nil, expectedSetlists).
+		Once()
+
+	setlistRepository.
+		On(
+			"GetAllByUserCount",
+			mock.IsType(expectedTotalCount),
+			userID,
+			request.SearchBy,
+		).
+		Return(nil, expectedTotalCount).
+		Once()
+
+	// when
+	result, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Len(t, result.Models, len(*expectedSetlists))
+	assert.Equal(t, uint(300), result.Models[0].TotalDuration)
+	assert.Equal(t, uint(0), result.Models[1].TotalDuration)
+	assert.Equal(t, expectedTotalCount, &result.TotalCount)
+	assert.Nil(t, errCode)
+
+	setlistRepository.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSetlist_WhenGetSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewGetSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("GetWithEntries", new(model.Setlist), id).Return(internalError).Once()
+
+	// when
+	resultSetlist, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, resultSetlist)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestGetSetlist_WhenSetlistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewGetSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	setlistRepository.On("GetWithEntries", new(model.Setlist), id).Return(nil).Once()
+
+	// when
+	resultSetlist, errCode := _uut.Handle(id)
+
+	// then
+	assert.Empty(t, resultSetlist)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "setlist not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestGetSetlist_WhenSuccessful_ShouldReturnSetlistWithTotalDuration(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewGetSetlist(setlistRepository)
+
+	id := uuid.New()
+
+	expectedSetlist := &model.Setlist{
+		ID:    id,
+		Title: "Some Setlist",
+		Entries: []model.SetlistEntry{
+			{ID: uuid.New(), Type: enums.SongEntry, PlannedDuration: &[]uint{240}[0]},
+			{ID: uuid.New(), Type: enums.TalkEntry, PlannedDuration: &[]uint{60}[0]},
+			{ID: uuid.New(), Type: enums.TuningBreakEntry},
+			{ID: uuid.New(), Type: enums.SongEntry, PlannedDuration: &[]uint{180}[0]},
+			{ID: uuid.New(), Type: enums.SongEntry, Song: &model.Song{Duration: &[]uint{200}[0]}},
+			{ID: uuid.New(), Type: enums.SongEntry, Song: &model.Song{}},
+		},
+	}
+	setlistRepository.On("GetWithEntries", new(model.Setlist), id).Return(nil, expectedSetlist).Once()
+
+	// when
+	resultSetlist, errCode := _uut.Handle(id)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, expectedSetlist.ID, resultSetlist.ID)
+	assert.Equal(t, expectedSetlist.Entries, resultSetlist.Entries)
+	assert.Equal(t, uint(680), resultSetlist.TotalDuration)
+
+	setlistRepository.AssertExpectations(t)
+}
@@ -0,0 +1,130 @@
+package setlist
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/setlist"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateSetlist_WhenGetSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewUpdateSetlist(setlistRepository)
+
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: "New Setlist",
+	}
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlist_WhenSetlistIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewUpdateSetlist(setlistRepository)
+
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: "New Setlist",
+	}
+
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "setlist not found", errCode.Error.Error())
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlist_WhenUpdateSetlistFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewUpdateSetlist(setlistRepository)
+
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: "New Setlist",
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID, Title: "Some Setlist"}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+
+	internalError := errors.New("internal error")
+	setlistRepository.On("Update", mock.IsType(new(model.Setlist))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestUpdateSetlist_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	_uut := setlist.NewUpdateSetlist(setlistRepository)
+
+	date := time.Now()
+	request := requests.UpdateSetlistRequest{
+		ID:    uuid.New(),
+		Title: "New Setlist",
+		Venue: "New Venue",
+		Date:  &date,
+		Notes: "New notes",
+	}
+
+	mockSetlist := &model.Setlist{ID: request.ID, Title: "Some Setlist"}
+	setlistRepository.On("Get", new(model.Setlist), request.ID).Return(nil, mockSetlist).Once()
+
+	setlistRepository.On("Update", mock.IsType(new(model.Setlist))).
+		Run(func(args mock.Arguments) {
+			newSetlist := args.Get(0).(*model.Setlist)
+			assert.Equal(t, request.ID, newSetlist.ID)
+			assert.Equal(t, request.Title, newSetlist.Title)
+			assert.Equal(t, request.Venue, newSetlist.Venue)
+			assert.Equal(t, request.Date, newSetlist.Date)
+			assert.Equal(t, request.Notes, newSetlist.Notes)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	setlistRepository.AssertExpectations(t)
+}
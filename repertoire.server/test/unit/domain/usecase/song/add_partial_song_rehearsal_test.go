@@ -5,7 +5,10 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/domain/processor"
 	"slices"
@@ -20,7 +23,7 @@ import (
 func TestAddPartialSongRehearsal_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPartialSongRehearsal(nil, songRepository, nil)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, nil, nil, nil, nil)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -43,7 +46,7 @@ func TestAddPartialSongRehearsal_WhenGetSongFails_ShouldReturnInternalServerErro
 func TestAddPartialSongRehearsal_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPartialSongRehearsal(nil, songRepository, nil)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, nil, nil, nil, nil)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -62,11 +65,11 @@ func TestAddPartialSongRehearsal_WhenSongIsEmpty_ShouldReturnNotFoundError(t *te
 	songRepository.AssertExpectations(t)
 }
 
-func TestAddPartialSongRehearsal_WhenCreateHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestAddPartialSongRehearsal_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
-	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPartialSongRehearsal(songSectionRepository, songRepository, nil)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, userRepository, nil, nil, nil)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -74,18 +77,96 @@ func TestAddPartialSongRehearsal_WhenCreateHistoryFails_ShouldReturnInternalServ
 
 	mockSong := &model.Song{
 		ID:       uuid.New(),
+		UserID:   uuid.New(),
 		Sections: []model.SongSection{{ID: uuid.New(), PartialOccurrences: 2}},
 	}
 	songRepository.On("GetWithSections", new(model.Song), request.ID).
 		Return(nil, mockSong).
 		Once()
 
-	sectionsCount := len(mockSong.Sections)
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestAddPartialSongRehearsal_WhenTransactionExecuteFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, userRepository, transactionManager, nil, nil)
+
+	request := requests.AddPartialSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:       uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), PartialOccurrences: 2}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
 
 	internalError := errors.New("internal error")
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionManager.On("Execute", mock.Anything).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+}
+
+func TestAddPartialSongRehearsal_WhenCreateRehearsalBatchFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, userRepository, transactionManager, nil, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.AddPartialSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:       uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), PartialOccurrences: 2}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(internalError).
-		Times(sectionsCount)
+		Once()
 
 	// when
 	errCode := _uut.Handle(request)
@@ -95,15 +176,24 @@ func TestAddPartialSongRehearsal_WhenCreateHistoryFails_ShouldReturnInternalServ
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
-	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
 }
 
-func TestAddPartialSongRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestAddPartialSongRehearsal_WhenCreateHistoriesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
-	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPartialSongRehearsal(songSectionRepository, songRepository, nil)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, userRepository, transactionManager, nil, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -116,23 +206,100 @@ func TestAddPartialSongRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerE
 	songRepository.On("GetWithSections", new(model.Song), request.ID).
 		Return(nil, mockSong).
 		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
 
-	sectionsCount := len(mockSong.Sections)
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
 
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(nil).
-		Times(sectionsCount)
+		Once()
 
 	internalError := errors.New("internal error")
-	songSectionRepository.
-		On(
-			"GetHistory",
-			new([]model.SongSectionHistory),
-			mock.IsType(uuid.UUID{}),
-			model.RehearsalsProperty,
-		).
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
 		Return(internalError).
-		Times(sectionsCount)
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestAddPartialSongRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerErrorWithoutUpdatingTheSong(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	_uut := song.NewAddPartialSongRehearsal(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		practiceSessionProcessor,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.AddPartialSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID: uuid.New(),
+		Sections: []model.SongSection{
+			{ID: uuid.New(), PartialOccurrences: 2},
+			{ID: uuid.New(), PartialOccurrences: 1},
+		},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Return(nil).
+		Once()
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
+
+	// the first section goes through, the second one fails mid-way
+	transactionSongSectionRepository.
+		On("GetHistory", new([]model.SongSectionHistory), mockSong.Sections[0].ID, model.RehearsalsProperty).
+		Return(nil, &[]model.SongSectionHistory{}).
+		Once()
+	progressProcessor.On("ComputeRehearsalsScore", []model.SongSectionHistory{}, enums.ClassicScoring).
+		Return(uint64(23)).
+		Once()
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ClassicScoring).
+		Return(uint64(123)).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.
+		On("GetHistory", new([]model.SongSectionHistory), mockSong.Sections[1].ID, model.RehearsalsProperty).
+		Return(internalError).
+		Once()
 
 	// when
 	errCode := _uut.Handle(request)
@@ -142,16 +309,35 @@ func TestAddPartialSongRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerE
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
-	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionSongRepository.AssertNotCalled(t, "UpdateWithAssociations", mock.Anything)
+	practiceSessionProcessor.AssertNotCalled(t, "RecordRehearsal", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestAddPartialSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
-	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := song.NewAddPartialSongRehearsal(songSectionRepository, songRepository, progressProcessor)
+	_uut := song.NewAddPartialSongRehearsal(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -164,15 +350,23 @@ func TestAddPartialSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError
 	songRepository.On("GetWithSections", new(model.Song), request.ID).
 		Return(nil, mockSong).
 		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
 
 	sectionsCount := len(mockSong.Sections)
 
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(nil).
-		Times(sectionsCount)
+		Once()
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
 
 	history := &[]model.SongSectionHistory{}
-	songSectionRepository.
+	transactionSongSectionRepository.
 		On(
 			"GetHistory",
 			new([]model.SongSectionHistory),
@@ -183,14 +377,16 @@ func TestAddPartialSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError
 		Times(sectionsCount)
 
 	var newRehearsalScore uint64 = 23
-	progressProcessor.On("ComputeRehearsalsScore", *history).Return(newRehearsalScore).Times(sectionsCount)
+	progressProcessor.On("ComputeRehearsalsScore", *history, enums.ClassicScoring).
+		Return(newRehearsalScore).
+		Times(sectionsCount)
 	var newProgress uint64 = 123
-	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{})).
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ClassicScoring).
 		Return(newProgress).
 		Times(sectionsCount)
 
 	internalError := errors.New("internal error")
-	songRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
 		Return(internalError).
 		Once()
 
@@ -202,15 +398,119 @@ func TestAddPartialSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
-	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 }
 
+func TestAddPartialSongRehearsal_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	_uut := song.NewAddPartialSongRehearsal(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		practiceSessionProcessor,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPartialSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:       uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), PartialOccurrences: 2}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
+
+	sectionsCount := len(mockSong.Sections)
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Return(nil).
+		Once()
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
+
+	history := &[]model.SongSectionHistory{}
+	transactionSongSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			mock.IsType(uuid.UUID{}),
+			model.RehearsalsProperty,
+		).
+		Return(nil, history).
+		Times(sectionsCount)
+
+	progressProcessor.On("ComputeRehearsalsScore", *history, enums.ClassicScoring).
+		Return(uint64(23)).
+		Times(sectionsCount)
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ClassicScoring).
+		Return(uint64(123)).
+		Times(sectionsCount)
+
+	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.
+		On(
+			"RecordRehearsal",
+			mock.IsType(model.Song{}),
+			mock.IsType(uuid.UUID{}),
+			mock.IsType([]model.PracticeSessionSection{}),
+			transactionPracticeSessionRepository,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+}
+
 func TestAddPartialSongRehearsal_WhenSectionsHaveZeroPartialOccurrences_ShouldNotUpdateTheSong(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPartialSongRehearsal(nil, songRepository, nil)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPartialSongRehearsal(songRepository, userRepository, transactionManager, nil, nil)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -230,6 +530,7 @@ func TestAddPartialSongRehearsal_WhenSectionsHaveZeroPartialOccurrences_ShouldNo
 	songRepository.On("GetWithSections", new(model.Song), request.ID).
 		Return(nil, &mockSong).
 		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).Once()
 
 	// when
 	errCode := _uut.Handle(request)
@@ -238,14 +539,29 @@ func TestAddPartialSongRehearsal_WhenSectionsHaveZeroPartialOccurrences_ShouldNo
 	assert.Nil(t, errCode)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 }
 
 func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *testing.T) {
 	// given
-	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := song.NewAddPartialSongRehearsal(songSectionRepository, songRepository, progressProcessor)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	_uut := song.NewAddPartialSongRehearsal(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		practiceSessionProcessor,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPartialSongRehearsalRequest{
 		ID: uuid.New(),
@@ -271,6 +587,7 @@ func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *t
 	songRepository.On("GetWithSections", new(model.Song), request.ID).
 		Return(nil, &mockSong).
 		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(nil, &model.User{ScoringStrategy: enums.LinearScoring}).Once()
 
 	oldSections := slices.Clone(mockSong.Sections)
 	sectionsCount := len(mockSong.Sections)
@@ -279,25 +596,45 @@ func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *t
 		return section.PartialOccurrences == 0
 	}))
 
-	songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
-		Run(func(args mock.Arguments) {
-			newHistory := args.Get(0).(*model.SongSectionHistory)
-			assert.NotEmpty(t, newHistory.ID)
-			assert.Equal(t, model.RehearsalsProperty, newHistory.Property)
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 
-			sections := slices.Clone(mockSong.Sections)
-			sections = slices.DeleteFunc(sections, func(section model.SongSection) bool {
-				return section.ID != newHistory.SongSectionID
-			})
+	var batchID uuid.UUID
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Run(func(args mock.Arguments) {
+			newBatch := args.Get(0).(*model.RehearsalBatch)
+			assert.NotEmpty(t, newBatch.ID)
+			assert.Equal(t, mockSong.ID, newBatch.SongID)
+			assert.Equal(t, mockSong.LastTimePlayed, newBatch.PreviousLastTimePlayed)
+			batchID = newBatch.ID
+		}).
+		Return(nil).
+		Once()
 
-			assert.Equal(t, sections[0].Rehearsals, newHistory.From)
-			assert.Equal(t, sections[0].Rehearsals+sections[0].PartialOccurrences, newHistory.To)
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Run(func(args mock.Arguments) {
+			newHistory := args.Get(0).(*[]model.SongSectionHistory)
+			assert.Len(t, *newHistory, sectionsCountWithOcc)
+
+			for _, h := range *newHistory {
+				assert.NotEmpty(t, h.ID)
+				assert.Equal(t, &batchID, h.BatchID)
+				assert.Equal(t, model.RehearsalsProperty, h.Property)
+
+				i := slices.IndexFunc(oldSections, func(s model.SongSection) bool {
+					return s.ID == h.SongSectionID
+				})
+				assert.Equal(t, oldSections[i].Rehearsals, h.From)
+				assert.Equal(t, oldSections[i].Rehearsals+oldSections[i].PartialOccurrences, h.To)
+			}
 		}).
 		Return(nil).
-		Times(sectionsCountWithOcc)
+		Once()
 
 	history := &[]model.SongSectionHistory{}
-	songSectionRepository.
+	transactionSongSectionRepository.
 		On(
 			"GetHistory",
 			new([]model.SongSectionHistory),
@@ -317,11 +654,11 @@ func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *t
 		Times(sectionsCountWithOcc)
 
 	var newRehearsalScore uint64 = 23
-	progressProcessor.On("ComputeRehearsalsScore", *history).
+	progressProcessor.On("ComputeRehearsalsScore", *history, enums.LinearScoring).
 		Return(newRehearsalScore).
 		Times(sectionsCountWithOcc)
 	var newProgress uint64 = 123
-	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{})).
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.LinearScoring).
 		Run(func(args mock.Arguments) {
 			sec := args.Get(0).(model.SongSection)
 			assert.Contains(t, mockSong.Sections, sec)
@@ -329,7 +666,7 @@ func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *t
 		Return(newProgress).
 		Times(sectionsCountWithOcc)
 
-	songRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
 		Run(func(args mock.Arguments) {
 			newSong := args.Get(0).(*model.Song)
 
@@ -353,13 +690,41 @@ func TestAddPartialSongRehearsal_WhenSuccessful_ShouldUpdateSongAndSections(t *t
 		Return(nil).
 		Once()
 
+	practiceSessionProcessor.
+		On(
+			"RecordRehearsal",
+			mock.IsType(model.Song{}),
+			mock.IsType(uuid.UUID{}),
+			mock.IsType([]model.PracticeSessionSection{}),
+			transactionPracticeSessionRepository,
+		).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, batchID, args.Get(1).(uuid.UUID))
+
+			sections := args.Get(2).([]model.PracticeSessionSection)
+			assert.Len(t, sections, sectionsCountWithOcc)
+			for _, section := range sections {
+				i := slices.IndexFunc(oldSections, func(s model.SongSection) bool {
+					return s.ID == section.SongSectionID
+				})
+				assert.Equal(t, oldSections[i].PartialOccurrences, section.Occurrences)
+			}
+		}).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(request)
 
 	// then
 	assert.Nil(t, errCode)
 
-	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 }
@@ -20,7 +20,7 @@ import (
 func TestAddPerfectSongRehearsal_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, nil, nil)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, nil, nil, nil)
 
 	request := requests.AddPerfectSongRehearsalRequest{
 		ID: uuid.New(),
@@ -43,7 +43,7 @@ func TestAddPerfectSongRehearsal_WhenGetSongFails_ShouldReturnInternalServerErro
 func TestAddPerfectSongRehearsal_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, nil, nil)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, nil, nil, nil)
 
 	request := requests.AddPerfectSongRehearsalRequest{
 		ID: uuid.New(),
@@ -67,7 +67,7 @@ func TestAddPerfectSongRehearsal_WhenTransactionExecuteFails_ShouldReturnError(t
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, nil, transactionManager)
 
 	request := requests.AddPerfectSongRehearsalRequest{
 		ID: uuid.New(),
@@ -99,7 +99,7 @@ func TestAddPerfectSongRehearsal_WhenProcessorFails_ShouldReturnInternalServerEr
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -120,7 +120,7 @@ func TestAddPerfectSongRehearsal_WhenProcessorFails_ShouldReturnInternalServerEr
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	songProcessor.On("AddPerfectRehearsal", &mockSong, transactionSongSectionRepository).
-		Return(internalError, false).
+		Return(internalError, nil).
 		Once()
 
 	// when
@@ -143,7 +143,7 @@ func TestAddPerfectSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -163,7 +163,7 @@ func TestAddPerfectSongRehearsal_WhenUpdateFails_ShouldReturnInternalServerError
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", &mockSong, transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 		Once()
 
 	internalError := errors.New("internal error")
@@ -192,7 +192,7 @@ func TestAddPerfectSongRehearsal_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *tes
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -212,7 +212,7 @@ func TestAddPerfectSongRehearsal_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *tes
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", &mockSong, transactionSongSectionRepository).
-		Return(nil, false).
+		Return(nil, nil).
 		Once()
 
 	// when
@@ -229,16 +229,75 @@ func TestAddPerfectSongRehearsal_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *tes
 	transactionSongRepository.AssertExpectations(t)
 }
 
+func TestAddPerfectSongRehearsal_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, practiceSessionProcessor, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := model.Song{ID: request.ID}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, &mockSong).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	songProcessor.On("AddPerfectRehearsal", &mockSong, transactionSongSectionRepository).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+		Once()
+
+	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mock.IsType(model.Song{}), mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	songRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
 func TestAddPerfectSongRehearsal_WhenSuccessful_ShouldUpdateSong(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsal(songRepository, songProcessor, practiceSessionProcessor, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPerfectSongRehearsalRequest{
 		ID: uuid.New(),
@@ -251,16 +310,22 @@ func TestAddPerfectSongRehearsal_WhenSuccessful_ShouldUpdateSong(t *testing.T) {
 
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
+	batchID := uuid.New()
 	songProcessor.On("AddPerfectRehearsal", &mockSong, transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &batchID).
 		Once()
 
 	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
 		Return(nil).
 		Once()
 
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mockSong, batchID, transactionPracticeSessionRepository).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(request)
 
@@ -269,8 +334,10 @@ func TestAddPerfectSongRehearsal_WhenSuccessful_ShouldUpdateSong(t *testing.T) {
 
 	songRepository.AssertExpectations(t)
 	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	repositoryFactory.AssertExpectations(t)
 	transactionSongSectionRepository.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
 }
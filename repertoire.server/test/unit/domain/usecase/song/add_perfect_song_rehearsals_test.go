@@ -20,7 +20,7 @@ import (
 func TestAddPerfectSongRehearsals_WhenGetSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, nil, nil)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, nil, nil, nil)
 
 	request := requests.AddPerfectSongRehearsalsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -45,7 +45,7 @@ func TestAddPerfectSongRehearsals_WhenGetSongsFails_ShouldReturnInternalServerEr
 func TestAddPerfectSongRehearsals_WhenSongsLenIs0_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, nil, nil)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, nil, nil, nil)
 
 	request := requests.AddPerfectSongRehearsalsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -71,7 +71,7 @@ func TestAddPerfectSongRehearsals_WhenTransactionExecuteFails_ShouldReturnError(
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, nil, transactionManager)
 
 	request := requests.AddPerfectSongRehearsalsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -103,7 +103,7 @@ func TestAddPerfectSongRehearsals_WhenProcessorFails_ShouldReturnInternalServerE
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -124,7 +124,7 @@ func TestAddPerfectSongRehearsals_WhenProcessorFails_ShouldReturnInternalServerE
 
 	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(internalError, false).
+		Return(internalError, nil).
 		Times(len(mockSongs))
 
 	// when
@@ -147,7 +147,7 @@ func TestAddPerfectSongRehearsals_WhenUpdateFails_ShouldReturnInternalServerErro
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -167,7 +167,7 @@ func TestAddPerfectSongRehearsals_WhenUpdateFails_ShouldReturnInternalServerErro
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, true).
+		Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 		Times(len(mockSongs))
 
 	internalError := errors.New("internal error")
@@ -196,7 +196,7 @@ func TestAddPerfectSongRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, nil, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -222,7 +222,7 @@ func TestAddPerfectSongRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	songProcessor.On("AddPerfectRehearsal", mock.IsType(new(model.Song)), transactionSongSectionRepository).
-		Return(nil, false).
+		Return(nil, nil).
 		Times(len(mockSongs))
 
 	// when
@@ -239,16 +239,88 @@ func TestAddPerfectSongRehearsals_WhenSongsAreNotUpdated_ShouldNotUpdateSongs(t
 	transactionSongRepository.AssertExpectations(t)
 }
 
+func TestAddPerfectSongRehearsals_WhenRecordPracticeSessionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, practiceSessionProcessor, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.AddPerfectSongRehearsalsRequest{
+		IDs: []uuid.UUID{
+			uuid.New(),
+			uuid.New(),
+		},
+	}
+
+	mockSongs := []model.Song{
+		{ID: request.IDs[0]},
+		{ID: request.IDs[1]},
+	}
+	songRepository.On("GetAllByIDsWithSections", new([]model.Song), request.IDs).
+		Return(nil, &mockSongs).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	for _, s := range mockSongs {
+		songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
+			Return(nil, &[]uuid.UUID{uuid.New()}[0]).
+			Once()
+	}
+
+	transactionSongRepository.On("UpdateAllWithAssociations", mock.IsType(new([]model.Song))).
+		Run(func(args mock.Arguments) {
+			newSongs := args.Get(0).(*[]model.Song)
+			assert.Len(t, *newSongs, len(mockSongs))
+			assert.ElementsMatch(t, mockSongs, *newSongs)
+		}).
+		Return(nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	practiceSessionProcessor.On("RecordPerfectRehearsal", mock.IsType(model.Song{}), mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	songRepository.AssertExpectations(t)
+	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
+}
+
 func TestAddPerfectSongRehearsals_WhenSuccessful_ShouldUpdateSongs(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	songProcessor := new(processor.SongProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
 	transactionManager := new(transaction.ManagerMock)
-	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, transactionManager)
+	_uut := song.NewAddPerfectSongRehearsals(songRepository, songProcessor, practiceSessionProcessor, transactionManager)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 	request := requests.AddPerfectSongRehearsalsRequest{
 		IDs: []uuid.UUID{
@@ -267,11 +339,12 @@ func TestAddPerfectSongRehearsals_WhenSuccessful_ShouldUpdateSongs(t *testing.T)
 
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 
 	for _, s := range mockSongs {
 		songProcessor.On("AddPerfectRehearsal", &s, transactionSongSectionRepository).
-			Return(nil, true).
+			Return(nil, &[]uuid.UUID{uuid.New()}[0]).
 			Once()
 	}
 
@@ -284,6 +357,12 @@ func TestAddPerfectSongRehearsals_WhenSuccessful_ShouldUpdateSongs(t *testing.T)
 		Return(nil).
 		Once()
 
+	for _, s := range mockSongs {
+		practiceSessionProcessor.On("RecordPerfectRehearsal", s, mock.IsType(uuid.UUID{}), transactionPracticeSessionRepository).
+			Return(nil).
+			Once()
+	}
+
 	// when
 	errCode := _uut.Handle(request)
 
@@ -292,8 +371,10 @@ func TestAddPerfectSongRehearsals_WhenSuccessful_ShouldUpdateSongs(t *testing.T)
 
 	songRepository.AssertExpectations(t)
 	songProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	repositoryFactory.AssertExpectations(t)
 	transactionSongSectionRepository.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
+	transactionPracticeSessionRepository.AssertExpectations(t)
 }
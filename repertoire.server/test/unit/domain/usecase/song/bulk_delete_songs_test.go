@@ -7,6 +7,7 @@ import (
 	"repertoire/server/domain/usecase/song"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -19,7 +20,7 @@ import (
 func TestBulkDeleteSongs_WhenGetSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil, nil)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -44,7 +45,7 @@ func TestBulkDeleteSongs_WhenGetSongsFails_ShouldReturnInternalServerError(t *te
 func TestBulkDeleteSongs_WhenSongsAreEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil, nil)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -68,7 +69,7 @@ func TestBulkDeleteSongs_WhenSongsAreEmpty_ShouldReturnNotFoundError(t *testing.
 func TestBulkDeleteSongs_WhenUpdateAllAlbumSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil, nil)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -109,7 +110,7 @@ func TestBulkDeleteSongs_WhenUpdateAllPlaylistsSongsFails_ShouldReturnInternalSe
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, playlistRepository, nil)
+	_uut := song.NewBulkDeleteSongs(songRepository, playlistRepository, nil, nil)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -152,7 +153,10 @@ func TestBulkDeleteSongs_WhenUpdateAllPlaylistsSongsFails_ShouldReturnInternalSe
 func TestBulkDeleteSongs_WhenDeleteSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -163,6 +167,9 @@ func TestBulkDeleteSongs_WhenDeleteSongsFails_ShouldReturnInternalServerError(t
 		Return(nil, mockSongs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Delete", request.IDs).Return(internalError).Once()
 
@@ -174,6 +181,8 @@ func TestBulkDeleteSongs_WhenDeleteSongsFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -181,7 +190,11 @@ func TestBulkDeleteSongs_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{uuid.New()},
@@ -192,10 +205,14 @@ func TestBulkDeleteSongs_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 		Return(nil, mockSongs).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", request.IDs).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, *mockSongs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, *mockSongs).
 		Return(internalError).
 		Once()
 
@@ -207,6 +224,8 @@ func TestBulkDeleteSongs_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -215,7 +234,11 @@ func TestBulkDeleteSongs_WhenWithoutAlbumsOrPlaylists_ShouldDeleteSongs(t *testi
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.BulkDeleteSongsRequest{
 		IDs: []uuid.UUID{
@@ -234,9 +257,13 @@ func TestBulkDeleteSongs_WhenWithoutAlbumsOrPlaylists_ShouldDeleteSongs(t *testi
 	songRepository.On("GetAllByIDsWithAlbumsAndPlaylists", mock.IsType(mockSongs), request.IDs).
 		Return(nil, mockSongs).
 		Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", request.IDs).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, *mockSongs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, *mockSongs).
 		Return(nil).
 		Once()
 
@@ -246,6 +273,8 @@ func TestBulkDeleteSongs_WhenWithoutAlbumsOrPlaylists_ShouldDeleteSongs(t *testi
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -254,7 +283,11 @@ func TestBulkDeleteSongs_WhenWithAlbums_ShouldDeleteSongsAndReorderAlbums(t *tes
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewBulkDeleteSongs(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	// given - mocking data
 	request := requests.BulkDeleteSongsRequest{
@@ -312,9 +345,13 @@ func TestBulkDeleteSongs_WhenWithAlbums_ShouldDeleteSongsAndReorderAlbums(t *tes
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", request.IDs).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, *mockSongs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, *mockSongs).
 		Return(nil).
 		Once()
 
@@ -324,6 +361,8 @@ func TestBulkDeleteSongs_WhenWithAlbums_ShouldDeleteSongsAndReorderAlbums(t *tes
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -333,7 +372,11 @@ func TestBulkDeleteSongs_WhenWithPlaylists_ShouldDeleteSongsAndReorderPlaylists(
 	songRepository := new(repository.SongRepositoryMock)
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewBulkDeleteSongs(songRepository, playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewBulkDeleteSongs(songRepository, playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	// given - mocking data
 	request := requests.BulkDeleteSongsRequest{
@@ -393,9 +436,13 @@ func TestBulkDeleteSongs_WhenWithPlaylists_ShouldDeleteSongsAndReorderPlaylists(
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", request.IDs).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, *mockSongs).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, *mockSongs).
 		Return(nil).
 		Once()
 
@@ -405,6 +452,8 @@ func TestBulkDeleteSongs_WhenWithPlaylists_ShouldDeleteSongsAndReorderPlaylists(
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
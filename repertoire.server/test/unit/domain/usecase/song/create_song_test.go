@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -47,7 +48,7 @@ func TestCreateSong_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerError(t
 	// given
 	albumRepository := new(repository.AlbumRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := song.NewCreateSong(jwtService, nil, albumRepository, nil)
+	_uut := song.NewCreateSong(jwtService, albumRepository, nil, nil)
 
 	request := requests.CreateSongRequest{
 		Title:   "Some Song",
@@ -78,10 +79,9 @@ func TestCreateSong_WhenGetAlbumWithSongsFails_ShouldReturnInternalServerError(t
 
 func TestCreateSong_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
-	songRepository := new(repository.SongRepositoryMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := song.NewCreateSong(jwtService, songRepository, albumRepository, nil)
+	_uut := song.NewCreateSong(jwtService, albumRepository, nil, nil)
 
 	request := requests.CreateSongRequest{
 		Title:   "Some Song",
@@ -107,14 +107,16 @@ func TestCreateSong_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 
 	jwtService.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
-	songRepository.AssertExpectations(t)
 }
 
 func TestCreateSong_WhenCreateSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
-	songRepository := new(repository.SongRepositoryMock)
 	jwtService := new(service.JwtServiceMock)
-	_uut := song.NewCreateSong(jwtService, songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewCreateSong(jwtService, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	songRepository := new(repository.SongRepositoryMock)
 
 	request := requests.CreateSongRequest{
 		Title: "Some Song",
@@ -123,6 +125,10 @@ func TestCreateSong_WhenCreateSongFails_ShouldReturnInternalServerError(t *testi
 	userID := uuid.New()
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Create", mock.IsType(new(model.Song))).
 		Return(internalError).
@@ -138,15 +144,21 @@ func TestCreateSong_WhenCreateSongFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, internalError, errCode.Error)
 
 	jwtService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
 func TestCreateSong_WhenCreateSongFails_ShouldReturnBadRequestError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewCreateSong(jwtService, songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewCreateSong(jwtService, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.CreateSongRequest{
 		Title: "Some Song",
@@ -156,12 +168,21 @@ func TestCreateSong_WhenCreateSongFails_ShouldReturnBadRequestError(t *testing.T
 
 	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Create", mock.IsType(new(model.Song))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongCreatedTopic, mock.IsType(model.Song{})).
+	messagePublisherService.On(
+		"PublishWithinTransaction",
+		outboxRepository,
+		topics.SongCreatedTopic,
+		mock.IsType(model.Song{}),
+	).
 		Return(internalError).
 		Once()
 
@@ -175,8 +196,10 @@ func TestCreateSong_WhenCreateSongFails_ShouldReturnBadRequestError(t *testing.T
 	assert.Equal(t, internalError, errCode.Error)
 
 	jwtService.AssertExpectations(t)
-	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
 }
 
 func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
@@ -199,6 +222,7 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			requests.CreateSongRequest{
 				Title:          "Some Song",
 				Bpm:            &[]uint{120}[0],
+				Duration:       &[]uint{200}[0],
 				SongsterrLink:  &[]string{"https://songsterr.com/some-song"}[0],
 				GuitarTuningID: &[]uuid.UUID{uuid.New()}[0],
 			},
@@ -260,7 +284,13 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 				Title: "Some Song",
 				Sections: []requests.CreateSectionRequest{
 					{Name: "First Section", TypeID: uuid.New()},
-					{Name: "Second Section", TypeID: uuid.New()},
+					{
+						Name:          "Second Section",
+						TypeID:        uuid.New(),
+						StartOffset:   &[]uint{20}[0],
+						BarCount:      &[]uint{8}[0],
+						TimeSignature: &[]string{"4/4"}[0],
+					},
 				},
 			},
 			nil,
@@ -271,16 +301,20 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			jwtService := new(service.JwtServiceMock)
-			songRepository := new(repository.SongRepositoryMock)
 			albumRepository := new(repository.AlbumRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
 			_uut := song.NewCreateSong(
 				jwtService,
-				songRepository,
 				albumRepository,
 				messagePublisherService,
+				transactionManager,
 			)
 
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			songRepository := new(repository.SongRepositoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
+
 			token := "this is a token"
 
 			// given - mocks
@@ -293,6 +327,10 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 					Once()
 			}
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			var createdSong model.Song
 			songRepository.On("Create", mock.IsType(new(model.Song))).
 				Run(func(args mock.Arguments) {
@@ -313,9 +351,14 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 					Once()
 			}
 
-			messagePublisherService.On("Publish", topics.SongCreatedTopic, mock.IsType(createdSong)).
+			messagePublisherService.On(
+				"PublishWithinTransaction",
+				outboxRepository,
+				topics.SongCreatedTopic,
+				mock.IsType(createdSong),
+			).
 				Run(func(args mock.Arguments) {
-					assert.Equal(t, createdSong, args.Get(1).(model.Song))
+					assert.Equal(t, createdSong, args.Get(2).(model.Song))
 				}).
 				Return(nil).
 				Once()
@@ -328,6 +371,9 @@ func TestCreateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			assert.Equal(t, id, createdSong.ID)
 
 			jwtService.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 		})
 	}
@@ -344,6 +390,7 @@ func assertCreatedSong(
 	assert.Equal(t, request.Description, song.Description)
 	assert.False(t, song.IsRecorded)
 	assert.Equal(t, request.Bpm, song.Bpm)
+	assert.Equal(t, request.Duration, song.Duration)
 	assert.Equal(t, request.SongsterrLink, song.SongsterrLink)
 	assert.Equal(t, request.YoutubeLink, song.YoutubeLink)
 	assert.Equal(t, request.Difficulty, song.Difficulty)
@@ -369,6 +416,9 @@ func assertCreatedSong(
 		assert.Zero(t, song.Sections[i].Progress)
 		assert.Equal(t, uint(i), song.Sections[i].Order)
 		assert.Equal(t, section.TypeID, song.Sections[i].SongSectionTypeID)
+		assert.Equal(t, section.StartOffset, song.Sections[i].StartOffset)
+		assert.Equal(t, section.BarCount, song.Sections[i].BarCount)
+		assert.Equal(t, section.TimeSignature, song.Sections[i].TimeSignature)
 		assert.Equal(t, song.ID, song.Sections[i].SongID)
 	}
 	if request.AlbumTitle != nil {
@@ -8,6 +8,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
@@ -20,7 +21,7 @@ import (
 func TestDeleteImageFromSong_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil)
+	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -42,7 +43,7 @@ func TestDeleteImageFromSong_WhenGetSongFails_ShouldReturnInternalServerError(t
 func TestDeleteImageFromSong_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil)
+	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -63,7 +64,7 @@ func TestDeleteImageFromSong_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testin
 func TestDeleteImageFromSong_WhenSongHasNoImage_ShouldReturnConflictError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil)
+	_uut := song.NewDeleteImageFromSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -86,7 +87,7 @@ func TestDeleteImageFromSong_WhenDeleteImageFails_ShouldReturnInternalServerErro
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, storageService, nil)
+	_uut := song.NewDeleteImageFromSong(songRepository, storageService, nil, nil)
 
 	id := uuid.New()
 
@@ -112,7 +113,10 @@ func TestDeleteImageFromSong_WhenUpdateSongFails_ShouldReturnInternalServerError
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	storageService := new(service.StorageServiceMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, storageService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteImageFromSong(songRepository, storageService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -122,6 +126,9 @@ func TestDeleteImageFromSong_WhenUpdateSongFails_ShouldReturnInternalServerError
 
 	storageService.On("DeleteFile", *mockSong.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Update", mock.IsType(mockSong)).
 		Return(internalError).
@@ -135,6 +142,8 @@ func TestDeleteImageFromSong_WhenUpdateSongFails_ShouldReturnInternalServerError
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 }
@@ -144,7 +153,11 @@ func TestDeleteImageFromSong_WhenPublishFails_ShouldReturnInternalServerError(t
 	songRepository := new(repository.SongRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteImageFromSong(songRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -154,12 +167,16 @@ func TestDeleteImageFromSong_WhenPublishFails_ShouldReturnInternalServerError(t
 
 	storageService.On("DeleteFile", *mockSong.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(mockSong)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
 		Return(internalError).
 		Once()
 
@@ -171,6 +188,8 @@ func TestDeleteImageFromSong_WhenPublishFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -181,7 +200,11 @@ func TestDeleteImageFromSong_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 	songRepository := new(repository.SongRepositoryMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteImageFromSong(songRepository, storageService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteImageFromSong(songRepository, storageService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -191,6 +214,10 @@ func TestDeleteImageFromSong_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 
 	storageService.On("DeleteFile", *mockSong.ImageURL).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(mockSong)).
 		Run(func(args mock.Arguments) {
 			newSong := args.Get(0).(*model.Song)
@@ -199,7 +226,7 @@ func TestDeleteImageFromSong_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
 		Return(nil).
 		Once()
 
@@ -209,6 +236,8 @@ func TestDeleteImageFromSong_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageService.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -6,6 +6,7 @@ import (
 	"repertoire/server/domain/usecase/song"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -19,7 +20,7 @@ import (
 func TestDeleteSong_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, nil, nil)
+	_uut := song.NewDeleteSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -42,7 +43,7 @@ func TestDeleteSong_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.
 func TestDeleteSong_WhenGetSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, nil, nil)
+	_uut := song.NewDeleteSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -64,7 +65,7 @@ func TestDeleteSong_WhenGetSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 func TestDeleteSong_WhenGetAllByAlbumAndTrackNoFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, nil, nil)
+	_uut := song.NewDeleteSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -102,7 +103,7 @@ func TestDeleteSong_WhenGetAllByAlbumAndTrackNoFails_ShouldReturnInternalServerE
 func TestDeleteSong_WhenUpdateAllAlbumSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, nil, nil)
+	_uut := song.NewDeleteSong(songRepository, nil, nil, nil)
 
 	id := uuid.New()
 
@@ -146,7 +147,7 @@ func TestDeleteSong_WhenUpdateAllPlaylistsSongsFails_ShouldReturnInternalServerE
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	playlistRepository := new(repository.PlaylistRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, playlistRepository, nil)
+	_uut := song.NewDeleteSong(songRepository, playlistRepository, nil, nil)
 
 	id := uuid.New()
 
@@ -185,7 +186,10 @@ func TestDeleteSong_WhenUpdateAllPlaylistsSongsFails_ShouldReturnInternalServerE
 func TestDeleteSong_WhenDeleteSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewDeleteSong(songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteSong(songRepository, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 
@@ -194,6 +198,9 @@ func TestDeleteSong_WhenDeleteSongFails_ShouldReturnInternalServerError(t *testi
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Delete", []uuid.UUID{id}).Return(internalError).Once()
 
@@ -205,6 +212,8 @@ func TestDeleteSong_WhenDeleteSongFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -212,7 +221,11 @@ func TestDeleteSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -221,10 +234,14 @@ func TestDeleteSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, []model.Song{*mockSong}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, []model.Song{*mockSong}).
 		Return(internalError).
 		Once()
 
@@ -236,6 +253,8 @@ func TestDeleteSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -244,7 +263,11 @@ func TestDeleteSong_WhenWithoutAlbumOrPlaylists_ShouldDeleteSong(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	mockSong := model.Song{ID: uuid.New()}
 
@@ -253,9 +276,13 @@ func TestDeleteSong_WhenWithoutAlbumOrPlaylists_ShouldDeleteSong(t *testing.T) {
 	songRepository.On("GetWithPlaylistsAndSongs", mock.IsType(&mockSong), id).
 		Return(nil, &mockSong).
 		Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, []model.Song{mockSong}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, []model.Song{mockSong}).
 		Return(nil).
 		Once()
 
@@ -265,6 +292,8 @@ func TestDeleteSong_WhenWithoutAlbumOrPlaylists_ShouldDeleteSong(t *testing.T) {
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -273,7 +302,11 @@ func TestDeleteSong_WhenWithAlbum_ShouldDeleteSongAndReorderAlbum(t *testing.T)
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteSong(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	mockSong := model.Song{
 		ID:           uuid.New(),
@@ -313,9 +346,13 @@ func TestDeleteSong_WhenWithAlbum_ShouldDeleteSongAndReorderAlbum(t *testing.T)
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, []model.Song{mockSong}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, []model.Song{mockSong}).
 		Return(nil).
 		Once()
 
@@ -325,6 +362,8 @@ func TestDeleteSong_WhenWithAlbum_ShouldDeleteSongAndReorderAlbum(t *testing.T)
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -334,7 +373,11 @@ func TestDeleteSong_WhenWithPlaylists_ShouldDeleteSongAndReorderPlaylists(t *tes
 	songRepository := new(repository.SongRepositoryMock)
 	playlistRepository := new(repository.PlaylistRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewDeleteSong(songRepository, playlistRepository, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewDeleteSong(songRepository, playlistRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 
@@ -412,9 +455,13 @@ func TestDeleteSong_WhenWithPlaylists_ShouldDeleteSongAndReorderPlaylists(t *tes
 		Return(nil).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
-	messagePublisherService.On("Publish", topics.SongsDeletedTopic, []model.Song{mockSong}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsDeletedTopic, []model.Song{mockSong}).
 		Return(nil).
 		Once()
 
@@ -424,6 +471,8 @@ func TestDeleteSong_WhenWithPlaylists_ShouldDeleteSongAndReorderPlaylists(t *tes
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	playlistRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -0,0 +1,135 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/song"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetSongsPracticeQueue_WhenGetUserIdFromJwtFails_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := song.NewGetSongsPracticeQueue(jwtService, nil, nil)
+
+	request := requests.GetSongsPracticeQueueRequest{}
+	token := "This is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	queue, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, queue)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetSongsPracticeQueue_WhenGetSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewGetSongsPracticeQueue(jwtService, songRepository, nil)
+
+	request := requests.GetSongsPracticeQueueRequest{}
+	token := "This is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllByUserWithSections", new([]model.Song), userID).
+		Return(internalError).
+		Once()
+
+	// when
+	queue, errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, queue)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestGetSongsPracticeQueue_WhenSuccessful_ShouldReturnQueue(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        requests.GetSongsPracticeQueueRequest
+		expectedBudget *time.Duration
+	}{
+		{
+			"Without budget",
+			requests.GetSongsPracticeQueueRequest{},
+			nil,
+		},
+		{
+			"With budget",
+			requests.GetSongsPracticeQueueRequest{Budget: &[]uint{45}[0]},
+			&[]time.Duration{45 * time.Minute}[0],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			jwtService := new(service.JwtServiceMock)
+			songRepository := new(repository.SongRepositoryMock)
+			practiceQueueProcessor := new(processor.PracticeQueueProcessorMock)
+			_uut := song.NewGetSongsPracticeQueue(jwtService, songRepository, practiceQueueProcessor)
+
+			token := "This is a token"
+
+			userID := uuid.New()
+			jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+			songs := &[]model.Song{
+				{ID: uuid.New(), Title: "Song 1"},
+				{ID: uuid.New(), Title: "Song 2"},
+			}
+			songRepository.On("GetAllByUserWithSections", new([]model.Song), userID).
+				Return(nil, songs).
+				Once()
+
+			expectedQueue := []model.PracticeQueueSong{
+				{Song: (*songs)[1], Priority: 2},
+			}
+			practiceQueueProcessor.On("BuildQueue", *songs, mock.IsType(tt.expectedBudget)).
+				Run(func(args mock.Arguments) {
+					budget := args.Get(1).(*time.Duration)
+					assert.Equal(t, tt.expectedBudget, budget)
+				}).
+				Return(expectedQueue).
+				Once()
+
+			// when
+			queue, errCode := _uut.Handle(tt.request, token)
+
+			// then
+			assert.Nil(t, errCode)
+			assert.Equal(t, expectedQueue, queue)
+
+			jwtService.AssertExpectations(t)
+			songRepository.AssertExpectations(t)
+			practiceQueueProcessor.AssertExpectations(t)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/provider"
@@ -23,7 +24,7 @@ import (
 func TestSaveImageToSong_WhenGetSongFails_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewSaveImageToSong(songRepository, nil, nil, nil)
+	_uut := song.NewSaveImageToSong(songRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -46,7 +47,7 @@ func TestSaveImageToSong_WhenGetSongFails_ShouldReturnNotFoundError(t *testing.T
 func TestSaveImageToSong_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewSaveImageToSong(songRepository, nil, nil, nil)
+	_uut := song.NewSaveImageToSong(songRepository, nil, nil, nil, nil)
 
 	file := new(multipart.FileHeader)
 	id := uuid.New()
@@ -74,6 +75,7 @@ func TestSaveImageToSong_WhenStorageDeleteFileFails_ShouldReturnError(t *testing
 		nil,
 		storageService,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -97,7 +99,7 @@ func TestSaveImageToSong_WhenStorageDeleteFileFails_ShouldReturnError(t *testing
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveImageToSong_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveImageToSong_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
@@ -107,6 +109,7 @@ func TestSaveImageToSong_WhenStorageUploadFails_ShouldReturnInternalServerError(
 		storageFilePathProvider,
 		storageService,
 		nil,
+		nil,
 	)
 
 	file := new(multipart.FileHeader)
@@ -117,11 +120,11 @@ func TestSaveImageToSong_WhenStorageUploadFails_ShouldReturnInternalServerError(
 	songRepository.On("Get", new(model.Song), id).Return(nil, mockSong).Once()
 
 	imagePath := "songs file path"
-	storageFilePathProvider.On("GetSongImagePath", file, mock.IsType(*mockSong)).
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(*mockSong)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -129,8 +132,7 @@ func TestSaveImageToSong_WhenStorageUploadFails_ShouldReturnInternalServerError(
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	songRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
@@ -142,13 +144,17 @@ func TestSaveImageToSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *
 	songRepository := new(repository.SongRepositoryMock)
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := song.NewSaveImageToSong(
 		songRepository,
 		storageFilePathProvider,
 		storageService,
 		nil,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -157,12 +163,15 @@ func TestSaveImageToSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *
 	songRepository.On("Get", new(model.Song), id).Return(nil, mockSong).Once()
 
 	imagePath := "songs file path"
-	storageFilePathProvider.On("GetSongImagePath", file, mock.IsType(*mockSong)).
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(*mockSong)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Update", mock.IsType(new(model.Song))).
 		Return(internalError).
@@ -176,6 +185,8 @@ func TestSaveImageToSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -187,13 +198,18 @@ func TestSaveImageToSong_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := song.NewSaveImageToSong(
 		songRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -202,18 +218,22 @@ func TestSaveImageToSong_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	songRepository.On("Get", new(model.Song), id).Return(nil, mockSong).Once()
 
 	imagePath := "songs file path"
-	storageFilePathProvider.On("GetSongImagePath", file, mock.IsType(*mockSong)).
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(*mockSong)).
 		Return(imagePath).
 		Once()
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(new(model.Song))).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
 		Return(internalError).
 		Once()
 
@@ -225,6 +245,8 @@ func TestSaveImageToSong_WhenPublishFails_ShouldReturnInternalServerError(t *tes
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -237,13 +259,18 @@ func TestSaveImageToSong_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErro
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := song.NewSaveImageToSong(
 		songRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -252,9 +279,9 @@ func TestSaveImageToSong_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErro
 	songRepository.On("Get", new(model.Song), id).Return(nil, mockSong).Once()
 
 	imagePath := "songs file path"
-	storageFilePathProvider.On("GetSongImagePath", file, mock.IsType(*mockSong)).
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(*mockSong)).
 		Run(func(args mock.Arguments) {
-			newSong := args.Get(1).(model.Song)
+			newSong := args.Get(0).(model.Song)
 			assert.Equal(t, newSong.ID, mockSong.ID)
 			assert.WithinDuration(t, time.Now(), newSong.UpdatedAt, time.Minute)
 		}).
@@ -263,6 +290,10 @@ func TestSaveImageToSong_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErro
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(new(model.Song))).
 		Run(func(args mock.Arguments) {
 			newSong := args.Get(0).(*model.Song)
@@ -271,7 +302,7 @@ func TestSaveImageToSong_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErro
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
 		Return(nil).
 		Once()
 
@@ -281,6 +312,8 @@ func TestSaveImageToSong_WhenWithoutOldImage_ShouldSaveNewOneAndNotReturnAnyErro
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -293,13 +326,18 @@ func TestSaveImageToSong_WhenWithOldImage_ShouldDeleteOldImageSaveNewAndOneNotRe
 	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
 	storageService := new(service.StorageServiceMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
 	_uut := song.NewSaveImageToSong(
 		songRepository,
 		storageFilePathProvider,
 		storageService,
 		messagePublisherService,
+		transactionManager,
 	)
 
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
 	file := new(multipart.FileHeader)
 	id := uuid.New()
 
@@ -310,9 +348,9 @@ func TestSaveImageToSong_WhenWithOldImage_ShouldDeleteOldImageSaveNewAndOneNotRe
 	storageService.On("DeleteFile", *mockSong.ImageURL).Return(nil).Once()
 
 	imagePath := "songs file path"
-	storageFilePathProvider.On("GetSongImagePath", file, mock.IsType(*mockSong)).
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(*mockSong)).
 		Run(func(args mock.Arguments) {
-			newSong := args.Get(1).(model.Song)
+			newSong := args.Get(0).(model.Song)
 			assert.Equal(t, newSong.ID, mockSong.ID)
 			assert.WithinDuration(t, time.Now(), newSong.UpdatedAt, time.Minute)
 		}).
@@ -321,6 +359,10 @@ func TestSaveImageToSong_WhenWithOldImage_ShouldDeleteOldImageSaveNewAndOneNotRe
 
 	storageService.On("Upload", file, imagePath).Return(nil).Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(new(model.Song))).
 		Run(func(args mock.Arguments) {
 			newSong := args.Get(0).(*model.Song)
@@ -329,7 +371,7 @@ func TestSaveImageToSong_WhenWithOldImage_ShouldDeleteOldImageSaveNewAndOneNotRe
 		Return(nil).
 		Once()
 
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSong.ID}).
 		Return(nil).
 		Once()
 
@@ -339,6 +381,8 @@ func TestSaveImageToSong_WhenWithOldImage_ShouldDeleteOldImageSaveNewAndOneNotRe
 	// then
 	assert.Nil(t, errCode)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	storageFilePathProvider.AssertExpectations(t)
 	storageService.AssertExpectations(t)
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"slices"
 	"testing"
 
@@ -19,7 +22,7 @@ import (
 func TestBulkDeleteSongSections_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(nil, songRepository)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, nil)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		SongID: uuid.New(),
@@ -44,7 +47,7 @@ func TestBulkDeleteSongSections_WhenGetSongFails_ShouldReturnInternalServerError
 func TestBulkDeleteSongSections_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(nil, songRepository)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, nil)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		SongID: uuid.New(),
@@ -68,7 +71,7 @@ func TestBulkDeleteSongSections_WhenSongIsNotFound_ShouldReturnNotFoundError(t *
 func TestBulkDeleteSongSections_WhenSectionsAreNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(nil, songRepository)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, nil)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		IDs:    []uuid.UUID{uuid.New()},
@@ -99,7 +102,7 @@ func TestBulkDeleteSongSections_WhenSectionsAreNotFound_ShouldReturnNotFoundErro
 func TestBulkDeleteSongSections_WhenNotAllSectionsAreFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(nil, songRepository)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, nil)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		IDs:    []uuid.UUID{uuid.New(), uuid.New()},
@@ -131,7 +134,10 @@ func TestBulkDeleteSongSections_WhenNotAllSectionsAreFound_ShouldReturnNotFoundE
 func TestBulkDeleteSongSections_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(nil, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		IDs:    []uuid.UUID{uuid.New()},
@@ -149,6 +155,9 @@ func TestBulkDeleteSongSections_WhenUpdateSongFails_ShouldReturnInternalServerEr
 		Return(nil, song).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
 		Return(internalError).
@@ -162,6 +171,8 @@ func TestBulkDeleteSongSections_WhenUpdateSongFails_ShouldReturnInternalServerEr
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -169,7 +180,10 @@ func TestBulkDeleteSongSections_WhenDeleteSectionsFails_ShouldReturnInternalServ
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkDeleteSongSections(songSectionRepository, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewBulkDeleteSongSections(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.BulkDeleteSongSectionsRequest{
 		IDs:    []uuid.UUID{uuid.New()},
@@ -187,6 +201,10 @@ func TestBulkDeleteSongSections_WhenDeleteSectionsFails_ShouldReturnInternalServ
 		Return(nil, song).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
 		Return(nil).
 		Once()
@@ -202,8 +220,68 @@ func TestBulkDeleteSongSections_WhenDeleteSectionsFails_ShouldReturnInternalServ
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestBulkDeleteSongSections_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewBulkDeleteSongSections(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.BulkDeleteSongSectionsRequest{
+		IDs:    []uuid.UUID{uuid.New()},
+		SongID: uuid.New(),
+	}
+
+	// given - mocking
+	song := &model.Song{
+		ID: request.SongID,
+		Sections: []model.SongSection{
+			{ID: request.IDs[0], Order: 0},
+		},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, song).
+		Once()
+
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
+		Return(nil).
+		Once()
+
+	songSectionRepository.On("Delete", request.IDs).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{request.SongID}).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestBulkDeleteSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
@@ -290,7 +368,12 @@ func TestBulkDeleteSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewBulkDeleteSongSections(songSectionRepository, songRepository)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewBulkDeleteSongSections(songRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.BulkDeleteSongSectionsRequest{
 				SongID: tt.song.ID,
@@ -305,6 +388,11 @@ func TestBulkDeleteSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 				Return(nil, &tt.song).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			songRepository.On("UpdateWithAssociations", mock.IsType(&tt.song)).
 				Run(func(args mock.Arguments) {
 					newSong := args.Get(0).(*model.Song)
@@ -331,14 +419,21 @@ func TestBulkDeleteSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *testin
 
 			songSectionRepository.On("Delete", request.IDs).Return(nil).Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{request.SongID}).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(request)
 
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 		})
 	}
 }
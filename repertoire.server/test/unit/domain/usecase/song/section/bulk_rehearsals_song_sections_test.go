@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
 	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
@@ -22,7 +24,7 @@ import (
 func TestBulkRehearsalsSongSections_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil)
+	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil, nil, nil)
 
 	request := requests.BulkRehearsalsSongSectionsRequest{
 		SongID: uuid.New(),
@@ -47,7 +49,7 @@ func TestBulkRehearsalsSongSections_WhenGetSongFails_ShouldReturnInternalServerE
 func TestBulkRehearsalsSongSections_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil)
+	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil, nil, nil)
 
 	request := requests.BulkRehearsalsSongSectionsRequest{
 		SongID: uuid.New(),
@@ -71,7 +73,7 @@ func TestBulkRehearsalsSongSections_WhenSongIsNotFound_ShouldReturnNotFoundError
 func TestBulkRehearsalsSongSections_WhenSectionsAreNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil)
+	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil, nil, nil)
 
 	request := requests.BulkRehearsalsSongSectionsRequest{
 		Sections: []requests.BulkRehearsalsSongSectionRequest{{ID: uuid.New(), Rehearsals: 12}},
@@ -102,7 +104,7 @@ func TestBulkRehearsalsSongSections_WhenSectionsAreNotFound_ShouldReturnNotFound
 func TestBulkRehearsalsSongSections_WhenNotAllSectionsAreFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil)
+	_uut := section.NewBulkRehearsalsSongSections(songRepository, nil, nil, nil, nil)
 
 	request := requests.BulkRehearsalsSongSectionsRequest{
 		Sections: []requests.BulkRehearsalsSongSectionRequest{
@@ -134,12 +136,58 @@ func TestBulkRehearsalsSongSections_WhenNotAllSectionsAreFound_ShouldReturnNotFo
 	songRepository.AssertExpectations(t)
 }
 
+func TestBulkRehearsalsSongSections_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := section.NewBulkRehearsalsSongSections(songRepository, userRepository, nil, nil, nil)
+
+	request := requests.BulkRehearsalsSongSectionsRequest{
+		Sections: []requests.BulkRehearsalsSongSectionRequest{{ID: uuid.New(), Rehearsals: 12}},
+		SongID:   uuid.New(),
+	}
+
+	song := &model.Song{
+		ID:     request.SongID,
+		UserID: uuid.New(),
+		Sections: []model.SongSection{
+			{ID: request.Sections[0].ID, Order: 0},
+		},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, song).
+		Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
 func TestBulkRehearsalsSongSections_WhenTransactionExecuteFails_ShouldReturnError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
 	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
@@ -161,6 +209,9 @@ func TestBulkRehearsalsSongSections_WhenTransactionExecuteFails_ShouldReturnErro
 	songRepository.On("GetWithSections", new(model.Song), request.SongID).
 		Return(nil, song).
 		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
 
 	internalError := errors.New("internal error")
 	transactionManager.On("Execute", mock.Anything).Return(internalError).Once()
@@ -174,18 +225,26 @@ func TestBulkRehearsalsSongSections_WhenTransactionExecuteFails_ShouldReturnErro
 	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 
 	repositoryFactory.AssertExpectations(t)
 }
 
-func TestBulkRehearsalsSongSections_WhenCreateHistoryFails_ShouldReturnInternalError(t *testing.T) {
+func TestBulkRehearsalsSongSections_WhenCreateRehearsalBatchFails_ShouldReturnInternalError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
 	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -209,15 +268,18 @@ func TestBulkRehearsalsSongSections_WhenCreateHistoryFails_ShouldReturnInternalE
 	songRepository.On("GetWithSections", new(model.Song), request.SongID).
 		Return(nil, song).
 		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
 
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
 
 	internalError := errors.New("internal error")
-	transactionSongSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(internalError).
-		Times(len(request.Sections))
+		Once()
 
 	// when
 	errCode := _uut.Handle(request)
@@ -228,6 +290,78 @@ func TestBulkRehearsalsSongSections_WhenCreateHistoryFails_ShouldReturnInternalE
 	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+
+	repositoryFactory.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+}
+
+func TestBulkRehearsalsSongSections_WhenCreateHistoriesFails_ShouldReturnInternalError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.BulkRehearsalsSongSectionsRequest{
+		Sections: []requests.BulkRehearsalsSongSectionRequest{
+			{ID: uuid.New(), Rehearsals: 1},
+		},
+		SongID: uuid.New(),
+	}
+
+	song := &model.Song{
+		ID: request.SongID,
+		Sections: []model.SongSection{
+			{ID: request.Sections[0].ID, Order: 0},
+		},
+	}
+
+	// given - mocking
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, song).
+		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, errCode.Error, internalError)
+	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 
@@ -239,9 +373,16 @@ func TestBulkRehearsalsSongSections_WhenCreateHistoryFails_ShouldReturnInternalE
 func TestBulkRehearsalsSongSections_WhenGetHistoryFails_ShouldReturnInternalError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
 	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -265,14 +406,21 @@ func TestBulkRehearsalsSongSections_WhenGetHistoryFails_ShouldReturnInternalErro
 	songRepository.On("GetWithSections", new(model.Song), request.SongID).
 		Return(nil, song).
 		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
 
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
 
-	transactionSongSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(nil).
-		Times(len(request.Sections))
+		Once()
+
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
 
 	internalError := errors.New("internal error")
 	transactionSongSectionRepository.
@@ -294,6 +442,7 @@ func TestBulkRehearsalsSongSections_WhenGetHistoryFails_ShouldReturnInternalErro
 	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 
@@ -305,9 +454,16 @@ func TestBulkRehearsalsSongSections_WhenGetHistoryFails_ShouldReturnInternalErro
 func TestBulkRehearsalsSongSections_WhenUpdateFails_ShouldReturnInternalError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
 	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -331,14 +487,21 @@ func TestBulkRehearsalsSongSections_WhenUpdateFails_ShouldReturnInternalError(t
 	songRepository.On("GetWithSections", new(model.Song), request.SongID).
 		Return(nil, song).
 		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
 
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
 
-	transactionSongSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
 		Return(nil).
-		Times(len(request.Sections))
+		Once()
+
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
 
 	transactionSongSectionRepository.
 		On(
@@ -350,11 +513,11 @@ func TestBulkRehearsalsSongSections_WhenUpdateFails_ShouldReturnInternalError(t
 		Return(nil).
 		Times(len(request.Sections))
 
-	progressProcessor.On("ComputeRehearsalsScore", mock.IsType([]model.SongSectionHistory{})).
+	progressProcessor.On("ComputeRehearsalsScore", mock.IsType([]model.SongSectionHistory{}), enums.ClassicScoring).
 		Return(uint64(0)).
 		Times(len(request.Sections))
 
-	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{})).
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ClassicScoring).
 		Return(uint64(0)).
 		Times(len(request.Sections))
 
@@ -372,8 +535,117 @@ func TestBulkRehearsalsSongSections_WhenUpdateFails_ShouldReturnInternalError(t
 	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+
+	repositoryFactory.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+}
+
+func TestBulkRehearsalsSongSections_WhenRecordPracticeSessionFails_ShouldReturnInternalError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		practiceSessionProcessor,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+	transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
+
+	request := requests.BulkRehearsalsSongSectionsRequest{
+		Sections: []requests.BulkRehearsalsSongSectionRequest{
+			{ID: uuid.New(), Rehearsals: 1},
+		},
+		SongID: uuid.New(),
+	}
+
+	song := &model.Song{
+		ID: request.SongID,
+		Sections: []model.SongSection{
+			{ID: request.Sections[0].ID, Order: 0},
+		},
+	}
+
+	// given - mocking
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, song).
+		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+		Return(nil).
+		Once()
+
+	transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+		Return(nil).
+		Once()
+
+	transactionSongSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			mock.IsType(uuid.UUID{}),
+			model.RehearsalsProperty,
+		).
+		Return(nil).
+		Times(len(request.Sections))
+
+	progressProcessor.On("ComputeRehearsalsScore", mock.IsType([]model.SongSectionHistory{}), enums.ClassicScoring).
+		Return(uint64(0)).
+		Times(len(request.Sections))
+
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ClassicScoring).
+		Return(uint64(0)).
+		Times(len(request.Sections))
+
+	transactionSongRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+		Return(nil).
+		Once()
+
+	repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+	internalError := errors.New("internal error")
+	practiceSessionProcessor.
+		On(
+			"RecordRehearsal",
+			mock.IsType(model.Song{}),
+			mock.IsType(uuid.UUID{}),
+			mock.IsType([]model.PracticeSessionSection{}),
+			transactionPracticeSessionRepository,
+		).
+		Return(wrapper.InternalServerError(internalError)).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, errCode.Error, internalError)
+	assert.Equal(t, errCode.Code, http.StatusInternalServerError)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
+	practiceSessionProcessor.AssertExpectations(t)
 
 	repositoryFactory.AssertExpectations(t)
 	transactionSongRepository.AssertExpectations(t)
@@ -383,9 +655,16 @@ func TestBulkRehearsalsSongSections_WhenUpdateFails_ShouldReturnInternalError(t
 func TestBulkRehearsalsSongSections_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
 	transactionManager := new(transaction.ManagerMock)
 	progressProcessor := new(processor.ProgressProcessorMock)
-	_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+	_uut := section.NewBulkRehearsalsSongSections(
+		songRepository,
+		userRepository,
+		transactionManager,
+		progressProcessor,
+		nil,
+	)
 
 	repositoryFactory := new(transaction.RepositoryFactoryMock)
 	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
@@ -409,6 +688,9 @@ func TestBulkRehearsalsSongSections_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *
 	songRepository.On("GetWithSections", new(model.Song), request.SongID).
 		Return(nil, song).
 		Once()
+	userRepository.On("Get", new(model.User), song.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
 
 	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
@@ -421,6 +703,7 @@ func TestBulkRehearsalsSongSections_WhenSongIsNotUpdated_ShouldNotUpdateSong(t *
 	assert.Nil(t, errCode)
 
 	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
 	transactionManager.AssertExpectations(t)
 	progressProcessor.AssertExpectations(t)
 
@@ -539,13 +822,22 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			songRepository := new(repository.SongRepositoryMock)
+			userRepository := new(repository.UserRepositoryMock)
 			transactionManager := new(transaction.ManagerMock)
 			progressProcessor := new(processor.ProgressProcessorMock)
-			_uut := section.NewBulkRehearsalsSongSections(songRepository, transactionManager, progressProcessor)
+			practiceSessionProcessor := new(processor.PracticeSessionProcessorMock)
+			_uut := section.NewBulkRehearsalsSongSections(
+				songRepository,
+				userRepository,
+				transactionManager,
+				progressProcessor,
+				practiceSessionProcessor,
+			)
 
 			repositoryFactory := new(transaction.RepositoryFactoryMock)
 			transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
 			transactionSongRepository := new(repository.SongRepositoryMock)
+			transactionPracticeSessionRepository := new(repository.PracticeSessionRepositoryMock)
 
 			var requestSections []requests.BulkRehearsalsSongSectionRequest
 			for _, s := range tt.sectionsTestData {
@@ -563,11 +855,48 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 			songRepository.On("GetWithSections", new(model.Song), request.SongID).
 				Return(nil, &tt.song).
 				Once()
+			userRepository.On("Get", new(model.User), tt.song.UserID).
+				Return(nil, &model.User{ScoringStrategy: enums.ExponentialScoring}).
+				Once()
 
 			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
 			repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
 			repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
 
+			var batchID uuid.UUID
+			transactionSongSectionRepository.On("CreateRehearsalBatch", mock.IsType(new(model.RehearsalBatch))).
+				Run(func(args mock.Arguments) {
+					newBatch := args.Get(0).(*model.RehearsalBatch)
+					assert.NotEmpty(t, newBatch.ID)
+					assert.Equal(t, tt.song.ID, newBatch.SongID)
+					assert.Equal(t, tt.song.LastTimePlayed, newBatch.PreviousLastTimePlayed)
+					batchID = newBatch.ID
+				}).
+				Return(nil).
+				Once()
+
+			transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
+				Run(func(args mock.Arguments) {
+					newHistory := args.Get(0).(*[]model.SongSectionHistory)
+					for _, h := range *newHistory {
+						assert.NotEmpty(t, h.ID)
+						assert.Equal(t, &batchID, h.BatchID)
+						assert.Equal(t, model.RehearsalsProperty, h.Property)
+
+						requestIndex := slices.IndexFunc(request.Sections, func(r requests.BulkRehearsalsSongSectionRequest) bool {
+							return r.ID == h.SongSectionID
+						})
+						songSectionIndex := slices.IndexFunc(tt.song.Sections, func(sec model.SongSection) bool {
+							return sec.ID == h.SongSectionID
+						})
+						assert.NotZero(t, request.Sections[requestIndex].Rehearsals)
+						assert.Equal(t, tt.song.Sections[songSectionIndex].Rehearsals, h.From)
+						assert.Equal(t, request.Sections[requestIndex].Rehearsals+h.From, h.To)
+					}
+				}).
+				Return(nil).
+				Once()
+
 			for _, s := range request.Sections {
 				sectionIndex := slices.IndexFunc(tt.song.Sections, func(sec model.SongSection) bool {
 					return sec.ID == s.ID
@@ -576,21 +905,6 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 					continue
 				}
 
-				transactionSongSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
-					Run(func(args mock.Arguments) {
-						newHistory := args.Get(0).(*model.SongSectionHistory)
-
-						assert.NotEmpty(t, newHistory.ID)
-						assert.Equal(t, model.RehearsalsProperty, newHistory.Property)
-						assert.Equal(t, s.Rehearsals+tt.song.Sections[sectionIndex].Rehearsals, newHistory.To)
-						songSectionIndex := slices.IndexFunc(tt.song.Sections, func(sec model.SongSection) bool {
-							return sec.ID == newHistory.SongSectionID
-						})
-						assert.Equal(t, tt.song.Sections[songSectionIndex].Rehearsals, newHistory.From)
-					}).
-					Return(nil).
-					Once()
-
 				var history []model.SongSectionHistory
 				transactionSongSectionRepository.
 					On(
@@ -602,11 +916,11 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 					Return(nil, &history).
 					Once()
 
-				progressProcessor.On("ComputeRehearsalsScore", history).
+				progressProcessor.On("ComputeRehearsalsScore", history, enums.ExponentialScoring).
 					Return(tt.expectedSong.Sections[sectionIndex].RehearsalsScore).
 					Once()
 
-				progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{})).
+				progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), enums.ExponentialScoring).
 					Run(func(args mock.Arguments) {
 						ss := args.Get(0).(model.SongSection)
 						assert.Equal(t, s.ID, ss.ID)
@@ -635,6 +949,29 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 				Return(nil).
 				Once()
 
+			repositoryFactory.On("NewPracticeSessionRepository").Return(transactionPracticeSessionRepository).Once()
+			practiceSessionProcessor.
+				On(
+					"RecordRehearsal",
+					mock.IsType(model.Song{}),
+					mock.IsType(uuid.UUID{}),
+					mock.IsType([]model.PracticeSessionSection{}),
+					transactionPracticeSessionRepository,
+				).
+				Run(func(args mock.Arguments) {
+					assert.Equal(t, batchID, args.Get(1).(uuid.UUID))
+
+					sections := args.Get(2).([]model.PracticeSessionSection)
+					for _, s := range sections {
+						ind := slices.IndexFunc(request.Sections, func(r requests.BulkRehearsalsSongSectionRequest) bool {
+							return r.ID == s.SongSectionID
+						})
+						assert.Equal(t, request.Sections[ind].Rehearsals, s.Occurrences)
+					}
+				}).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(request)
 
@@ -642,8 +979,10 @@ func TestBulkRehearsalsSongSections_WhenSuccessful_ShouldNotReturnAnyError(t *te
 			assert.Nil(t, errCode)
 
 			songRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
 			transactionManager.AssertExpectations(t)
 			progressProcessor.AssertExpectations(t)
+			practiceSessionProcessor.AssertExpectations(t)
 
 			repositoryFactory.AssertExpectations(t)
 			transactionSongRepository.AssertExpectations(t)
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
@@ -18,7 +21,7 @@ import (
 func TestCreateSongSection_WhenCountSectionsBySongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, nil)
+	_uut := section.NewCreateSongSection(songSectionRepository, nil, nil, nil)
 
 	request := requests.CreateSongSectionRequest{
 		SongID: uuid.New(),
@@ -46,7 +49,7 @@ func TestCreateSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *t
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, nil)
 
 	request := requests.CreateSongSectionRequest{
 		SongID: uuid.New(),
@@ -80,7 +83,7 @@ func TestCreateSongSection_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testi
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, nil)
 
 	request := requests.CreateSongSectionRequest{
 		SongID: uuid.New(),
@@ -113,7 +116,7 @@ func TestCreateSongSection_WhenIsBandMemberAssociatedWithSongFails_ShouldReturnI
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, nil)
 
 	request := requests.CreateSongSectionRequest{
 		SongID:       uuid.New(),
@@ -153,7 +156,7 @@ func TestCreateSongSection_WhenBandMemberIsNotAssociatedWithTheSong_ShouldReturn
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, nil)
 
 	request := requests.CreateSongSectionRequest{
 		SongID:       uuid.New(),
@@ -192,7 +195,10 @@ func TestCreateSongSection_WhenCreateSectionFails_ShouldReturnInternalServerErro
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreateSongSectionRequest{
 		SongID: uuid.New(),
@@ -210,6 +216,9 @@ func TestCreateSongSection_WhenCreateSectionFails_ShouldReturnInternalServerErro
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songSectionRepository.On("Create", mock.IsType(new(model.SongSection))).
 		Return(internalError).
@@ -223,6 +232,8 @@ func TestCreateSongSection_WhenCreateSectionFails_ShouldReturnInternalServerErro
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
@@ -231,7 +242,10 @@ func TestCreateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.CreateSongSectionRequest{
 		SongID: uuid.New(),
@@ -249,6 +263,10 @@ func TestCreateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songSectionRepository.On("Create", mock.IsType(new(model.SongSection))).
 		Return(nil).
 		Once()
@@ -266,10 +284,72 @@ func TestCreateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
+func TestCreateSongSection_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewCreateSongSection(songSectionRepository, songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.CreateSongSectionRequest{
+		SongID: uuid.New(),
+		Name:   "Some Artist",
+		TypeID: uuid.New(),
+	}
+
+	expectedCount := &[]int64{20}[0]
+	songSectionRepository.On("CountAllBySong", mock.IsType(expectedCount), request.SongID).
+		Return(nil, expectedCount).
+		Once()
+
+	mockSong := &model.Song{ID: uuid.New()}
+	songRepository.On("Get", new(model.Song), request.SongID).
+		Return(nil, mockSong).
+		Once()
+
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	songSectionRepository.On("Create", mock.IsType(new(model.SongSection))).
+		Return(nil).
+		Once()
+
+	songRepository.On("Update", mock.IsType(new(model.Song))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{request.SongID}).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
 func TestCreateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -332,13 +412,21 @@ func TestCreateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewCreateSongSection(songSectionRepository, songRepository)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewCreateSongSection(songSectionRepository, songRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.CreateSongSectionRequest{
-				SongID:       uuid.New(),
-				Name:         "Some Artist",
-				TypeID:       uuid.New(),
-				BandMemberID: tt.bandMemberID,
+				SongID:        uuid.New(),
+				Name:          "Some Artist",
+				TypeID:        uuid.New(),
+				BandMemberID:  tt.bandMemberID,
+				StartOffset:   &[]uint{60}[0],
+				BarCount:      &[]uint{16}[0],
+				TimeSignature: &[]string{"4/4"}[0],
 			}
 
 			songSectionRepository.On("CountAllBySong", mock.IsType(&tt.expectedSectionsCount), request.SongID).
@@ -355,6 +443,11 @@ func TestCreateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 					Once()
 			}
 
+			repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			songSectionRepository.On("Create", mock.IsType(new(model.SongSection))).
 				Run(func(args mock.Arguments) {
 					newSection := args.Get(0).(*model.SongSection)
@@ -373,14 +466,21 @@ func TestCreateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 				Return(nil).
 				Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{request.SongID}).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(request)
 
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 		})
 	}
 }
@@ -402,5 +502,8 @@ func assertCreatedSongSection(
 	assert.Equal(t, request.TypeID, section.SongSectionTypeID)
 	assert.Equal(t, request.BandMemberID, section.BandMemberID)
 	assert.Equal(t, request.InstrumentID, section.InstrumentID)
+	assert.Equal(t, request.StartOffset, section.StartOffset)
+	assert.Equal(t, request.BarCount, section.BarCount)
+	assert.Equal(t, request.TimeSignature, section.TimeSignature)
 	assert.Equal(t, request.SongID, section.SongID)
 }
@@ -5,8 +5,11 @@ import (
 	"math"
 	"net/http"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"slices"
 	"testing"
 
@@ -18,7 +21,7 @@ import (
 func TestDeleteSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewDeleteSongSection(nil, songRepository)
+	_uut := section.NewDeleteSongSection(songRepository, nil, nil)
 
 	id := uuid.New()
 	songID := uuid.New()
@@ -42,7 +45,7 @@ func TestDeleteSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *t
 func TestDeleteSongSection_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewDeleteSongSection(nil, songRepository)
+	_uut := section.NewDeleteSongSection(songRepository, nil, nil)
 
 	id := uuid.New()
 	songID := uuid.New()
@@ -65,7 +68,7 @@ func TestDeleteSongSection_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testi
 func TestDeleteSongSection_WhenSectionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewDeleteSongSection(nil, songRepository)
+	_uut := section.NewDeleteSongSection(songRepository, nil, nil)
 
 	id := uuid.New()
 	songID := uuid.New()
@@ -95,7 +98,10 @@ func TestDeleteSongSection_WhenSectionIsNotFound_ShouldReturnNotFoundError(t *te
 func TestDeleteSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewDeleteSongSection(nil, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewDeleteSongSection(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	songID := uuid.New()
@@ -111,6 +117,9 @@ func TestDeleteSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 		Return(nil, song).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
 		Return(internalError).
@@ -124,6 +133,8 @@ func TestDeleteSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -131,7 +142,10 @@ func TestDeleteSongSection_WhenDeleteSectionFails_ShouldReturnInternalServerErro
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewDeleteSongSection(songSectionRepository, songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewDeleteSongSection(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	songID := uuid.New()
@@ -147,6 +161,10 @@ func TestDeleteSongSection_WhenDeleteSectionFails_ShouldReturnInternalServerErro
 		Return(nil, song).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
 		Return(nil).
 		Once()
@@ -162,8 +180,66 @@ func TestDeleteSongSection_WhenDeleteSectionFails_ShouldReturnInternalServerErro
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	songSectionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestDeleteSongSection_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewDeleteSongSection(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	id := uuid.New()
+	songID := uuid.New()
+
+	// given - mocking
+	song := &model.Song{
+		ID: songID,
+		Sections: []model.SongSection{
+			{ID: id, Order: 0},
+		},
+	}
+	songRepository.On("GetWithSections", new(model.Song), songID).
+		Return(nil, song).
+		Once()
+
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	songRepository.On("UpdateWithAssociations", mock.IsType(song)).
+		Return(nil).
+		Once()
+
+	songSectionRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{songID}).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id, songID)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songSectionRepository.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 }
 
 func TestDeleteSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
@@ -248,7 +324,12 @@ func TestDeleteSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewDeleteSongSection(songSectionRepository, songRepository)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewDeleteSongSection(songRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			id := tt.song.Sections[tt.sectionsIndex].ID
 			songID := tt.song.ID
@@ -258,6 +339,11 @@ func TestDeleteSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 				Return(nil, &tt.song).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewSongSectionRepository").Return(songSectionRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			songRepository.On("UpdateWithAssociations", mock.IsType(&tt.song)).
 				Run(func(args mock.Arguments) {
 					newSong := args.Get(0).(*model.Song)
@@ -281,14 +367,21 @@ func TestDeleteSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 
 			songSectionRepository.On("Delete", []uuid.UUID{id}).Return(nil).Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{songID}).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(id, songID)
 
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 		})
 	}
 }
@@ -6,8 +6,11 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"slices"
 	"testing"
 
@@ -19,7 +22,7 @@ import (
 func TestMoveSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewMoveSongSection(songRepository)
+	_uut := section.NewMoveSongSection(songRepository, nil, nil)
 
 	request := requests.MoveSongSectionRequest{
 		ID:     uuid.New(),
@@ -47,7 +50,7 @@ func TestMoveSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *tes
 func TestMoveSongSection_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewMoveSongSection(songRepository)
+	_uut := section.NewMoveSongSection(songRepository, nil, nil)
 
 	request := requests.MoveSongSectionRequest{
 		ID:     uuid.New(),
@@ -74,7 +77,7 @@ func TestMoveSongSection_WhenSongIsNotFound_ShouldReturnNotFoundError(t *testing
 func TestMoveSongSection_WhenSectionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewMoveSongSection(songRepository)
+	_uut := section.NewMoveSongSection(songRepository, nil, nil)
 
 	song := &model.Song{ID: uuid.New()}
 
@@ -103,7 +106,7 @@ func TestMoveSongSection_WhenSectionIsNotFound_ShouldReturnNotFoundError(t *test
 func TestMoveSongSection_WhenOverSectionIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewMoveSongSection(songRepository)
+	_uut := section.NewMoveSongSection(songRepository, nil, nil)
 
 	song := &model.Song{
 		ID: uuid.New(),
@@ -137,7 +140,10 @@ func TestMoveSongSection_WhenOverSectionIsNotFound_ShouldReturnNotFoundError(t *
 func TestMoveSongSection_WhenUpdateFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewMoveSongSection(songRepository)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewMoveSongSection(songRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	song := &model.Song{
 		ID: uuid.New(),
@@ -158,6 +164,9 @@ func TestMoveSongSection_WhenUpdateFails_ShouldReturnInternalServerError(t *test
 		Return(nil, song).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
 		Return(internalError).
@@ -171,6 +180,64 @@ func TestMoveSongSection_WhenUpdateFails_ShouldReturnInternalServerError(t *test
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestMoveSongSection_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewMoveSongSection(songRepository, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	song := &model.Song{
+		ID: uuid.New(),
+		Sections: []model.SongSection{
+			{ID: uuid.New(), Order: 0},
+			{ID: uuid.New(), Order: 1},
+		},
+	}
+
+	request := requests.MoveSongSectionRequest{
+		ID:     song.Sections[0].ID,
+		OverID: song.Sections[1].ID,
+		SongID: song.ID,
+	}
+
+	// given - mocking
+	songRepository.On("GetWithSections", new(model.Song), request.SongID).
+		Return(nil, song).
+		Once()
+
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	songRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{song.ID}).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -217,7 +284,12 @@ func TestMoveSongSection_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// given
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewMoveSongSection(songRepository)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewMoveSongSection(songRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.MoveSongSectionRequest{
 				ID:     tt.song.Sections[tt.index].ID,
@@ -230,6 +302,10 @@ func TestMoveSongSection_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil, tt.song).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			songRepository.On("UpdateWithAssociations", mock.IsType(new(model.Song))).
 				Run(func(args mock.Arguments) {
 					song := args.Get(0).(*model.Song)
@@ -250,12 +326,19 @@ func TestMoveSongSection_WhenIsValid_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil).
 				Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{tt.song.ID}).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(request)
 
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 		})
 	}
@@ -5,8 +5,12 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/song/section"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
 	"repertoire/server/test/unit/domain/processor"
 	"testing"
 	"time"
@@ -19,7 +23,7 @@ import (
 func TestUpdateSongSection_WhenGetSectionFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil)
+	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil, nil, nil, nil)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:     uuid.New(),
@@ -46,7 +50,7 @@ func TestUpdateSongSection_WhenGetSectionFails_ShouldReturnInternalServerError(t
 func TestUpdateSongSection_WhenSectionsIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil)
+	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil, nil, nil, nil)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:     uuid.New(),
@@ -72,7 +76,7 @@ func TestUpdateSongSection_WhenSectionsIsEmpty_ShouldReturnNotFoundError(t *test
 func TestUpdateSongSection_WhenRehearsalsIsDecreasing_ShouldReturnConflictError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil)
+	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil, nil, nil, nil)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:         uuid.New(),
@@ -140,7 +144,7 @@ func TestUpdateSongSection_WhenGetSongFails_ShouldReturnInternalServerError(t *t
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil, nil, nil, nil)
 
 			// given - mocking
 			mockSection := &model.SongSection{
@@ -211,7 +215,7 @@ func TestUpdateSongSection_WhenCountSectionsFails_ShouldReturnInternalServerErro
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil, nil, nil, nil)
 
 			// given - mocking
 			mockSection := &model.SongSection{
@@ -247,11 +251,63 @@ func TestUpdateSongSection_WhenCountSectionsFails_ShouldReturnInternalServerErro
 	}
 }
 
+func TestUpdateSongSection_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, userRepository, nil, nil, nil)
+
+	request := requests.UpdateSongSectionRequest{
+		ID:         uuid.New(),
+		Name:       "Some Section",
+		Rehearsals: 50,
+		TypeID:     uuid.New(),
+	}
+
+	// given - mocking
+	mockSection := &model.SongSection{
+		ID:     request.ID,
+		Name:   "Old name",
+		SongID: uuid.New(),
+	}
+	songSectionRepository.On("Get", new(model.SongSection), request.ID).
+		Return(nil, mockSection).
+		Once()
+
+	mockSong := &model.Song{ID: mockSection.SongID, UserID: uuid.New()}
+	songRepository.On("Get", new(model.Song), mockSection.SongID).
+		Return(nil, mockSong).
+		Once()
+
+	sectionsCount := &[]int64{20}[0]
+	songSectionRepository.On("CountAllBySong", mock.IsType(sectionsCount), mockSection.SongID).
+		Return(nil, sectionsCount).
+		Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songSectionRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
 func TestUpdateSongSection_WhenIsBandMemberAssociatedWithSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+	_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil, nil, nil, nil)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:           uuid.New(),
@@ -291,7 +347,7 @@ func TestUpdateSongSection_WhenBandMemberIsNotAssociatedWithSong_ShouldReturnCon
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+	_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil, nil, nil, nil)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:           uuid.New(),
@@ -326,7 +382,7 @@ func TestUpdateSongSection_WhenBandMemberIsNotAssociatedWithSong_ShouldReturnCon
 	songRepository.AssertExpectations(t)
 }
 
-func TestUpdateSongSection_WhenCreateHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestUpdateSongSection_WhenCreateHistoriesFails_ShouldReturnInternalServerError(t *testing.T) {
 	tests := []struct {
 		name     string
 		request  requests.UpdateSongSectionRequest
@@ -370,7 +426,13 @@ func TestUpdateSongSection_WhenCreateHistoryFails_ShouldReturnInternalServerErro
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+			userRepository := new(repository.UserRepositoryMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, userRepository, transactionManager, nil, nil)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+			transactionSongRepository := new(repository.SongRepositoryMock)
 
 			// given - mocking
 			mockSection := &model.SongSection{
@@ -392,11 +454,19 @@ func TestUpdateSongSection_WhenCreateHistoryFails_ShouldReturnInternalServerErro
 				Return(nil, sectionsCount).
 				Once()
 
+			userRepository.On("Get", new(model.User), mockSong.UserID).
+				Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+				Once()
+
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+			repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+			repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
 			internalError := errors.New("internal error")
-			songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+			transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
 				Run(func(args mock.Arguments) {
-					newHistory := args.Get(0).(*model.SongSectionHistory)
-					assert.Equal(t, tt.property, newHistory.Property)
+					newHistory := args.Get(0).(*[]model.SongSectionHistory)
+					assert.Equal(t, tt.property, (*newHistory)[0].Property)
 				}).
 				Return(internalError).
 				Once()
@@ -411,6 +481,11 @@ func TestUpdateSongSection_WhenCreateHistoryFails_ShouldReturnInternalServerErro
 
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
+			transactionSongSectionRepository.AssertExpectations(t)
+			transactionSongRepository.AssertExpectations(t)
 		})
 	}
 }
@@ -459,7 +534,13 @@ func TestUpdateSongSection_WhenGetHistoryFails_ShouldReturnInternalServerError(t
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, nil)
+			userRepository := new(repository.UserRepositoryMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, userRepository, transactionManager, nil, nil)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+			transactionSongRepository := new(repository.SongRepositoryMock)
 
 			// given - mocking
 			mockSection := &model.SongSection{
@@ -481,12 +562,20 @@ func TestUpdateSongSection_WhenGetHistoryFails_ShouldReturnInternalServerError(t
 				Return(nil, sectionsCount).
 				Once()
 
-			songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+			userRepository.On("Get", new(model.User), mockSong.UserID).
+				Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+				Once()
+
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+			repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+			repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+			transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
 				Return(nil).
 				Once()
 
 			internalError := errors.New("internal error")
-			songSectionRepository.
+			transactionSongSectionRepository.
 				On(
 					"GetHistory",
 					mock.IsType(new([]model.SongSectionHistory)),
@@ -505,6 +594,11 @@ func TestUpdateSongSection_WhenGetHistoryFails_ShouldReturnInternalServerError(t
 			assert.Equal(t, internalError, errCode.Error)
 
 			songRepository.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
+			transactionSongSectionRepository.AssertExpectations(t)
+			transactionSongRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
 		})
 	}
 }
@@ -512,7 +606,12 @@ func TestUpdateSongSection_WhenGetHistoryFails_ShouldReturnInternalServerError(t
 func TestUpdateSongSection_WhenUpdateSectionFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songSectionRepository := new(repository.SongSectionRepositoryMock)
-	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil, transactionManager, nil, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
 
 	request := requests.UpdateSongSectionRequest{
 		ID:     uuid.New(),
@@ -529,8 +628,12 @@ func TestUpdateSongSection_WhenUpdateSectionFails_ShouldReturnInternalServerErro
 		Return(nil, mockSection).
 		Once()
 
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
 	internalError := errors.New("internal error")
-	songSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
+	transactionSongSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
 		Return(internalError).
 		Once()
 
@@ -543,6 +646,10 @@ func TestUpdateSongSection_WhenUpdateSectionFails_ShouldReturnInternalServerErro
 	assert.Equal(t, internalError, errCode.Error)
 
 	songSectionRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
 }
 
 func TestUpdateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testing.T) {
@@ -589,8 +696,21 @@ func TestUpdateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
+			userRepository := new(repository.UserRepositoryMock)
+			transactionManager := new(transaction.ManagerMock)
 			progressProcessor := new(processor.ProgressProcessorMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, progressProcessor)
+			_uut := section.NewUpdateSongSection(
+				songSectionRepository,
+				songRepository,
+				userRepository,
+				transactionManager,
+				progressProcessor,
+				nil,
+			)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+			transactionSongRepository := new(repository.SongRepositoryMock)
 
 			// given - mocking
 			mockSection := &model.SongSection{
@@ -614,25 +734,33 @@ func TestUpdateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 				Return(nil, sectionsCount).
 				Once()
 
+			userRepository.On("Get", new(model.User), mockSong.UserID).
+				Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+				Once()
+
 			var history []model.SongSectionHistory
 			songSectionHistoryTimes := 0
 
 			if mockSection.Rehearsals != tt.request.Rehearsals {
 				songSectionHistoryTimes++
-				progressProcessor.On("ComputeRehearsalsScore", history).Return(uint64(125)).Once()
+				progressProcessor.On("ComputeRehearsalsScore", history, enums.ClassicScoring).Return(uint64(125)).Once()
 			}
 
 			if mockSection.Confidence != tt.request.Confidence {
 				songSectionHistoryTimes++
-				progressProcessor.On("ComputeConfidenceScore", history).Return(uint(12)).Once()
+				progressProcessor.On("ComputeConfidenceScore", history, enums.ClassicScoring).Return(uint(12)).Once()
 			}
 
 			if songSectionHistoryTimes > 0 {
-				songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+				transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+				repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+				repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+				transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
 					Return(nil).
-					Times(songSectionHistoryTimes)
+					Once()
 
-				songSectionRepository.
+				transactionSongSectionRepository.
 					On(
 						"GetHistory",
 						mock.IsType(new([]model.SongSectionHistory)),
@@ -646,13 +774,13 @@ func TestUpdateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 					Return(nil, &history).
 					Times(songSectionHistoryTimes)
 
-				progressProcessor.On("ComputeProgress", mock.IsType(*mockSection)).
+				progressProcessor.On("ComputeProgress", mock.IsType(*mockSection), enums.ClassicScoring).
 					Return(uint64(780)).
 					Times(songSectionHistoryTimes)
 			}
 
 			internalError := errors.New("internal error")
-			songRepository.On("Update", mock.IsType(mockSong)).Return(internalError).Once()
+			transactionSongRepository.On("Update", mock.IsType(mockSong)).Return(internalError).Once()
 
 			// when
 			errCode := _uut.Handle(tt.request)
@@ -664,14 +792,78 @@ func TestUpdateSongSection_WhenUpdateSongFails_ShouldReturnInternalServerError(t
 
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
+			transactionSongSectionRepository.AssertExpectations(t)
+			transactionSongRepository.AssertExpectations(t)
 			progressProcessor.AssertExpectations(t)
 		})
 	}
 }
 
+func TestUpdateSongSection_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := section.NewUpdateSongSection(songSectionRepository, nil, nil, transactionManager, nil, messagePublisherService)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UpdateSongSectionRequest{
+		ID:     uuid.New(),
+		Name:   "Some Artist",
+		TypeID: uuid.New(),
+	}
+
+	// given - mocking
+	mockSection := &model.SongSection{
+		ID:     request.ID,
+		Name:   "Old name",
+		SongID: uuid.New(),
+	}
+	songSectionRepository.On("Get", new(model.SongSection), request.ID).
+		Return(nil, mockSection).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+
+	transactionSongSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
+		Return(nil).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{mockSection.SongID}).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songSectionRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
 func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 	id := uuid.New()
 	songID := uuid.New()
+	typeID := uuid.New()
 
 	tests := []struct {
 		name                   string
@@ -684,6 +876,27 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 		expectedSongRehearsals float64
 		expectedSongProgress   float64
 	}{
+		{
+			"without searchable changes",
+			&model.SongSection{
+				ID:                id,
+				Name:              "Same name",
+				SongSectionTypeID: typeID,
+				SongID:            uuid.New(),
+			},
+			requests.UpdateSongSectionRequest{
+				ID:           id,
+				Name:         "Same name",
+				TypeID:       typeID,
+				InstrumentID: &[]uuid.UUID{uuid.New()}[0],
+			},
+			0,
+			nil,
+			nil,
+			0,
+			0,
+			0,
+		},
 		{
 			"without confidence or rehearsals",
 			&model.SongSection{
@@ -766,11 +979,14 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 				SongID:     uuid.New(),
 			},
 			requests.UpdateSongSectionRequest{
-				ID:         id,
-				Name:       "Some New Name",
-				Rehearsals: 40,
-				Confidence: 50,
-				TypeID:     uuid.New(),
+				ID:            id,
+				Name:          "Some New Name",
+				Rehearsals:    40,
+				Confidence:    50,
+				TypeID:        uuid.New(),
+				StartOffset:   &[]uint{75}[0],
+				BarCount:      &[]uint{12}[0],
+				TimeSignature: &[]string{"3/4"}[0],
 			},
 			8,
 			&model.Song{
@@ -791,14 +1007,33 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 			// given
 			songSectionRepository := new(repository.SongSectionRepositoryMock)
 			songRepository := new(repository.SongRepositoryMock)
+			userRepository := new(repository.UserRepositoryMock)
+			transactionManager := new(transaction.ManagerMock)
 			progressProcessor := new(processor.ProgressProcessorMock)
-			_uut := section.NewUpdateSongSection(songSectionRepository, songRepository, progressProcessor)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			_uut := section.NewUpdateSongSection(
+				songSectionRepository,
+				songRepository,
+				userRepository,
+				transactionManager,
+				progressProcessor,
+				messagePublisherService,
+			)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+			transactionSongRepository := new(repository.SongRepositoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			// given - mocking
 			songSectionRepository.On("Get", new(model.SongSection), tt.request.ID).
 				Return(nil, tt.songSection).
 				Once()
 
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+			repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+			repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
 			var history []model.SongSectionHistory
 			songSectionHistoryTimes := 0
 
@@ -808,37 +1043,40 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 			if tt.songSection.Rehearsals != tt.request.Rehearsals {
 				songSectionHistoryTimes++
 				rehearsalScore = 125
-				progressProcessor.On("ComputeRehearsalsScore", history).Return(rehearsalScore).Once()
+				progressProcessor.On("ComputeRehearsalsScore", history, enums.LinearScoring).Return(rehearsalScore).Once()
 			}
 
 			if tt.songSection.Confidence != tt.request.Confidence {
 				songSectionHistoryTimes++
 				confidenceScore = 88
-				progressProcessor.On("ComputeConfidenceScore", history).Return(confidenceScore).Once()
+				progressProcessor.On("ComputeConfidenceScore", history, enums.LinearScoring).Return(confidenceScore).Once()
 			}
 
 			if songSectionHistoryTimes > 0 {
-				songSectionRepository.On("CreateHistory", mock.IsType(new(model.SongSectionHistory))).
+				transactionSongSectionRepository.On("CreateHistories", mock.IsType(new([]model.SongSectionHistory))).
 					Run(func(args mock.Arguments) {
-						newHistory := args.Get(0).(*model.SongSectionHistory)
-
-						assert.NotEmpty(t, newHistory.ID)
-						assert.Equal(t, tt.songSection.ID, newHistory.SongSectionID)
-						assert.NotEmpty(t, newHistory.Property)
-						if newHistory.Property == model.ConfidenceProperty {
-							assert.Equal(t, tt.songSection.Confidence, newHistory.From)
-							assert.Equal(t, tt.request.Confidence, newHistory.To)
-						} else if newHistory.Property == model.RehearsalsProperty {
-							assert.Equal(t, tt.songSection.Rehearsals, newHistory.From)
-							assert.Equal(t, tt.request.Rehearsals, newHistory.To)
-						} else {
-							assert.Fail(t, "invalid property")
+						newHistory := args.Get(0).(*[]model.SongSectionHistory)
+						assert.Len(t, *newHistory, songSectionHistoryTimes)
+
+						for _, h := range *newHistory {
+							assert.NotEmpty(t, h.ID)
+							assert.Equal(t, tt.songSection.ID, h.SongSectionID)
+							assert.NotEmpty(t, h.Property)
+							if h.Property == model.ConfidenceProperty {
+								assert.Equal(t, tt.songSection.Confidence, h.From)
+								assert.Equal(t, tt.request.Confidence, h.To)
+							} else if h.Property == model.RehearsalsProperty {
+								assert.Equal(t, tt.songSection.Rehearsals, h.From)
+								assert.Equal(t, tt.request.Rehearsals, h.To)
+							} else {
+								assert.Fail(t, "invalid property")
+							}
 						}
 					}).
 					Return(nil).
-					Times(songSectionHistoryTimes)
+					Once()
 
-				songSectionRepository.
+				transactionSongSectionRepository.
 					On(
 						"GetHistory",
 						mock.IsType(new([]model.SongSectionHistory)),
@@ -852,7 +1090,7 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 					Return(nil, &history).
 					Times(songSectionHistoryTimes)
 
-				progressProcessor.On("ComputeProgress", mock.IsType(*tt.songSection)).
+				progressProcessor.On("ComputeProgress", mock.IsType(*tt.songSection), enums.LinearScoring).
 					Run(func(args mock.Arguments) {
 						newSection := args.Get(0).(model.SongSection)
 						assert.Equal(t, tt.songSection.ID, newSection.ID)
@@ -874,7 +1112,11 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 					Return(nil, tt.sectionsCount).
 					Once()
 
-				songRepository.On("Update", mock.IsType(tt.song)).
+				userRepository.On("Get", new(model.User), tt.song.UserID).
+					Return(nil, &model.User{ScoringStrategy: enums.LinearScoring}).
+					Once()
+
+				transactionSongRepository.On("Update", mock.IsType(tt.song)).
 					Run(func(args mock.Arguments) {
 						newSong := args.Get(0).(*model.Song)
 
@@ -890,7 +1132,7 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 					Once()
 			}
 
-			songSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
+			transactionSongSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
 				Run(func(args mock.Arguments) {
 					newSection := args.Get(0).(*model.SongSection)
 					assertUpdatedSongSection(t, tt.request, *newSection, rehearsalScore, confidenceScore, tt.progress)
@@ -898,6 +1140,14 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 				Return(nil).
 				Once()
 
+			if tt.songSection.Name != tt.request.Name || tt.songSection.SongSectionTypeID != tt.request.TypeID {
+				repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+				messagePublisherService.
+					On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, []uuid.UUID{tt.songSection.SongID}).
+					Return(nil).
+					Once()
+			}
+
 			// when
 			errCode := _uut.Handle(tt.request)
 
@@ -906,7 +1156,13 @@ func TestUpdateSongSection_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T)
 
 			songSectionRepository.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
+			transactionSongSectionRepository.AssertExpectations(t)
+			transactionSongRepository.AssertExpectations(t)
 			progressProcessor.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
 		})
 	}
 }
@@ -928,4 +1184,7 @@ func assertUpdatedSongSection(
 	assert.Equal(t, request.TypeID, section.SongSectionTypeID)
 	assert.Equal(t, request.BandMemberID, section.BandMemberID)
 	assert.Equal(t, request.InstrumentID, section.InstrumentID)
+	assert.Equal(t, request.StartOffset, section.StartOffset)
+	assert.Equal(t, request.BarCount, section.BarCount)
+	assert.Equal(t, request.TimeSignature, section.TimeSignature)
 }
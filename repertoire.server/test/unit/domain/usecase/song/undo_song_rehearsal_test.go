@@ -0,0 +1,605 @@
+package song
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/song"
+	"repertoire/server/internal/enums"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUndoSongRehearsal_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, nil, nil, nil)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetWithSections", new(model.Song), request.ID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, nil, nil, nil)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	songRepository.On("GetWithSections", new(model.Song), request.ID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "song not found", errCode.Error.Error())
+
+	songRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, nil, nil)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), mockSong.UserID).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenTransactionExecuteFails_ShouldReturnError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionManager.On("Execute", mock.Anything).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenGetLastRehearsalBatchFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenThereIsNoRehearsalBatch_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "there is no rehearsal to undo", errCode.Error.Error())
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenDeleteRehearsalBatchFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	mockBatch := &model.RehearsalBatch{ID: uuid.New(), SongID: mockSong.ID}
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil, mockBatch).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.On("DeleteRehearsalBatch", mockBatch.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenGetHistoryFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:       request.ID,
+		UserID:   uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), Rehearsals: 3}},
+	}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	mockBatch := &model.RehearsalBatch{
+		ID:     uuid.New(),
+		SongID: mockSong.ID,
+		History: []model.SongSectionHistory{
+			{ID: uuid.New(), From: 1, To: 3, SongSectionID: mockSong.Sections[0].ID},
+		},
+	}
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil, mockBatch).
+		Once()
+	transactionSongSectionRepository.On("DeleteRehearsalBatch", mockBatch.ID).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			mockSong.Sections[0].ID,
+			model.RehearsalsProperty,
+		).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenUpdateSectionFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, progressProcessor)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:       request.ID,
+		UserID:   uuid.New(),
+		Sections: []model.SongSection{{ID: uuid.New(), Rehearsals: 3}},
+	}
+	mockUser := &model.User{ScoringStrategy: enums.ClassicScoring}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, mockUser).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	mockBatch := &model.RehearsalBatch{
+		ID:     uuid.New(),
+		SongID: mockSong.ID,
+		History: []model.SongSectionHistory{
+			{ID: uuid.New(), From: 1, To: 3, SongSectionID: mockSong.Sections[0].ID},
+		},
+	}
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil, mockBatch).
+		Once()
+	transactionSongSectionRepository.On("DeleteRehearsalBatch", mockBatch.ID).Return(nil).Once()
+
+	history := &[]model.SongSectionHistory{}
+	transactionSongSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			mockSong.Sections[0].ID,
+			model.RehearsalsProperty,
+		).
+		Return(nil, history).
+		Once()
+	progressProcessor.On("ComputeRehearsalsScore", *history, mockUser.ScoringStrategy).
+		Return(uint64(0)).
+		Once()
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), mockUser.ScoringStrategy).
+		Return(uint64(0)).
+		Once()
+
+	internalError := errors.New("internal error")
+	transactionSongSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, nil)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{ID: request.ID, UserID: uuid.New()}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, &model.User{ScoringStrategy: enums.ClassicScoring}).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	mockBatch := &model.RehearsalBatch{ID: uuid.New(), SongID: mockSong.ID}
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil, mockBatch).
+		Once()
+	transactionSongSectionRepository.On("DeleteRehearsalBatch", mockBatch.ID).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	transactionSongRepository.On("Update", mock.IsType(new(model.Song))).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
+
+func TestUndoSongRehearsal_WhenSuccessful_ShouldRevertSectionsAndSong(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := song.NewUndoSongRehearsal(songRepository, userRepository, transactionManager, progressProcessor)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	transactionSongSectionRepository := new(repository.SongSectionRepositoryMock)
+	transactionSongRepository := new(repository.SongRepositoryMock)
+
+	request := requests.UndoSongRehearsalRequest{
+		ID: uuid.New(),
+	}
+
+	mockSong := &model.Song{
+		ID:             request.ID,
+		UserID:         uuid.New(),
+		Rehearsals:     5,
+		Progress:       50,
+		LastTimePlayed: &[]time.Time{time.Now()}[0],
+		Sections: []model.SongSection{
+			{ID: uuid.New(), Rehearsals: 6, Progress: 60},
+			{ID: uuid.New(), Rehearsals: 4, Progress: 40},
+			{ID: uuid.New(), Rehearsals: 5, Progress: 50},
+		},
+	}
+	mockUser := &model.User{ScoringStrategy: enums.LinearScoring}
+	songRepository.On("GetWithSections", new(model.Song), request.ID).
+		Return(nil, mockSong).
+		Once()
+	userRepository.On("Get", new(model.User), mockSong.UserID).
+		Return(nil, mockUser).
+		Once()
+
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+	repositoryFactory.On("NewSongSectionRepository").Return(transactionSongSectionRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(transactionSongRepository).Once()
+
+	mockBatch := &model.RehearsalBatch{
+		ID:                     uuid.New(),
+		SongID:                 mockSong.ID,
+		PreviousLastTimePlayed: &[]time.Time{time.Now().Add(-24 * time.Hour)}[0],
+		History: []model.SongSectionHistory{
+			{ID: uuid.New(), From: 4, To: 6, SongSectionID: mockSong.Sections[0].ID},
+			{ID: uuid.New(), From: 3, To: 4, SongSectionID: mockSong.Sections[1].ID},
+			{ID: uuid.New(), From: 0, To: 1, SongSectionID: uuid.New()}, // deleted section
+		},
+	}
+	transactionSongSectionRepository.On("GetLastRehearsalBatch", new(model.RehearsalBatch), mockSong.ID).
+		Return(nil, mockBatch).
+		Once()
+	transactionSongSectionRepository.On("DeleteRehearsalBatch", mockBatch.ID).Return(nil).Once()
+
+	revertedSectionsCount := 2
+
+	history := &[]model.SongSectionHistory{{ID: uuid.New()}}
+	transactionSongSectionRepository.
+		On(
+			"GetHistory",
+			new([]model.SongSectionHistory),
+			mock.IsType(uuid.UUID{}),
+			model.RehearsalsProperty,
+		).
+		Return(nil, history).
+		Times(revertedSectionsCount)
+
+	var newRehearsalsScore uint64 = 12
+	var newProgress uint64 = 20
+	progressProcessor.On("ComputeRehearsalsScore", *history, mockUser.ScoringStrategy).
+		Return(newRehearsalsScore).
+		Times(revertedSectionsCount)
+	progressProcessor.On("ComputeProgress", mock.IsType(model.SongSection{}), mockUser.ScoringStrategy).
+		Run(func(args mock.Arguments) {
+			section := args.Get(0).(model.SongSection)
+			assert.Equal(t, newRehearsalsScore, section.RehearsalsScore)
+		}).
+		Return(newProgress).
+		Times(revertedSectionsCount)
+
+	transactionSongSectionRepository.On("Update", mock.IsType(new(model.SongSection))).
+		Run(func(args mock.Arguments) {
+			section := args.Get(0).(*model.SongSection)
+			assert.Equal(t, newRehearsalsScore, section.RehearsalsScore)
+			assert.Equal(t, newProgress, section.Progress)
+		}).
+		Return(nil).
+		Times(revertedSectionsCount)
+
+	transactionSongRepository.On("Update", mock.IsType(new(model.Song))).
+		Run(func(args mock.Arguments) {
+			newSong := args.Get(0).(*model.Song)
+			assert.Equal(t, uint(4), newSong.Sections[0].Rehearsals)
+			assert.Equal(t, uint(3), newSong.Sections[1].Rehearsals)
+			assert.Equal(t, uint(5), newSong.Sections[2].Rehearsals)
+			assert.Equal(t, uint64(50), newSong.Sections[2].Progress)
+
+			assert.Equal(t, float64(4+3+5)/3, newSong.Rehearsals)
+			assert.Equal(t, float64(20+20+50)/3, newSong.Progress)
+			assert.Equal(t, mockBatch.PreviousLastTimePlayed, newSong.LastTimePlayed)
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request)
+
+	// then
+	assert.Nil(t, errCode)
+
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	transactionSongSectionRepository.AssertExpectations(t)
+	transactionSongRepository.AssertExpectations(t)
+}
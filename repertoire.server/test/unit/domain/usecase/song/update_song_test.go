@@ -9,6 +9,7 @@ import (
 	"repertoire/server/internal/enums"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -23,7 +24,7 @@ import (
 func TestUpdateSong_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, nil, nil)
+	_uut := song.NewUpdateSong(songRepository, nil, nil, nil)
 
 	request := requests.UpdateSongRequest{
 		ID:    uuid.New(),
@@ -47,7 +48,7 @@ func TestUpdateSong_WhenGetSongFails_ShouldReturnInternalServerError(t *testing.
 func TestUpdateSong_WhenSongIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, nil, nil)
+	_uut := song.NewUpdateSong(songRepository, nil, nil, nil)
 
 	request := requests.UpdateSongRequest{
 		ID:    uuid.New(),
@@ -71,7 +72,7 @@ func TestUpdateSong_WhenGetAlbumFails_ShouldReturnInternalServerError(t *testing
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, albumRepository, nil)
+	_uut := song.NewUpdateSong(songRepository, albumRepository, nil, nil)
 
 	request := requests.UpdateSongRequest{
 		ID:      uuid.New(),
@@ -106,7 +107,7 @@ func TestUpdateSong_WhenAlbumIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, albumRepository, nil)
+	_uut := song.NewUpdateSong(songRepository, albumRepository, nil, nil)
 
 	request := requests.UpdateSongRequest{
 		ID:      uuid.New(),
@@ -175,7 +176,7 @@ func TestUpdateSong_WhenAlbumArtistAndRequestArtistDoNotMatch_ShouldReturnConfli
 			// given
 			songRepository := new(repository.SongRepositoryMock)
 			albumRepository := new(repository.AlbumRepositoryMock)
-			_uut := song.NewUpdateSong(songRepository, albumRepository, nil)
+			_uut := song.NewUpdateSong(songRepository, albumRepository, nil, nil)
 
 			songRepository.On("Get", new(model.Song), tt.request.ID).
 				Return(nil, &tt.song).
@@ -202,7 +203,10 @@ func TestUpdateSong_WhenAlbumArtistAndRequestArtistDoNotMatch_ShouldReturnConfli
 func TestUpdateSong_WhenGetAllSongsByAlbumFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUpdateSong(songRepository, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateSongRequest{
 		ID: uuid.New(),
@@ -217,6 +221,9 @@ func TestUpdateSong_WhenGetAllSongsByAlbumFails_ShouldReturnInternalServerError(
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.
 		On(
@@ -236,13 +243,18 @@ func TestUpdateSong_WhenGetAllSongsByAlbumFails_ShouldReturnInternalServerError(
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
 func TestUpdateSong_WhenUpdateAllSongsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUpdateSong(songRepository, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateSongRequest{
 		ID: uuid.New(),
@@ -257,6 +269,9 @@ func TestUpdateSong_WhenUpdateAllSongsFails_ShouldReturnInternalServerError(t *t
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.
 		On(
 			"GetAllByAlbumAndTrackNo",
@@ -280,6 +295,8 @@ func TestUpdateSong_WhenUpdateAllSongsFails_ShouldReturnInternalServerError(t *t
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -287,7 +304,10 @@ func TestUpdateSong_WhenCountSongsFails_ShouldReturnInternalServerError(t *testi
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	albumRepository := new(repository.AlbumRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, albumRepository, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUpdateSong(songRepository, albumRepository, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateSongRequest{
 		ID:       uuid.New(),
@@ -306,6 +326,9 @@ func TestUpdateSong_WhenCountSongsFails_ShouldReturnInternalServerError(t *testi
 		Return(nil, &mockAlbum).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("CountByAlbum", new(int64), *request.AlbumID).
 		Return(internalError).
@@ -319,6 +342,8 @@ func TestUpdateSong_WhenCountSongsFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	albumRepository.AssertExpectations(t)
 }
@@ -326,7 +351,10 @@ func TestUpdateSong_WhenCountSongsFails_ShouldReturnInternalServerError(t *testi
 func TestUpdateSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	songRepository := new(repository.SongRepositoryMock)
-	_uut := song.NewUpdateSong(songRepository, nil, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUpdateSong(songRepository, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.UpdateSongRequest{
 		ID:    uuid.New(),
@@ -341,6 +369,9 @@ func TestUpdateSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testi
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	songRepository.On("Update", mock.IsType(mockSong)).
 		Return(internalError).
@@ -353,6 +384,8 @@ func TestUpdateSong_WhenUpdateSongFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 }
 
@@ -360,7 +393,11 @@ func TestUpdateSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	// given
 	songRepository := new(repository.SongRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := song.NewUpdateSong(songRepository, nil, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := song.NewUpdateSong(songRepository, nil, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	request := requests.UpdateSongRequest{
 		ID:    uuid.New(),
@@ -375,12 +412,16 @@ func TestUpdateSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 		Return(nil, mockSong).
 		Once()
 
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	songRepository.On("Update", mock.IsType(mockSong)).
 		Return(nil).
 		Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
 		Return(internalError).
 		Once()
 
@@ -391,6 +432,8 @@ func TestUpdateSong_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	songRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
 }
@@ -474,6 +517,7 @@ func TestUpdateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 				Description:    "This is a nice description",
 				IsRecorded:     true,
 				Bpm:            &[]uint{123}[0],
+				Duration:       &[]uint{321}[0],
 				GuitarTuningID: &[]uuid.UUID{uuid.New()}[0],
 				ReleaseDate:    &[]internal.Date{internal.Date(time.Now())}[0],
 				Difficulty:     &[]enums.Difficulty{enums.Hard}[0],
@@ -497,12 +541,20 @@ func TestUpdateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			// given
 			songRepository := new(repository.SongRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := song.NewUpdateSong(songRepository, nil, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := song.NewUpdateSong(songRepository, nil, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			songRepository.On("Get", new(model.Song), tt.request.ID).
 				Return(nil, tt.song).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			assertOldAlbumReordered(t, songRepository, *tt.song, tt.request.AlbumID)
 
 			var newSong *model.Song
@@ -514,9 +566,9 @@ func TestUpdateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
 				Run(func(args mock.Arguments) {
-					ids := args.Get(1).([]uuid.UUID)
+					ids := args.Get(2).([]uuid.UUID)
 					assert.Len(t, ids, 1)
 					assert.Equal(t, newSong.ID, ids[0])
 				}).
@@ -529,6 +581,8 @@ func TestUpdateSong_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
 		})
@@ -664,7 +718,11 @@ func TestUpdateSong_WhenRequestHasAlbum_ShouldNotReturnAnyError(t *testing.T) {
 			songRepository := new(repository.SongRepositoryMock)
 			albumRepository := new(repository.AlbumRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := song.NewUpdateSong(songRepository, albumRepository, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := song.NewUpdateSong(songRepository, albumRepository, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			songRepository.On("Get", new(model.Song), tt.request.ID).
 				Return(nil, &tt.song).
@@ -674,6 +732,10 @@ func TestUpdateSong_WhenRequestHasAlbum_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil, &tt.album).
 				Once()
 
+			repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			assertOldAlbumReordered(t, songRepository, tt.song, tt.request.AlbumID)
 
 			var songsCount int64 = 12
@@ -691,9 +753,9 @@ func TestUpdateSong_WhenRequestHasAlbum_ShouldNotReturnAnyError(t *testing.T) {
 				Return(nil).
 				Once()
 
-			messagePublisherService.On("Publish", topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, mock.IsType([]uuid.UUID{})).
 				Run(func(args mock.Arguments) {
-					ids := args.Get(1).([]uuid.UUID)
+					ids := args.Get(2).([]uuid.UUID)
 					assert.Len(t, ids, 1)
 					assert.Equal(t, newSong.ID, ids[0])
 				}).
@@ -706,6 +768,8 @@ func TestUpdateSong_WhenRequestHasAlbum_ShouldNotReturnAnyError(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			songRepository.AssertExpectations(t)
 			albumRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
@@ -718,6 +782,7 @@ func assertUpdatedSong(t *testing.T, request requests.UpdateSongRequest, song *m
 	assert.Equal(t, request.Description, song.Description)
 	assert.Equal(t, request.IsRecorded, song.IsRecorded)
 	assert.Equal(t, request.Bpm, song.Bpm)
+	assert.Equal(t, request.Duration, song.Duration)
 	assert.Equal(t, request.SongsterrLink, song.SongsterrLink)
 	assert.Equal(t, request.YoutubeLink, song.YoutubeLink)
 	assert.Equal(t, request.ReleaseDate, song.ReleaseDate)
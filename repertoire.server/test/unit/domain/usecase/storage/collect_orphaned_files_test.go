@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"errors"
+	"net/http"
+	httpStorage "repertoire/server/data/http/storage"
+	"repertoire/server/domain/usecase/storage"
+	"repertoire/server/internal"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectOrphanedFiles_WhenGracePeriodIsInvalid_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	_uut := storage.NewCollectOrphanedFiles(nil, nil, internal.Env{OrphanedFilesGracePeriod: "something"})
+
+	// when
+	result, errCode := _uut.Handle(nil, false)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+}
+
+func TestCollectOrphanedFiles_WhenGetAllUserIDsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := storage.NewCollectOrphanedFiles(userRepository, nil, internal.Env{})
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetAllIDs", new([]uuid.UUID)).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(nil, false)
+
+	// then
+	assert.Empty(t, result)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestCollectOrphanedFiles_WhenGetImagePathsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := storage.NewCollectOrphanedFiles(userRepository, nil, internal.Env{})
+
+	userID := uuid.New()
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetImagePaths", new([]string), userID).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(&userID, false)
+
+	// then
+	assert.Empty(t, result.Files)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+}
+
+func TestCollectOrphanedFiles_WhenGetUsageFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	storageService := new(service.StorageServiceMock)
+	_uut := storage.NewCollectOrphanedFiles(userRepository, storageService, internal.Env{})
+
+	userID := uuid.New()
+
+	userRepository.On("GetImagePaths", new([]string), userID).Return(nil).Once()
+
+	unauthorizedError := wrapper.UnauthorizedError(errors.New("unauthorized error"))
+	storageService.On("GetUsage", userID).Return(httpStorage.UsageResponse{}, unauthorizedError).Once()
+
+	// when
+	result, errCode := _uut.Handle(&userID, false)
+
+	// then
+	assert.Empty(t, result.Files)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, unauthorizedError, errCode)
+
+	userRepository.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+}
+
+func TestCollectOrphanedFiles_WhenQuarantineFilesFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	storageService := new(service.StorageServiceMock)
+	_uut := storage.NewCollectOrphanedFiles(userRepository, storageService, internal.Env{})
+
+	userID := uuid.New()
+	oldTime := time.Now().Add(-48 * time.Hour)
+
+	userRepository.On("GetImagePaths", new([]string), userID).Return(nil).Once()
+	storageService.On("GetUsage", userID).
+		Return(httpStorage.UsageResponse{Files: []httpStorage.FileUsageResponse{
+			{Path: "some-file.jpg", Size: 1, ModTime: oldTime},
+		}}, nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	storageService.On("QuarantineFiles", []string{"some-file.jpg"}).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(&userID, false)
+
+	// then
+	assert.Empty(t, result.Files)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	userRepository.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+}
+
+func TestCollectOrphanedFiles_WhenDeleteFileFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	storageService := new(service.StorageServiceMock)
+	env := internal.Env{OrphanedFilesCollectionMode: internal.DeleteOrphanedFilesCollectionMode}
+	_uut := storage.NewCollectOrphanedFiles(userRepository, storageService, env)
+
+	userID := uuid.New()
+	oldTime := time.Now().Add(-48 * time.Hour)
+
+	userRepository.On("GetImagePaths", new([]string), userID).Return(nil).Once()
+	storageService.On("GetUsage", userID).
+		Return(httpStorage.UsageResponse{Files: []httpStorage.FileUsageResponse{
+			{Path: "some-file.jpg", Size: 1, ModTime: oldTime},
+		}}, nil).
+		Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	storageService.On("DeleteFile", internal.FilePath("some-file.jpg")).Return(internalError).Once()
+
+	// when
+	result, errCode := _uut.Handle(&userID, false)
+
+	// then
+	assert.Empty(t, result.Files)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	userRepository.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+}
+
+func TestCollectOrphanedFiles_WhenSuccessful_ShouldCollectTheUnreferencedFilesPastTheGracePeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    internal.Env
+		dryRun bool
+	}{
+		{"Quarantine", internal.Env{}, false},
+		{"Delete", internal.Env{OrphanedFilesCollectionMode: internal.DeleteOrphanedFilesCollectionMode}, false},
+		{"Dry Run", internal.Env{}, true},
+		{"Custom Grace Period", internal.Env{OrphanedFilesGracePeriod: "1h"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			userRepository := new(repository.UserRepositoryMock)
+			storageService := new(service.StorageServiceMock)
+			_uut := storage.NewCollectOrphanedFiles(userRepository, storageService, tt.env)
+
+			userIDs := []uuid.UUID{uuid.New(), uuid.New()}
+			oldTime := time.Now().Add(-48 * time.Hour)
+			recentTime := time.Now().Add(-time.Minute)
+
+			userRepository.On("GetAllIDs", new([]uuid.UUID)).Return(nil, &userIDs).Once()
+
+			// the first user has both referenced and unreferenced files
+			imagePaths := []string{"first/albums/album/image.jpg", "first/profile_pic.jpg"}
+			userRepository.On("GetImagePaths", new([]string), userIDs[0]).Return(nil, &imagePaths).Once()
+			storageService.On("GetUsage", userIDs[0]).
+				Return(httpStorage.UsageResponse{Files: []httpStorage.FileUsageResponse{
+					{Path: "first/albums/album/image.jpg", Size: 1, ModTime: oldTime},
+					{Path: "first/albums/album/old-image.jpg", Size: 2, ModTime: oldTime},
+					{Path: "first/profile_pic.jpg", Size: 3, ModTime: oldTime},
+					{Path: "first/songs/song/just-uploaded.jpg", Size: 4, ModTime: recentTime},
+					{Path: "first/exports/export.zip", Size: 5, ModTime: oldTime},
+				}}, nil).
+				Once()
+
+			// the second user has nothing to collect
+			userRepository.On("GetImagePaths", new([]string), userIDs[1]).Return(nil).Once()
+			storageService.On("GetUsage", userIDs[1]).Return(httpStorage.UsageResponse{}, nil).Once()
+
+			expectedPaths := []string{"first/albums/album/old-image.jpg", "first/exports/export.zip"}
+			if !tt.dryRun && tt.env.OrphanedFilesCollectionMode == internal.DeleteOrphanedFilesCollectionMode {
+				storageService.On("DeleteFile", internal.FilePath(expectedPaths[0])).Return(nil).Once()
+				storageService.On("DeleteFile", internal.FilePath(expectedPaths[1])).
+					Return(wrapper.NotFoundError(errors.New("not found"))).
+					Once()
+			} else if !tt.dryRun {
+				storageService.On("QuarantineFiles", expectedPaths).Return(nil).Once()
+			}
+
+			// when
+			result, errCode := _uut.Handle(nil, tt.dryRun)
+
+			// then
+			assert.Nil(t, errCode)
+			assert.Equal(t, model.OrphanedFilesCollection{
+				DryRun: tt.dryRun,
+				Size:   7,
+				Files: []model.OrphanedFile{
+					{Path: expectedPaths[0], Size: 2, ModifiedAt: oldTime},
+					{Path: expectedPaths[1], Size: 5, ModifiedAt: oldTime},
+				},
+			}, result)
+
+			userRepository.AssertExpectations(t)
+			storageService.AssertExpectations(t)
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/domain/usecase/udata/band/member/role"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -19,7 +21,7 @@ import (
 func TestDeleteBandMemberRole_WhenGetUserIdFromJwtFails_ShouldReturnError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	_uut := role.NewDeleteBandMemberRole(nil, jwtService)
+	_uut := role.NewDeleteBandMemberRole(nil, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -41,7 +43,7 @@ func TestDeleteBandMemberRole_WhenGetBandMemberRolesFails_ShouldReturnInternalSe
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewDeleteBandMemberRole(userDataRepository, jwtService)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -70,7 +72,7 @@ func TestDeleteBandMemberRole_WhenRoleIsNotFound_ShouldReturnNotFoundError(t *te
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewDeleteBandMemberRole(userDataRepository, jwtService)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -97,11 +99,53 @@ func TestDeleteBandMemberRole_WhenRoleIsNotFound_ShouldReturnNotFoundError(t *te
 	userDataRepository.AssertExpectations(t)
 }
 
+func TestDeleteBandMemberRole_WhenGetAllIDsByBandMemberRoleFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, artistRepository, jwtService, nil, nil)
+
+	id := uuid.New()
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	bandMemberRoles := &[]model.BandMemberRole{
+		{ID: id},
+	}
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).
+		Return(nil, bandMemberRoles).
+		Once()
+
+	internalError := errors.New("internal error")
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+}
+
 func TestDeleteBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewDeleteBandMemberRole(userDataRepository, jwtService)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, artistRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -116,6 +160,14 @@ func TestDeleteBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnInte
 		Return(nil, bandMemberRoles).
 		Once()
 
+	artistIDs := &[]uuid.UUID{}
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), id).
+		Return(nil, artistIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	userDataRepository.On("UpdateAllBandMemberRoles", mock.IsType(bandMemberRoles)).
 		Return(internalError).
@@ -131,13 +183,20 @@ func TestDeleteBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnInte
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteBandMemberRole_WhenDeleteBandMemberRoleFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewDeleteBandMemberRole(userDataRepository, jwtService)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, artistRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -152,6 +211,14 @@ func TestDeleteBandMemberRole_WhenDeleteBandMemberRoleFails_ShouldReturnInternal
 		Return(nil, bandMemberRoles).
 		Once()
 
+	artistIDs := &[]uuid.UUID{}
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), id).
+		Return(nil, artistIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllBandMemberRoles", mock.IsType(bandMemberRoles)).
 		Return(nil).
 		Once()
@@ -171,13 +238,22 @@ func TestDeleteBandMemberRole_WhenDeleteBandMemberRoleFails_ShouldReturnInternal
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteBandMemberRole_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewDeleteBandMemberRole(userDataRepository, jwtService)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := role.NewDeleteBandMemberRole(userDataRepository, artistRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -192,6 +268,15 @@ func TestDeleteBandMemberRole_WhenSuccessful_ShouldReturnGuitarTunings(t *testin
 		Return(nil, bandMemberRoles).
 		Once()
 
+	artistIDs := &[]uuid.UUID{uuid.New()}
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), id).
+		Return(nil, artistIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllBandMemberRoles", mock.IsType(bandMemberRoles)).
 		Run(func(args mock.Arguments) {
 			newBandMemberRoles := args.Get(0).(*[]model.BandMemberRole)
@@ -209,6 +294,10 @@ func TestDeleteBandMemberRole_WhenSuccessful_ShouldReturnGuitarTunings(t *testin
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, (*artistIDs)[0]).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(id, token)
 
@@ -217,4 +306,8 @@ func TestDeleteBandMemberRole_WhenSuccessful_ShouldReturnGuitarTunings(t *testin
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
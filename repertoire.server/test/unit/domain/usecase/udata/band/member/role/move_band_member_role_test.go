@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"repertoire/server/api/requests"
 	"repertoire/server/domain/usecase/udata/band/member/role"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -21,7 +23,7 @@ import (
 func TestMoveBandMemberRole_WhenGetUserIdFromJwtFails_ShouldReturnError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	_uut := role.NewMoveBandMemberRole(nil, jwtService)
+	_uut := role.NewMoveBandMemberRole(nil, nil, jwtService, nil, nil)
 
 	request := requests.MoveBandMemberRoleRequest{
 		ID:     uuid.New(),
@@ -46,7 +48,7 @@ func TestMoveBandMemberRole_WhenGetBandMemberRolesFails_ShouldReturnInternalServ
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewMoveBandMemberRole(userDataRepository, jwtService)
+	_uut := role.NewMoveBandMemberRole(userDataRepository, nil, jwtService, nil, nil)
 
 	request := requests.MoveBandMemberRoleRequest{
 		ID:     uuid.New(),
@@ -78,7 +80,7 @@ func TestMoveBandMemberRole_WhenBandMemberRoleIsNotFound_ShouldReturnNotFoundErr
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewMoveBandMemberRole(userDataRepository, jwtService)
+	_uut := role.NewMoveBandMemberRole(userDataRepository, nil, jwtService, nil, nil)
 
 	request := requests.MoveBandMemberRoleRequest{
 		ID:     uuid.New(),
@@ -110,7 +112,7 @@ func TestMoveBandMemberRole_WhenOverBandMemberRoleIsNotFound_ShouldReturnNotFoun
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewMoveBandMemberRole(userDataRepository, jwtService)
+	_uut := role.NewMoveBandMemberRole(userDataRepository, nil, jwtService, nil, nil)
 
 	request := requests.MoveBandMemberRoleRequest{
 		ID:     uuid.New(),
@@ -140,11 +142,57 @@ func TestMoveBandMemberRole_WhenOverBandMemberRoleIsNotFound_ShouldReturnNotFoun
 	userDataRepository.AssertExpectations(t)
 }
 
+func TestMoveBandMemberRole_WhenGetAllIDsByBandMemberRoleFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	_uut := role.NewMoveBandMemberRole(userDataRepository, artistRepository, jwtService, nil, nil)
+
+	request := requests.MoveBandMemberRoleRequest{
+		ID:     uuid.New(),
+		OverID: uuid.New(),
+	}
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	expectedRoles := &[]model.BandMemberRole{
+		{ID: request.ID},
+		{ID: request.OverID},
+	}
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).
+		Return(nil, expectedRoles).
+		Once()
+
+	internalError := errors.New("internal error")
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), request.ID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+}
+
 func TestMoveBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := role.NewMoveBandMemberRole(userDataRepository, jwtService)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := role.NewMoveBandMemberRole(userDataRepository, artistRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	request := requests.MoveBandMemberRoleRequest{
 		ID:     uuid.New(),
@@ -163,6 +211,14 @@ func TestMoveBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnIntern
 		Return(nil, expectedRoles).
 		Once()
 
+	artistIDs := &[]uuid.UUID{}
+	artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), request.ID).
+		Return(nil, artistIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	userDataRepository.On("UpdateAllBandMemberRoles", mock.IsType(expectedRoles)).
 		Return(internalError).
@@ -178,6 +234,9 @@ func TestMoveBandMemberRole_WhenUpdateAllBandMemberRolesFails_ShouldReturnIntern
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestMoveBandMemberRole_WhenSuccessful_ShouldReturnBandMemberRoles(t *testing.T) {
@@ -218,7 +277,19 @@ func TestMoveBandMemberRole_WhenSuccessful_ShouldReturnBandMemberRoles(t *testin
 			// given
 			jwtService := new(service.JwtServiceMock)
 			userDataRepository := new(repository.UserDataRepositoryMock)
-			_uut := role.NewMoveBandMemberRole(userDataRepository, jwtService)
+			artistRepository := new(repository.ArtistRepositoryMock)
+			messagePublisherService := new(service.MessagePublisherServiceMock)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := role.NewMoveBandMemberRole(
+				userDataRepository,
+				artistRepository,
+				jwtService,
+				messagePublisherService,
+				transactionManager,
+			)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			request := requests.MoveBandMemberRoleRequest{
 				ID:     (*tt.role)[tt.index].ID,
@@ -234,6 +305,15 @@ func TestMoveBandMemberRole_WhenSuccessful_ShouldReturnBandMemberRoles(t *testin
 				Return(nil, tt.role).
 				Once()
 
+			artistIDs := &[]uuid.UUID{uuid.New()}
+			artistRepository.On("GetAllIDsByBandMemberRole", new([]uuid.UUID), request.ID).
+				Return(nil, artistIDs).
+				Once()
+
+			repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			userDataRepository.On("UpdateAllBandMemberRoles", mock.IsType(tt.role)).
 				Run(func(args mock.Arguments) {
 					newBandMemberRoles := args.Get(0).(*[]model.BandMemberRole)
@@ -253,6 +333,10 @@ func TestMoveBandMemberRole_WhenSuccessful_ShouldReturnBandMemberRoles(t *testin
 				Return(nil).
 				Once()
 
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistUpdatedTopic, (*artistIDs)[0]).
+				Return(nil).
+				Once()
+
 			// when
 			errCode := _uut.Handle(request, token)
 
@@ -261,6 +345,10 @@ func TestMoveBandMemberRole_WhenSuccessful_ShouldReturnBandMemberRoles(t *testin
 
 			jwtService.AssertExpectations(t)
 			userDataRepository.AssertExpectations(t)
+			artistRepository.AssertExpectations(t)
+			messagePublisherService.AssertExpectations(t)
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 		})
 	}
 }
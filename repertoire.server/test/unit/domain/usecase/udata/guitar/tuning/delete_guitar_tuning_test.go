@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/domain/usecase/udata/guitar/tuning"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -19,7 +21,7 @@ import (
 func TestDeleteGuitarTuning_WhenGetUserIdFromJwtFails_ShouldReturnError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	_uut := tuning.NewDeleteGuitarTuning(nil, jwtService)
+	_uut := tuning.NewDeleteGuitarTuning(nil, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -41,7 +43,7 @@ func TestDeleteGuitarTuning_WhenGetGuitarTuningsFails_ShouldReturnInternalServer
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, jwtService)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -70,7 +72,7 @@ func TestDeleteGuitarTuning_WhenGuitarTuningIsNotFound_ShouldReturnNotFoundError
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, jwtService)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -97,11 +99,53 @@ func TestDeleteGuitarTuning_WhenGuitarTuningIsNotFound_ShouldReturnNotFoundError
 	userDataRepository.AssertExpectations(t)
 }
 
+func TestDeleteGuitarTuning_WhenGetAllIDsByGuitarTuningFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, songRepository, jwtService, nil, nil)
+
+	id := uuid.New()
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	tunings := &[]model.GuitarTuning{
+		{ID: id},
+	}
+	userDataRepository.On("GetGuitarTunings", new([]model.GuitarTuning), userID).
+		Return(nil, tunings).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllIDsByGuitarTuning", new([]uuid.UUID), id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
 func TestDeleteGuitarTuning_WhenUpdateAllGuitarTuningsFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, songRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -116,6 +160,14 @@ func TestDeleteGuitarTuning_WhenUpdateAllGuitarTuningsFails_ShouldReturnInternal
 		Return(nil, tunings).
 		Once()
 
+	songIDs := &[]uuid.UUID{}
+	songRepository.On("GetAllIDsByGuitarTuning", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	userDataRepository.On("UpdateAllGuitarTunings", mock.IsType(tunings)).
 		Return(internalError).
@@ -131,13 +183,20 @@ func TestDeleteGuitarTuning_WhenUpdateAllGuitarTuningsFails_ShouldReturnInternal
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteGuitarTuning_WhenDeleteGuitarTuningFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, songRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -152,6 +211,14 @@ func TestDeleteGuitarTuning_WhenDeleteGuitarTuningFails_ShouldReturnInternalServ
 		Return(nil, tunings).
 		Once()
 
+	songIDs := &[]uuid.UUID{}
+	songRepository.On("GetAllIDsByGuitarTuning", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllGuitarTunings", mock.IsType(tunings)).
 		Return(nil).
 		Once()
@@ -171,13 +238,22 @@ func TestDeleteGuitarTuning_WhenDeleteGuitarTuningFails_ShouldReturnInternalServ
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteGuitarTuning_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := tuning.NewDeleteGuitarTuning(userDataRepository, songRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -192,6 +268,15 @@ func TestDeleteGuitarTuning_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.
 		Return(nil, tunings).
 		Once()
 
+	songIDs := &[]uuid.UUID{uuid.New()}
+	songRepository.On("GetAllIDsByGuitarTuning", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllGuitarTunings", mock.IsType(tunings)).
 		Run(func(args mock.Arguments) {
 			newGuitarTunings := args.Get(0).(*[]model.GuitarTuning)
@@ -209,6 +294,10 @@ func TestDeleteGuitarTuning_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, *songIDs).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(id, token)
 
@@ -217,4 +306,8 @@ func TestDeleteGuitarTuning_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/server/domain/usecase/udata/section/types"
+	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
 	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"slices"
@@ -19,7 +21,7 @@ import (
 func TestDeleteSongSectionType_WhenGetUserIdFromJwtFails_ShouldReturnError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
-	_uut := types.NewDeleteSongSectionType(nil, jwtService)
+	_uut := types.NewDeleteSongSectionType(nil, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -41,7 +43,7 @@ func TestDeleteSongSectionType_WhenGetSectionTypesFails_ShouldReturnInternalServ
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := types.NewDeleteSongSectionType(userDataRepository, jwtService)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -70,7 +72,7 @@ func TestDeleteSongSectionType_WhenTypeIsNotFound_ShouldReturnNotFoundError(t *t
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := types.NewDeleteSongSectionType(userDataRepository, jwtService)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, nil, jwtService, nil, nil)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -97,11 +99,53 @@ func TestDeleteSongSectionType_WhenTypeIsNotFound_ShouldReturnNotFoundError(t *t
 	userDataRepository.AssertExpectations(t)
 }
 
+func TestDeleteSongSectionType_WhenGetAllIDsBySectionTypeFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, songRepository, jwtService, nil, nil)
+
+	id := uuid.New()
+	token := "this is a token"
+
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	sectionTypes := &[]model.SongSectionType{
+		{ID: id},
+	}
+	userDataRepository.On("GetSectionTypes", new([]model.SongSectionType), userID).
+		Return(nil, sectionTypes).
+		Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllIDsBySectionType", new([]uuid.UUID), id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(id, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
 func TestDeleteSongSectionType_WhenUpdateAllSectionTypesFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := types.NewDeleteSongSectionType(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, songRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -116,6 +160,14 @@ func TestDeleteSongSectionType_WhenUpdateAllSectionTypesFails_ShouldReturnIntern
 		Return(nil, sectionTypes).
 		Once()
 
+	songIDs := &[]uuid.UUID{}
+	songRepository.On("GetAllIDsBySectionType", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	userDataRepository.On("UpdateAllSectionTypes", mock.IsType(sectionTypes)).
 		Return(internalError).
@@ -131,13 +183,20 @@ func TestDeleteSongSectionType_WhenUpdateAllSectionTypesFails_ShouldReturnIntern
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteSongSectionType_WhenDeleteSectionTypeFails_ShouldReturnInternalServerError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := types.NewDeleteSongSectionType(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, songRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -152,6 +211,14 @@ func TestDeleteSongSectionType_WhenDeleteSectionTypeFails_ShouldReturnInternalSe
 		Return(nil, sectionTypes).
 		Once()
 
+	songIDs := &[]uuid.UUID{}
+	songRepository.On("GetAllIDsBySectionType", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllSectionTypes", mock.IsType(sectionTypes)).
 		Return(nil).
 		Once()
@@ -171,13 +238,22 @@ func TestDeleteSongSectionType_WhenDeleteSectionTypeFails_ShouldReturnInternalSe
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
 
 func TestDeleteSongSectionType_WhenSuccessful_ShouldReturnGuitarTunings(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userDataRepository := new(repository.UserDataRepositoryMock)
-	_uut := types.NewDeleteSongSectionType(userDataRepository, jwtService)
+	songRepository := new(repository.SongRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := types.NewDeleteSongSectionType(userDataRepository, songRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	id := uuid.New()
 	token := "this is a token"
@@ -192,6 +268,15 @@ func TestDeleteSongSectionType_WhenSuccessful_ShouldReturnGuitarTunings(t *testi
 		Return(nil, sectionTypes).
 		Once()
 
+	songIDs := &[]uuid.UUID{uuid.New()}
+	songRepository.On("GetAllIDsBySectionType", new([]uuid.UUID), id).
+		Return(nil, songIDs).
+		Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userDataRepository.On("UpdateAllSectionTypes", mock.IsType(sectionTypes)).
 		Run(func(args mock.Arguments) {
 			newSectionTypes := args.Get(0).(*[]model.SongSectionType)
@@ -209,6 +294,10 @@ func TestDeleteSongSectionType_WhenSuccessful_ShouldReturnGuitarTunings(t *testi
 		Return(nil).
 		Once()
 
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongsUpdatedTopic, *songIDs).
+		Return(nil).
+		Once()
+
 	// when
 	errCode := _uut.Handle(id, token)
 
@@ -217,4 +306,8 @@ func TestDeleteSongSectionType_WhenSuccessful_ShouldReturnGuitarTunings(t *testi
 
 	jwtService.AssertExpectations(t)
 	userDataRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 }
@@ -6,18 +6,20 @@ import (
 	"repertoire/server/domain/usecase/user"
 	"repertoire/server/internal/message/topics"
 	"repertoire/server/internal/wrapper"
+	"repertoire/server/test/unit/data/database/transaction"
 	"repertoire/server/test/unit/data/repository"
 	"repertoire/server/test/unit/data/service"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDeleteUser_WhenGetUserIdFromJwtFails_ShouldReturnTheError(t *testing.T) {
 	// give
 	jwtService := new(service.JwtServiceMock)
-	_uut := user.NewDeleteUser(nil, jwtService, nil)
+	_uut := user.NewDeleteUser(nil, jwtService, nil, nil)
 
 	token := "This is a token"
 
@@ -38,13 +40,19 @@ func TestDeleteUser_WhenDeleteUserFails_ShouldReturnInternalServerError(t *testi
 	// give
 	jwtService := new(service.JwtServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
-	_uut := user.NewDeleteUser(userRepository, jwtService, nil)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewDeleteUser(userRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
 
 	token := "This is a token"
 
 	id := uuid.New()
 	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
 
+	repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	internalError := errors.New("internal error")
 	userRepository.On("Delete", id).Return(internalError).Once()
 
@@ -56,6 +64,8 @@ func TestDeleteUser_WhenDeleteUserFails_ShouldReturnInternalServerError(t *testi
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
 }
@@ -65,17 +75,25 @@ func TestDeleteUser_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	jwtService := new(service.JwtServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
 	messagePublisherService := new(service.MessagePublisherServiceMock)
-	_uut := user.NewDeleteUser(userRepository, jwtService, messagePublisherService)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewDeleteUser(userRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
 
 	token := "This is a token"
 
 	id := uuid.New()
 	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
 
+	repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 	userRepository.On("Delete", id).Return(nil).Once()
 
 	internalError := errors.New("internal error")
-	messagePublisherService.On("Publish", topics.UserDeletedTopic, id).
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.UserDeletedTopic, id).
 		Return(internalError).
 		Once()
 
@@ -87,6 +105,8 @@ func TestDeleteUser_WhenPublishFails_ShouldReturnInternalServerError(t *testing.
 	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
 	assert.Equal(t, internalError, errCode.Error)
 
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
 	jwtService.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
 	messagePublisherService.AssertExpectations(t)
@@ -113,16 +133,24 @@ func TestDeleteUser_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			jwtService := new(service.JwtServiceMock)
 			userRepository := new(repository.UserRepositoryMock)
 			messagePublisherService := new(service.MessagePublisherServiceMock)
-			_uut := user.NewDeleteUser(userRepository, jwtService, messagePublisherService)
+			transactionManager := new(transaction.ManagerMock)
+			_uut := user.NewDeleteUser(userRepository, jwtService, messagePublisherService, transactionManager)
+
+			repositoryFactory := new(transaction.RepositoryFactoryMock)
+			outboxRepository := new(repository.OutboxRepositoryMock)
 
 			token := "This is a token"
 
 			id := uuid.New()
 			jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
 
+			repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+			repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+			transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
 			userRepository.On("Delete", id).Return(nil).Once()
 
-			messagePublisherService.On("Publish", topics.UserDeletedTopic, id).
+			messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.UserDeletedTopic, id).
 				Return(nil).
 				Once()
 
@@ -132,6 +160,8 @@ func TestDeleteUser_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
 			// then
 			assert.Nil(t, errCode)
 
+			transactionManager.AssertExpectations(t)
+			repositoryFactory.AssertExpectations(t)
 			jwtService.AssertExpectations(t)
 			userRepository.AssertExpectations(t)
 			messagePublisherService.AssertExpectations(t)
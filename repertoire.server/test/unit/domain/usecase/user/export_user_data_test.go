@@ -0,0 +1,271 @@
+package user
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"repertoire/server/domain/usecase/user"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportUserData_WhenGetUserIdFromJwtFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewExportUserData(nil, nil, jwtService, nil, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, nil)
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenCountSongsFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewExportUserData(nil, songRepository, jwtService, nil, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	internalError := errors.New("internal error")
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(internalError).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, nil)
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := user.NewExportUserData(nil, songRepository, jwtService, messagePublisherService, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	count := &[]int64{1000}[0]
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, count).Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("Publish", topics.UserDataExportRequestedTopic, id).
+		Return(internalError).
+		Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, nil)
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenLibraryIsLarge_ShouldQueueTheExport(t *testing.T) {
+	// given
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := user.NewExportUserData(nil, songRepository, jwtService, messagePublisherService, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	count := &[]int64{1000}[0]
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, count).Once()
+
+	messagePublisherService.On("Publish", topics.UserDataExportRequestedTopic, id).Return(nil).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, func() io.Writer {
+		assert.Fail(t, "the writer should not be requested when the export is queued")
+		return nil
+	})
+
+	// then
+	assert.True(t, isQueued)
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewExportUserData(userRepository, songRepository, jwtService, nil, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, &[]int64{10}[0]).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetWithAllData", new(model.User), id).Return(internalError).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, nil)
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenUserIsEmpty_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewExportUserData(userRepository, songRepository, jwtService, nil, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, &[]int64{10}[0]).Once()
+	userRepository.On("GetWithAllData", new(model.User), id).Return(nil).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, nil)
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "user not found", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenExportFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	userDataExportProcessor := new(processor.UserDataExportProcessorMock)
+	_uut := user.NewExportUserData(userRepository, songRepository, jwtService, nil, userDataExportProcessor)
+
+	token := "This is a token"
+	writer := new(bytes.Buffer)
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, &[]int64{10}[0]).Once()
+
+	mockUser := &model.User{ID: id}
+	userRepository.On("GetWithAllData", new(model.User), id).Return(nil, mockUser).Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	userDataExportProcessor.On("Export", writer, *mockUser).Return(internalError).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, func() io.Writer { return writer })
+
+	// then
+	assert.False(t, isQueued)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, internalError, errCode)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userDataExportProcessor.AssertExpectations(t)
+}
+
+func TestExportUserData_WhenSuccessful_ShouldStreamTheExport(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	jwtService := new(service.JwtServiceMock)
+	userDataExportProcessor := new(processor.UserDataExportProcessorMock)
+	_uut := user.NewExportUserData(userRepository, songRepository, jwtService, nil, userDataExportProcessor)
+
+	token := "This is a token"
+	writer := new(bytes.Buffer)
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	songRepository.On("GetAllByUserCount", new(int64), id, []string{}).Return(nil, &[]int64{10}[0]).Once()
+
+	mockUser := &model.User{ID: id}
+	userRepository.On("GetWithAllData", new(model.User), id).Return(nil, mockUser).Once()
+
+	userDataExportProcessor.On("Export", writer, *mockUser).Return(nil).Once()
+
+	// when
+	isQueued, errCode := _uut.Handle(token, func() io.Writer { return writer })
+
+	// then
+	assert.False(t, isQueued)
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userDataExportProcessor.AssertExpectations(t)
+}
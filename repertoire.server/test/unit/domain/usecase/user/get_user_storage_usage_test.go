@@ -0,0 +1,117 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/data/http/storage"
+	"repertoire/server/domain/usecase/user"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/provider"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserStorageUsage_WhenGetUserIdFromJwtFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewGetUserStorageUsage(jwtService, nil, nil)
+
+	token := "this is a token"
+
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	usage, errCode := _uut.Handle(token)
+
+	// then
+	assert.Empty(t, usage)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestGetUserStorageUsage_WhenStorageGetUsageFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	storageService := new(service.StorageServiceMock)
+	_uut := user.NewGetUserStorageUsage(jwtService, storageService, nil)
+
+	token := "this is a token"
+
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
+	storageService.On("GetUsage", id).Return(storage.UsageResponse{}, internalError).Once()
+
+	// when
+	usage, errCode := _uut.Handle(token)
+
+	// then
+	assert.Empty(t, usage)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode)
+
+	jwtService.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+}
+
+func TestGetUserStorageUsage_WhenSuccessful_ShouldReturnTheUsageBrokenDownByCategory(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	storageService := new(service.StorageServiceMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	_uut := user.NewGetUserStorageUsage(jwtService, storageService, storageFilePathProvider)
+
+	token := "this is a token"
+
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	files := []struct {
+		storage.FileUsageResponse
+		category enums.StorageUsageCategory
+	}{
+		{storage.FileUsageResponse{Path: "album.jpg", Size: 1}, enums.AlbumStorageUsage},
+		{storage.FileUsageResponse{Path: "album_64.jpg", Size: 2}, enums.AlbumStorageUsage},
+		{storage.FileUsageResponse{Path: "artist.jpg", Size: 4}, enums.ArtistStorageUsage},
+		{storage.FileUsageResponse{Path: "member.jpg", Size: 8}, enums.BandMemberStorageUsage},
+		{storage.FileUsageResponse{Path: "playlist.jpg", Size: 16}, enums.PlaylistStorageUsage},
+		{storage.FileUsageResponse{Path: "song.jpg", Size: 32}, enums.SongStorageUsage},
+		{storage.FileUsageResponse{Path: "profile.jpg", Size: 64}, enums.OtherStorageUsage},
+	}
+	usageResponse := storage.UsageResponse{Size: 127, Quota: 1000}
+	for _, file := range files {
+		usageResponse.Files = append(usageResponse.Files, file.FileUsageResponse)
+		storageFilePathProvider.On("GetStorageUsageCategory", file.Path).Return(file.category).Once()
+	}
+	storageService.On("GetUsage", id).Return(usageResponse, nil).Once()
+
+	// when
+	usage, errCode := _uut.Handle(token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, model.StorageUsage{
+		Size:        127,
+		Quota:       1000,
+		Albums:      3,
+		Artists:     4,
+		BandMembers: 8,
+		Playlists:   16,
+		Songs:       32,
+		Others:      64,
+	}, usage)
+
+	jwtService.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+}
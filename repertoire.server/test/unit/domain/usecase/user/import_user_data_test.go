@@ -0,0 +1,660 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"repertoire/server/domain/usecase/user"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/provider"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportUserData_WhenGetUserIdFromJwtFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewImportUserData(jwtService, nil, nil, nil, nil)
+
+	token := "This is a token"
+
+	// given - mocking
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	errCode := _uut.Handle(new(multipart.FileHeader), token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenFileIsNotAnArchive_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewImportUserData(jwtService, nil, nil, nil, nil)
+
+	token := "This is a token"
+	file := newMultipartFile(t, []byte("not a zip"))
+
+	// given - mocking
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "the archive is not valid", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenArchiveIsNotAnExport_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewImportUserData(jwtService, nil, nil, nil, nil)
+
+	token := "This is a token"
+	file := newArchiveFile(t, map[string]any{model.ExportedSongsFile: []model.ExportedSong{}}, nil)
+
+	// given - mocking
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.New(), nil).Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, "the archive is not a repertoire export", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenGetBandMemberRolesFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewImportUserData(jwtService, nil, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+
+	token := "This is a token"
+	file := newArchiveFile(t, map[string]any{model.ExportedUserFile: model.ExportedUser{}}, nil)
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	internalError := errors.New("internal error")
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenImageIsTooLarge_ShouldReturnBadRequestError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewImportUserData(jwtService, nil, nil, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+
+	token := "This is a token"
+	image := "images/artists/some-artist/image.png"
+	exportedArtist := model.ExportedArtist{ID: uuid.New(), Name: "Artist", Image: &image}
+	file := newArchiveFile(t, map[string]any{
+		model.ExportedUserFile:    model.ExportedUser{},
+		model.ExportedArtistsFile: []model.ExportedArtist{exportedArtist},
+	}, map[string][]byte{image: make([]byte, 10<<20+1)})
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewArtistRepository").Return(new(repository.ArtistRepositoryMock)).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).Return(nil).Once()
+	userDataRepository.On("GetGuitarTunings", new([]model.GuitarTuning), userID).Return(nil).Once()
+	userDataRepository.On("GetInstruments", new([]model.Instrument), userID).Return(nil).Once()
+	userDataRepository.On("GetSectionTypes", new([]model.SongSectionType), userID).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusBadRequest, errCode.Code)
+	assert.Equal(t, image+" is too large", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenSuccessful_ShouldRecreateTheDataWithNewIDs(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	storageService := new(service.StorageServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewImportUserData(
+		jwtService,
+		storageFilePathProvider,
+		storageService,
+		messagePublisherService,
+		transactionManager,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	albumRepository := new(repository.AlbumRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	practiceSessionRepository := new(repository.PracticeSessionRepositoryMock)
+	setlistRepository := new(repository.SetlistRepositoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	token := "This is a token"
+
+	exportedRole := model.ExportedUserDataItem{ID: uuid.New(), Name: "Vocalist"}
+	exportedTuning := model.ExportedUserDataItem{ID: uuid.New(), Name: "E Standard"}
+	exportedInstrument := model.ExportedUserDataItem{ID: uuid.New(), Name: "Theremin"}
+	exportedSectionType := model.ExportedUserDataItem{ID: uuid.New(), Name: "Chorus"}
+	exportedMember := model.ExportedBandMember{ID: uuid.New(), Name: "Member", RoleIDs: []uuid.UUID{exportedRole.ID}}
+	exportedArtist := model.ExportedArtist{
+		ID:          uuid.New(),
+		Name:        "Artist",
+		IsBand:      true,
+		BandMembers: []model.ExportedBandMember{exportedMember},
+	}
+	exportedAlbum := model.ExportedAlbum{ID: uuid.New(), Title: "Album", ArtistID: &exportedArtist.ID}
+	songImage := "images/songs/some-song/image.png"
+	exportedSong := model.ExportedSong{
+		ID:             uuid.New(),
+		Title:          "Song",
+		Image:          &songImage,
+		AlbumID:        &exportedAlbum.ID,
+		AlbumTrackNo:   &[]uint{1}[0],
+		ArtistID:       &exportedArtist.ID,
+		GuitarTuningID: &exportedTuning.ID,
+		Settings: model.ExportedSongSettings{
+			DefaultInstrumentID: &exportedInstrument.ID,
+			DefaultBandMemberID: &exportedMember.ID,
+		},
+		Sections: []model.ExportedSongSection{
+			{
+				ID:                uuid.New(),
+				Name:              "Chorus 1",
+				SongSectionTypeID: exportedSectionType.ID,
+				InstrumentID:      &exportedInstrument.ID,
+				BandMemberID:      &exportedMember.ID,
+				History: []model.ExportedSongSectionHistory{
+					{Property: model.RehearsalsProperty, From: 0, To: 1},
+				},
+			},
+		},
+	}
+	exportedPlaylist := model.ExportedPlaylist{
+		ID:      uuid.New(),
+		Title:   "Playlist",
+		SongIDs: []uuid.UUID{exportedSong.ID, uuid.New()},
+	}
+	exportedSession := model.ExportedPracticeSession{
+		ID: uuid.New(),
+		Songs: []model.ExportedPracticeSessionSong{
+			{
+				SongID: exportedSong.ID,
+				Sections: []model.ExportedPracticeSessionSection{
+					{SongSectionID: exportedSong.Sections[0].ID, Occurrences: 2},
+				},
+			},
+			{SongID: uuid.New()},
+		},
+	}
+	exportedSetlist := model.ExportedSetlist{
+		ID:    uuid.New(),
+		Title: "Setlist",
+		Entries: []model.ExportedSetlistEntry{
+			{Type: enums.SongEntry, SongID: &[]uuid.UUID{uuid.New()}[0], EntryNo: 1},
+			{Type: enums.TalkEntry, Title: "Intro", EntryNo: 2},
+			{Type: enums.SongEntry, SongID: &exportedSong.ID, EntryNo: 3},
+		},
+	}
+	imageContent := []byte("image")
+
+	file := newArchiveFile(t, map[string]any{
+		model.ExportedUserFile:             model.ExportedUser{},
+		model.ExportedArtistsFile:          []model.ExportedArtist{exportedArtist},
+		model.ExportedAlbumsFile:           []model.ExportedAlbum{exportedAlbum},
+		model.ExportedSongsFile:            []model.ExportedSong{exportedSong},
+		model.ExportedPlaylistsFile:        []model.ExportedPlaylist{exportedPlaylist},
+		model.ExportedPracticeSessionsFile: []model.ExportedPracticeSession{exportedSession},
+		model.ExportedSetlistsFile:         []model.ExportedSetlist{exportedSetlist},
+		model.ExportedBandMemberRolesFile:  []model.ExportedUserDataItem{exportedRole},
+		model.ExportedGuitarTuningsFile:    []model.ExportedUserDataItem{exportedTuning},
+		model.ExportedInstrumentsFile:      []model.ExportedUserDataItem{exportedInstrument},
+		model.ExportedSectionTypesFile:     []model.ExportedUserDataItem{exportedSectionType},
+	}, map[string][]byte{songImage: imageContent})
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(practiceSessionRepository).Once()
+	repositoryFactory.On("NewSetlistRepository").Return(setlistRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	// the user already has the tuning and the section type, with a different casing
+	existingTuning := model.GuitarTuning{ID: uuid.New(), Name: "E Standard"}
+	existingSectionType := model.SongSectionType{ID: uuid.New(), Name: "chorus"}
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).
+		Return(nil, &[]model.BandMemberRole{}).
+		Once()
+	userDataRepository.On("GetGuitarTunings", new([]model.GuitarTuning), userID).
+		Return(nil, &[]model.GuitarTuning{existingTuning}).
+		Once()
+	userDataRepository.On("GetInstruments", new([]model.Instrument), userID).
+		Return(nil, &[]model.Instrument{{ID: uuid.New(), Name: "Piano"}}).
+		Once()
+	userDataRepository.On("GetSectionTypes", new([]model.SongSectionType), userID).
+		Return(nil, &[]model.SongSectionType{existingSectionType}).
+		Once()
+
+	var newRole model.BandMemberRole
+	userDataRepository.On("CreateBandMemberRole", mock.IsType(new(model.BandMemberRole))).
+		Run(func(args mock.Arguments) {
+			newRole = *args.Get(0).(*model.BandMemberRole)
+			assert.NotEqual(t, exportedRole.ID, newRole.ID)
+			assert.Equal(t, exportedRole.Name, newRole.Name)
+			assert.Equal(t, uint(0), newRole.Order)
+			assert.Equal(t, userID, newRole.UserID)
+		}).
+		Return(nil).
+		Once()
+	var newInstrument model.Instrument
+	userDataRepository.On("CreateInstrument", mock.IsType(new(model.Instrument))).
+		Run(func(args mock.Arguments) {
+			newInstrument = *args.Get(0).(*model.Instrument)
+			assert.Equal(t, exportedInstrument.Name, newInstrument.Name)
+			assert.Equal(t, uint(1), newInstrument.Order)
+			assert.Equal(t, userID, newInstrument.UserID)
+		}).
+		Return(nil).
+		Once()
+
+	var newArtist model.Artist
+	artistRepository.On("Create", mock.IsType(new(model.Artist))).
+		Run(func(args mock.Arguments) {
+			newArtist = *args.Get(0).(*model.Artist)
+			assert.NotEqual(t, exportedArtist.ID, newArtist.ID)
+			assert.Equal(t, exportedArtist.Name, newArtist.Name)
+			assert.Equal(t, userID, newArtist.UserID)
+			assert.Len(t, newArtist.BandMembers, 1)
+			assert.NotEqual(t, exportedMember.ID, newArtist.BandMembers[0].ID)
+			assert.Equal(t, newArtist.ID, newArtist.BandMembers[0].ArtistID)
+			assert.Equal(t, []model.BandMemberRole{newRole}, newArtist.BandMembers[0].Roles)
+		}).
+		Return(nil).
+		Once()
+
+	var newAlbum model.Album
+	albumRepository.On("Create", mock.IsType(new(model.Album))).
+		Run(func(args mock.Arguments) {
+			newAlbum = *args.Get(0).(*model.Album)
+			assert.NotEqual(t, exportedAlbum.ID, newAlbum.ID)
+			assert.Equal(t, &newArtist.ID, newAlbum.ArtistID)
+			assert.Equal(t, userID, newAlbum.UserID)
+		}).
+		Return(nil).
+		Once()
+
+	imagePath := userID.String() + "/songs/image.png"
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(model.Song{})).
+		Return(imagePath).
+		Once()
+	var isCommitted bool
+	storageService.On("UploadFile", "image.png", imageContent, imagePath).
+		Run(func(args mock.Arguments) {
+			assert.True(t, isCommitted)
+		}).
+		Return(nil).
+		Once()
+
+	var newSong model.Song
+	songRepository.On("Create", mock.IsType(new(model.Song))).
+		Run(func(args mock.Arguments) {
+			newSong = *args.Get(0).(*model.Song)
+			assert.NotEqual(t, exportedSong.ID, newSong.ID)
+			assert.Equal(t, &newAlbum.ID, newSong.AlbumID)
+			assert.Equal(t, exportedSong.AlbumTrackNo, newSong.AlbumTrackNo)
+			assert.Equal(t, &newArtist.ID, newSong.ArtistID)
+			assert.Equal(t, &existingTuning.ID, newSong.GuitarTuningID)
+			assert.Equal(t, imagePath, string(*newSong.ImageURL))
+			assert.Equal(t, &newInstrument.ID, newSong.Settings.DefaultInstrumentID)
+			assert.Equal(t, &newArtist.BandMembers[0].ID, newSong.Settings.DefaultBandMemberID)
+			assert.Equal(t, userID, newSong.UserID)
+
+			assert.Len(t, newSong.Sections, 1)
+			section := newSong.Sections[0]
+			assert.NotEqual(t, exportedSong.Sections[0].ID, section.ID)
+			assert.Equal(t, newSong.ID, section.SongID)
+			assert.Equal(t, existingSectionType.ID, section.SongSectionTypeID)
+			assert.Equal(t, &newInstrument.ID, section.InstrumentID)
+			assert.Equal(t, &newArtist.BandMembers[0].ID, section.BandMemberID)
+			assert.Len(t, section.History, 1)
+			assert.Equal(t, section.ID, section.History[0].SongSectionID)
+		}).
+		Return(nil).
+		Once()
+
+	var newPlaylist model.Playlist
+	playlistRepository.On("Create", mock.IsType(new(model.Playlist))).
+		Run(func(args mock.Arguments) {
+			newPlaylist = *args.Get(0).(*model.Playlist)
+			assert.NotEqual(t, exportedPlaylist.ID, newPlaylist.ID)
+			assert.Equal(t, userID, newPlaylist.UserID)
+			// the song that is not part of the archive is skipped
+			assert.Len(t, newPlaylist.PlaylistSongs, 1)
+			assert.Equal(t, newSong.ID, newPlaylist.PlaylistSongs[0].SongID)
+			assert.Equal(t, uint(1), newPlaylist.PlaylistSongs[0].SongTrackNo)
+		}).
+		Return(nil).
+		Once()
+
+	practiceSessionRepository.On("Create", mock.IsType(new(model.PracticeSession))).
+		Run(func(args mock.Arguments) {
+			newSession := *args.Get(0).(*model.PracticeSession)
+			assert.NotEqual(t, exportedSession.ID, newSession.ID)
+			assert.Equal(t, userID, newSession.UserID)
+			// the song that is not part of the archive is skipped
+			assert.Len(t, newSession.Songs, 1)
+			assert.Equal(t, newSession.ID, newSession.Songs[0].PracticeSessionID)
+			assert.Equal(t, newSong.ID, newSession.Songs[0].SongID)
+			assert.Len(t, newSession.Songs[0].Sections, 1)
+			assert.Equal(t, newSong.Sections[0].ID, newSession.Songs[0].Sections[0].SongSectionID)
+			assert.Equal(t, uint(2), newSession.Songs[0].Sections[0].Occurrences)
+		}).
+		Return(nil).
+		Once()
+
+	setlistRepository.On("Create", mock.IsType(new(model.Setlist))).
+		Run(func(args mock.Arguments) {
+			newSetlist := *args.Get(0).(*model.Setlist)
+			assert.NotEqual(t, exportedSetlist.ID, newSetlist.ID)
+			assert.Equal(t, userID, newSetlist.UserID)
+			// the song entry that is not part of the archive is skipped, and the rest are renumbered
+			assert.Len(t, newSetlist.Entries, 2)
+			assert.Equal(t, enums.TalkEntry, newSetlist.Entries[0].Type)
+			assert.Nil(t, newSetlist.Entries[0].SongID)
+			assert.Equal(t, uint(1), newSetlist.Entries[0].EntryNo)
+			assert.Equal(t, &newSong.ID, newSetlist.Entries[1].SongID)
+			assert.Equal(t, uint(2), newSetlist.Entries[1].EntryNo)
+			assert.Equal(t, newSetlist.ID, newSetlist.Entries[1].SetlistID)
+		}).
+		Return(nil).
+		Once()
+
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistCreatedTopic, mock.IsType(model.Artist{})).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongCreatedTopic, mock.IsType(model.Song{})).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.PlaylistCreatedTopic, mock.IsType(model.Playlist{})).
+		Run(func(args mock.Arguments) {
+			isCommitted = true
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	albumRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	playlistRepository.AssertExpectations(t)
+	practiceSessionRepository.AssertExpectations(t)
+	setlistRepository.AssertExpectations(t)
+}
+
+func TestImportUserData_WhenAnImageUploadFails_ShouldClearTheImageOfItsEntityAndKeepTheImport(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	storageFilePathProvider := new(provider.StorageFilePathProviderMock)
+	storageService := new(service.StorageServiceMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewImportUserData(
+		jwtService,
+		storageFilePathProvider,
+		storageService,
+		messagePublisherService,
+		transactionManager,
+	)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	userDataRepository := new(repository.UserDataRepositoryMock)
+	artistRepository := new(repository.ArtistRepositoryMock)
+	albumRepository := new(repository.AlbumRepositoryMock)
+	songRepository := new(repository.SongRepositoryMock)
+	playlistRepository := new(repository.PlaylistRepositoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	clearRepositoryFactory := new(transaction.RepositoryFactoryMock)
+	clearArtistRepository := new(repository.ArtistRepositoryMock)
+	clearAlbumRepository := new(repository.AlbumRepositoryMock)
+	clearOutboxRepository := new(repository.OutboxRepositoryMock)
+
+	token := "This is a token"
+
+	artistImage := "images/artists/some-artist/artist.png"
+	albumImage := "images/albums/some-album/album.png"
+	songImage := "images/songs/some-song/song.png"
+	exportedArtist := model.ExportedArtist{ID: uuid.New(), Name: "Artist", Image: &artistImage}
+	exportedAlbum := model.ExportedAlbum{ID: uuid.New(), Title: "Album", Image: &albumImage}
+	exportedSong := model.ExportedSong{ID: uuid.New(), Title: "Song", Image: &songImage}
+	file := newArchiveFile(t, map[string]any{
+		model.ExportedUserFile:    model.ExportedUser{},
+		model.ExportedArtistsFile: []model.ExportedArtist{exportedArtist},
+		model.ExportedAlbumsFile:  []model.ExportedAlbum{exportedAlbum},
+		model.ExportedSongsFile:   []model.ExportedSong{exportedSong},
+	}, map[string][]byte{
+		artistImage: []byte("artist"),
+		albumImage:  []byte("album"),
+		songImage:   []byte("song"),
+	})
+
+	// given - mocking
+	userID := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(userID, nil).Once()
+
+	repositoryFactory.On("NewUserDataRepository").Return(userDataRepository).Once()
+	repositoryFactory.On("NewArtistRepository").Return(artistRepository).Once()
+	repositoryFactory.On("NewAlbumRepository").Return(albumRepository).Once()
+	repositoryFactory.On("NewSongRepository").Return(songRepository).Once()
+	repositoryFactory.On("NewPlaylistRepository").Return(playlistRepository).Once()
+	repositoryFactory.On("NewPracticeSessionRepository").Return(new(repository.PracticeSessionRepositoryMock)).Once()
+	repositoryFactory.On("NewSetlistRepository").Return(new(repository.SetlistRepositoryMock)).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	userDataRepository.On("GetBandMemberRoles", new([]model.BandMemberRole), userID).Return(nil).Once()
+	userDataRepository.On("GetGuitarTunings", new([]model.GuitarTuning), userID).Return(nil).Once()
+	userDataRepository.On("GetInstruments", new([]model.Instrument), userID).Return(nil).Once()
+	userDataRepository.On("GetSectionTypes", new([]model.SongSectionType), userID).Return(nil).Once()
+
+	artistImagePath := userID.String() + "/artists/artist.png"
+	albumImagePath := userID.String() + "/albums/album.png"
+	songImagePath := userID.String() + "/songs/song.png"
+	storageFilePathProvider.On("GetArtistImagePath", mock.IsType(model.Artist{})).Return(artistImagePath).Once()
+	storageFilePathProvider.On("GetAlbumImagePath", mock.IsType(model.Album{})).Return(albumImagePath).Once()
+	storageFilePathProvider.On("GetSongImagePath", mock.IsType(model.Song{})).Return(songImagePath).Once()
+
+	artistRepository.On("Create", mock.IsType(new(model.Artist))).Return(nil).Once()
+	var newAlbum model.Album
+	albumRepository.On("Create", mock.IsType(new(model.Album))).
+		Run(func(args mock.Arguments) {
+			newAlbum = *args.Get(0).(*model.Album)
+		}).
+		Return(nil).
+		Once()
+	songRepository.On("Create", mock.IsType(new(model.Song))).Return(nil).Once()
+
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.ArtistCreatedTopic, mock.IsType(model.Artist{})).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.AlbumCreatedTopic, mock.IsType(model.Album{})).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.SongCreatedTopic, mock.IsType(model.Song{})).
+		Return(nil).
+		Once()
+
+	// the upload of the album image fails, between the ones of the artist and the song
+	storageService.On("UploadFile", "artist.png", []byte("artist"), artistImagePath).Return(nil).Once()
+	storageService.On("UploadFile", "album.png", []byte("album"), albumImagePath).
+		Return(wrapper.InternalServerError(errors.New("internal error"))).
+		Once()
+	storageService.On("UploadFile", "song.png", []byte("song"), songImagePath).Return(nil).Once()
+
+	clearRepositoryFactory.On("NewOutboxRepository").Return(clearOutboxRepository).Once()
+	clearRepositoryFactory.On("NewArtistRepository").Return(clearArtistRepository).Once()
+	clearRepositoryFactory.On("NewAlbumRepository").Return(clearAlbumRepository).Once()
+	clearRepositoryFactory.On("NewSongRepository").Return(new(repository.SongRepositoryMock)).Once()
+	clearRepositoryFactory.On("NewPlaylistRepository").Return(new(repository.PlaylistRepositoryMock)).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, clearRepositoryFactory).Once()
+
+	clearAlbumRepository.On("Get", new(model.Album), mock.IsType(uuid.UUID{})).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, newAlbum.ID, args.Get(1))
+			*args.Get(0).(*model.Album) = newAlbum
+		}).
+		Return(nil).
+		Once()
+	clearAlbumRepository.On("Update", mock.IsType(new(model.Album))).
+		Run(func(args mock.Arguments) {
+			album := args.Get(0).(*model.Album)
+			assert.Equal(t, newAlbum.ID, album.ID)
+			assert.Nil(t, album.ImageURL)
+		}).
+		Return(nil).
+		Once()
+	messagePublisherService.On("PublishWithinTransaction", clearOutboxRepository, topics.AlbumsUpdatedTopic, mock.IsType([]uuid.UUID{})).
+		Run(func(args mock.Arguments) {
+			assert.Equal(t, []uuid.UUID{newAlbum.ID}, args.Get(2))
+		}).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(file, token)
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, albumImagePath, string(*newAlbum.ImageURL))
+
+	jwtService.AssertExpectations(t)
+	storageFilePathProvider.AssertExpectations(t)
+	storageService.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	userDataRepository.AssertExpectations(t)
+	artistRepository.AssertExpectations(t)
+	albumRepository.AssertExpectations(t)
+	songRepository.AssertExpectations(t)
+	clearRepositoryFactory.AssertExpectations(t)
+	clearArtistRepository.AssertExpectations(t)
+	clearAlbumRepository.AssertExpectations(t)
+}
+
+func newArchiveFile(t *testing.T, files map[string]any, images map[string][]byte) *multipart.FileHeader {
+	archive := new(bytes.Buffer)
+	writer := zip.NewWriter(archive)
+	for name, content := range files {
+		f, _ := writer.Create(name)
+		_ = json.NewEncoder(f).Encode(content)
+	}
+	for name, content := range images {
+		f, _ := writer.Create(name)
+		_, _ = f.Write(content)
+	}
+	_ = writer.Close()
+
+	return newMultipartFile(t, archive.Bytes())
+}
+
+func newMultipartFile(t *testing.T, content []byte) *multipart.FileHeader {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("archive", "export.zip")
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	// the archive is stored on the disk, like the large uploads are
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(0)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+	return form.File["archive"][0]
+}
@@ -147,7 +147,7 @@ func TestSaveProfilePictureToUser_WhenStorageDeleteFileFails_ShouldReturnError(t
 	storageService.AssertExpectations(t)
 }
 
-func TestSaveProfilePictureToUser_WhenStorageUploadFails_ShouldReturnInternalServerError(t *testing.T) {
+func TestSaveProfilePictureToUser_WhenStorageUploadFails_ShouldReturnError(t *testing.T) {
 	// given
 	jwtService := new(service.JwtServiceMock)
 	userRepository := new(repository.UserRepositoryMock)
@@ -171,11 +171,11 @@ func TestSaveProfilePictureToUser_WhenStorageUploadFails_ShouldReturnInternalSer
 	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
 
 	imagePath := "users file path"
-	storageFilePathProvider.On("GetUserProfilePicturePath", file, mock.IsType(*mockUser)).
+	storageFilePathProvider.On("GetUserProfilePicturePath", mock.IsType(*mockUser)).
 		Return(imagePath).
 		Once()
 
-	internalError := errors.New("internal error")
+	internalError := wrapper.InternalServerError(errors.New("internal error"))
 	storageService.On("Upload", file, imagePath).Return(internalError).Once()
 
 	// when
@@ -183,8 +183,7 @@ func TestSaveProfilePictureToUser_WhenStorageUploadFails_ShouldReturnInternalSer
 
 	// then
 	assert.NotNil(t, errCode)
-	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
-	assert.Equal(t, internalError, errCode.Error)
+	assert.Equal(t, internalError, errCode)
 
 	jwtService.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
@@ -216,7 +215,7 @@ func TestSaveProfilePictureToUser_WhenUpdateUserFails_ShouldReturnInternalServer
 	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
 
 	imagePath := "users file path"
-	storageFilePathProvider.On("GetUserProfilePicturePath", file, mock.IsType(*mockUser)).
+	storageFilePathProvider.On("GetUserProfilePicturePath", mock.IsType(*mockUser)).
 		Return(imagePath).
 		Once()
 
@@ -265,9 +264,9 @@ func TestSaveProfilePictureToUser_WhenWithoutOldProfilePicture_ShouldSaveNewOneA
 	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
 
 	imagePath := "users file path"
-	storageFilePathProvider.On("GetUserProfilePicturePath", file, mock.IsType(*mockUser)).
+	storageFilePathProvider.On("GetUserProfilePicturePath", mock.IsType(*mockUser)).
 		Run(func(args mock.Arguments) {
-			newUser := args.Get(1).(model.User)
+			newUser := args.Get(0).(model.User)
 			assert.Equal(t, newUser.ID, id)
 			assert.WithinDuration(t, newUser.UpdatedAt, time.Now().UTC(), time.Minute)
 		}).
@@ -322,9 +321,9 @@ func TestSaveProfilePictureToUser_WhenWithOldProfilePicture_ShouldDeleteOldPictu
 	storageService.On("DeleteFile", *mockUser.ProfilePictureURL).Return(nil).Once()
 
 	imagePath := "users file path"
-	storageFilePathProvider.On("GetUserProfilePicturePath", file, mock.IsType(*mockUser)).
+	storageFilePathProvider.On("GetUserProfilePicturePath", mock.IsType(*mockUser)).
 		Run(func(args mock.Arguments) {
-			newUser := args.Get(1).(model.User)
+			newUser := args.Get(0).(model.User)
 			assert.Equal(t, newUser.ID, id)
 			assert.WithinDuration(t, newUser.UpdatedAt, time.Now().UTC(), time.Minute)
 		}).
@@ -0,0 +1,278 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/api/requests"
+	"repertoire/server/domain/usecase/user"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/internal/wrapper"
+	"repertoire/server/model"
+	"repertoire/server/test/unit/data/database/transaction"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateUserScoringStrategy_WhenGetUserIdFromJwtFails_ShouldReturnTheError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	_uut := user.NewUpdateUserScoringStrategy(nil, jwtService, nil, nil)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	forbiddenError := wrapper.ForbiddenError(errors.New("forbidden error"))
+	jwtService.On("GetUserIdFromJwt", token).Return(uuid.Nil, forbiddenError).Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, forbiddenError, errCode)
+
+	jwtService.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenGetUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, nil, nil)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Get", new(model.User), id).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenUserIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, nil, nil)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	userRepository.On("Get", new(model.User), id).Return(nil).Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusNotFound, errCode.Code)
+	assert.Equal(t, "user not found", errCode.Error.Error())
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenStrategyIsTheSame_ShouldNotUpdateAnything(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, nil, nil)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	mockUser := &model.User{ID: id, ScoringStrategy: request.ScoringStrategy}
+	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenUpdateUserFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, nil, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	mockUser := &model.User{ID: id, ScoringStrategy: enums.ClassicScoring}
+	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
+
+	repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("Update", mock.IsType(mockUser)).Return(internalError).Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.LinearScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	mockUser := &model.User{ID: id, ScoringStrategy: enums.ClassicScoring}
+	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
+	repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	userRepository.On("Update", mock.IsType(mockUser)).Return(nil).Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.UserScoringStrategyUpdatedTopic, id).
+		Return(internalError).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestUpdateUserScoringStrategy_WhenSuccessful_ShouldNotReturnAnyError(t *testing.T) {
+	// given
+	jwtService := new(service.JwtServiceMock)
+	userRepository := new(repository.UserRepositoryMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	transactionManager := new(transaction.ManagerMock)
+	_uut := user.NewUpdateUserScoringStrategy(userRepository, jwtService, messagePublisherService, transactionManager)
+
+	repositoryFactory := new(transaction.RepositoryFactoryMock)
+	outboxRepository := new(repository.OutboxRepositoryMock)
+
+	request := requests.UpdateUserScoringStrategyRequest{
+		ScoringStrategy: enums.ExponentialScoring,
+	}
+
+	token := "This is a token"
+
+	// given - mocking
+	id := uuid.New()
+	jwtService.On("GetUserIdFromJwt", token).Return(id, nil).Once()
+
+	mockUser := &model.User{ID: id, ScoringStrategy: enums.ClassicScoring}
+	userRepository.On("Get", new(model.User), id).Return(nil, mockUser).Once()
+	repositoryFactory.On("NewUserRepository").Return(userRepository).Once()
+	repositoryFactory.On("NewOutboxRepository").Return(outboxRepository).Once()
+	transactionManager.On("Execute", mock.Anything).Return(nil, repositoryFactory).Once()
+
+	userRepository.On("Update", mock.IsType(mockUser)).
+		Run(func(args mock.Arguments) {
+			newUser := args.Get(0).(*model.User)
+			assert.Equal(t, request.ScoringStrategy, newUser.ScoringStrategy)
+		}).
+		Return(nil).
+		Once()
+
+	messagePublisherService.On("PublishWithinTransaction", outboxRepository, topics.UserScoringStrategyUpdatedTopic, id).
+		Return(nil).
+		Once()
+
+	// when
+	errCode := _uut.Handle(request, token)
+
+	// then
+	assert.Nil(t, errCode)
+
+	transactionManager.AssertExpectations(t)
+	repositoryFactory.AssertExpectations(t)
+	jwtService.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
@@ -0,0 +1,124 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+	"repertoire/server/domain/usecase/user"
+	"repertoire/server/internal/enums"
+	"repertoire/server/internal/message/topics"
+	"repertoire/server/test/unit/data/repository"
+	"repertoire/server/test/unit/data/service"
+	"repertoire/server/test/unit/domain/processor"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeScoringStrategies_WhenGetUsersFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	_uut := user.NewUpgradeScoringStrategies(userRepository, progressProcessor, nil)
+
+	// given - mocking
+	versions := map[enums.ScoringStrategy]uint{enums.ClassicScoring: 2}
+	progressProcessor.On("GetScoringStrategyVersions").Return(versions).Once()
+
+	internalError := errors.New("internal error")
+	userRepository.On("GetAllIDsByOutdatedScoringStrategy", new([]uuid.UUID), enums.ClassicScoring, uint(2)).
+		Return(internalError).
+		Once()
+
+	// when
+	upgraded, errCode := _uut.Handle()
+
+	// then
+	assert.Zero(t, upgraded)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+}
+
+func TestUpgradeScoringStrategies_WhenPublishFails_ShouldReturnInternalServerError(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := user.NewUpgradeScoringStrategies(userRepository, progressProcessor, messagePublisherService)
+
+	// given - mocking
+	versions := map[enums.ScoringStrategy]uint{enums.ClassicScoring: 2}
+	progressProcessor.On("GetScoringStrategyVersions").Return(versions).Once()
+
+	ids := []uuid.UUID{uuid.New()}
+	userRepository.On("GetAllIDsByOutdatedScoringStrategy", new([]uuid.UUID), enums.ClassicScoring, uint(2)).
+		Return(nil, &ids).
+		Once()
+
+	internalError := errors.New("internal error")
+	messagePublisherService.On("Publish", topics.UserScoringStrategyUpdatedTopic, ids[0]).
+		Return(internalError).
+		Once()
+
+	// when
+	upgraded, errCode := _uut.Handle()
+
+	// then
+	assert.Zero(t, upgraded)
+	assert.NotNil(t, errCode)
+	assert.Equal(t, http.StatusInternalServerError, errCode.Code)
+	assert.Equal(t, internalError, errCode.Error)
+
+	userRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
+
+func TestUpgradeScoringStrategies_WhenSuccessful_ShouldRecomputeTheScoresOfTheOutdatedUsers(t *testing.T) {
+	// given
+	userRepository := new(repository.UserRepositoryMock)
+	progressProcessor := new(processor.ProgressProcessorMock)
+	messagePublisherService := new(service.MessagePublisherServiceMock)
+	_uut := user.NewUpgradeScoringStrategies(userRepository, progressProcessor, messagePublisherService)
+
+	// given - mocking
+	versions := map[enums.ScoringStrategy]uint{
+		enums.ClassicScoring:     2,
+		enums.ExponentialScoring: 1,
+		enums.LinearScoring:      3,
+	}
+	progressProcessor.On("GetScoringStrategyVersions").Return(versions).Once()
+
+	classicIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	linearIDs := []uuid.UUID{uuid.New()}
+	userRepository.On("GetAllIDsByOutdatedScoringStrategy", new([]uuid.UUID), enums.ClassicScoring, uint(2)).
+		Return(nil, &classicIDs).
+		Once()
+	userRepository.On("GetAllIDsByOutdatedScoringStrategy", new([]uuid.UUID), enums.ExponentialScoring, uint(1)).
+		Return(nil).
+		Once()
+	userRepository.On("GetAllIDsByOutdatedScoringStrategy", new([]uuid.UUID), enums.LinearScoring, uint(3)).
+		Return(nil, &linearIDs).
+		Once()
+
+	for _, id := range append(classicIDs, linearIDs...) {
+		messagePublisherService.On("Publish", topics.UserScoringStrategyUpdatedTopic, id).
+			Return(nil).
+			Once()
+	}
+
+	// when
+	upgraded, errCode := _uut.Handle()
+
+	// then
+	assert.Nil(t, errCode)
+	assert.Equal(t, len(classicIDs)+len(linearIDs), upgraded)
+
+	userRepository.AssertExpectations(t)
+	progressProcessor.AssertExpectations(t)
+	messagePublisherService.AssertExpectations(t)
+}
@@ -1,9 +1,16 @@
 package internal
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
 	"os"
 	"repertoire/server/internal"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +39,32 @@ func TestToFullURL_WhenURLIsAlreadyFull_ShouldReturnTheFilePathAsItIs(t *testing
 	assert.Equal(t, _uut, *result)
 }
 
-func TestToFullURL_WhenSuccessful_ShouldReturnFilePathPrefixedByStorageUrlAndSuffixedByLastModifiedDate(t *testing.T) {
+func TestToFullURL_WhenStorageUrlIsNotSet_ShouldStillSignTheFilePath(t *testing.T) {
+	// given
+	_ = os.Setenv("STORAGE_FETCH_URL", "")
+	_uut := internal.FilePath("some_file_path")
+
+	// when
+	result := _uut.ToFullURL()
+
+	// then
+	assert.Equal(t, _uut.ToSignedPath(), string(*result))
+}
+
+func TestToFullURL_WhenFilePathOnlyContainsTheStorageUrl_ShouldPrefixItAndSignIt(t *testing.T) {
+	// given
+	storageUrl := "the_storage_url"
+	_ = os.Setenv("STORAGE_FETCH_URL", storageUrl)
+	_uut := internal.FilePath("some_file_path/" + storageUrl)
+
+	// when
+	result := _uut.ToFullURL()
+
+	// then
+	assert.Equal(t, storageUrl+_uut.ToSignedPath(), string(*result))
+}
+
+func TestToFullURL_WhenSuccessful_ShouldReturnFilePathPrefixedByStorageUrlAndSuffixedBySignature(t *testing.T) {
 	// given
 	storageUrl := "the_storage_url"
 	_ = os.Setenv("STORAGE_FETCH_URL", storageUrl)
@@ -42,7 +74,38 @@ func TestToFullURL_WhenSuccessful_ShouldReturnFilePathPrefixedByStorageUrlAndSuf
 	result := _uut.ToFullURL()
 
 	// then
-	assert.Equal(t, storageUrl+string(_uut), string(*result))
+	assert.Equal(t, storageUrl+_uut.ToSignedPath(), string(*result))
+}
+
+func TestToSignedPath_WhenSuccessful_ShouldReturnFilePathWithExpirationAndSignature(t *testing.T) {
+	// given
+	secretKey := "some_secret_key"
+	_ = os.Setenv("STORAGE_SIGNED_URL_SECRET_KEY", secretKey)
+	_ = os.Setenv("STORAGE_SIGNED_URL_EXPIRATION_TIME", "1h")
+	_uut := internal.FilePath("some_user/some_file_path")
+
+	// when
+	result := _uut.ToSignedPath()
+
+	// then
+	path, query, found := strings.Cut(result, "?")
+	assert.True(t, found)
+	assert.Equal(t, string(_uut), path)
+
+	values, err := url.ParseQuery(query)
+	assert.NoError(t, err)
+
+	expires, err := strconv.ParseInt(values.Get("expires"), 10, 64)
+	assert.NoError(t, err)
+	assert.Greater(t, expires, time.Now().UTC().Add(time.Hour).Unix())
+	assert.LessOrEqual(t, expires, time.Now().UTC().Add(2*time.Hour).Unix())
+
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(path + ":" + values.Get("expires")))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), values.Get("signature"))
+
+	// the same URL is returned while it is still valid, so that it can be cached
+	assert.Equal(t, result, _uut.ToSignedPath())
 }
 
 func TestStripURL_WhenIsNil_ShouldReturnNil(t *testing.T) {
@@ -69,3 +132,45 @@ func TestStripURL_WhenSuccessful_ShouldReturnTheFilePathWithoutTheStorageUrlOrTr
 	// then
 	assert.Equal(t, filePath, string(*result))
 }
+
+func TestStripURL_WhenURLIsSigned_ShouldReturnTheFilePathWithoutTheSignature(t *testing.T) {
+	// given
+	storageUrl := "the_storage_url"
+	_ = os.Setenv("STORAGE_FETCH_URL", storageUrl)
+	filePath := internal.FilePath("some_file_path")
+	_uut := filePath.ToFullURL()
+
+	// when
+	result := _uut.StripURL()
+
+	// then
+	assert.Equal(t, filePath, *result)
+}
+
+func TestToImageVariants_WhenIsNil_ShouldReturnNil(t *testing.T) {
+	// given
+	var _uut *internal.FilePath
+
+	// when
+	result := _uut.ToImageVariants()
+
+	// then
+	assert.Nil(t, result)
+}
+
+func TestToImageVariants_WhenSuccessful_ShouldReturnTheSignedURLsOfEachSize(t *testing.T) {
+	// given
+	storageUrl := "the_storage_url"
+	_ = os.Setenv("STORAGE_FETCH_URL", storageUrl)
+	_uut := internal.FilePath("some_file_path")
+
+	// when
+	result := _uut.ToImageVariants()
+
+	// then
+	fullURL := string(*_uut.ToFullURL())
+	assert.Equal(t, fullURL+"&size=64", string(*result.Small))
+	assert.Equal(t, fullURL+"&size=256", string(*result.Medium))
+	assert.Equal(t, fullURL+"&size=1024", string(*result.Large))
+	assert.Equal(t, _uut, *result.Medium.StripURL())
+}
@@ -0,0 +1,308 @@
+package query
+
+import (
+	"repertoire/server/internal/query"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter_WhenConditionIsSimple_ShouldReturnTheTypedCondition(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name     string
+		input    string
+		expected query.Condition
+	}{
+		{
+			"String",
+			"title ~* something entirely different",
+			query.Condition{Property: "title", Operator: query.Match, Value: "something entirely different"},
+		},
+		{
+			"Number",
+			"bpm >= 120",
+			query.Condition{Property: "bpm", Operator: query.GreaterOrEqual, Value: 120.0},
+		},
+		{
+			"Date",
+			"songs.release_date < 2024-03-01",
+			query.Condition{
+				Property: "songs.release_date",
+				Operator: query.Less,
+				Value:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			"UUID",
+			"album_id = " + id.String(),
+			query.Condition{Property: "album_id", Operator: query.Equal, Value: id},
+		},
+		{
+			"Bool",
+			"is_recorded != false",
+			query.Condition{Property: "is_recorded", Operator: query.NotEqual, Value: false},
+		},
+		{
+			"Enum In",
+			"difficulty IN easy, hard",
+			query.Condition{Property: "difficulty", Operator: query.In, Value: []any{"easy", "hard"}},
+		},
+		{
+			"Is Not Null",
+			"last_time_played is not null",
+			query.Condition{Property: "last_time_played", Operator: query.IsNotNull},
+		},
+		{
+			"Unquoted with keywords",
+			"title ~* Rock AND Roll OR Jazz",
+			query.Condition{Property: "title", Operator: query.Match, Value: "Rock AND Roll OR Jazz"},
+		},
+		{
+			"Quoted",
+			"title = 'Rock AND Roll''s (Live)'",
+			query.Condition{Property: "title", Operator: query.Equal, Value: "Rock AND Roll's (Live)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result, err := query.ParseFilter(query.SongFields, tt.input)
+
+			// then
+			assert.NoError(t, err)
+			condition, ok := result.(query.Condition)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected.Property, condition.Property)
+			assert.Equal(t, tt.expected.Operator, condition.Operator)
+			assert.Equal(t, tt.expected.Value, condition.Value)
+			assert.Equal(t, query.SongFields[tt.expected.Property], condition.Field)
+		})
+	}
+}
+
+func TestParseFilter_WhenDateIsRelative_ShouldResolveItFromNow(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Duration
+	}{
+		{"Now", "last_time_played < now", 0},
+		{"In the past", "last_time_played < now-14d", -14 * 24 * time.Hour},
+		{"In the future", "release_date > NOW+2w", 14 * 24 * time.Hour},
+		{"In hours", "created_at > now-6h", -6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result, err := query.ParseFilter(query.SongFields, tt.input)
+
+			// then
+			assert.NoError(t, err)
+			value := result.(query.Condition).Value.(time.Time)
+			assert.WithinDuration(t, time.Now().Add(tt.expected), value, time.Minute)
+		})
+	}
+}
+
+func TestParseFilter_WhenConditionsAreCombined_ShouldGroupThemWithAndBindingTighter(t *testing.T) {
+	// given
+	input := "title = a OR bpm > 100 AND (difficulty = easy OR difficulty IS NULL)"
+
+	// when
+	result, err := query.ParseFilter(query.SongFields, input)
+
+	// then
+	assert.NoError(t, err)
+
+	or, ok := result.(query.Group)
+	assert.True(t, ok)
+	assert.Equal(t, query.Or, or.Operator)
+	assert.Len(t, or.Filters, 2)
+	assert.Equal(t, "title", or.Filters[0].(query.Condition).Property)
+
+	and, ok := or.Filters[1].(query.Group)
+	assert.True(t, ok)
+	assert.Equal(t, query.And, and.Operator)
+	assert.Len(t, and.Filters, 2)
+	assert.Equal(t, 100.0, and.Filters[0].(query.Condition).Value)
+
+	nested, ok := and.Filters[1].(query.Group)
+	assert.True(t, ok)
+	assert.Equal(t, query.Or, nested.Operator)
+	assert.Equal(t, query.Equal, nested.Filters[0].(query.Condition).Operator)
+	assert.Equal(t, query.IsNull, nested.Filters[1].(query.Condition).Operator)
+}
+
+func TestParseFilter_WhenUnquotedValueContainsKeywords_ShouldSplitOnlyBetweenConditions(t *testing.T) {
+	// given
+	input := "title ~* Rock AND Roll AND bpm > 100 OR (artist_id IS NULL)"
+
+	// when
+	result, err := query.ParseFilter(query.SongFields, input)
+
+	// then
+	assert.NoError(t, err)
+
+	or, ok := result.(query.Group)
+	assert.True(t, ok)
+	assert.Equal(t, query.Or, or.Operator)
+	assert.Len(t, or.Filters, 2)
+	assert.Equal(t, query.IsNull, or.Filters[1].(query.Condition).Operator)
+
+	and, ok := or.Filters[0].(query.Group)
+	assert.True(t, ok)
+	assert.Equal(t, query.And, and.Operator)
+	assert.Len(t, and.Filters, 2)
+	assert.Equal(t, "Rock AND Roll", and.Filters[0].(query.Condition).Value)
+	assert.Equal(t, 100.0, and.Filters[1].(query.Condition).Value)
+}
+
+func TestParseFilter_WhenInputIsInvalid_ShouldReturnErrorDescribingIt(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			"Unknown field",
+			"password = 1234",
+			"unknown field 'password'",
+		},
+		{
+			"Injected field",
+			"title = a OR 1 = 1",
+			"unknown field '1'",
+		},
+		{
+			"Operator not allowed",
+			"release_date ~* 2024",
+			"operator '~*' is not allowed on field 'release_date'",
+		},
+		{
+			"Invalid operator",
+			"title LIKE %a%",
+			"invalid operator for field 'title'",
+		},
+		{
+			"Invalid number",
+			"bpm > 1; DROP TABLE songs",
+			"invalid number value '1; DROP TABLE songs' for field 'bpm'",
+		},
+		{
+			"Invalid enum",
+			"difficulty IN easy, trivial",
+			"invalid enum value 'trivial' for field 'difficulty'",
+		},
+		{
+			"Invalid relative date",
+			"last_time_played < now-14y",
+			"invalid date value 'now-14y' for field 'last_time_played'",
+		},
+		{
+			"Missing value",
+			"title =",
+			"value is missing for field 'title'",
+		},
+		{
+			"Unclosed quote",
+			"title = 'abc",
+			"quote is not closed for field 'title'",
+		},
+		{
+			"Unclosed parenthesis",
+			"(title = a OR bpm > 100",
+			"parenthesis is not closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result, err := query.ParseFilter(query.SongFields, tt.input)
+
+			// then
+			assert.Nil(t, result)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestParseOrder_WhenInputIsValid_ShouldReturnTheOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected query.Order
+	}{
+		{
+			"Only Field",
+			"title",
+			query.Order{Property: "title"},
+		},
+		{
+			"Descending",
+			"songs.created_at DESC",
+			query.Order{Property: "songs.created_at", Descending: true},
+		},
+		{
+			"Nulls",
+			`"Album".title asc nulls last`,
+			query.Order{Property: `"Album".title`, Nulls: query.NullsLast},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result, err := query.ParseOrder(query.SongFields, tt.input)
+
+			// then
+			assert.NoError(t, err)
+			tt.expected.Field = query.SongFields[tt.expected.Property]
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseOrder_WhenInputIsInvalid_ShouldReturnErrorDescribingIt(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			"Unknown field",
+			"password desc",
+			"unknown field 'password'",
+		},
+		{
+			"Field without column",
+			"playlist_id asc",
+			"field 'playlist_id' cannot be ordered by",
+		},
+		{
+			"Invalid nulls",
+			"title asc nulls middle",
+			"invalid nulls ordering 'middle' for field 'title'",
+		},
+		{
+			"Invalid order",
+			"title ascending",
+			"invalid order 'ascending' for field 'title'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			_, err := query.ParseOrder(query.SongFields, tt.input)
+
+			// then
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
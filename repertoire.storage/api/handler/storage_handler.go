@@ -1,55 +1,182 @@
 package handler
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
+	"path"
+	"repertoire/storage/data/storage"
+	"repertoire/storage/domain/service"
 	"repertoire/storage/internal"
+	"slices"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+// the file path is a form field, so it should not be much longer than a path can be
+const maxFilePathLength = 4096
+
+// the images are read in memory to be processed, unlike the other files that are streamed
+const maxImageUploadSize = 20 << 20
+
 type StorageHandler struct {
-	env internal.Env
+	blob                storage.Blob
+	imageService        service.ImageService
+	storageUsageService service.StorageUsageService
 }
 
 func NewStorageHandler(
-	env internal.Env,
+	blob storage.Blob,
+	imageService service.ImageService,
+	storageUsageService service.StorageUsageService,
 ) *StorageHandler {
 	return &StorageHandler{
-		env: env,
+		blob:                blob,
+		imageService:        imageService,
+		storageUsageService: storageUsageService,
 	}
 }
 
+// Get serves the file through http.ServeContent, which also handles the range and the conditional requests
 func (s StorageHandler) Get(c *gin.Context) {
 	filePath := c.Param("filePath")
+	if !s.validatePath(c, filePath) {
+		return
+	}
+
+	if size := c.Query("size"); size != "" {
+		parsedSize, err := strconv.Atoi(size)
+		if err != nil || !slices.Contains(internal.ImageVariantSizes, parsedSize) {
+			_ = c.AbortWithError(http.StatusBadRequest, errors.New(fmt.Sprintf("invalid size: %s", size)))
+			return
+		}
+		// the images uploaded before the variants were generated only have the original
+		variantPath := internal.ImageVariantPath(filePath, parsedSize)
+		if _, err := s.blob.Stat(variantPath); err == nil {
+			filePath = variantPath
+		}
+	}
 
-	storagePath := filepath.Join(s.env.UploadDirectory, filePath)
-	if _, err := os.Stat(storagePath); os.IsNotExist(err) {
-		filename := filepath.Base(storagePath)
+	content, info, err := s.blob.Open(filePath)
+	if errors.Is(err, storage.ErrNotFound) {
+		filename := path.Base(filePath)
 		_ = c.AbortWithError(http.StatusNotFound, errors.New(fmt.Sprintf("file not found: %s", filename)))
 		return
 	}
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	defer func() { _ = content.Close() }()
 
-	c.File(storagePath)
+	c.Header("ETag", info.ETag)
+	http.ServeContent(c.Writer, c.Request, path.Base(filePath), info.ModTime, content)
 }
 
+// Upload streams the file straight into the storage,
+// which is why the filePath field has to be sent before the file in the multipart form
 func (s StorageHandler) Upload(c *gin.Context) {
-	file, err := c.FormFile("file")
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
 		_ = c.AbortWithError(http.StatusBadRequest, err)
 		return
 	}
 
-	filePath := c.PostForm("filePath")
+	var filePath *string
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = c.AbortWithError(http.StatusBadRequest, err)
+			return
+		}
 
-	if err = c.SaveUploadedFile(file, filepath.Join(s.env.UploadDirectory, filePath)); err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		switch part.FormName() {
+		case "filePath":
+			value, err := io.ReadAll(io.LimitReader(part, maxFilePathLength))
+			if err != nil {
+				_ = c.AbortWithError(http.StatusBadRequest, err)
+				return
+			}
+			text := string(value)
+			filePath = &text
+
+		case "file":
+			if filePath == nil {
+				_ = c.AbortWithError(http.StatusBadRequest, errors.New("the filePath has to be sent before the file"))
+				return
+			}
+			s.upload(c, part, *filePath)
+			return
+		}
+	}
+
+	_ = c.AbortWithError(http.StatusBadRequest, errors.New("the file is missing"))
+}
+
+func (s StorageHandler) upload(c *gin.Context, file *multipart.Part, filePath string) {
+	if !s.validateWritePath(c, filePath) {
+		return
+	}
+
+	if internal.IsImagePath(filePath) {
+		s.uploadImage(c, file, filePath)
 		return
 	}
 
+	if err := s.storageUsageService.Write(filePath, file); err != nil {
+		s.abortWithUploadError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "file has been uploaded successfully!",
+	})
+}
+
+// uploadImage saves the normalized image, alongside its resized variants, instead of the uploaded bytes
+func (s StorageHandler) uploadImage(c *gin.Context, file *multipart.Part, filePath string) {
+	content, err := io.ReadAll(io.LimitReader(file, maxImageUploadSize+1))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+	if len(content) > maxImageUploadSize {
+		_ = c.AbortWithError(http.StatusRequestEntityTooLarge, service.ErrImageTooLarge)
+		return
+	}
+
+	processedImage, err := s.imageService.Process(content)
+	if errors.Is(err, service.ErrImageTooLarge) {
+		_ = c.AbortWithError(http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	if err != nil {
+		_ = c.AbortWithError(http.StatusUnsupportedMediaType, err)
+		return
+	}
+
+	if err = s.storageUsageService.Write(filePath, bytes.NewReader(processedImage.Image)); err != nil {
+		s.abortWithUploadError(c, err)
+		return
+	}
+	for size, variant := range processedImage.Variants {
+		err = s.storageUsageService.Write(internal.ImageVariantPath(filePath, size), bytes.NewReader(variant))
+		if err != nil {
+			// the image is not kept without all its variants
+			_ = s.storageUsageService.Delete(filePath)
+			s.deleteImageVariants(filePath)
+			s.abortWithUploadError(c, err)
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "file has been uploaded successfully!",
 	})
@@ -64,8 +191,17 @@ func (s StorageHandler) DeleteDirectories(c *gin.Context) {
 	}
 
 	for _, directoryPath := range request.DirectoryPaths {
-		storagePath := filepath.Join(s.env.UploadDirectory, directoryPath)
-		_ = os.RemoveAll(storagePath)
+		if !s.validateWritePath(c, directoryPath) {
+			return
+		}
+	}
+
+	for _, directoryPath := range request.DirectoryPaths {
+		err = s.storageUsageService.DeleteDirectory(directoryPath)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -73,19 +209,61 @@ func (s StorageHandler) DeleteDirectories(c *gin.Context) {
 	})
 }
 
+// QuarantineFiles moves the files (alongside the variants of the images) out of the user directory,
+// so that they can still be restored, instead of deleting them right away
+func (s StorageHandler) QuarantineFiles(c *gin.Context) {
+	var request struct{ FilePaths []string }
+	err := c.BindJSON(&request)
+	if err != nil {
+		_ = c.AbortWithError(http.StatusBadRequest, err)
+		return
+	}
+
+	for _, filePath := range request.FilePaths {
+		if !s.validateWritePath(c, filePath) {
+			return
+		}
+	}
+
+	for _, filePath := range request.FilePaths {
+		filePaths := []string{filePath}
+		if internal.IsImagePath(filePath) {
+			for _, size := range internal.ImageVariantSizes {
+				filePaths = append(filePaths, internal.ImageVariantPath(filePath, size))
+			}
+		}
+		for _, path := range filePaths {
+			err = s.storageUsageService.Move(path, internal.QuarantinePath(path))
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				_ = c.AbortWithError(http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "files have been quarantined successfully!",
+	})
+}
+
 func (s StorageHandler) DeleteFile(c *gin.Context) {
 	filePath := c.Param("filePath")
+	if !s.validateWritePath(c, filePath) {
+		return
+	}
 
-	storagePath := filepath.Join(s.env.UploadDirectory, filePath)
-	if err := os.Remove(storagePath); err != nil {
-		if os.IsNotExist(err) {
-			filename := filepath.Base(storagePath)
+	if err := s.storageUsageService.Delete(filePath); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			filename := path.Base(filePath)
 			_ = c.AbortWithError(http.StatusNotFound, errors.New(fmt.Sprintf("file not found: %s", filename)))
 			return
 		}
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
+	if internal.IsImagePath(filePath) {
+		s.deleteImageVariants(filePath)
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "file has been deleted successfully!",
@@ -94,16 +272,16 @@ func (s StorageHandler) DeleteFile(c *gin.Context) {
 
 func (s StorageHandler) DeleteDirectory(c *gin.Context) {
 	directoryPath := c.Param("directoryPath")
-
-	storagePath := filepath.Join(s.env.UploadDirectory, directoryPath)
-
-	if _, err := os.Stat(storagePath); err != nil {
-		directory := filepath.Base(storagePath)
-		_ = c.AbortWithError(http.StatusNotFound, errors.New(fmt.Sprintf("directory not found: %s", directory)))
+	if !s.validateWritePath(c, directoryPath) {
 		return
 	}
 
-	if err := os.RemoveAll(storagePath); err != nil {
+	if err := s.storageUsageService.DeleteDirectory(directoryPath); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			directory := path.Base(directoryPath)
+			_ = c.AbortWithError(http.StatusNotFound, errors.New(fmt.Sprintf("directory not found: %s", directory)))
+			return
+		}
 		_ = c.AbortWithError(http.StatusInternalServerError, err)
 		return
 	}
@@ -112,3 +290,50 @@ func (s StorageHandler) DeleteDirectory(c *gin.Context) {
 		"message": "directory has been deleted successfully!",
 	})
 }
+
+// GetUsage returns the files (and their sizes) of the directory that the token is allowed to write into
+func (s StorageHandler) GetUsage(c *gin.Context) {
+	usage, err := s.storageUsageService.GetUsage(c.GetString(internal.PathPrefixContextKey))
+	if err != nil {
+		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// deleteImageVariants deletes the resized copies of the image, whichever of them exist
+func (s StorageHandler) deleteImageVariants(filePath string) {
+	for _, size := range internal.ImageVariantSizes {
+		_ = s.storageUsageService.Delete(internal.ImageVariantPath(filePath, size))
+	}
+}
+
+// abortWithUploadError responds with 413 when the upload has been stopped because it exceeds the quota of the user
+func (s StorageHandler) abortWithUploadError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrStorageQuotaExceeded) {
+		_ = c.AbortWithError(http.StatusRequestEntityTooLarge, err)
+		return
+	}
+	_ = c.AbortWithError(http.StatusInternalServerError, err)
+}
+
+func (s StorageHandler) validatePath(c *gin.Context, path string) bool {
+	if internal.HasPathTraversal(path) {
+		_ = c.AbortWithError(http.StatusBadRequest, errors.New(fmt.Sprintf("invalid path: %s", path)))
+		return false
+	}
+	return true
+}
+
+// validateWritePath makes sure that the path is also inside the directory that the token is allowed to write into
+func (s StorageHandler) validateWritePath(c *gin.Context, path string) bool {
+	if !s.validatePath(c, path) {
+		return false
+	}
+	if !internal.IsPathUnderPrefix(path, c.GetString(internal.PathPrefixContextKey)) {
+		_ = c.AbortWithError(http.StatusForbidden, errors.New(fmt.Sprintf("path not allowed: %s", path)))
+		return false
+	}
+	return true
+}
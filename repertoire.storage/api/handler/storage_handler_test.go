@@ -3,11 +3,16 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"repertoire/storage/data/storage"
+	"repertoire/storage/domain/service"
 	"repertoire/storage/internal"
 	"testing"
 
@@ -17,20 +22,35 @@ import (
 
 // Utils
 
+var pathPrefix = "some-user"
+
 func getGinStorageHandler() (*gin.Engine, internal.Env) {
+	return getGinStorageHandlerWithQuota(0)
+}
+
+func getGinStorageHandlerWithQuota(quota int64) (*gin.Engine, internal.Env) {
 	gin.SetMode(gin.TestMode)
 	engine := gin.Default()
+	engine.Use(func(c *gin.Context) {
+		c.Set(internal.PathPrefixContextKey, pathPrefix)
+	})
 
 	env := internal.Env{
-		UploadDirectory: "../../Test_Uploads/",
+		UploadDirectory:  "../../Test_Uploads/",
+		UserStorageQuota: quota,
 	}
+	blob, _ := storage.NewBlob(env)
 	storageHandler := StorageHandler{
-		env: env,
+		blob:                blob,
+		imageService:        service.NewImageService(),
+		storageUsageService: service.NewStorageUsageService(blob, env),
 	}
 
 	engine.GET("/files/*filePath", storageHandler.Get)
+	engine.GET("/usage", storageHandler.GetUsage)
 	engine.PUT("/upload", storageHandler.Upload)
 	engine.PUT("/directories", storageHandler.DeleteDirectories)
+	engine.PUT("/quarantine", storageHandler.QuarantineFiles)
 	engine.DELETE("/files/*filePath", storageHandler.DeleteFile)
 	engine.DELETE("/directories/*directoryPath", storageHandler.DeleteDirectory)
 
@@ -38,17 +58,23 @@ func getGinStorageHandler() (*gin.Engine, internal.Env) {
 }
 
 func createMultipartBody(fileName, filePath string) (*bytes.Buffer, string) {
+	return createMultipartBodyWithContent(fileName, filePath, []byte("This is a test file"))
+}
+
+func createMultipartBodyWithContent(fileName, filePath string, content []byte) (*bytes.Buffer, string) {
 	tempFile, _ := os.CreateTemp("", fileName)
 	defer func(name string) {
 		_ = os.Remove(name)
 	}(tempFile.Name())
 
-	_, _ = tempFile.WriteString("This is a test file")
+	_, _ = tempFile.Write(content)
 	_ = tempFile.Close()
 
 	var requestBody bytes.Buffer
 	multiWriter := multipart.NewWriter(&requestBody)
 
+	_ = multiWriter.WriteField("filePath", filePath)
+
 	fileWriter, _ := multiWriter.CreateFormFile("file", tempFile.Name())
 
 	file, _ := os.Open(tempFile.Name())
@@ -58,12 +84,17 @@ func createMultipartBody(fileName, filePath string) (*bytes.Buffer, string) {
 
 	_, _ = file.WriteTo(fileWriter)
 
-	_ = multiWriter.WriteField("filePath", filePath)
 	_ = multiWriter.Close()
 
 	return &requestBody, multiWriter.FormDataContentType()
 }
 
+func createImage(width int, height int) []byte {
+	var content bytes.Buffer
+	_ = png.Encode(&content, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return content.Bytes()
+}
+
 func createFile(filePath string, content string, uploadTestDirectory string) {
 	// create directories
 	dir := filepath.Dir(filepath.Join(uploadTestDirectory, filePath))
@@ -78,7 +109,7 @@ func createFile(filePath string, content string, uploadTestDirectory string) {
 
 func TestStorageHandler_Get_WhenFileIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
-	filePath := "somewhere/else/test-file.txt"
+	filePath := pathPrefix + "/somewhere/else/test-file.txt"
 
 	handler, _ := getGinStorageHandler()
 
@@ -91,6 +122,21 @@ func TestStorageHandler_Get_WhenFileIsNotFound_ShouldReturnNotFoundError(t *test
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestStorageHandler_Get_WhenPathHasTraversal_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	filePath := pathPrefix + "/../../go.mod"
+
+	handler, _ := getGinStorageHandler()
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/files/"+filePath, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestStorageHandler_Get_WhenSuccessful_ShouldReturnFile(t *testing.T) {
 	// given
 	handler, env := getGinStorageHandler()
@@ -99,7 +145,7 @@ func TestStorageHandler_Get_WhenSuccessful_ShouldReturnFile(t *testing.T) {
 		_ = os.RemoveAll(env.UploadDirectory)
 	})
 
-	filePath := "somewhere/test-file.txt"
+	filePath := pathPrefix + "/somewhere/test-file.txt"
 	fileContent := "This is a test file"
 	createFile(filePath, fileContent, env.UploadDirectory)
 
@@ -114,6 +160,114 @@ func TestStorageHandler_Get_WhenSuccessful_ShouldReturnFile(t *testing.T) {
 	assert.Equal(t, fileContent, w.Body.String())
 }
 
+func TestStorageHandler_Get_WhenRangeIsRequested_ShouldReturnPartialContent(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/test-file.txt"
+	createFile(filePath, "This is a test file", env.UploadDirectory)
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/files/"+filePath, nil)
+	req.Header.Set("Range", "bytes=10-13")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusPartialContent, w.Code)
+	assert.Equal(t, "bytes 10-13/19", w.Header().Get("Content-Range"))
+	assert.Equal(t, "test", w.Body.String())
+}
+
+func TestStorageHandler_Get_WhenETagMatches_ShouldReturnNotModified(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/test-file.txt"
+	createFile(filePath, "This is a test file", env.UploadDirectory)
+
+	req := httptest.NewRequest(http.MethodGet, "/files/"+filePath, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+
+	// when
+	req = httptest.NewRequest(http.MethodGet, "/files/"+filePath, nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestStorageHandler_Get_WhenSizeIsInvalid_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/image.jpg"
+	createFile(filePath, "", env.UploadDirectory)
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/files/"+filePath+"?size=100", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStorageHandler_Get_WhenSizeIsGiven_ShouldReturnImageVariant(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasVariant      bool
+		expectedContent string
+	}{
+		{"With Variant", true, "variant"},
+		{"Without Variant", false, "original"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			handler, env := getGinStorageHandler()
+
+			t.Cleanup(func() {
+				_ = os.RemoveAll(env.UploadDirectory)
+			})
+
+			filePath := pathPrefix + "/somewhere/image.jpg"
+			createFile(filePath, "original", env.UploadDirectory)
+			if tt.hasVariant {
+				createFile(internal.ImageVariantPath(filePath, 256), "variant", env.UploadDirectory)
+			}
+
+			// when
+			req := httptest.NewRequest(http.MethodGet, "/files/"+filePath+"?size=256", nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			// then
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedContent, w.Body.String())
+		})
+	}
+}
+
 func TestStorageHandler_Upload_WhenFileIsMissing_ShouldReturnBadRequest(t *testing.T) {
 	// given
 	req := httptest.NewRequest(http.MethodPut, "/upload", nil)
@@ -159,6 +313,91 @@ func TestStorageHandler_Upload_WhenFilePathIsMissing_ShouldReturnBadRequest(t *t
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestStorageHandler_Upload_WhenFileIsSentBeforeFilePath_ShouldReturnBadRequest(t *testing.T) {
+	// given
+	var requestBody bytes.Buffer
+	multiWriter := multipart.NewWriter(&requestBody)
+	fileWriter, _ := multiWriter.CreateFormFile("file", "test-file.txt")
+	_, _ = fileWriter.Write([]byte("This is a test file"))
+	_ = multiWriter.WriteField("filePath", pathPrefix+"/somewhere/test-file.txt")
+	_ = multiWriter.Close()
+
+	req := httptest.NewRequest(http.MethodPut, "/upload", &requestBody)
+	req.Header.Set("Content-Type", multiWriter.FormDataContentType())
+
+	handler, env := getGinStorageHandler()
+
+	// when
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, pathPrefix+"/somewhere/test-file.txt"))
+	assert.Error(t, err)
+}
+
+func TestStorageHandler_Upload_WhenQuotaIsExceeded_ShouldReturnRequestEntityTooLarge(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandlerWithQuota(10)
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/else/test-file.txt"
+	body, contentType := createMultipartBody("old-test-file.txt", filePath)
+
+	// when
+	req := httptest.NewRequest(http.MethodPut, "/upload", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+	assert.Error(t, err)
+}
+
+func TestStorageHandler_Upload_WhenFilePathIsInvalid_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name         string
+		filePath     string
+		expectedCode int
+	}{
+		{"Outside the allowed directory", "another-user/test-file.txt", http.StatusForbidden},
+		{"With path traversal", pathPrefix + "/../another-user/test-file.txt", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			handler, env := getGinStorageHandler()
+
+			t.Cleanup(func() {
+				_ = os.RemoveAll(env.UploadDirectory)
+			})
+
+			body, contentType := createMultipartBody("old-test-file.txt", tt.filePath)
+
+			// when
+			req := httptest.NewRequest(http.MethodPut, "/upload", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			// then
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			_, err := os.Stat(filepath.Join(env.UploadDirectory, "another-user/test-file.txt"))
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestStorageHandler_Upload_WhenSuccessful_ShouldSaveFile(t *testing.T) {
 	// given
 	handler, env := getGinStorageHandler()
@@ -168,7 +407,7 @@ func TestStorageHandler_Upload_WhenSuccessful_ShouldSaveFile(t *testing.T) {
 	})
 
 	oldFileName := "old-test-file.txt"
-	filePath := "somewhere/else/test-file.txt"
+	filePath := pathPrefix + "/somewhere/else/test-file.txt"
 	body, contentType := createMultipartBody(oldFileName, filePath)
 
 	// when
@@ -186,6 +425,147 @@ func TestStorageHandler_Upload_WhenSuccessful_ShouldSaveFile(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestStorageHandler_Upload_WhenImageIsNotAnImage_ShouldReturnUnsupportedMediaType(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/image.jpg"
+	body, contentType := createMultipartBody("old-image.jpg", filePath)
+
+	// when
+	req := httptest.NewRequest(http.MethodPut, "/upload", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+	assert.Error(t, err)
+}
+
+func TestStorageHandler_Upload_WhenImageIsTooLarge_ShouldReturnRequestEntityTooLarge(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/image.png"
+	content := append(createImage(10, 10), make([]byte, maxImageUploadSize)...)
+	body, contentType := createMultipartBodyWithContent("image.png", filePath, content)
+
+	// when
+	req := httptest.NewRequest(http.MethodPut, "/upload", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+	assert.Error(t, err)
+}
+
+func TestStorageHandler_Upload_WhenImageIsSuccessful_ShouldSaveNormalizedImageAndVariants(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/image.jpg"
+	body, contentType := createMultipartBodyWithContent("old-image.png", filePath, createImage(2000, 1000))
+
+	// when
+	req := httptest.NewRequest(http.MethodPut, "/upload", body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	uploadedFile, err := os.Open(filepath.Join(env.UploadDirectory, filePath))
+	assert.NoError(t, err)
+	_, err = jpeg.Decode(uploadedFile)
+	_ = uploadedFile.Close()
+	assert.NoError(t, err)
+
+	for _, size := range internal.ImageVariantSizes {
+		variantFile, err := os.Open(filepath.Join(env.UploadDirectory, internal.ImageVariantPath(filePath, size)))
+		assert.NoError(t, err)
+		variant, err := jpeg.Decode(variantFile)
+		_ = variantFile.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, size, variant.Bounds().Dx())
+	}
+}
+
+func TestStorageHandler_GetUsage_ShouldReturnTheFilesOfTheAllowedDirectory(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandlerWithQuota(100)
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	createFile(pathPrefix+"/albums/image.jpg", "12345", env.UploadDirectory)
+	createFile(pathPrefix+"/songs/image.jpg", "123", env.UploadDirectory)
+	createFile("another-user/songs/image.jpg", "1", env.UploadDirectory)
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/usage", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var usage service.StorageUsage
+	_ = json.Unmarshal(w.Body.Bytes(), &usage)
+	assert.Equal(t, int64(8), usage.Size)
+	assert.Equal(t, int64(100), usage.Quota)
+	assert.Len(t, usage.Files, 2)
+}
+
+func TestStorageHandler_DeleteDirectories_WhenAnyPathIsOutsideTheAllowedDirectory_ShouldNotDeleteAnything(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	directoryPaths := []string{pathPrefix + "/somewhere", "another-user/somewhere"}
+	for _, directoryPath := range directoryPaths {
+		createFile(directoryPath+"/test-file.txt", "asd", env.UploadDirectory)
+	}
+
+	// when
+	var body = struct{ DirectoryPaths []string }{directoryPaths}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, "/directories", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	for _, directoryPath := range directoryPaths {
+		_, err := os.Stat(filepath.Join(env.UploadDirectory, directoryPath))
+		assert.NoError(t, err)
+	}
+}
+
 func TestStorageHandler_DeleteDirectories_WhenSuccessful_ShouldDeleteDirectory(t *testing.T) {
 	// given
 	handler, env := getGinStorageHandler()
@@ -194,14 +574,19 @@ func TestStorageHandler_DeleteDirectories_WhenSuccessful_ShouldDeleteDirectory(t
 		_ = os.RemoveAll(env.UploadDirectory)
 	})
 
-	directoryPaths := []string{"somewhere/else", "somewhere/else/two", "somebody/like", "helloThere"}
+	directoryPaths := []string{
+		pathPrefix + "/somewhere/else",
+		pathPrefix + "/somewhere/else/two",
+		pathPrefix + "/somebody/like",
+		pathPrefix + "/helloThere",
+	}
 
 	for _, directoryPath := range directoryPaths {
 		filePath := directoryPath + "/test-file.txt"
 		createFile(filePath, "asd", env.UploadDirectory)
 	}
 
-	directoryPaths = append(directoryPaths, "one/more/directory-that-is-not-to-be-found")
+	directoryPaths = append(directoryPaths, pathPrefix+"/one/more/directory-that-is-not-to-be-found")
 
 	// when
 	var body = struct{ DirectoryPaths []string }{directoryPaths}
@@ -220,9 +605,78 @@ func TestStorageHandler_DeleteDirectories_WhenSuccessful_ShouldDeleteDirectory(t
 	}
 }
 
+func TestStorageHandler_QuarantineFiles_WhenAnyPathIsOutsideTheAllowedDirectory_ShouldNotMoveAnything(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePaths := []string{pathPrefix + "/test-file.txt", "another-user/test-file.txt"}
+	for _, filePath := range filePaths {
+		createFile(filePath, "asd", env.UploadDirectory)
+	}
+
+	// when
+	var body = struct{ FilePaths []string }{filePaths}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, "/quarantine", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	for _, filePath := range filePaths {
+		_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+		assert.NoError(t, err)
+	}
+}
+
+func TestStorageHandler_QuarantineFiles_WhenSuccessful_ShouldMoveTheFilesAndTheImageVariants(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	imagePath := pathPrefix + "/albums/image.jpg"
+	createFile(imagePath, "", env.UploadDirectory)
+	for _, size := range internal.ImageVariantSizes {
+		createFile(internal.ImageVariantPath(imagePath, size), "", env.UploadDirectory)
+	}
+	filePath := pathPrefix + "/exports/export.zip"
+	createFile(filePath, "", env.UploadDirectory)
+
+	filePaths := []string{imagePath, filePath, pathPrefix + "/missing-file.txt"}
+
+	// when
+	var body = struct{ FilePaths []string }{filePaths}
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPut, "/quarantine", bytes.NewBuffer(jsonBody))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	movedPaths := []string{imagePath, filePath}
+	for _, size := range internal.ImageVariantSizes {
+		movedPaths = append(movedPaths, internal.ImageVariantPath(imagePath, size))
+	}
+	for _, movedPath := range movedPaths {
+		_, err := os.Stat(filepath.Join(env.UploadDirectory, movedPath))
+		assert.Error(t, err)
+		_, err = os.Stat(filepath.Join(env.UploadDirectory, internal.QuarantinePath(movedPath)))
+		assert.NoError(t, err)
+	}
+}
+
 func TestStorageHandler_DeleteFile_WhenFileIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
-	filePath := "somewhere/else/test-file.txt"
+	filePath := pathPrefix + "/somewhere/else/test-file.txt"
 
 	handler, _ := getGinStorageHandler()
 
@@ -235,6 +689,29 @@ func TestStorageHandler_DeleteFile_WhenFileIsNotFound_ShouldReturnNotFoundError(
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestStorageHandler_DeleteFile_WhenFileIsOutsideTheAllowedDirectory_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := "another-user/test-file.txt"
+	createFile(filePath, "", env.UploadDirectory)
+
+	// when
+	req := httptest.NewRequest(http.MethodDelete, "/files/"+filePath, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+	assert.NoError(t, err)
+}
+
 func TestStorageHandler_DeleteFile_WhenSuccessful_ShouldDeleteFile(t *testing.T) {
 	// given
 	handler, env := getGinStorageHandler()
@@ -243,7 +720,7 @@ func TestStorageHandler_DeleteFile_WhenSuccessful_ShouldDeleteFile(t *testing.T)
 		_ = os.RemoveAll(env.UploadDirectory)
 	})
 
-	filePath := "somewhere/test-file.txt"
+	filePath := pathPrefix + "/somewhere/test-file.txt"
 	createFile(filePath, "", env.UploadDirectory)
 
 	// when
@@ -259,9 +736,39 @@ func TestStorageHandler_DeleteFile_WhenSuccessful_ShouldDeleteFile(t *testing.T)
 	assert.Error(t, err)
 }
 
+func TestStorageHandler_DeleteFile_WhenFileIsImage_ShouldAlsoDeleteItsVariants(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	filePath := pathPrefix + "/somewhere/image.jpg"
+	createFile(filePath, "", env.UploadDirectory)
+	for _, size := range internal.ImageVariantSizes {
+		createFile(internal.ImageVariantPath(filePath, size), "", env.UploadDirectory)
+	}
+
+	// when
+	req := httptest.NewRequest(http.MethodDelete, "/files/"+filePath, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, filePath))
+	assert.Error(t, err)
+	for _, size := range internal.ImageVariantSizes {
+		_, err = os.Stat(filepath.Join(env.UploadDirectory, internal.ImageVariantPath(filePath, size)))
+		assert.Error(t, err)
+	}
+}
+
 func TestStorageHandler_DeleteDirectory_WhenDirectoryIsNotFound_ShouldReturnNotFoundError(t *testing.T) {
 	// given
-	directoryPath := "somewhere/else"
+	directoryPath := pathPrefix + "/somewhere/else"
 
 	handler, _ := getGinStorageHandler()
 
@@ -274,6 +781,29 @@ func TestStorageHandler_DeleteDirectory_WhenDirectoryIsNotFound_ShouldReturnNotF
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestStorageHandler_DeleteDirectory_WhenDirectoryOnlyStartsWithThePrefix_ShouldReturnForbiddenError(t *testing.T) {
+	// given
+	handler, env := getGinStorageHandler()
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(env.UploadDirectory)
+	})
+
+	directoryPath := pathPrefix + "-else"
+	createFile(directoryPath+"/test-file.txt", "", env.UploadDirectory)
+
+	// when
+	req := httptest.NewRequest(http.MethodDelete, "/directories/"+directoryPath, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	_, err := os.Stat(filepath.Join(env.UploadDirectory, directoryPath))
+	assert.NoError(t, err)
+}
+
 func TestStorageHandler_DeleteDirectory_WhenSuccessful_ShouldDeleteDirectory(t *testing.T) {
 	// given
 	handler, env := getGinStorageHandler()
@@ -282,7 +812,7 @@ func TestStorageHandler_DeleteDirectory_WhenSuccessful_ShouldDeleteDirectory(t *
 		_ = os.RemoveAll(env.UploadDirectory)
 	})
 
-	directoryPath := "somewhere"
+	directoryPath := pathPrefix + "/somewhere"
 	filePath := directoryPath + "/else/test-file.txt"
 	createFile(filePath, "", env.UploadDirectory)
 
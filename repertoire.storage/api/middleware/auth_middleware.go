@@ -4,17 +4,25 @@ import (
 	"errors"
 	"net/http"
 	"repertoire/storage/domain/service"
+	"repertoire/storage/internal"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthMiddleware struct {
-	jwtService service.JwtService
+	jwtService          service.JwtService
+	revokedTokenService service.RevokedTokenService
 }
 
-func NewAuthMiddleware(jwtService service.JwtService) AuthMiddleware {
-	return AuthMiddleware{jwtService: jwtService}
+func NewAuthMiddleware(
+	jwtService service.JwtService,
+	revokedTokenService service.RevokedTokenService,
+) AuthMiddleware {
+	return AuthMiddleware{
+		jwtService:          jwtService,
+		revokedTokenService: revokedTokenService,
+	}
 }
 
 func (a AuthMiddleware) Handler() gin.HandlerFunc {
@@ -23,11 +31,16 @@ func (a AuthMiddleware) Handler() gin.HandlerFunc {
 		t := strings.Split(authHeader, " ")
 		if len(t) == 2 {
 			authToken := t[1]
-			err := a.jwtService.Authorize(authToken)
+			prefix, tokenID, err := a.jwtService.Authorize(authToken)
 			if err != nil {
 				_ = c.AbortWithError(http.StatusUnauthorized, errors.New("invalid token"))
 				return
 			}
+			if a.revokedTokenService.IsRevoked(tokenID) {
+				_ = c.AbortWithError(http.StatusUnauthorized, errors.New("token has been revoked"))
+				return
+			}
+			c.Set(internal.PathPrefixContextKey, prefix)
 
 			c.Next()
 			return
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"repertoire/storage/domain/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SignedUrlMiddleware struct {
+	signedUrlService service.SignedUrlService
+}
+
+func NewSignedUrlMiddleware(signedUrlService service.SignedUrlService) SignedUrlMiddleware {
+	return SignedUrlMiddleware{signedUrlService: signedUrlService}
+}
+
+func (s SignedUrlMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := s.signedUrlService.Verify(c.Param("filePath"), c.Query("expires"), c.Query("signature"))
+		if err != nil {
+			_ = c.AbortWithError(http.StatusForbidden, err)
+			return
+		}
+
+		c.Next()
+	}
+}
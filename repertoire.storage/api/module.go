@@ -15,6 +15,7 @@ var Module = fx.Options(
 	fx.Provide(middleware.NewAuthMiddleware),
 	fx.Provide(middleware.NewCorsMiddleware),
 	fx.Provide(middleware.NewErrorHandlerMiddleware),
+	fx.Provide(middleware.NewSignedUrlMiddleware),
 	fx.Provide(server.NewRequestHandler),
 	fx.Provide(handler.NewStorageHandler),
 	fx.Provide(router.NewStorageRouter),
@@ -2,24 +2,28 @@ package router
 
 import (
 	"repertoire/storage/api/handler"
+	"repertoire/storage/api/middleware"
 	"repertoire/storage/api/server"
 )
 
 type StorageRouter struct {
-	requestHandler *server.RequestHandler
-	handler        *handler.StorageHandler
+	requestHandler      *server.RequestHandler
+	handler             *handler.StorageHandler
+	signedUrlMiddleware middleware.SignedUrlMiddleware
 }
 
 func (s StorageRouter) RegisterRoutes() {
 	api := s.requestHandler.PublicRouter.Group("/storage")
 	{
-		api.GET("/files/*filePath", s.handler.Get)
+		api.GET("/files/*filePath", s.signedUrlMiddleware.Handler(), s.handler.Get)
 	}
 
 	privateApi := s.requestHandler.PrivateRouter.Group("/storage")
 	{
+		privateApi.GET("/usage", s.handler.GetUsage)
 		privateApi.PUT("/upload", s.handler.Upload)
 		privateApi.PUT("/directories", s.handler.DeleteDirectories)
+		privateApi.PUT("/quarantine", s.handler.QuarantineFiles)
 		privateApi.DELETE("/files/*filePath", s.handler.DeleteFile)
 		privateApi.DELETE("/directories/*directoryPath", s.handler.DeleteDirectory)
 	}
@@ -28,9 +32,11 @@ func (s StorageRouter) RegisterRoutes() {
 func NewStorageRouter(
 	requestHandler *server.RequestHandler,
 	handler *handler.StorageHandler,
+	signedUrlMiddleware middleware.SignedUrlMiddleware,
 ) StorageRouter {
 	return StorageRouter{
-		handler:        handler,
-		requestHandler: requestHandler,
+		handler:             handler,
+		requestHandler:      requestHandler,
+		signedUrlMiddleware: signedUrlMiddleware,
 	}
 }
@@ -2,6 +2,7 @@ package data
 
 import (
 	"repertoire/storage/data/logger"
+	"repertoire/storage/data/storage"
 
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
@@ -18,4 +19,5 @@ var loggers = fx.Options(
 
 var Module = fx.Options(
 	loggers,
+	fx.Provide(storage.NewBlob),
 )
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"repertoire/storage/internal"
+	"strings"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type ObjectInfo struct {
+	Size    int64
+	ModTime time.Time
+	ETag    string
+}
+
+type ObjectEntry struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+}
+
+// Blob is the backend where the files are kept, and where the paths are always relative to its root
+type Blob interface {
+	Stat(path string) (ObjectInfo, error)
+	Open(path string) (io.ReadSeekCloser, ObjectInfo, error)
+	Write(path string, content io.Reader) error
+	Move(sourcePath string, destinationPath string) error
+	Delete(path string) error
+	DeleteDirectory(path string) error
+	List(directoryPath string) ([]ObjectEntry, error)
+}
+
+func NewBlob(env internal.Env) (Blob, error) {
+	if env.StorageBackend == internal.S3StorageBackend {
+		return newS3Blob(env)
+	}
+	return newFilesystemBlob(env), nil
+}
+
+// toKey turns the path into the key of the object, without the leading (or trailing) slash
+func toKey(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
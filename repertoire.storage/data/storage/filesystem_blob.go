@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"repertoire/storage/internal"
+	"strings"
+)
+
+// the prefix of the files that are still being written
+const tempFilePrefix = ".upload-"
+
+type filesystemBlob struct {
+	directory string
+}
+
+func newFilesystemBlob(env internal.Env) Blob {
+	return filesystemBlob{directory: env.UploadDirectory}
+}
+
+func (f filesystemBlob) Stat(path string) (ObjectInfo, error) {
+	info, err := os.Stat(f.fullPath(path))
+	if os.IsNotExist(err) || err == nil && info.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return toObjectInfo(info), nil
+}
+
+func (f filesystemBlob) Open(path string) (io.ReadSeekCloser, ObjectInfo, error) {
+	info, err := f.Stat(path)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	file, err := os.Open(f.fullPath(path))
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return file, info, nil
+}
+
+// Write copies the content into a temporary file first,
+// so that the file is never read (or overwritten) while it is only partially written
+func (f filesystemBlob) Write(path string, content io.Reader) error {
+	fullPath := f.fullPath(path)
+	err := os.MkdirAll(filepath.Dir(fullPath), 0750)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(fullPath), tempFilePrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tempFile.Close()
+		_ = os.Remove(tempFile.Name())
+	}()
+
+	if _, err = io.Copy(tempFile, content); err != nil {
+		return err
+	}
+	if err = tempFile.Chmod(0644); err != nil {
+		return err
+	}
+	if err = tempFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tempFile.Name(), fullPath)
+}
+
+func (f filesystemBlob) Move(sourcePath string, destinationPath string) error {
+	if _, err := f.Stat(sourcePath); err != nil {
+		return err
+	}
+	fullDestinationPath := f.fullPath(destinationPath)
+	if err := os.MkdirAll(filepath.Dir(fullDestinationPath), 0750); err != nil {
+		return err
+	}
+	return os.Rename(f.fullPath(sourcePath), fullDestinationPath)
+}
+
+func (f filesystemBlob) Delete(path string) error {
+	err := os.Remove(f.fullPath(path))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (f filesystemBlob) DeleteDirectory(path string) error {
+	fullPath := f.fullPath(path)
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return os.RemoveAll(fullPath)
+}
+
+// List returns all the files inside the directory (and its subdirectories), and nothing when it does not exist
+func (f filesystemBlob) List(directoryPath string) ([]ObjectEntry, error) {
+	var entries []ObjectEntry
+	err := filepath.WalkDir(f.fullPath(directoryPath), func(fullPath string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), tempFilePrefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(f.directory, fullPath)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, ObjectEntry{
+			Path:    filepath.ToSlash(relativePath),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+		return nil
+	})
+	return entries, err
+}
+
+func (f filesystemBlob) fullPath(path string) string {
+	return filepath.Join(f.directory, filepath.FromSlash(toKey(path)))
+}
+
+// toObjectInfo uses the modification time and the size as the ETag, as hashing every served file would be too slow
+func toObjectInfo(info os.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		ETag:    fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+	}
+}
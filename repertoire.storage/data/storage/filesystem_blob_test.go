@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"repertoire/storage/internal"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemBlob_Write_ShouldCreateTheDirectoriesAndTheFile(t *testing.T) {
+	// given
+	directory := t.TempDir()
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: directory})
+
+	// when
+	err := _uut.Write("/some-user/somewhere/file.txt", strings.NewReader("This is a test file"))
+
+	// then
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(directory, "some-user", "somewhere", "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "This is a test file", string(content))
+
+	entries, _ := os.ReadDir(filepath.Join(directory, "some-user", "somewhere"))
+	assert.Len(t, entries, 1)
+}
+
+func TestFilesystemBlob_Open_WhenFileExists_ShouldReturnItsContentAndInfo(t *testing.T) {
+	// given
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: t.TempDir()})
+	_ = _uut.Write("some-user/file.txt", strings.NewReader("This is a test file"))
+
+	// when
+	reader, info, err := _uut.Open("some-user/file.txt")
+
+	// then
+	assert.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "This is a test file", string(content))
+	assert.Equal(t, int64(19), info.Size)
+	assert.NotEmpty(t, info.ETag)
+
+	stat, _ := _uut.Stat("some-user/file.txt")
+	assert.Equal(t, info, stat)
+}
+
+func TestFilesystemBlob_WhenFileIsMissing_ShouldReturnNotFound(t *testing.T) {
+	// given
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: t.TempDir()})
+	_ = _uut.Write("some-user/somewhere/file.txt", strings.NewReader(""))
+
+	// when
+	_, statErr := _uut.Stat("some-user/else.txt")
+	_, directoryStatErr := _uut.Stat("some-user/somewhere")
+	_, _, openErr := _uut.Open("some-user/else.txt")
+	deleteErr := _uut.Delete("some-user/else.txt")
+	deleteDirectoryErr := _uut.DeleteDirectory("some-user/else")
+
+	// then
+	assert.ErrorIs(t, statErr, ErrNotFound)
+	assert.ErrorIs(t, directoryStatErr, ErrNotFound)
+	assert.ErrorIs(t, openErr, ErrNotFound)
+	assert.ErrorIs(t, deleteErr, ErrNotFound)
+	assert.ErrorIs(t, deleteDirectoryErr, ErrNotFound)
+}
+
+func TestFilesystemBlob_Delete_ShouldDeleteTheFilesAndDirectories(t *testing.T) {
+	// given
+	directory := t.TempDir()
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: directory})
+	_ = _uut.Write("some-user/file.txt", strings.NewReader(""))
+	_ = _uut.Write("some-user/somewhere/else/file.txt", strings.NewReader(""))
+
+	// when
+	deleteErr := _uut.Delete("some-user/file.txt")
+	deleteDirectoryErr := _uut.DeleteDirectory("some-user/somewhere")
+
+	// then
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, deleteDirectoryErr)
+
+	entries, _ := os.ReadDir(filepath.Join(directory, "some-user"))
+	assert.Empty(t, entries)
+}
+
+func TestFilesystemBlob_List_ShouldReturnAllTheFilesInsideTheDirectory(t *testing.T) {
+	// given
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: t.TempDir()})
+	_ = _uut.Write("some-user/somewhere/1.txt", strings.NewReader("1"))
+	_ = _uut.Write("some-user/somewhere/else/2.txt", strings.NewReader("22"))
+	_ = _uut.Write("some-user/somewhere-else/3.txt", strings.NewReader("333"))
+
+	// when
+	result, err := _uut.List("some-user/somewhere")
+	missingResult, missingErr := _uut.List("another-user")
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "some-user/somewhere/1.txt", result[0].Path)
+	assert.Equal(t, int64(1), result[0].Size)
+	assert.WithinDuration(t, time.Now(), result[0].ModTime, time.Minute)
+	assert.Equal(t, "some-user/somewhere/else/2.txt", result[1].Path)
+	assert.Equal(t, int64(2), result[1].Size)
+
+	assert.NoError(t, missingErr)
+	assert.Empty(t, missingResult)
+}
+
+func TestFilesystemBlob_Move_ShouldMoveTheFileIntoTheDestinationDirectory(t *testing.T) {
+	// given
+	directory := t.TempDir()
+	_uut := newFilesystemBlob(internal.Env{UploadDirectory: directory})
+	_ = _uut.Write("some-user/somewhere/file.txt", strings.NewReader("This is a test file"))
+
+	// when
+	err := _uut.Move("some-user/somewhere/file.txt", ".quarantine/some-user/somewhere/file.txt")
+	missingErr := _uut.Move("some-user/else.txt", ".quarantine/some-user/else.txt")
+
+	// then
+	assert.NoError(t, err)
+
+	_, statErr := _uut.Stat("some-user/somewhere/file.txt")
+	assert.ErrorIs(t, statErr, ErrNotFound)
+	content, err := os.ReadFile(filepath.Join(directory, ".quarantine", "some-user", "somewhere", "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "This is a test file", string(content))
+
+	assert.ErrorIs(t, missingErr, ErrNotFound)
+}
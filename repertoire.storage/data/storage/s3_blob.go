@@ -0,0 +1,431 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"repertoire/storage/internal"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the minimum size of the parts of a multipart upload (except the last one),
+// so the content is uploaded in a single request when it is smaller than that
+const s3PartSize = 5 << 20
+
+type s3Blob struct {
+	client      *http.Client
+	endpoint    *url.URL
+	bucket      string
+	credentials s3Credentials
+}
+
+// newS3Blob keeps the files in the bucket of an S3-compatible storage (e.g. AWS S3, MinIO).
+// The objects are addressed path-style (endpoint/bucket/key), as that is supported by all of them
+func newS3Blob(env internal.Env) (Blob, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(env.S3Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Host == "" || env.S3Bucket == "" {
+		return nil, errors.New("the S3 endpoint and bucket are required")
+	}
+
+	return s3Blob{
+		client:   &http.Client{},
+		endpoint: endpoint,
+		bucket:   env.S3Bucket,
+		credentials: s3Credentials{
+			region:          env.S3Region,
+			accessKeyID:     env.S3AccessKeyID,
+			secretAccessKey: env.S3SecretAccessKey,
+		},
+	}, nil
+}
+
+func (s s3Blob) Stat(path string) (ObjectInfo, error) {
+	res, err := s.do(http.MethodHead, toKey(path), nil, nil, nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	_ = res.Body.Close()
+
+	modTime, _ := http.ParseTime(res.Header.Get("Last-Modified"))
+	return ObjectInfo{
+		Size:    res.ContentLength,
+		ModTime: modTime,
+		ETag:    res.Header.Get("ETag"),
+	}, nil
+}
+
+func (s s3Blob) Open(path string) (io.ReadSeekCloser, ObjectInfo, error) {
+	info, err := s.Stat(path)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return &s3ObjectReader{blob: s, key: toKey(path), size: info.Size}, info, nil
+}
+
+// Write streams the content in parts, so that it never has to be fully kept in memory
+func (s s3Blob) Write(path string, content io.Reader) error {
+	key := toKey(path)
+	buffer := make([]byte, s3PartSize)
+	n, err := io.ReadFull(content, buffer)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return s.putObject(key, buffer[:n])
+	}
+	if err != nil {
+		return err
+	}
+
+	uploadID, err := s.createMultipartUpload(key)
+	if err != nil {
+		return err
+	}
+	var parts []s3CompletedPart
+	for partNumber := 1; n > 0; partNumber++ {
+		etag, err := s.uploadPart(key, uploadID, partNumber, buffer[:n])
+		if err != nil {
+			s.abortMultipartUpload(key, uploadID)
+			return err
+		}
+		parts = append(parts, s3CompletedPart{PartNumber: partNumber, ETag: etag})
+
+		n, err = io.ReadFull(content, buffer)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			s.abortMultipartUpload(key, uploadID)
+			return err
+		}
+	}
+	return s.completeMultipartUpload(key, uploadID, parts)
+}
+
+// Move copies the object to the destination and then deletes the source, as S3 cannot rename objects
+func (s s3Blob) Move(sourcePath string, destinationPath string) error {
+	if _, err := s.Stat(sourcePath); err != nil {
+		return err
+	}
+	// CopyObject can respond with 200 and still fail, in which case the source is kept
+	header := http.Header{"X-Amz-Copy-Source": {uriEncode(s.bucket+"/"+toKey(sourcePath), true)}}
+	var result struct {
+		XMLName xml.Name
+		Message string
+	}
+	err := s.doXML(http.MethodPut, toKey(destinationPath), nil, header, nil, &result)
+	if err == nil && result.XMLName.Local == "Error" {
+		err = errors.New("failed to copy " + sourcePath + ": " + result.Message)
+	}
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(http.MethodDelete, toKey(sourcePath), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// Delete checks that the object exists first, as S3 does not complain when deleting a missing object
+func (s s3Blob) Delete(path string) error {
+	if _, err := s.Stat(path); err != nil {
+		return err
+	}
+	res, err := s.do(http.MethodDelete, toKey(path), nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// DeleteDirectory deletes all the objects under the prefix, as there are no actual directories in S3
+func (s s3Blob) DeleteDirectory(path string) error {
+	prefix := toKey(path) + "/"
+	hasObjects := false
+	continuationToken := ""
+	for {
+		list, err := s.listObjects(prefix, continuationToken)
+		if err != nil {
+			return err
+		}
+		if len(list.Contents) > 0 {
+			hasObjects = true
+			objects := make([]s3Object, len(list.Contents))
+			for i, content := range list.Contents {
+				objects[i] = s3Object{Key: content.Key}
+			}
+			if err = s.deleteObjects(objects); err != nil {
+				return err
+			}
+		}
+		if !list.IsTruncated {
+			break
+		}
+		continuationToken = list.NextContinuationToken
+	}
+
+	if !hasObjects {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s s3Blob) List(directoryPath string) ([]ObjectEntry, error) {
+	prefix := toKey(directoryPath) + "/"
+	var entries []ObjectEntry
+	continuationToken := ""
+	for {
+		list, err := s.listObjects(prefix, continuationToken)
+		if err != nil {
+			return nil, err
+		}
+		for _, content := range list.Contents {
+			entries = append(entries, ObjectEntry{Path: content.Key, Size: content.Size, ModTime: content.LastModified})
+		}
+		if !list.IsTruncated {
+			return entries, nil
+		}
+		continuationToken = list.NextContinuationToken
+	}
+}
+
+// Requests
+
+type s3CompletedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+type s3Object struct {
+	Key string
+}
+
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+func (s s3Blob) putObject(key string, content []byte) error {
+	res, err := s.do(http.MethodPut, key, nil, nil, content)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+func (s s3Blob) createMultipartUpload(key string) (string, error) {
+	var result struct{ UploadId string }
+	err := s.doXML(http.MethodPost, key, url.Values{"uploads": {""}}, nil, nil, &result)
+	return result.UploadId, err
+}
+
+func (s s3Blob) uploadPart(key string, uploadID string, partNumber int, content []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadID}}
+	res, err := s.do(http.MethodPut, key, query, nil, content)
+	if err != nil {
+		return "", err
+	}
+	_ = res.Body.Close()
+	return res.Header.Get("ETag"), nil
+}
+
+func (s s3Blob) completeMultipartUpload(key string, uploadID string, parts []s3CompletedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+		Parts   []s3CompletedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	// S3 can still fail the completion after it already responded with 200, so the body has to be checked too
+	var result struct {
+		XMLName xml.Name
+		Message string
+	}
+	err = s.doXML(http.MethodPost, key, url.Values{"uploadId": {uploadID}}, nil, body, &result)
+	if err == nil && result.XMLName.Local == "Error" {
+		err = errors.New("failed to complete the upload of " + key + ": " + result.Message)
+	}
+	return err
+}
+
+// abortMultipartUpload only tries to clean up the uploaded parts, as the upload has already failed anyway
+func (s s3Blob) abortMultipartUpload(key string, uploadID string) {
+	res, err := s.do(http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if err == nil {
+		_ = res.Body.Close()
+	}
+}
+
+func (s s3Blob) listObjects(prefix string, continuationToken string) (s3ListBucketResult, error) {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if continuationToken != "" {
+		query.Set("continuation-token", continuationToken)
+	}
+	var result s3ListBucketResult
+	err := s.doXML(http.MethodGet, "", query, nil, nil, &result)
+	return result, err
+}
+
+func (s s3Blob) deleteObjects(objects []s3Object) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name   `xml:"Delete"`
+		Quiet   bool       `xml:"Quiet"`
+		Objects []s3Object `xml:"Object"`
+	}{Quiet: true, Objects: objects})
+	if err != nil {
+		return err
+	}
+
+	hash := md5.Sum(body)
+	header := http.Header{"Content-Md5": {base64.StdEncoding.EncodeToString(hash[:])}}
+	var result struct {
+		Errors []struct {
+			Key     string
+			Message string
+		} `xml:"Error"`
+	}
+	err = s.doXML(http.MethodPost, "", url.Values{"delete": {""}}, header, body, &result)
+	if err == nil && len(result.Errors) > 0 {
+		err = errors.New("failed to delete " + result.Errors[0].Key + ": " + result.Errors[0].Message)
+	}
+	return err
+}
+
+func (s s3Blob) doXML(method string, key string, query url.Values, header http.Header, body []byte, result any) error {
+	res, err := s.do(method, key, query, header, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	return xml.NewDecoder(res.Body).Decode(result)
+}
+
+// do sends the signed request for the object with the key (or for the bucket, when the key is empty),
+// and it returns ErrNotFound when the object does not exist
+func (s s3Blob) do(method string, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	requestURL := *s.endpoint
+	requestURL.Path = s.endpoint.Path + "/" + s.bucket
+	requestURL.RawPath = uriEncode(s.endpoint.Path, true) + "/" + uriEncode(s.bucket, true)
+	if key != "" {
+		requestURL.Path += "/" + key
+		requestURL.RawPath += "/" + uriEncode(key, true)
+	}
+	requestURL.RawQuery = canonicalQuery(query)
+
+	var bodyReader io.Reader
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+		payloadHash = hashHex(body)
+	}
+	req, err := http.NewRequest(method, requestURL.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.credentials.sign(req, payloadHash, time.Now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		_ = res.Body.Close()
+		return nil, ErrNotFound
+	}
+	if res.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		_ = res.Body.Close()
+		return nil, errors.New("S3 " + method + " " + key + " failed with " + res.Status + ": " + string(message))
+	}
+	return res, nil
+}
+
+// canonicalQuery encodes the query sorted by key, as it has to be the same for signing and for sending it
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var parameters []string
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parameters = append(parameters, uriEncode(key, false)+"="+uriEncode(value, false))
+		}
+	}
+	return strings.Join(parameters, "&")
+}
+
+// Reader
+
+// s3ObjectReader reads the object lazily, from the current offset,
+// so that seeking (e.g. for range requests) only downloads the requested part
+type s3ObjectReader struct {
+	blob   s3Blob
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (r *s3ObjectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		header := http.Header{"Range": {"bytes=" + strconv.FormatInt(r.offset, 10) + "-"}}
+		res, err := r.blob.do(http.MethodGet, r.key, nil, header, nil)
+		if err != nil {
+			return 0, err
+		}
+		r.body = res.Body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *s3ObjectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	if offset != r.offset {
+		_ = r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *s3ObjectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/storage/internal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Utils
+
+// fakeS3 is an in-memory stand-in for an S3-compatible storage (like MinIO),
+// which implements only the requests that the blob makes
+type fakeS3 struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	uploads  map[string]map[int][]byte
+	requests []string
+	pageSize int
+}
+
+var fakeS3LastModified = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newFakeS3Blob(t *testing.T) (*fakeS3, Blob) {
+	fake := &fakeS3{objects: map[string][]byte{}, uploads: map[string]map[int][]byte{}, pageSize: 2}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	blob, err := newS3Blob(internal.Env{
+		S3Endpoint:        server.URL,
+		S3Region:          "us-east-1",
+		S3Bucket:          "bucket",
+		S3AccessKeyID:     "access-key",
+		S3SecretAccessKey: "secret-key",
+	})
+	assert.NoError(t, err)
+	return fake, blob
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/") ||
+		r.Header.Get("X-Amz-Content-Sha256") != hashHex(body) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/bucket"), "/")
+	query := r.URL.Query()
+	f.requests = append(f.requests, r.Method+" "+r.URL.RawQuery)
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, query.Get("prefix")) && k > query.Get("continuation-token") {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		isTruncated := len(keys) > f.pageSize
+		keys = keys[:min(len(keys), f.pageSize)]
+		_, _ = fmt.Fprint(w, "<ListBucketResult>")
+		for _, k := range keys {
+			_, _ = fmt.Fprintf(w, "<Contents><Key>%s</Key><Size>%d</Size><LastModified>%s</LastModified></Contents>",
+				k, len(f.objects[k]), fakeS3LastModified.Format(time.RFC3339))
+		}
+		if isTruncated {
+			_, _ = fmt.Fprintf(w, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[len(keys)-1])
+		}
+		_, _ = fmt.Fprint(w, "</ListBucketResult>")
+
+	case r.Method == http.MethodPost && query.Has("delete"):
+		var request struct {
+			Objects []s3Object `xml:"Object"`
+		}
+		_ = xml.Unmarshal(body, &request)
+		for _, object := range request.Objects {
+			delete(f.objects, object.Key)
+		}
+		_, _ = fmt.Fprint(w, "<DeleteResult></DeleteResult>")
+
+	case r.Method == http.MethodPost && query.Has("uploads"):
+		uploadID := "upload-" + strconv.Itoa(len(f.uploads))
+		f.uploads[uploadID] = map[int][]byte{}
+		_, _ = fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", uploadID)
+
+	case r.Method == http.MethodPut && query.Has("uploadId"):
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		f.uploads[query.Get("uploadId")][partNumber] = body
+		w.Header().Set("ETag", fmt.Sprintf(`"part-%d"`, partNumber))
+
+	case r.Method == http.MethodPost && query.Has("uploadId"):
+		var request struct {
+			Parts []s3CompletedPart `xml:"Part"`
+		}
+		_ = xml.Unmarshal(body, &request)
+		var content []byte
+		for _, part := range request.Parts {
+			content = append(content, f.uploads[query.Get("uploadId")][part.PartNumber]...)
+		}
+		f.objects[key] = content
+		_, _ = fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		content, ok := f.objects[strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "bucket/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.objects[key] = content
+		_, _ = fmt.Fprint(w, "<CopyObjectResult></CopyObjectResult>")
+
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		content, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", fmt.Sprintf(`"%x"`, hashHex(content)[:16]))
+		http.ServeContent(w, r, key, fakeS3LastModified, bytes.NewReader(content))
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Tests
+
+func TestS3Blob_WhenEndpointOrBucketIsMissing_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name string
+		env  internal.Env
+	}{
+		{"Missing Endpoint", internal.Env{S3Bucket: "bucket"}},
+		{"Missing Bucket", internal.Env{S3Endpoint: "http://localhost:9000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			_, err := newS3Blob(tt.env)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestS3Blob_Write_WhenContentIsSmall_ShouldPutTheObject(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+
+	path := "/some-user/my song (live).txt"
+	content := "This is a test file"
+
+	// when
+	err := _uut.Write(path, strings.NewReader(content))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(content), fake.objects["some-user/my song (live).txt"])
+	assert.Equal(t, []string{"PUT "}, fake.requests)
+}
+
+func TestS3Blob_Write_WhenContentIsLarge_ShouldUploadItInParts(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+
+	content := bytes.Repeat([]byte("0123456789"), s3PartSize/5+1)
+
+	// when
+	err := _uut.Write("some-user/song.mp3", bytes.NewReader(content))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, content, fake.objects["some-user/song.mp3"])
+	assert.Len(t, fake.uploads["upload-0"], 3)
+}
+
+func TestS3Blob_Open_WhenObjectExists_ShouldReadItFromAnyOffset(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+	fake.objects["some-user/file.txt"] = []byte("This is a test file")
+
+	// when
+	reader, info, err := _uut.Open("some-user/file.txt")
+
+	// then
+	assert.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	assert.Equal(t, int64(19), info.Size)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), info.ModTime)
+	assert.NotEmpty(t, info.ETag)
+
+	content, _ := io.ReadAll(reader)
+	assert.Equal(t, "This is a test file", string(content))
+
+	_, err = reader.Seek(10, io.SeekStart)
+	assert.NoError(t, err)
+	content, _ = io.ReadAll(reader)
+	assert.Equal(t, "test file", string(content))
+
+	end, _ := reader.Seek(0, io.SeekEnd)
+	assert.Equal(t, int64(19), end)
+}
+
+func TestS3Blob_WhenObjectIsMissing_ShouldReturnNotFound(t *testing.T) {
+	// given
+	_, _uut := newFakeS3Blob(t)
+
+	// when
+	_, statErr := _uut.Stat("some-user/file.txt")
+	_, _, openErr := _uut.Open("some-user/file.txt")
+	deleteErr := _uut.Delete("some-user/file.txt")
+	deleteDirectoryErr := _uut.DeleteDirectory("some-user/somewhere")
+
+	// then
+	assert.ErrorIs(t, statErr, ErrNotFound)
+	assert.ErrorIs(t, openErr, ErrNotFound)
+	assert.ErrorIs(t, deleteErr, ErrNotFound)
+	assert.ErrorIs(t, deleteDirectoryErr, ErrNotFound)
+}
+
+func TestS3Blob_Delete_WhenObjectExists_ShouldDeleteIt(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+	fake.objects["some-user/file.txt"] = []byte("content")
+
+	// when
+	err := _uut.Delete("some-user/file.txt")
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, fake.objects)
+}
+
+func TestS3Blob_DeleteDirectory_WhenObjectsExist_ShouldDeleteAllOfThemUnderThePrefix(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+	fake.objects["some-user/somewhere/1.txt"] = []byte("1")
+	fake.objects["some-user/somewhere/2.txt"] = []byte("2")
+	fake.objects["some-user/somewhere/else/3.txt"] = []byte("3")
+	fake.objects["some-user/somewhere-else/4.txt"] = []byte("4")
+
+	// when
+	err := _uut.DeleteDirectory("some-user/somewhere")
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, fake.objects, 1)
+	assert.Contains(t, fake.objects, "some-user/somewhere-else/4.txt")
+}
+
+func TestS3Blob_List_ShouldReturnAllTheObjectsUnderThePrefix(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+	fake.objects["some-user/somewhere/1.txt"] = []byte("1")
+	fake.objects["some-user/somewhere/2.txt"] = []byte("22")
+	fake.objects["some-user/somewhere/else/3.txt"] = []byte("333")
+	fake.objects["some-user/somewhere-else/4.txt"] = []byte("4444")
+
+	// when
+	result, err := _uut.List("some-user/somewhere")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectEntry{
+		{Path: "some-user/somewhere/1.txt", Size: 1, ModTime: fakeS3LastModified},
+		{Path: "some-user/somewhere/2.txt", Size: 2, ModTime: fakeS3LastModified},
+		{Path: "some-user/somewhere/else/3.txt", Size: 3, ModTime: fakeS3LastModified},
+	}, result)
+}
+
+func TestS3Blob_Move_WhenObjectExists_ShouldCopyItAndDeleteTheSource(t *testing.T) {
+	// given
+	fake, _uut := newFakeS3Blob(t)
+	fake.objects["some-user/file.txt"] = []byte("content")
+
+	// when
+	err := _uut.Move("some-user/file.txt", ".quarantine/some-user/file.txt")
+	missingErr := _uut.Move("some-user/else.txt", ".quarantine/some-user/else.txt")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{".quarantine/some-user/file.txt": []byte("content")}, fake.objects)
+
+	assert.ErrorIs(t, missingErr, ErrNotFound)
+}
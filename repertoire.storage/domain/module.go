@@ -7,5 +7,9 @@ import (
 )
 
 var Module = fx.Options(
+	fx.Provide(service.NewImageService),
 	fx.Provide(service.NewJwtService),
+	fx.Provide(service.NewRevokedTokenService),
+	fx.Provide(service.NewSignedUrlService),
+	fx.Provide(service.NewStorageUsageService),
 )
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"repertoire/storage/internal"
+	"slices"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// the images are normalized to JPEG, as there is no WebP encoder in Go
+const imageQuality = 85
+
+// the longest side of the normalized image, so that huge photos do not have to be stored in full
+const imageMaxSize = 2048
+
+// the images are decoded in memory (with a few copies while being processed), so their pixels are capped,
+// as a small compressed file can still declare huge dimensions (e.g. decompression bombs)
+const imageMaxPixels = 40_000_000
+
+var ErrImageTooLarge = errors.New("image is too large")
+
+var supportedImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+type ProcessedImage struct {
+	Image    []byte
+	Variants map[int][]byte
+}
+
+type ImageService interface {
+	Process(content []byte) (ProcessedImage, error)
+}
+
+type imageService struct {
+}
+
+func NewImageService() ImageService {
+	return imageService{}
+}
+
+// Process validates that the content is an image (by its content, not by its extension),
+// and re-encodes it to JPEG, alongside its resized variants.
+// Re-encoding also strips the metadata (e.g. EXIF), after applying its orientation.
+func (i imageService) Process(content []byte) (ProcessedImage, error) {
+	contentType := http.DetectContentType(content)
+	if !slices.Contains(supportedImageTypes, contentType) {
+		return ProcessedImage{}, errors.New("unsupported image type: " + contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return ProcessedImage{}, errors.New("invalid image: " + err.Error())
+	}
+	if config.Width*config.Height > imageMaxPixels {
+		return ProcessedImage{}, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return ProcessedImage{}, errors.New("invalid image: " + err.Error())
+	}
+	if contentType == "image/jpeg" {
+		img = orient(img, exifOrientation(content))
+	}
+	img = flatten(img)
+
+	result := ProcessedImage{Variants: map[int][]byte{}}
+	result.Image, err = encode(resize(img, imageMaxSize))
+	if err != nil {
+		return ProcessedImage{}, err
+	}
+	for _, size := range internal.ImageVariantSizes {
+		result.Variants[size], err = encode(resize(img, size))
+		if err != nil {
+			return ProcessedImage{}, err
+		}
+	}
+	return result, nil
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: imageQuality})
+	return buffer.Bytes(), err
+}
+
+// flatten draws the image on a white background, as JPEG has no transparency
+func flatten(img image.Image) image.Image {
+	flattened := image.NewRGBA(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()))
+	draw.Draw(flattened, flattened.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flattened, flattened.Bounds(), img, img.Bounds().Min, draw.Over)
+	return flattened
+}
+
+// resize scales the image down, so that its longest side fits the size, but it never scales it up
+func resize(img image.Image, size int) image.Image {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*size/width)
+		width = size
+	} else {
+		width = max(1, width*size/height)
+		height = size
+	}
+	resized := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return resized
+}
+
+// orient rotates and flips the image, so that it is displayed the same way without the EXIF orientation
+func orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	oriented := image.NewRGBA(image.Rect(0, 0, width, height))
+	if orientation >= 5 {
+		oriented = image.NewRGBA(image.Rect(0, 0, height, width))
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = width-1-x, y
+			case 3: // rotated 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, height-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // transversed
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90° counterclockwise
+				dx, dy = y, width-1-x
+			}
+			oriented.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return oriented
+}
+
+// exifOrientation reads the orientation tag from the EXIF segment of the JPEG, and it defaults to 1 (normal)
+func exifOrientation(content []byte) int {
+	const orientationTag = 0x0112
+
+	// look for the APP1 segment, before the image data starts
+	for i := 2; i+4 <= len(content) && content[i] == 0xFF; {
+		marker := content[i+1]
+		length := int(binary.BigEndian.Uint16(content[i+2:]))
+		if marker == 0xDA || i+2+length > len(content) {
+			break
+		}
+		segment := content[i+4 : i+2+length]
+		i += 2 + length
+
+		if marker != 0xE1 || !bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			continue
+		}
+		tiff := segment[6:]
+		if len(tiff) < 8 {
+			return 1
+		}
+
+		var order binary.ByteOrder = binary.BigEndian
+		if string(tiff[:2]) == "II" {
+			order = binary.LittleEndian
+		}
+		offset := int(order.Uint32(tiff[4:]))
+		if offset+2 > len(tiff) {
+			return 1
+		}
+		entries := int(order.Uint16(tiff[offset:]))
+		for e := 0; e < entries; e++ {
+			entry := offset + 2 + e*12
+			if entry+12 > len(tiff) {
+				return 1
+			}
+			if order.Uint16(tiff[entry:]) == orientationTag {
+				return int(order.Uint16(tiff[entry+8:]))
+			}
+		}
+		return 1
+	}
+	return 1
+}
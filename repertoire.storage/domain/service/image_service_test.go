@@ -0,0 +1,166 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"repertoire/storage/internal"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Utils
+
+func createImage(width int, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	return img
+}
+
+// withExifOrientation inserts an EXIF segment with the orientation tag, right after the start of the JPEG
+func withExifOrientation(content []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2A\x00\x00\x00\x08")       // big endian header, with IFD0 right after it
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)      // number of entries
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112) // orientation tag
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)      // short type
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)      // count
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // value padding and next IFD
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, content[:2]...)
+	result = append(result, app1...)
+	return append(result, content[2:]...)
+}
+
+// withDimensions rewrites the header of the PNG, so that it declares other dimensions than the ones it has
+func withDimensions(content []byte, width uint32, height uint32) []byte {
+	result := append([]byte{}, content...)
+	header := result[12:29] // the IHDR chunk type and data, right after the signature and the chunk length
+	binary.BigEndian.PutUint32(header[4:], width)
+	binary.BigEndian.PutUint32(header[8:], height)
+	binary.BigEndian.PutUint32(result[29:], crc32.ChecksumIEEE(header))
+	return result
+}
+
+func decode(t *testing.T, content []byte) image.Image {
+	assert.Equal(t, "image/jpeg", http.DetectContentType(content))
+	img, err := jpeg.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+	return img
+}
+
+// Tests
+
+func TestImageService_Process_WhenContentIsNotAnImage_ShouldReturnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{"Text", []byte("This is not an image, even if it is named like one")},
+		{"Corrupted Image", []byte("\x89PNG\r\n\x1a\nthe rest of the image is missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := NewImageService()
+
+			// when
+			result, err := _uut.Process(tt.content)
+
+			// then
+			assert.Error(t, err)
+			assert.Empty(t, result)
+		})
+	}
+}
+
+func TestImageService_Process_WhenImageHasTooManyPixels_ShouldReturnImageTooLargeError(t *testing.T) {
+	// given
+	_uut := NewImageService()
+
+	var content bytes.Buffer
+	_ = png.Encode(&content, createImage(10, 10))
+
+	// when
+	result, err := _uut.Process(withDimensions(content.Bytes(), 50_000, 50_000))
+
+	// then
+	assert.ErrorIs(t, err, ErrImageTooLarge)
+	assert.Empty(t, result)
+}
+
+func TestImageService_Process_WhenImageIsLarge_ShouldReturnResizedJpegAndVariants(t *testing.T) {
+	// given
+	_uut := NewImageService()
+
+	var content bytes.Buffer
+	_ = png.Encode(&content, createImage(3000, 1500))
+
+	// when
+	result, err := _uut.Process(content.Bytes())
+
+	// then
+	assert.NoError(t, err)
+
+	img := decode(t, result.Image)
+	assert.Equal(t, 2048, img.Bounds().Dx())
+	assert.Equal(t, 1024, img.Bounds().Dy())
+
+	assert.Len(t, result.Variants, len(internal.ImageVariantSizes))
+	for _, size := range internal.ImageVariantSizes {
+		variant := decode(t, result.Variants[size])
+		assert.Equal(t, size, variant.Bounds().Dx())
+		assert.Equal(t, size/2, variant.Bounds().Dy())
+	}
+}
+
+func TestImageService_Process_WhenImageIsSmall_ShouldNotScaleItUp(t *testing.T) {
+	// given
+	_uut := NewImageService()
+
+	var content bytes.Buffer
+	_ = png.Encode(&content, createImage(100, 50))
+
+	// when
+	result, err := _uut.Process(content.Bytes())
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), decode(t, result.Image).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 64, 32), decode(t, result.Variants[64]).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 100, 50), decode(t, result.Variants[256]).Bounds())
+	assert.Equal(t, image.Rect(0, 0, 100, 50), decode(t, result.Variants[1024]).Bounds())
+}
+
+func TestImageService_Process_WhenJpegHasExifOrientation_ShouldApplyItAndStripTheMetadata(t *testing.T) {
+	// given
+	_uut := NewImageService()
+
+	var content bytes.Buffer
+	_ = jpeg.Encode(&content, createImage(200, 100), nil)
+	jpegWithExif := withExifOrientation(content.Bytes(), 6)
+
+	// when
+	result, err := _uut.Process(jpegWithExif)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 200), decode(t, result.Image).Bounds())
+	assert.NotContains(t, string(result.Image), "Exif")
+}
@@ -11,7 +11,9 @@ import (
 )
 
 type JwtService interface {
-	Authorize(authToken string) error
+	// Authorize validates the token and returns the directory that the token is allowed to write into,
+	// alongside the ID of the token (jti)
+	Authorize(authToken string) (string, uuid.UUID, error)
 }
 
 type jwtService struct {
@@ -26,7 +28,7 @@ func NewJwtService(env internal.Env, logger *logger.Logger) JwtService {
 	}
 }
 
-func (j jwtService) Authorize(authToken string) error {
+func (j jwtService) Authorize(authToken string) (string, uuid.UUID, error) {
 	token, _ := jwt.Parse(authToken, func(t *jwt.Token) (interface{}, error) {
 		return []byte(j.env.JwtSecretKey), nil
 	})
@@ -34,11 +36,12 @@ func (j jwtService) Authorize(authToken string) error {
 	if token != nil && token.Valid {
 		if err := j.validateToken(token); err != nil {
 			j.logger.Warn("Invalid Token", zap.Error(err), zap.String("token", authToken))
-			return err
+			return "", uuid.Nil, err
 		}
-		return nil
+		claims := token.Claims.(jwt.MapClaims)
+		return claims["prefix"].(string), uuid.MustParse(claims["jti"].(string)), nil
 	}
-	return errors.New("invalid token")
+	return "", uuid.Nil, errors.New("invalid token")
 }
 
 func (j jwtService) validateToken(token *jwt.Token) error {
@@ -101,5 +104,14 @@ func (j jwtService) validateToken(token *jwt.Token) error {
 		return errors.New("invalid sub")
 	}
 
+	// prefix
+	prefix, prefixFound := token.Claims.(jwt.MapClaims)["prefix"].(string)
+	if !prefixFound {
+		return errors.New("missing prefix")
+	}
+	if prefix == "" || internal.HasPathTraversal(prefix) {
+		return errors.New("invalid prefix")
+	}
+
 	return nil
 }
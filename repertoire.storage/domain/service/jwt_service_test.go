@@ -151,6 +151,42 @@ func TestJwtService_Authorize_WhenTokenIsInvalid_ShouldReturnError(t *testing.T)
 			}),
 			env.JwtSecretKey,
 		},
+		// prefix
+		{
+			"When prefix is missing",
+			jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"aud": env.JwtAudience,
+				"iss": env.JwtIssuer,
+				"exp": time.Now().UTC().Add(time.Minute).Unix(),
+				"jti": uuid.New().String(),
+				"sub": uuid.New().String(),
+			}),
+			env.JwtSecretKey,
+		},
+		{
+			"When prefix is empty",
+			jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"aud":    env.JwtAudience,
+				"iss":    env.JwtIssuer,
+				"exp":    time.Now().UTC().Add(time.Minute).Unix(),
+				"jti":    uuid.New().String(),
+				"sub":    uuid.New().String(),
+				"prefix": "",
+			}),
+			env.JwtSecretKey,
+		},
+		{
+			"When prefix is outside the uploads",
+			jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"aud":    env.JwtAudience,
+				"iss":    env.JwtIssuer,
+				"exp":    time.Now().UTC().Add(time.Minute).Unix(),
+				"jti":    uuid.New().String(),
+				"sub":    uuid.New().String(),
+				"prefix": "../something",
+			}),
+			env.JwtSecretKey,
+		},
 	}
 
 	for _, tt := range tests {
@@ -161,10 +197,12 @@ func TestJwtService_Authorize_WhenTokenIsInvalid_ShouldReturnError(t *testing.T)
 			token, _ := tt.claims.SignedString([]byte(tt.secretKey))
 
 			// when
-			err := _uut.Authorize(token)
+			prefix, tokenID, err := _uut.Authorize(token)
 
 			// then
 			assert.Error(t, err)
+			assert.Empty(t, prefix)
+			assert.Empty(t, tokenID)
 		})
 	}
 }
@@ -183,12 +221,13 @@ func TestJwtService_Authorize_WhenSuccessful_ShouldNotReturnError(t *testing.T)
 		{
 			"Normal Token",
 			jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-				"jti": uuid.New().String(),
-				"sub": uuid.New().String(),
-				"iss": env.JwtIssuer,
-				"aud": env.JwtAudience,
-				"iat": time.Now().UTC().Unix(),
-				"exp": time.Now().UTC().Add(time.Minute).Unix(),
+				"jti":    uuid.New().String(),
+				"sub":    uuid.New().String(),
+				"iss":    env.JwtIssuer,
+				"aud":    env.JwtAudience,
+				"iat":    time.Now().UTC().Unix(),
+				"exp":    time.Now().UTC().Add(time.Minute).Unix(),
+				"prefix": uuid.New().String(),
 			}),
 		},
 	}
@@ -201,10 +240,12 @@ func TestJwtService_Authorize_WhenSuccessful_ShouldNotReturnError(t *testing.T)
 			token, _ := tt.claims.SignedString([]byte(env.JwtSecretKey))
 
 			// when
-			err := _uut.Authorize(token)
+			prefix, tokenID, err := _uut.Authorize(token)
 
 			// then
 			assert.NoError(t, err)
+			assert.Equal(t, tt.claims.Claims.(jwt.MapClaims)["prefix"], prefix)
+			assert.Equal(t, tt.claims.Claims.(jwt.MapClaims)["jti"], tokenID.String())
 		})
 	}
 }
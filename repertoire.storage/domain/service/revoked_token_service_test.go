@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"repertoire/storage/data/logger"
+	"repertoire/storage/internal"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Utils
+
+type fakeAuth struct {
+	revokedTokens []revokedTokenResponse
+	failing       atomic.Bool
+	requests      atomic.Int32
+}
+
+func newFakeAuth(t *testing.T, revokedTokens ...revokedTokenResponse) (*fakeAuth, string) {
+	auth := &fakeAuth{revokedTokens: revokedTokens}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.requests.Add(1)
+		if r.URL.Path != "/auth/revoked-tokens" || auth.failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(auth.revokedTokens)
+	}))
+	t.Cleanup(server.Close)
+	return auth, server.URL + "/auth"
+}
+
+// Tests
+
+func TestRevokedTokenService_IsRevoked_WhenAuthUrlIsMissing_ShouldReturnFalse(t *testing.T) {
+	// given
+	_uut := NewRevokedTokenService(internal.Env{}, logger.NewLoggerMock())
+
+	// when
+	revoked := _uut.IsRevoked(uuid.New())
+
+	// then
+	assert.False(t, revoked)
+}
+
+func TestRevokedTokenService_IsRevoked_ShouldCheckTheRevocationList(t *testing.T) {
+	revokedTokenID := uuid.New()
+	expiredTokenID := uuid.New()
+	_, authUrl := newFakeAuth(
+		t,
+		revokedTokenResponse{ID: revokedTokenID, ExpiresAt: time.Now().UTC().Add(time.Hour)},
+		revokedTokenResponse{ID: expiredTokenID, ExpiresAt: time.Now().UTC().Add(-time.Minute)},
+	)
+
+	tests := []struct {
+		name     string
+		tokenID  uuid.UUID
+		expected bool
+	}{
+		{"when token is revoked", revokedTokenID, true},
+		{"when the revocation has expired", expiredTokenID, false},
+		{"when token is not revoked", uuid.New(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := NewRevokedTokenService(internal.Env{AuthUrl: authUrl}, logger.NewLoggerMock())
+
+			// when
+			revoked := _uut.IsRevoked(tt.tokenID)
+
+			// then
+			assert.Equal(t, tt.expected, revoked)
+		})
+	}
+}
+
+func TestRevokedTokenService_IsRevoked_WhenListIsFresh_ShouldNotFetchItAgain(t *testing.T) {
+	// given
+	revokedTokenID := uuid.New()
+	auth, authUrl := newFakeAuth(t, revokedTokenResponse{ID: revokedTokenID, ExpiresAt: time.Now().UTC().Add(time.Hour)})
+
+	env := internal.Env{AuthUrl: authUrl, RevokedTokensRefreshInterval: "1h"}
+	_uut := NewRevokedTokenService(env, logger.NewLoggerMock())
+
+	// when
+	_uut.IsRevoked(revokedTokenID)
+	revoked := _uut.IsRevoked(revokedTokenID)
+
+	// then
+	assert.True(t, revoked)
+	assert.Equal(t, int32(1), auth.requests.Load())
+}
+
+func TestRevokedTokenService_IsRevoked_WhenFetchFails_ShouldKeepTheLastKnownList(t *testing.T) {
+	// given
+	revokedTokenID := uuid.New()
+	auth, authUrl := newFakeAuth(t, revokedTokenResponse{ID: revokedTokenID, ExpiresAt: time.Now().UTC().Add(time.Hour)})
+
+	env := internal.Env{AuthUrl: authUrl, RevokedTokensRefreshInterval: "1ns"}
+	_uut := NewRevokedTokenService(env, logger.NewLoggerMock())
+	_uut.IsRevoked(revokedTokenID)
+
+	auth.failing.Store(true)
+
+	// when
+	revoked := _uut.IsRevoked(revokedTokenID)
+
+	// then
+	assert.True(t, revoked)
+	assert.Equal(t, int32(2), auth.requests.Load())
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"repertoire/storage/internal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SignedUrlService interface {
+	Verify(filePath string, expires string, signature string) error
+}
+
+type signedUrlService struct {
+	env internal.Env
+}
+
+func NewSignedUrlService(env internal.Env) SignedUrlService {
+	return signedUrlService{env: env}
+}
+
+// Verify checks that the URL of the file has been signed by the server (with the shared secret key)
+// and that it has not expired yet
+func (s signedUrlService) Verify(filePath string, expires string, signature string) error {
+	if s.env.SignedUrlSecretKey == "" {
+		return errors.New("signed urls are not configured")
+	}
+	if expires == "" || signature == "" {
+		return errors.New("missing signature")
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New("invalid expiration time")
+	}
+	if time.Now().UTC().Unix() > expiresAt {
+		return errors.New("signature has expired")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.env.SignedUrlSecretKey))
+	mac.Write([]byte(strings.TrimPrefix(filePath, "/") + ":" + expires))
+	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"repertoire/storage/internal"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Utils
+
+func sign(secretKey string, filePath string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(filePath + ":" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Tests
+
+func TestSignedUrlService_Verify_WhenSignatureIsInvalid_ShouldReturnError(t *testing.T) {
+	env := internal.Env{
+		SignedUrlSecretKey: "This-is-a-very-long-secret-key-that-is-used-to-sign-the-urls",
+	}
+
+	filePath := "some-user/some-file.png"
+	expires := strconv.FormatInt(time.Now().UTC().Add(time.Minute).Unix(), 10)
+	expired := strconv.FormatInt(time.Now().UTC().Add(-time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		filePath  string
+		expires   string
+		signature string
+	}{
+		{
+			"When Expires is missing",
+			filePath,
+			"",
+			sign(env.SignedUrlSecretKey, filePath, expires),
+		},
+		{
+			"When Signature is missing",
+			filePath,
+			expires,
+			"",
+		},
+		{
+			"When Expires is not a number",
+			filePath,
+			"something",
+			sign(env.SignedUrlSecretKey, filePath, "something"),
+		},
+		{
+			"When Signature has expired",
+			filePath,
+			expired,
+			sign(env.SignedUrlSecretKey, filePath, expired),
+		},
+		{
+			"When Secret Key is wrong",
+			filePath,
+			expires,
+			sign("wrong secret key", filePath, expires),
+		},
+		{
+			"When Signature is for another file",
+			filePath,
+			expires,
+			sign(env.SignedUrlSecretKey, "some-user/another-file.png", expires),
+		},
+		{
+			"When Expires has been changed",
+			filePath,
+			strconv.FormatInt(time.Now().UTC().Add(time.Hour).Unix(), 10),
+			sign(env.SignedUrlSecretKey, filePath, expires),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut := NewSignedUrlService(env)
+
+			// when
+			err := _uut.Verify(tt.filePath, tt.expires, tt.signature)
+
+			// then
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSignedUrlService_Verify_WhenSecretKeyIsMissing_ShouldReturnError(t *testing.T) {
+	// given
+	_uut := NewSignedUrlService(internal.Env{})
+
+	filePath := "some-user/some-file.png"
+	expires := strconv.FormatInt(time.Now().UTC().Add(time.Minute).Unix(), 10)
+	signature := sign("", filePath, expires)
+
+	// when
+	err := _uut.Verify("/"+filePath, expires, signature)
+
+	// then
+	assert.Error(t, err)
+}
+
+func TestSignedUrlService_Verify_WhenSuccessful_ShouldNotReturnError(t *testing.T) {
+	// given
+	env := internal.Env{
+		SignedUrlSecretKey: "This-is-a-very-long-secret-key-that-is-used-to-sign-the-urls",
+	}
+	_uut := NewSignedUrlService(env)
+
+	filePath := "some-user/some-file.png"
+	expires := strconv.FormatInt(time.Now().UTC().Add(time.Minute).Unix(), 10)
+	signature := sign(env.SignedUrlSecretKey, filePath, expires)
+
+	// when
+	err := _uut.Verify("/"+filePath, expires, signature)
+
+	// then
+	assert.NoError(t, err)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"repertoire/storage/data/storage"
+	"repertoire/storage/internal"
+	"sync"
+)
+
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+type StorageUsage struct {
+	Size  int64                 `json:"size"`
+	Quota int64                 `json:"quota"`
+	Files []storage.ObjectEntry `json:"files"`
+}
+
+// StorageUsageService writes and deletes the files through the blob,
+// while it keeps track of the bytes used by each user directory (the first segment of the paths),
+// so that the uploads can be stopped as soon as they exceed the quota
+type StorageUsageService interface {
+	GetUsage(directoryPath string) (StorageUsage, error)
+	Write(filePath string, content io.Reader) error
+	Move(sourcePath string, destinationPath string) error
+	Delete(filePath string) error
+	DeleteDirectory(directoryPath string) error
+}
+
+type storageUsageService struct {
+	blob   storage.Blob
+	quota  int64
+	mutex  *sync.Mutex
+	usages map[string]int64
+}
+
+func NewStorageUsageService(blob storage.Blob, env internal.Env) StorageUsageService {
+	return storageUsageService{
+		blob:   blob,
+		quota:  env.UserStorageQuota,
+		mutex:  &sync.Mutex{},
+		usages: map[string]int64{},
+	}
+}
+
+// GetUsage lists all the files of the directory, and it also refreshes the tracked usage of the directory.
+// The resized copies of the images are not listed on their own, but counted in the size of their original
+func (s storageUsageService) GetUsage(directoryPath string) (StorageUsage, error) {
+	files, err := s.blob.List(directoryPath)
+	if err != nil {
+		return StorageUsage{}, err
+	}
+
+	usage := StorageUsage{Quota: s.quota, Files: []storage.ObjectEntry{}}
+	indexes := make(map[string]int, len(files))
+	var variants []storage.ObjectEntry
+	for _, file := range files {
+		usage.Size += file.Size
+		if _, isVariant := internal.ImageOriginalPath(file.Path); isVariant {
+			variants = append(variants, file)
+			continue
+		}
+		indexes[file.Path] = len(usage.Files)
+		usage.Files = append(usage.Files, file)
+	}
+	for _, variant := range variants {
+		originalPath, _ := internal.ImageOriginalPath(variant.Path)
+		if index, hasOriginal := indexes[originalPath]; hasOriginal {
+			usage.Files[index].Size += variant.Size
+		} else {
+			usage.Files = append(usage.Files, variant)
+		}
+	}
+
+	if directoryPath == internal.RootDirectory(directoryPath) {
+		s.mutex.Lock()
+		s.usages[directoryPath] = usage.Size
+		s.mutex.Unlock()
+	}
+	return usage, nil
+}
+
+// Write counts the bytes while they are written, and it fails with ErrStorageQuotaExceeded once they exceed the quota.
+// The file that is replaced is not counted against the quota, as it is going to be overwritten
+func (s storageUsageService) Write(filePath string, content io.Reader) error {
+	directory := internal.RootDirectory(filePath)
+	if err := s.loadUsage(directory); err != nil {
+		return err
+	}
+
+	var replacedSize int64
+	if info, err := s.blob.Stat(filePath); err == nil {
+		replacedSize = info.Size
+	}
+
+	reader := &quotaReader{service: s, directory: directory, reader: content, allowance: replacedSize}
+	err := s.blob.Write(filePath, reader)
+	if err != nil {
+		s.addUsage(directory, -reader.written)
+		return err
+	}
+
+	s.addUsage(directory, -replacedSize)
+	return nil
+}
+
+// Move moves the file (e.g. into the quarantine), and its size is moved to the usage of the destination directory
+func (s storageUsageService) Move(sourcePath string, destinationPath string) error {
+	info, err := s.blob.Stat(sourcePath)
+	if err != nil {
+		return err
+	}
+	if err = s.blob.Move(sourcePath, destinationPath); err != nil {
+		return err
+	}
+
+	s.addUsage(internal.RootDirectory(sourcePath), -info.Size)
+	s.addUsage(internal.RootDirectory(destinationPath), info.Size)
+	return nil
+}
+
+func (s storageUsageService) Delete(filePath string) error {
+	info, err := s.blob.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	if err = s.blob.Delete(filePath); err != nil {
+		return err
+	}
+
+	s.addUsage(internal.RootDirectory(filePath), -info.Size)
+	return nil
+}
+
+func (s storageUsageService) DeleteDirectory(directoryPath string) error {
+	files, err := s.blob.List(directoryPath)
+	if err != nil {
+		return err
+	}
+	if err = s.blob.DeleteDirectory(directoryPath); err != nil {
+		return err
+	}
+
+	var size int64
+	for _, file := range files {
+		size += file.Size
+	}
+	s.addUsage(internal.RootDirectory(directoryPath), -size)
+	return nil
+}
+
+// loadUsage computes the usage of the directory the first time it is needed (e.g. after a restart)
+func (s storageUsageService) loadUsage(directory string) error {
+	s.mutex.Lock()
+	_, isLoaded := s.usages[directory]
+	s.mutex.Unlock()
+	if isLoaded {
+		return nil
+	}
+
+	_, err := s.GetUsage(directory)
+	return err
+}
+
+// addUsage changes the tracked usage only when it has already been loaded, otherwise it is computed later anyway
+func (s storageUsageService) addUsage(directory string, size int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if usage, isLoaded := s.usages[directory]; isLoaded {
+		s.usages[directory] = max(0, usage+size)
+	}
+}
+
+// reserveUsage adds the size to the usage of the directory, unless it would exceed the quota (when there is one)
+func (s storageUsageService) reserveUsage(directory string, size int64, allowance int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	usage := s.usages[directory] + size
+	if s.quota > 0 && usage-allowance > s.quota {
+		return false
+	}
+	s.usages[directory] = usage
+	return true
+}
+
+type quotaReader struct {
+	service   storageUsageService
+	directory string
+	reader    io.Reader
+	allowance int64
+	written   int64
+}
+
+func (r *quotaReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && !r.service.reserveUsage(r.directory, int64(n), r.allowance) {
+		return 0, ErrStorageQuotaExceeded
+	}
+	r.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"repertoire/storage/data/storage"
+	"repertoire/storage/internal"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Utils
+
+func newStorageUsageService(t *testing.T, quota int64) (StorageUsageService, storage.Blob) {
+	env := internal.Env{UploadDirectory: t.TempDir(), UserStorageQuota: quota}
+	blob, _ := storage.NewBlob(env)
+	return NewStorageUsageService(blob, env), blob
+}
+
+// Tests
+
+func TestStorageUsageService_Write_WhenQuotaIsExceeded_ShouldNotWriteTheFile(t *testing.T) {
+	// given
+	_uut, blob := newStorageUsageService(t, 10)
+	_ = _uut.Write("some-user/first.txt", strings.NewReader("123456"))
+
+	// when
+	err := _uut.Write("some-user/second.txt", strings.NewReader("123456"))
+
+	// then
+	assert.ErrorIs(t, err, ErrStorageQuotaExceeded)
+
+	_, err = blob.Stat("some-user/second.txt")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	usage, _ := _uut.GetUsage("some-user")
+	assert.Equal(t, int64(6), usage.Size)
+	assert.Equal(t, int64(10), usage.Quota)
+}
+
+func TestStorageUsageService_Write_WhenQuotaIsNotExceeded_ShouldWriteTheFile(t *testing.T) {
+	tests := []struct {
+		name         string
+		quota        int64
+		filePath     string
+		expectedSize int64
+	}{
+		{"Another user", 10, "another-user/second.txt", 6},
+		{"Replaced file", 10, "some-user/first.txt", 6},
+		{"No quota", 0, "some-user/second.txt", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			_uut, _ := newStorageUsageService(t, tt.quota)
+			_ = _uut.Write("some-user/first.txt", strings.NewReader("123456"))
+
+			// when
+			err := _uut.Write(tt.filePath, strings.NewReader("abcdef"))
+
+			// then
+			assert.NoError(t, err)
+
+			usage, _ := _uut.GetUsage(internal.RootDirectory(tt.filePath))
+			assert.Equal(t, tt.expectedSize, usage.Size)
+		})
+	}
+}
+
+func TestStorageUsageService_Delete_ShouldFreeTheUsage(t *testing.T) {
+	// given
+	_uut, _ := newStorageUsageService(t, 10)
+	_ = _uut.Write("some-user/first.txt", strings.NewReader("123"))
+	_ = _uut.Write("some-user/somewhere/second.txt", strings.NewReader("456"))
+	_ = _uut.Write("some-user/somewhere/third.txt", strings.NewReader("789"))
+
+	// when
+	deleteErr := _uut.Delete("some-user/first.txt")
+	deleteDirectoryErr := _uut.DeleteDirectory("some-user/somewhere")
+
+	// then
+	assert.NoError(t, deleteErr)
+	assert.NoError(t, deleteDirectoryErr)
+
+	err := _uut.Write("some-user/fourth.txt", strings.NewReader("0123456789"))
+	assert.NoError(t, err)
+}
+
+func TestStorageUsageService_GetUsage_ShouldReturnAllTheFilesOfTheDirectory(t *testing.T) {
+	// given
+	_uut, _ := newStorageUsageService(t, 0)
+	_ = _uut.Write("some-user/first.txt", strings.NewReader("123"))
+	_ = _uut.Write("some-user/somewhere/second.txt", strings.NewReader("4567"))
+	_ = _uut.Write("another-user/third.txt", strings.NewReader("89"))
+
+	// when
+	usage, err := _uut.GetUsage("some-user")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), usage.Size)
+	assert.Equal(t, int64(0), usage.Quota)
+	assert.Len(t, usage.Files, 2)
+	assert.Equal(t, "some-user/first.txt", usage.Files[0].Path)
+	assert.Equal(t, int64(3), usage.Files[0].Size)
+	assert.Equal(t, "some-user/somewhere/second.txt", usage.Files[1].Path)
+	assert.Equal(t, int64(4), usage.Files[1].Size)
+}
+
+func TestStorageUsageService_GetUsage_ShouldCountTheImageVariantsInTheSizeOfTheirOriginal(t *testing.T) {
+	// given
+	_uut, _ := newStorageUsageService(t, 0)
+	_ = _uut.Write("some-user/image.jpg", strings.NewReader("123"))
+	_ = _uut.Write("some-user/image_64.jpg", strings.NewReader("4"))
+	_ = _uut.Write("some-user/image_256.jpg", strings.NewReader("56"))
+	_ = _uut.Write("some-user/lonely_64.jpg", strings.NewReader("7"))
+	_ = _uut.Write("some-user/file_64.txt", strings.NewReader("89"))
+
+	// when
+	usage, err := _uut.GetUsage("some-user")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), usage.Size)
+
+	sizes := map[string]int64{}
+	for _, file := range usage.Files {
+		sizes[file.Path] = file.Size
+	}
+	assert.Equal(t, map[string]int64{
+		"some-user/image.jpg":     6,
+		"some-user/lonely_64.jpg": 1,
+		"some-user/file_64.txt":   2,
+	}, sizes)
+}
+
+func TestStorageUsageService_Move_ShouldMoveTheUsageToTheDestinationDirectory(t *testing.T) {
+	// given
+	_uut, blob := newStorageUsageService(t, 10)
+	_ = _uut.Write("some-user/first.txt", strings.NewReader("123456"))
+
+	// when
+	err := _uut.Move("some-user/first.txt", internal.QuarantinePath("some-user/first.txt"))
+
+	// then
+	assert.NoError(t, err)
+
+	_, err = blob.Stat(internal.QuarantinePath("some-user/first.txt"))
+	assert.NoError(t, err)
+
+	err = _uut.Write("some-user/second.txt", strings.NewReader("0123456789"))
+	assert.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -17,7 +18,19 @@ type Env struct {
 	JwtIssuer    string
 	JwtAudience  string
 
-	UploadDirectory string
+	AuthUrl                      string
+	RevokedTokensRefreshInterval string
+
+	UploadDirectory    string
+	SignedUrlSecretKey string
+	UserStorageQuota   int64
+
+	StorageBackend    string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
 }
 
 func NewEnv() Env {
@@ -28,6 +41,9 @@ func NewEnv() Env {
 		}
 	}
 
+	// the quota is in bytes, and there is no quota when it is missing (or 0)
+	userStorageQuota, _ := strconv.ParseInt(os.Getenv("USER_STORAGE_QUOTA"), 10, 64)
+
 	env := Env{
 		ApplicationHost: os.Getenv("SERVER_HOST"),
 		ApplicationPort: os.Getenv("SERVER_PORT"),
@@ -38,9 +54,29 @@ func NewEnv() Env {
 		JwtIssuer:    os.Getenv("JWT_ISSUER"),
 		JwtAudience:  os.Getenv("JWT_AUDIENCE"),
 
-		UploadDirectory: os.Getenv("UPLOAD_DIRECTORY"),
+		AuthUrl:                      os.Getenv("AUTH_URL"),
+		RevokedTokensRefreshInterval: os.Getenv("REVOKED_TOKENS_REFRESH_INTERVAL"),
+
+		UploadDirectory:    os.Getenv("UPLOAD_DIRECTORY"),
+		SignedUrlSecretKey: os.Getenv("SIGNED_URL_SECRET_KEY"),
+		UserStorageQuota:   userStorageQuota,
+
+		StorageBackend:    os.Getenv("STORAGE_BACKEND"),
+		S3Endpoint:        os.Getenv("S3_ENDPOINT"),
+		S3Region:          os.Getenv("S3_REGION"),
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+	}
+
+	// without it, anyone could sign the URLs of the files
+	if env.SignedUrlSecretKey == "" {
+		log.Fatal("SIGNED_URL_SECRET_KEY is required")
 	}
 	return env
 }
 
 var DevelopmentEnvironment = "development"
+
+var FilesystemStorageBackend = "filesystem"
+var S3StorageBackend = "s3"
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PathPrefixContextKey is the key of the directory that the authorized token is allowed to write into
+const PathPrefixContextKey = "pathPrefix"
+
+// HasPathTraversal checks whether the path tries to get out of its directory, through ".." segments
+func HasPathTraversal(path string) bool {
+	segments := strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+	return slices.Contains(segments, "..")
+}
+
+// IsPathUnderPrefix checks whether the path is the prefix directory itself or anything inside it
+func IsPathUnderPrefix(path string, prefix string) bool {
+	path = strings.TrimPrefix(path, "/")
+	return prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/"))
+}
+
+// QuarantineDirectory is where the quarantined files are moved, outside all the user directories,
+// so that they are neither served through the user tokens nor counted against the quotas
+const QuarantineDirectory = ".quarantine"
+
+// QuarantinePath returns the path that the file is moved to when it is quarantined, which keeps its original path
+func QuarantinePath(path string) string {
+	return QuarantineDirectory + "/" + strings.TrimPrefix(path, "/")
+}
+
+// RootDirectory returns the first segment of the path, which is the directory of the user that owns the file
+func RootDirectory(path string) string {
+	directory, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return directory
+}
+
+// ImageVariantSizes are the sizes (of the longest side, in pixels) of the resized copies generated for each image
+var ImageVariantSizes = []int{64, 256, 1024}
+
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".webp"}
+
+// IsImagePath checks whether the file is supposed to be an image, based on its extension
+func IsImagePath(path string) bool {
+	return slices.Contains(imageExtensions, strings.ToLower(filepath.Ext(path)))
+}
+
+// ImageVariantPath returns the path of the resized copy of the image (e.g. cover.jpg -> cover_256.jpg)
+func ImageVariantPath(path string, size int) string {
+	extension := filepath.Ext(path)
+	return strings.TrimSuffix(path, extension) + "_" + strconv.Itoa(size) + extension
+}
+
+// ImageOriginalPath returns the path of the image that the resized copy belongs to (e.g. cover_256.jpg -> cover.jpg),
+// and false when the path is not one of a variant
+func ImageOriginalPath(path string) (string, bool) {
+	if !IsImagePath(path) {
+		return "", false
+	}
+	extension := filepath.Ext(path)
+	name, size, found := cutLast(strings.TrimSuffix(path, extension), "_")
+	if !found {
+		return "", false
+	}
+	parsedSize, err := strconv.Atoi(size)
+	if err != nil || !slices.Contains(ImageVariantSizes, parsedSize) {
+		return "", false
+	}
+	return name + extension, true
+}
+
+func cutLast(s string, separator string) (string, string, bool) {
+	index := strings.LastIndex(s, separator)
+	if index < 0 {
+		return s, "", false
+	}
+	return s[:index], s[index+len(separator):], true
+}
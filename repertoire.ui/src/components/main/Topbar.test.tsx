@@ -27,7 +27,7 @@ describe('Topbar', () => {
       <AppShell>
         <Topbar toggleSidebar={toggleSidebar} />
       </AppShell>,
-      { auth: { token, historyOnSignIn: { index: 0, justSignedIn: false } } }
+      { auth: { token, refreshToken: null, historyOnSignIn: { index: 0, justSignedIn: false } } }
     )
 
   const handlers = [
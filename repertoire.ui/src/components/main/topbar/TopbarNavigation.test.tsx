@@ -9,7 +9,7 @@ describe('Topbar Navigation', () => {
 
   it('should render', () => {
     const [{ rerender }, store] = reduxRouterRender(<TopbarNavigation />, {
-      auth: { token: '', historyOnSignIn: { index: 0, justSignedIn: false } }
+      auth: { token: '', refreshToken: null, historyOnSignIn: { index: 0, justSignedIn: false } }
     })
 
     const backButton = screen.getByRole('button', { name: 'back' })
@@ -6,10 +6,15 @@ import { userEvent } from '@testing-library/user-event'
 import { screen } from '@testing-library/react'
 import { RootState } from '../../../state/store.ts'
 import TopbarUser from './TopbarUser.tsx'
+import { SignOutRequest } from '../../../types/requests/AuthRequests.ts'
 
 describe('Topbar User', () => {
   const render = () =>
-    reduxRouterRender(<TopbarUser />, { auth: { token: 'some token', historyOnSignIn: undefined } })
+    reduxRouterRender(<TopbarUser />, {
+      auth: { token: 'some token', refreshToken: refreshToken, historyOnSignIn: undefined }
+    })
+
+  const refreshToken = 'some refresh token'
 
   const user: User = {
     ...emptyUser,
@@ -74,12 +79,22 @@ describe('Topbar User', () => {
     it('should sign out when clicking on sign out', async () => {
       const userEventDispatcher = userEvent.setup()
 
+      let capturedRequest: SignOutRequest | undefined
+      server.use(
+        http.put('/sign-out', async (req) => {
+          capturedRequest = (await req.request.json()) as SignOutRequest
+          return HttpResponse.json({ message: 'you have been signed out' })
+        })
+      )
+
       const [_, store] = render()
 
       await userEventDispatcher.click(await screen.findByRole('button', { name: 'user' }))
       await userEventDispatcher.click(screen.getByRole('menuitem', { name: /sign out/i }))
 
+      expect(capturedRequest).toStrictEqual({ refreshToken })
       expect((store.getState() as RootState).auth.token).toBeNull()
+      expect((store.getState() as RootState).auth.refreshToken).toBeNull()
     })
   })
 })
@@ -22,10 +22,11 @@ import SettingsModal from '../modal/SettingsModal.tsx'
 import { useDisclosure } from '@mantine/hooks'
 import { signOut } from '../../../state/slice/authSlice.ts'
 import useAuth from '../../../hooks/useAuth.ts'
-import { useAppDispatch } from '../../../state/store.ts'
+import { useAppDispatch, useAppSelector } from '../../../state/store.ts'
 import { useEffect } from 'react'
 import { setUserId } from '../../../state/slice/globalSlice.ts'
 import { useGetCurrentUserQuery } from '../../../state/api/usersApi.ts'
+import { useSignOutMutation } from '../../../state/authApi.ts'
 
 function TopbarUser({ ...others }: UnstyledButtonProps) {
   const dispatch = useAppDispatch()
@@ -40,7 +41,14 @@ function TopbarUser({ ...others }: UnstyledButtonProps) {
   const [openedAccount, { open: openAccount, close: closeAccount }] = useDisclosure(false)
   const [openedSettings, { open: openSettings, close: closeSettings }] = useDisclosure(false)
 
-  function handleSignOut() {
+  const refreshToken = useAppSelector((state) => state.auth.refreshToken)
+  const [signOutMutation] = useSignOutMutation()
+
+  async function handleSignOut() {
+    // the session is revoked on a best-effort basis, as the user is signed out locally either way
+    if (refreshToken) {
+      await signOutMutation({ refreshToken })
+    }
     dispatch(signOut())
   }
 
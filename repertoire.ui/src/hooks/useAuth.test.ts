@@ -4,7 +4,7 @@ import useAuth from './useAuth.ts'
 describe('use Auth', () => {
   it('should return true when there is a token, so the user is authenticated', () => {
     const [{ result }] = reduxRenderHook(() => useAuth(), {
-      auth: { token: '', historyOnSignIn: undefined }
+      auth: { token: '', refreshToken: null, historyOnSignIn: undefined }
     })
 
     expect(result.current).toBeTruthy()
@@ -12,7 +12,7 @@ describe('use Auth', () => {
 
   it('should return false when there is no token, so the user is not authenticated', () => {
     const [{ result }] = reduxRenderHook(() => useAuth(), {
-      auth: { token: null, historyOnSignIn: undefined }
+      auth: { token: null, refreshToken: null, historyOnSignIn: undefined }
     })
 
     expect(result.current).toBeFalsy()
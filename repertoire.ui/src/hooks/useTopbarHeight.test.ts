@@ -6,6 +6,7 @@ describe('use Topbar Height', () => {
     const [{ result }] = reduxRenderHook(() => useTopbarHeight(), {
       auth: {
         token: 'something',
+        refreshToken: null,
         historyOnSignIn: undefined
       }
     })
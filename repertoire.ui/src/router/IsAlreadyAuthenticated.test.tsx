@@ -15,7 +15,7 @@ describe('Is Already Authenticated', () => {
         <Route path={'/home'} element={<div data-testid={'home'}>Home</div>} />
         <Route path={'*'} element={<Navigate to={'/'} replace />} />
       </Routes>,
-      { auth: { token, historyOnSignIn: undefined } }
+      { auth: { token, refreshToken: null, historyOnSignIn: undefined } }
     )
 
   it('should remain on Outlet if user is not authenticated', () => {
@@ -15,7 +15,7 @@ describe('Require Authentication', () => {
         <Route path={'sign-in'} element={<div data-testid={'sign-in'}>SignIn</div>} />
         <Route path={'*'} element={<Navigate to={'/'} replace />} />
       </Routes>,
-      { auth: { token, historyOnSignIn: undefined } }
+      { auth: { token, refreshToken: null, historyOnSignIn: undefined } }
     )
 
   it('should remain on Outlet if user is authenticated', () => {
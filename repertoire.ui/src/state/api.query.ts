@@ -1,6 +1,6 @@
 import { Mutex } from 'async-mutex'
 import { RootState } from './store.ts'
-import { setToken, signOut } from './slice/authSlice.ts'
+import { setTokens, signOut } from './slice/authSlice.ts'
 import { setErrorPath } from './slice/globalSlice.ts'
 import {
   BaseQueryApi,
@@ -11,6 +11,7 @@ import {
 } from '@reduxjs/toolkit/query'
 import { toast } from 'react-toastify'
 import HttpErrorResponse from '../types/responses/HttpErrorResponse.ts'
+import { TokensResponse } from '../types/responses/AuthResponses.ts'
 
 type Arguments = string | FetchArgs
 type ApiQuery = BaseQueryFn<Arguments, unknown, FetchBaseQueryError>
@@ -49,14 +50,14 @@ export const queryWithRefresh: (
             {
               url: `refresh`,
               method: 'PUT',
-              body: { token: authState.token }
+              body: { refreshToken: authState.refreshToken }
             },
             api,
             extraOptions
           )
-          const newToken = refreshResult?.data as string | undefined
-          if (newToken) {
-            api.dispatch(setToken(newToken))
+          const newTokens = refreshResult?.data as TokensResponse | undefined
+          if (newTokens) {
+            api.dispatch(setTokens(newTokens))
             result = await queryWithAuthorization(baseUrl)(args, api, extraOptions)
           } else {
             api.dispatch(signOut())
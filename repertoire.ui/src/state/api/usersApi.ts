@@ -6,6 +6,7 @@ import {
   UpdateUserRequest
 } from '../../types/requests/UserRequests.ts'
 import HttpMessageResponse from '../../types/responses/HttpMessageResponse.ts'
+import { TokensResponse } from '../../types/responses/AuthResponses.ts'
 import createFormData from '../../utils/createFormData.ts'
 
 const usersApi = api.injectEndpoints({
@@ -14,7 +15,7 @@ const usersApi = api.injectEndpoints({
       query: () => `users/current`,
       providesTags: ['User']
     }),
-    signUp: build.mutation<string, SignUpRequest>({
+    signUp: build.mutation<TokensResponse, SignUpRequest>({
       query: (body) => ({
         url: `users/sign-up`,
         method: 'POST',
@@ -1,5 +1,7 @@
 import { createApi } from '@reduxjs/toolkit/query/react'
-import { SignInRequest } from '../types/requests/AuthRequests.ts'
+import { SignInRequest, SignOutRequest } from '../types/requests/AuthRequests.ts'
+import { TokensResponse } from '../types/responses/AuthResponses.ts'
+import HttpMessageResponse from '../types/responses/HttpMessageResponse.ts'
 import { queryWithRedirection, queryWithRefresh } from './api.query.ts'
 
 const query = queryWithRedirection(
@@ -16,13 +18,20 @@ export const authApi = createApi({
   reducerPath: 'authApi',
   endpoints: (build) => {
     return {
-      signIn: build.mutation<string, SignInRequest>({
+      signIn: build.mutation<TokensResponse, SignInRequest>({
         query: (body) => ({
           url: `sign-in`,
           method: 'PUT',
           body: body
         })
       }),
+      signOut: build.mutation<HttpMessageResponse, SignOutRequest>({
+        query: (body) => ({
+          url: `sign-out`,
+          method: 'PUT',
+          body: body
+        })
+      }),
       getCentrifugeToken: build.query<string, void>({
         query: () => 'centrifugo/token'
       })
@@ -30,4 +39,4 @@ export const authApi = createApi({
   }
 })
 
-export const { useSignInMutation, useLazyGetCentrifugeTokenQuery } = authApi
+export const { useSignInMutation, useSignOutMutation, useLazyGetCentrifugeTokenQuery } = authApi
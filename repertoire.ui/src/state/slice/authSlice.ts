@@ -1,12 +1,15 @@
 import { createSlice, PayloadAction } from '@reduxjs/toolkit'
+import { TokensResponse } from '../../types/responses/AuthResponses.ts'
 
 interface AuthState {
   token: string | null
+  refreshToken: string | null
   historyOnSignIn: { index: number; justSignedIn: boolean }
 }
 
 const initialState: AuthState = {
   token: localStorage.getItem('access_token'),
+  refreshToken: localStorage.getItem('refresh_token'),
   historyOnSignIn: { index: 0, justSignedIn: false }
 }
 
@@ -14,18 +17,24 @@ export const authSlice = createSlice({
   name: 'auth',
   initialState,
   reducers: {
-    setToken: (state, action: PayloadAction<string>) => {
-      state.token = action.payload
+    setTokens: (state, action: PayloadAction<TokensResponse>) => {
+      state.token = action.payload.token
+      state.refreshToken = action.payload.refreshToken
       localStorage.setItem('access_token', state.token)
+      localStorage.setItem('refresh_token', state.refreshToken)
     },
-    signIn: (state, action: PayloadAction<string>) => {
-      state.token = action.payload
+    signIn: (state, action: PayloadAction<TokensResponse>) => {
+      state.token = action.payload.token
+      state.refreshToken = action.payload.refreshToken
       localStorage.setItem('access_token', state.token)
+      localStorage.setItem('refresh_token', state.refreshToken)
       state.historyOnSignIn = { index: (history.state?.idx ?? 0) + 1, justSignedIn: true }
     },
     signOut: (state) => {
       state.token = null
+      state.refreshToken = null
       localStorage.removeItem('access_token')
+      localStorage.removeItem('refresh_token')
     },
     resetHistoryOnSignIn: (state) => {
       state.historyOnSignIn = { ...state.historyOnSignIn, justSignedIn: false }
@@ -33,6 +42,6 @@ export const authSlice = createSlice({
   }
 })
 
-export const { setToken, signIn, signOut, resetHistoryOnSignIn } = authSlice.actions
+export const { setTokens, signIn, signOut, resetHistoryOnSignIn } = authSlice.actions
 
 export default authSlice.reducer
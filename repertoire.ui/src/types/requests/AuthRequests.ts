@@ -2,3 +2,7 @@ export interface SignInRequest {
   email: string
   password: string
 }
+
+export interface SignOutRequest {
+  refreshToken: string
+}
@@ -0,0 +1,4 @@
+export interface TokensResponse {
+  token: string
+  refreshToken: string
+}
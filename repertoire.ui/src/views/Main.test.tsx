@@ -51,7 +51,7 @@ describe('Main', () => {
           <Route path={'/'} element={<div data-testid={'outlet'}>Outlet</div>} />
         </Route>
       </Routes>,
-      { auth: { token, historyOnSignIn: { index: 0, justSignedIn: false } } }
+      { auth: { token, refreshToken: null, historyOnSignIn: { index: 0, justSignedIn: false } } }
     )
 
   it('should render and display just the outlet when user is not authenticated', () => {
@@ -7,6 +7,7 @@ import { setupServer } from 'msw/node'
 import { SignInRequest } from '../types/requests/AuthRequests.ts'
 import { expect } from 'vitest'
 import { RootState } from '../state/store.ts'
+import { TokensResponse } from '../types/responses/AuthResponses.ts'
 
 describe('Sign In', () => {
   const server = setupServer()
@@ -82,25 +83,26 @@ describe('Sign In', () => {
     expect(screen.getAllByText(error)).toHaveLength(2)
   })
 
-  it('should send sign in request and save token', async () => {
+  it('should send sign in request and save tokens', async () => {
     const email = 'someone@else.com'
     const password = 'ThisIsAGoodPassword123'
 
     let capturedSignInRequest: SignInRequest | undefined
 
-    const expectedToken = 'token'
+    const expectedTokens: TokensResponse = { token: 'token', refreshToken: 'refresh token' }
 
     server.use(
       http.put('/sign-in', async (req) => {
         capturedSignInRequest = (await req.request.json()) as SignInRequest
-        return HttpResponse.json(expectedToken)
+        return HttpResponse.json(expectedTokens)
       })
     )
 
     const store = await sendSignInRequest(email, password)
 
     expect(capturedSignInRequest).toStrictEqual({ email, password })
-    expect((store.getState() as RootState).auth.token).toBe(expectedToken)
+    expect((store.getState() as RootState).auth.token).toBe(expectedTokens.token)
+    expect((store.getState() as RootState).auth.refreshToken).toBe(expectedTokens.refreshToken)
     expect(window.location.pathname).toBe('/home')
   })
 
@@ -46,8 +46,8 @@ function SignIn(): ReactElement {
 
   async function handleSignIn({ email, password }: SignInForm): Promise<void> {
     try {
-      const token = await signInMutation({ email, password }).unwrap()
-      dispatch(signIn(token))
+      const tokens = await signInMutation({ email, password }).unwrap()
+      dispatch(signIn(tokens))
       dispatch(api.util.resetApiState())
       dispatch(authApi.util.resetApiState())
       navigate(location.state?.from?.pathname ?? 'home')
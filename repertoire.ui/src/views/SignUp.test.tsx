@@ -7,6 +7,7 @@ import { http, HttpResponse } from 'msw'
 import { RootState } from '../state/store.ts'
 import { setupServer } from 'msw/node'
 import { SignUpRequest } from '../types/requests/UserRequests.ts'
+import { TokensResponse } from '../types/responses/AuthResponses.ts'
 
 describe('Sign Up', () => {
   const server = setupServer()
@@ -122,26 +123,27 @@ describe('Sign Up', () => {
     expect(screen.getAllByText(error)).toHaveLength(3)
   })
 
-  it('should send sign up request and save token', async () => {
+  it('should send sign up request and save tokens', async () => {
     const name = 'Someone Else'
     const email = 'someone@else.com'
     const password = 'ThisIsAGoodPassword123'
 
     let capturedSignUpRequest: SignUpRequest | undefined
 
-    const expectedToken = 'token'
+    const expectedTokens: TokensResponse = { token: 'token', refreshToken: 'refresh token' }
 
     server.use(
       http.post('/users/sign-up', async (req) => {
         capturedSignUpRequest = (await req.request.json()) as SignUpRequest
-        return HttpResponse.json(expectedToken)
+        return HttpResponse.json(expectedTokens)
       })
     )
 
     const store = await sendSignUpRequest(name, email, password)
 
     expect(capturedSignUpRequest).toStrictEqual({ name, email, password })
-    expect((store.getState() as RootState).auth.token).toBe(expectedToken)
+    expect((store.getState() as RootState).auth.token).toBe(expectedTokens.token)
+    expect((store.getState() as RootState).auth.refreshToken).toBe(expectedTokens.refreshToken)
     expect(window.location.pathname).toBe('/home')
   })
 
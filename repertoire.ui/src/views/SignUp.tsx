@@ -48,8 +48,8 @@ function SignUp(): ReactElement {
 
   async function signUp({ name, email, password }): Promise<void> {
     try {
-      const token = await signUpMutation({ name, email, password }).unwrap()
-      dispatch(signIn(token))
+      const tokens = await signUpMutation({ name, email, password }).unwrap()
+      dispatch(signIn(tokens))
       dispatch(api.util.resetApiState())
       dispatch(authApi.util.resetApiState())
       navigate(location.state?.from?.pathname ?? 'home')